@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/config"
+)
+
+// googleTokenInfoURL is requested by /readyz, when enabled, to check that
+// Google's OAuth endpoint is reachable. No token is sent.
+const googleTokenInfoURL = "https://oauth2.googleapis.com/tokeninfo"
+
+// readyzTimeout bounds all readiness checks of one probe together.
+const readyzTimeout = 5 * time.Second
+
+// readinessCheck is one named /readyz check.
+type readinessCheck struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// readinessChecks returns the checks /readyz runs: the token store and the
+// OAuth client config, and Google reachability when enabled. Sandbox mode
+// never talks to Google, so only the token store is checked.
+func readinessChecks(cfg *config.Config, oauthMgr *auth.OAuthManager) []readinessCheck {
+	checks := []readinessCheck{{"token_store", func(ctx context.Context) error {
+		return auth.CheckTokenStore(ctx, oauthMgr.TokenStore())
+	}}}
+	if cfg.Sandbox {
+		return checks
+	}
+	checks = append(checks, readinessCheck{"oauth_config", func(context.Context) error {
+		return oauthMgr.CheckConfig()
+	}})
+	if cfg.Server.ReadyzCheckGoogle {
+		checks = append(checks, readinessCheck{"google", func(ctx context.Context) error {
+			return pingURL(ctx, http.DefaultClient, googleTokenInfoURL)
+		}})
+	}
+	return checks
+}
+
+// healthzHandler reports that the process is up and serving HTTP.
+func healthzHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "ok")
+}
+
+// readyzHandler runs every check and answers 200 when all pass, or 503.
+// The response names each check as ok or failed; error details only go to
+// the log, since the endpoint is unauthenticated.
+func readyzHandler(checks []readinessCheck) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readyzTimeout)
+		defer cancel()
+
+		status, results := http.StatusOK, make(map[string]string, len(checks))
+		for _, c := range checks {
+			results[c.name] = "ok"
+			if err := c.run(ctx); err != nil {
+				slog.WarnContext(ctx, "readiness check failed", "check", c.name, "error", err)
+				results[c.name], status = "failed", http.StatusServiceUnavailable
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]any{
+			"ready":  status == http.StatusOK,
+			"checks": results,
+		})
+	}
+}
+
+// pingURL reports whether url answers at all. Client errors count as
+// reachable, since the request carries no credentials; server errors do not.
+func pingURL(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("reaching %s: %w", url, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("%s answered %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadyzHandler(t *testing.T) {
+	pass := readinessCheck{"store", func(context.Context) error { return nil }}
+	fail := readinessCheck{"google", func(context.Context) error { return errors.New("secret detail") }}
+
+	tests := []struct {
+		name       string
+		checks     []readinessCheck
+		wantStatus int
+		wantChecks map[string]string
+	}{
+		{"all pass", []readinessCheck{pass}, http.StatusOK, map[string]string{"store": "ok"}},
+		{"one fails", []readinessCheck{pass, fail}, http.StatusServiceUnavailable,
+			map[string]string{"store": "ok", "google": "failed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			readyzHandler(tt.checks)(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var body struct {
+				Ready  bool              `json:"ready"`
+				Checks map[string]string `json:"checks"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Ready != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("ready = %v", body.Ready)
+			}
+			for name, want := range tt.wantChecks {
+				if body.Checks[name] != want {
+					t.Errorf("checks[%s] = %q, want %q", name, body.Checks[name], want)
+				}
+			}
+		})
+	}
+}
+
+func TestPingURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"client error is reachable", http.StatusBadRequest, false},
+		{"server error", http.StatusBadGateway, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+			if err := pingURL(context.Background(), srv.Client(), srv.URL); (err != nil) != tt.wantErr {
+				t.Errorf("pingURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
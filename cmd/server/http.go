@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/config"
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+	"github.com/evert/google-workspace-mcp-go/internal/session"
+	"github.com/evert/google-workspace-mcp-go/internal/tools/gmail"
+)
+
+// serveHTTP serves the MCP server over HTTP until ctx is cancelled: the
+// streamable HTTP transport at /mcp, or the legacy SSE transport at /sse,
+// next to the OAuth callback. The unix transport serves streamable HTTP on
+// a Unix domain socket instead of a TCP port. TCP listeners serve HTTPS when
+// a certificate is configured. A non-nil resumer persists streamable HTTP
+// sessions; a non-nil mailbox receives Gmail push notifications.
+func serveHTTP(ctx context.Context, cfg *config.Config, server *mcp.Server, resumer *session.Resumer, oauthMgr *auth.OAuthManager, factory *services.Factory, mailbox *gmail.MailboxWatcher) error {
+	mux, err := newMux(ctx, cfg, server, resumer, oauthMgr, factory, mailbox)
+	if err != nil {
+		return err
+	}
+
+	var tlsCfg *tls.Config
+	if cfg.TLSEnabled() {
+		if tlsCfg, err = serverTLSConfig(cfg); err != nil {
+			return err
+		}
+		slog.Info("TLS enabled", "cert", cfg.Server.TLSCert, "clientCA", cfg.Server.ClientCA)
+	}
+
+	ln, err := listen(cfg)
+	if err != nil {
+		return err
+	}
+	httpServer := &http.Server{
+		Handler:           mux,
+		TLSConfig:         tlsCfg,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+	if cfg.Server.Transport == "sse" {
+		// An SSE session is one long-lived response; a write timeout
+		// would cut every session off after a minute.
+		httpServer.WriteTimeout = 0
+	}
+
+	// Graceful shutdown
+	go func() {
+		<-ctx.Done()
+		slog.Info("shutting down HTTP server")
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer shutdownCancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			slog.Error("HTTP server shutdown error", "error", err)
+		}
+	}()
+
+	slog.Info("listening", "addr", ln.Addr().String())
+	serve := httpServer.Serve
+	if tlsCfg != nil {
+		// The certificate comes from TLSConfig.GetCertificate.
+		serve = func(ln net.Listener) error { return httpServer.ServeTLS(ln, "", "") }
+	}
+	if err := serve(ln); err != http.ErrServerClosed {
+		return fmt.Errorf("HTTP server error: %w", err)
+	}
+	return nil
+}
+
+// listen opens the listener for the configured HTTP transport.
+func listen(cfg *config.Config) (net.Listener, error) {
+	if cfg.Server.Transport == "unix" {
+		return listenUnix(cfg.Server.SocketPath, cfg.Server.SocketPerm)
+	}
+	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listening on %s: %w", addr, err)
+	}
+	return ln, nil
+}
+
+// newMux routes the MCP endpoint for the configured transport, behind bearer
+// authentication when it is enabled, and /oauth/callback, the Gmail push
+// endpoint, and the /healthz and /readyz probes separately. The push
+// endpoint checks its own token, since Pub/Sub cannot send bearer keys.
+func newMux(ctx context.Context, cfg *config.Config, server *mcp.Server, resumer *session.Resumer, oauthMgr *auth.OAuthManager, factory *services.Factory, mailbox *gmail.MailboxWatcher) (*http.ServeMux, error) {
+	getServer := func(r *http.Request) *mcp.Server { return server }
+	path, mcpHandler := "/mcp", http.Handler(mcp.NewStreamableHTTPHandler(getServer, nil))
+	if resumer != nil {
+		mcpHandler = resumer.Wrap(mcpHandler)
+	}
+	if cfg.Server.Transport == "sse" {
+		// SSE clients open a stream with GET and post messages to the same
+		// path with the session ID the stream announces.
+		path, mcpHandler = "/sse", mcp.NewSSEHandler(getServer, nil)
+	}
+
+	mux := http.NewServeMux()
+	if cfg.HTTPAuthEnabled() {
+		validator, err := bearerValidator(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("initializing HTTP bearer auth: %w", err)
+		}
+		mux.Handle(path, middleware.BearerAuth(validator, mcpHandler))
+		slog.Info("bearer authentication enabled for "+path,
+			"apiKeys", len(cfg.HTTPAuth.APIKeys),
+			"oidcIssuer", cfg.HTTPAuth.OIDCIssuer,
+		)
+	} else {
+		mux.Handle(path, mcpHandler)
+		slog.Warn(path + " is unauthenticated — set MCP_API_KEYS or MCP_OIDC_ISSUER before exposing beyond localhost")
+	}
+	mux.HandleFunc("/oauth/callback", auth.OAuthCallbackHandler(oauthMgr, factory))
+	if mailbox.Enabled() {
+		mux.Handle("POST "+gmail.PushPath, mailbox)
+	}
+	mux.HandleFunc("GET /healthz", healthzHandler)
+	mux.Handle("GET /readyz", readyzHandler(readinessChecks(cfg, oauthMgr)))
+	return mux, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/config"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+func TestNewMuxSSE(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Server.Transport = "sse"
+	cfg.HTTPAuth.APIKeys = []string{"secret-key"}
+
+	oauthMgr := auth.NewOAuthManager("id", "secret", "http://localhost/oauth/callback", nil, auth.NewInMemoryTokenStore())
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+	mux, err := newMux(context.Background(), cfg, server, nil, oauthMgr, services.NewFactory(oauthMgr), nil)
+	if err != nil {
+		t.Fatalf("newMux: %v", err)
+	}
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		header     string
+		wantStatus int
+	}{
+		{"stream with key", "/sse", "Bearer secret-key", http.StatusOK},
+		{"stream without key", "/sse", "", http.StatusUnauthorized},
+		{"stream with wrong key", "/sse", "Bearer nope", http.StatusUnauthorized},
+		{"no /mcp under sse", "/mcp", "Bearer secret-key", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The SSE stream stays open, so cancel it once the headers arrive.
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+				t.Errorf("content type: got %q, want text/event-stream", resp.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
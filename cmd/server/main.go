@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -16,10 +15,21 @@ import (
 	"github.com/evert/google-workspace-mcp-go/internal/auth"
 	"github.com/evert/google-workspace-mcp-go/internal/config"
 	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/audit"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/redact"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/tracing"
 	"github.com/evert/google-workspace-mcp-go/internal/registry"
+	"github.com/evert/google-workspace-mcp-go/internal/sandbox"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
+	"github.com/evert/google-workspace-mcp-go/internal/session"
+	"github.com/evert/google-workspace-mcp-go/internal/tools/gmail"
+	"github.com/evert/google-workspace-mcp-go/internal/tools/output"
 )
 
+// serverVersion is reported to MCP clients and as the trace service.version.
+const serverVersion = "1.0.0"
+
 func main() {
 	// Structured logging to stderr (stdout is reserved for MCP stdio transport)
 	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
@@ -44,32 +54,77 @@ func run(ctx context.Context, logger *slog.Logger) error {
 		return fmt.Errorf("loading config: %w", err)
 	}
 
-	// Set log level from config
-	switch cfg.LogLevel {
-	case "debug":
-		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
-	case "warn":
-		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
-	case "error":
-		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+	// Set log level and PII redaction from config
+	logger = newLogger(cfg.LogLevel, cfg.LogRedactPII)
+	slog.SetDefault(logger)
+
+	// Export OpenTelemetry spans when a collector is configured
+	if cfg.Tracing.Enabled {
+		shutdown, err := tracing.Setup(ctx, cfg.Tracing.Endpoint, serverVersion)
+		if err != nil {
+			return fmt.Errorf("initializing tracing: %w", err)
+		}
+		defer func() {
+			flushCtx, flushCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer flushCancel()
+			if err := shutdown(flushCtx); err != nil {
+				slog.Warn("flushing trace spans failed", "error", err)
+			}
+		}()
+		slog.Info("OpenTelemetry tracing enabled", "endpoint", cfg.Tracing.Endpoint)
 	}
 
 	// Initialize token store
 	var tokenStore auth.TokenStore
-	if cfg.PersistentAuth {
+	switch cfg.TokenStore {
+	case "file":
 		fileStore, err := auth.NewFileTokenStore(cfg.CredentialsDir)
 		if err != nil {
 			return fmt.Errorf("initializing file token store: %w", err)
 		}
 		tokenStore = fileStore
 		slog.Info("using persistent file-based token store", "dir", cfg.CredentialsDir)
-	} else {
+	case "keyring":
+		keyringStore, err := auth.NewKeyringTokenStore(auth.DefaultKeyringService)
+		if err != nil {
+			return fmt.Errorf("initializing keyring token store: %w", err)
+		}
+		tokenStore = keyringStore
+		slog.Info("using OS keyring token store", "service", auth.DefaultKeyringService)
+	case "vault":
+		vaultStore, err := auth.NewVaultTokenStore(auth.VaultConfig{
+			Address:   cfg.Vault.Address,
+			Token:     cfg.Vault.Token,
+			Namespace: cfg.Vault.Namespace,
+			Mount:     cfg.Vault.Mount,
+			Path:      cfg.Vault.Path,
+		})
+		if err != nil {
+			return fmt.Errorf("initializing vault token store: %w", err)
+		}
+		go vaultStore.StartRenewal(ctx)
+		tokenStore = vaultStore
+		slog.Info("using HashiCorp Vault token store",
+			"addr", cfg.Vault.Address,
+			"mount", cfg.Vault.Mount,
+			"path", cfg.Vault.Path,
+		)
+	default:
 		tokenStore = auth.NewInMemoryTokenStore()
 		slog.Info("using in-memory token store (tokens will not survive restart)")
 	}
 
 	// Determine scopes
 	scopes := auth.AllScopes(cfg.EnabledServices, cfg.ReadOnly)
+	if cfg.AdminEnabled() {
+		scopes = append(scopes, auth.AdminScopes(cfg.ReadOnly)...)
+	}
+	if cfg.CloudSearchEnabled() {
+		scopes = append(scopes, auth.CloudSearchScope)
+	}
+	if cfg.GmailPermanentDeleteEnabled() {
+		scopes = append(scopes, auth.GmailFullScope)
+	}
 
 	// Create OAuth manager
 	oauthMgr := auth.NewOAuthManager(
@@ -82,14 +137,46 @@ func run(ctx context.Context, logger *slog.Logger) error {
 
 	// Create service factory
 	factory := services.NewFactory(oauthMgr)
+	factory.SetRetryPolicies(retryPolicies(cfg))
+	factory.SetProvenance(cfg.StampProvenance)
+	if cfg.GmailDelegationKey != "" {
+		key, err := os.ReadFile(cfg.GmailDelegationKey)
+		if err != nil {
+			return fmt.Errorf("reading WORKSPACE_MCP_GMAIL_DELEGATION_KEY: %w", err)
+		}
+		if err := factory.SetDelegation(key); err != nil {
+			return fmt.Errorf("WORKSPACE_MCP_GMAIL_DELEGATION_KEY: %w", err)
+		}
+		slog.Warn("Gmail delegate tools act through a domain-wide delegation service account and can change any mailbox in the domain",
+			"key", cfg.GmailDelegationKey)
+	}
+	if cfg.Sandbox {
+		factory.SetSandbox(sandbox.NewTransport())
+		slog.Warn("sandbox mode — tools serve synthetic demo data and never call Google; writes are echoed but not stored",
+			"demoUser", sandbox.DemoUser)
+	}
 
-	// Load tier config — try absolute path (container) then relative (local dev)
+	// Revoke idle credentials when a TTL is configured
+	if cfg.TokenTTL > 0 {
+		sweeper, err := auth.NewTokenSweeper(oauthMgr, cfg.TokenTTL, cfg.TokenSweepInterval, factory.InvalidateClient)
+		if err != nil {
+			return fmt.Errorf("initializing token TTL sweeper: %w", err)
+		}
+		go sweeper.Run(ctx)
+		slog.Info("token TTL sweeper enabled", "ttl", cfg.TokenTTL, "interval", cfg.TokenSweepInterval)
+	}
+
+	// Load tier config — the config file's tool_tiers section wins; otherwise
+	// try absolute path (container) then relative (local dev)
 	tierConfigPath := "/configs/tool_tiers.yaml"
 	if _, statErr := os.Stat(tierConfigPath); statErr != nil {
 		tierConfigPath = filepath.Join("configs", "tool_tiers.yaml")
 	}
-	tierMap, err := config.LoadTiers(tierConfigPath)
-	if err != nil {
+	var tierMap map[string]config.ToolInfo
+	if len(cfg.ToolTiers) > 0 {
+		tierMap = config.TierMap(cfg.ToolTiers)
+		tierConfigPath = ""
+	} else if tierMap, err = config.LoadTiers(tierConfigPath); err != nil {
 		slog.Warn("could not load tier config — all tools will be registered unfiltered",
 			"path", tierConfigPath,
 			"error", err,
@@ -97,26 +184,148 @@ func run(ctx context.Context, logger *slog.Logger) error {
 		tierMap = make(map[string]config.ToolInfo)
 	}
 
+	// Resource reads carry no user_google_email; res tracks each session's
+	// account and routes resource subscriptions to their service.
+	res := middleware.NewResources(tokenStore, cfg.AllowedUsers)
+	serverOpts := &mcp.ServerOptions{
+		SubscribeHandler:   res.Subscribe,
+		UnsubscribeHandler: res.Unsubscribe,
+	}
+
+	// Persist streamable HTTP sessions when a session store is configured.
+	// The resumer assigns session IDs so restored sessions keep theirs.
+	var resumer *session.Resumer
+	if cfg.Server.SessionStore != "" {
+		if resumer, err = session.NewResumer(cfg.Server.SessionStore, cfg.Server.SessionTTL); err != nil {
+			return fmt.Errorf("initializing session store: %w", err)
+		}
+		serverOpts.GetSessionID = resumer.SessionID
+		slog.Info("MCP sessions persist across restarts", "store", cfg.Server.SessionStore, "ttl", cfg.Server.SessionTTL)
+	}
+
 	// Create MCP server
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    "google-workspace-mcp",
-		Version: "1.0.0",
-	}, nil)
+		Version: serverVersion,
+	}, serverOpts)
+
+	// Turn handler panics into error results so one bad tool cannot take
+	// down the server. Added first so it sits directly above the handlers
+	// and every other middleware sees the error result.
+	server.AddReceivingMiddleware(middleware.RecoveryMiddleware())
+
+	// Attach machine-readable detail to Google API errors. Added next so it
+	// sees the error text before the auth enhancer appends to it.
+	server.AddReceivingMiddleware(middleware.ErrorDetailsMiddleware(oauthMgr, registry.ToolService(tierMap), cfg.ReadOnly))
+
+	// Apply annotation hints from the tier config to tools/list. Added next
+	// so the cache, confirmation, read-only filter, and audit log all see
+	// the configured hints.
+	tierFilter := registry.NewTierFilter(cfg, tierMap)
+	server.AddReceivingMiddleware(registry.AnnotationMiddleware(tierFilter))
+
+	// Serve repeated read-only tool calls from memory. Added next so it sits
+	// inside everything but recovery and annotations: tier filtering, the account allowlist,
+	// and rate limits still apply to cache hits.
+	if cfg.Cache.TTL > 0 {
+		cache := middleware.NewResponseCache(cfg.Cache.TTL, cfg.Cache.MaxEntries)
+		server.AddReceivingMiddleware(middleware.CacheMiddleware(cache, cfg.Cache.Tools, registry.ToolService(tierMap)))
+		slog.Info("response cache enabled", "ttl", cfg.Cache.TTL, "max_entries", cfg.Cache.MaxEntries, "tools", cfg.Cache.Tools)
+	}
+
+	// Ask the user before destructive tools run. Added inside logging and
+	// the account allowlist so only permitted calls prompt, and the wait for
+	// an answer is part of the logged request.
+	if cfg.RequireConfirmation {
+		server.AddReceivingMiddleware(middleware.ConfirmationMiddleware())
+		slog.Info("confirmation required for destructive tools")
+	}
+
+	// Bound the tool calls each session runs at once. Added inside logging
+	// so rejected calls are logged, and outside the cache and confirmation
+	// so a call waiting for the user's answer keeps its slot.
+	if cfg.Concurrency.MaxPerSession > 0 {
+		limiter := middleware.NewSessionLimiter(cfg.Concurrency.MaxPerSession, cfg.Concurrency.Wait)
+		server.AddReceivingMiddleware(middleware.ConcurrencyLimitMiddleware(limiter))
+		slog.Info("per-session tool call concurrency limit enabled", "max", cfg.Concurrency.MaxPerSession, "wait", cfg.Concurrency.Wait)
+	}
+
+	// Present results in the requested format. Added outside the cache so
+	// cached results are formatted per call.
+	responseFormat, err := response.ParseFormat(cfg.ResponseFormat)
+	if err != nil {
+		return err
+	}
+	server.AddReceivingMiddleware(middleware.ResponseFormatMiddleware(responseFormat))
+
+	// Remember each session's account for resource requests. Added inside
+	// the account allowlist so only permitted accounts are recorded.
+	server.AddReceivingMiddleware(res.Middleware())
 
 	// Wire SDK middleware
 	server.AddReceivingMiddleware(
-		middleware.LoggingMiddleware(logger),
-		middleware.AuthEnhancerMiddleware(oauthMgr),
+		middleware.LoggingMiddleware(logger, cfg.LogRedactPII),
+		middleware.AuthEnhancerMiddleware(oauthMgr, cfg.AllowedUsers),
 	)
 
+	// Gmail push notifications arrive through Pub/Sub on the HTTP server
+	var mailbox *gmail.MailboxWatcher
+	if cfg.GmailPushEnabled() {
+		mailbox = gmail.NewMailboxWatcher(factory, cfg.GmailPush.Topic, cfg.GmailPush.Token)
+		slog.Info("Gmail push notifications enabled", "topic", cfg.GmailPush.Topic, "path", gmail.PushPath)
+	}
+
 	// Register all tools through the registry
-	registry.RegisterAll(server, factory, cfg, tierMap, oauthMgr)
+	registry.RegisterAll(server, factory, cfg, tierMap, tierFilter, res, mailbox, oauthMgr)
+
+	// Record every write tool call. Added after the tier filter so denied
+	// attempts are recorded too.
+	auditSink, err := newAuditSink(cfg)
+	if err != nil {
+		return fmt.Errorf("configuring audit log: %w", err)
+	}
+	if auditSink != nil {
+		defer auditSink.Close()
+		server.AddReceivingMiddleware(middleware.AuditMiddleware(auditSink, registry.ToolService(tierMap)))
+		slog.Info("audit logging enabled", "file", cfg.Audit.File, "webhook", cfg.Audit.WebhookURL != "")
+	}
+
+	// Mask PII classes in all output. Added last so it wraps every other
+	// middleware and sees the final tool result.
+	redactor, err := redact.New(cfg.Redaction.Profiles, cfg.Redaction.Patterns)
+	if err != nil {
+		return fmt.Errorf("configuring output redaction: %w", err)
+	}
+	if redactor != nil {
+		server.AddReceivingMiddleware(middleware.RedactionMiddleware(redactor))
+		server.AddSendingMiddleware(middleware.RedactionMiddleware(redactor))
+		slog.Info("output redaction enabled", "profiles", cfg.Redaction.Profiles, "patterns", len(cfg.Redaction.Patterns))
+	}
+
+	// Cut long tool output to the budget. Added after redaction so the
+	// complete text is redacted before it is split into parts.
+	if cfg.MaxOutputKB > 0 {
+		overflow := response.NewOverflow(cfg.MaxOutputKB * 1024)
+		server.AddReceivingMiddleware(middleware.OutputBudgetMiddleware(overflow))
+		output.Register(server, overflow)
+		slog.Info("tool output budget enabled", "max_kb", cfg.MaxOutputKB)
+	}
+
+	// Apply edits to the tier config without a restart. Tiers set in the
+	// server config file are fixed until restart.
+	if tierConfigPath != "" {
+		go func() {
+			if err := registry.WatchTiers(ctx, server, tierConfigPath, tierFilter); err != nil {
+				slog.Warn("tier config hot reload disabled", "path", tierConfigPath, "error", err)
+			}
+		}()
+	}
 
 	slog.Info("starting Google Workspace MCP server",
 		"transport", cfg.Server.Transport,
 		"tier", cfg.ToolTier,
 		"readOnly", cfg.ReadOnly,
-		"persistentAuth", cfg.PersistentAuth,
+		"tokenStore", cfg.TokenStore,
 	)
 
 	// Start server on selected transport
@@ -126,46 +335,87 @@ func run(ctx context.Context, logger *slog.Logger) error {
 			return fmt.Errorf("stdio server error: %w", err)
 		}
 
-	case "streamable-http":
-		mcpHandler := mcp.NewStreamableHTTPHandler(
-			func(r *http.Request) *mcp.Server { return server },
-			nil,
-		)
-
-		// Use a mux to route /oauth/callback separately from MCP
-		mux := http.NewServeMux()
-		mux.Handle("/mcp", mcpHandler)
-		mux.HandleFunc("/oauth/callback", auth.OAuthCallbackHandler(oauthMgr, factory))
-
-		addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
-		httpServer := &http.Server{
-			Addr:              addr,
-			Handler:           mux,
-			ReadTimeout:       30 * time.Second,
-			WriteTimeout:      60 * time.Second,
-			ReadHeaderTimeout: 10 * time.Second,
-			IdleTimeout:       120 * time.Second,
+	case "streamable-http", "sse", "unix":
+		if err := serveHTTP(ctx, cfg, server, resumer, oauthMgr, factory, mailbox); err != nil {
+			return err
 		}
 
-		// Graceful shutdown
-		go func() {
-			<-ctx.Done()
-			slog.Info("shutting down HTTP server")
-			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer shutdownCancel()
-			if err := httpServer.Shutdown(shutdownCtx); err != nil {
-				slog.Error("HTTP server shutdown error", "error", err)
-			}
-		}()
+	default:
+		return fmt.Errorf("unknown transport %q — use 'stdio', 'streamable-http', 'sse', or 'unix'", cfg.Server.Transport)
+	}
 
-		slog.Info("listening", "addr", addr)
-		if err := httpServer.ListenAndServe(); err != http.ErrServerClosed {
-			return fmt.Errorf("HTTP server error: %w", err)
+	return nil
+}
+
+// retryPolicies converts the retry settings in cfg into the factory's global
+// and per-service retry policies.
+func retryPolicies(cfg *config.Config) (services.RetryPolicy, map[string]services.RetryPolicy) {
+	def := services.DefaultRetryPolicy
+	def.MaxRetries = cfg.Retry.MaxRetries
+	def.MaxWait = cfg.Retry.MaxWait
+
+	perService := make(map[string]services.RetryPolicy)
+	for service, limits := range cfg.ServiceLimits {
+		if limits.MaxRetries != nil {
+			policy := def
+			policy.MaxRetries = *limits.MaxRetries
+			perService[service] = policy
 		}
+	}
+	return def, perService
+}
 
-	default:
-		return fmt.Errorf("unknown transport %q — use 'stdio' or 'streamable-http'", cfg.Server.Transport)
+// newAuditSink opens the audit sinks configured in cfg. It returns nil when
+// auditing is disabled.
+func newAuditSink(cfg *config.Config) (audit.Sink, error) {
+	var sinks audit.MultiSink
+	if cfg.Audit.File != "" {
+		file, err := audit.NewFileSink(cfg.Audit.File)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, file)
+	}
+	if cfg.Audit.WebhookURL != "" {
+		sinks = append(sinks, audit.NewWebhookSink(cfg.Audit.WebhookURL, cfg.Audit.WebhookToken))
 	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+	return sinks, nil
+}
 
-	return nil
+// bearerValidator builds the /mcp bearer validator from static API keys
+// and/or an OIDC issuer. Static keys are checked first.
+func bearerValidator(ctx context.Context, cfg *config.Config) (auth.BearerValidator, error) {
+	var validators auth.MultiValidator
+	if len(cfg.HTTPAuth.APIKeys) > 0 {
+		validators = append(validators, auth.NewStaticKeyValidator(cfg.HTTPAuth.APIKeys))
+	}
+	if cfg.HTTPAuth.OIDCIssuer != "" {
+		oidc, err := auth.NewOIDCValidator(ctx, cfg.HTTPAuth.OIDCIssuer, cfg.HTTPAuth.OIDCAudience)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, oidc)
+	}
+	return validators, nil
+}
+
+// newLogger returns the stderr JSON logger for the configured level. With
+// redactPII, email addresses in every log attribute are masked.
+func newLogger(level string, redactPII bool) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: slog.LevelInfo}
+	switch level {
+	case "debug":
+		opts.Level = slog.LevelDebug
+	case "warn":
+		opts.Level = slog.LevelWarn
+	case "error":
+		opts.Level = slog.LevelError
+	}
+	if redactPII {
+		opts.ReplaceAttr = middleware.RedactLogAttr
+	}
+	return slog.New(slog.NewJSONHandler(os.Stderr, opts))
 }
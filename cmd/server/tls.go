@@ -0,0 +1,97 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/evert/google-workspace-mcp-go/internal/config"
+)
+
+// serverTLSConfig builds the TLS config for the HTTP listener. The server
+// certificate is reloaded when its files change, so rotated certificates
+// are picked up without a restart. With a client CA, clients must present
+// a certificate it signed (mutual TLS).
+func serverTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	certs, err := newCertReloader(cfg.Server.TLSCert, cfg.Server.TLSKey)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: certs.getCertificate,
+	}
+	if cfg.Server.ClientCA != "" {
+		pem, err := os.ReadFile(cfg.Server.ClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("reading client CA %s: %w", cfg.Server.ClientCA, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("client CA %s contains no PEM certificates", cfg.Server.ClientCA)
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsCfg, nil
+}
+
+// certReloader serves a certificate and key pair from disk, reloading it
+// when either file's modification time changes. A pair that fails to load
+// (for example mid-rotation) is logged and the previous one keeps serving.
+type certReloader struct {
+	certFile, keyFile string
+
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	modified [2]time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.reloadIfChanged(); err != nil {
+		slog.Warn("TLS certificate reload failed — serving the previous certificate", "cert", r.certFile, "error", err)
+	}
+	return r.cert, nil
+}
+
+func (r *certReloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reloadIfChanged()
+}
+
+// reloadIfChanged loads the pair when it has not been loaded yet or its
+// files changed. The caller holds r.mu.
+func (r *certReloader) reloadIfChanged() error {
+	var modified [2]time.Time
+	for i, name := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			return fmt.Errorf("reading TLS file: %w", err)
+		}
+		modified[i] = info.ModTime()
+	}
+	if r.cert != nil && modified == r.modified {
+		return nil
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("loading TLS certificate %s and key %s: %w", r.certFile, r.keyFile, err)
+	}
+	r.cert, r.modified = &cert, modified
+	return nil
+}
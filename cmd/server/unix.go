@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"time"
+)
+
+// listenUnix listens on a Unix domain socket at path with permissions perm.
+// A stale socket left by a crashed server is removed first; the socket file
+// is removed again when the listener is closed on shutdown.
+func listenUnix(path string, perm os.FileMode) (net.Listener, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listening on unix socket %s: %w", path, err)
+	}
+	ln.(*net.UnixListener).SetUnlinkOnClose(true)
+	if err := os.Chmod(path, perm); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("setting permissions on unix socket %s: %w", path, err)
+	}
+	return ln, nil
+}
+
+// removeStaleSocket removes a socket at path that no server is listening on.
+// It refuses to remove anything that is not a socket, or a live socket.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("checking unix socket %s: %w", path, err)
+	}
+	if info.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("unix socket path %s exists and is not a socket", path)
+	}
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("unix socket %s is in use by another server", path)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("removing stale unix socket %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,400 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidBearerToken is returned when a bearer token is not accepted by any validator.
+var ErrInvalidBearerToken = errors.New("invalid bearer token")
+
+// BearerValidator validates bearer tokens presented to the HTTP transport and
+// returns an identifier for the authenticated caller (for logging).
+type BearerValidator interface {
+	Validate(ctx context.Context, token string) (string, error)
+}
+
+// StaticKeyValidator accepts a fixed set of pre-shared API keys.
+type StaticKeyValidator struct {
+	keys [][]byte
+}
+
+// NewStaticKeyValidator creates a validator for the given API keys. Empty keys are ignored.
+func NewStaticKeyValidator(keys []string) *StaticKeyValidator {
+	v := &StaticKeyValidator{}
+	for _, k := range keys {
+		if k != "" {
+			v.keys = append(v.keys, []byte(k))
+		}
+	}
+	return v
+}
+
+// Validate compares the token against every configured key in constant time.
+func (v *StaticKeyValidator) Validate(_ context.Context, token string) (string, error) {
+	matched := -1
+	for i, k := range v.keys {
+		if subtle.ConstantTimeCompare([]byte(token), k) == 1 {
+			matched = i
+		}
+	}
+	if matched < 0 {
+		return "", ErrInvalidBearerToken
+	}
+	return fmt.Sprintf("api-key#%d", matched+1), nil
+}
+
+// MultiValidator tries each validator in order and accepts the first match.
+type MultiValidator []BearerValidator
+
+// Validate returns the first successful validation, or the last error.
+func (m MultiValidator) Validate(ctx context.Context, token string) (string, error) {
+	err := ErrInvalidBearerToken
+	for _, v := range m {
+		subject, vErr := v.Validate(ctx, token)
+		if vErr == nil {
+			return subject, nil
+		}
+		err = vErr
+	}
+	return "", err
+}
+
+// jwksRefreshInterval limits how often an unknown key ID triggers a JWKS refetch.
+const jwksRefreshInterval = time.Minute
+
+// jwtClockSkew is the tolerance applied to exp/nbf checks.
+const jwtClockSkew = 30 * time.Second
+
+// OIDCValidator validates JWT access/ID tokens issued by an OpenID Connect
+// provider. Signing keys are discovered via the issuer's
+// /.well-known/openid-configuration document and cached, with a refetch
+// when an unknown key ID is seen (key rotation).
+type OIDCValidator struct {
+	issuer   string
+	audience string
+	jwksURI  string
+	client   *http.Client
+	now      func() time.Time
+
+	mu        sync.RWMutex
+	keys      map[string]crypto.PublicKey
+	lastFetch time.Time
+}
+
+// NewOIDCValidator discovers the issuer's JWKS endpoint and loads its keys.
+// audience must appear in the token's aud claim: shared issuers such as
+// Google sign ID tokens for every OAuth client, so the issuer alone does not
+// identify tokens meant for this server.
+func NewOIDCValidator(ctx context.Context, issuer, audience string) (*OIDCValidator, error) {
+	if audience == "" {
+		return nil, fmt.Errorf("OIDC audience is required — set MCP_OIDC_AUDIENCE to this server's client ID")
+	}
+	v := &OIDCValidator{
+		issuer:   strings.TrimRight(issuer, "/"),
+		audience: audience,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		now:      time.Now,
+		keys:     make(map[string]crypto.PublicKey),
+	}
+
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := v.getJSON(ctx, v.issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, fmt.Errorf("fetching OIDC discovery document: %w", err)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC discovery document for %s has no jwks_uri", v.issuer)
+	}
+	if discovery.Issuer != "" && strings.TrimRight(discovery.Issuer, "/") != v.issuer {
+		return nil, fmt.Errorf("OIDC discovery issuer %q does not match configured issuer %q", discovery.Issuer, v.issuer)
+	}
+	v.jwksURI = discovery.JWKSURI
+
+	if err := v.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// jwtHeader is the subset of the JOSE header we use.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jwtClaims is the subset of registered claims we validate.
+type jwtClaims struct {
+	Issuer    string          `json:"iss"`
+	Subject   string          `json:"sub"`
+	Email     string          `json:"email"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt int64           `json:"exp"`
+	NotBefore int64           `json:"nbf"`
+}
+
+// Validate verifies the JWT signature, issuer, audience, and validity window.
+func (v *OIDCValidator) Validate(ctx context.Context, token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("%w: not a JWT", ErrInvalidBearerToken)
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return "", fmt.Errorf("%w: bad header", ErrInvalidBearerToken)
+	}
+	key, err := v.key(ctx, header.Kid)
+	if err != nil {
+		return "", err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("%w: bad signature encoding", ErrInvalidBearerToken)
+	}
+	if err := verifyJWTSignature(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidBearerToken, err)
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return "", fmt.Errorf("%w: bad claims", ErrInvalidBearerToken)
+	}
+	if err := v.checkClaims(claims); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidBearerToken, err)
+	}
+
+	if claims.Email != "" {
+		return claims.Email, nil
+	}
+	return claims.Subject, nil
+}
+
+// checkClaims validates issuer, audience, and the exp/nbf window.
+func (v *OIDCValidator) checkClaims(c jwtClaims) error {
+	if strings.TrimRight(c.Issuer, "/") != v.issuer {
+		return fmt.Errorf("unexpected issuer %q", c.Issuer)
+	}
+	now := v.now()
+	if c.ExpiresAt == 0 || now.After(time.Unix(c.ExpiresAt, 0).Add(jwtClockSkew)) {
+		return fmt.Errorf("token expired")
+	}
+	if c.NotBefore != 0 && now.Add(jwtClockSkew).Before(time.Unix(c.NotBefore, 0)) {
+		return fmt.Errorf("token not yet valid")
+	}
+	if len(c.Audience) == 0 || string(c.Audience) == "null" {
+		return fmt.Errorf("token has no aud claim")
+	}
+
+	var auds []string
+	var single string
+	if err := json.Unmarshal(c.Audience, &single); err == nil {
+		auds = []string{single}
+	} else if err := json.Unmarshal(c.Audience, &auds); err != nil {
+		return fmt.Errorf("malformed aud claim")
+	}
+	for _, a := range auds {
+		if a == v.audience {
+			return nil
+		}
+	}
+	return fmt.Errorf("token audience does not include %q", v.audience)
+}
+
+// key returns the public key for kid, refetching the JWKS at most once per
+// jwksRefreshInterval when the key is unknown.
+func (v *OIDCValidator) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	stale := v.now().Sub(v.lastFetch) > jwksRefreshInterval
+	v.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+	if stale {
+		if err := v.refreshKeys(ctx); err != nil {
+			return nil, err
+		}
+		v.mu.RLock()
+		key, ok = v.keys[kid]
+		v.mu.RUnlock()
+		if ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidBearerToken, kid)
+}
+
+// jwk is a single JSON Web Key (RSA or EC public key).
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// refreshKeys refetches the JWKS and replaces the cached key set.
+func (v *OIDCValidator) refreshKeys(ctx context.Context) error {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := v.getJSON(ctx, v.jwksURI, &set); err != nil {
+		return fmt.Errorf("fetching OIDC signing keys: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			continue // skip unsupported key types rather than failing the whole set
+		}
+		keys[k.Kid] = pub
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.lastFetch = v.now()
+	v.mu.Unlock()
+	return nil
+}
+
+// publicKey converts a JWK into an *rsa.PublicKey or *ecdsa.PublicKey.
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// verifyJWTSignature checks a JWS signature for the supported RS*/ES* algorithms.
+func verifyJWTSignature(alg string, key crypto.PublicKey, signingInput string, sig []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported alg %q", alg)
+	}
+	digest := hashBytes(hash, []byte(signingInput))
+
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("alg %q does not match RSA key", alg)
+		}
+		return rsa.VerifyPKCS1v15(pub, hash, digest, sig)
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") {
+			return fmt.Errorf("alg %q does not match EC key", alg)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return fmt.Errorf("bad ECDSA signature length")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return fmt.Errorf("ECDSA signature verification failed")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+func hashBytes(h crypto.Hash, data []byte) []byte {
+	switch h {
+	case crypto.SHA384:
+		sum := sha512.Sum384(data)
+		return sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512(data)
+		return sum[:]
+	default:
+		sum := sha256.Sum256(data)
+		return sum[:]
+	}
+}
+
+// decodeJWTSegment base64url-decodes and unmarshals a JWT header or payload.
+func decodeJWTSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// getJSON fetches url and decodes the JSON response into v.
+func (v *OIDCValidator) getJSON(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
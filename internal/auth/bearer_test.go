@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStaticKeyValidator(t *testing.T) {
+	v := NewStaticKeyValidator([]string{"alpha", "", "beta"})
+
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{"first key", "alpha", "api-key#1", false},
+		{"second key", "beta", "api-key#2", false},
+		{"unknown key", "gamma", "", true},
+		{"empty token", "", "", true},
+		{"prefix of key", "alp", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Validate(context.Background(), tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBearerToken) {
+					t.Errorf("expected ErrInvalidBearerToken, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("subject: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeIssuer serves an OIDC discovery document and JWKS for a single RSA key.
+func fakeIssuer(t *testing.T, key *rsa.PrivateKey, kid string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   srv.URL,
+			"jwks_uri": srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	return srv
+}
+
+// signJWT builds an RS256-signed JWT for the given claims.
+func signJWT(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	payload, _ := json.Marshal(claims)
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(input))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("signing JWT: %v", err)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestOIDCValidator(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	srv := fakeIssuer(t, key, "k1")
+
+	v, err := NewOIDCValidator(context.Background(), srv.URL, "mcp-server")
+	if err != nil {
+		t.Fatalf("NewOIDCValidator: %v", err)
+	}
+
+	exp := time.Now().Add(time.Hour).Unix()
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{"iss": srv.URL, "sub": "user-1", "aud": "mcp-server", "exp": exp}
+		for k, val := range overrides {
+			c[k] = val
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{"valid token", signJWT(t, key, "k1", claims(nil)), "user-1", false},
+		{"email preferred over sub", signJWT(t, key, "k1", claims(map[string]any{"email": "a@example.com"})), "a@example.com", false},
+		{"audience list", signJWT(t, key, "k1", claims(map[string]any{"aud": []string{"other", "mcp-server"}})), "user-1", false},
+		{"wrong audience", signJWT(t, key, "k1", claims(map[string]any{"aud": "other"})), "", true},
+		{"wrong audience list", signJWT(t, key, "k1", claims(map[string]any{"aud": []string{"other", "another"}})), "", true},
+		{"missing audience", signJWT(t, key, "k1", claims(map[string]any{"aud": nil})), "", true},
+		{"wrong issuer", signJWT(t, key, "k1", claims(map[string]any{"iss": "https://evil.example.com"})), "", true},
+		{"expired", signJWT(t, key, "k1", claims(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})), "", true},
+		{"not yet valid", signJWT(t, key, "k1", claims(map[string]any{"nbf": time.Now().Add(time.Hour).Unix()})), "", true},
+		{"signed by other key", signJWT(t, otherKey, "k1", claims(nil)), "", true},
+		{"unknown kid", signJWT(t, key, "k2", claims(nil)), "", true},
+		{"not a JWT", "opaque-token", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Validate(context.Background(), tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBearerToken) {
+					t.Errorf("expected ErrInvalidBearerToken, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("subject: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiValidator(t *testing.T) {
+	v := MultiValidator{NewStaticKeyValidator([]string{"one"}), NewStaticKeyValidator([]string{"two"})}
+
+	if _, err := v.Validate(context.Background(), "two"); err != nil {
+		t.Errorf("expected second validator to accept token: %v", err)
+	}
+	if _, err := v.Validate(context.Background(), "three"); err == nil {
+		t.Error("expected unknown token to be rejected")
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"html"
 	"log/slog"
 	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
 )
 
 // ClientInvalidator is called after successful OAuth to clear cached API clients.
@@ -50,18 +53,22 @@ func OAuthCallbackHandler(oauthMgr *OAuthManager, invalidator ClientInvalidator)
 			return
 		}
 
-		// Verify HMAC-signed state and extract user email.
-		state, valid := oauthMgr.VerifyAndExtractEmail(rawState)
-		if !valid {
-			slog.Error("OAuth callback invalid state signature", "state", rawState)
+		// Verify and consume the signed, single-use state, check that the
+		// session that started the flow is still open, and extract the user
+		// email, all before the token is saved.
+		info, err := oauthMgr.CompleteState(rawState)
+		if err != nil {
+			slog.Error("OAuth callback rejected state", "reason", err)
 			w.Header().Set("Content-Type", "text/html; charset=utf-8")
 			w.WriteHeader(http.StatusBadRequest)
-			fmt.Fprint(w, renderErrorPage("Invalid OAuth state — possible CSRF attempt. Please restart the authentication from the MCP client."))
+			fmt.Fprint(w, renderErrorPage(html.EscapeString(fmt.Sprintf(
+				"Invalid OAuth state (%v) — the link may have expired, already been used, belong to a session that has ended, or been tampered with. Please restart the authentication from the MCP client.", err))))
 			return
 		}
+		state := info.Email
 
 		// Exchange code for token and persist it
-		_, err := oauthMgr.ExchangeCode(r.Context(), code, state)
+		token, err := oauthMgr.ExchangeCode(r.Context(), code, state)
 		if err != nil {
 			slog.Error("OAuth token exchange failed", "email", state, "error", err)
 			w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -77,14 +84,30 @@ func OAuthCallbackHandler(oauthMgr *OAuthManager, invalidator ClientInvalidator)
 			slog.Info("invalidated cached client after re-auth", "email", state)
 		}
 
-		slog.Info("OAuth authentication successful", "email", state)
+		scopes := grantedScopes(token)
+		slog.Info("OAuth authentication successful",
+			"email", state,
+			"session", info.Owner.SessionID,
+			"scopes", len(scopes),
+		)
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
-		fmt.Fprint(w, renderSuccessPage(html.EscapeString(state)))
+		fmt.Fprint(w, renderSuccessPage(state, scopes))
+	}
+}
+
+// grantedScopes returns the scopes Google reports as granted in the token
+// response (the "scope" field), which may be narrower than requested when the
+// user unticks permissions on the consent screen.
+func grantedScopes(token *oauth2.Token) []string {
+	if token == nil {
+		return nil
 	}
+	raw, _ := token.Extra("scope").(string)
+	return strings.Fields(raw)
 }
 
-func renderSuccessPage(email string) string {
+func renderSuccessPage(email string, scopes []string) string {
 	safeEmail := html.EscapeString(email)
 	return fmt.Sprintf(`<!DOCTYPE html>
 <html lang="en">
@@ -176,6 +199,25 @@ func renderSuccessPage(email string) string {
       color: #666;
       margin-top: 4px;
     }
+    .scopes {
+      text-align: left;
+      font-size: 12px;
+      color: #aaa;
+      background: #1f1f1f;
+      border: 1px solid #444;
+      border-radius: 8px;
+      padding: 12px 16px;
+      margin-bottom: 24px;
+      list-style: none;
+      word-break: break-all;
+    }
+    .scopes li { padding: 2px 0; }
+    .scopes-title {
+      font-size: 13px;
+      color: #ccc;
+      margin-bottom: 8px;
+      text-align: left;
+    }
     .close-hint {
       margin-top: 16px;
       font-size: 13px;
@@ -194,6 +236,7 @@ func renderSuccessPage(email string) string {
       Your Google Workspace account has been connected.<br>
       All MCP tools are now available for this account.
     </p>
+    %[3]s
     <span class="badge">Google Workspace MCP</span>
     <div class="countdown-wrap">
       <p class="countdown-msg" id="countdown-msg">This window will close automatically in</p>
@@ -227,7 +270,24 @@ func renderSuccessPage(email string) string {
     })();
   </script>
 </body>
-</html>`, safeEmail, oauthSuccessAutoCloseSeconds)
+</html>`, safeEmail, oauthSuccessAutoCloseSeconds, renderScopeList(scopes))
+}
+
+// renderScopeList renders the granted scopes as an HTML list, or nothing when
+// Google did not report them.
+func renderScopeList(scopes []string) string {
+	if len(scopes) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(`<p class="scopes-title">Granted scopes</p><ul class="scopes">`)
+	for _, s := range scopes {
+		sb.WriteString("<li>")
+		sb.WriteString(html.EscapeString(s))
+		sb.WriteString("</li>")
+	}
+	sb.WriteString("</ul>")
+	return sb.String()
 }
 
 func renderErrorPage(errMsg string) string {
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"golang.org/x/oauth2"
 )
@@ -17,6 +18,28 @@ import (
 type TokenStore interface {
 	Save(userEmail string, token *oauth2.Token) error
 	Load(userEmail string) (*oauth2.Token, error)
+	// Delete removes the user's token. Deleting a missing token is not an error.
+	Delete(userEmail string) error
+}
+
+// TokenEntry describes a stored credential without exposing the token itself.
+type TokenEntry struct {
+	Email   string
+	SavedAt time.Time // last authorization or refresh
+}
+
+// ListableTokenStore is implemented by stores that can enumerate their users,
+// which the TTL sweeper requires.
+type ListableTokenStore interface {
+	TokenStore
+	List() ([]TokenEntry, error)
+}
+
+// storedToken is the on-disk file format: the oauth2.Token fields plus the
+// owning email, so the directory can be enumerated despite hashed filenames.
+type storedToken struct {
+	*oauth2.Token
+	Email string `json:"email,omitempty"`
 }
 
 // FileTokenStore stores tokens as JSON files on disk.
@@ -49,7 +72,7 @@ func NewFileTokenStore(dir string) (*FileTokenStore, error) {
 
 // Save persists a token for the given user email.
 func (s *FileTokenStore) Save(userEmail string, token *oauth2.Token) error {
-	data, err := json.Marshal(token)
+	data, err := json.Marshal(storedToken{Token: token, Email: userEmail})
 	if err != nil {
 		return fmt.Errorf("marshaling token: %w", err)
 	}
@@ -78,6 +101,43 @@ func (s *FileTokenStore) Load(userEmail string) (*oauth2.Token, error) {
 	return &token, nil
 }
 
+// Delete removes the token file for the given user email.
+func (s *FileTokenStore) Delete(userEmail string) error {
+	path := s.tokenPath(userEmail)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing token file %s: %w", path, err)
+	}
+	return nil
+}
+
+// List returns every stored credential, using the file modification time as
+// the last-saved time. Files written before the email was recorded are skipped.
+func (s *FileTokenStore) List() ([]TokenEntry, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("listing credentials directory %s: %w", s.dir, err)
+	}
+
+	var entries []TokenEntry
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading token from %s: %w", path, err)
+		}
+		var st storedToken
+		if err := json.Unmarshal(data, &st); err != nil || st.Email == "" {
+			slog.Debug("skipping token file without an owner email", "path", path)
+			continue
+		}
+		entries = append(entries, TokenEntry{Email: st.Email, SavedAt: info.ModTime()})
+	}
+	return entries, nil
+}
+
 func (s *FileTokenStore) tokenPath(userEmail string) string {
 	// Use a SHA-256 hash of the email as the filename to prevent path traversal.
 	hash := sha256.Sum256([]byte(userEmail))
@@ -88,14 +148,16 @@ func (s *FileTokenStore) tokenPath(userEmail string) string {
 // Tokens are lost when the process exits. This is the default mode —
 // no credentials are written to disk.
 type InMemoryTokenStore struct {
-	mu     sync.RWMutex
-	tokens map[string]*oauth2.Token
+	mu      sync.RWMutex
+	tokens  map[string]*oauth2.Token
+	savedAt map[string]time.Time
 }
 
 // NewInMemoryTokenStore creates a token store that keeps tokens in memory only.
 func NewInMemoryTokenStore() *InMemoryTokenStore {
 	return &InMemoryTokenStore{
-		tokens: make(map[string]*oauth2.Token),
+		tokens:  make(map[string]*oauth2.Token),
+		savedAt: make(map[string]time.Time),
 	}
 }
 
@@ -104,6 +166,7 @@ func (s *InMemoryTokenStore) Save(userEmail string, token *oauth2.Token) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.tokens[userEmail] = token
+	s.savedAt[userEmail] = time.Now()
 	return nil
 }
 
@@ -118,6 +181,26 @@ func (s *InMemoryTokenStore) Load(userEmail string) (*oauth2.Token, error) {
 	return token, nil
 }
 
+// Delete removes the token for the given user email.
+func (s *InMemoryTokenStore) Delete(userEmail string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, userEmail)
+	delete(s.savedAt, userEmail)
+	return nil
+}
+
+// List returns every user with a stored token.
+func (s *InMemoryTokenStore) List() ([]TokenEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := make([]TokenEntry, 0, len(s.tokens))
+	for email := range s.tokens {
+		entries = append(entries, TokenEntry{Email: email, SavedAt: s.savedAt[email]})
+	}
+	return entries, nil
+}
+
 // PersistingTokenSource wraps an oauth2.TokenSource to persist refreshed tokens to disk.
 // It tracks the last known access token so it only writes to disk when the token
 // actually changes (i.e. on refresh), not on every Token() call.
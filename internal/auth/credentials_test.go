@@ -319,3 +319,44 @@ func TestPersistingTokenSource_WithInMemoryStore(t *testing.T) {
 		t.Errorf("persisted token should be 'refreshed', got %s", loaded.AccessToken)
 	}
 }
+
+func TestFileTokenStore_DeleteAndList(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileTokenStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileTokenStore: %v", err)
+	}
+
+	for _, email := range []string{"a@example.com", "b@example.com"} {
+		if err := store.Save(email, &oauth2.Token{AccessToken: "tok-" + email}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	// Legacy files without an owner email are ignored by List.
+	if err := os.WriteFile(filepath.Join(dir, "legacy.json"), []byte(`{"access_token":"x"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := store.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	for _, e := range entries {
+		if e.SavedAt.IsZero() {
+			t.Errorf("expected SavedAt for %s", e.Email)
+		}
+	}
+
+	if err := store.Delete("a@example.com"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Load("a@example.com"); err == nil {
+		t.Error("expected deleted token to be gone")
+	}
+	if err := store.Delete("a@example.com"); err != nil {
+		t.Errorf("deleting a missing token should succeed: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/zalando/go-keyring"
+)
+
+// TokenStoreChecker is implemented by token stores whose backend can become
+// unreachable. Check reports whether the store can currently be used.
+type TokenStoreChecker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckTokenStore verifies that store is usable. Stores without a backend to
+// reach, such as the in-memory store, always pass.
+func CheckTokenStore(ctx context.Context, store TokenStore) error {
+	if c, ok := store.(TokenStoreChecker); ok {
+		return c.Check(ctx)
+	}
+	return nil
+}
+
+// Check verifies the credentials directory exists and is writable.
+func (s *FileTokenStore) Check(context.Context) error {
+	f, err := os.CreateTemp(s.dir, ".readyz-*")
+	if err != nil {
+		return fmt.Errorf("credentials directory %s is not writable: %w", s.dir, err)
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// Check verifies the OS keychain still answers lookups.
+func (s *KeyringTokenStore) Check(context.Context) error {
+	if _, err := keyring.Get(s.service, keyringProbeUser); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("OS keyring unavailable (service %q): %w", s.service, err)
+	}
+	return nil
+}
+
+// Check verifies Vault accepts the token for the configured path with a
+// single metadata listing, without reading any secret.
+func (s *VaultTokenStore) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, vaultRequestTimeout)
+	defer cancel()
+	resp, err := s.do(ctx, http.MethodGet, fmt.Sprintf("%s/metadata/%s?list=true", s.cfg.Mount, s.cfg.Path), nil)
+	if err != nil {
+		return fmt.Errorf("reaching vault: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("vault: %s", vaultErrorDetail(resp))
+	}
+	return nil
+}
+
+// CheckConfig reports whether the OAuth client configuration is complete:
+// a client ID and secret, and an absolute redirect URL.
+func (m *OAuthManager) CheckConfig() error {
+	if m.config.ClientID == "" || m.config.ClientSecret == "" {
+		return fmt.Errorf("OAuth client ID and secret must be set")
+	}
+	u, err := url.Parse(m.config.RedirectURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("OAuth redirect URL %q is not an absolute http(s) URL", m.config.RedirectURL)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileTokenStoreCheck(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileTokenStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckTokenStore(context.Background(), store); err != nil {
+		t.Fatalf("writable directory: %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("probe file left behind: %v", entries)
+	}
+
+	store.dir = filepath.Join(dir, "missing")
+	if err := CheckTokenStore(context.Background(), store); err == nil {
+		t.Error("missing directory: want error")
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		secret   string
+		redirect string
+		wantErr  bool
+	}{
+		{"complete", "id", "secret", "http://localhost:8000/oauth/callback", false},
+		{"missing secret", "id", "", "http://localhost:8000/oauth/callback", true},
+		{"relative redirect", "id", "secret", "/oauth/callback", true},
+		{"non-http redirect", "id", "secret", "urn:ietf:wg:oauth:2.0:oob", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewOAuthManager(tt.id, tt.secret, tt.redirect, nil, NewInMemoryTokenStore())
+			if err := m.CheckConfig(); (err != nil) != tt.wantErr {
+				t.Errorf("CheckConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/oauth2"
+)
+
+// DefaultKeyringService is the service name under which tokens are stored in
+// the OS keychain. Each user email is stored as a separate account entry.
+const DefaultKeyringService = "google-workspace-mcp"
+
+// keyringProbeUser is looked up once at startup to verify the keychain is reachable.
+const keyringProbeUser = "__google-workspace-mcp-probe__"
+
+// KeyringTokenStore stores tokens in the OS credential store: macOS Keychain,
+// Windows Credential Manager, or the Secret Service API (libsecret) on Linux.
+// Refresh tokens never touch the filesystem. Intended for local stdio deployments
+// where a desktop session (and therefore an unlocked keychain) is available.
+type KeyringTokenStore struct {
+	service string
+}
+
+// NewKeyringTokenStore creates a token store backed by the OS keychain.
+// It performs a probe lookup so that a missing or locked keychain (e.g. a
+// headless Linux host without a Secret Service daemon) fails at startup rather
+// than on the first tool call.
+func NewKeyringTokenStore(service string) (*KeyringTokenStore, error) {
+	if service == "" {
+		service = DefaultKeyringService
+	}
+	if _, err := keyring.Get(service, keyringProbeUser); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return nil, fmt.Errorf("OS keyring unavailable (service %q): %w", service, err)
+	}
+	return &KeyringTokenStore{service: service}, nil
+}
+
+// Save persists a token for the given user email in the OS keychain.
+func (s *KeyringTokenStore) Save(userEmail string, token *oauth2.Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("marshaling token: %w", err)
+	}
+	if err := keyring.Set(s.service, userEmail, string(data)); err != nil {
+		return fmt.Errorf("writing token to OS keyring for %s: %w", userEmail, err)
+	}
+	return nil
+}
+
+// Load reads a token for the given user email from the OS keychain.
+func (s *KeyringTokenStore) Load(userEmail string) (*oauth2.Token, error) {
+	data, err := keyring.Get(s.service, userEmail)
+	if err != nil {
+		if errors.Is(err, keyring.ErrNotFound) {
+			return nil, fmt.Errorf("no credentials found for %s — call start_google_auth to authenticate", userEmail)
+		}
+		return nil, fmt.Errorf("reading token from OS keyring for %s: %w", userEmail, err)
+	}
+
+	var token oauth2.Token
+	if err := json.Unmarshal([]byte(data), &token); err != nil {
+		return nil, fmt.Errorf("parsing token for %s: %w", userEmail, err)
+	}
+	return &token, nil
+}
+
+// Delete removes the user's token from the OS keychain.
+func (s *KeyringTokenStore) Delete(userEmail string) error {
+	if err := keyring.Delete(s.service, userEmail); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("removing token from OS keyring for %s: %w", userEmail, err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/oauth2"
+)
+
+func TestKeyringTokenStore_SaveAndLoad(t *testing.T) {
+	keyring.MockInit()
+
+	store, err := NewKeyringTokenStore("")
+	if err != nil {
+		t.Fatalf("NewKeyringTokenStore: %v", err)
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  "kr-access-123",
+		RefreshToken: "kr-refresh-456",
+		TokenType:    "Bearer",
+		Expiry:       time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	email := "keyring@example.com"
+
+	if err := store.Save(email, token); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := store.Load(email)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.AccessToken != token.AccessToken {
+		t.Errorf("AccessToken: got %q, want %q", loaded.AccessToken, token.AccessToken)
+	}
+	if loaded.RefreshToken != token.RefreshToken {
+		t.Errorf("RefreshToken: got %q, want %q", loaded.RefreshToken, token.RefreshToken)
+	}
+	if !loaded.Expiry.Equal(token.Expiry) {
+		t.Errorf("Expiry: got %v, want %v", loaded.Expiry, token.Expiry)
+	}
+}
+
+func TestKeyringTokenStore_LoadNonExistent(t *testing.T) {
+	keyring.MockInit()
+
+	store, err := NewKeyringTokenStore("")
+	if err != nil {
+		t.Fatalf("NewKeyringTokenStore: %v", err)
+	}
+
+	_, err = store.Load("nobody@example.com")
+	if err == nil {
+		t.Fatal("expected error for non-existent token")
+	}
+	if !strings.Contains(err.Error(), "start_google_auth") {
+		t.Errorf("expected actionable auth hint, got: %v", err)
+	}
+}
+
+func TestKeyringTokenStore_Unavailable(t *testing.T) {
+	keyring.MockInitWithError(keyring.ErrUnsupportedPlatform)
+	defer keyring.MockInit()
+
+	if _, err := NewKeyringTokenStore(""); err == nil {
+		t.Fatal("expected error when keyring is unavailable")
+	}
+}
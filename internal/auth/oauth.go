@@ -3,10 +3,15 @@ package auth
 import (
 	"context"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
@@ -14,11 +19,58 @@ import (
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/validate"
 )
 
+// stateTTL is how long an issued OAuth state remains valid. The user must
+// complete the Google consent screen within this window.
+const stateTTL = 10 * time.Minute
+
 // OAuthManager handles OAuth2 configuration and token exchange.
 type OAuthManager struct {
 	config     *oauth2.Config
 	tokenStore TokenStore
 	stateKey   []byte // HMAC key for signing OAuth state
+	revokeURL  string // Google token revocation endpoint (overridable in tests)
+
+	// pending tracks issued, not-yet-consumed states by nonce so that unknown
+	// or replayed states are rejected even when their signature is valid. It
+	// lives in this process only: with several replicas, the callback must be
+	// routed to the replica that issued the link.
+	mu      sync.Mutex
+	pending map[string]pendingState
+	now     func() time.Time
+
+	// sessions holds the open MCP sessions that started OAuth flows, with
+	// the bearer subject each is authenticated as.
+	sessions map[string]string
+}
+
+// StateOwner identifies who started an OAuth flow: the MCP session and the
+// bearer subject it is authenticated as. Both are empty over stdio, where
+// the operator is the only caller.
+type StateOwner struct {
+	SessionID string
+	Subject   string
+}
+
+// pendingState records who initiated an OAuth flow and when it expires.
+type pendingState struct {
+	email   string
+	owner   StateOwner
+	expires time.Time
+}
+
+// statePayload is the signed content of the OAuth state parameter.
+type statePayload struct {
+	Email     string `json:"e"`
+	SessionID string `json:"s,omitempty"`
+	Subject   string `json:"u,omitempty"`
+	Nonce     string `json:"n"`
+	Expires   int64  `json:"x"`
+}
+
+// StateInfo is the verified content of an OAuth state.
+type StateInfo struct {
+	Email string
+	Owner StateOwner
 }
 
 // NewOAuthManager creates an OAuth manager with the given credentials.
@@ -34,40 +86,208 @@ func NewOAuthManager(clientID, clientSecret, redirectURL string, scopes []string
 		},
 		tokenStore: store,
 		stateKey:   []byte(clientSecret),
+		revokeURL:  googleRevokeURL,
+		pending:    make(map[string]pendingState),
+		now:        time.Now,
+		sessions:   make(map[string]string),
 	}
 }
 
 // GetAuthURL returns the URL for the user to authenticate.
-// The state parameter is the user email signed with HMAC to prevent CSRF.
+// The state parameter is signed with HMAC, expires after stateTTL, and may
+// only be used once, to prevent CSRF and replay.
 func (m *OAuthManager) GetAuthURL(userEmail string) string {
+	return m.GetAuthURLFor(userEmail, StateOwner{})
+}
+
+// GetAuthURLFor is like GetAuthURL but binds the state to owner: the
+// callback only accepts it while owner's session is open, under the same
+// subject. Register the session with OpenSession first.
+func (m *OAuthManager) GetAuthURLFor(userEmail string, owner StateOwner) string {
 	if err := validate.Email(userEmail); err != nil {
 		return ""
 	}
-	state := m.signState(userEmail)
+	state, err := m.signState(userEmail, owner)
+	if err != nil {
+		return ""
+	}
 	return m.config.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce)
 }
 
-// VerifyAndExtractEmail verifies the HMAC-signed state parameter and extracts
-// the user email. Returns the email and true if valid, or ("", false) if not.
+// VerifyAndExtractEmail verifies the OAuth state parameter without consuming
+// it and extracts the user email. Returns the email and true if valid, or
+// ("", false) if not.
 func (m *OAuthManager) VerifyAndExtractEmail(state string) (string, bool) {
-	// State format: "email:hex-signature"
-	parts := strings.SplitN(state, ":", 2)
-	if len(parts) != 2 {
+	info, err := m.VerifyState(state)
+	if err != nil {
 		return "", false
 	}
-	email := parts[0]
-	providedSig := parts[1]
+	return info.Email, true
+}
 
-	expectedSig := m.hmacSign(email)
-	if !hmac.Equal([]byte(providedSig), []byte(expectedSig)) {
-		return "", false
+// VerifyState checks the state signature and expiry and that it was issued
+// by this server and not used yet. It does not consume the state; the
+// callback uses ConsumeState. The returned error explains why a state was
+// rejected.
+func (m *OAuthManager) VerifyState(state string) (StateInfo, error) {
+	payload, err := m.parseState(state)
+	if err != nil {
+		return StateInfo{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.checkPendingLocked(payload, m.now())
+}
+
+// ConsumeState verifies the state like VerifyState, checks that completer
+// is the owner the state was issued to, and removes it so it cannot be
+// replayed.
+func (m *OAuthManager) ConsumeState(state string, completer StateOwner) (StateInfo, error) {
+	payload, err := m.parseState(state)
+	if err != nil {
+		return StateInfo{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.consumeLocked(payload, completer)
+}
+
+// CompleteState consumes a state arriving at the OAuth callback. The
+// browser redirect carries no MCP identity, so the flow completes on behalf
+// of the session that started it only while that session is still open;
+// states from closed sessions are rejected.
+func (m *OAuthManager) CompleteState(state string) (StateInfo, error) {
+	payload, err := m.parseState(state)
+	if err != nil {
+		return StateInfo{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var completer StateOwner
+	if subject, open := m.sessions[payload.SessionID]; open {
+		completer = StateOwner{SessionID: payload.SessionID, Subject: subject}
+	}
+	return m.consumeLocked(payload, completer)
+}
+
+// consumeLocked checks a decoded state and its owner and removes it.
+// Caller must hold m.mu.
+func (m *OAuthManager) consumeLocked(payload statePayload, completer StateOwner) (StateInfo, error) {
+	info, err := m.checkPendingLocked(payload, m.now())
+	if err != nil {
+		return StateInfo{}, err
+	}
+	if info.Owner != completer {
+		return StateInfo{}, fmt.Errorf("state was issued to another MCP session, or that session has ended")
+	}
+	delete(m.pending, payload.Nonce)
+	return info, nil
+}
+
+// OpenSession records an MCP session and its bearer subject as able to
+// complete the OAuth flows it starts. It returns true the first time, when
+// the caller should arrange for EndSession once the session closes.
+func (m *OAuthManager) OpenSession(owner StateOwner) bool {
+	if owner.SessionID == "" {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[owner.SessionID]; ok {
+		return false
+	}
+	m.sessions[owner.SessionID] = owner.Subject
+	return true
+}
+
+// EndSession forgets a closed MCP session and drops the states it issued.
+func (m *OAuthManager) EndSession(sessionID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, sessionID)
+	for nonce, p := range m.pending {
+		if p.owner.SessionID == sessionID {
+			delete(m.pending, nonce)
+		}
+	}
+}
+
+// parseState checks the signature of a state string and decodes its payload.
+func (m *OAuthManager) parseState(state string) (statePayload, error) {
+	encoded, sig, ok := strings.Cut(state, ".")
+	if !ok || encoded == "" || sig == "" {
+		return statePayload{}, fmt.Errorf("malformed state")
+	}
+	if !hmac.Equal([]byte(sig), []byte(m.hmacSign(encoded))) {
+		return statePayload{}, fmt.Errorf("invalid state signature")
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return statePayload{}, fmt.Errorf("malformed state payload")
 	}
-	return email, true
+	var payload statePayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return statePayload{}, fmt.Errorf("malformed state payload")
+	}
+	return payload, nil
+}
+
+// checkPendingLocked checks a decoded state against the pending states.
+// Caller must hold m.mu.
+func (m *OAuthManager) checkPendingLocked(payload statePayload, now time.Time) (StateInfo, error) {
+	m.pruneLocked(now)
+
+	pending, ok := m.pending[payload.Nonce]
+	if !ok {
+		return StateInfo{}, fmt.Errorf("unknown or already used state")
+	}
+	if now.After(pending.expires) || now.Unix() > payload.Expires {
+		return StateInfo{}, fmt.Errorf("state expired")
+	}
+	owner := StateOwner{SessionID: payload.SessionID, Subject: payload.Subject}
+	if pending.email != payload.Email || pending.owner != owner {
+		return StateInfo{}, fmt.Errorf("state does not match the initiating request")
+	}
+	return StateInfo{Email: payload.Email, Owner: owner}, nil
+}
+
+// signState creates a signed, single-use state string "base64(payload).hex-signature"
+// and records it as pending.
+func (m *OAuthManager) signState(email string, owner StateOwner) (string, error) {
+	nonceBytes := make([]byte, 16)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return "", fmt.Errorf("generating state nonce: %w", err)
+	}
+	now := m.now()
+	payload := statePayload{
+		Email:     email,
+		SessionID: owner.SessionID,
+		Subject:   owner.Subject,
+		Nonce:     hex.EncodeToString(nonceBytes),
+		Expires:   now.Add(stateTTL).Unix(),
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("marshaling state: %w", err)
+	}
+
+	m.mu.Lock()
+	m.pruneLocked(now)
+	m.pending[payload.Nonce] = pendingState{email: email, owner: owner, expires: now.Add(stateTTL)}
+	m.mu.Unlock()
+
+	encoded := base64.RawURLEncoding.EncodeToString(raw)
+	return encoded + "." + m.hmacSign(encoded), nil
 }
 
-// signState creates an HMAC-signed state string: "email:hex-signature"
-func (m *OAuthManager) signState(email string) string {
-	return email + ":" + m.hmacSign(email)
+// pruneLocked drops expired pending states. Caller must hold m.mu.
+func (m *OAuthManager) pruneLocked(now time.Time) {
+	for nonce, p := range m.pending {
+		if now.After(p.expires) {
+			delete(m.pending, nonce)
+		}
+	}
 }
 
 // hmacSign returns the hex-encoded HMAC-SHA256 of the given data.
@@ -1,9 +1,33 @@
 package auth
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 )
 
+// mustSignState issues a state for tests, failing the test on error.
+func mustSignState(t *testing.T, mgr *OAuthManager, email string) string {
+	t.Helper()
+	state, err := mgr.signState(email, StateOwner{})
+	if err != nil {
+		t.Fatalf("signState: %v", err)
+	}
+	return state
+}
+
+// mustSignStateFor issues a state for alice@example.com bound to owner.
+func mustSignStateFor(t *testing.T, mgr *OAuthManager, owner StateOwner) string {
+	t.Helper()
+	state, err := mgr.signState("alice@example.com", owner)
+	if err != nil {
+		t.Fatalf("signState: %v", err)
+	}
+	return state
+}
+
 func TestSignAndVerifyState(t *testing.T) {
 	mgr := NewOAuthManager("client-id", "client-secret", "http://localhost/callback", []string{"scope"}, nil)
 
@@ -23,7 +47,7 @@ func TestSignAndVerifyState(t *testing.T) {
 				t.Fatal("expected non-empty auth URL")
 			}
 
-			state := mgr.signState(tt.email)
+			state := mustSignState(t, mgr, tt.email)
 			email, ok := mgr.VerifyAndExtractEmail(state)
 			if !ok {
 				t.Fatal("expected valid state verification")
@@ -38,14 +62,23 @@ func TestSignAndVerifyState(t *testing.T) {
 func TestVerifyAndExtractEmail_Invalid(t *testing.T) {
 	mgr := NewOAuthManager("client-id", "client-secret", "http://localhost/callback", []string{"scope"}, nil)
 
+	valid := mustSignState(t, mgr, "user@example.com")
+	encoded, _, _ := strings.Cut(valid, ".")
+
+	// Forge a payload for a different email, signed with a wrong key.
+	forged, _ := json.Marshal(statePayload{Email: "evil@attacker.com", Nonce: "abc", Expires: time.Now().Add(time.Hour).Unix()})
+	forgedEncoded := base64.RawURLEncoding.EncodeToString(forged)
+
 	tests := []struct {
 		name  string
 		state string
 	}{
 		{"empty string", ""},
-		{"no colon", "nocolonhere"},
-		{"wrong signature", "user@example.com:deadbeef"},
-		{"tampered email", "evil@attacker.com:" + mgr.hmacSign("user@example.com")},
+		{"no separator", "noseparatorhere"},
+		{"legacy email:sig format", "user@example.com:" + mgr.hmacSign("user@example.com")},
+		{"wrong signature", encoded + ".deadbeef"},
+		{"tampered payload", forgedEncoded + "." + strings.SplitN(valid, ".", 2)[1]},
+		{"validly signed but never issued", forgedEncoded + "." + mgr.hmacSign(forgedEncoded)},
 	}
 
 	for _, tt := range tests {
@@ -58,11 +91,112 @@ func TestVerifyAndExtractEmail_Invalid(t *testing.T) {
 	}
 }
 
+func TestConsumeState_RejectsReplay(t *testing.T) {
+	mgr := NewOAuthManager("client-id", "client-secret", "http://localhost/callback", nil, nil)
+
+	state := mustSignState(t, mgr, "user@example.com")
+
+	info, err := mgr.ConsumeState(state, StateOwner{})
+	if err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if info.Email != "user@example.com" {
+		t.Errorf("unexpected state info: %+v", info)
+	}
+
+	if _, err := mgr.ConsumeState(state, StateOwner{}); err == nil {
+		t.Error("expected replayed state to be rejected")
+	}
+}
+
+func TestConsumeState_RejectsExpired(t *testing.T) {
+	mgr := NewOAuthManager("client-id", "client-secret", "http://localhost/callback", nil, nil)
+
+	issued := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mgr.now = func() time.Time { return issued }
+	state := mustSignState(t, mgr, "user@example.com")
+
+	mgr.now = func() time.Time { return issued.Add(stateTTL + time.Second) }
+	if _, err := mgr.ConsumeState(state, StateOwner{}); err == nil {
+		t.Error("expected expired state to be rejected")
+	}
+}
+
+func TestConsumeState_RequiresOwner(t *testing.T) {
+	mgr := NewOAuthManager("client-id", "client-secret", "http://localhost/callback", nil, nil)
+	sessionA := StateOwner{SessionID: "session-a", Subject: "alice@example.com"}
+
+	tests := []struct {
+		name      string
+		completer StateOwner
+		wantErr   bool
+	}{
+		{"issuing session", sessionA, false},
+		{"other session", StateOwner{SessionID: "session-b", Subject: "alice@example.com"}, true},
+		{"other subject", StateOwner{SessionID: "session-a", Subject: "bob@example.com"}, true},
+		{"no session", StateOwner{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := mustSignStateFor(t, mgr, sessionA)
+			info, err := mgr.ConsumeState(state, tt.completer)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConsumeState: got err %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && info.Owner != sessionA {
+				t.Errorf("owner = %+v, want %+v", info.Owner, sessionA)
+			}
+		})
+	}
+}
+
+func TestCompleteState_RequiresOpenSession(t *testing.T) {
+	mgr := NewOAuthManager("client-id", "client-secret", "http://localhost/callback", nil, nil)
+	sessionA := StateOwner{SessionID: "session-a", Subject: "alice@example.com"}
+	sessionB := StateOwner{SessionID: "session-b", Subject: "bob@example.com"}
+	mgr.OpenSession(sessionA)
+	mgr.OpenSession(sessionB)
+
+	if _, err := mgr.CompleteState(mustSignStateFor(t, mgr, sessionA)); err != nil {
+		t.Errorf("open session: %v", err)
+	}
+
+	state := mustSignStateFor(t, mgr, sessionA)
+	mgr.EndSession(sessionA.SessionID)
+	// Session B is still open, but the state belongs to A.
+	if _, err := mgr.CompleteState(state); err == nil {
+		t.Error("expected a state from an ended session to be rejected")
+	}
+
+	// Stdio flows have no session and complete as before.
+	if _, err := mgr.CompleteState(mustSignStateFor(t, mgr, StateOwner{})); err != nil {
+		t.Errorf("stdio: %v", err)
+	}
+}
+
+func TestVerifyState_DoesNotConsume(t *testing.T) {
+	mgr := NewOAuthManager("client-id", "client-secret", "http://localhost/callback", nil, nil)
+
+	state := mustSignState(t, mgr, "user@example.com")
+
+	for i := range 2 {
+		if _, err := mgr.VerifyState(state); err != nil {
+			t.Fatalf("verify %d: %v", i+1, err)
+		}
+	}
+	if _, err := mgr.ConsumeState(state, StateOwner{}); err != nil {
+		t.Fatalf("consume after verify: %v", err)
+	}
+	if _, err := mgr.VerifyState(state); err == nil {
+		t.Error("expected consumed state to fail verification")
+	}
+}
+
 func TestDifferentSecrets(t *testing.T) {
 	mgr1 := NewOAuthManager("id", "secret1", "http://localhost/callback", nil, nil)
 	mgr2 := NewOAuthManager("id", "secret2", "http://localhost/callback", nil, nil)
 
-	state := mgr1.signState("user@example.com")
+	state := mustSignState(t, mgr1, "user@example.com")
 
 	// Same manager should verify
 	if _, ok := mgr1.VerifyAndExtractEmail(state); !ok {
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// googleRevokeURL is Google's OAuth 2.0 token revocation endpoint.
+const googleRevokeURL = "https://oauth2.googleapis.com/revoke"
+
+// revokeTimeout bounds a single revocation request to Google.
+const revokeTimeout = 10 * time.Second
+
+// revokeClient posts revocations; its timeout also covers reading the reply.
+var revokeClient = &http.Client{Timeout: revokeTimeout}
+
+// Revocation reasons recorded in the audit log.
+const (
+	RevokeReasonRequested = "requested"
+	RevokeReasonExpired   = "ttl_expired"
+)
+
+// RevokeCredentials revokes the user's token at Google and removes it from the
+// token store. Revoking the refresh token also invalidates every access token
+// issued from it. A token Google no longer recognizes is treated as already
+// revoked, so stale credentials can still be cleaned up. Every call emits an
+// audit log entry.
+func (m *OAuthManager) RevokeCredentials(ctx context.Context, userEmail, reason string) error {
+	token, err := m.tokenStore.Load(userEmail)
+	if err != nil {
+		return err
+	}
+
+	value := token.RefreshToken
+	if value == "" {
+		value = token.AccessToken
+	}
+	revokeErr := m.revokeAtGoogle(ctx, value)
+	if revokeErr != nil {
+		auditRevocation(userEmail, reason, false, revokeErr)
+		return fmt.Errorf("revoking token at Google for %s: %w", userEmail, revokeErr)
+	}
+
+	if err := m.tokenStore.Delete(userEmail); err != nil {
+		auditRevocation(userEmail, reason, true, err)
+		return fmt.Errorf("token revoked at Google but removing it from the store failed for %s: %w", userEmail, err)
+	}
+
+	auditRevocation(userEmail, reason, true, nil)
+	return nil
+}
+
+// revokeAtGoogle posts the token to Google's revocation endpoint.
+func (m *OAuthManager) revokeAtGoogle(ctx context.Context, token string) error {
+	if token == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, revokeTimeout)
+	defer cancel()
+
+	form := url.Values{"token": {token}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.revokeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("building revoke request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := revokeClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode == http.StatusBadRequest && strings.Contains(string(body), "invalid_token"):
+		// Already revoked or expired — nothing left to invalidate at Google.
+		return nil
+	default:
+		return fmt.Errorf("google returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
+
+// auditRevocation writes a structured audit record for a revocation attempt.
+// The token value is never logged.
+func auditRevocation(userEmail, reason string, revokedAtGoogle bool, err error) {
+	attrs := []any{
+		"audit", true,
+		"event", "credentials_revoked",
+		"user_google_email", userEmail,
+		"reason", reason,
+		"revoked_at_google", revokedAtGoogle,
+	}
+	if err != nil {
+		slog.Error("credential revocation failed", append(attrs, "error", err)...)
+		return
+	}
+	slog.Info("credentials revoked", attrs...)
+}
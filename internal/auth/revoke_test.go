@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// newRevokeServer fakes Google's revocation endpoint and records revoked tokens.
+func newRevokeServer(t *testing.T, status int, body string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var revoked []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(data))
+		revoked = append(revoked, form.Get("token"))
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &revoked
+}
+
+func TestRevokeCredentials(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantErr    bool
+		wantStored bool
+	}{
+		{"revoked", http.StatusOK, "", false, false},
+		{"already invalid at Google", http.StatusBadRequest, `{"error":"invalid_token"}`, false, false},
+		{"Google error keeps token", http.StatusInternalServerError, "boom", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, revoked := newRevokeServer(t, tt.status, tt.body)
+			store := NewInMemoryTokenStore()
+			mgr := NewOAuthManager("id", "secret", "http://localhost/callback", nil, store)
+			mgr.revokeURL = srv.URL
+
+			email := "user@example.com"
+			_ = store.Save(email, &oauth2.Token{AccessToken: "access", RefreshToken: "refresh"})
+
+			err := mgr.RevokeCredentials(context.Background(), email, RevokeReasonRequested)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(*revoked) != 1 || (*revoked)[0] != "refresh" {
+				t.Errorf("expected the refresh token to be revoked, got %v", *revoked)
+			}
+			_, loadErr := store.Load(email)
+			if stored := loadErr == nil; stored != tt.wantStored {
+				t.Errorf("token stored = %v, want %v", stored, tt.wantStored)
+			}
+		})
+	}
+}
+
+func TestTokenSweeper_RevokesExpired(t *testing.T) {
+	srv, revoked := newRevokeServer(t, http.StatusOK, "")
+	store := NewInMemoryTokenStore()
+	mgr := NewOAuthManager("id", "secret", "http://localhost/callback", nil, store)
+	mgr.revokeURL = srv.URL
+
+	_ = store.Save("old@example.com", &oauth2.Token{RefreshToken: "old"})
+	_ = store.Save("fresh@example.com", &oauth2.Token{RefreshToken: "fresh"})
+	store.savedAt["old@example.com"] = time.Now().Add(-48 * time.Hour)
+
+	var invalidated []string
+	sweeper, err := NewTokenSweeper(mgr, 24*time.Hour, time.Hour, func(email string) {
+		invalidated = append(invalidated, email)
+	})
+	if err != nil {
+		t.Fatalf("NewTokenSweeper: %v", err)
+	}
+
+	n, err := sweeper.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+	if n != 1 || len(*revoked) != 1 || (*revoked)[0] != "old" {
+		t.Errorf("expected only the stale token to be revoked, got n=%d revoked=%v", n, *revoked)
+	}
+	if len(invalidated) != 1 || invalidated[0] != "old@example.com" {
+		t.Errorf("expected onRevoke for old@example.com, got %v", invalidated)
+	}
+	if _, err := store.Load("fresh@example.com"); err != nil {
+		t.Errorf("fresh token should remain: %v", err)
+	}
+}
+
+func TestNewTokenSweeper_RequiresListableStore(t *testing.T) {
+	mgr := NewOAuthManager("id", "secret", "http://localhost/callback", nil, &KeyringTokenStore{service: "test"})
+	if _, err := NewTokenSweeper(mgr, time.Hour, time.Hour, nil); err == nil {
+		t.Error("expected error for a store that cannot list credentials")
+	}
+}
@@ -15,9 +15,12 @@ var ServiceScopes = map[string][]string{
 		"https://www.googleapis.com/auth/gmail.send",
 		"https://www.googleapis.com/auth/gmail.labels",
 		"https://www.googleapis.com/auth/gmail.settings.basic",
+		"https://www.googleapis.com/auth/gmail.settings.sharing",
 	},
 	"drive": {
 		"https://www.googleapis.com/auth/drive",
+		"https://www.googleapis.com/auth/drive.activity.readonly",
+		"https://www.googleapis.com/auth/drive.labels.readonly",
 	},
 	"calendar": {
 		"https://www.googleapis.com/auth/calendar",
@@ -42,8 +45,21 @@ var ServiceScopes = map[string][]string{
 	"tasks": {
 		"https://www.googleapis.com/auth/tasks",
 	},
+	"keep": {
+		"https://www.googleapis.com/auth/keep",
+	},
+	"classroom": {
+		"https://www.googleapis.com/auth/classroom.courses.readonly",
+		"https://www.googleapis.com/auth/classroom.coursework.students",
+		"https://www.googleapis.com/auth/classroom.announcements",
+	},
+	"meet": {
+		"https://www.googleapis.com/auth/meetings.space.created",
+		"https://www.googleapis.com/auth/meetings.space.readonly",
+	},
 	"contacts": {
 		"https://www.googleapis.com/auth/contacts",
+		"https://www.googleapis.com/auth/directory.readonly",
 	},
 	"search": {
 		"https://www.googleapis.com/auth/cse",
@@ -65,6 +81,8 @@ var ReadOnlyScopes = map[string][]string{
 	},
 	"drive": {
 		"https://www.googleapis.com/auth/drive.readonly",
+		"https://www.googleapis.com/auth/drive.activity.readonly",
+		"https://www.googleapis.com/auth/drive.labels.readonly",
 	},
 	"calendar": {
 		"https://www.googleapis.com/auth/calendar.readonly",
@@ -89,8 +107,20 @@ var ReadOnlyScopes = map[string][]string{
 	"tasks": {
 		"https://www.googleapis.com/auth/tasks.readonly",
 	},
+	"keep": {
+		"https://www.googleapis.com/auth/keep.readonly",
+	},
+	"classroom": {
+		"https://www.googleapis.com/auth/classroom.courses.readonly",
+		"https://www.googleapis.com/auth/classroom.coursework.students.readonly",
+		"https://www.googleapis.com/auth/classroom.announcements.readonly",
+	},
+	"meet": {
+		"https://www.googleapis.com/auth/meetings.space.readonly",
+	},
 	"contacts": {
 		"https://www.googleapis.com/auth/contacts.readonly",
+		"https://www.googleapis.com/auth/directory.readonly",
 	},
 	"search": {
 		"https://www.googleapis.com/auth/cse",
@@ -144,3 +174,49 @@ func AllScopes(services []string, readOnly bool) []string {
 
 	return scopes
 }
+
+// adminScopes and adminReadOnlyScopes are the Admin SDK scopes. They are
+// kept out of ServiceScopes so AllScopes never asks ordinary users for
+// organization-wide access; they are requested only when admin tools are
+// enabled (see AdminScopes).
+var (
+	adminScopes = []string{
+		"https://www.googleapis.com/auth/admin.directory.user",
+		"https://www.googleapis.com/auth/admin.directory.group",
+		"https://www.googleapis.com/auth/admin.reports.audit.readonly",
+	}
+	adminReadOnlyScopes = []string{
+		"https://www.googleapis.com/auth/admin.directory.user.readonly",
+		"https://www.googleapis.com/auth/admin.directory.group.readonly",
+		"https://www.googleapis.com/auth/admin.reports.audit.readonly",
+	}
+)
+
+// AdminScopes returns the scopes of the opt-in admin service.
+func AdminScopes(readOnly bool) []string {
+	if readOnly {
+		return adminReadOnlyScopes
+	}
+	return adminScopes
+}
+
+// CloudSearchScope lets search_workspace query Cloud Search. Only tenants
+// licensed for Cloud Search can use it, so it is requested only when a
+// search application is configured.
+const CloudSearchScope = "https://www.googleapis.com/auth/cloud_search.query"
+
+// GmailFullScope lets delete_gmail_message_permanently bypass Trash. It
+// grants complete mailbox access, so it is requested only when permanent
+// deletion is enabled.
+const GmailFullScope = "https://mail.google.com/"
+
+// ScopesFor returns the scopes of one service, including the admin service.
+func ScopesFor(service string, readOnly bool) []string {
+	if service == "admin" {
+		return AdminScopes(readOnly)
+	}
+	if readOnly {
+		return ReadOnlyScopes[service]
+	}
+	return ServiceScopes[service]
+}
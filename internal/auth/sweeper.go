@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// TokenSweeper periodically revokes credentials that have not been
+// authorized or refreshed within the TTL. Because refreshed tokens are saved
+// back to the store, the last-saved time tracks recent use.
+type TokenSweeper struct {
+	mgr      *OAuthManager
+	store    ListableTokenStore
+	ttl      time.Duration
+	interval time.Duration
+	onRevoke func(userEmail string)
+	now      func() time.Time
+}
+
+// NewTokenSweeper creates a sweeper for the manager's token store. It fails
+// when the store cannot enumerate its users (e.g. the OS keyring). onRevoke,
+// if non-nil, is called after each successful revocation so callers can drop
+// cached clients.
+func NewTokenSweeper(mgr *OAuthManager, ttl, interval time.Duration, onRevoke func(string)) (*TokenSweeper, error) {
+	store, ok := mgr.TokenStore().(ListableTokenStore)
+	if !ok {
+		return nil, fmt.Errorf("token store %T cannot list stored credentials", mgr.TokenStore())
+	}
+	if ttl <= 0 || interval <= 0 {
+		return nil, fmt.Errorf("token TTL and sweep interval must be positive")
+	}
+	return &TokenSweeper{
+		mgr:      mgr,
+		store:    store,
+		ttl:      ttl,
+		interval: interval,
+		onRevoke: onRevoke,
+		now:      time.Now,
+	}, nil
+}
+
+// Run sweeps immediately and then every interval until ctx is cancelled.
+func (s *TokenSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.Sweep(ctx); err != nil {
+			slog.Warn("token sweep failed", "error", err)
+		} else if n > 0 {
+			slog.Info("token sweep revoked expired credentials", "count", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep revokes every credential last saved more than ttl ago and returns how
+// many were revoked. Individual failures are logged and do not stop the sweep.
+func (s *TokenSweeper) Sweep(ctx context.Context) (int, error) {
+	entries, err := s.store.List()
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := s.now().Add(-s.ttl)
+	revoked := 0
+	for _, e := range entries {
+		if e.SavedAt.IsZero() || e.SavedAt.After(cutoff) {
+			continue
+		}
+		if err := s.mgr.RevokeCredentials(ctx, e.Email, RevokeReasonExpired); err != nil {
+			continue // already audited
+		}
+		if s.onRevoke != nil {
+			s.onRevoke(e.Email)
+		}
+		revoked++
+	}
+	return revoked, nil
+}
@@ -0,0 +1,345 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// vaultRequestTimeout bounds each individual Vault HTTP call.
+const vaultRequestTimeout = 10 * time.Second
+
+// vaultMinRenewInterval prevents tight renewal loops for very short TTLs.
+const vaultMinRenewInterval = 30 * time.Second
+
+// VaultConfig configures the HashiCorp Vault KV v2 token store.
+type VaultConfig struct {
+	Address   string // VAULT_ADDR, e.g. https://vault.example.com:8200
+	Token     string // VAULT_TOKEN used to authenticate to Vault
+	Namespace string // VAULT_NAMESPACE (Vault Enterprise), optional
+	Mount     string // KV v2 mount point (default "secret")
+	Path      string // Path prefix under the mount (default "google-workspace-mcp")
+
+	// HTTPClient is used for Vault requests. Defaults to a client with a
+	// per-request timeout when nil.
+	HTTPClient *http.Client
+}
+
+// VaultTokenStore stores OAuth tokens in a HashiCorp Vault KV v2 secrets engine.
+// Each user's token is written to <mount>/data/<path>/<sha256(email)>, so emails
+// never appear in secret paths and cannot be used for path traversal.
+type VaultTokenStore struct {
+	cfg    VaultConfig
+	client *http.Client
+}
+
+// NewVaultTokenStore creates a Vault-backed token store. It verifies the Vault
+// token with a lookup-self call so misconfiguration fails at startup.
+func NewVaultTokenStore(cfg VaultConfig) (*VaultTokenStore, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("vault address is required (set VAULT_ADDR)")
+	}
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("vault token is required (set VAULT_TOKEN)")
+	}
+	cfg.Address = strings.TrimRight(cfg.Address, "/")
+	if cfg.Mount == "" {
+		cfg.Mount = "secret"
+	}
+	if cfg.Path == "" {
+		cfg.Path = "google-workspace-mcp"
+	}
+	cfg.Mount = strings.Trim(cfg.Mount, "/")
+	cfg.Path = strings.Trim(cfg.Path, "/")
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: vaultRequestTimeout}
+	}
+
+	s := &VaultTokenStore{cfg: cfg, client: client}
+	if _, err := s.lookupSelf(context.Background()); err != nil {
+		return nil, fmt.Errorf("verifying vault token: %w", err)
+	}
+	return s, nil
+}
+
+// vaultSecret is the subset of the KV v2 read response we use.
+type vaultSecret struct {
+	Data struct {
+		Data struct {
+			Token   *oauth2.Token `json:"token"`
+			Email   string        `json:"email"`
+			SavedAt time.Time     `json:"saved_at"`
+		} `json:"data"`
+	} `json:"data"`
+}
+
+// Save writes a token for the given user email to Vault.
+func (s *VaultTokenStore) Save(userEmail string, token *oauth2.Token) error {
+	body, err := json.Marshal(map[string]any{
+		"data": map[string]any{"token": token, "email": userEmail, "saved_at": time.Now().UTC()},
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling token: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), vaultRequestTimeout)
+	defer cancel()
+
+	resp, err := s.do(ctx, http.MethodPost, s.secretPath(userEmail), body)
+	if err != nil {
+		return fmt.Errorf("writing token to vault for %s: %w", userEmail, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("writing token to vault for %s: %s", userEmail, vaultErrorDetail(resp))
+	}
+	return nil
+}
+
+// Load reads a token for the given user email from Vault.
+func (s *VaultTokenStore) Load(userEmail string) (*oauth2.Token, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), vaultRequestTimeout)
+	defer cancel()
+
+	resp, err := s.do(ctx, http.MethodGet, s.secretPath(userEmail), nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading token from vault for %s: %w", userEmail, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("no credentials found for %s — call start_google_auth to authenticate", userEmail)
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("reading token from vault for %s: %s", userEmail, vaultErrorDetail(resp))
+	}
+
+	var secret vaultSecret
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return nil, fmt.Errorf("parsing vault secret for %s: %w", userEmail, err)
+	}
+	if secret.Data.Data.Token == nil {
+		return nil, fmt.Errorf("no credentials found for %s — call start_google_auth to authenticate", userEmail)
+	}
+	return secret.Data.Data.Token, nil
+}
+
+// Delete permanently removes every version of the user's token from Vault.
+func (s *VaultTokenStore) Delete(userEmail string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), vaultRequestTimeout)
+	defer cancel()
+
+	resp, err := s.do(ctx, http.MethodDelete, s.metadataPath(userEmail), nil)
+	if err != nil {
+		return fmt.Errorf("deleting token from vault for %s: %w", userEmail, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("deleting token from vault for %s: %s", userEmail, vaultErrorDetail(resp))
+	}
+	return nil
+}
+
+// List enumerates stored tokens by listing the path prefix and reading each
+// secret's email and saved_at fields. Secrets written without them are skipped.
+func (s *VaultTokenStore) List() ([]TokenEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), vaultRequestTimeout)
+	defer cancel()
+
+	resp, err := s.do(ctx, http.MethodGet, fmt.Sprintf("%s/metadata/%s?list=true", s.cfg.Mount, s.cfg.Path), nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing vault tokens: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("listing vault tokens: %s", vaultErrorDetail(resp))
+	}
+
+	var list struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("parsing vault list response: %w", err)
+	}
+
+	var entries []TokenEntry
+	for _, key := range list.Data.Keys {
+		secret, err := s.read(ctx, fmt.Sprintf("%s/data/%s/%s", s.cfg.Mount, s.cfg.Path, key))
+		if err != nil {
+			return nil, err
+		}
+		if secret == nil || secret.Data.Data.Email == "" {
+			continue
+		}
+		entries = append(entries, TokenEntry{Email: secret.Data.Data.Email, SavedAt: secret.Data.Data.SavedAt})
+	}
+	return entries, nil
+}
+
+// read fetches a KV v2 secret, returning nil when it does not exist.
+func (s *VaultTokenStore) read(ctx context.Context, path string) (*vaultSecret, error) {
+	resp, err := s.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading vault secret: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("reading vault secret: %s", vaultErrorDetail(resp))
+	}
+	var secret vaultSecret
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return nil, fmt.Errorf("parsing vault secret: %w", err)
+	}
+	return &secret, nil
+}
+
+// StartRenewal keeps the Vault token alive by calling renew-self at half its
+// remaining TTL. It returns immediately for non-renewable or root tokens and
+// otherwise blocks until ctx is cancelled, so callers run it in a goroutine.
+func (s *VaultTokenStore) StartRenewal(ctx context.Context) {
+	info, err := s.lookupSelf(ctx)
+	if err != nil {
+		slog.Warn("vault token lookup failed — token renewal disabled", "error", err)
+		return
+	}
+	if !info.Renewable || info.TTL == 0 {
+		slog.Info("vault token is not renewable or has no TTL — renewal disabled")
+		return
+	}
+
+	ttl := time.Duration(info.TTL) * time.Second
+	for {
+		wait := max(ttl/2, vaultMinRenewInterval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		renewed, err := s.renewSelf(ctx)
+		if err != nil {
+			slog.Warn("vault token renewal failed — will retry", "error", err)
+			ttl = vaultMinRenewInterval * 2
+			continue
+		}
+		ttl = time.Duration(renewed) * time.Second
+		slog.Debug("renewed vault token", "ttl", ttl)
+	}
+}
+
+// vaultTokenInfo is the subset of the lookup-self response we use.
+type vaultTokenInfo struct {
+	TTL       int  `json:"ttl"`
+	Renewable bool `json:"renewable"`
+}
+
+func (s *VaultTokenStore) lookupSelf(ctx context.Context) (*vaultTokenInfo, error) {
+	resp, err := s.do(ctx, http.MethodGet, "auth/token/lookup-self", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("token lookup: %s", vaultErrorDetail(resp))
+	}
+
+	var out struct {
+		Data vaultTokenInfo `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("parsing token lookup response: %w", err)
+	}
+	return &out.Data, nil
+}
+
+// renewSelf renews the Vault token and returns its new lease duration in seconds.
+func (s *VaultTokenStore) renewSelf(ctx context.Context) (int, error) {
+	resp, err := s.do(ctx, http.MethodPost, "auth/token/renew-self", []byte("{}"))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return 0, fmt.Errorf("token renewal: %s", vaultErrorDetail(resp))
+	}
+
+	var out struct {
+		Auth struct {
+			LeaseDuration int `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return 0, fmt.Errorf("parsing token renewal response: %w", err)
+	}
+	return out.Auth.LeaseDuration, nil
+}
+
+// secretPath returns the KV v2 data path for a user's token.
+func (s *VaultTokenStore) secretPath(userEmail string) string {
+	return fmt.Sprintf("%s/data/%s/%s", s.cfg.Mount, s.cfg.Path, secretKey(userEmail))
+}
+
+// metadataPath returns the KV v2 metadata path for a user's token.
+func (s *VaultTokenStore) metadataPath(userEmail string) string {
+	return fmt.Sprintf("%s/metadata/%s/%s", s.cfg.Mount, s.cfg.Path, secretKey(userEmail))
+}
+
+// secretKey hashes the email so it never appears in secret paths.
+func secretKey(userEmail string) string {
+	hash := sha256.Sum256([]byte(userEmail))
+	return hex.EncodeToString(hash[:])
+}
+
+// do sends an authenticated request to the Vault HTTP API.
+func (s *VaultTokenStore) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.cfg.Address+"/v1/"+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("building vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", s.cfg.Token)
+	if s.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.cfg.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return s.client.Do(req)
+}
+
+// vaultErrorDetail summarizes a non-2xx Vault response.
+func vaultErrorDetail(resp *http.Response) string {
+	var out struct {
+		Errors []string `json:"errors"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if json.Unmarshal(data, &out) == nil && len(out.Errors) > 0 {
+		return fmt.Sprintf("vault returned %d: %s", resp.StatusCode, strings.Join(out.Errors, "; "))
+	}
+	return fmt.Sprintf("vault returned %d", resp.StatusCode)
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// fakeVault is a minimal in-memory KV v2 server for testing VaultTokenStore.
+type fakeVault struct {
+	mu      sync.Mutex
+	token   string
+	secrets map[string]json.RawMessage
+}
+
+func newFakeVault(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	fv := &fakeVault{token: token, secrets: make(map[string]json.RawMessage)}
+	srv := httptest.NewServer(fv)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (fv *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != fv.token {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `{"errors":["permission denied"]}`)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	if path == "auth/token/lookup-self" {
+		_, _ = io.WriteString(w, `{"data":{"ttl":0,"renewable":false}}`)
+		return
+	}
+
+	fv.mu.Lock()
+	defer fv.mu.Unlock()
+	if r.URL.Query().Get("list") == "true" {
+		var keys []string
+		prefix := strings.Replace(path, "/metadata/", "/data/", 1) + "/"
+		for p := range fv.secrets {
+			if strings.HasPrefix(p, prefix) {
+				keys = append(keys, strings.TrimPrefix(p, prefix))
+			}
+		}
+		data, _ := json.Marshal(map[string]any{"data": map[string]any{"keys": keys}})
+		_, _ = w.Write(data)
+		return
+	}
+	switch r.Method {
+	case http.MethodDelete:
+		delete(fv.secrets, strings.Replace(path, "/metadata/", "/data/", 1))
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPost:
+		var body struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fv.secrets[path] = body.Data
+		_, _ = io.WriteString(w, `{"data":{"version":1}}`)
+	case http.MethodGet:
+		data, ok := fv.secrets[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"errors":[]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"data":{"data":`+string(data)+`}}`)
+	}
+}
+
+func TestVaultTokenStore_SaveAndLoad(t *testing.T) {
+	srv := newFakeVault(t, "vault-token")
+
+	store, err := NewVaultTokenStore(VaultConfig{Address: srv.URL, Token: "vault-token"})
+	if err != nil {
+		t.Fatalf("NewVaultTokenStore: %v", err)
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  "vault-access-123",
+		RefreshToken: "vault-refresh-456",
+		TokenType:    "Bearer",
+		Expiry:       time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	email := "vault@example.com"
+
+	if err := store.Save(email, token); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := store.Load(email)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.AccessToken != token.AccessToken {
+		t.Errorf("AccessToken: got %q, want %q", loaded.AccessToken, token.AccessToken)
+	}
+	if loaded.RefreshToken != token.RefreshToken {
+		t.Errorf("RefreshToken: got %q, want %q", loaded.RefreshToken, token.RefreshToken)
+	}
+}
+
+func TestVaultTokenStore_LoadNonExistent(t *testing.T) {
+	srv := newFakeVault(t, "vault-token")
+
+	store, err := NewVaultTokenStore(VaultConfig{Address: srv.URL, Token: "vault-token"})
+	if err != nil {
+		t.Fatalf("NewVaultTokenStore: %v", err)
+	}
+
+	_, err = store.Load("nobody@example.com")
+	if err == nil {
+		t.Fatal("expected error for non-existent token")
+	}
+	if !strings.Contains(err.Error(), "start_google_auth") {
+		t.Errorf("expected actionable auth hint, got: %v", err)
+	}
+}
+
+func TestVaultTokenStore_InvalidToken(t *testing.T) {
+	srv := newFakeVault(t, "vault-token")
+
+	_, err := NewVaultTokenStore(VaultConfig{Address: srv.URL, Token: "wrong"})
+	if err == nil {
+		t.Fatal("expected error for invalid vault token")
+	}
+	if !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("expected vault error detail, got: %v", err)
+	}
+}
+
+func TestVaultTokenStore_SecretPath(t *testing.T) {
+	store := &VaultTokenStore{cfg: VaultConfig{Mount: "kv", Path: "mcp/tokens"}}
+
+	path := store.secretPath("user@example.com")
+	if !strings.HasPrefix(path, "kv/data/mcp/tokens/") {
+		t.Errorf("unexpected secret path: %s", path)
+	}
+	if strings.Contains(path, "user@example.com") {
+		t.Errorf("secret path must not contain the raw email: %s", path)
+	}
+	if path == store.secretPath("other@example.com") {
+		t.Error("expected different paths for different emails")
+	}
+}
+
+func TestVaultTokenStore_DeleteAndList(t *testing.T) {
+	srv := newFakeVault(t, "vault-token")
+
+	store, err := NewVaultTokenStore(VaultConfig{Address: srv.URL, Token: "vault-token"})
+	if err != nil {
+		t.Fatalf("NewVaultTokenStore: %v", err)
+	}
+	for _, email := range []string{"a@example.com", "b@example.com"} {
+		if err := store.Save(email, &oauth2.Token{AccessToken: "tok"}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	entries, err := store.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	if err := store.Delete("a@example.com"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Load("a@example.com"); err == nil {
+		t.Error("expected deleted token to be gone")
+	}
+	if entries, _ := store.List(); len(entries) != 1 || entries[0].Email != "b@example.com" {
+		t.Errorf("expected only b@example.com to remain, got %+v", entries)
+	}
+}
@@ -6,44 +6,290 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
-// Config holds all server configuration loaded from environment variables and CLI flags.
+// Config holds all server configuration loaded from an optional config file,
+// environment variables, and CLI flags. The yaml tags define the config file
+// schema (see configs/config.example.yaml).
 type Config struct {
 	OAuth struct {
-		ClientID     string
-		ClientSecret string
-		RedirectURL  string
-	}
+		ClientID     string `yaml:"client_id"`
+		ClientSecret string `yaml:"client_secret"`
+		RedirectURL  string `yaml:"-"`
+	} `yaml:"oauth"`
 	Server struct {
-		Transport string
-		Port      int
-		Host      string
-		BaseURI   string
-	}
-	ToolTier        string
-	EnabledServices []string
-	ReadOnly        bool
-	EnableOAuth21   bool
-	PersistentAuth  bool
-	LogLevel        string
-	CredentialsDir  string
-	CSEID           string
-}
-
-// Load reads configuration from environment variables and CLI flags.
-// CLI flags take precedence over environment variables.
+		Transport string `yaml:"transport"`
+		Port      int    `yaml:"port"`
+		Host      string `yaml:"host"`
+		BaseURI   string `yaml:"base_uri"`
+		// SocketPath is the Unix domain socket the unix transport listens
+		// on; SocketMode is its permission bits in octal (default 0660).
+		SocketPath string      `yaml:"socket_path"`
+		SocketMode string      `yaml:"socket_mode"`
+		SocketPerm os.FileMode `yaml:"-"`
+		// TLSCert and TLSKey make the HTTP transports serve HTTPS. With
+		// ClientCA set, clients must present a certificate it signed.
+		TLSCert  string `yaml:"tls_cert"`
+		TLSKey   string `yaml:"tls_key"`
+		ClientCA string `yaml:"client_ca"`
+		// ReadyzCheckGoogle makes /readyz also check that Google's OAuth
+		// endpoint is reachable.
+		ReadyzCheckGoogle bool `yaml:"readyz_check_google"`
+		// SessionStore is a file that keeps streamable HTTP sessions so
+		// clients can resume them after a restart; SessionTTL is how long
+		// an unused session stays resumable.
+		SessionStore string        `yaml:"session_store"`
+		SessionTTL   time.Duration `yaml:"session_ttl"`
+	} `yaml:"server"`
+	Vault struct {
+		Address   string `yaml:"address"`
+		Token     string `yaml:"token"`
+		Namespace string `yaml:"namespace"`
+		Mount     string `yaml:"mount"`
+		Path      string `yaml:"path"`
+	} `yaml:"vault"`
+	// HTTPAuth protects the streamable-http /mcp endpoint. When neither API
+	// keys nor an OIDC issuer are set, the endpoint is unauthenticated.
+	HTTPAuth struct {
+		APIKeys      []string `yaml:"api_keys"`
+		OIDCIssuer   string   `yaml:"oidc_issuer"`
+		OIDCAudience string   `yaml:"oidc_audience"`
+	} `yaml:"http_auth"`
+	ToolTier        string   `yaml:"tool_tier"`
+	EnabledServices []string `yaml:"enabled_services"`
+	ReadOnly        bool     `yaml:"read_only"`
+	EnableOAuth21   bool     `yaml:"enable_oauth21"`
+	PersistentAuth  bool     `yaml:"persistent_auth"`
+	TokenStore      string   `yaml:"token_store"`
+	LogLevel        string   `yaml:"log_level"`
+	CredentialsDir  string   `yaml:"credentials_dir"`
+	CSEID           string   `yaml:"cse_id"`
+
+	// CloudSearchApp is the Cloud Search search application ID used by
+	// search_workspace ("default" for the default application). Empty
+	// disables Workspace search and its scope.
+	CloudSearchApp string `yaml:"cloud_search_app"`
+
+	// LogRedactPII masks email addresses in all logs and reduces message
+	// bodies and document content in debug logs to their lengths.
+	LogRedactPII bool `yaml:"log_redact_pii"`
+
+	// TokenTTL, when non-zero, revokes credentials not authorized or refreshed
+	// within this duration; the sweep runs every TokenSweepInterval.
+	TokenTTL           time.Duration `yaml:"token_ttl"`
+	TokenSweepInterval time.Duration `yaml:"token_sweep_interval"`
+
+	// ServiceLimits caps request sizes per service (keyed by service name).
+	ServiceLimits map[string]ServiceLimits `yaml:"limits"`
+
+	// Tools restricts which tools are exposed regardless of tier. When Allow
+	// is non-empty only the listed tools are available; Deny always wins.
+	Tools struct {
+		Allow []string `yaml:"allow"`
+		Deny  []string `yaml:"deny"`
+	} `yaml:"tools"`
+
+	// StampProvenance marks files, events, and drafts created by tools with
+	// the server name, MCP session, and creation time.
+	StampProvenance bool `yaml:"stamp_provenance"`
+
+	// ResponseFormat is the default presentation of tool results: text,
+	// markdown, or json (structured output only). Calls override it with
+	// the response_format argument.
+	ResponseFormat string `yaml:"response_format"`
+
+	// AdminTools enables the admin service (Admin SDK Directory and Reports
+	// tools). It is off by default because its scopes grant
+	// organization-wide access and only Workspace administrators can use them.
+	AdminTools bool `yaml:"admin_tools"`
+
+	// GmailPermanentDelete requests the full https://mail.google.com/ scope
+	// so Gmail messages can be deleted without passing through Trash.
+	GmailPermanentDelete bool `yaml:"gmail_permanent_delete"`
+
+	// GmailPush enables watch_gmail_mailbox: Gmail publishes mailbox
+	// changes to Topic (projects/{project}/topics/{topic}), and a Pub/Sub
+	// push subscription delivers them to /gmail/push?token={Token}.
+	GmailPush struct {
+		Topic string `yaml:"topic"`
+		Token string `yaml:"token"`
+	} `yaml:"gmail_push"`
+
+	// GmailDelegationKey is the JSON key file of a service account with
+	// domain-wide delegation. The mailbox delegate tools need it because
+	// Google rejects users.settings.delegates calls made with user OAuth.
+	GmailDelegationKey string `yaml:"gmail_delegation_key"`
+
+	// DownloadDir is the directory download_drive_file writes local copies
+	// to. Empty allows downloads into Drive folders only.
+	DownloadDir string `yaml:"download_dir"`
+
+	// Sandbox serves synthetic fixture data instead of calling Google, so
+	// the server runs without OAuth credentials.
+	Sandbox bool `yaml:"sandbox"`
+
+	// RequireConfirmation asks the user, through MCP elicitation, to confirm
+	// every call of a destructive tool before it runs.
+	RequireConfirmation bool `yaml:"require_confirmation"`
+
+	// AllowedUsers, when set, restricts which user_google_email values tool
+	// calls may use. Entries are full addresses or domains.
+	AllowedUsers []string `yaml:"allowed_users"`
+
+	// UnsubscribeDomains, when set, limits which domains (and their
+	// subdomains) the Gmail unsubscribe tools may contact or email.
+	UnsubscribeDomains []string `yaml:"unsubscribe_allowed_domains"`
+
+	// AttachmentRules are the default rules of file_attachments_by_rules,
+	// mapping email attachments to Drive folders. First match wins.
+	AttachmentRules []AttachmentRule `yaml:"attachment_rules"`
+
+	// Redaction masks sensitive values in all tool output. Profiles are
+	// built-in classes ("email", "phone"); Patterns are custom regexes.
+	Redaction struct {
+		Profiles []string `yaml:"profiles"`
+		Patterns []string `yaml:"patterns"`
+	} `yaml:"redaction"`
+
+	// Retry controls automatic retries of Google API calls that fail with a
+	// transient error (429, 500, 503). limits.<service>.max_retries
+	// overrides MaxRetries per service.
+	Retry struct {
+		MaxRetries int           `yaml:"max_retries"`
+		MaxWait    time.Duration `yaml:"max_wait"`
+	} `yaml:"retry"`
+
+	// RateLimit throttles tool calls per (service, user email) with a token
+	// bucket. Zero QPS disables it; limits.<service>.qps overrides it.
+	RateLimit struct {
+		QPS   float64 `yaml:"qps"`
+		Burst int     `yaml:"burst"`
+	} `yaml:"rate_limit"`
+
+	// Concurrency bounds the tool calls one MCP session runs at once. Zero
+	// MaxPerSession disables it; calls over the limit wait up to Wait.
+	Concurrency struct {
+		MaxPerSession int           `yaml:"max_per_session"`
+		Wait          time.Duration `yaml:"wait"`
+	} `yaml:"concurrency"`
+
+	// MaxOutputKB cuts the text of tool results at this many kilobytes; the
+	// rest is read with continue_output. Zero disables the budget.
+	MaxOutputKB int `yaml:"max_output_kb"`
+
+	// Cache answers repeated calls of read-only tools from memory. A zero
+	// TTL disables it; Tools lists the cacheable tools.
+	Cache struct {
+		TTL        time.Duration `yaml:"ttl"`
+		MaxEntries int           `yaml:"max_entries"`
+		Tools      []string      `yaml:"tools"`
+	} `yaml:"cache"`
+
+	// Audit appends a record of every write tool call to a JSON Lines file
+	// and/or POSTs it to a webhook.
+	Audit struct {
+		File         string `yaml:"file"`
+		WebhookURL   string `yaml:"webhook_url"`
+		WebhookToken string `yaml:"webhook_token"`
+	} `yaml:"audit"`
+
+	// Tracing exports OpenTelemetry spans over OTLP/HTTP. Endpoint
+	// overrides OTEL_EXPORTER_OTLP_ENDPOINT; setting that variable also
+	// enables tracing.
+	Tracing struct {
+		Enabled  bool   `yaml:"enabled"`
+		Endpoint string `yaml:"endpoint"`
+	} `yaml:"tracing"`
+
+	// ToolTiers, when set, replaces configs/tool_tiers.yaml. It has the same
+	// shape as that file's services section.
+	ToolTiers map[string]ServiceTiers `yaml:"tool_tiers"`
+
+	// ConfigFile is the config file the values were loaded from, if any.
+	ConfigFile string `yaml:"-"`
+}
+
+// ServiceLimits holds per-service request limits.
+type ServiceLimits struct {
+	// MaxPageSize caps the page_size argument of the service's tools. Zero
+	// means no cap beyond each tool's own limit.
+	MaxPageSize int `yaml:"max_page_size"`
+
+	// MaxRetries overrides Retry.MaxRetries for the service; 0 disables
+	// retries. Nil keeps the global setting.
+	MaxRetries *int `yaml:"max_retries"`
+
+	// QPS and Burst override RateLimit for the service when QPS is set.
+	QPS   float64 `yaml:"qps"`
+	Burst int     `yaml:"burst"`
+}
+
+// AttachmentRule sends email attachments matching every set condition to a
+// Drive folder.
+type AttachmentRule struct {
+	Sender   string `yaml:"sender"`
+	Domain   string `yaml:"domain"`
+	Type     string `yaml:"type"` // MIME type, MIME family (image/*), or extension (.pdf)
+	FolderID string `yaml:"folder_id"`
+}
+
+// DefaultCacheTools are the read-only tools cached when the response cache is
+// enabled: listings of slow-changing settings and metadata.
+var DefaultCacheTools = []string{
+	"list_calendars",
+	"list_gmail_labels",
+	"list_gmail_filters",
+	"get_spreadsheet_info",
+	"list_task_lists",
+	"list_contact_groups",
+	"list_chat_spaces",
+	"get_search_engine_info",
+}
+
+// Load reads configuration from an optional config file (--config or
+// WORKSPACE_MCP_CONFIG), environment variables, and CLI flags. Environment
+// variables override file values, and CLI flags override both.
 func Load() (*Config, error) {
 	cfg := &Config{}
+	cfg.Server.Host = "0.0.0.0"
+	cfg.Server.BaseURI = "http://localhost"
+	cfg.Server.Transport = "stdio"
+	cfg.Server.Port = 8000
+	cfg.Server.SocketMode = "0660"
+	cfg.Server.SessionTTL = 24 * time.Hour
+	cfg.LogLevel = "info"
+	cfg.ToolTier = "complete"
+	cfg.Vault.Mount = "secret"
+	cfg.Vault.Path = "google-workspace-mcp"
+	cfg.TokenSweepInterval = time.Hour
+	cfg.Retry.MaxRetries = 3
+	cfg.Retry.MaxWait = 30 * time.Second
+	cfg.RateLimit.Burst = 10
+	cfg.Concurrency.Wait = 30 * time.Second
+	cfg.Cache.MaxEntries = 1000
+	cfg.Cache.Tools = slices.Clone(DefaultCacheTools)
+
+	cfg.ConfigFile = configFlag(os.Args[1:])
+	if cfg.ConfigFile == "" {
+		cfg.ConfigFile = os.Getenv("WORKSPACE_MCP_CONFIG")
+	}
+	if cfg.ConfigFile != "" {
+		if err := cfg.loadFile(cfg.ConfigFile); err != nil {
+			return nil, err
+		}
+	}
 
 	// Environment variables
-	cfg.OAuth.ClientID = os.Getenv("GOOGLE_OAUTH_CLIENT_ID")
-	cfg.OAuth.ClientSecret = os.Getenv("GOOGLE_OAUTH_CLIENT_SECRET")
-	cfg.CSEID = os.Getenv("GOOGLE_CSE_ID")
+	envString(&cfg.OAuth.ClientID, "GOOGLE_OAUTH_CLIENT_ID")
+	envString(&cfg.OAuth.ClientSecret, "GOOGLE_OAUTH_CLIENT_SECRET")
+	envString(&cfg.CSEID, "GOOGLE_CSE_ID")
+	envString(&cfg.CloudSearchApp, "GOOGLE_CLOUD_SEARCH_APP")
 
-	cfg.CredentialsDir = os.Getenv("WORKSPACE_MCP_CREDENTIALS_DIR")
+	envString(&cfg.CredentialsDir, "WORKSPACE_MCP_CREDENTIALS_DIR")
 	if cfg.CredentialsDir == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
@@ -54,67 +300,304 @@ func Load() (*Config, error) {
 
 	// Enabled services (comma-separated, empty = all)
 	if svcEnv := os.Getenv("ENABLED_SERVICES"); svcEnv != "" {
-		for _, s := range strings.Split(svcEnv, ",") {
-			s = strings.TrimSpace(s)
-			if s != "" {
-				cfg.EnabledServices = append(cfg.EnabledServices, s)
-			}
+		cfg.EnabledServices = splitList(svcEnv)
+	}
+
+	envString(&cfg.Server.Host, "WORKSPACE_MCP_HOST")
+	envString(&cfg.Server.BaseURI, "WORKSPACE_MCP_BASE_URI")
+	envString(&cfg.Server.Transport, "MCP_TRANSPORT")
+	envString(&cfg.Server.SocketPath, "MCP_SOCKET_PATH")
+	envString(&cfg.Server.SocketMode, "MCP_SOCKET_MODE")
+	envString(&cfg.Server.TLSCert, "MCP_TLS_CERT")
+	envString(&cfg.Server.TLSKey, "MCP_TLS_KEY")
+	envString(&cfg.Server.ClientCA, "MCP_TLS_CLIENT_CA")
+	envBool(&cfg.Server.ReadyzCheckGoogle, "MCP_READYZ_CHECK_GOOGLE")
+	envString(&cfg.Server.SessionStore, "MCP_SESSION_STORE")
+	sessionTTL, err := envDuration("MCP_SESSION_TTL", cfg.Server.SessionTTL)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Server.SessionTTL = sessionTTL
+	envString(&cfg.LogLevel, "LOG_LEVEL")
+	envString(&cfg.ToolTier, "TOOL_TIER")
+	envBool(&cfg.EnableOAuth21, "MCP_ENABLE_OAUTH21")
+	envBool(&cfg.PersistentAuth, "WORKSPACE_MCP_PERSISTENT_AUTH")
+	envBool(&cfg.ReadOnly, "WORKSPACE_MCP_READ_ONLY")
+	envBool(&cfg.StampProvenance, "WORKSPACE_MCP_STAMP_PROVENANCE")
+	envBool(&cfg.Sandbox, "WORKSPACE_MCP_SANDBOX")
+	envBool(&cfg.AdminTools, "WORKSPACE_MCP_ADMIN_TOOLS")
+	envBool(&cfg.GmailPermanentDelete, "WORKSPACE_MCP_GMAIL_PERMANENT_DELETE")
+	envString(&cfg.GmailPush.Topic, "WORKSPACE_MCP_GMAIL_PUSH_TOPIC")
+	envString(&cfg.GmailPush.Token, "WORKSPACE_MCP_GMAIL_PUSH_TOKEN")
+	envString(&cfg.GmailDelegationKey, "WORKSPACE_MCP_GMAIL_DELEGATION_KEY")
+	envString(&cfg.DownloadDir, "WORKSPACE_MCP_DOWNLOAD_DIR")
+	envBool(&cfg.LogRedactPII, "LOG_REDACT_PII")
+	envBool(&cfg.RequireConfirmation, "REQUIRE_CONFIRMATION")
+	envString(&cfg.TokenStore, "TOKEN_STORE")
+	cfg.TokenStore = strings.ToLower(cfg.TokenStore)
+	envString(&cfg.ResponseFormat, "RESPONSE_FORMAT")
+
+	// Vault token store (TOKEN_STORE=vault)
+	envString(&cfg.Vault.Address, "VAULT_ADDR")
+	envString(&cfg.Vault.Token, "VAULT_TOKEN")
+	envString(&cfg.Vault.Namespace, "VAULT_NAMESPACE")
+	envString(&cfg.Vault.Mount, "VAULT_KV_MOUNT")
+	envString(&cfg.Vault.Path, "VAULT_KV_PATH")
+
+	// Bearer authentication for the HTTP transport
+	if keys := os.Getenv("MCP_API_KEYS"); keys != "" {
+		cfg.HTTPAuth.APIKeys = splitList(keys)
+	}
+	envString(&cfg.HTTPAuth.OIDCIssuer, "MCP_OIDC_ISSUER")
+	envString(&cfg.HTTPAuth.OIDCAudience, "MCP_OIDC_AUDIENCE")
+
+	// Per-tool allow/deny lists
+	if allow := os.Getenv("TOOLS_ALLOW"); allow != "" {
+		cfg.Tools.Allow = splitList(allow)
+	}
+	if deny := os.Getenv("TOOLS_DENY"); deny != "" {
+		cfg.Tools.Deny = splitList(deny)
+	}
+
+	// Account allowlist
+	if users := os.Getenv("ALLOWED_USERS"); users != "" {
+		cfg.AllowedUsers = splitList(users)
+	}
+
+	// Gmail unsubscribe target allow-list
+	if domains := os.Getenv("UNSUBSCRIBE_ALLOWED_DOMAINS"); domains != "" {
+		cfg.UnsubscribeDomains = splitList(domains)
+	}
+
+	// Output redaction (custom patterns are config-file only)
+	if profiles := os.Getenv("REDACT_PROFILES"); profiles != "" {
+		cfg.Redaction.Profiles = splitList(profiles)
+	}
+
+	// Token TTL sweeper
+	tokenTTL, err := envDuration("TOKEN_TTL", cfg.TokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	sweepInterval, err := envDuration("TOKEN_SWEEP_INTERVAL", cfg.TokenSweepInterval)
+	if err != nil {
+		return nil, err
+	}
+	cfg.TokenTTL, cfg.TokenSweepInterval = tokenTTL, sweepInterval
+
+	// Google API retries
+	if v := os.Getenv("API_MAX_RETRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid API_MAX_RETRIES %q — must be a non-negative integer", v)
 		}
+		cfg.Retry.MaxRetries = n
+	}
+	if cfg.Retry.MaxWait, err = envDuration("API_RETRY_MAX_WAIT", cfg.Retry.MaxWait); err != nil {
+		return nil, err
 	}
 
-	cfg.Server.Host = envOrDefault("WORKSPACE_MCP_HOST", "0.0.0.0")
-	cfg.Server.BaseURI = envOrDefault("WORKSPACE_MCP_BASE_URI", "http://localhost")
-	cfg.Server.Transport = envOrDefault("MCP_TRANSPORT", "stdio")
-	cfg.LogLevel = envOrDefault("LOG_LEVEL", "info")
-	cfg.ToolTier = envOrDefault("TOOL_TIER", "complete")
-	cfg.EnableOAuth21 = envBool("MCP_ENABLE_OAUTH21")
-	cfg.PersistentAuth = envBool("WORKSPACE_MCP_PERSISTENT_AUTH")
-	cfg.ReadOnly = envBool("WORKSPACE_MCP_READ_ONLY")
+	// Per-user tool call rate limit
+	if v := os.Getenv("RATE_LIMIT_QPS"); v != "" {
+		qps, err := strconv.ParseFloat(v, 64)
+		if err != nil || qps < 0 {
+			return nil, fmt.Errorf("invalid RATE_LIMIT_QPS %q — must be a non-negative number", v)
+		}
+		cfg.RateLimit.QPS = qps
+	}
+	if v := os.Getenv("RATE_LIMIT_BURST"); v != "" {
+		burst, err := strconv.Atoi(v)
+		if err != nil || burst < 1 {
+			return nil, fmt.Errorf("invalid RATE_LIMIT_BURST %q — must be a positive integer", v)
+		}
+		cfg.RateLimit.Burst = burst
+	}
+
+	// Per-session concurrent tool call limit
+	if v := os.Getenv("MAX_CONCURRENT_TOOL_CALLS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid MAX_CONCURRENT_TOOL_CALLS %q — must be a non-negative integer", v)
+		}
+		cfg.Concurrency.MaxPerSession = n
+	}
+	if cfg.Concurrency.Wait, err = envDuration("CONCURRENT_TOOL_CALL_WAIT", cfg.Concurrency.Wait); err != nil {
+		return nil, err
+	}
+
+	// Tool output size budget
+	if v := os.Getenv("MAX_OUTPUT_KB"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid MAX_OUTPUT_KB %q — must be a non-negative integer", v)
+		}
+		cfg.MaxOutputKB = n
+	}
+
+	// Response cache for read-only tools
+	if cfg.Cache.TTL, err = envDuration("RESPONSE_CACHE_TTL", cfg.Cache.TTL); err != nil {
+		return nil, err
+	}
+	if v := os.Getenv("RESPONSE_CACHE_MAX_ENTRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid RESPONSE_CACHE_MAX_ENTRIES %q — must be a positive integer", v)
+		}
+		cfg.Cache.MaxEntries = n
+	}
+	if tools := os.Getenv("RESPONSE_CACHE_TOOLS"); tools != "" {
+		cfg.Cache.Tools = splitList(tools)
+	}
+
+	// Audit log sinks
+	envString(&cfg.Audit.File, "AUDIT_LOG_FILE")
+	envString(&cfg.Audit.WebhookURL, "AUDIT_WEBHOOK_URL")
+	envString(&cfg.Audit.WebhookToken, "AUDIT_WEBHOOK_TOKEN")
+
+	// OpenTelemetry tracing. The exporter reads the remaining OTEL_*
+	// variables itself; OTEL_SDK_DISABLED turns tracing off entirely.
+	envBool(&cfg.Tracing.Enabled, "WORKSPACE_MCP_TRACING")
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" {
+		cfg.Tracing.Enabled = true
+	}
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		cfg.Tracing.Enabled = false
+	}
 
 	// Port
 	portStr := os.Getenv("MCP_PORT")
 	if portStr == "" {
 		portStr = os.Getenv("PORT")
 	}
-	if portStr == "" {
-		portStr = "8000"
-	}
-	port, err := strconv.Atoi(portStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid port %q: %w", portStr, err)
+	if portStr != "" {
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q: %w", portStr, err)
+		}
+		cfg.Server.Port = port
 	}
-	cfg.Server.Port = port
 
 	// CLI flags override env vars
-	flag.StringVar(&cfg.Server.Transport, "transport", cfg.Server.Transport, "Transport mode: stdio or streamable-http")
+	flag.StringVar(&cfg.Server.Transport, "transport", cfg.Server.Transport, "Transport mode: stdio, streamable-http, sse, or unix")
+	flag.StringVar(&cfg.Server.SocketPath, "socket-path", cfg.Server.SocketPath, "Unix domain socket path for the unix transport")
 	var toolsFlag string
-	flag.StringVar(&toolsFlag, "tools", "", "Services to enable (comma-separated): gmail,drive,calendar,docs,sheets,chat,forms,slides,tasks,contacts,search,appscript")
+	flag.StringVar(&toolsFlag, "tools", "", "Services to enable (comma-separated): gmail,drive,calendar,docs,sheets,chat,forms,slides,tasks,keep,classroom,meet,contacts,search,appscript,admin")
 	flag.StringVar(&cfg.ToolTier, "tool-tier", cfg.ToolTier, "Load tools by tier: core, extended, or complete")
 	flag.BoolVar(&cfg.ReadOnly, "read-only", cfg.ReadOnly, "Request only read-only scopes, disable write tools")
+	flag.BoolVar(&cfg.AdminTools, "admin-tools", cfg.AdminTools, "Enable the Admin SDK Directory and Reports tools (requires a Workspace administrator)")
+	flag.BoolVar(&cfg.GmailPermanentDelete, "gmail-permanent-delete", cfg.GmailPermanentDelete, "Allow permanent Gmail deletion (requests the full https://mail.google.com/ scope)")
+	flag.StringVar(&cfg.GmailPush.Topic, "gmail-push-topic", cfg.GmailPush.Topic, "Pub/Sub topic for Gmail push notifications (projects/{project}/topics/{topic})")
+	flag.StringVar(&cfg.GmailDelegationKey, "gmail-delegation-key", cfg.GmailDelegationKey, "Service account JSON key with domain-wide delegation, for the Gmail delegate tools")
+	flag.StringVar(&cfg.DownloadDir, "download-dir", cfg.DownloadDir, "Directory download_drive_file saves local copies to")
+	flag.BoolVar(&cfg.Sandbox, "sandbox", cfg.Sandbox, "Serve synthetic demo data instead of calling Google (no credentials needed)")
+	flag.BoolVar(&cfg.LogRedactPII, "log-redact-pii", cfg.LogRedactPII, "Mask email addresses, message bodies, and document content in logs")
+	flag.BoolVar(&cfg.RequireConfirmation, "require-confirmation", cfg.RequireConfirmation, "Ask the user to confirm destructive tool calls via MCP elicitation")
+	flag.StringVar(&cfg.ResponseFormat, "response-format", cfg.ResponseFormat, "Default tool result format: text, markdown, or json")
+	flag.BoolVar(&cfg.StampProvenance, "stamp-provenance", cfg.StampProvenance, "Stamp created files, events, and drafts with provenance metadata")
 	flag.BoolVar(&cfg.PersistentAuth, "persistent-auth", cfg.PersistentAuth, "Persist OAuth tokens to disk (survives restarts)")
+	flag.StringVar(&cfg.TokenStore, "token-store", cfg.TokenStore, "Token store backend: memory, file, keyring, or vault (default: file if --persistent-auth, else memory)")
+	flag.StringVar(&cfg.ConfigFile, "config", cfg.ConfigFile, "Path to a YAML or JSON config file (env vars and flags override its values)")
 	flag.Parse()
 
 	// CLI --tools flag overrides (not appends to) the ENABLED_SERVICES env var.
 	if toolsFlag != "" {
-		cfg.EnabledServices = nil
-		for _, s := range strings.Split(toolsFlag, ",") {
-			s = strings.TrimSpace(s)
-			if s != "" {
-				cfg.EnabledServices = append(cfg.EnabledServices, s)
-			}
-		}
+		cfg.EnabledServices = splitList(toolsFlag)
 	}
 
 	// Validate tool tier
 	if TierLevel(cfg.ToolTier) == 0 {
 		return nil, fmt.Errorf("invalid TOOL_TIER %q — must be one of: core, extended, complete", cfg.ToolTier)
 	}
+	cfg.ResponseFormat = strings.ToLower(cfg.ResponseFormat)
+	if cfg.ResponseFormat != "" && !slices.Contains([]string{"text", "markdown", "json"}, cfg.ResponseFormat) {
+		return nil, fmt.Errorf("invalid RESPONSE_FORMAT %q — must be one of: text, markdown, json", cfg.ResponseFormat)
+	}
+
+	if cfg.Server.Transport == "unix" && cfg.Server.SocketPath == "" {
+		return nil, fmt.Errorf("MCP_TRANSPORT=unix requires MCP_SOCKET_PATH (or --socket-path)")
+	}
+	perm, err := strconv.ParseUint(cfg.Server.SocketMode, 8, 32)
+	if err != nil || perm > 0o777 {
+		return nil, fmt.Errorf("invalid MCP_SOCKET_MODE %q — use octal permission bits such as 0660", cfg.Server.SocketMode)
+	}
+	cfg.Server.SocketPerm = os.FileMode(perm)
+	if (cfg.Server.TLSCert == "") != (cfg.Server.TLSKey == "") {
+		return nil, fmt.Errorf("MCP_TLS_CERT and MCP_TLS_KEY must be set together")
+	}
+	if cfg.Server.ClientCA != "" && cfg.Server.TLSCert == "" {
+		return nil, fmt.Errorf("MCP_TLS_CLIENT_CA requires MCP_TLS_CERT and MCP_TLS_KEY")
+	}
+	if cfg.TLSEnabled() && cfg.Server.Transport == "unix" {
+		return nil, fmt.Errorf("TLS is not supported on the unix transport — the socket's file permissions control access")
+	}
+	if cfg.Server.SessionStore != "" && cfg.Server.Transport != "streamable-http" && cfg.Server.Transport != "unix" {
+		return nil, fmt.Errorf("MCP_SESSION_STORE requires the streamable-http or unix transport")
+	}
+	if cfg.Server.SessionTTL <= 0 {
+		return nil, fmt.Errorf("MCP_SESSION_TTL must be positive")
+	}
+
+	// Token store: an explicit TOKEN_STORE wins; otherwise the legacy
+	// persistent-auth toggle selects between file and memory.
+	if cfg.TokenStore == "" {
+		cfg.TokenStore = "memory"
+		if cfg.PersistentAuth {
+			cfg.TokenStore = "file"
+		}
+	}
+	switch cfg.TokenStore {
+	case "memory", "file", "keyring":
+	case "vault":
+		if cfg.Vault.Address == "" || cfg.Vault.Token == "" {
+			return nil, fmt.Errorf("TOKEN_STORE=vault requires VAULT_ADDR and VAULT_TOKEN")
+		}
+	default:
+		return nil, fmt.Errorf("invalid TOKEN_STORE %q — must be one of: memory, file, keyring, vault", cfg.TokenStore)
+	}
+
+	if t := cfg.GmailPush.Topic; t != "" {
+		if !validTopicName(t) {
+			return nil, fmt.Errorf("invalid WORKSPACE_MCP_GMAIL_PUSH_TOPIC %q — use projects/{project}/topics/{topic}", t)
+		}
+		if cfg.GmailPush.Token == "" {
+			return nil, fmt.Errorf("WORKSPACE_MCP_GMAIL_PUSH_TOPIC requires WORKSPACE_MCP_GMAIL_PUSH_TOKEN to authenticate push deliveries")
+		}
+		if cfg.Server.Transport == "stdio" {
+			return nil, fmt.Errorf("WORKSPACE_MCP_GMAIL_PUSH_TOPIC requires an HTTP transport to receive Pub/Sub pushes")
+		}
+	}
+
+	if cfg.HTTPAuth.OIDCIssuer != "" && cfg.HTTPAuth.OIDCAudience == "" {
+		return nil, fmt.Errorf("MCP_OIDC_ISSUER requires MCP_OIDC_AUDIENCE — without it, tokens the issuer signed for any client would be accepted")
+	}
+
+	if k := cfg.GmailDelegationKey; k != "" {
+		if info, err := os.Stat(k); err != nil || info.IsDir() {
+			return nil, fmt.Errorf("WORKSPACE_MCP_GMAIL_DELEGATION_KEY %q is not an existing file", k)
+		}
+	}
+
+	if d := cfg.DownloadDir; d != "" {
+		if info, err := os.Stat(d); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("WORKSPACE_MCP_DOWNLOAD_DIR %q is not an existing directory", d)
+		}
+	}
 
-	// Validate required fields
-	if cfg.OAuth.ClientID == "" {
+	if u := cfg.Audit.WebhookURL; u != "" {
+		if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid AUDIT_WEBHOOK_URL %q — must be an http(s) URL", u)
+		}
+	}
+
+	// Validate required fields. Sandbox mode never talks to Google, so it
+	// needs no OAuth client and keeps no tokens.
+	if cfg.Sandbox {
+		cfg.TokenStore = "memory"
+	}
+	if cfg.OAuth.ClientID == "" && !cfg.Sandbox {
 		return nil, fmt.Errorf("GOOGLE_OAUTH_CLIENT_ID environment variable is required")
 	}
-	if cfg.OAuth.ClientSecret == "" {
+	if cfg.OAuth.ClientSecret == "" && !cfg.Sandbox {
 		return nil, fmt.Errorf("GOOGLE_OAUTH_CLIENT_SECRET environment variable is required")
 	}
 
@@ -130,14 +613,86 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
-func envOrDefault(key, def string) string {
+// TLSEnabled reports whether the HTTP transports serve HTTPS.
+func (c *Config) TLSEnabled() bool {
+	return c.Server.TLSCert != ""
+}
+
+// HTTPAuthEnabled reports whether bearer authentication is configured for /mcp.
+func (c *Config) HTTPAuthEnabled() bool {
+	return len(c.HTTPAuth.APIKeys) > 0 || c.HTTPAuth.OIDCIssuer != ""
+}
+
+// CloudSearchEnabled reports whether search_workspace is configured and the
+// search service is enabled.
+func (c *Config) CloudSearchEnabled() bool {
+	return c.CloudSearchApp != "" && (len(c.EnabledServices) == 0 || slices.Contains(c.EnabledServices, "search"))
+}
+
+// GmailPermanentDeleteEnabled reports whether permanent Gmail deletion is
+// allowed: it is opted into, the server is not read-only, and the gmail
+// service is enabled.
+func (c *Config) GmailPermanentDeleteEnabled() bool {
+	return c.GmailPermanentDelete && !c.ReadOnly && (len(c.EnabledServices) == 0 || slices.Contains(c.EnabledServices, "gmail"))
+}
+
+// GmailPushEnabled reports whether Gmail push notifications are configured
+// and the gmail service is enabled.
+func (c *Config) GmailPushEnabled() bool {
+	return c.GmailPush.Topic != "" && (len(c.EnabledServices) == 0 || slices.Contains(c.EnabledServices, "gmail"))
+}
+
+// AdminEnabled reports whether the opt-in admin service is enabled: admin
+// tools are on and the service filter, if any, includes admin.
+func (c *Config) AdminEnabled() bool {
+	return c.AdminTools && (len(c.EnabledServices) == 0 || slices.Contains(c.EnabledServices, "admin"))
+}
+
+// validTopicName reports whether name has the Pub/Sub topic form
+// projects/{project}/topics/{topic}.
+func validTopicName(name string) bool {
+	parts := strings.Split(name, "/")
+	return len(parts) == 4 && parts[0] == "projects" && parts[1] != "" && parts[2] == "topics" && parts[3] != ""
+}
+
+// splitList splits a comma-separated value, trimming blanks.
+func splitList(v string) []string {
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// envString overrides *dst with the environment variable key when it is set.
+func envString(dst *string, key string) {
 	if v := os.Getenv(key); v != "" {
-		return v
+		*dst = v
 	}
-	return def
 }
 
-func envBool(key string) bool {
-	v := strings.ToLower(os.Getenv(key))
-	return v == "true" || v == "1" || v == "yes"
+// envDuration parses a Go duration (e.g. "720h") from the environment.
+func envDuration(key string, def time.Duration) (time.Duration, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s %q — use a positive Go duration like 720h", key, v)
+	}
+	return d, nil
+}
+
+// envBool overrides *dst with the environment variable key when it is set;
+// "true", "1", and "yes" enable it and any other value disables it.
+func envBool(dst *bool, key string) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return
+	}
+	v = strings.ToLower(v)
+	*dst = v == "true" || v == "1" || v == "yes"
 }
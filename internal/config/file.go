@@ -0,0 +1,81 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadFile overlays values from a YAML or JSON config file onto cfg. Keys
+// missing from the file keep their current value; unknown keys are an error
+// so typos do not go unnoticed.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file %s: %w", path, err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	for service, limits := range c.ServiceLimits {
+		if limits.MaxPageSize < 0 {
+			return fmt.Errorf("parsing config file %s: limits.%s.max_page_size must not be negative", path, service)
+		}
+		if limits.QPS < 0 || limits.Burst < 0 {
+			return fmt.Errorf("parsing config file %s: limits.%s rate limit must not be negative", path, service)
+		}
+		if limits.MaxRetries != nil && *limits.MaxRetries < 0 {
+			return fmt.Errorf("parsing config file %s: limits.%s.max_retries must not be negative", path, service)
+		}
+	}
+	if c.Retry.MaxRetries < 0 || c.Retry.MaxWait < 0 {
+		return fmt.Errorf("parsing config file %s: retry settings must not be negative", path)
+	}
+	if c.RateLimit.QPS < 0 || c.RateLimit.Burst < 0 {
+		return fmt.Errorf("parsing config file %s: rate_limit settings must not be negative", path)
+	}
+	if c.Cache.TTL < 0 || c.Cache.MaxEntries < 0 {
+		return fmt.Errorf("parsing config file %s: cache settings must not be negative", path)
+	}
+	if err := ValidateAnnotations(c.ToolTiers); err != nil {
+		return fmt.Errorf("parsing config file %s: tool_tiers: %w", path, err)
+	}
+	for i, r := range c.AttachmentRules {
+		if r.FolderID == "" {
+			return fmt.Errorf("parsing config file %s: attachment_rules[%d] has no folder_id", path, i)
+		}
+	}
+	return nil
+}
+
+// configFlag returns the value of a --config / -config flag in args. The
+// file must be read before environment variables are applied, which happens
+// before flag.Parse runs, so the flag is located by hand here and declared
+// again for flag.Parse so it appears in --help.
+func configFlag(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			return ""
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "config" {
+			continue
+		}
+		if hasValue {
+			return value
+		}
+		if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr bool
+		check   func(t *testing.T, c *Config)
+	}{
+		{
+			name: "yaml",
+			file: "config.yaml",
+			content: `
+oauth:
+  client_id: file-client
+server:
+  port: 9000
+enabled_services: [gmail, drive]
+read_only: true
+token_ttl: 720h
+limits:
+  gmail:
+    max_page_size: 5
+    max_retries: 0
+retry:
+  max_retries: 5
+  max_wait: 10s
+cache:
+  ttl: 2m
+  tools: [list_calendars]
+tool_tiers:
+  gmail:
+    core: [search_gmail_messages]
+    annotations:
+      search_gmail_messages: {idempotent: true}
+`,
+			check: func(t *testing.T, c *Config) {
+				if c.OAuth.ClientID != "file-client" || c.Server.Port != 9000 || !c.ReadOnly {
+					t.Errorf("scalar fields not loaded: %+v", c)
+				}
+				if len(c.EnabledServices) != 2 || c.TokenTTL != 720*time.Hour {
+					t.Errorf("list/duration fields not loaded: %v %v", c.EnabledServices, c.TokenTTL)
+				}
+				if gmail := c.ServiceLimits["gmail"]; gmail.MaxPageSize != 5 || gmail.MaxRetries == nil || *gmail.MaxRetries != 0 {
+					t.Errorf("limits not loaded: %+v", c.ServiceLimits)
+				}
+				if c.Retry.MaxRetries != 5 || c.Retry.MaxWait != 10*time.Second {
+					t.Errorf("retry not loaded: %+v", c.Retry)
+				}
+				if c.Cache.TTL != 2*time.Minute || len(c.Cache.Tools) != 1 || c.Cache.Tools[0] != "list_calendars" {
+					t.Errorf("cache not loaded: %+v", c.Cache)
+				}
+				if info := TierMap(c.ToolTiers)["search_gmail_messages"]; info.Tier != "core" || info.Hints.Idempotent == nil || !*info.Hints.Idempotent {
+					t.Errorf("tool tiers not loaded: %+v", c.ToolTiers)
+				}
+				if c.Server.Host != "default-host" {
+					t.Errorf("missing key must keep default, got %q", c.Server.Host)
+				}
+			},
+		},
+		{
+			name:    "json",
+			file:    "config.json",
+			content: `{"tool_tier": "core", "server": {"transport": "streamable-http"}}`,
+			check: func(t *testing.T, c *Config) {
+				if c.ToolTier != "core" || c.Server.Transport != "streamable-http" {
+					t.Errorf("json fields not loaded: %+v", c)
+				}
+			},
+		},
+		{name: "empty file", file: "empty.yaml", content: ""},
+		{name: "unknown key", file: "typo.yaml", content: "tool_teir: core\n", wantErr: true},
+		{name: "unknown tier", file: "tier.yaml", content: "tool_tiers:\n  gmail:\n    basic: [x]\n", wantErr: true},
+		{name: "annotation for unlisted tool", file: "hints.yaml", content: "tool_tiers:\n  gmail:\n    core: [a]\n    annotations:\n      b: {read_only: true}\n", wantErr: true},
+		{name: "negative limit", file: "limit.yaml", content: "limits:\n  drive:\n    max_page_size: -1\n", wantErr: true},
+		{name: "negative retries", file: "retry.yaml", content: "limits:\n  gmail:\n    max_retries: -2\n", wantErr: true},
+		{name: "bad duration", file: "ttl.yaml", content: "token_ttl: soon\n", wantErr: true},
+		{name: "negative cache ttl", file: "cache.yaml", content: "cache:\n  ttl: -1s\n", wantErr: true},
+		{name: "attachment rule without folder", file: "rules.yaml", content: "attachment_rules:\n  - domain: example.com\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{}
+			c.Server.Host = "default-host"
+			err := c.loadFile(writeConfig(t, tt.file, tt.content))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.check != nil {
+				tt.check(t, c)
+			}
+		})
+	}
+}
+
+func TestLoadFileExample(t *testing.T) {
+	c := &Config{}
+	if err := c.loadFile(filepath.Join("..", "..", "configs", "config.example.yaml")); err != nil {
+		t.Fatalf("example config does not parse: %v", err)
+	}
+}
+
+func TestConfigFlag(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--config", "a.yaml"}, "a.yaml"},
+		{[]string{"-config=b.yaml", "--read-only"}, "b.yaml"},
+		{[]string{"--transport", "stdio", "--config=c.json"}, "c.json"},
+		{[]string{"--read-only"}, ""},
+		{[]string{"--", "--config", "d.yaml"}, ""},
+		{[]string{"--config"}, ""},
+	}
+	for _, tt := range tests {
+		if got := configFlag(tt.args); got != tt.want {
+			t.Errorf("configFlag(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestEnvOverridesFile(t *testing.T) {
+	c := &Config{ReadOnly: true, ToolTier: "core"}
+
+	t.Setenv("WORKSPACE_MCP_READ_ONLY", "false")
+	t.Setenv("TOOL_TIER", "extended")
+	envBool(&c.ReadOnly, "WORKSPACE_MCP_READ_ONLY")
+	envString(&c.ToolTier, "TOOL_TIER")
+	envString(&c.LogLevel, "UNSET_TEST_VARIABLE")
+
+	if c.ReadOnly || c.ToolTier != "extended" || c.LogLevel != "" {
+		t.Errorf("env overlay: got readOnly=%v tier=%q logLevel=%q", c.ReadOnly, c.ToolTier, c.LogLevel)
+	}
+}
@@ -3,14 +3,38 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 )
 
-// ToolInfo describes a tool's tier and service.
+// ToolInfo describes a tool's tier, service, and any annotation hints the
+// tier config sets for it.
 type ToolInfo struct {
 	Tier    string
 	Service string
+	Hints   ToolHints
+}
+
+// ToolHints overrides the behaviour annotations a tool declares in code.
+// Unset hints keep the tool's own value.
+type ToolHints struct {
+	ReadOnly    *bool `yaml:"read_only,omitempty"`
+	Destructive *bool `yaml:"destructive,omitempty"`
+	Idempotent  *bool `yaml:"idempotent,omitempty"`
+	OpenWorld   *bool `yaml:"open_world,omitempty"`
+}
+
+// IsZero reports whether no hint is set.
+func (h ToolHints) IsZero() bool {
+	return h == ToolHints{}
+}
+
+// Equal reports whether h and o set the same hints to the same values.
+func (h ToolHints) Equal(o ToolHints) bool {
+	same := func(a, b *bool) bool { return (a == nil) == (b == nil) && (a == nil || *a == *b) }
+	return same(h.ReadOnly, o.ReadOnly) && same(h.Destructive, o.Destructive) &&
+		same(h.Idempotent, o.Idempotent) && same(h.OpenWorld, o.OpenWorld)
 }
 
 // TierConfig holds the tier configuration loaded from tool_tiers.yaml.
@@ -23,6 +47,8 @@ type ServiceTiers struct {
 	Core     []string `yaml:"core"`
 	Extended []string `yaml:"extended"`
 	Complete []string `yaml:"complete"`
+	// Annotations sets annotation hints for tools listed in this service.
+	Annotations map[string]ToolHints `yaml:"annotations,omitempty"`
 }
 
 // LoadTiers reads and parses the tool tiers YAML file, returning a map of
@@ -38,8 +64,29 @@ func LoadTiers(path string) (map[string]ToolInfo, error) {
 		return nil, fmt.Errorf("parsing tier config %s: %w", path, err)
 	}
 
+	if err := ValidateAnnotations(tc.Services); err != nil {
+		return nil, fmt.Errorf("parsing tier config %s: %w", path, err)
+	}
+	return TierMap(tc.Services), nil
+}
+
+// ValidateAnnotations checks that every annotated tool is listed in a tier
+// of the same service, so a misspelt name does not go unnoticed.
+func ValidateAnnotations(services map[string]ServiceTiers) error {
+	for service, tiers := range services {
+		for name := range tiers.Annotations {
+			if !slices.Contains(tiers.Core, name) && !slices.Contains(tiers.Extended, name) && !slices.Contains(tiers.Complete, name) {
+				return fmt.Errorf("%s.annotations names %q, which is not listed in any %s tier", service, name, service)
+			}
+		}
+	}
+	return nil
+}
+
+// TierMap flattens per-service tier lists into a map of tool name -> ToolInfo.
+func TierMap(services map[string]ServiceTiers) map[string]ToolInfo {
 	tools := make(map[string]ToolInfo)
-	for service, tiers := range tc.Services {
+	for service, tiers := range services {
 		for _, name := range tiers.Core {
 			tools[name] = ToolInfo{Tier: "core", Service: service}
 		}
@@ -49,9 +96,14 @@ func LoadTiers(path string) (map[string]ToolInfo, error) {
 		for _, name := range tiers.Complete {
 			tools[name] = ToolInfo{Tier: "complete", Service: service}
 		}
+		for name, hints := range tiers.Annotations {
+			if info, ok := tools[name]; ok {
+				info.Hints = hints
+				tools[name] = info
+			}
+		}
 	}
-
-	return tools, nil
+	return tools
 }
 
 // TierLevel returns the numeric level for a tier name (higher = more inclusive).
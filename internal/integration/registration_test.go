@@ -12,6 +12,7 @@ import (
 
 	"github.com/evert/google-workspace-mcp-go/internal/auth"
 	"github.com/evert/google-workspace-mcp-go/internal/config"
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
 	"github.com/evert/google-workspace-mcp-go/internal/registry"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
 )
@@ -74,7 +75,9 @@ func createTestServer(t *testing.T) *mcp.Server {
 		Version: "1.0.0-test",
 	}, nil)
 
-	registry.RegisterAll(server, factory, sharedCfg, sharedTierMap, oauthMgr)
+	filter := registry.NewTierFilter(sharedCfg, sharedTierMap)
+	server.AddReceivingMiddleware(registry.AnnotationMiddleware(filter))
+	registry.RegisterAll(server, factory, sharedCfg, sharedTierMap, filter, middleware.NewResources(tokenStore, nil), nil, oauthMgr)
 	return server
 }
 
@@ -91,7 +94,7 @@ func TestFullToolRegistration(t *testing.T) {
 		toolCount++
 	}
 
-	expectedTotal := 136
+	expectedTotal := 267
 	if toolCount != expectedTotal {
 		t.Errorf("tier config has %d tools, expected %d", toolCount, expectedTotal)
 	}
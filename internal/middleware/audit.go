@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/audit"
+)
+
+// AuditMiddleware returns MCP SDK middleware that writes an audit record for
+// every tools/call of a write tool: user, tool, service, session, input hash,
+// and result. serviceOf maps a tool name to its service (empty for tools
+// outside the tier map).
+//
+// Tools are classified by their ReadOnlyHint annotation, learned from the
+// first tools/list response. Until then every call is audited, so nothing
+// is missed by a client that calls tools without listing them.
+func AuditMiddleware(sink audit.Sink, serviceOf func(tool string) (string, bool)) mcp.Middleware {
+	var mu sync.RWMutex
+	var readOnly map[string]bool
+
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method == "tools/list" {
+				result, err := next(ctx, method, req)
+				if list, ok := result.(*mcp.ListToolsResult); ok && err == nil {
+					mu.Lock()
+					readOnly = learnReadOnly(readOnly, list.Tools)
+					mu.Unlock()
+				}
+				return result, err
+			}
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if method != "tools/call" || !ok {
+				return next(ctx, method, req)
+			}
+			mu.RLock()
+			skip := readOnly[params.Name]
+			mu.RUnlock()
+			if skip {
+				return next(ctx, method, req)
+			}
+
+			start := time.Now()
+			result, err := next(ctx, method, req)
+			rec := auditRecord(req, params, result, err)
+			rec.Time, rec.DurationMS = start.UTC(), time.Since(start).Milliseconds()
+			rec.Service, _ = serviceOf(params.Name)
+			if werr := sink.Write(ctx, rec); werr != nil {
+				slog.ErrorContext(ctx, "writing audit record failed", "tool", params.Name, "error", werr)
+			}
+			return result, err
+		}
+	}
+}
+
+// learnReadOnly adds the read-only tools in a tools/list page to known.
+func learnReadOnly(known map[string]bool, tools []*mcp.Tool) map[string]bool {
+	if known == nil {
+		known = make(map[string]bool)
+	}
+	for _, tool := range tools {
+		if tool.Annotations != nil && tool.Annotations.ReadOnlyHint {
+			known[tool.Name] = true
+		}
+	}
+	return known
+}
+
+// auditRecord builds the record for one completed tool call.
+func auditRecord(req mcp.Request, params *mcp.CallToolParamsRaw, result mcp.Result, err error) audit.Record {
+	rec := audit.Record{
+		User:      extractUserEmail(req),
+		Tool:      params.Name,
+		InputHash: audit.HashArguments(params.Arguments),
+		Result:    audit.ResultOK,
+	}
+	if r, ok := req.(*mcp.CallToolRequest); ok && r.Session != nil {
+		rec.Session = r.Session.ID()
+	}
+	switch res, _ := result.(*mcp.CallToolResult); {
+	case err != nil:
+		rec.SetError(err.Error())
+	case res != nil && res.IsError:
+		rec.SetError(firstText(res))
+	}
+	return rec
+}
+
+// firstText returns the first text content of a tool result.
+func firstText(res *mcp.CallToolResult) string {
+	for _, c := range res.Content {
+		if tc, ok := c.(*mcp.TextContent); ok {
+			return tc.Text
+		}
+	}
+	return ""
+}
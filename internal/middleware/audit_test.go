@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/audit"
+)
+
+type memorySink struct {
+	mu      sync.Mutex
+	records []audit.Record
+}
+
+func (s *memorySink) Write(_ context.Context, rec audit.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, rec)
+	return nil
+}
+
+func (s *memorySink) Close() error { return nil }
+
+func TestAuditMiddleware(t *testing.T) {
+	sink := &memorySink{}
+	serviceOf := func(tool string) (string, bool) { return "gmail", true }
+	handler := AuditMiddleware(sink, serviceOf)(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method == "tools/list" {
+			return &mcp.ListToolsResult{Tools: []*mcp.Tool{
+				{Name: "search_gmail_messages", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}},
+				{Name: "send_gmail_message", Annotations: &mcp.ToolAnnotations{}},
+			}}, nil
+		}
+		switch req.GetParams().(*mcp.CallToolParamsRaw).Name {
+		case "delete_event":
+			return nil, errors.New("boom")
+		case "share_drive_file":
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "permission denied"}}}, nil
+		}
+		return &mcp.CallToolResult{}, nil
+	})
+	call := func(tool string) {
+		_, _ = handler(context.Background(), "tools/call", &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{
+			Name:      tool,
+			Arguments: json.RawMessage(`{"user_google_email":"alice@example.com","to":"bob@example.com"}`),
+		}})
+	}
+
+	// Until tools/list reveals annotations, every call is audited.
+	call("search_gmail_messages")
+	if len(sink.records) != 1 {
+		t.Fatalf("got %d records before tools/list, want 1", len(sink.records))
+	}
+
+	_, _ = handler(context.Background(), "tools/list", &mcp.ListToolsRequest{Params: &mcp.ListToolsParams{}})
+	sink.records = nil
+	for _, tool := range []string{"search_gmail_messages", "send_gmail_message", "delete_event", "share_drive_file"} {
+		call(tool)
+	}
+
+	tests := []struct {
+		tool, result, err string
+	}{
+		{"send_gmail_message", audit.ResultOK, ""},
+		{"delete_event", audit.ResultError, "boom"},
+		{"share_drive_file", audit.ResultError, "permission denied"},
+	}
+	if len(sink.records) != len(tests) {
+		t.Fatalf("got %d records %+v, want %d (read-only tool skipped)", len(sink.records), sink.records, len(tests))
+	}
+	for i, tt := range tests {
+		rec := sink.records[i]
+		if rec.Tool != tt.tool || rec.Result != tt.result || rec.Error != tt.err {
+			t.Errorf("record %d = %+v, want tool %s result %s error %q", i, rec, tt.tool, tt.result, tt.err)
+		}
+		if rec.User != "alice@example.com" || rec.Service != "gmail" || len(rec.InputHash) != 64 || rec.Time.IsZero() {
+			t.Errorf("record %d missing fields: %+v", i, rec)
+		}
+	}
+}
@@ -21,9 +21,25 @@ var authErrorMarkers = []string{
 // AuthEnhancerMiddleware returns MCP SDK middleware that detects auth-related
 // tool errors and appends the OAuth authentication URL so the user can
 // authenticate without an extra round-trip.
-func AuthEnhancerMiddleware(oauthMgr *auth.OAuthManager) mcp.Middleware {
+//
+// When allowedUsers is non-empty, tool calls whose user_google_email is not
+// on the list are rejected before they reach the tool, so a shared server
+// cannot be used to read arbitrary mailboxes. Entries are full addresses
+// ("alice@example.com") or domains ("example.com" or "@example.com").
+func AuthEnhancerMiddleware(oauthMgr *auth.OAuthManager, allowedUsers []string) mcp.Middleware {
 	return func(next mcp.MethodHandler) mcp.MethodHandler {
 		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method == "tools/call" && len(allowedUsers) > 0 {
+				if email := extractUserEmail(req); email != "" && !userAllowed(email, allowedUsers) {
+					return &mcp.CallToolResult{
+						IsError: true,
+						Content: []mcp.Content{&mcp.TextContent{
+							Text: fmt.Sprintf("account %s is not allowed on this server — contact the server operator", email),
+						}},
+					}, nil
+				}
+			}
+
 			result, err := next(ctx, method, req)
 
 			// Only enhance tools/call responses.
@@ -61,7 +77,7 @@ func AuthEnhancerMiddleware(oauthMgr *auth.OAuthManager) mcp.Middleware {
 			}
 
 			// Append the auth URL to the existing error message.
-			authURL := oauthMgr.GetAuthURL(userEmail)
+			authURL := AuthURL(oauthMgr, req, userEmail)
 			textContent.Text = fmt.Sprintf(
 				"%s\n\nPlease authenticate by visiting this URL:\n%s",
 				textContent.Text, authURL,
@@ -83,6 +99,59 @@ func isAuthRelatedError(text string) bool {
 	return false
 }
 
+// userAllowed reports whether email matches an allowlist entry: either the
+// full address or its domain. Comparison is case-insensitive.
+func userAllowed(email string, allowed []string) bool {
+	email = strings.ToLower(strings.TrimSpace(email))
+	_, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return false
+	}
+	for _, entry := range allowed {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if strings.Contains(entry, "@") && !strings.HasPrefix(entry, "@") {
+			if email == entry {
+				return true
+			}
+			continue
+		}
+		if domain == strings.TrimPrefix(entry, "@") {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthURL returns a Google sign-in URL for userEmail whose state is bound to
+// the request's MCP session and bearer subject, so the OAuth callback only
+// completes it while that session is open.
+func AuthURL(oauthMgr *auth.OAuthManager, req mcp.Request, userEmail string) string {
+	var owner auth.StateOwner
+	if ss, ok := req.GetSession().(*mcp.ServerSession); ok && ss != nil && ss.ID() != "" {
+		owner.SessionID = ss.ID()
+		if extra := req.GetExtra(); extra != nil && extra.TokenInfo != nil {
+			owner.Subject = extra.TokenInfo.UserID
+		}
+		if oauthMgr.OpenSession(owner) {
+			go func() {
+				ss.Wait()
+				oauthMgr.EndSession(owner.SessionID)
+			}()
+		}
+	}
+	return oauthMgr.GetAuthURLFor(userEmail, owner)
+}
+
+// sessionID returns the MCP session ID for the request, or "" when there is
+// no server session (e.g. in tests or before initialization).
+func sessionID(req mcp.Request) string {
+	ss, ok := req.GetSession().(*mcp.ServerSession)
+	if !ok || ss == nil {
+		return ""
+	}
+	return ss.ID()
+}
+
 // extractUserEmail tries to read user_google_email from the raw tool arguments.
 func extractUserEmail(req mcp.Request) string {
 	params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
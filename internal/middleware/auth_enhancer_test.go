@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
@@ -36,7 +40,7 @@ func fakeToolRequest(argsJSON string) mcp.Request {
 
 func TestAuthEnhancer_NoCredentials(t *testing.T) {
 	oauthMgr := testOAuthMgr()
-	mw := AuthEnhancerMiddleware(oauthMgr)
+	mw := AuthEnhancerMiddleware(oauthMgr, nil)
 
 	errText := "no credentials found for user@test.com — call start_google_auth to authenticate"
 	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
@@ -72,7 +76,7 @@ func TestAuthEnhancer_NoCredentials(t *testing.T) {
 
 func TestAuthEnhancer_AuthExpired(t *testing.T) {
 	oauthMgr := testOAuthMgr()
-	mw := AuthEnhancerMiddleware(oauthMgr)
+	mw := AuthEnhancerMiddleware(oauthMgr, nil)
 
 	errText := "authentication expired for this user — call start_google_auth tool to re-authenticate"
 	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
@@ -96,7 +100,7 @@ func TestAuthEnhancer_AuthExpired(t *testing.T) {
 
 func TestAuthEnhancer_NonAuthError_Unchanged(t *testing.T) {
 	oauthMgr := testOAuthMgr()
-	mw := AuthEnhancerMiddleware(oauthMgr)
+	mw := AuthEnhancerMiddleware(oauthMgr, nil)
 
 	errText := "resource not found — verify the ID is correct"
 	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
@@ -120,7 +124,7 @@ func TestAuthEnhancer_NonAuthError_Unchanged(t *testing.T) {
 
 func TestAuthEnhancer_NonToolCall_Unchanged(t *testing.T) {
 	oauthMgr := testOAuthMgr()
-	mw := AuthEnhancerMiddleware(oauthMgr)
+	mw := AuthEnhancerMiddleware(oauthMgr, nil)
 
 	// Simulate a non-tool-call method (e.g. tools/list) that returns a tool list result.
 	// The middleware should pass through any non-"tools/call" method unchanged.
@@ -142,7 +146,7 @@ func TestAuthEnhancer_NonToolCall_Unchanged(t *testing.T) {
 
 func TestAuthEnhancer_MissingEmail_Unchanged(t *testing.T) {
 	oauthMgr := testOAuthMgr()
-	mw := AuthEnhancerMiddleware(oauthMgr)
+	mw := AuthEnhancerMiddleware(oauthMgr, nil)
 
 	errText := "no credentials found for unknown — call start_google_auth to authenticate"
 	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
@@ -167,7 +171,7 @@ func TestAuthEnhancer_MissingEmail_Unchanged(t *testing.T) {
 
 func TestAuthEnhancer_SuccessResult_Unchanged(t *testing.T) {
 	oauthMgr := testOAuthMgr()
-	mw := AuthEnhancerMiddleware(oauthMgr)
+	mw := AuthEnhancerMiddleware(oauthMgr, nil)
 
 	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
 		return &mcp.CallToolResult{
@@ -189,7 +193,7 @@ func TestAuthEnhancer_SuccessResult_Unchanged(t *testing.T) {
 
 func TestAuthEnhancer_NilResult_NoPanic(t *testing.T) {
 	oauthMgr := testOAuthMgr()
-	mw := AuthEnhancerMiddleware(oauthMgr)
+	mw := AuthEnhancerMiddleware(oauthMgr, nil)
 
 	// Simulate the SDK returning a typed-nil *CallToolResult with an error,
 	// which is what happens when input validation fails before the handler runs.
@@ -236,3 +240,90 @@ func TestIsAuthRelatedError(t *testing.T) {
 		}
 	}
 }
+
+func TestAuthEnhancer_AllowedUsers(t *testing.T) {
+	mw := AuthEnhancerMiddleware(testOAuthMgr(), []string{"alice@example.com", "corp.example"})
+
+	tests := []struct {
+		name       string
+		args       string
+		wantCalled bool
+	}{
+		{"listed address", `{"user_google_email":"Alice@Example.com"}`, true},
+		{"listed domain", `{"user_google_email":"bob@corp.example"}`, true},
+		{"other address", `{"user_google_email":"mallory@example.com"}`, false},
+		{"subdomain not listed", `{"user_google_email":"eve@sub.corp.example"}`, false},
+		{"no user argument", `{"query":"test"}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+				called = true
+				return &mcp.CallToolResult{}, nil
+			}
+
+			result, err := mw(next)(context.Background(), "tools/call", fakeToolRequest(tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("tool called = %v, want %v", called, tt.wantCalled)
+			}
+			if !tt.wantCalled && !result.(*mcp.CallToolResult).IsError {
+				t.Error("rejected call must return a tool error")
+			}
+		})
+	}
+}
+
+func TestAuthURLBindsSession(t *testing.T) {
+	mgr := testOAuthMgr()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "sign_in"}, func(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: AuthURL(mgr, req, "alice@example.com")}}}, nil, nil
+	})
+	ts := httptest.NewServer(mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil))
+	defer ts.Close()
+
+	ctx := context.Background()
+	cs, err := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"}, nil).
+		Connect(ctx, &mcp.StreamableClientTransport{Endpoint: ts.URL}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signIn := func() string {
+		t.Helper()
+		res, err := cs.CallTool(ctx, &mcp.CallToolParams{Name: "sign_in", Arguments: map[string]any{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, err := url.Parse(res.Content[0].(*mcp.TextContent).Text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u.Query().Get("state")
+	}
+
+	info, err := mgr.CompleteState(signIn())
+	if err != nil {
+		t.Fatalf("complete while the session is open: %v", err)
+	}
+	if info.Owner.SessionID != cs.ID() {
+		t.Errorf("owner session = %q, want %q", info.Owner.SessionID, cs.ID())
+	}
+
+	// Once the session closes, its outstanding links stop working.
+	state := signIn()
+	cs.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := mgr.VerifyState(state); err != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("state still valid after its session closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+)
+
+// BearerAuth returns HTTP middleware that requires a valid
+// "Authorization: Bearer <token>" header on every request. Requests without
+// a token, or with a token the validator rejects, get a 401 with a
+// WWW-Authenticate challenge and never reach next.
+func BearerAuth(validator auth.BearerValidator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			unauthorized(w, "")
+			return
+		}
+
+		subject, err := validator.Validate(r.Context(), token)
+		if err != nil {
+			slog.Warn("rejected bearer token",
+				"remote", r.RemoteAddr,
+				"error", err,
+			)
+			unauthorized(w, "invalid_token")
+			return
+		}
+
+		slog.Debug("authenticated HTTP request", "subject", subject, "remote", r.RemoteAddr)
+		withSubject(subject, next).ServeHTTP(w, r)
+	})
+}
+
+// withSubject hands the validated subject to the MCP SDK as the request's
+// TokenInfo, so tools see the caller in req.Extra and streamable sessions
+// stay bound to the subject that created them. The SDK only reads TokenInfo
+// set by its own middleware, so the subject is passed through it.
+func withSubject(subject string, next http.Handler) http.Handler {
+	return sdkauth.RequireBearerToken(func(context.Context, string, *http.Request) (*sdkauth.TokenInfo, error) {
+		// The token was already checked; the expiry only satisfies the SDK.
+		return &sdkauth.TokenInfo{UserID: subject, Expiration: time.Now().Add(time.Minute)}, nil
+	}, nil)(next)
+}
+
+// bearerToken extracts the token from the Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// unauthorized writes a 401 with an RFC 6750 challenge.
+func unauthorized(w http.ResponseWriter, errCode string) {
+	challenge := `Bearer realm="google-workspace-mcp"`
+	if errCode != "" {
+		challenge += `, error="` + errCode + `"`
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	http.Error(w, "unauthorized", http.StatusUnauthorized)
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+)
+
+func TestBearerAuth(t *testing.T) {
+	validator := auth.NewStaticKeyValidator([]string{"secret-key"})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := BearerAuth(validator, next)
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+		wantError  string
+	}{
+		{"valid key", "Bearer secret-key", http.StatusNoContent, ""},
+		{"case-insensitive scheme", "bearer secret-key", http.StatusNoContent, ""},
+		{"missing header", "", http.StatusUnauthorized, ""},
+		{"basic scheme", "Basic c2VjcmV0LWtleQ==", http.StatusUnauthorized, ""},
+		{"empty token", "Bearer ", http.StatusUnauthorized, ""},
+		{"wrong key", "Bearer nope", http.StatusUnauthorized, `error="invalid_token"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status: got %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusUnauthorized {
+				return
+			}
+			challenge := rec.Header().Get("WWW-Authenticate")
+			if !strings.HasPrefix(challenge, "Bearer ") {
+				t.Errorf("expected Bearer challenge, got %q", challenge)
+			}
+			if tt.wantError != "" && !strings.Contains(challenge, tt.wantError) {
+				t.Errorf("expected %s in challenge, got %q", tt.wantError, challenge)
+			}
+		})
+	}
+}
+
+func TestBearerAuthSubject(t *testing.T) {
+	validator := auth.NewStaticKeyValidator([]string{"first", "second"})
+	var got string
+	handler := BearerAuth(validator, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if info := sdkauth.TokenInfoFromContext(r.Context()); info != nil {
+			got = info.UserID
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req.Header.Set("Authorization", "Bearer second")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "api-key#2" {
+		t.Errorf("subject: got %q, want %q", got, "api-key#2")
+	}
+}
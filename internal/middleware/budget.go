@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+)
+
+// TruncatedOutput replaces the structured content of a result whose text
+// was cut to the output budget.
+type TruncatedOutput struct {
+	Truncated         bool   `json:"truncated"`
+	ContinuationToken string `json:"continuation_token"`
+}
+
+// OutputBudgetMiddleware returns MCP SDK middleware that limits the text of
+// successful tool results to the overflow's budget. The cut-off rest is kept
+// for the calling session and read with the response.ContinueTool tool,
+// whose own results are already limited. Structured content of a cut result
+// is replaced by a TruncatedOutput.
+func OutputBudgetMiddleware(overflow *response.Overflow) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if method != "tools/call" || err != nil || !ok || params.Name == response.ContinueTool {
+				return result, err
+			}
+			toolResult, ok := result.(*mcp.CallToolResult)
+			if !ok || toolResult == nil || toolResult.IsError {
+				return result, err
+			}
+
+			// Results may be shared with the response cache, so the
+			// truncated text goes into a copy.
+			copied := *toolResult
+			copied.Content = make([]mcp.Content, len(toolResult.Content))
+			var token string
+			for i, c := range toolResult.Content {
+				if text, ok := c.(*mcp.TextContent); ok {
+					limited := *text
+					var cut string
+					limited.Text, cut = overflow.Limit(sessionID(req), text.Text)
+					if cut != "" {
+						token = cut
+					}
+					c = &limited
+				}
+				copied.Content[i] = c
+			}
+			// The structured output of a cut result carries the same data,
+			// so it is replaced by a pointer to the continuation.
+			if token != "" && copied.StructuredContent != nil {
+				copied.StructuredContent = TruncatedOutput{Truncated: true, ContinuationToken: token}
+			}
+			return &copied, nil
+		}
+	}
+}
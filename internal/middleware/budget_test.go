@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+)
+
+func TestOutputBudgetMiddleware(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+	server.AddReceivingMiddleware(OutputBudgetMiddleware(response.NewOverflow(100)))
+	long := strings.Repeat("0123456789\n", 30)
+	shared := response.New().Raw(long).TextResult()
+	handler := func(_ context.Context, _ *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, any, error) {
+		return shared, nil, nil
+	}
+	mcp.AddTool(server, &mcp.Tool{Name: "probe"}, handler)
+	mcp.AddTool(server, &mcp.Tool{Name: response.ContinueTool}, handler)
+	cs := connectTestClient(t, server, nil)
+
+	call := func(name string) string {
+		t.Helper()
+		res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: map[string]any{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Content[0].(*mcp.TextContent).Text
+	}
+
+	got := call("probe")
+	head, notice, ok := strings.Cut(got, "\n\n[Output truncated")
+	if !ok || len(head) > 100 || !strings.Contains(notice, response.ContinueTool) {
+		t.Errorf("probe output not truncated to the budget: %q", got)
+	}
+	if got := call(response.ContinueTool); got != long {
+		t.Errorf("%s output was truncated again", response.ContinueTool)
+	}
+	if shared.Content[0].(*mcp.TextContent).Text != long {
+		t.Error("handler's result was modified")
+	}
+}
+
+func TestOutputBudgetMiddlewareStructured(t *testing.T) {
+	type listing struct {
+		Items []string `json:"items"`
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+	server.AddReceivingMiddleware(OutputBudgetMiddleware(response.NewOverflow(100)))
+	mcp.AddTool(server, &mcp.Tool{Name: "list"}, func(_ context.Context, _ *mcp.CallToolRequest, in struct {
+		N int `json:"n"`
+	}) (*mcp.CallToolResult, listing, error) {
+		out := listing{Items: make([]string, in.N)}
+		rb := response.New()
+		for i := range out.Items {
+			out.Items[i] = strings.Repeat("x", 20)
+			rb.Line("%s", out.Items[i])
+		}
+		return rb.TextResult(), out, nil
+	})
+	cs := connectTestClient(t, server, nil)
+
+	call := func(n int) *mcp.CallToolResult {
+		t.Helper()
+		res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: "list", Arguments: map[string]any{"n": n}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	big := call(50)
+	text := big.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "[Output truncated") {
+		t.Fatalf("text not truncated: %q", text)
+	}
+	stub, ok := big.StructuredContent.(map[string]any)
+	if !ok || stub["truncated"] != true || stub["continuation_token"] == "" || stub["items"] != nil {
+		t.Errorf("structured content of a truncated result = %v, want a truncation stub", big.StructuredContent)
+	}
+	if !strings.Contains(text, stub["continuation_token"].(string)) {
+		t.Errorf("stub token %v not named in the text notice", stub["continuation_token"])
+	}
+
+	small := call(2)
+	if got, ok := small.StructuredContent.(map[string]any); !ok || len(got["items"].([]any)) != 2 {
+		t.Errorf("structured content of a result within budget = %v, want it unchanged", small.StructuredContent)
+	}
+}
@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"container/list"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/audit"
+)
+
+// ResponseCache is an in-memory LRU of tool results that expire after a
+// fixed TTL. It is safe for concurrent use.
+type ResponseCache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // most recently used at the front
+}
+
+type cacheEntry struct {
+	key     string
+	scope   string // service and user, for invalidation after writes
+	result  *mcp.CallToolResult
+	expires time.Time
+}
+
+// NewResponseCache returns a cache holding at most maxEntries results for ttl each.
+func NewResponseCache(ttl time.Duration, maxEntries int) *ResponseCache {
+	return &ResponseCache{
+		ttl:        ttl,
+		maxEntries: max(maxEntries, 1),
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// get returns a copy of the unexpired result stored under key.
+func (c *ResponseCache) get(key string) (*mcp.CallToolResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*cacheEntry)
+	if !c.now().Before(e.expires) {
+		c.remove(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return cloneToolResult(e.result), true
+}
+
+// put stores a copy of res, evicting the least recently used entry when full.
+func (c *ResponseCache) put(key, scope string, res *mcp.CallToolResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	e := &cacheEntry{key: key, scope: scope, result: cloneToolResult(res), expires: c.now().Add(c.ttl)}
+	c.entries[key] = c.order.PushFront(e)
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// invalidate drops every entry in scope.
+func (c *ResponseCache) invalidate(scope string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		if el.Value.(*cacheEntry).scope == scope {
+			c.remove(el)
+		}
+		el = next
+	}
+}
+
+func (c *ResponseCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).key)
+}
+
+// cloneToolResult copies res deeply enough that later middleware (such as
+// output redaction, which rewrites text in place) cannot alter the cache.
+func cloneToolResult(res *mcp.CallToolResult) *mcp.CallToolResult {
+	out := *res
+	out.Content = make([]mcp.Content, len(res.Content))
+	for i, c := range res.Content {
+		if tc, ok := c.(*mcp.TextContent); ok {
+			copied := *tc
+			c = &copied
+		}
+		out.Content[i] = c
+	}
+	return &out
+}
+
+// CacheMiddleware returns MCP SDK middleware that answers repeated calls of
+// the given tools from c. Results are keyed by tool and canonical argument
+// hash, so each user_google_email has its own entries. Error results are
+// never cached.
+//
+// Only tools whose ReadOnlyHint was seen in a tools/list response are
+// cached. Any other tool call — a write, or a tool not yet known to be
+// read-only — drops the cached results of its service for that user, so an
+// agent sees its own changes immediately.
+func CacheMiddleware(c *ResponseCache, tools []string, serviceOf func(tool string) (string, bool)) mcp.Middleware {
+	cacheable := make(map[string]bool, len(tools))
+	for _, t := range tools {
+		cacheable[t] = true
+	}
+	var mu sync.RWMutex
+	var readOnly map[string]bool
+
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method == "tools/list" {
+				result, err := next(ctx, method, req)
+				if list, ok := result.(*mcp.ListToolsResult); ok && err == nil {
+					mu.Lock()
+					readOnly = learnReadOnly(readOnly, list.Tools)
+					mu.Unlock()
+				}
+				return result, err
+			}
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if method != "tools/call" || !ok {
+				return next(ctx, method, req)
+			}
+			mu.RLock()
+			isRead := readOnly[params.Name]
+			mu.RUnlock()
+
+			service, _ := serviceOf(params.Name)
+			scope := service + "\x00" + strings.ToLower(extractUserEmail(req))
+			if !isRead {
+				result, err := next(ctx, method, req)
+				c.invalidate(scope)
+				return result, err
+			}
+			if !cacheable[params.Name] {
+				return next(ctx, method, req)
+			}
+
+			key := params.Name + "\x00" + audit.HashArguments(params.Arguments)
+			if cached, ok := c.get(key); ok {
+				slog.DebugContext(ctx, "response cache hit", "tool", params.Name)
+				return cached, nil
+			}
+			result, err := next(ctx, method, req)
+			if res, ok := result.(*mcp.CallToolResult); ok && err == nil && !res.IsError {
+				c.put(key, scope, res)
+			}
+			return result, err
+		}
+	}
+}
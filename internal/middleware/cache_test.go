@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestCacheMiddleware(t *testing.T) {
+	services := map[string]string{
+		"list_gmail_labels":    "gmail",
+		"manage_gmail_label":   "gmail",
+		"search_gmail_message": "gmail",
+		"list_calendars":       "calendar",
+	}
+	serviceOf := func(tool string) (string, bool) { s, ok := services[tool]; return s, ok }
+
+	calls := map[string]int{}
+	handler := CacheMiddleware(NewResponseCache(time.Minute, 10), []string{"list_gmail_labels", "list_calendars"}, serviceOf)(
+		func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method == "tools/list" {
+				return &mcp.ListToolsResult{Tools: []*mcp.Tool{
+					{Name: "list_gmail_labels", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}},
+					{Name: "search_gmail_message", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}},
+					{Name: "list_calendars", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}},
+					{Name: "manage_gmail_label", Annotations: &mcp.ToolAnnotations{}},
+				}}, nil
+			}
+			name := req.GetParams().(*mcp.CallToolParamsRaw).Name
+			calls[name]++
+			if name == "list_calendars" && calls[name] == 1 {
+				return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "backend error"}}}, nil
+			}
+			return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("%s #%d", name, calls[name])}}}, nil
+		})
+
+	call := func(tool, user string) string {
+		t.Helper()
+		res, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{
+			Name:      tool,
+			Arguments: json.RawMessage(fmt.Sprintf(`{"user_google_email":%q}`, user)),
+		}})
+		if err != nil {
+			t.Fatalf("%s: %v", tool, err)
+		}
+		text := res.(*mcp.CallToolResult).Content[0].(*mcp.TextContent)
+		got := text.Text
+		text.Text = "mutated by a later middleware"
+		return got
+	}
+
+	steps := []struct {
+		name, tool, user, want string
+	}{
+		{"not cached before tools/list", "list_gmail_labels", "alice@example.com", "list_gmail_labels #1"},
+		{"list tools", "", "", ""},
+		{"miss", "list_gmail_labels", "alice@example.com", "list_gmail_labels #2"},
+		{"hit is unaffected by mutation", "list_gmail_labels", "alice@example.com", "list_gmail_labels #2"},
+		{"other user misses", "list_gmail_labels", "bob@example.com", "list_gmail_labels #3"},
+		{"read tool outside the list is not cached", "search_gmail_message", "alice@example.com", "search_gmail_message #1"},
+		{"uncached read does not invalidate", "list_gmail_labels", "alice@example.com", "list_gmail_labels #2"},
+		{"error result", "list_calendars", "alice@example.com", "backend error"},
+		{"error result not cached", "list_calendars", "alice@example.com", "list_calendars #2"},
+		{"write", "manage_gmail_label", "alice@example.com", "manage_gmail_label #1"},
+		{"write invalidates its service for the user", "list_gmail_labels", "alice@example.com", "list_gmail_labels #4"},
+		{"other user's entry survives", "list_gmail_labels", "bob@example.com", "list_gmail_labels #3"},
+		{"other service survives", "list_calendars", "alice@example.com", "list_calendars #2"},
+	}
+	for _, s := range steps {
+		if s.tool == "" {
+			_, _ = handler(context.Background(), "tools/list", &mcp.ListToolsRequest{Params: &mcp.ListToolsParams{}})
+			continue
+		}
+		if got := call(s.tool, s.user); got != s.want {
+			t.Errorf("%s: got %q, want %q", s.name, got, s.want)
+		}
+	}
+}
+
+func TestResponseCacheExpiryAndEviction(t *testing.T) {
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	c := NewResponseCache(time.Minute, 2)
+	c.now = func() time.Time { return now }
+	res := &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "x"}}}
+
+	c.put("a", "s", res)
+	c.put("b", "s", res)
+	if _, ok := c.get("a"); !ok { // a is now most recently used
+		t.Fatal("a missing")
+	}
+	c.put("c", "s", res)
+	if _, ok := c.get("b"); ok {
+		t.Error("b should have been evicted as least recently used")
+	}
+	if _, ok := c.get("a"); !ok {
+		t.Error("a should have survived eviction")
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := c.get("c"); ok {
+		t.Error("c should have expired after the TTL")
+	}
+}
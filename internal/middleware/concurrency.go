@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// errNoSlot is returned by Acquire when no slot freed within the wait.
+var errNoSlot = errors.New("no free tool call slot")
+
+// sessionSlots is one session's semaphore and the number of calls holding
+// or waiting for it.
+type sessionSlots struct {
+	sem   chan struct{}
+	users int
+}
+
+// SessionLimiter bounds the tool calls each MCP session runs at once, so an
+// agent fanning out hundreds of parallel calls queues behind its own limit
+// instead of exhausting the process.
+type SessionLimiter struct {
+	limit int
+	wait  time.Duration
+
+	mu       sync.Mutex
+	sessions map[mcp.Session]*sessionSlots
+}
+
+// NewSessionLimiter creates a limiter allowing limit concurrent calls per
+// session. A call over the limit waits up to wait for a slot.
+func NewSessionLimiter(limit int, wait time.Duration) *SessionLimiter {
+	return &SessionLimiter{limit: limit, wait: wait, sessions: make(map[mcp.Session]*sessionSlots)}
+}
+
+// Acquire takes a slot in the session's semaphore, waiting up to the
+// limiter's wait. The returned func releases the slot. It fails with
+// errNoSlot when the wait runs out, or with ctx's error when the call is
+// cancelled first.
+func (l *SessionLimiter) Acquire(ctx context.Context, session mcp.Session) (func(), error) {
+	l.mu.Lock()
+	s, ok := l.sessions[session]
+	if !ok {
+		s = &sessionSlots{sem: make(chan struct{}, l.limit)}
+		l.sessions[session] = s
+	}
+	s.users++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(l.wait)
+	defer timer.Stop()
+	select {
+	case s.sem <- struct{}{}:
+		return func() {
+			<-s.sem
+			l.done(session, s)
+		}, nil
+	case <-timer.C:
+		l.done(session, s)
+		return nil, errNoSlot
+	case <-ctx.Done():
+		l.done(session, s)
+		return nil, ctx.Err()
+	}
+}
+
+// done drops a call from the session, forgetting the session's semaphore
+// once no call uses it.
+func (l *SessionLimiter) done(session mcp.Session, s *sessionSlots) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if s.users--; s.users == 0 {
+		delete(l.sessions, session)
+	}
+}
+
+// ConcurrencyLimitMiddleware returns MCP SDK middleware that holds a slot of
+// the limiter for the duration of every tools/call request. Calls that find
+// no free slot in time get an error result asking the agent to wait for its
+// earlier calls.
+func ConcurrencyLimitMiddleware(l *SessionLimiter) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+			release, err := l.Acquire(ctx, req.GetSession())
+			if errors.Is(err, errNoSlot) {
+				return &mcp.CallToolResult{
+					IsError: true,
+					Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf(
+						"too many tool calls in flight for this session — at most %d run at once. Wait for earlier calls to finish, and issue no more than %d calls in parallel",
+						l.limit, l.limit,
+					)}},
+				}, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			defer release()
+			return next(ctx, method, req)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestSessionLimiterAcquire(t *testing.T) {
+	l := NewSessionLimiter(2, 20*time.Millisecond)
+	a, b := &mcp.ServerSession{}, &mcp.ServerSession{}
+
+	releaseA1, err := l.Acquire(context.Background(), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	releaseA2, err := l.Acquire(context.Background(), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Acquire(context.Background(), a); !errors.Is(err, errNoSlot) {
+		t.Errorf("third call in session: err = %v, want errNoSlot", err)
+	}
+	releaseB, err := l.Acquire(context.Background(), b)
+	if err != nil {
+		t.Errorf("other sessions must have their own slots: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.Acquire(ctx, a); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled call: err = %v, want context.Canceled", err)
+	}
+
+	releaseA1()
+	releaseA3, err := l.Acquire(context.Background(), a)
+	if err != nil {
+		t.Errorf("released slot not reusable: %v", err)
+	}
+	for _, release := range []func(){releaseA2, releaseA3, releaseB} {
+		release()
+	}
+	if len(l.sessions) != 0 {
+		t.Errorf("idle sessions not forgotten: %d", len(l.sessions))
+	}
+}
+
+func TestConcurrencyLimitMiddleware(t *testing.T) {
+	l := NewSessionLimiter(1, 20*time.Millisecond)
+	entered, unblock := make(chan struct{}), make(chan struct{})
+	handler := ConcurrencyLimitMiddleware(l)(func(_ context.Context, method string, _ mcp.Request) (mcp.Result, error) {
+		if method == "tools/call" {
+			entered <- struct{}{}
+			<-unblock
+		}
+		return &mcp.CallToolResult{}, nil
+	})
+	req := fakeToolRequest(`{}`)
+
+	done := make(chan struct{})
+	go func() {
+		handler(context.Background(), "tools/call", req)
+		close(done)
+	}()
+	<-entered
+
+	result, err := handler(context.Background(), "tools/call", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toolResult := result.(*mcp.CallToolResult)
+	if !toolResult.IsError || !strings.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "too many tool calls") {
+		t.Errorf("unexpected result: %+v", toolResult)
+	}
+	if _, err := handler(context.Background(), "tools/list", req); err != nil {
+		t.Errorf("other methods must not be limited: %v", err)
+	}
+
+	close(unblock)
+	<-done
+}
@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxConfirmArguments caps the tool arguments shown in a confirmation prompt.
+const maxConfirmArguments = 500
+
+// confirmSchema asks the user for a single yes/no answer. The answer is not
+// required: the SDK validates declined results against the schema too, and
+// they carry no content.
+var confirmSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"confirm": map[string]any{
+			"type":        "boolean",
+			"title":       "Run this operation",
+			"description": "This cannot be undone.",
+		},
+	},
+}
+
+// ConfirmationMiddleware returns MCP SDK middleware that asks the user,
+// through MCP elicitation, to confirm every call of a tool annotated with
+// DestructiveHint before it runs. Calls are refused when the user declines,
+// or when the client does not support elicitation.
+//
+// The SDK does not expose registered tools, so destructive tools are read
+// from complete tools/list responses, with the tier config's annotation
+// overrides applied. Each complete listing replaces the set; a call made
+// before any listing lists all pages first.
+func ConfirmationMiddleware() mcp.Middleware {
+	var mu sync.Mutex
+	var destructive map[string]bool // nil until a complete listing is seen
+
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method == "tools/list" {
+				result, err := next(ctx, method, req)
+				if list, ok := result.(*mcp.ListToolsResult); ok && err == nil {
+					// A page of a paginated listing shows only some tools,
+					// so it makes the next call list them all instead.
+					var known map[string]bool
+					if params, _ := req.GetParams().(*mcp.ListToolsParams); (params == nil || params.Cursor == "") && list.NextCursor == "" {
+						known = destructiveTools(list.Tools)
+					}
+					mu.Lock()
+					destructive = known
+					mu.Unlock()
+				}
+				return result, err
+			}
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if method != "tools/call" || !ok {
+				return next(ctx, method, req)
+			}
+			mu.Lock()
+			known := destructive
+			mu.Unlock()
+			if known == nil {
+				// A client may call tools without listing them first; list
+				// them here so destructive tools are never run unconfirmed.
+				var err error
+				if known, err = listDestructive(ctx, next, req); err != nil {
+					slog.WarnContext(ctx, "listing tools for confirmation failed", "error", err)
+					return refusedResult("could not check whether %s needs confirmation (%v) — the operation was not run; try again", params.Name, err), nil
+				}
+				mu.Lock()
+				destructive = known
+				mu.Unlock()
+			}
+			if !known[params.Name] {
+				return next(ctx, method, req)
+			}
+			if refusal := confirmCall(ctx, req, params); refusal != nil {
+				return refusal, nil
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// destructiveTools returns the names of the tools that declare
+// DestructiveHint true.
+func destructiveTools(tools []*mcp.Tool) map[string]bool {
+	known := make(map[string]bool)
+	for _, tool := range tools {
+		if a := tool.Annotations; a != nil && a.DestructiveHint != nil && *a.DestructiveHint {
+			known[tool.Name] = true
+		}
+	}
+	return known
+}
+
+// listDestructive runs tools/list beneath the middleware, following every
+// page, and returns the destructive tools it reports.
+func listDestructive(ctx context.Context, next mcp.MethodHandler, req mcp.Request) (map[string]bool, error) {
+	session, _ := req.GetSession().(*mcp.ServerSession)
+	var tools []*mcp.Tool
+	cursor := ""
+	for {
+		result, err := next(ctx, "tools/list", &mcp.ListToolsRequest{Session: session, Params: &mcp.ListToolsParams{Cursor: cursor}})
+		if err != nil {
+			return nil, err
+		}
+		list, ok := result.(*mcp.ListToolsResult)
+		if !ok {
+			return nil, fmt.Errorf("unexpected tools/list result %T", result)
+		}
+		tools = append(tools, list.Tools...)
+		if list.NextCursor == "" {
+			return destructiveTools(tools), nil
+		}
+		cursor = list.NextCursor
+	}
+}
+
+// confirmCall asks the user to confirm a tool call. It returns nil when
+// the call may proceed, or the error result to return instead.
+func confirmCall(ctx context.Context, req mcp.Request, params *mcp.CallToolParamsRaw) *mcp.CallToolResult {
+	session, ok := req.GetSession().(*mcp.ServerSession)
+	if !ok || session == nil {
+		return refusedResult("%s requires user confirmation, but there is no client session to ask", params.Name)
+	}
+	args := string(params.Arguments)
+	if len(args) > maxConfirmArguments {
+		args = args[:maxConfirmArguments] + "…"
+	}
+	res, err := session.Elicit(ctx, &mcp.ElicitParams{
+		Message:         fmt.Sprintf("Allow %s? Arguments: %s", params.Name, args),
+		RequestedSchema: confirmSchema,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "confirmation request failed", "tool", params.Name, "error", err)
+		return refusedResult("%s requires user confirmation on this server, and the confirmation request failed (%v). "+
+			"The operation was not run; the client must support MCP elicitation for destructive tools", params.Name, err)
+	}
+	if res.Action != "accept" || res.Content["confirm"] != true {
+		return refusedResult("the user did not confirm %s — the operation was not run. Do not retry unless the user asks", params.Name)
+	}
+	return nil
+}
+
+func refusedResult(format string, args ...any) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf(format, args...)}},
+	}
+}
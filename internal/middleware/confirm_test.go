@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/ptr"
+)
+
+type confirmInput struct {
+	EventID string `json:"event_id"`
+}
+
+func TestConfirmationMiddleware(t *testing.T) {
+	accept := func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+		return &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}}, nil
+	}
+	tests := []struct {
+		name    string
+		list    bool
+		tool    string
+		elicit  func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error)
+		wantRan bool
+		wantMsg string
+	}{
+		{"confirmed", true, "delete_event", accept, true, ""},
+		{"confirmed without listing tools first", false, "delete_event", accept, true, ""},
+		{"declined", true, "delete_event", func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			return &mcp.ElicitResult{Action: "decline"}, nil
+		}, false, "did not confirm delete_event"},
+		{"unchecked", true, "delete_event", func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			return &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": false}}, nil
+		}, false, "did not confirm"},
+		{"client without elicitation", true, "delete_event", nil, false, "must support MCP elicitation"},
+		{"non-destructive tool is not asked", true, "update_event", nil, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ran := false
+			var prompt string
+			server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+			handler := func(context.Context, *mcp.CallToolRequest, confirmInput) (*mcp.CallToolResult, any, error) {
+				ran = true
+				return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "done"}}}, nil, nil
+			}
+			mcp.AddTool(server, &mcp.Tool{Name: "delete_event", Annotations: &mcp.ToolAnnotations{DestructiveHint: ptr.Bool(true)}}, handler)
+			mcp.AddTool(server, &mcp.Tool{Name: "update_event", Annotations: &mcp.ToolAnnotations{DestructiveHint: ptr.Bool(false)}}, handler)
+			server.AddReceivingMiddleware(ConfirmationMiddleware())
+
+			opts := &mcp.ClientOptions{}
+			if tt.elicit != nil {
+				opts.ElicitationHandler = func(ctx context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+					prompt = req.Params.Message
+					return tt.elicit(ctx, req)
+				}
+			}
+			cs := connectTestClient(t, server, opts)
+
+			if tt.list {
+				if _, err := cs.ListTools(context.Background(), nil); err != nil {
+					t.Fatalf("ListTools: %v", err)
+				}
+			}
+			res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: tt.tool, Arguments: map[string]any{"event_id": "evt-42"}})
+			if err != nil {
+				t.Fatalf("CallTool: %v", err)
+			}
+			if ran != tt.wantRan {
+				t.Errorf("handler ran = %v, want %v", ran, tt.wantRan)
+			}
+			if res.IsError == tt.wantRan {
+				t.Errorf("IsError = %v", res.IsError)
+			}
+			if text := res.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, tt.wantMsg) {
+				t.Errorf("result = %q, want it to contain %q", text, tt.wantMsg)
+			}
+			if tt.elicit != nil && !strings.Contains(prompt, "evt-42") {
+				t.Errorf("prompt %q does not show the arguments", prompt)
+			}
+		})
+	}
+}
+
+func TestConfirmationMiddlewareToolSet(t *testing.T) {
+	asked := 0
+	opts := &mcp.ClientOptions{ElicitationHandler: func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+		asked++
+		return &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}}, nil
+	}}
+	handler := func(context.Context, *mcp.CallToolRequest, confirmInput) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "done"}}}, nil, nil
+	}
+	destructive := func(name string, hint bool) *mcp.Tool {
+		return &mcp.Tool{Name: name, Annotations: &mcp.ToolAnnotations{DestructiveHint: ptr.Bool(hint)}}
+	}
+	call := func(t *testing.T, cs *mcp.ClientSession, name string) {
+		t.Helper()
+		res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: map[string]any{"event_id": "evt-1"}})
+		if err != nil || res.IsError {
+			t.Fatalf("CallTool %s: %v %+v", name, err, res)
+		}
+	}
+
+	t.Run("relisting replaces the set", func(t *testing.T) {
+		asked = 0
+		server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+		mcp.AddTool(server, destructive("purge", true), handler)
+		server.AddReceivingMiddleware(ConfirmationMiddleware())
+		cs := connectTestClient(t, server, opts)
+
+		if _, err := cs.ListTools(context.Background(), nil); err != nil {
+			t.Fatal(err)
+		}
+		call(t, cs, "purge")
+		server.RemoveTools("purge")
+		mcp.AddTool(server, destructive("purge", false), handler)
+		if _, err := cs.ListTools(context.Background(), nil); err != nil {
+			t.Fatal(err)
+		}
+		call(t, cs, "purge")
+		if asked != 1 {
+			t.Errorf("asked %d times, want 1: purge is no longer destructive after relisting", asked)
+		}
+	})
+
+	t.Run("unlisted call reads every page", func(t *testing.T) {
+		asked = 0
+		server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, &mcp.ServerOptions{PageSize: 1})
+		mcp.AddTool(server, destructive("a_read", false), handler)
+		mcp.AddTool(server, destructive("b_read", false), handler)
+		mcp.AddTool(server, destructive("z_delete", true), handler)
+		server.AddReceivingMiddleware(ConfirmationMiddleware())
+		cs := connectTestClient(t, server, opts)
+
+		// A single page does not settle the set either.
+		if _, err := cs.ListTools(context.Background(), &mcp.ListToolsParams{}); err != nil {
+			t.Fatal(err)
+		}
+		call(t, cs, "z_delete")
+		if asked != 1 {
+			t.Errorf("asked %d times, want 1 for a destructive tool on a later page", asked)
+		}
+	})
+}
+
+// connectTestClient connects a client to server over in-memory transports.
+func connectTestClient(t *testing.T, server *mcp.Server, opts *mcp.ClientOptions) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	st, ct := mcp.NewInMemoryTransports()
+	ss, err := server.Connect(ctx, st, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { ss.Close() })
+	cs, err := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"}, opts).Connect(ctx, ct, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { cs.Close() })
+	return cs
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+)
+
+// ErrorDetailsMiddleware returns MCP SDK middleware that adds the
+// machine-readable ErrorDetail of a failed tools/call to the result's
+// structured content, as {"error": {...}}. When the user must
+// re-authenticate it fills in the re-auth URL, and for missing scopes the
+// scopes of the tool's service. toolService maps a tool name to its service;
+// readOnly selects the read-only scopes.
+//
+// Install it directly above the tool handlers, before any middleware that
+// rewrites the error text.
+func ErrorDetailsMiddleware(oauthMgr *auth.OAuthManager, toolService func(string) (string, bool), readOnly bool) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			if method != "tools/call" || err != nil {
+				return result, err
+			}
+			toolResult, ok := result.(*mcp.CallToolResult)
+			if !ok || toolResult == nil || !toolResult.IsError || toolResult.StructuredContent != nil || len(toolResult.Content) == 0 {
+				return result, err
+			}
+			text, ok := toolResult.Content[0].(*mcp.TextContent)
+			if !ok {
+				return result, err
+			}
+			detail, ok := recentErrors.get(text.Text)
+			if !ok {
+				return result, err
+			}
+
+			if detail.scopeMissing {
+				detail.RequiredScopes = toolScopes(req, toolService, readOnly)
+			}
+			if detail.ReauthRequired && oauthMgr != nil {
+				detail.ReauthURL = AuthURL(oauthMgr, req, extractUserEmail(req))
+			}
+			toolResult.StructuredContent = map[string]ErrorDetail{"error": detail}
+			return result, err
+		}
+	}
+}
+
+// toolScopes returns the OAuth scopes of the called tool's service.
+func toolScopes(req mcp.Request, toolService func(string) (string, bool), readOnly bool) []string {
+	params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+	if !ok || toolService == nil {
+		return nil
+	}
+	service, ok := toolService(params.Name)
+	if !ok {
+		return nil
+	}
+	return auth.ScopesFor(service, readOnly)
+}
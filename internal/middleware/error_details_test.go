@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/googleapi"
+)
+
+func TestGoogleErrorDetail(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *googleapi.Error
+		want ErrorDetail
+	}{
+		{
+			name: "400 legacy reason",
+			err:  &googleapi.Error{Code: 400, Errors: []googleapi.ErrorItem{{Reason: "invalid"}}},
+			want: ErrorDetail{Status: 400, Reason: "invalid"},
+		},
+		{
+			name: "401",
+			err:  &googleapi.Error{Code: 401},
+			want: ErrorDetail{Status: 401, ReauthRequired: true},
+		},
+		{
+			name: "403 insufficient scope",
+			err: &googleapi.Error{Code: 403, Message: "Request had insufficient authentication scopes.", Details: []any{
+				map[string]any{"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "ACCESS_TOKEN_SCOPE_INSUFFICIENT"},
+			}},
+			want: ErrorDetail{Status: 403, Reason: "ACCESS_TOKEN_SCOPE_INSUFFICIENT", ReauthRequired: true, scopeMissing: true},
+		},
+		{
+			name: "403 user rate limit",
+			err:  &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "userRateLimitExceeded"}}},
+			want: ErrorDetail{Status: 403, Reason: "userRateLimitExceeded", Retryable: true},
+		},
+		{
+			name: "403 forbidden",
+			err:  &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}},
+			want: ErrorDetail{Status: 403, Reason: "forbidden"},
+		},
+		{
+			name: "404",
+			err:  &googleapi.Error{Code: 404},
+			want: ErrorDetail{Status: 404},
+		},
+		{
+			name: "429",
+			err:  &googleapi.Error{Code: 429},
+			want: ErrorDetail{Status: 429, Retryable: true},
+		},
+		{
+			name: "503",
+			err:  &googleapi.Error{Code: 503},
+			want: ErrorDetail{Status: 503, Retryable: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := googleErrorDetail(tt.err)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorDetailsMiddleware(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+	toolService := func(tool string) (string, bool) { return "gmail", tool == "probe" }
+	server.AddReceivingMiddleware(ErrorDetailsMiddleware(testOAuthMgr(), toolService, true))
+	type input struct {
+		UserEmail string `json:"user_google_email"`
+		Code      int    `json:"code"`
+	}
+	mcp.AddTool(server, &mcp.Tool{Name: "probe"}, func(_ context.Context, _ *mcp.CallToolRequest, in input) (*mcp.CallToolResult, any, error) {
+		if in.Code == 0 {
+			return nil, nil, fmt.Errorf("plain failure")
+		}
+		return nil, nil, HandleGoogleAPIError(&googleapi.Error{Code: in.Code, Message: "Request had insufficient authentication scopes."})
+	})
+	cs := connectTestClient(t, server, nil)
+
+	call := func(code int) *mcp.CallToolResult {
+		t.Helper()
+		res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+			Name:      "probe",
+			Arguments: map[string]any{"user_google_email": "a@example.com", "code": code},
+		})
+		if err != nil || !res.IsError {
+			t.Fatalf("CallTool: %v, %+v", err, res)
+		}
+		return res
+	}
+
+	res := call(403)
+	var got struct{ Error ErrorDetail }
+	if err := json.Unmarshal(mustJSON(t, res.StructuredContent), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Error.Status != 403 || !got.Error.ReauthRequired || got.Error.Retryable {
+		t.Errorf("detail = %+v", got.Error)
+	}
+	if len(got.Error.RequiredScopes) != 1 || !strings.HasSuffix(got.Error.RequiredScopes[0], "gmail.readonly") {
+		t.Errorf("required scopes = %v, want the read-only Gmail scope", got.Error.RequiredScopes)
+	}
+	if !strings.Contains(got.Error.ReauthURL, "test-client-id") {
+		t.Errorf("reauth URL = %q", got.Error.ReauthURL)
+	}
+	if !strings.Contains(res.Content[0].(*mcp.TextContent).Text, "permission denied") {
+		t.Errorf("text content changed: %v", res.Content[0])
+	}
+
+	if res := call(503); !strings.Contains(string(mustJSON(t, res.StructuredContent)), `"retryable":true`) {
+		t.Errorf("503 detail = %s", mustJSON(t, res.StructuredContent))
+	}
+	if res := call(0); res.StructuredContent != nil {
+		t.Errorf("non-Google error got structured content %v", res.StructuredContent)
+	}
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
@@ -4,11 +4,41 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"golang.org/x/oauth2"
 	"google.golang.org/api/googleapi"
 )
 
+// ErrorDetail is the machine-readable part of a translated Google API error.
+// ErrorDetailsMiddleware returns it as the tool result's structured content
+// under "error", so agents can decide to retry, re-authenticate, or give up
+// without parsing the message.
+type ErrorDetail struct {
+	// Status is the HTTP status Google returned; 0 for OAuth token errors.
+	Status int `json:"status,omitempty"`
+	// Reason is Google's machine-readable reason, e.g. "rateLimitExceeded".
+	Reason    string `json:"reason,omitempty"`
+	Retryable bool   `json:"retryable"`
+	// ReauthRequired is set when the user must authorize again, because
+	// the token is invalid or lacks a scope the tool needs.
+	ReauthRequired bool     `json:"reauth_required,omitempty"`
+	RequiredScopes []string `json:"required_scopes,omitempty"`
+	ReauthURL      string   `json:"reauth_url,omitempty"`
+
+	// scopeMissing marks a 403 caused by an insufficient token scope.
+	scopeMissing bool
+}
+
+// APIError is a Google API error translated into an agent-actionable
+// message, with its machine-readable detail.
+type APIError struct {
+	Detail ErrorDetail
+	msg    string
+}
+
+func (e *APIError) Error() string { return e.msg }
+
 // HandleGoogleAPIError translates Google API errors into agent-actionable messages.
 // These messages tell the AI what to do next, not the end user.
 func HandleGoogleAPIError(err error) error {
@@ -18,59 +48,144 @@ func HandleGoogleAPIError(err error) error {
 
 	var retrieveErr *oauth2.RetrieveError
 	if errors.As(err, &retrieveErr) {
-		return fmt.Errorf(
+		return newAPIError(ErrorDetail{Reason: retrieveErr.ErrorCode, ReauthRequired: true}, fmt.Sprintf(
 			"google oauth token error — refresh or consent may be invalid (%s). "+
 				"Call start_google_auth for this user; if the client hides the link, copy the URL from this MCP server's stderr / MCP logs output",
 			retrieveErr.Error(),
-		)
+		))
 	}
 
 	var googleErr *googleapi.Error
 	if errors.As(err, &googleErr) {
-		switch googleErr.Code {
-		case 400:
-			return fmt.Errorf(
-				"bad request — check that all required parameters are provided and valid. Detail: %s",
-				googleErr.Message)
-		case 401:
-			return fmt.Errorf(
-				"authentication expired for this user — call start_google_auth tool to re-authenticate, " +
-					"or verify the OAuth configuration is correct. If the host hides tool text, use the OAuth URL printed to this server's stderr / MCP logs")
-		case 403:
-			msg := googleErr.Message
-			lower := strings.ToLower(msg)
-
-			// Detect Google Workspace admin policy restrictions on sharing
-			if strings.Contains(lower, "sharing outside") ||
-				strings.Contains(lower, "not allowed to share") ||
-				(strings.Contains(lower, "insufficient permissions") && strings.Contains(lower, "parent")) {
-				return fmt.Errorf(
-					"permission denied — this may be restricted by your organization's Google Workspace policy "+
-						"(e.g., sharing outside the domain is disabled, or you lack write access to the target folder). "+
-						"Detail: %s", msg)
-			}
+		return newAPIError(googleErrorDetail(googleErr), googleErrorMessage(googleErr))
+	}
 
-			return fmt.Errorf(
-				"permission denied — the required OAuth scope may not be granted. "+
-					"Suggest the user re-authenticate with broader scopes. Detail: %s", msg)
-		case 404:
-			return fmt.Errorf(
-				"resource not found — verify the ID is correct and the user has access to it")
-		case 409:
-			return fmt.Errorf(
-				"conflict — the resource was modified by another process. Retry with the latest version. Detail: %s",
-				googleErr.Message)
-		case 429:
-			return fmt.Errorf(
-				"rate limit exceeded for this Google API — wait 30-60 seconds before retrying this tool call")
-		case 500, 502, 503:
-			return fmt.Errorf(
-				"Google API server error (%d) — this is a transient issue, retry after a few seconds. Detail: %s",
-				googleErr.Code, googleErr.Message)
-		default:
-			return fmt.Errorf("Google API error (%d): %s", googleErr.Code, googleErr.Message)
+	return err
+}
+
+// googleErrorMessage returns the agent-actionable message for a Google API
+// error.
+func googleErrorMessage(googleErr *googleapi.Error) string {
+	switch googleErr.Code {
+	case 400:
+		return fmt.Sprintf(
+			"bad request — check that all required parameters are provided and valid. Detail: %s",
+			googleErr.Message)
+	case 401:
+		return "authentication expired for this user — call start_google_auth tool to re-authenticate, " +
+			"or verify the OAuth configuration is correct. If the host hides tool text, use the OAuth URL printed to this server's stderr / MCP logs"
+	case 403:
+		msg := googleErr.Message
+		if isPolicyRestriction(msg) {
+			return fmt.Sprintf(
+				"permission denied — this may be restricted by your organization's Google Workspace policy "+
+					"(e.g., sharing outside the domain is disabled, or you lack write access to the target folder). "+
+					"Detail: %s", msg)
 		}
+		return fmt.Sprintf(
+			"permission denied — the required OAuth scope may not be granted. "+
+				"Suggest the user re-authenticate with broader scopes. Detail: %s", msg)
+	case 404:
+		return "resource not found — verify the ID is correct and the user has access to it"
+	case 409:
+		return fmt.Sprintf(
+			"conflict — the resource was modified by another process. Retry with the latest version. Detail: %s",
+			googleErr.Message)
+	case 429:
+		return "rate limit exceeded for this Google API — wait 30-60 seconds before retrying this tool call"
+	case 500, 502, 503:
+		return fmt.Sprintf(
+			"Google API server error (%d) — this is a transient issue, retry after a few seconds. Detail: %s",
+			googleErr.Code, googleErr.Message)
+	default:
+		return fmt.Sprintf("Google API error (%d): %s", googleErr.Code, googleErr.Message)
 	}
+}
 
-	return err
+// isPolicyRestriction detects Google Workspace admin policy restrictions on
+// sharing in a 403 message.
+func isPolicyRestriction(msg string) bool {
+	lower := strings.ToLower(msg)
+	return strings.Contains(lower, "sharing outside") ||
+		strings.Contains(lower, "not allowed to share") ||
+		(strings.Contains(lower, "insufficient permissions") && strings.Contains(lower, "parent"))
+}
+
+// googleErrorDetail classifies a Google API error for ErrorDetail.
+func googleErrorDetail(googleErr *googleapi.Error) ErrorDetail {
+	d := ErrorDetail{Status: googleErr.Code, Reason: googleReason(googleErr)}
+	switch googleErr.Code {
+	case 401:
+		d.ReauthRequired = true
+	case 403:
+		d.scopeMissing = d.Reason == "ACCESS_TOKEN_SCOPE_INSUFFICIENT" ||
+			strings.Contains(strings.ToLower(googleErr.Message), "insufficient authentication scopes")
+		d.ReauthRequired = d.scopeMissing
+		// Per-user quota errors are 403s that clear up on their own.
+		d.Retryable = d.Reason == "rateLimitExceeded" || d.Reason == "userRateLimitExceeded"
+	case 409, 429, 500, 502, 503, 504:
+		d.Retryable = true
+	}
+	return d
+}
+
+// googleReason returns the error's machine-readable reason: the ErrorInfo
+// reason of newer APIs, else the first legacy error item's reason.
+func googleReason(googleErr *googleapi.Error) string {
+	for _, d := range googleErr.Details {
+		if info, ok := d.(map[string]any); ok {
+			if reason, ok := info["reason"].(string); ok && strings.HasSuffix(fmt.Sprint(info["@type"]), "google.rpc.ErrorInfo") {
+				return reason
+			}
+		}
+	}
+	if len(googleErr.Errors) > 0 {
+		return googleErr.Errors[0].Reason
+	}
+	return ""
+}
+
+// newAPIError returns an APIError and records its detail for
+// ErrorDetailsMiddleware.
+func newAPIError(detail ErrorDetail, msg string) *APIError {
+	recentErrors.put(msg, detail)
+	return &APIError{Detail: detail, msg: msg}
+}
+
+// recentErrors maps the messages of recent APIErrors to their detail. The
+// SDK turns a tool handler's error into text content and keeps the error
+// value out of reach of middleware, so ErrorDetailsMiddleware finds the
+// detail by the result text. Concurrent calls failing with the same message
+// share an entry; their status and retry advice match, and only the reason
+// may come from the other call.
+var recentErrors = &errorDetails{byMsg: make(map[string]ErrorDetail)}
+
+// maxRecentErrors bounds recentErrors; a result is looked up right after its
+// handler returns, so only a handful of entries are ever needed.
+const maxRecentErrors = 256
+
+type errorDetails struct {
+	mu    sync.Mutex
+	byMsg map[string]ErrorDetail
+	order []string
+}
+
+func (e *errorDetails) put(msg string, d ErrorDetail) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.byMsg[msg]; !ok {
+		e.order = append(e.order, msg)
+	}
+	e.byMsg[msg] = d
+	if len(e.order) > maxRecentErrors {
+		delete(e.byMsg, e.order[0])
+		e.order = e.order[1:]
+	}
+}
+
+func (e *errorDetails) get(msg string) (ErrorDetail, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	d, ok := e.byMsg[msg]
+	return d, ok
 }
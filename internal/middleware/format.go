@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+)
+
+// responseFormatArg is the tool argument that overrides the server's
+// response format for one call.
+const responseFormatArg = "response_format"
+
+// ResponseFormatMiddleware returns MCP SDK middleware that presents
+// successful tool results in the requested response.Format: the call's
+// response_format argument, else def. It advertises the argument in every
+// tool's input schema and removes it from the arguments before the tool
+// sees them.
+func ResponseFormatMiddleware(def response.Format) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method == "tools/list" {
+				result, err := next(ctx, method, req)
+				if list, ok := result.(*mcp.ListToolsResult); ok && err == nil {
+					for i, tool := range list.Tools {
+						list.Tools[i] = withFormatArg(tool)
+					}
+				}
+				return result, err
+			}
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if method != "tools/call" || !ok {
+				return next(ctx, method, req)
+			}
+
+			format, err := takeFormatArg(params, def)
+			if err != nil {
+				return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}}, nil
+			}
+			result, err := next(ctx, method, req)
+			if toolResult, ok := result.(*mcp.CallToolResult); ok && toolResult != nil && err == nil && !toolResult.IsError {
+				return formatResult(toolResult, format), nil
+			}
+			return result, err
+		}
+	}
+}
+
+// takeFormatArg removes response_format from the call's arguments and
+// returns the format to use.
+func takeFormatArg(params *mcp.CallToolParamsRaw, def response.Format) (response.Format, error) {
+	var args map[string]json.RawMessage
+	if err := json.Unmarshal(params.Arguments, &args); err != nil {
+		return def, nil
+	}
+	raw, ok := args[responseFormatArg]
+	if !ok {
+		return def, nil
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		return "", fmt.Errorf("%s must be a string: text, markdown, or json", responseFormatArg)
+	}
+	format, err := response.ParseFormat(name)
+	if err != nil {
+		return "", err
+	}
+
+	delete(args, responseFormatArg)
+	stripped, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("re-encoding arguments: %w", err)
+	}
+	params.Arguments = stripped
+	return format, nil
+}
+
+// formatResult returns a copy of res in format. Results may be shared with
+// the response cache, so res is never modified.
+func formatResult(res *mcp.CallToolResult, format response.Format) *mcp.CallToolResult {
+	if format == response.FormatText || (format == response.FormatJSON && res.StructuredContent == nil) {
+		return res
+	}
+	copied := *res
+	copied.Content = make([]mcp.Content, 0, len(res.Content))
+	for _, c := range res.Content {
+		text, ok := c.(*mcp.TextContent)
+		switch {
+		case !ok:
+			copied.Content = append(copied.Content, c)
+		case format == response.FormatMarkdown:
+			md := *text
+			md.Text = response.Markdown(text.Text)
+			copied.Content = append(copied.Content, &md)
+		}
+	}
+	return &copied
+}
+
+// withFormatArg returns a copy of tool whose input schema accepts
+// response_format. Tool definitions are shared, so tool is never modified.
+func withFormatArg(tool *mcp.Tool) *mcp.Tool {
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	if !ok || schema == nil {
+		return tool
+	}
+	s := *schema
+	s.Properties = maps.Clone(schema.Properties)
+	if s.Properties == nil {
+		s.Properties = make(map[string]*jsonschema.Schema)
+	}
+	s.Properties[responseFormatArg] = &jsonschema.Schema{
+		Type:        "string",
+		Enum:        []any{"text", "markdown", "json"},
+		Description: "How to present the result: text, markdown (headings, tables, lists), or json (structured output only, no text block). Defaults to the server setting",
+	}
+	copied := *tool
+	copied.InputSchema = &s
+	return &copied
+}
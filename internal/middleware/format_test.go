@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+)
+
+func TestResponseFormatMiddleware(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+	server.AddReceivingMiddleware(ResponseFormatMiddleware(response.FormatText))
+	type input struct {
+		Name string `json:"name"`
+	}
+	type output struct {
+		Name string `json:"name"`
+	}
+	shared := response.New().Header("Probe").KeyValue("Name", "x").TextResult()
+	mcp.AddTool(server, &mcp.Tool{Name: "probe"}, func(_ context.Context, _ *mcp.CallToolRequest, in input) (*mcp.CallToolResult, output, error) {
+		return shared, output{Name: in.Name}, nil
+	})
+	cs := connectTestClient(t, server, nil)
+
+	call := func(format string) *mcp.CallToolResult {
+		t.Helper()
+		args := map[string]any{"name": "x"}
+		if format != "" {
+			args["response_format"] = format
+		}
+		res, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: "probe", Arguments: args})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	if res := call(""); res.Content[0].(*mcp.TextContent).Text != "═══ Probe ═══\n• Name: x\n" {
+		t.Errorf("default text = %q", res.Content[0].(*mcp.TextContent).Text)
+	}
+	if res := call("markdown"); !strings.Contains(res.Content[0].(*mcp.TextContent).Text, "| Name | x |") {
+		t.Errorf("markdown = %q", res.Content[0].(*mcp.TextContent).Text)
+	}
+	if res := call("json"); len(res.Content) != 0 || res.StructuredContent == nil {
+		t.Errorf("json: content %v, structured %v; want structured output only", res.Content, res.StructuredContent)
+	}
+	if res := call("html"); !res.IsError {
+		t.Error("unknown format: want error result")
+	}
+	if got := shared.Content[0].(*mcp.TextContent).Text; !strings.HasPrefix(got, "═══") {
+		t.Errorf("handler's result was modified: %q", got)
+	}
+
+	tools, err := cs.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(mustJSON(t, tools.Tools[0].InputSchema), &schema); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := schema.Properties["response_format"]; !ok {
+		t.Errorf("tools/list schema lacks response_format: %v", schema.Properties)
+	}
+}
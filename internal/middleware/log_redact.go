@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// logEmailRE matches email addresses in log values.
+var logEmailRE = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+
+const redactedEmail = "[redacted email]"
+
+// maxLoggedContent caps the tool result text written to debug logs.
+const maxLoggedContent = 4096
+
+// contentArgs are tool argument names whose values are message bodies or
+// document content. With PII redaction they are logged as their length only.
+var contentArgs = map[string]bool{
+	"body": true, "html_body": true, "content": true, "text": true,
+	"message": true, "raw": true, "values": true, "requests": true,
+	"description": true, "notes": true, "comment": true, "reply": true,
+	"markdown": true, "html": true, "questions": true, "contacts": true,
+}
+
+// RedactLogAttr is a slog.HandlerOptions.ReplaceAttr function that masks
+// email addresses in string and error log attributes.
+func RedactLogAttr(_ []string, a slog.Attr) slog.Attr {
+	switch v := a.Value.Any().(type) {
+	case string:
+		a.Value = slog.StringValue(logEmailRE.ReplaceAllLiteralString(v, redactedEmail))
+	case error:
+		a.Value = slog.StringValue(logEmailRE.ReplaceAllLiteralString(v.Error(), redactedEmail))
+	}
+	return a
+}
+
+// logArguments returns tool arguments for a debug log. With redactPII, email
+// addresses are masked and body or content arguments are replaced by their
+// length; IDs, numbers, and flags are kept.
+func logArguments(raw json.RawMessage, redactPII bool) any {
+	var args any
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return fmt.Sprintf("[%d bytes, not JSON]", len(raw))
+	}
+	if !redactPII {
+		return args
+	}
+	return redactLogValue("", args)
+}
+
+func redactLogValue(key string, v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, child := range val {
+			val[k] = redactLogValue(k, child)
+		}
+		return val
+	case []any:
+		if contentArgs[key] {
+			return fmt.Sprintf("[redacted %d items]", len(val))
+		}
+		for i, child := range val {
+			val[i] = redactLogValue(key, child)
+		}
+		return val
+	case string:
+		if contentArgs[key] || strings.HasSuffix(key, "_body") || strings.HasSuffix(key, "_content") {
+			return fmt.Sprintf("[redacted %d chars]", len(val))
+		}
+		return logEmailRE.ReplaceAllLiteralString(val, redactedEmail)
+	default:
+		return v
+	}
+}
+
+// logResultContent returns the text content of a tool result for a debug
+// log. With redactPII only the length of each text block is kept.
+func logResultContent(res *mcp.CallToolResult, redactPII bool) []string {
+	out := make([]string, 0, len(res.Content))
+	for _, c := range res.Content {
+		tc, ok := c.(*mcp.TextContent)
+		switch {
+		case !ok:
+			out = append(out, fmt.Sprintf("[%T]", c))
+		case redactPII:
+			out = append(out, fmt.Sprintf("[redacted %d chars]", len(tc.Text)))
+		case len(tc.Text) > maxLoggedContent:
+			out = append(out, tc.Text[:maxLoggedContent]+"…[truncated]")
+		default:
+			out = append(out, tc.Text)
+		}
+	}
+	return out
+}
@@ -3,36 +3,82 @@ package middleware
 import (
 	"context"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/tracing"
 )
 
 // LoggingMiddleware returns MCP SDK middleware that logs incoming requests
-// and outgoing responses using structured logging.
-func LoggingMiddleware(logger *slog.Logger) mcp.Middleware {
+// and outgoing responses using structured logging. Each request also runs
+// in an OpenTelemetry span; for tools/call the span covers the tool handler,
+// so Google API spans started beneath it nest under the tool call.
+//
+// At debug level tool arguments and result text are logged too. With
+// redactPII, email addresses are masked and message bodies and document
+// content are reduced to their lengths, keeping IDs for correlation.
+func LoggingMiddleware(logger *slog.Logger, redactPII bool) mcp.Middleware {
 	return func(next mcp.MethodHandler) mcp.MethodHandler {
 		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			ctx, span := startRequestSpan(ctx, method, req)
+			defer span.End()
+
+			attrs := []any{"method", method}
+			if sc := span.SpanContext(); sc.IsValid() {
+				attrs = append(attrs, "trace_id", sc.TraceID().String())
+			}
+
 			start := time.Now()
-			logger.InfoContext(ctx, "handling request", "method", method)
+			logger.InfoContext(ctx, "handling request", attrs...)
+			debug := logger.Enabled(ctx, slog.LevelDebug)
+			if params, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok && debug {
+				logger.DebugContext(ctx, "tool arguments", slices.Concat(attrs,
+					[]any{"tool", params.Name, "arguments", logArguments(params.Arguments, redactPII)})...)
+			}
 
 			result, err := next(ctx, method, req)
 
-			duration := time.Since(start)
+			attrs = append(attrs, "duration", time.Since(start))
 			if err != nil {
-				logger.ErrorContext(ctx, "request failed",
-					"method", method,
-					"duration", duration,
-					"error", err,
-				)
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				logger.ErrorContext(ctx, "request failed", append(attrs, "error", err)...)
 			} else {
-				logger.InfoContext(ctx, "request completed",
-					"method", method,
-					"duration", duration,
-				)
+				if r, ok := result.(*mcp.CallToolResult); ok {
+					if r.IsError {
+						span.SetStatus(codes.Error, "tool returned an error result")
+					}
+					if debug {
+						logger.DebugContext(ctx, "tool result", slices.Concat(attrs,
+							[]any{"is_error", r.IsError, "content", logResultContent(r, redactPII)})...)
+					}
+				}
+				logger.InfoContext(ctx, "request completed", attrs...)
 			}
 
 			return result, err
 		}
 	}
 }
+
+// startRequestSpan starts the span for one MCP request. Tool calls are named
+// after the tool and carry the user's email.
+func startRequestSpan(ctx context.Context, method string, req mcp.Request) (context.Context, trace.Span) {
+	name := method
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(tracing.AttrMethod.String(method)),
+	}
+	if params, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok {
+		name = method + " " + params.Name
+		opts = append(opts, trace.WithAttributes(tracing.AttrTool.String(params.Name)))
+		if user := extractUserEmail(req); user != "" {
+			opts = append(opts, trace.WithAttributes(tracing.AttrUser.String(user)))
+		}
+	}
+	return tracing.Tracer().Start(ctx, name, opts...)
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestLoggingMiddleware_Spans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	toolCall := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{
+		Name:      "get_doc_content",
+		Arguments: json.RawMessage(`{"user_google_email":"alice@example.com"}`),
+	}}
+
+	tests := []struct {
+		name       string
+		method     string
+		req        mcp.Request
+		result     mcp.Result
+		err        error
+		wantName   string
+		wantStatus codes.Code
+		wantUser   string
+	}{
+		{"tool success", "tools/call", toolCall, &mcp.CallToolResult{}, nil, "tools/call get_doc_content", codes.Unset, "alice@example.com"},
+		{"tool error result", "tools/call", toolCall, &mcp.CallToolResult{IsError: true}, nil, "tools/call get_doc_content", codes.Error, "alice@example.com"},
+		{"handler error", "tools/call", toolCall, nil, errors.New("boom"), "tools/call get_doc_content", codes.Error, "alice@example.com"},
+		{"other method", "tools/list", &mcp.ListToolsRequest{Params: &mcp.ListToolsParams{}}, &mcp.ListToolsResult{}, nil, "tools/list", codes.Unset, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.Reset()
+			var inner context.Context
+			handler := LoggingMiddleware(logger, false)(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+				inner = ctx
+				return tt.result, tt.err
+			})
+			_, _ = handler(context.Background(), tt.method, tt.req)
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != tt.wantName {
+				t.Errorf("span name = %q, want %q", span.Name(), tt.wantName)
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("span status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+			var user string
+			for _, a := range span.Attributes() {
+				if a.Key == "enduser.id" {
+					user = a.Value.AsString()
+				}
+			}
+			if user != tt.wantUser {
+				t.Errorf("enduser.id = %q, want %q", user, tt.wantUser)
+			}
+			if !trace.SpanContextFromContext(inner).IsValid() {
+				t.Error("handler context does not carry the request span")
+			}
+		})
+	}
+}
+
+func TestLoggingMiddleware_DebugRedaction(t *testing.T) {
+	args := `{"user_google_email":"alice@example.com","document_id":"1AbC","query":"from:bob@example.com","body":"Dear Bob, salary attached","to":["carol@example.com"],"max_results":5}`
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "send_gmail_message", Arguments: json.RawMessage(args)}}
+	result := &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Sent to carol@example.com"}}}
+
+	tests := []struct {
+		name      string
+		redactPII bool
+		want      []string
+		wantNot   []string
+	}{
+		{
+			name: "plain",
+			want: []string{"alice@example.com", "Dear Bob, salary attached", "Sent to carol@example.com", "1AbC"},
+		},
+		{
+			name:      "redacted",
+			redactPII: true,
+			want:      []string{"1AbC", "[redacted email]", "from:[redacted email]", "[redacted 25 chars]", `"max_results":5`},
+			wantNot:   []string{"@example.com", "salary", "Sent to"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+			if tt.redactPII {
+				opts.ReplaceAttr = RedactLogAttr
+			}
+			logger := slog.New(slog.NewJSONHandler(&buf, opts))
+			handler := LoggingMiddleware(logger, tt.redactPII)(func(context.Context, string, mcp.Request) (mcp.Result, error) {
+				return result, nil
+			})
+			_, _ = handler(context.Background(), "tools/call", req)
+
+			out := buf.String()
+			for _, s := range tt.want {
+				if !strings.Contains(out, s) {
+					t.Errorf("log missing %q:\n%s", s, out)
+				}
+			}
+			for _, s := range tt.wantNot {
+				if strings.Contains(out, s) {
+					t.Errorf("log contains %q:\n%s", s, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactLogAttr(t *testing.T) {
+	tests := []struct {
+		name string
+		attr slog.Attr
+		want string
+	}{
+		{"string", slog.String("email", "alice@example.com"), "[redacted email]"},
+		{"error", slog.Any("error", errors.New("no token for bob@example.com")), "no token for [redacted email]"},
+		{"id kept", slog.String("file_id", "1AbC_dEf"), "1AbC_dEf"},
+		{"number kept", slog.Int("count", 3), "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactLogAttr(nil, tt.attr).Value.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// bucketIdleTTL is how long a bucket may sit unused before it is pruned.
+const bucketIdleTTL = 10 * time.Minute
+
+// Rate is a token-bucket rate: QPS tokens are added per second up to Burst.
+// A zero QPS means unlimited.
+type Rate struct {
+	QPS   float64
+	Burst int
+}
+
+type bucketKey struct{ service, user string }
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter is a token-bucket limiter keyed by (service, user email), so
+// one account hammering one API cannot exhaust the project's Google quota
+// for everyone else.
+type RateLimiter struct {
+	def        Rate
+	perService map[string]Rate
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+// NewRateLimiter creates a limiter applying def to every service not listed
+// in perService.
+func NewRateLimiter(def Rate, perService map[string]Rate) *RateLimiter {
+	return &RateLimiter{
+		def:        def,
+		perService: perService,
+		buckets:    make(map[bucketKey]*bucket),
+		now:        time.Now,
+	}
+}
+
+// Allow takes one token from the (service, user) bucket. When the bucket is
+// empty it returns false and how long until a token is available.
+func (l *RateLimiter) Allow(service, user string) (bool, time.Duration) {
+	rate, ok := l.perService[service]
+	if !ok {
+		rate = l.def
+	}
+	if rate.QPS <= 0 {
+		return true, 0
+	}
+	burst := float64(max(rate.Burst, 1))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.prune(now)
+
+	key := bucketKey{service, user}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate.QPS)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / rate.QPS * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// prune drops buckets idle for longer than bucketIdleTTL; a new bucket
+// starts full, so forgetting them changes nothing.
+func (l *RateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < bucketIdleTTL {
+		return
+	}
+	l.lastPrune = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > bucketIdleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimitMiddleware returns MCP SDK middleware that rejects tools/call
+// requests exceeding the limiter's rate for the tool's service and the
+// request's user_google_email. serviceOf maps a tool name to its service;
+// tools without a service are not limited.
+func RateLimitMiddleware(l *RateLimiter, serviceOf func(tool string) (string, bool)) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if !ok {
+				return next(ctx, method, req)
+			}
+			service, ok := serviceOf(params.Name)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			if allowed, wait := l.Allow(service, extractUserEmail(req)); !allowed {
+				return &mcp.CallToolResult{
+					IsError: true,
+					Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf(
+						"rate limit exceeded for %s tools on this server — wait %.1f seconds before calling %s again, and avoid calling tools in a tight loop",
+						service, math.Max(wait.Seconds(), 0.1), params.Name,
+					)}},
+				}, nil
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
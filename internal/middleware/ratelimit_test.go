@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(Rate{QPS: 2, Burst: 3}, map[string]Rate{"search": {QPS: 0}})
+	l.now = func() time.Time { return now }
+
+	for i := range 3 {
+		if ok, _ := l.Allow("gmail", "a@example.com"); !ok {
+			t.Fatalf("call %d within burst was rejected", i+1)
+		}
+	}
+	ok, wait := l.Allow("gmail", "a@example.com")
+	if ok || wait != 500*time.Millisecond {
+		t.Errorf("over burst: got ok=%v wait=%v, want rejected with 500ms", ok, wait)
+	}
+
+	if ok, _ := l.Allow("gmail", "b@example.com"); !ok {
+		t.Error("other users must have their own bucket")
+	}
+	if ok, _ := l.Allow("drive", "a@example.com"); !ok {
+		t.Error("other services must have their own bucket")
+	}
+	for range 10 {
+		if ok, _ := l.Allow("search", "a@example.com"); !ok {
+			t.Fatal("zero QPS override must disable limiting")
+		}
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if ok, _ := l.Allow("gmail", "a@example.com"); !ok {
+		t.Error("token should have refilled after 500ms")
+	}
+}
+
+func TestRateLimiterPrune(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(Rate{QPS: 1, Burst: 1}, nil)
+	l.now = func() time.Time { return now }
+
+	l.Allow("gmail", "a@example.com")
+	now = now.Add(2 * bucketIdleTTL)
+	l.Allow("gmail", "b@example.com")
+
+	if len(l.buckets) != 1 {
+		t.Errorf("idle bucket not pruned: %d buckets", len(l.buckets))
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	l := NewRateLimiter(Rate{QPS: 1, Burst: 1}, nil)
+	serviceOf := func(tool string) (string, bool) { return "gmail", tool == "search_gmail_messages" }
+	calls := 0
+	handler := RateLimitMiddleware(l, serviceOf)(func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		calls++
+		return &mcp.CallToolResult{}, nil
+	})
+
+	req := fakeToolRequest(`{"user_google_email":"user@test.com"}`)
+	if _, err := handler(context.Background(), "tools/call", req); err != nil || calls != 1 {
+		t.Fatalf("first call: err=%v calls=%d", err, calls)
+	}
+	result, err := handler(context.Background(), "tools/call", req)
+	if err != nil || calls != 1 {
+		t.Fatalf("second call should be rejected: err=%v calls=%d", err, calls)
+	}
+	toolResult := result.(*mcp.CallToolResult)
+	if !toolResult.IsError || !strings.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "rate limit exceeded") {
+		t.Errorf("unexpected result: %+v", toolResult)
+	}
+
+	other := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "start_google_auth", Arguments: []byte(`{}`)}}
+	for range 3 {
+		if _, err := handler(context.Background(), "tools/call", other); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 4 {
+		t.Errorf("tools without a service must not be limited, calls=%d", calls)
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RecoveryMiddleware returns MCP SDK middleware that turns a panic in the
+// handler beneath it into an error instead of crashing the server. A
+// panicking tools/call gets an IsError result; other methods get a JSON-RPC
+// error. The panic value and stack go to the log only, so internal details
+// never reach the client.
+func RecoveryMiddleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (result mcp.Result, err error) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					return
+				}
+				tool := ""
+				if params, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok {
+					tool = params.Name
+				}
+				slog.ErrorContext(ctx, "recovered from panic in request handler",
+					"method", method,
+					"tool", tool,
+					"panic", fmt.Sprint(r),
+					"stack", string(debug.Stack()),
+				)
+				if method != "tools/call" {
+					result, err = nil, fmt.Errorf("internal server error while handling %s", method)
+					return
+				}
+				result, err = panicResult(tool), nil
+			}()
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// panicResult is the tool result returned in place of a panicking handler.
+func panicResult(tool string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf(
+			"internal error in %s — the server hit a bug handling this call and it may not have completed. "+
+				"Check the resource's state before retrying; the details are in the server logs", tool)}},
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	type nilTyped struct{ name string }
+	tests := []struct {
+		name     string
+		method   string
+		req      mcp.Request
+		handler  mcp.MethodHandler
+		wantErr  bool
+		wantText string
+	}{
+		{
+			name:   "tool panic becomes error result",
+			method: "tools/call",
+			req:    &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_events"}},
+			handler: func(context.Context, string, mcp.Request) (mcp.Result, error) {
+				var p *nilTyped
+				_ = p.name // nil pointer dereference
+				return nil, nil
+			},
+			wantText: "internal error in get_events",
+		},
+		{
+			name:   "other method panic becomes error",
+			method: "tools/list",
+			req:    &mcp.ListToolsRequest{Params: &mcp.ListToolsParams{}},
+			handler: func(context.Context, string, mcp.Request) (mcp.Result, error) {
+				panic("secret detail")
+			},
+			wantErr: true,
+		},
+		{
+			name:   "no panic passes through",
+			method: "tools/call",
+			req:    &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_events"}},
+			handler: func(context.Context, string, mcp.Request) (mcp.Result, error) {
+				return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil
+			},
+			wantText: "ok",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := RecoveryMiddleware()(tt.handler)(context.Background(), tt.method, tt.req)
+			if tt.wantErr {
+				if err == nil || strings.Contains(err.Error(), "secret detail") {
+					t.Errorf("error = %v, want a sanitized error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			res := result.(*mcp.CallToolResult)
+			text := res.Content[0].(*mcp.TextContent).Text
+			if !strings.Contains(text, tt.wantText) {
+				t.Errorf("text = %q, want it to contain %q", text, tt.wantText)
+			}
+			if res.IsError != (tt.wantText != "ok") {
+				t.Errorf("IsError = %v", res.IsError)
+			}
+			if strings.Contains(text, "nil pointer") {
+				t.Errorf("panic detail leaked to the client: %q", text)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/redact"
+)
+
+// RedactionMiddleware returns MCP SDK middleware that masks sensitive values
+// in every tools/call result — text content and structured content alike —
+// so the client and its LLM provider never see them. Installed as sending
+// middleware it also masks log notifications (e.g. Drive watch events).
+func RedactionMiddleware(r *redact.Redactor) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if params, ok := req.GetParams().(*mcp.LoggingMessageParams); ok {
+				params.Data = redactJSON(r, params.Data)
+			}
+
+			result, err := next(ctx, method, req)
+			if method != "tools/call" || err != nil {
+				return result, err
+			}
+			if res, ok := result.(*mcp.CallToolResult); ok {
+				redactToolResult(r, res)
+			}
+			return result, err
+		}
+	}
+}
+
+// redactToolResult masks the text content and structured content of res in place.
+func redactToolResult(r *redact.Redactor, res *mcp.CallToolResult) {
+	for _, c := range res.Content {
+		if tc, ok := c.(*mcp.TextContent); ok {
+			tc.Text = r.String(tc.Text)
+		}
+	}
+	// Structured output is a typed struct. If it cannot be redacted, drop it
+	// rather than leak it — the text content carries the same information.
+	res.StructuredContent = redactJSON(r, res.StructuredContent)
+}
+
+// redactJSON round-trips v through JSON so every string field of a typed
+// value can be masked. It returns nil if v cannot be round-tripped.
+func redactJSON(r *redact.Redactor, v any) any {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	return r.Value(decoded)
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/redact"
+)
+
+func TestRedactionMiddleware(t *testing.T) {
+	r, err := redact.New([]string{"email"}, nil)
+	if err != nil {
+		t.Fatalf("redact.New: %v", err)
+	}
+
+	type output struct {
+		From string `json:"from"`
+	}
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{
+			Content:           []mcp.Content{&mcp.TextContent{Text: "From: a@example.com"}},
+			StructuredContent: output{From: "a@example.com"},
+		}, nil
+	}
+	handler := RedactionMiddleware(r)(next)
+
+	result, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "x"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res := result.(*mcp.CallToolResult)
+	if got := res.Content[0].(*mcp.TextContent).Text; got != "From: [redacted email]" {
+		t.Errorf("text content: got %q", got)
+	}
+	structured, ok := res.StructuredContent.(map[string]any)
+	if !ok || structured["from"] != "[redacted email]" {
+		t.Errorf("structured content: got %#v", res.StructuredContent)
+	}
+}
@@ -0,0 +1,191 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/googleapi"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+)
+
+// ResourceSubscriber handles resources/subscribe and resources/unsubscribe
+// for the URIs of one scheme.
+type ResourceSubscriber interface {
+	Subscribe(ctx context.Context, session *mcp.ServerSession, uri string) error
+	Unsubscribe(ctx context.Context, session *mcp.ServerSession, uri string) error
+}
+
+// Resources is the state MCP resource handlers share. Resource requests
+// carry no user_google_email, so it remembers which Google account each
+// session works with: the account of the session's last successful tool
+// call. It also routes subscriptions to the service owning a URI scheme.
+type Resources struct {
+	store        auth.TokenStore
+	allowedUsers []string
+
+	mu          sync.Mutex
+	users       map[*mcp.ServerSession]string
+	subscribers map[string]ResourceSubscriber
+}
+
+// NewResources creates the shared resource state. store supplies the
+// account of sessions that have not called a tool yet, when it holds
+// exactly one permitted user.
+func NewResources(store auth.TokenStore, allowedUsers []string) *Resources {
+	return &Resources{
+		store:        store,
+		allowedUsers: allowedUsers,
+		users:        make(map[*mcp.ServerSession]string),
+		subscribers:  make(map[string]ResourceSubscriber),
+	}
+}
+
+// User returns the Google account resource requests of session act for.
+func (r *Resources) User(session *mcp.ServerSession) (string, error) {
+	r.mu.Lock()
+	email, ok := r.users[session]
+	r.mu.Unlock()
+	if ok {
+		return email, nil
+	}
+	if email, ok := r.onlyStoredUser(); ok {
+		return email, nil
+	}
+	return "", fmt.Errorf("no Google account is associated with this session yet — call any tool with user_google_email first")
+}
+
+// UserResource parses a user-scoped resource URI of the form
+// scheme://{user}/kind/{id}, such as gmail://alice@example.com/message/123,
+// and checks the account is permitted on this server. The user may be
+// percent-encoded.
+func (r *Resources) UserResource(uri, scheme, kind string) (email, id string, err error) {
+	rest, ok := strings.CutPrefix(uri, scheme+"://")
+	parts := strings.Split(rest, "/")
+	if !ok || len(parts) != 3 || parts[1] != kind || parts[2] == "" {
+		return "", "", mcp.ResourceNotFoundError(uri)
+	}
+	if email, err = url.PathUnescape(parts[0]); err != nil || !strings.Contains(email, "@") {
+		return "", "", mcp.ResourceNotFoundError(uri)
+	}
+	if len(r.allowedUsers) > 0 && !userAllowed(email, r.allowedUsers) {
+		return "", "", fmt.Errorf("account %s is not allowed on this server — contact the server operator", email)
+	}
+	return email, parts[2], nil
+}
+
+// ResourceError translates a Google API error from reading the resource at
+// uri: a 404 becomes the MCP resource-not-found error, anything else the
+// same agent-actionable error tools return.
+func ResourceError(err error, uri string) error {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+		return mcp.ResourceNotFoundError(uri)
+	}
+	return HandleGoogleAPIError(err)
+}
+
+// onlyStoredUser returns the token store's user when it holds exactly one
+// that is permitted on this server.
+func (r *Resources) onlyStoredUser() (string, bool) {
+	lister, ok := r.store.(auth.ListableTokenStore)
+	if !ok {
+		return "", false
+	}
+	entries, err := lister.List()
+	if err != nil || len(entries) != 1 {
+		return "", false
+	}
+	email := entries[0].Email
+	if len(r.allowedUsers) > 0 && !userAllowed(email, r.allowedUsers) {
+		return "", false
+	}
+	return email, true
+}
+
+// Middleware returns MCP SDK middleware that records the account of each
+// successful tools/call for its session. Install it inside the account
+// allowlist so only permitted accounts are recorded.
+func (r *Resources) Middleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			if method != "tools/call" || err != nil {
+				return result, err
+			}
+			if tr, ok := result.(*mcp.CallToolResult); !ok || tr == nil || tr.IsError {
+				return result, err
+			}
+			if email := extractUserEmail(req); email != "" {
+				session, _ := req.GetSession().(*mcp.ServerSession)
+				r.setUser(session, email)
+			}
+			return result, err
+		}
+	}
+}
+
+// setUser records the session's account, forgetting it when the session
+// ends.
+func (r *Resources) setUser(session *mcp.ServerSession, email string) {
+	if session == nil {
+		return
+	}
+	r.mu.Lock()
+	_, known := r.users[session]
+	r.users[session] = email
+	r.mu.Unlock()
+	if !known {
+		go func() {
+			session.Wait()
+			r.mu.Lock()
+			delete(r.users, session)
+			r.mu.Unlock()
+		}()
+	}
+}
+
+// HandleSubscriptions routes subscriptions to URIs of scheme to sub.
+func (r *Resources) HandleSubscriptions(scheme string, sub ResourceSubscriber) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribers[scheme] = sub
+}
+
+// Subscribe is the server's ServerOptions.SubscribeHandler.
+func (r *Resources) Subscribe(ctx context.Context, req *mcp.SubscribeRequest) error {
+	sub, err := r.subscriber(req.Params.URI)
+	if err != nil {
+		return err
+	}
+	return sub.Subscribe(ctx, req.Session, req.Params.URI)
+}
+
+// Unsubscribe is the server's ServerOptions.UnsubscribeHandler.
+func (r *Resources) Unsubscribe(ctx context.Context, req *mcp.UnsubscribeRequest) error {
+	sub, err := r.subscriber(req.Params.URI)
+	if err != nil {
+		return err
+	}
+	return sub.Unsubscribe(ctx, req.Session, req.Params.URI)
+}
+
+func (r *Resources) subscriber(uri string) (ResourceSubscriber, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sub, ok := r.subscribers[u.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("resource %s does not support subscriptions", uri)
+	}
+	return sub, nil
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+)
+
+func TestResourcesUser(t *testing.T) {
+	store, err := auth.NewFileTokenStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := NewResources(store, nil)
+	if _, err := res.User(nil); err == nil {
+		t.Error("empty store: want error")
+	}
+
+	store.Save("only@example.com", &oauth2.Token{AccessToken: "t"})
+	if email, err := res.User(nil); err != nil || email != "only@example.com" {
+		t.Errorf("single stored user: got %q, %v", email, err)
+	}
+	if _, err := NewResources(store, []string{"other.com"}).User(nil); err == nil {
+		t.Error("stored user outside the allowlist: want error")
+	}
+
+	store.Save("second@example.com", &oauth2.Token{AccessToken: "t"})
+	if _, err := res.User(nil); err == nil {
+		t.Error("several stored users: want error")
+	}
+}
+
+func TestResourcesUserResource(t *testing.T) {
+	res := NewResources(auth.NewInMemoryTokenStore(), []string{"example.com"})
+	tests := []struct {
+		uri       string
+		wantEmail string
+		wantID    string
+		wantErr   bool
+	}{
+		{uri: "gmail://a@example.com/message/123", wantEmail: "a@example.com", wantID: "123"},
+		{uri: "gmail://a%40example.com/message/123", wantEmail: "a@example.com", wantID: "123"},
+		{uri: "gmail://a@example.com/event/123", wantErr: true},
+		{uri: "gmail://a@example.com/message/", wantErr: true},
+		{uri: "gmail://a@example.com/message/1/2", wantErr: true},
+		{uri: "gmail://nobody/message/123", wantErr: true},
+		{uri: "gcal://a@example.com/message/123", wantErr: true},
+		{uri: "gmail://a@other.com/message/123", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			email, id, err := res.UserResource(tt.uri, "gmail", "message")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if email != tt.wantEmail || id != tt.wantID {
+				t.Errorf("got (%q, %q), want (%q, %q)", email, id, tt.wantEmail, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestResourceError(t *testing.T) {
+	notFound := ResourceError(fmt.Errorf("get: %w", &googleapi.Error{Code: 404}), "gmail://a@example.com/message/1")
+	if notFound.Error() != mcp.ResourceNotFoundError("x").Error() {
+		t.Errorf("404: got %v, want resource not found", notFound)
+	}
+	if err := ResourceError(&googleapi.Error{Code: 500}, "u"); err == nil || err.Error() == notFound.Error() {
+		t.Errorf("500: got %v, want the Google API error", err)
+	}
+}
+
+func TestResourcesMiddlewareRecordsSessionUser(t *testing.T) {
+	res := NewResources(auth.NewInMemoryTokenStore(), nil)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+	server.AddReceivingMiddleware(res.Middleware())
+	type input struct {
+		UserEmail string `json:"user_google_email"`
+		Fail      bool   `json:"fail"`
+	}
+	mcp.AddTool(server, &mcp.Tool{Name: "probe"}, func(_ context.Context, _ *mcp.CallToolRequest, in input) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{IsError: in.Fail, Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil, nil
+	})
+	cs := connectTestClient(t, server, nil)
+	var ss *mcp.ServerSession
+	for s := range server.Sessions() {
+		ss = s
+	}
+
+	call := func(email string, fail bool) {
+		args := map[string]any{"user_google_email": email, "fail": fail}
+		if _, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: "probe", Arguments: args}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	call("a@example.com", false)
+	call("b@example.com", true)
+	if email, err := res.User(ss); err != nil || email != "a@example.com" {
+		t.Errorf("session user = %q, %v; want a@example.com (failed calls are ignored)", email, err)
+	}
+}
@@ -0,0 +1,89 @@
+// Package audit records mutating tool calls as structured, append-only
+// events. A Sink receives one Record per call; records never contain tool
+// arguments, only a hash of them.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Results recorded for a call.
+const (
+	ResultOK    = "ok"
+	ResultError = "error"
+)
+
+// maxErrorLen caps the error text stored in a record.
+const maxErrorLen = 300
+
+// Record is one audited tool call.
+type Record struct {
+	Time       time.Time `json:"time"`
+	User       string    `json:"user,omitempty"`
+	Tool       string    `json:"tool"`
+	Service    string    `json:"service,omitempty"`
+	Session    string    `json:"session,omitempty"`
+	InputHash  string    `json:"input_hash"`
+	Result     string    `json:"result"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+// SetError marks the record failed with msg, truncated to a bounded length.
+func (r *Record) SetError(msg string) {
+	r.Result = ResultError
+	if len(msg) > maxErrorLen {
+		msg = msg[:maxErrorLen] + "…"
+	}
+	r.Error = msg
+}
+
+// Sink stores audit records. Implementations must be safe for concurrent use.
+type Sink interface {
+	Write(ctx context.Context, rec Record) error
+	Close() error
+}
+
+// HashArguments returns the hex SHA-256 of a tool call's JSON arguments in
+// canonical form (object keys sorted), so identical inputs hash identically
+// regardless of key order. Undecodable input is hashed as-is.
+func HashArguments(args json.RawMessage) string {
+	data := []byte(args)
+	var v any
+	if err := json.Unmarshal(args, &v); err == nil {
+		if canonical, err := json.Marshal(v); err == nil {
+			data = canonical
+		}
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// MultiSink writes each record to every sink, returning the first error.
+type MultiSink []Sink
+
+// Write implements Sink.
+func (m MultiSink) Write(ctx context.Context, rec Record) error {
+	var first error
+	for _, s := range m {
+		if err := s.Write(ctx, rec); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Close implements Sink.
+func (m MultiSink) Close() error {
+	var first error
+	for _, s := range m {
+		if err := s.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
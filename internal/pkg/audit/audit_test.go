@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHashArguments(t *testing.T) {
+	a := HashArguments(json.RawMessage(`{"b":2,"a":"x"}`))
+	b := HashArguments(json.RawMessage(`{ "a": "x", "b": 2 }`))
+	if a != b {
+		t.Errorf("key order or whitespace changed the hash: %s vs %s", a, b)
+	}
+	if c := HashArguments(json.RawMessage(`{"a":"y","b":2}`)); c == a {
+		t.Error("different inputs produced the same hash")
+	}
+	if len(a) != 64 {
+		t.Errorf("hash length = %d, want 64 hex chars", len(a))
+	}
+	if HashArguments(json.RawMessage(`not json`)) == "" {
+		t.Error("undecodable input should still be hashed")
+	}
+}
+
+func TestRecordSetError(t *testing.T) {
+	var r Record
+	r.SetError(strings.Repeat("x", 500))
+	if r.Result != ResultError || len([]rune(r.Error)) != maxErrorLen+1 {
+		t.Errorf("SetError produced result %q with %d runes", r.Result, len([]rune(r.Error)))
+	}
+}
+
+func TestFileSinkAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	for i := range 2 {
+		sink, err := NewFileSink(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sink.Write(context.Background(), Record{Tool: "send_gmail_message", Result: ResultOK, DurationMS: int64(i)}); err != nil {
+			t.Fatal(err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines int
+	for sc := bufio.NewScanner(f); sc.Scan(); lines++ {
+		var rec Record
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil || rec.Tool != "send_gmail_message" {
+			t.Errorf("line %d = %s (%v)", lines, sc.Text(), err)
+		}
+	}
+	if lines != 2 {
+		t.Errorf("got %d lines, want 2 (reopening must append)", lines)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("file mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	got := make(chan Record, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		var rec Record
+		if err := json.NewDecoder(r.Body).Decode(&rec); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+		got <- rec
+	}))
+	defer srv.Close()
+
+	sink := NewWebhookSink(srv.URL, "secret")
+	if err := sink.Write(context.Background(), Record{Tool: "delete_event", User: "alice@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case rec := <-got:
+		if rec.Tool != "delete_event" || rec.User != "alice@example.com" {
+			t.Errorf("delivered record = %+v", rec)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("record was not delivered before Close returned")
+	}
+}
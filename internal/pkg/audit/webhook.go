@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	webhookQueueSize = 256
+	webhookTimeout   = 5 * time.Second
+)
+
+// WebhookSink POSTs each record as JSON to a URL. Delivery happens on a
+// background goroutine so a slow receiver never delays tool calls; when the
+// queue is full the record is dropped and Write reports an error.
+type WebhookSink struct {
+	url    string
+	token  string
+	client *http.Client
+	queue  chan Record
+	wg     sync.WaitGroup
+	once   sync.Once
+}
+
+// NewWebhookSink starts a sink posting to url. A non-empty token is sent as
+// an "Authorization: Bearer" header.
+func NewWebhookSink(url, token string) *WebhookSink {
+	s := &WebhookSink{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: webhookTimeout},
+		queue:  make(chan Record, webhookQueueSize),
+	}
+	s.wg.Add(1)
+	go s.run()
+	return s
+}
+
+// Write implements Sink.
+func (s *WebhookSink) Write(_ context.Context, rec Record) error {
+	select {
+	case s.queue <- rec:
+		return nil
+	default:
+		return fmt.Errorf("audit webhook queue full — record for %s dropped", rec.Tool)
+	}
+}
+
+// Close stops accepting records and waits for queued ones to be delivered.
+func (s *WebhookSink) Close() error {
+	s.once.Do(func() { close(s.queue) })
+	s.wg.Wait()
+	return nil
+}
+
+func (s *WebhookSink) run() {
+	defer s.wg.Done()
+	for rec := range s.queue {
+		if err := s.post(rec); err != nil {
+			slog.Error("audit webhook delivery failed", "tool", rec.Tool, "error", err)
+		}
+	}
+}
+
+func (s *WebhookSink) post(rec Record) error {
+	body, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encoding audit record: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+package htmlutil
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// pageStyle keeps cleaned pages readable on any screen size: a centered
+// column on desktop, full width on phones, and tables that scroll instead of
+// overflowing.
+const pageStyle = `body{max-width:48rem;margin:0 auto;padding:1rem;font-family:system-ui,sans-serif;line-height:1.5}` +
+	`img{max-width:100%;height:auto}` +
+	`table{border-collapse:collapse;display:block;overflow-x:auto}` +
+	`td,th{border:1px solid #ccc;padding:.25rem .5rem}`
+
+// classRuleRE matches simple ".class{declarations}" CSS rules.
+var classRuleRE = regexp.MustCompile(`\.([A-Za-z0-9_-]+)\{([^}]*)\}`)
+
+// droppedElements are removed from cleaned output together with their content.
+var droppedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Meta: true, atom.Link: true,
+	atom.Noscript: true, atom.Iframe: true, atom.Object: true, atom.Embed: true,
+}
+
+// keptAttrs lists the attributes preserved per element; id is kept
+// everywhere so in-document links keep working.
+var keptAttrs = map[atom.Atom][]string{
+	atom.A:   {"href"},
+	atom.Img: {"src", "alt"},
+	atom.Td:  {"colspan", "rowspan"},
+	atom.Th:  {"colspan", "rowspan"},
+	atom.Ol:  {"start"},
+}
+
+// Cleaned is HTML reduced to semantic markup by Clean.
+type Cleaned struct {
+	Title string
+	Body  string // inner HTML of <body>
+}
+
+// ImageResolver maps an <img> src to the URL to publish. Returning "" drops
+// the image.
+type ImageResolver func(src string) (string, error)
+
+// textStyle is the emphasis carried by CSS that Clean turns into markup.
+type textStyle struct{ bold, italic bool }
+
+// Clean converts exported HTML (such as a Google Docs HTML export) into
+// minimal semantic HTML: styles, classes, scripts, and comments are removed,
+// bold/italic CSS becomes <strong>/<em>, Google redirect links are unwrapped,
+// and every image source is passed through resolve.
+func Clean(src string, resolve ImageResolver) (Cleaned, error) {
+	doc, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		return Cleaned{}, fmt.Errorf("parsing HTML: %w", err)
+	}
+	body := findElement(doc, atom.Body)
+	if body == nil {
+		return Cleaned{}, fmt.Errorf("HTML has no body")
+	}
+
+	c := cleaner{styles: classStyles(doc), resolve: resolve}
+	if err := c.clean(body); err != nil {
+		return Cleaned{}, err
+	}
+
+	var b strings.Builder
+	for n := body.FirstChild; n != nil; n = n.NextSibling {
+		if err := html.Render(&b, n); err != nil {
+			return Cleaned{}, fmt.Errorf("rendering HTML: %w", err)
+		}
+	}
+	var title string
+	if t := findElement(doc, atom.Title); t != nil {
+		title = strings.TrimSpace(textContent(t))
+	}
+	return Cleaned{Title: title, Body: b.String()}, nil
+}
+
+// Page wraps the cleaned body in a standalone, mobile-friendly HTML document.
+func (c Cleaned) Page() string {
+	return "<!DOCTYPE html>\n<html>\n<head>\n" +
+		`<meta charset="utf-8">` + "\n" +
+		`<meta name="viewport" content="width=device-width, initial-scale=1">` + "\n" +
+		"<title>" + html.EscapeString(c.Title) + "</title>\n" +
+		"<style>" + pageStyle + "</style>\n" +
+		"</head>\n<body>\n" + c.Body + "\n</body>\n</html>\n"
+}
+
+type cleaner struct {
+	styles  map[string]textStyle
+	resolve ImageResolver
+}
+
+// clean rewrites the children of n in place.
+func (c *cleaner) clean(n *html.Node) error {
+	for child := n.FirstChild; child != nil; {
+		next := child.NextSibling
+		switch child.Type {
+		case html.CommentNode:
+			n.RemoveChild(child)
+		case html.ElementNode:
+			if err := c.cleanElement(n, child); err != nil {
+				return err
+			}
+		}
+		child = next
+	}
+	return nil
+}
+
+// cleanElement cleans one element of parent, dropping or unwrapping it when
+// it carries no meaning of its own.
+func (c *cleaner) cleanElement(parent, n *html.Node) error {
+	if droppedElements[n.DataAtom] {
+		parent.RemoveChild(n)
+		return nil
+	}
+	if err := c.clean(n); err != nil {
+		return err
+	}
+	style := c.styleOf(n)
+	keep, err := c.cleanAttrs(n)
+	if err != nil || !keep {
+		parent.RemoveChild(n)
+		return err
+	}
+
+	if style.italic {
+		wrapChildren(n, atom.Em)
+	}
+	switch {
+	case style.bold && n.DataAtom == atom.Span:
+		n.DataAtom, n.Data = atom.Strong, "strong"
+	case style.bold && !isHeading(n.DataAtom):
+		wrapChildren(n, atom.Strong)
+	case n.DataAtom == atom.Span && len(n.Attr) == 0:
+		unwrap(n)
+	}
+	return nil
+}
+
+// styleOf returns the emphasis set on n through its classes or style attribute.
+func (c *cleaner) styleOf(n *html.Node) textStyle {
+	var s textStyle
+	for _, a := range n.Attr {
+		switch a.Key {
+		case "class":
+			for _, class := range strings.Fields(a.Val) {
+				cs := c.styles[class]
+				s.bold, s.italic = s.bold || cs.bold, s.italic || cs.italic
+			}
+		case "style":
+			ds := parseDeclarations(a.Val)
+			s.bold, s.italic = s.bold || ds.bold, s.italic || ds.italic
+		}
+	}
+	return s
+}
+
+// cleanAttrs strips all but the allowed attributes of n and rewrites link
+// and image URLs. It reports false when n should be removed.
+func (c *cleaner) cleanAttrs(n *html.Node) (bool, error) {
+	allowed := keptAttrs[n.DataAtom]
+	attrs := n.Attr[:0]
+	for _, a := range n.Attr {
+		if a.Namespace != "" || (a.Key != "id" && !slices.Contains(allowed, a.Key)) {
+			continue
+		}
+		switch {
+		case a.Key == "href":
+			a.Val = unwrapRedirect(a.Val)
+			if !safeURL(a.Val) {
+				continue
+			}
+		case a.Key == "src" && n.DataAtom == atom.Img:
+			src, err := c.resolve(a.Val)
+			if err != nil {
+				return false, err
+			}
+			if src == "" {
+				return false, nil
+			}
+			a.Val = src
+		}
+		attrs = append(attrs, a)
+	}
+	n.Attr = attrs
+	return true, nil
+}
+
+// classStyles reads bold/italic class rules from the document's <style> blocks.
+func classStyles(doc *html.Node) map[string]textStyle {
+	styles := make(map[string]textStyle)
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Style {
+			for _, m := range classRuleRE.FindAllStringSubmatch(textContent(n), -1) {
+				if s := parseDeclarations(m[2]); s.bold || s.italic {
+					styles[m[1]] = s
+				}
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+	return styles
+}
+
+// parseDeclarations extracts emphasis from CSS declarations.
+func parseDeclarations(css string) textStyle {
+	var s textStyle
+	for _, decl := range strings.Split(css, ";") {
+		prop, val, _ := strings.Cut(decl, ":")
+		prop = strings.TrimSpace(strings.ToLower(prop))
+		val = strings.TrimSpace(strings.ToLower(val))
+		switch {
+		case prop == "font-weight" && (val == "bold" || val == "700" || val == "800" || val == "900"):
+			s.bold = true
+		case prop == "font-style" && val == "italic":
+			s.italic = true
+		}
+	}
+	return s
+}
+
+// unwrapRedirect returns the target of a Google redirect link
+// (https://www.google.com/url?q=TARGET&...), or href unchanged.
+func unwrapRedirect(href string) string {
+	u, err := url.Parse(href)
+	if err != nil || u.Host != "www.google.com" || u.Path != "/url" {
+		return href
+	}
+	if q := u.Query().Get("q"); q != "" {
+		return q
+	}
+	return href
+}
+
+// safeURL rejects script URLs in links.
+func safeURL(href string) bool {
+	scheme, _, found := strings.Cut(strings.TrimSpace(href), ":")
+	return !found || !strings.EqualFold(scheme, "javascript")
+}
+
+// wrapChildren moves the children of n into a new child element of type a.
+func wrapChildren(n *html.Node, a atom.Atom) {
+	if n.FirstChild == nil {
+		return
+	}
+	wrapper := &html.Node{Type: html.ElementNode, DataAtom: a, Data: a.String()}
+	for child := n.FirstChild; child != nil; child = n.FirstChild {
+		n.RemoveChild(child)
+		wrapper.AppendChild(child)
+	}
+	n.AppendChild(wrapper)
+}
+
+// unwrap replaces n with its children.
+func unwrap(n *html.Node) {
+	parent := n.Parent
+	for child := n.FirstChild; child != nil; child = n.FirstChild {
+		n.RemoveChild(child)
+		parent.InsertBefore(child, n)
+	}
+	parent.RemoveChild(n)
+}
+
+// findElement returns the first element of type a in depth-first order.
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// textContent concatenates the text nodes below n.
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(textContent(child))
+	}
+	return b.String()
+}
+
+func isHeading(a atom.Atom) bool {
+	switch a {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package htmlutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// googleExport mimics the structure of a Google Docs HTML export.
+const googleExport = `<html><head><meta content="text/html; charset=UTF-8" http-equiv="content-type">
+<style type="text/css">.c1{font-weight:700}.c2{font-style:italic}.c3{color:#000000;font-size:11pt}</style>
+<title>Launch Plan</title></head>
+<body class="c3 doc-content"><h1 class="c1" id="h.abc"><span class="c1">Overview</span></h1>
+<p class="c3"><span class="c3">Plain </span><span class="c1">bold</span><span class="c2"> italic</span></p>
+<!-- comment -->
+<p><a href="https://www.google.com/url?q=https://example.com/page&amp;sa=D&amp;ust=1">link</a>
+<a href="javascript:alert(1)">bad</a></p>
+<p><span style="overflow: hidden;"><img alt="chart" src="images/image1.png" style="width: 600px;"></span></p>
+<table><tr><td colspan="2" class="c3">cell</td></tr></table>
+<script>alert(1)</script></body></html>`
+
+func TestClean(t *testing.T) {
+	var resolved []string
+	got, err := Clean(googleExport, func(src string) (string, error) {
+		resolved = append(resolved, src)
+		return "data:image/png;base64,AAAA", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Title != "Launch Plan" {
+		t.Errorf("Title = %q", got.Title)
+	}
+	for _, want := range []string{
+		`<h1 id="h.abc"><strong>Overview</strong></h1>`,
+		`<p>Plain <strong>bold</strong><em> italic</em></p>`,
+		`<a href="https://example.com/page">link</a>`,
+		`<a>bad</a>`,
+		`<img alt="chart" src="data:image/png;base64,AAAA"/>`,
+		`<td colspan="2">cell</td>`,
+	} {
+		if !strings.Contains(got.Body, want) {
+			t.Errorf("body missing %q:\n%s", want, got.Body)
+		}
+	}
+	for _, unwanted := range []string{"class=", "style=", "<script", "comment", "<span", "javascript:"} {
+		if strings.Contains(got.Body, unwanted) {
+			t.Errorf("body contains %q:\n%s", unwanted, got.Body)
+		}
+	}
+	if len(resolved) != 1 || resolved[0] != "images/image1.png" {
+		t.Errorf("resolved images = %v", resolved)
+	}
+}
+
+func TestCleanImageResolver(t *testing.T) {
+	src := `<body><p>a<img src="x.png">b</p></body>`
+
+	dropped, err := Clean(src, func(string) (string, error) { return "", nil })
+	if err != nil || strings.Contains(dropped.Body, "<img") {
+		t.Errorf("empty resolution should drop the image, got %q (err %v)", dropped.Body, err)
+	}
+
+	want := errors.New("upload failed")
+	if _, err := Clean(src, func(string) (string, error) { return "", want }); !errors.Is(err, want) {
+		t.Errorf("resolver error not returned, got %v", err)
+	}
+}
+
+func TestCleanedPage(t *testing.T) {
+	page := Cleaned{Title: "A & B", Body: "<p>hi</p>"}.Page()
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		`<meta name="viewport" content="width=device-width, initial-scale=1">`,
+		"<title>A &amp; B</title>",
+		"img{max-width:100%",
+		"<p>hi</p>",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package htmlutil
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Patterns for the Markdown subset understood by FromMarkdown.
+var (
+	mdHeadingRE   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdRuleRE      = regexp.MustCompile(`^(\*\s*){3,}$|^(-\s*){3,}$|^(_\s*){3,}$`)
+	mdBulletRE    = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	mdOrderedRE   = regexp.MustCompile(`^\s*(\d+)[.)]\s+(.*)$`)
+	mdTableSepRE  = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+	mdCodeSpanRE  = regexp.MustCompile("`([^`]+)`")
+	mdLinkRE      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBoldRE      = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	mdItalicRE    = regexp.MustCompile(`(^|[^\w*])\*(\S(?:.*?\S)?)\*([^\w*]|$)|(^|[^\w])_(\S(?:.*?\S)?)_([^\w]|$)`)
+	mdStrikeRE    = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	mdPlaceholder = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// FromMarkdown converts common Markdown to HTML suitable for an email body:
+// headings, paragraphs, bullet and numbered lists, block quotes, fenced code,
+// horizontal rules, pipe tables, and inline emphasis, code, and links. Raw
+// HTML in the input is escaped rather than passed through.
+func FromMarkdown(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	var b strings.Builder
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			i++
+
+		case strings.HasPrefix(trimmed, "```"):
+			i++
+			var code []string
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```") {
+				code = append(code, lines[i])
+				i++
+			}
+			i++ // closing fence
+			b.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case mdHeadingRE.MatchString(trimmed):
+			m := mdHeadingRE.FindStringSubmatch(trimmed)
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", len(m[1]), inlineMarkdown(m[2]), len(m[1]))
+			i++
+
+		case mdRuleRE.MatchString(trimmed):
+			b.WriteString("<hr>\n")
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">") {
+				q := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(q, " "))
+				i++
+			}
+			b.WriteString("<blockquote>\n" + FromMarkdown(strings.Join(quoted, "\n")) + "</blockquote>\n")
+
+		case mdBulletRE.MatchString(line), mdOrderedRE.MatchString(line):
+			i = writeList(&b, lines, i)
+
+		case strings.Contains(trimmed, "|") && i+1 < len(lines) && mdTableSepRE.MatchString(strings.TrimSpace(lines[i+1])):
+			i = writeTable(&b, lines, i)
+
+		default:
+			var para []string
+			for i < len(lines) && continuesParagraph(lines, i, len(para) == 0) {
+				para = append(para, inlineMarkdown(strings.TrimSpace(lines[i])))
+				i++
+			}
+			b.WriteString("<p>" + strings.Join(para, "<br>\n") + "</p>\n")
+		}
+	}
+	return b.String()
+}
+
+// continuesParagraph reports whether lines[i] continues a paragraph; the
+// first line always does, later ones stop at any other block.
+func continuesParagraph(lines []string, i int, first bool) bool {
+	if first {
+		return true
+	}
+	t := strings.TrimSpace(lines[i])
+	return t != "" && !strings.HasPrefix(t, "```") && !strings.HasPrefix(t, ">") &&
+		!mdHeadingRE.MatchString(t) && !mdRuleRE.MatchString(t) &&
+		!mdBulletRE.MatchString(lines[i]) && !mdOrderedRE.MatchString(lines[i])
+}
+
+// writeList renders consecutive list items of one kind and returns the index
+// of the first line after the list.
+func writeList(b *strings.Builder, lines []string, i int) int {
+	ordered := !mdBulletRE.MatchString(lines[i])
+	itemRE, tag := mdBulletRE, "ul"
+	if ordered {
+		itemRE, tag = mdOrderedRE, "ol"
+	}
+	if m := mdOrderedRE.FindStringSubmatch(lines[i]); ordered && m[1] != "1" {
+		fmt.Fprintf(b, "<ol start=\"%s\">\n", m[1])
+	} else {
+		b.WriteString("<" + tag + ">\n")
+	}
+	for i < len(lines) && itemRE.MatchString(lines[i]) {
+		m := itemRE.FindStringSubmatch(lines[i])
+		b.WriteString("<li>" + inlineMarkdown(m[len(m)-1]) + "</li>\n")
+		i++
+	}
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// writeTable renders a pipe table whose header row is lines[i] and returns
+// the index of the first line after it.
+func writeTable(b *strings.Builder, lines []string, i int) int {
+	b.WriteString("<table>\n<tr>")
+	for _, cell := range tableCells(lines[i]) {
+		b.WriteString("<th>" + inlineMarkdown(cell) + "</th>")
+	}
+	b.WriteString("</tr>\n")
+	for i += 2; i < len(lines) && strings.Contains(lines[i], "|"); i++ {
+		b.WriteString("<tr>")
+		for _, cell := range tableCells(lines[i]) {
+			b.WriteString("<td>" + inlineMarkdown(cell) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>\n")
+	return i
+}
+
+// tableCells splits a pipe table row into trimmed cells.
+func tableCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	cells := strings.Split(row, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// inlineMarkdown escapes text and renders code spans, links, bold, italic,
+// and strikethrough. Code spans are set aside first so their content stays
+// literal.
+func inlineMarkdown(s string) string {
+	var spans []string
+	s = mdCodeSpanRE.ReplaceAllStringFunc(s, func(m string) string {
+		spans = append(spans, "<code>"+html.EscapeString(m[1:len(m)-1])+"</code>")
+		return fmt.Sprintf("\x00%d\x00", len(spans)-1)
+	})
+
+	s = html.EscapeString(s)
+	s = mdLinkRE.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdLinkRE.FindStringSubmatch(m)
+		if !linkableURL(html.UnescapeString(sub[2])) {
+			return sub[1]
+		}
+		return `<a href="` + sub[2] + `">` + sub[1] + "</a>"
+	})
+	s = mdBoldRE.ReplaceAllString(s, "<strong>$1$2</strong>")
+	s = mdItalicRE.ReplaceAllString(s, "$1$4<em>$2$5</em>$3$6")
+	s = mdStrikeRE.ReplaceAllString(s, "<del>$1</del>")
+
+	return mdPlaceholder.ReplaceAllStringFunc(s, func(m string) string {
+		n, _ := strconv.Atoi(strings.Trim(m, "\x00"))
+		return spans[n]
+	})
+}
+
+// linkableURL allows web and mail links only.
+func linkableURL(u string) bool {
+	lower := strings.ToLower(u)
+	return strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "mailto:")
+}
@@ -0,0 +1,39 @@
+package htmlutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFromMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"heading", "## Weekly report", []string{"<h2>Weekly report</h2>"}},
+		{"paragraph lines", "first line\nsecond line", []string{"<p>first line<br>\nsecond line</p>"}},
+		{"emphasis", "**bold**, *italic*, _also_ and ~~gone~~", []string{"<strong>bold</strong>", "<em>italic</em>", "<em>also</em>", "<del>gone</del>"}},
+		{"snake case stays", "use my_var_name here", []string{"<p>use my_var_name here</p>"}},
+		{"code span", "run `a <b> **c**`", []string{"<code>a &lt;b&gt; **c**</code>"}},
+		{"link", "[docs](https://example.com/a?b=1&c=2)", []string{`<a href="https://example.com/a?b=1&amp;c=2">docs</a>`}},
+		{"unsafe link", "[x](javascript:void)", []string{"<p>x</p>"}},
+		{"raw html escaped", "<script>alert(1)</script>", []string{"&lt;script&gt;"}},
+		{"bullets", "- one\n- two", []string{"<ul>\n<li>one</li>\n<li>two</li>\n</ul>"}},
+		{"numbered from 3", "3. three\n4. four", []string{`<ol start="3">`, "<li>four</li>"}},
+		{"quote", "> quoted *text*", []string{"<blockquote>\n<p>quoted <em>text</em></p>\n</blockquote>"}},
+		{"fence", "```\n<b>**x**</b>\n```", []string{"<pre><code>&lt;b&gt;**x**&lt;/b&gt;</code></pre>"}},
+		{"rule", "---", []string{"<hr>"}},
+		{"table", "| a | b |\n|---|:-:|\n| 1 | 2 |", []string{"<tr><th>a</th><th>b</th></tr>", "<tr><td>1</td><td>2</td></tr>"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromMarkdown(tt.in)
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("FromMarkdown(%q) = %q, missing %q", tt.in, got, w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Package media decides when binary payloads are returned to clients as
+// inline MCP image content instead of text.
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// MaxInlineImageSize is the largest image (1 MB) returned as inline image
+// content. Larger images are only described, since base64 inflates them by a
+// third and most clients reject or downscale big images anyway.
+const MaxInlineImageSize = 1 << 20
+
+// inlineTypes are the image formats multimodal clients can display.
+var inlineTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// InlineImage reports whether an image of mimeType and size bytes should be
+// returned as mcp.ImageContent.
+func InlineImage(mimeType string, size int) bool {
+	mimeType, _, _ = strings.Cut(strings.ToLower(mimeType), ";")
+	return inlineTypes[strings.TrimSpace(mimeType)] && size > 0 && size <= MaxInlineImageSize
+}
+
+// FetchImage downloads the image at url with client. It fails when the
+// response is not an inlinable image or exceeds MaxInlineImageSize, and
+// returns the data with its MIME type otherwise.
+func FetchImage(ctx context.Context, client *http.Client, url string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating image request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching image: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("fetching image: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxInlineImageSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("reading image: %w", err)
+	}
+	mimeType := resp.Header.Get("Content-Type")
+	if !InlineImage(mimeType, len(data)) {
+		return nil, "", fmt.Errorf("image not inlinable (%s, %d bytes read)", mimeType, len(data))
+	}
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	return data, strings.TrimSpace(mimeType), nil
+}
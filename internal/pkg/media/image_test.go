@@ -0,0 +1,61 @@
+package media
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInlineImage(t *testing.T) {
+	tests := []struct {
+		mimeType string
+		size     int
+		want     bool
+	}{
+		{"image/png", 1024, true},
+		{"image/JPEG", 1024, true},
+		{"image/webp; charset=binary", 1024, true},
+		{"image/png", MaxInlineImageSize, true},
+		{"image/png", MaxInlineImageSize + 1, false},
+		{"image/png", 0, false},
+		{"image/svg+xml", 1024, false},
+		{"image/tiff", 1024, false},
+		{"application/pdf", 1024, false},
+	}
+	for _, tt := range tests {
+		if got := InlineImage(tt.mimeType, tt.size); got != tt.want {
+			t.Errorf("InlineImage(%q, %d) = %v, want %v", tt.mimeType, tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestFetchImage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/small.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("png"))
+		case "/large.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte(strings.Repeat("x", MaxInlineImageSize+1)))
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	data, mimeType, err := FetchImage(context.Background(), srv.Client(), srv.URL+"/small.png")
+	if err != nil || string(data) != "png" || mimeType != "image/png" {
+		t.Errorf("small png: got %q, %q, %v", data, mimeType, err)
+	}
+	for _, path := range []string{"/large.png", "/page.html", "/missing.png"} {
+		if _, _, err := FetchImage(context.Background(), srv.Client(), srv.URL+path); err == nil {
+			t.Errorf("%s: want error", path)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Package progress reports MCP progress notifications from tool handlers in
+// a uniform format and lets item loops stop early when the call is cancelled.
+//
+// A Reporter is cheap and safe to use whether or not the client asked for
+// progress: without a progress token it only checks for cancellation.
+//
+// Handlers that accumulate results per item stop between items on
+// cancellation and return what they have, flagged with Partial; handlers
+// with nothing useful to return propagate the Next error instead.
+package progress
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Reporter sends progress for one tool call. Work is split into phases
+// (Begin), each a run of items (Next); progress is cumulative across phases
+// so it only ever increases, as the protocol requires.
+type Reporter struct {
+	ctx     context.Context
+	session *mcp.ServerSession
+	token   any
+
+	action string // e.g. "Fetching message"
+	total  int    // items in the current phase; 0 if unknown
+	item   int    // items started in the current phase
+	offset int    // items completed in earlier phases
+
+	stoppedAt string // item Next refused to start after cancellation
+}
+
+// New returns a Reporter for req. Call Begin before the first item.
+func New(ctx context.Context, req *mcp.CallToolRequest) *Reporter {
+	r := &Reporter{ctx: ctx}
+	if req != nil && req.Params != nil && req.Session != nil {
+		r.session = req.Session
+		r.token = req.Params.GetProgressToken()
+	}
+	return r
+}
+
+// Begin starts a phase of total items, each described by action (a verb and
+// singular noun such as "Fetching message"). Pass total 0 when the item count
+// is not known up front, e.g. for paged listings.
+func (r *Reporter) Begin(action string, total int) *Reporter {
+	r.offset += r.item
+	r.action, r.total, r.item = action, total, 0
+	return r
+}
+
+// Next reports that the next item is starting. It returns an error, without
+// notifying, once the call has been cancelled; loops should stop and return it.
+func (r *Reporter) Next() error {
+	if err := r.ctx.Err(); err != nil {
+		r.stoppedAt = r.position(r.item + 1)
+		return fmt.Errorf("cancelled at %s: %w", r.stoppedAt, err)
+	}
+	r.item++
+	total := 0
+	if r.total > 0 {
+		total = r.offset + r.total
+	}
+	r.notify(r.offset+r.item-1, total, r.position(r.item))
+	return nil
+}
+
+// Cancelled reports whether the call has been cancelled, so results gathered
+// so far are incomplete.
+func (r *Reporter) Cancelled() bool {
+	return r.ctx.Err() != nil
+}
+
+// Partial describes where cancelled work stopped, e.g. "cancelled at
+// Fetching message 4/25; results are partial". It is empty unless Cancelled.
+func (r *Reporter) Partial() string {
+	if !r.Cancelled() {
+		return ""
+	}
+	// Without a refused Next, cancellation failed the item in flight.
+	at := r.stoppedAt
+	if at == "" {
+		at = r.position(max(r.item, 1))
+	}
+	return fmt.Sprintf("cancelled at %s; results are partial", at)
+}
+
+// Done reports that all work has finished.
+func (r *Reporter) Done() {
+	r.offset += r.item
+	r.item, r.total = 0, 0
+	r.notify(r.offset, r.offset, fmt.Sprintf("Done %d/%d", r.offset, r.offset))
+}
+
+// position describes item n of the phase, e.g. "Fetching message 3/25".
+func (r *Reporter) position(n int) string {
+	if r.total > 0 {
+		return fmt.Sprintf("%s %d/%d", r.action, n, r.total)
+	}
+	return fmt.Sprintf("%s %d", r.action, n)
+}
+
+func (r *Reporter) notify(progress, total int, message string) {
+	if r.token == nil {
+		return
+	}
+	// Progress is advisory; a client that went away surfaces through ctx.
+	_ = r.session.NotifyProgress(r.ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: r.token,
+		Progress:      float64(progress),
+		Total:         float64(total),
+		Message:       message,
+	})
+}
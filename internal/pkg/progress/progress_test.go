@@ -0,0 +1,182 @@
+package progress
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type emptyInput struct{}
+
+// callWithProgress runs handler as a tool on an in-memory server and returns
+// the progress messages the client received, in order.
+func callWithProgress(t *testing.T, token any, handler func(context.Context, *mcp.CallToolRequest) error) []*mcp.ProgressNotificationParams {
+	t.Helper()
+	ctx := context.Background()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "work"}, func(ctx context.Context, req *mcp.CallToolRequest, _ emptyInput) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{}, nil, handler(ctx, req)
+	})
+
+	var mu sync.Mutex
+	var got []*mcp.ProgressNotificationParams
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, req.Params)
+		},
+	})
+
+	st, ct := mcp.NewInMemoryTransports()
+	ss, err := server.Connect(ctx, st, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	defer ss.Close()
+	cs, err := client.Connect(ctx, ct, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+
+	params := &mcp.CallToolParams{Name: "work", Arguments: map[string]any{}}
+	if token != nil {
+		// SetProgressToken drops the token when Meta is nil, so set it directly.
+		params.Meta = mcp.Meta{"progressToken": token}
+	}
+	if _, err := cs.CallTool(ctx, params); err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	// Closing the client waits for pending notifications to be handled.
+	cs.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	return got
+}
+
+func TestReporterPhases(t *testing.T) {
+	got := callWithProgress(t, "tok", func(ctx context.Context, req *mcp.CallToolRequest) error {
+		p := New(ctx, req).Begin("Listing page", 0)
+		for range 2 {
+			if err := p.Next(); err != nil {
+				return err
+			}
+		}
+		p.Begin("Fetching message", 3)
+		for range 3 {
+			if err := p.Next(); err != nil {
+				return err
+			}
+		}
+		p.Done()
+		return nil
+	})
+
+	want := []struct {
+		progress, total float64
+		message         string
+	}{
+		{0, 0, "Listing page 1"},
+		{1, 0, "Listing page 2"},
+		{2, 5, "Fetching message 1/3"},
+		{3, 5, "Fetching message 2/3"},
+		{4, 5, "Fetching message 3/3"},
+		{5, 5, "Done 5/5"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d notifications, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.ProgressToken != "tok" || g.Progress != w.progress || g.Total != w.total || g.Message != w.message {
+			t.Errorf("notification %d = {%v %v %v %q}, want {tok %v %v %q}",
+				i, g.ProgressToken, g.Progress, g.Total, g.Message, w.progress, w.total, w.message)
+		}
+	}
+}
+
+func TestReporterWithoutToken(t *testing.T) {
+	got := callWithProgress(t, nil, func(ctx context.Context, req *mcp.CallToolRequest) error {
+		p := New(ctx, req).Begin("Fetching message", 2)
+		for range 2 {
+			if err := p.Next(); err != nil {
+				return err
+			}
+		}
+		p.Done()
+		return nil
+	})
+	if len(got) != 0 {
+		t.Errorf("got %d notifications without a progress token, want 0", len(got))
+	}
+}
+
+func TestReporterCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := New(ctx, nil).Begin("Sharing file", 4)
+
+	var done []int
+	for i := range 4 {
+		if i == 2 {
+			cancel()
+		}
+		if err := p.Next(); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("error = %v, want context.Canceled", err)
+			}
+			if want := "cancelled at Sharing file 3/4: context canceled"; err.Error() != want {
+				t.Errorf("error = %q, want %q", err, want)
+			}
+			break
+		}
+		done = append(done, i)
+	}
+	if !slices.Equal(done, []int{0, 1}) {
+		t.Errorf("items processed = %v, want [0 1]", done)
+	}
+}
+
+func TestReporterPartial(t *testing.T) {
+	tests := []struct {
+		name     string
+		cancelAt int // cancel before this item starts; -1 never
+		inFlight bool
+		want     string
+	}{
+		{"complete", -1, false, ""},
+		{"refused next item", 2, false, "cancelled at Reading message 3/5; results are partial"},
+		{"item in flight", 2, true, "cancelled at Reading message 2/5; results are partial"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			p := New(ctx, nil).Begin("Reading message", 5)
+			for i := range 5 {
+				if i == tt.cancelAt && !tt.inFlight {
+					cancel()
+				}
+				if p.Next() != nil {
+					break
+				}
+				if i+1 == tt.cancelAt && tt.inFlight {
+					cancel() // the API call for this item fails
+					break
+				}
+			}
+			if got := p.Cancelled(); got != (tt.want != "") {
+				t.Errorf("Cancelled() = %v", got)
+			}
+			if got := p.Partial(); got != tt.want {
+				t.Errorf("Partial() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Package provenance stamps Workspace objects created by this server (Drive
+// files, calendar events, Gmail drafts) so agent-generated artifacts can be
+// found and audited later.
+package provenance
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+)
+
+// Property keys and the creator value written to stamped objects.
+const (
+	KeyCreatedBy = "created-by"
+	KeySession   = "mcp-session"
+	KeyCreatedAt = "mcp-created-at"
+	CreatedBy    = "google-workspace-mcp"
+)
+
+// Header is the email header carrying the stamp on drafts. It stays on the
+// message when the draft is sent.
+const Header = "X-Created-By"
+
+// DriveQuery matches stamped Drive files in a files.list query.
+const DriveQuery = "appProperties has { key='" + KeyCreatedBy + "' and value='" + CreatedBy + "' }"
+
+// CalendarFilter matches stamped events as a privateExtendedProperty filter.
+const CalendarFilter = KeyCreatedBy + "=" + CreatedBy
+
+// Stamp identifies the session and time an object was created.
+type Stamp struct {
+	Session   string
+	CreatedAt time.Time
+}
+
+// For returns the stamp for objects created while handling req.
+func For(req *mcp.CallToolRequest) Stamp {
+	s := Stamp{CreatedAt: time.Now().UTC()}
+	if req != nil && req.Session != nil {
+		s.Session = req.Session.ID()
+	}
+	return s
+}
+
+// Properties returns the stamp as Drive appProperties or Calendar private
+// extended properties.
+func (s Stamp) Properties() map[string]string {
+	props := map[string]string{
+		KeyCreatedBy: CreatedBy,
+		KeyCreatedAt: s.CreatedAt.Format(time.RFC3339),
+	}
+	if s.Session != "" {
+		props[KeySession] = s.Session
+	}
+	return props
+}
+
+// HeaderValue returns the stamp formatted for the Header email header.
+func (s Stamp) HeaderValue() string {
+	v := CreatedBy + "; created=" + s.CreatedAt.Format(time.RFC3339)
+	if s.Session != "" {
+		v += "; session=" + s.Session
+	}
+	return v
+}
+
+// StampRawMessage adds the Header to a base64url-encoded RFC 2822 message.
+func (s Stamp) StampRawMessage(raw string) (string, error) {
+	msg, err := base64.URLEncoding.DecodeString(raw)
+	if err != nil {
+		return "", fmt.Errorf("decoding message: %w", err)
+	}
+	stamped := Header + ": " + s.HeaderValue() + "\r\n" + string(msg)
+	return base64.URLEncoding.EncodeToString([]byte(stamped)), nil
+}
+
+// StampDriveFile writes the stamp to an existing Drive file, for objects such
+// as Docs or Sheets created through APIs that cannot set appProperties.
+func (s Stamp) StampDriveFile(ctx context.Context, srv *drive.Service, fileID string) error {
+	_, err := srv.Files.Update(fileID, &drive.File{AppProperties: s.Properties()}).
+		SupportsAllDrives(true).
+		Fields("id").
+		Context(ctx).
+		Do()
+	return err
+}
+
+// ParseHeaderValue reads the session and creation time from a Header value.
+// It reports false when the value was not written by this server.
+func ParseHeaderValue(v string) (Stamp, bool) {
+	parts := strings.Split(v, ";")
+	if strings.TrimSpace(parts[0]) != CreatedBy {
+		return Stamp{}, false
+	}
+	var s Stamp
+	for _, part := range parts[1:] {
+		key, val, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "created":
+			s.CreatedAt, _ = time.Parse(time.RFC3339, val)
+		case "session":
+			s.Session = val
+		}
+	}
+	return s, true
+}
@@ -0,0 +1,61 @@
+package provenance
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStampProperties(t *testing.T) {
+	s := Stamp{Session: "sess-1", CreatedAt: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)}
+	props := s.Properties()
+
+	if props[KeyCreatedBy] != CreatedBy || props[KeySession] != "sess-1" || props[KeyCreatedAt] != "2025-03-01T09:30:00Z" {
+		t.Errorf("Properties() = %v", props)
+	}
+	if _, ok := (Stamp{}).Properties()[KeySession]; ok {
+		t.Error("empty session must not be written")
+	}
+}
+
+func TestHeaderValueRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		stamp Stamp
+	}{
+		{"with session", Stamp{Session: "abc", CreatedAt: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)}},
+		{"stdio without session", Stamp{CreatedAt: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseHeaderValue(tt.stamp.HeaderValue())
+			if !ok || got != tt.stamp {
+				t.Errorf("round trip: got %+v (ok=%v), want %+v", got, ok, tt.stamp)
+			}
+		})
+	}
+
+	for _, v := range []string{"", "someone-else; created=2025-03-01T09:30:00Z"} {
+		if _, ok := ParseHeaderValue(v); ok {
+			t.Errorf("ParseHeaderValue(%q) should not match", v)
+		}
+	}
+}
+
+func TestStampRawMessage(t *testing.T) {
+	raw := base64.URLEncoding.EncodeToString([]byte("To: a@example.com\r\nSubject: Hi\r\n\r\nBody"))
+	stamped, err := Stamp{Session: "s"}.StampRawMessage(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoded, _ := base64.URLEncoding.DecodeString(stamped)
+	msg := string(decoded)
+	if !strings.HasPrefix(msg, Header+": "+CreatedBy+";") || !strings.HasSuffix(msg, "Subject: Hi\r\n\r\nBody") {
+		t.Errorf("stamped message = %q", msg)
+	}
+
+	if _, err := (Stamp{}).StampRawMessage("!!not base64"); err == nil {
+		t.Error("expected error for invalid message")
+	}
+}
@@ -0,0 +1,105 @@
+// Package redact masks sensitive values (email addresses, phone numbers, or
+// operator-defined patterns) in tool output before it reaches the MCP client.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// profiles maps built-in profile names to their pattern and replacement.
+var profiles = map[string]rule{
+	"email": {
+		re:   regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`),
+		mask: "[redacted email]",
+	},
+	// phone requires separators between digit groups so dates, times, and
+	// long numeric IDs are not mistaken for phone numbers.
+	"phone": {
+		re:   regexp.MustCompile(`(?:\+\d{1,3}[\s.\-]?)?(?:\(\d{2,4}\)|\b\d{2,4})[\s.\-]\d{3,4}[\s.\-]\d{3,4}\b`),
+		mask: "[redacted phone]",
+	},
+}
+
+type rule struct {
+	re   *regexp.Regexp
+	mask string
+}
+
+// Redactor applies an ordered set of masking rules. A nil *Redactor is valid
+// and leaves text unchanged.
+type Redactor struct {
+	rules []rule
+}
+
+// New builds a Redactor from built-in profile names and custom regular
+// expressions. It returns nil when neither is given.
+func New(profileNames, patterns []string) (*Redactor, error) {
+	var r Redactor
+	for _, name := range profileNames {
+		p, ok := profiles[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown redaction profile %q — must be one of: %s", name, strings.Join(Profiles(), ", "))
+		}
+		r.rules = append(r.rules, p)
+	}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.rules = append(r.rules, rule{re: re, mask: "[redacted]"})
+	}
+	if len(r.rules) == 0 {
+		return nil, nil
+	}
+	return &r, nil
+}
+
+// Profiles returns the names of the built-in profiles, sorted.
+func Profiles() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// String masks every match of every rule in s.
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, rl := range r.rules {
+		s = rl.re.ReplaceAllLiteralString(s, rl.mask)
+	}
+	return s
+}
+
+// Value masks every string inside a decoded JSON value (maps, slices, and
+// strings as produced by encoding/json), including map keys.
+func (r *Redactor) Value(v any) any {
+	if r == nil {
+		return v
+	}
+	switch v := v.(type) {
+	case string:
+		return r.String(v)
+	case []any:
+		for i := range v {
+			v[i] = r.Value(v[i])
+		}
+		return v
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			out[r.String(k)] = r.Value(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,78 @@
+package redact
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactorString(t *testing.T) {
+	r, err := New([]string{"email", "Phone"}, []string{`ACME-\d{4}`})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"email", "From: Jane <jane.doe+news@example.co.uk>", "From: Jane <[redacted email]>"},
+		{"us phone", "Call (555) 123-4567 today", "Call [redacted phone] today"},
+		{"international phone", "Mobile: +44 20 7946 0958", "Mobile: [redacted phone]"},
+		{"dotted phone", "555.123.4567", "[redacted phone]"},
+		{"custom pattern", "Ticket ACME-1234 opened", "Ticket [redacted] opened"},
+		{"date untouched", "Due 2025-06-02T10:00:00Z", "Due 2025-06-02T10:00:00Z"},
+		{"drive id untouched", "ID: 1a2B3c4D5e6F7g8H9i0J", "ID: 1a2B3c4D5e6F7g8H9i0J"},
+		{"size untouched", "Size: 1,234,567 bytes", "Size: 1,234,567 bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.String(tt.in); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactorValue(t *testing.T) {
+	r, err := New([]string{"email"}, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	in := map[string]any{
+		"from":  "a@example.com",
+		"count": float64(2),
+		"to":    []any{"b@example.com", "team"},
+		"nested": map[string]any{
+			"c@example.com": true,
+		},
+	}
+	want := map[string]any{
+		"from":  "[redacted email]",
+		"count": float64(2),
+		"to":    []any{"[redacted email]", "team"},
+		"nested": map[string]any{
+			"[redacted email]": true,
+		},
+	}
+	if got := r.Value(in); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if r, err := New(nil, nil); r != nil || err != nil {
+		t.Errorf("no rules: got %v, %v; want nil, nil", r, err)
+	}
+	if _, err := New([]string{"ssn"}, nil); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+	if _, err := New(nil, []string{"("}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+
+	var nilRedactor *Redactor
+	if got := nilRedactor.String("a@example.com"); got != "a@example.com" {
+		t.Errorf("nil redactor changed text: %q", got)
+	}
+}
@@ -0,0 +1,99 @@
+package response
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format selects how tool results are presented to the client.
+type Format string
+
+const (
+	// FormatText is the Builder's own plain-text layout.
+	FormatText Format = "text"
+	// FormatMarkdown renders the layout as Markdown: headers become
+	// headings, key-value runs become tables, and items become lists.
+	FormatMarkdown Format = "markdown"
+	// FormatJSON drops the text block of results that carry structured
+	// content, so the client relies on the structured output alone.
+	FormatJSON Format = "json"
+)
+
+// ParseFormat validates a format name. The empty string is FormatText.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case "":
+		return FormatText, nil
+	case FormatText, FormatMarkdown, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown response format %q (valid: text, markdown, json)", s)
+	}
+}
+
+// Markdown converts text in the Builder's layout to Markdown. Lines the
+// Builder did not decorate are kept as they are.
+func Markdown(text string) string {
+	var out []string
+	inTable := false
+	for _, line := range strings.Split(text, "\n") {
+		key, value, isKV := keyValueLine(line)
+		if isKV && !inTable {
+			out = appendBlankLine(out)
+			out = append(out, "| Field | Value |", "| --- | --- |")
+		}
+		if !isKV && inTable && line != "" {
+			out = append(out, "")
+		}
+		inTable = isKV
+
+		switch {
+		case isKV:
+			out = append(out, "| "+escapeCell(key)+" | "+escapeCell(value)+" |")
+		case strings.HasPrefix(line, "═══ ") && strings.HasSuffix(line, " ═══"):
+			out = append(appendBlankLine(out), "## "+strings.TrimSuffix(strings.TrimPrefix(line, "═══ "), " ═══"))
+		case strings.HasPrefix(line, "── ") && strings.HasSuffix(line, " ──"):
+			out = append(appendBlankLine(out), "### "+strings.TrimSuffix(strings.TrimPrefix(line, "── "), " ──"))
+		case line != "" && strings.Trim(line, "─") == "":
+			out = append(appendBlankLine(out), "---")
+		default:
+			out = append(out, itemLine(line))
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// keyValueLine splits a "• Key: value" line written by KeyValue.
+func keyValueLine(line string) (key, value string, ok bool) {
+	rest, ok := strings.CutPrefix(line, "• ")
+	if !ok {
+		return "", "", false
+	}
+	key, value, ok = strings.Cut(rest, ": ")
+	return key, value, ok
+}
+
+// itemLine turns an "  → item" line written by Item into a list item,
+// nesting deeper indentation.
+func itemLine(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	item, ok := strings.CutPrefix(trimmed, "→ ")
+	if !ok {
+		return line
+	}
+	indent := len(line) - len(trimmed) - 2
+	return strings.Repeat(" ", max(indent, 0)) + "- " + item
+}
+
+// appendBlankLine separates a block from preceding text, as Markdown
+// requires before headings and tables.
+func appendBlankLine(out []string) []string {
+	if len(out) > 0 && out[len(out)-1] != "" {
+		return append(out, "")
+	}
+	return out
+}
+
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,53 @@
+package response
+
+import "testing"
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Format
+		wantErr bool
+	}{
+		{"", FormatText, false},
+		{"text", FormatText, false},
+		{"Markdown", FormatMarkdown, false},
+		{" json ", FormatJSON, false},
+		{"html", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q, wantErr %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	text := New().
+		Header("Drive Files").
+		KeyValue("Query", "name contains 'a|b'").
+		KeyValue("Count", 2).
+		Section("Files").
+		Item("report.pdf").
+		Raw("    → page 1\n").
+		Separator().
+		Line("plain line").
+		Build()
+
+	want := "## Drive Files\n" +
+		"\n" +
+		"| Field | Value |\n" +
+		"| --- | --- |\n" +
+		"| Query | name contains 'a\\|b' |\n" +
+		"| Count | 2 |\n" +
+		"\n" +
+		"### Files\n" +
+		"- report.pdf\n" +
+		"  - page 1\n" +
+		"\n" +
+		"---\n" +
+		"plain line\n"
+	if got := Markdown(text); got != want {
+		t.Errorf("Markdown =\n%s\nwant\n%s", got, want)
+	}
+}
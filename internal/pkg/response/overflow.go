@@ -0,0 +1,104 @@
+package response
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ContinueTool is the tool that reads output cut off by an Overflow.
+const ContinueTool = "continue_output"
+
+// overflowTTL is how long the rest of a truncated output can be read.
+const overflowTTL = 30 * time.Minute
+
+// maxOverflowEntries bounds the outputs held at once; the oldest are dropped
+// first.
+const maxOverflowEntries = 200
+
+// Overflow enforces a size budget on tool output. Text over the budget is
+// cut, and the rest is kept under a continuation token that ContinueTool
+// reads in budget-sized parts. Entries belong to the session that produced
+// them and expire after 30 minutes.
+type Overflow struct {
+	budget int
+
+	mu      sync.Mutex
+	entries map[string]overflowEntry
+	order   []string
+	now     func() time.Time
+}
+
+type overflowEntry struct {
+	owner   string
+	rest    string
+	total   int
+	expires time.Time
+}
+
+// NewOverflow returns an Overflow that cuts output at budget bytes.
+func NewOverflow(budget int) *Overflow {
+	return &Overflow{budget: budget, entries: make(map[string]overflowEntry), now: time.Now}
+}
+
+// Limit returns text unchanged and no token when it fits the budget.
+// Otherwise it returns the first part followed by a notice naming the
+// continuation token, keeps the rest for owner, and returns the token.
+func (o *Overflow) Limit(owner, text string) (string, string) {
+	return o.limit(owner, text, len(text))
+}
+
+// Next returns the part of an output that follows token, itself limited to
+// the budget. It fails when the token is unknown, expired, or belongs to
+// another owner.
+func (o *Overflow) Next(owner, token string) (string, error) {
+	o.mu.Lock()
+	e, ok := o.entries[token]
+	o.mu.Unlock()
+	if !ok || e.owner != owner || o.now().After(e.expires) {
+		return "", fmt.Errorf("unknown or expired continuation token — outputs can be continued for %s; call the original tool again", overflowTTL)
+	}
+	limited, _ := o.limit(owner, e.rest, e.total)
+	return limited, nil
+}
+
+func (o *Overflow) limit(owner, text string, total int) (string, string) {
+	if len(text) <= o.budget {
+		return text, ""
+	}
+	head, rest := cut(text, o.budget)
+	token := o.put(overflowEntry{owner: owner, rest: rest, total: total, expires: o.now().Add(overflowTTL)})
+	shown := total - len(rest)
+	return fmt.Sprintf("%s\n\n[Output truncated: %d of %d bytes shown. Call %s with token %q for the next part.]",
+		head, shown, total, ContinueTool, token), token
+}
+
+// cut splits text at most budget bytes in, preferring the last line break
+// in the second half of the budget so lines stay whole.
+func cut(text string, budget int) (head, rest string) {
+	i := budget
+	for i > 0 && !utf8.RuneStart(text[i]) {
+		i--
+	}
+	if nl := strings.LastIndexByte(text[:i], '\n'); nl >= budget/2 {
+		i = nl + 1
+	}
+	return text[:i], text[i:]
+}
+
+func (o *Overflow) put(e overflowEntry) string {
+	token := rand.Text()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.entries[token] = e
+	o.order = append(o.order, token)
+	// Entries expire in insertion order, so expired ones are at the front.
+	for len(o.order) > maxOverflowEntries || (len(o.order) > 0 && o.now().After(o.entries[o.order[0]].expires)) {
+		delete(o.entries, o.order[0])
+		o.order = o.order[1:]
+	}
+	return token
+}
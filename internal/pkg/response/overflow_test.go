@@ -0,0 +1,64 @@
+package response
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+var tokenRE = regexp.MustCompile(`token "([A-Z0-9]+)"`)
+
+func TestOverflow(t *testing.T) {
+	o := NewOverflow(10)
+	if got, token := o.Limit("s1", "short"); got != "short" || token != "" {
+		t.Errorf("within budget: got %q", got)
+	}
+
+	// Lines are kept whole when a break falls in the second half of the budget.
+	text := "line one\nline two\nline three"
+	var parts []string
+	out, _ := o.Limit("s1", text)
+	for {
+		head, _, _ := strings.Cut(out, "\n\n[Output truncated")
+		parts = append(parts, head)
+		m := tokenRE.FindStringSubmatch(out)
+		if m == nil {
+			break
+		}
+		if _, err := o.Next("s2", m[1]); err == nil {
+			t.Fatal("token readable by another session")
+		}
+		var err error
+		if out, err = o.Next("s1", m[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if strings.Join(parts, "") != text {
+		t.Errorf("parts %q do not reassemble the text", parts)
+	}
+	if parts[0] != "line one\n" {
+		t.Errorf("first part = %q, want a whole line", parts[0])
+	}
+}
+
+func TestOverflowExpiry(t *testing.T) {
+	o := NewOverflow(4)
+	now := time.Now()
+	o.now = func() time.Time { return now }
+	out, token := o.Limit("s", "abcdefgh")
+	if got := tokenRE.FindStringSubmatch(out)[1]; got != token {
+		t.Fatalf("notice names token %q, Limit returned %q", got, token)
+	}
+	now = now.Add(overflowTTL + time.Second)
+	if _, err := o.Next("s", token); err == nil {
+		t.Error("expired token: want error")
+	}
+}
+
+func TestCutRuneBoundary(t *testing.T) {
+	head, rest := cut("ééééé", 5)
+	if head != "éé" || rest != "ééé" {
+		t.Errorf("cut = %q, %q; want whole runes", head, rest)
+	}
+}
@@ -0,0 +1,125 @@
+// Package rollback undoes the side effects of multi-step tools. A composite
+// tool records every artifact it creates; if a later step fails, the recorded
+// artifacts are removed so the user is not left with half-built documents,
+// files, or events.
+package rollback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/drive/v3"
+)
+
+// undoTimeout bounds the total time spent rolling back one transaction.
+const undoTimeout = 30 * time.Second
+
+// UndoFunc removes one created artifact.
+type UndoFunc func(ctx context.Context) error
+
+type step struct {
+	artifact string
+	undo     UndoFunc
+}
+
+// Tx records the artifacts created by a composite tool. The zero value is
+// ready to use. Typical use:
+//
+//	var tx rollback.Tx
+//	created, err := ... // step 1
+//	tx.Record("document "+created.Id, rollback.TrashDriveFile(driveSrv, created.Id))
+//	if err := ...; err != nil { // step 2
+//		return nil, nil, tx.Fail(ctx, middleware.HandleGoogleAPIError(err))
+//	}
+//	tx.Commit()
+type Tx struct {
+	mu    sync.Mutex
+	steps []step
+}
+
+// Record registers an artifact and the function that removes it.
+// artifact is a short human-readable label such as "event abc123".
+func (tx *Tx) Record(artifact string, undo UndoFunc) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.steps = append(tx.steps, step{artifact: artifact, undo: undo})
+}
+
+// Commit keeps everything recorded so far; a later Rollback is a no-op.
+func (tx *Tx) Commit() {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.steps = nil
+}
+
+// Rollback removes recorded artifacts in reverse creation order. It keeps
+// going after individual failures and returns an error naming every artifact
+// that could not be removed. Rollback runs even if ctx is already cancelled,
+// since a cancelled request is a common reason to roll back.
+func (tx *Tx) Rollback(ctx context.Context) error {
+	tx.mu.Lock()
+	steps := tx.steps
+	tx.steps = nil
+	tx.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), undoTimeout)
+	defer cancel()
+
+	var left []string
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		if err := s.undo(ctx); err != nil {
+			slog.Warn("rollback step failed", "artifact", s.artifact, "error", err)
+			left = append(left, s.artifact)
+			continue
+		}
+		slog.Debug("rolled back artifact", "artifact", s.artifact)
+	}
+	if len(left) > 0 {
+		return fmt.Errorf("rollback incomplete — remove manually: %s", strings.Join(left, ", "))
+	}
+	return nil
+}
+
+// Fail rolls back the transaction and returns cause annotated with the
+// outcome, so the caller can return it directly from a tool handler.
+func (tx *Tx) Fail(ctx context.Context, cause error) error {
+	tx.mu.Lock()
+	n := len(tx.steps)
+	tx.mu.Unlock()
+	if n == 0 {
+		return cause
+	}
+
+	if err := tx.Rollback(ctx); err != nil {
+		return errors.Join(cause, err)
+	}
+	return fmt.Errorf("%w (rolled back %d item(s) created before the failure)", cause, n)
+}
+
+// TrashDriveFile returns an UndoFunc that moves a Drive file to the trash.
+// Docs, Sheets, Slides, and Forms files are Drive files and can be undone
+// this way. Trashing rather than deleting keeps the file recoverable.
+func TrashDriveFile(srv *drive.Service, fileID string) UndoFunc {
+	return func(ctx context.Context) error {
+		_, err := srv.Files.Update(fileID, &drive.File{Trashed: true}).
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		return err
+	}
+}
+
+// DeleteCalendarEvent returns an UndoFunc that deletes a calendar event
+// without notifying attendees.
+func DeleteCalendarEvent(srv *calendar.Service, calendarID, eventID string) UndoFunc {
+	return func(ctx context.Context) error {
+		return srv.Events.Delete(calendarID, eventID).SendUpdates("none").Context(ctx).Do()
+	}
+}
@@ -0,0 +1,103 @@
+package rollback
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRollbackReverseOrder(t *testing.T) {
+	var tx Tx
+	var undone []string
+	for _, id := range []string{"a", "b", "c"} {
+		tx.Record("item "+id, func(ctx context.Context) error {
+			undone = append(undone, id)
+			return nil
+		})
+	}
+
+	if err := tx.Rollback(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"c", "b", "a"}; !reflect.DeepEqual(undone, want) {
+		t.Errorf("undo order: got %v, want %v", undone, want)
+	}
+
+	// A second rollback has nothing left to do.
+	undone = nil
+	if err := tx.Rollback(context.Background()); err != nil || len(undone) != 0 {
+		t.Errorf("second rollback: err=%v undone=%v", err, undone)
+	}
+}
+
+func TestRollbackContinuesAfterFailure(t *testing.T) {
+	var tx Tx
+	var undone []string
+	tx.Record("file 1", func(ctx context.Context) error { undone = append(undone, "1"); return nil })
+	tx.Record("file 2", func(ctx context.Context) error { return errors.New("boom") })
+	tx.Record("file 3", func(ctx context.Context) error { undone = append(undone, "3"); return nil })
+
+	err := tx.Rollback(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "file 2") {
+		t.Fatalf("expected error naming file 2, got %v", err)
+	}
+	if want := []string{"3", "1"}; !reflect.DeepEqual(undone, want) {
+		t.Errorf("undone: got %v, want %v", undone, want)
+	}
+}
+
+func TestRollbackIgnoresCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var tx Tx
+	tx.Record("event x", func(ctx context.Context) error { return ctx.Err() })
+	if err := tx.Rollback(ctx); err != nil {
+		t.Errorf("undo should run with a live context, got %v", err)
+	}
+}
+
+func TestFail(t *testing.T) {
+	cause := errors.New("step 2 failed")
+
+	tests := []struct {
+		name     string
+		record   int
+		undoErr  error
+		wantText string
+	}{
+		{"nothing recorded", 0, nil, "step 2 failed"},
+		{"rolled back", 2, nil, "rolled back 2 item(s)"},
+		{"rollback incomplete", 1, errors.New("forbidden"), "remove manually: item"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tx Tx
+			for i := 0; i < tt.record; i++ {
+				tx.Record("item", func(ctx context.Context) error { return tt.undoErr })
+			}
+			err := tx.Fail(context.Background(), cause)
+			if !errors.Is(err, cause) {
+				t.Errorf("cause not preserved: %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("error %q does not contain %q", err, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestCommit(t *testing.T) {
+	var tx Tx
+	called := false
+	tx.Record("doc", func(ctx context.Context) error { called = true; return nil })
+	tx.Commit()
+
+	cause := errors.New("late failure")
+	if err := tx.Fail(context.Background(), cause); err != cause || called {
+		t.Errorf("committed transaction must not roll back: err=%v called=%v", err, called)
+	}
+}
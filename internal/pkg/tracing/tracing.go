@@ -0,0 +1,89 @@
+// Package tracing configures OpenTelemetry tracing for the server. Spans are
+// created through the global tracer provider, which is a no-op until Setup
+// installs an OTLP exporter, so instrumentation costs nothing when tracing
+// is disabled.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName is the default service.name resource attribute; OTEL_SERVICE_NAME
+// overrides it.
+const ServiceName = "google-workspace-mcp"
+
+const instrumentationName = "github.com/evert/google-workspace-mcp-go"
+
+// Span attribute keys shared by the middleware and the service factory.
+const (
+	AttrMethod        = attribute.Key("mcp.method.name")
+	AttrTool          = attribute.Key("gen_ai.tool.name")
+	AttrUser          = attribute.Key("enduser.id")
+	AttrGoogleService = attribute.Key("google.service")
+	AttrClientCached  = attribute.Key("google.client.cached")
+)
+
+// Tracer returns the server's tracer from the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup installs a global tracer provider that batches spans to an OTLP/HTTP
+// collector. endpoint (e.g. "http://otel-collector:4318") overrides the
+// standard OTEL_EXPORTER_OTLP_ENDPOINT variables when set; headers, TLS, and
+// sampling follow the standard OTEL_* variables. The returned function
+// flushes pending spans and must be called before exit.
+func Setup(ctx context.Context, endpoint, version string) (func(context.Context) error, error) {
+	var opts []otlptracehttp.Option
+	if endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", ServiceName),
+		attribute.String("service.version", version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("building trace resource: %w", err)
+	}
+	// Environment variables (OTEL_SERVICE_NAME, OTEL_RESOURCE_ATTRIBUTES)
+	// take precedence over the defaults above.
+	if envRes, err := resource.New(ctx, resource.WithFromEnv()); err == nil {
+		if merged, err := resource.Merge(res, envRes); err == nil {
+			res = merged
+		}
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Transport wraps base so each Google API request made for service is
+// recorded as a client span with its latency and status code. Trace context
+// is not propagated to Google.
+func Transport(base http.RoundTripper, service string) http.RoundTripper {
+	return otelhttp.NewTransport(base,
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return service + " " + r.Method
+		}),
+		otelhttp.WithSpanOptions(trace.WithAttributes(AttrGoogleService.String(service))),
+	)
+}
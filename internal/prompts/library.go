@@ -0,0 +1,115 @@
+package prompts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// library returns the prompts the server can offer.
+func library() []workflow {
+	return []workflow{
+		{
+			prompt: &mcp.Prompt{
+				Name:        "summarize_email_thread",
+				Title:       "Summarize Email Thread",
+				Description: "Summarize a Gmail thread: decisions, open questions and who owes what.",
+				Arguments: []*mcp.PromptArgument{userArg, {
+					Name:        "thread_id",
+					Title:       "Thread ID",
+					Description: "The Gmail thread ID, as returned by search_gmail_messages",
+					Required:    true,
+				}},
+			},
+			services: []string{"gmail"},
+			render:   summarizeThread,
+		},
+		{
+			prompt: &mcp.Prompt{
+				Name:        "draft_email_reply",
+				Title:       "Draft Email Reply",
+				Description: "Draft a reply to a Gmail message and save it as a draft for review.",
+				Arguments: []*mcp.PromptArgument{userArg, {
+					Name:        "message_id",
+					Title:       "Message ID",
+					Description: "The Gmail message to reply to",
+					Required:    true,
+				}, {
+					Name:        "intent",
+					Title:       "What to say",
+					Description: "The gist of the reply, e.g. \"accept, but propose Thursday instead\"",
+				}},
+			},
+			services: []string{"gmail"},
+			render:   draftReply,
+		},
+		{
+			prompt: &mcp.Prompt{
+				Name:        "prepare_meeting_brief",
+				Title:       "Prepare Meeting Brief",
+				Description: "Brief me for a calendar event: attendees, agenda, related mail and documents.",
+				Arguments: []*mcp.PromptArgument{userArg, {
+					Name:        "event_id",
+					Title:       "Event ID",
+					Description: "The event ID on the primary calendar, as returned by get_events",
+					Required:    true,
+				}},
+			},
+			services: []string{"calendar"},
+			render:   meetingBrief,
+		},
+	}
+}
+
+func summarizeThread(args map[string]string, _ func(string) bool) string {
+	return fmt.Sprintf(`Summarize the Gmail thread %[2]s of %[1]s.
+
+1. Call get_gmail_thread_content with user_google_email=%[1]q and thread_id=%[2]q.
+2. Reply with:
+   - a two- or three-sentence summary of what the thread is about,
+   - decisions that were made,
+   - open questions,
+   - action items, each with its owner and any deadline.
+Quote senders by name. Say so when the thread does not settle something rather than guessing.`,
+		args["user_google_email"], args["thread_id"])
+}
+
+func draftReply(args map[string]string, _ func(string) bool) string {
+	intent := args["intent"]
+	if intent == "" {
+		intent = "whatever the message most needs in response — ask me if that is unclear"
+	}
+	return fmt.Sprintf(`Draft a reply to Gmail message %[2]s of %[1]s.
+
+1. Call get_gmail_message_content with user_google_email=%[1]q and message_id=%[2]q. Note its sender, subject and thread ID.
+2. If earlier messages matter, call get_gmail_thread_content with that thread ID.
+3. Write a reply that says: %[3]s.
+   Match the sender's tone and language, keep it short, and do not invent facts, dates or commitments.
+4. Show me the draft. Then call draft_gmail_message with user_google_email=%[1]q, to set to the sender, subject prefixed with "Re: " unless it already is, and thread_id set to the thread ID, so it lands in the same conversation.
+Do not send it; I will review the draft in Gmail.`,
+		args["user_google_email"], args["message_id"], intent)
+}
+
+func meetingBrief(args map[string]string, enabled func(string) bool) string {
+	user, event := args["user_google_email"], args["event_id"]
+	var b strings.Builder
+	fmt.Fprintf(&b, "Prepare a brief for calendar event %s of %s.\n\n", event, user)
+	fmt.Fprintf(&b, "1. Call get_events with user_google_email=%q, event_id=%q and detailed=true. Note the title, time, attendees, description and attachments.\n", user, event)
+	step := 2
+	if enabled("gmail") {
+		fmt.Fprintf(&b, "%d. Call search_gmail_messages with user_google_email=%q and a query for the event title and the attendees' addresses over the last 30 days (e.g. `newer_than:30d`). Read the most relevant threads with get_gmail_thread_content.\n", step, user)
+		step++
+	}
+	if enabled("drive") {
+		fmt.Fprintf(&b, "%d. Open documents linked or attached to the event with get_drive_file_content. If there are none, call search_drive_files with user_google_email=%q for files whose name contains the event title.\n", step, user)
+		step++
+	}
+	fmt.Fprintf(&b, "%d. Reply with a one-page brief:\n", step)
+	b.WriteString("   - when and where, and who is attending (with their response status),\n")
+	b.WriteString("   - the purpose and agenda,\n")
+	b.WriteString("   - relevant background from mail and documents, with links,\n")
+	b.WriteString("   - open questions and what I should prepare.\n")
+	b.WriteString("Keep it skimmable and say which sources you used.")
+	return b.String()
+}
@@ -0,0 +1,75 @@
+// Package prompts registers MCP prompts: user-invoked templates for common
+// workspace workflows. Each prompt expands to instructions that name the
+// tools to call and pre-fill their arguments, so a prompt-capable client is
+// useful without the user knowing the tool surface.
+package prompts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// workflow is one prompt of the library.
+type workflow struct {
+	prompt *mcp.Prompt
+	// services must all be enabled for the prompt to be offered.
+	services []string
+	// render expands the arguments into the instruction text. enabled reports
+	// whether an optional service is available, so steps using it can be
+	// left out.
+	render func(args map[string]string, enabled func(string) bool) string
+}
+
+// Register adds every prompt whose services are enabled. enabled reports
+// whether a service's tools are registered on the server.
+func Register(server *mcp.Server, enabled func(service string) bool) {
+	for _, w := range library() {
+		if !allEnabled(w.services, enabled) {
+			continue
+		}
+		server.AddPrompt(w.prompt, handler(w, enabled))
+	}
+}
+
+// handler checks the prompt's required arguments and returns its rendered
+// text as a single user message.
+func handler(w workflow, enabled func(string) bool) mcp.PromptHandler {
+	return func(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := req.Params.Arguments
+		for _, a := range w.prompt.Arguments {
+			if a.Required && args[a.Name] == "" {
+				return nil, &jsonrpc.Error{
+					Code:    jsonrpc.CodeInvalidParams,
+					Message: fmt.Sprintf("prompt %q requires argument %q", w.prompt.Name, a.Name),
+				}
+			}
+		}
+		return &mcp.GetPromptResult{
+			Description: w.prompt.Description,
+			Messages: []*mcp.PromptMessage{{
+				Role:    "user",
+				Content: &mcp.TextContent{Text: w.render(args, enabled)},
+			}},
+		}, nil
+	}
+}
+
+func allEnabled(services []string, enabled func(string) bool) bool {
+	for _, s := range services {
+		if !enabled(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// userArg is the account argument every prompt takes.
+var userArg = &mcp.PromptArgument{
+	Name:        "user_google_email",
+	Title:       "Google account",
+	Description: "The Google account to work in",
+	Required:    true,
+}
@@ -0,0 +1,95 @@
+package prompts
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func connect(t *testing.T, enabled ...string) *mcp.ClientSession {
+	t.Helper()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+	Register(server, func(s string) bool {
+		for _, e := range enabled {
+			if e == s {
+				return true
+			}
+		}
+		return false
+	})
+	st, ct := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(context.Background(), st, nil); err != nil {
+		t.Fatal(err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1"}, nil)
+	cs, err := client.Connect(context.Background(), ct, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cs.Close() })
+	return cs
+}
+
+func TestRegisterFiltersByService(t *testing.T) {
+	tests := []struct {
+		enabled []string
+		want    []string
+	}{
+		{enabled: []string{"gmail", "calendar"}, want: []string{"draft_email_reply", "prepare_meeting_brief", "summarize_email_thread"}},
+		{enabled: []string{"calendar"}, want: []string{"prepare_meeting_brief"}},
+		{enabled: []string{"drive"}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.enabled, ","), func(t *testing.T) {
+			cs := connect(t, tt.enabled...)
+			var got []string
+			for p, err := range cs.Prompts(context.Background(), nil) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, p.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("prompts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPrompt(t *testing.T) {
+	cs := connect(t, "gmail", "calendar")
+	ctx := context.Background()
+
+	res, err := cs.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "summarize_email_thread",
+		Arguments: map[string]string{"user_google_email": "a@example.com", "thread_id": "t1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := res.Messages[0].Content.(*mcp.TextContent).Text
+	if !strings.Contains(text, `get_gmail_thread_content with user_google_email="a@example.com" and thread_id="t1"`) {
+		t.Errorf("thread prompt does not pre-fill the tool call:\n%s", text)
+	}
+
+	if _, err := cs.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "draft_email_reply",
+		Arguments: map[string]string{"user_google_email": "a@example.com"},
+	}); err == nil || !strings.Contains(err.Error(), "message_id") {
+		t.Errorf("missing required argument: got %v", err)
+	}
+
+	res, err = cs.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "prepare_meeting_brief",
+		Arguments: map[string]string{"user_google_email": "a@example.com", "event_id": "e1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text = res.Messages[0].Content.(*mcp.TextContent).Text
+	if !strings.Contains(text, "search_gmail_messages") || strings.Contains(text, "search_drive_files") {
+		t.Errorf("meeting brief should use only the enabled services:\n%s", text)
+	}
+}
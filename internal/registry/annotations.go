@@ -0,0 +1,100 @@
+package registry
+
+import (
+	"context"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/config"
+)
+
+// AnnotationMiddleware returns MCP middleware that applies the annotation
+// hints from the tier config to tools/list responses. Install it directly
+// above the handlers so every other middleware that learns read-only or
+// destructive tools from tools/list sees the configured hints.
+func AnnotationMiddleware(filter *TierFilter) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			if method != "tools/list" || err != nil {
+				return result, err
+			}
+			if list, ok := result.(*mcp.ListToolsResult); ok {
+				for i, tool := range list.Tools {
+					if hints := filter.Hints(tool.Name); !hints.IsZero() {
+						list.Tools[i] = annotate(tool, hints)
+					}
+				}
+			}
+			return result, err
+		}
+	}
+}
+
+// annotate returns a copy of tool with hints applied over its annotations.
+// The server's own tool definitions are shared, so they are never modified.
+func annotate(tool *mcp.Tool, hints config.ToolHints) *mcp.Tool {
+	copied := *tool
+	a := mcp.ToolAnnotations{}
+	if tool.Annotations != nil {
+		a = *tool.Annotations
+	}
+	if hints.ReadOnly != nil {
+		a.ReadOnlyHint = *hints.ReadOnly
+	}
+	if hints.Destructive != nil {
+		a.DestructiveHint = hints.Destructive
+	}
+	if hints.Idempotent != nil {
+		a.IdempotentHint = *hints.Idempotent
+	}
+	if hints.OpenWorld != nil {
+		a.OpenWorldHint = hints.OpenWorld
+	}
+	copied.Annotations = &a
+	return &copied
+}
+
+// Hints returns the annotation hints the tier config sets for a tool.
+func (f *TierFilter) Hints(name string) config.ToolHints {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.hints[name]
+}
+
+// UpdateHints swaps in the annotation hints from a new tier map and returns
+// the tools whose hints changed, sorted by name.
+func (f *TierFilter) UpdateHints(tierMap map[string]config.ToolInfo) []string {
+	next := toolHints(tierMap)
+
+	f.mu.Lock()
+	prev := f.hints
+	f.hints = next
+	f.mu.Unlock()
+
+	var changed []string
+	for name, h := range next {
+		if !prev[name].Equal(h) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range prev {
+		if _, ok := next[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// toolHints collects the tools that have annotation hints set.
+func toolHints(tierMap map[string]config.ToolInfo) map[string]config.ToolHints {
+	hints := make(map[string]config.ToolHints)
+	for name, info := range tierMap {
+		if !info.Hints.IsZero() {
+			hints[name] = info.Hints
+		}
+	}
+	return hints
+}
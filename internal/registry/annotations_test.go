@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/config"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/ptr"
+)
+
+func TestAnnotationMiddleware(t *testing.T) {
+	filter := NewTierFilter(&config.Config{ToolTier: "complete"}, map[string]config.ToolInfo{
+		"archive_file": {Tier: "core", Service: "drive", Hints: config.ToolHints{Destructive: ptr.Bool(true), Idempotent: ptr.Bool(true)}},
+		"list_files":   {Tier: "core", Service: "drive"},
+	})
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	handler := func(context.Context, *mcp.CallToolRequest, struct{}) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{}, nil, nil
+	}
+	archive := &mcp.Tool{Name: "archive_file", Annotations: &mcp.ToolAnnotations{Title: "Archive", DestructiveHint: ptr.Bool(false)}}
+	mcp.AddTool(server, archive, handler)
+	mcp.AddTool(server, &mcp.Tool{Name: "list_files", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}, handler)
+	server.AddReceivingMiddleware(AnnotationMiddleware(filter))
+
+	st, ct := mcp.NewInMemoryTransports()
+	ss, err := server.Connect(context.Background(), st, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ss.Close()
+	cs, err := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"}, nil).Connect(context.Background(), ct, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cs.Close()
+
+	list, err := cs.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]*mcp.ToolAnnotations{}
+	for _, tool := range list.Tools {
+		got[tool.Name] = tool.Annotations
+	}
+	if a := got["archive_file"]; a.Title != "Archive" || !*a.DestructiveHint || !a.IdempotentHint {
+		t.Errorf("archive_file annotations = %+v, want configured hints over the tool's own", a)
+	}
+	if a := got["list_files"]; !a.ReadOnlyHint || a.DestructiveHint != nil {
+		t.Errorf("list_files annotations = %+v, want them unchanged", a)
+	}
+	if *archive.Annotations.DestructiveHint {
+		t.Error("the registered tool definition was modified")
+	}
+}
+
+func TestTierFilterUpdateHints(t *testing.T) {
+	filter := NewTierFilter(&config.Config{}, map[string]config.ToolInfo{
+		"a": {Hints: config.ToolHints{ReadOnly: ptr.Bool(true)}},
+		"b": {Hints: config.ToolHints{Destructive: ptr.Bool(true)}},
+	})
+	changed := filter.UpdateHints(map[string]config.ToolInfo{
+		"a": {Hints: config.ToolHints{ReadOnly: ptr.Bool(true)}},
+		"c": {Hints: config.ToolHints{Idempotent: ptr.Bool(false)}},
+	})
+	if want := []string{"b", "c"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("changed = %v, want %v", changed, want)
+	}
+	if !filter.Hints("b").IsZero() || filter.Hints("c").Idempotent == nil {
+		t.Error("hints not swapped")
+	}
+}
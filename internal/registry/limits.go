@@ -0,0 +1,87 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/config"
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+)
+
+// pageSizeLimitMiddleware caps the page_size argument of tools/call requests
+// at the max_page_size configured for the tool's service. Requests that omit
+// page_size are left alone, since every tool's own default is already small.
+func pageSizeLimitMiddleware(limits map[string]config.ServiceLimits, tierMap map[string]config.ToolInfo) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if !ok {
+				return next(ctx, method, req)
+			}
+			info, ok := tierMap[params.Name]
+			if !ok {
+				return next(ctx, method, req)
+			}
+			if limit := limits[info.Service].MaxPageSize; limit > 0 {
+				params.Arguments = capPageSize(params.Arguments, limit)
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// capPageSize lowers a page_size argument above limit to limit. Arguments that
+// cannot be decoded are returned unchanged for the handler to reject.
+func capPageSize(args json.RawMessage, limit int) json.RawMessage {
+	var m map[string]any
+	if err := json.Unmarshal(args, &m); err != nil {
+		return args
+	}
+	size, ok := m["page_size"].(float64)
+	if !ok || size <= float64(limit) {
+		return args
+	}
+
+	m["page_size"] = limit
+	capped, err := json.Marshal(m)
+	if err != nil {
+		return args
+	}
+	slog.Debug("capped page_size to service limit", "requested", size, "max", limit)
+	return capped
+}
+
+// rateLimitMiddleware builds the per-user tool call rate limiter from the
+// global and per-service settings in cfg. It returns nil when no rate is set.
+func rateLimitMiddleware(cfg *config.Config, tierMap map[string]config.ToolInfo) mcp.Middleware {
+	def := middleware.Rate{QPS: cfg.RateLimit.QPS, Burst: cfg.RateLimit.Burst}
+	perService := make(map[string]middleware.Rate)
+	for service, limits := range cfg.ServiceLimits {
+		if limits.QPS > 0 {
+			perService[service] = middleware.Rate{QPS: limits.QPS, Burst: cmp.Or(limits.Burst, def.Burst)}
+		}
+	}
+	if def.QPS <= 0 && len(perService) == 0 {
+		return nil
+	}
+
+	slog.Info("tool call rate limit enabled", "qps", def.QPS, "burst", def.Burst, "per_service", len(perService))
+	limiter := middleware.NewRateLimiter(def, perService)
+	return middleware.RateLimitMiddleware(limiter, ToolService(tierMap))
+}
+
+// ToolService returns a lookup of each tool's service in tierMap, for
+// middleware that classifies tool calls by service.
+func ToolService(tierMap map[string]config.ToolInfo) func(tool string) (string, bool) {
+	return func(tool string) (string, bool) {
+		info, ok := tierMap[tool]
+		return info.Service, ok
+	}
+}
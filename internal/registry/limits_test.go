@@ -0,0 +1,28 @@
+package registry
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCapPageSize(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"above limit", `{"page_size":100,"query":"x"}`, `{"page_size":20,"query":"x"}`},
+		{"at limit", `{"page_size":20}`, `{"page_size":20}`},
+		{"below limit", `{"page_size":5}`, `{"page_size":5}`},
+		{"no page_size", `{"query":"x"}`, `{"query":"x"}`},
+		{"not an object", `[1,2]`, `[1,2]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := capPageSize(json.RawMessage(tt.args), 20)
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,21 +5,29 @@ import (
 	"fmt"
 	"log/slog"
 	"regexp"
+	"slices"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/evert/google-workspace-mcp-go/internal/auth"
 	"github.com/evert/google-workspace-mcp-go/internal/config"
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/prompts"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
+	"github.com/evert/google-workspace-mcp-go/internal/tools/admin"
 	"github.com/evert/google-workspace-mcp-go/internal/tools/appscript"
 	authtools "github.com/evert/google-workspace-mcp-go/internal/tools/auth"
 	"github.com/evert/google-workspace-mcp-go/internal/tools/calendar"
 	"github.com/evert/google-workspace-mcp-go/internal/tools/chat"
+	"github.com/evert/google-workspace-mcp-go/internal/tools/classroom"
 	"github.com/evert/google-workspace-mcp-go/internal/tools/contacts"
 	"github.com/evert/google-workspace-mcp-go/internal/tools/docs"
 	"github.com/evert/google-workspace-mcp-go/internal/tools/drive"
 	"github.com/evert/google-workspace-mcp-go/internal/tools/forms"
 	"github.com/evert/google-workspace-mcp-go/internal/tools/gmail"
+	"github.com/evert/google-workspace-mcp-go/internal/tools/keep"
+	"github.com/evert/google-workspace-mcp-go/internal/tools/meet"
 	"github.com/evert/google-workspace-mcp-go/internal/tools/search"
 	"github.com/evert/google-workspace-mcp-go/internal/tools/sheets"
 	"github.com/evert/google-workspace-mcp-go/internal/tools/slides"
@@ -53,33 +61,48 @@ func serviceEnabled(cfg *config.Config, service string) bool {
 // RegisterAll registers all tool packages with the server, applying tier, service, and mode filters.
 // Each service package exposes Register(server, factory) which adds its tools.
 // Tier and read-only filtering is enforced via middleware that intercepts tools/call
-// requests, rejecting calls to tools excluded by the current config.
-func RegisterAll(server *mcp.Server, factory *services.Factory, cfg *config.Config, tierMap map[string]config.ToolInfo, oauthMgr *auth.OAuthManager) {
+// requests, rejecting calls to tools excluded by filter. The filter can be updated
+// to change tier visibility at runtime (see WatchTiers); build it with
+// NewTierFilter before installing AnnotationMiddleware, which shares it.
+// Services that expose MCP resources register them with res. mailbox backs
+// the Gmail push notification tools and may be nil. Prompts are registered
+// for the enabled services.
+func RegisterAll(server *mcp.Server, factory *services.Factory, cfg *config.Config, tierMap map[string]config.ToolInfo, filter *TierFilter, res *middleware.Resources, mailbox *gmail.MailboxWatcher, oauthMgr *auth.OAuthManager) {
 	slog.Info("registering tools",
 		"tier", cfg.ToolTier,
 		"services", cfg.EnabledServices,
 		"readOnly", cfg.ReadOnly,
+		"allow", cfg.Tools.Allow,
+		"deny", cfg.Tools.Deny,
 	)
+	warnUnknownTools(cfg, tierMap)
 
 	// Install tier/read-only filtering middleware. This intercepts tools/call
 	// requests and blocks calls to tools that are excluded by the current tier
 	// or read-only config. tools/list responses are also filtered so excluded
-	// tools never appear in the tool listing.
-	if len(tierMap) > 0 {
-		server.AddReceivingMiddleware(tierFilterMiddleware(cfg, tierMap))
+	// tools never appear in the tool listing. It is installed even when the
+	// tier map is empty so a later reload can still take effect.
+	server.AddReceivingMiddleware(tierFilterMiddleware(cfg, filter))
+	if len(cfg.ServiceLimits) > 0 {
+		server.AddReceivingMiddleware(pageSizeLimitMiddleware(cfg.ServiceLimits, tierMap))
+	}
+	if mw := rateLimitMiddleware(cfg, tierMap); mw != nil {
+		server.AddReceivingMiddleware(mw)
 	}
 
 	// Phase 2: Core services (Gmail, Drive, Calendar, Sheets)
 	if serviceEnabled(cfg, "gmail") {
-		gmail.Register(server, factory)
+		gmail.Register(server, factory, cfg.UnsubscribeDomains, filingRules(cfg.AttachmentRules), cfg.GmailPermanentDeleteEnabled(), mailbox, res)
 		slog.Info("registered service", "service", "gmail")
 	}
 	if serviceEnabled(cfg, "drive") {
-		drive.Register(server, factory)
+		// Only a stdio server runs as the user on their own machine, so only
+		// there may tools read local files.
+		drive.Register(server, factory, res, cfg.Server.Transport == "stdio", cfg.DownloadDir)
 		slog.Info("registered service", "service", "drive")
 	}
 	if serviceEnabled(cfg, "calendar") {
-		calendar.Register(server, factory)
+		calendar.Register(server, factory, res)
 		slog.Info("registered service", "service", "calendar")
 	}
 	if serviceEnabled(cfg, "sheets") {
@@ -96,6 +119,18 @@ func RegisterAll(server *mcp.Server, factory *services.Factory, cfg *config.Conf
 		tasks.Register(server, factory)
 		slog.Info("registered service", "service", "tasks")
 	}
+	if serviceEnabled(cfg, "keep") {
+		keep.Register(server, factory)
+		slog.Info("registered service", "service", "keep")
+	}
+	if serviceEnabled(cfg, "classroom") {
+		classroom.Register(server, factory)
+		slog.Info("registered service", "service", "classroom")
+	}
+	if serviceEnabled(cfg, "meet") {
+		meet.Register(server, factory)
+		slog.Info("registered service", "service", "meet")
+	}
 	if serviceEnabled(cfg, "contacts") {
 		contacts.Register(server, factory)
 		slog.Info("registered service", "service", "contacts")
@@ -115,33 +150,56 @@ func RegisterAll(server *mcp.Server, factory *services.Factory, cfg *config.Conf
 		slog.Info("registered service", "service", "slides")
 	}
 	if serviceEnabled(cfg, "search") {
-		search.Register(server, factory, cfg.CSEID)
+		search.Register(server, factory, cfg.CSEID, cfg.CloudSearchApp)
 		slog.Info("registered service", "service", "search")
 	}
 	if serviceEnabled(cfg, "appscript") {
 		appscript.Register(server, factory)
 		slog.Info("registered service", "service", "appscript")
 	}
+	if cfg.AdminEnabled() {
+		admin.Register(server, factory)
+		slog.Info("registered service", "service", "admin")
+	}
+
+	// Prompts for common workflows, offered when the services they use are enabled
+	prompts.Register(server, func(service string) bool { return serviceEnabled(cfg, service) })
 
-	// Auth tool (filtered out when OAuth 2.1 is enabled)
+	// Auth tools (filtered out when OAuth 2.1 is enabled)
 	if !cfg.EnableOAuth21 {
-		authtools.Register(server, oauthMgr)
+		authtools.Register(server, oauthMgr, factory, cfg.Server.Transport == "stdio")
 		slog.Info("registered service", "service", "auth")
 	}
 }
 
-// tierFilterMiddleware returns MCP middleware that enforces per-tool tier and
-// read-only filtering. It blocks tools/call requests for tools that are above
-// the configured tier or are write tools in read-only mode.
-func tierFilterMiddleware(cfg *config.Config, tierMap map[string]config.ToolInfo) mcp.Middleware {
-	// Pre-build the set of excluded tool names for fast lookup.
-	excluded := make(map[string]bool)
-	for toolName, info := range tierMap {
-		if config.TierLevel(info.Tier) > config.TierLevel(cfg.ToolTier) {
-			excluded[toolName] = true
+// filingRules converts configured attachment rules to Gmail filing rules.
+func filingRules(rules []config.AttachmentRule) []gmail.FilingRule {
+	out := make([]gmail.FilingRule, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, gmail.FilingRule{Sender: r.Sender, Domain: r.Domain, Type: r.Type, FolderID: r.FolderID})
+	}
+	return out
+}
+
+// warnUnknownTools logs allow/deny entries that name no known tool, which are
+// usually typos that would otherwise silently hide or expose tools.
+func warnUnknownTools(cfg *config.Config, tierMap map[string]config.ToolInfo) {
+	if len(tierMap) == 0 {
+		return
+	}
+	for _, list := range [][]string{cfg.Tools.Allow, cfg.Tools.Deny} {
+		for _, name := range list {
+			if _, ok := tierMap[name]; !ok && name != "start_google_auth" && name != "revoke_google_credentials" && name != response.ContinueTool {
+				slog.Warn("unknown tool in TOOLS_ALLOW/TOOLS_DENY", "tool", name)
+			}
 		}
 	}
+}
 
+// tierFilterMiddleware returns MCP middleware that enforces per-tool tier and
+// read-only filtering. It blocks tools/call requests for tools that are above
+// the configured tier or are write tools in read-only mode.
+func tierFilterMiddleware(cfg *config.Config, filter *TierFilter) mcp.Middleware {
 	// readOnlyAllowed tracks which tools are safe to call in read-only mode.
 	// Built lazily on first tools/list response (when annotations are available).
 	readOnlyAllowed := make(map[string]bool)
@@ -164,7 +222,7 @@ func tierFilterMiddleware(cfg *config.Config, tierMap map[string]config.ToolInfo
 							}
 							readOnlyBuilt = true
 						}
-						listResult.Tools = filterToolPtrList(listResult.Tools, excluded, cfg)
+						listResult.Tools = filterToolPtrList(listResult.Tools, filter, cfg)
 					}
 				}
 
@@ -179,8 +237,16 @@ func tierFilterMiddleware(cfg *config.Config, tierMap map[string]config.ToolInfo
 
 			toolName := params.Name
 
-			// Check tier exclusion.
-			if excluded[toolName] {
+			// Check the operator's allow/deny lists, then tier exclusion.
+			if filter.Disabled(toolName) {
+				return &mcp.CallToolResult{
+					IsError: true,
+					Content: []mcp.Content{&mcp.TextContent{
+						Text: fmt.Sprintf("tool %q is disabled by this server's TOOLS_ALLOW/TOOLS_DENY configuration", toolName),
+					}},
+				}, nil
+			}
+			if filter.Excluded(toolName) {
 				return &mcp.CallToolResult{
 					IsError: true,
 					Content: []mcp.Content{&mcp.TextContent{
@@ -204,12 +270,12 @@ func tierFilterMiddleware(cfg *config.Config, tierMap map[string]config.ToolInfo
 	}
 }
 
-// filterToolPtrList removes tools from the list that are excluded by tier or
-// read-only config.
-func filterToolPtrList(tools []*mcp.Tool, excluded map[string]bool, cfg *config.Config) []*mcp.Tool {
+// filterToolPtrList removes tools from the list that are excluded by tier,
+// allow/deny lists, or read-only config.
+func filterToolPtrList(tools []*mcp.Tool, filter *TierFilter, cfg *config.Config) []*mcp.Tool {
 	filtered := make([]*mcp.Tool, 0, len(tools))
 	for _, tool := range tools {
-		if excluded[tool.Name] {
+		if filter.Hidden(tool.Name) {
 			continue
 		}
 		// In read-only mode, exclude tools that are not marked as read-only.
@@ -229,6 +295,14 @@ func ShouldIncludeTool(toolName string, cfg *config.Config, tierMap map[string]c
 		return false
 	}
 
+	// Filter by operator allow/deny lists
+	if slices.Contains(cfg.Tools.Deny, toolName) {
+		return false
+	}
+	if len(cfg.Tools.Allow) > 0 && !slices.Contains(cfg.Tools.Allow, toolName) && !alwaysAllowed(toolName) {
+		return false
+	}
+
 	// Filter by tier level
 	if config.TierLevel(info.Tier) > config.TierLevel(cfg.ToolTier) {
 		return false
@@ -253,8 +327,8 @@ func ShouldIncludeTool(toolName string, cfg *config.Config, tierMap map[string]c
 		return false
 	}
 
-	// Filter out legacy auth tool when OAuth 2.1 is enabled
-	if cfg.EnableOAuth21 && toolName == "start_google_auth" {
+	// Filter out legacy auth tools when OAuth 2.1 is enabled
+	if cfg.EnableOAuth21 && (toolName == "start_google_auth" || toolName == "revoke_google_credentials") {
 		return false
 	}
 
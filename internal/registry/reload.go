@@ -0,0 +1,214 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/config"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+)
+
+// reloadDebounce coalesces the burst of events editors emit for a single save.
+const reloadDebounce = 250 * time.Millisecond
+
+// listChangedTool is an internal placeholder tool. Re-adding it is the only
+// way to make the SDK emit notifications/tools/list_changed, since the server
+// exposes no direct notify API. It is never listed and cannot be called.
+const listChangedTool = "_tool_tiers_reloaded"
+
+// TierFilter holds the set of tools hidden by the current tier config and the
+// operator's allow/deny lists, and the annotation hints the tier config sets.
+// It is shared with the filtering and annotation middleware, and its tier set
+// and hints are swapped when tool_tiers.yaml changes.
+type TierFilter struct {
+	cfg   *config.Config
+	allow map[string]bool // empty = every tool allowed
+	deny  map[string]bool
+
+	mu       sync.RWMutex
+	excluded map[string]bool
+	hints    map[string]config.ToolHints
+}
+
+// NewTierFilter builds a filter hiding every tool above cfg.ToolTier and every
+// tool disabled by cfg.Tools.
+func NewTierFilter(cfg *config.Config, tierMap map[string]config.ToolInfo) *TierFilter {
+	return &TierFilter{
+		cfg:      cfg,
+		allow:    toolSet(cfg.Tools.Allow),
+		deny:     toolSet(cfg.Tools.Deny),
+		excluded: excludedTools(cfg, tierMap),
+		hints:    toolHints(tierMap),
+	}
+}
+
+// Disabled reports whether a tool is turned off by the allow/deny lists.
+// The deny list wins over the allow list.
+func (f *TierFilter) Disabled(name string) bool {
+	if f.deny[name] {
+		return true
+	}
+	return len(f.allow) > 0 && !f.allow[name] && !alwaysAllowed(name)
+}
+
+// alwaysAllowed reports whether a tool stays available under an allowlist
+// without being listed: every other tool depends on being able to authorize,
+// and on reading output cut by the output budget. It can still be removed
+// with the deny list.
+func alwaysAllowed(name string) bool {
+	return name == "start_google_auth" || name == response.ContinueTool
+}
+
+// Hidden reports whether a tool should be left out of tools/list, either
+// because of its tier or because it is disabled.
+func (f *TierFilter) Hidden(name string) bool {
+	return f.Disabled(name) || f.Excluded(name)
+}
+
+// Excluded reports whether a tool is hidden by the current tier config.
+func (f *TierFilter) Excluded(name string) bool {
+	if name == listChangedTool {
+		return true
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.excluded[name]
+}
+
+// Update swaps in a new tier map and returns the tools that became visible
+// and hidden as a result, each sorted by name.
+func (f *TierFilter) Update(tierMap map[string]config.ToolInfo) (shown, hidden []string) {
+	next := excludedTools(f.cfg, tierMap)
+
+	f.mu.Lock()
+	prev := f.excluded
+	f.excluded = next
+	f.mu.Unlock()
+
+	for name := range prev {
+		if !next[name] {
+			shown = append(shown, name)
+		}
+	}
+	for name := range next {
+		if !prev[name] {
+			hidden = append(hidden, name)
+		}
+	}
+	sort.Strings(shown)
+	sort.Strings(hidden)
+	return shown, hidden
+}
+
+// toolSet builds a lookup set from a list of tool names.
+func toolSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// excludedTools returns the names of tools above the configured tier.
+func excludedTools(cfg *config.Config, tierMap map[string]config.ToolInfo) map[string]bool {
+	excluded := make(map[string]bool)
+	for toolName, info := range tierMap {
+		if config.TierLevel(info.Tier) > config.TierLevel(cfg.ToolTier) {
+			excluded[toolName] = true
+		}
+	}
+	return excluded
+}
+
+// WatchTiers reloads the tier config at path whenever it changes and notifies
+// connected clients that the tool list changed. It blocks until ctx is
+// cancelled. An invalid file is logged and the previous config stays active.
+func WatchTiers(ctx context.Context, server *mcp.Server, path string, filter *TierFilter) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating tier config watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// Watch the directory rather than the file: editors and ConfigMap
+	// updates replace the file via rename, which drops a direct file watch.
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("watching tier config %s: %w", path, err)
+	}
+	slog.Info("watching tier config for changes", "path", path)
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if tierConfigEvent(ev, path) {
+				debounce = time.After(reloadDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Warn("tier config watcher error", "path", path, "error", err)
+		case <-debounce:
+			debounce = nil
+			reloadTiers(server, path, filter)
+		}
+	}
+}
+
+// tierConfigEvent reports whether ev touches the watched config file. Kubernetes
+// ConfigMaps swap a "..data" symlink, so events on it count as well.
+func tierConfigEvent(ev fsnotify.Event, path string) bool {
+	if !ev.Has(fsnotify.Write) && !ev.Has(fsnotify.Create) && !ev.Has(fsnotify.Rename) {
+		return false
+	}
+	base := filepath.Base(ev.Name)
+	return base == filepath.Base(path) || base == "..data"
+}
+
+// reloadTiers loads path into filter and, if visibility or annotations
+// changed, notifies clients.
+func reloadTiers(server *mcp.Server, path string, filter *TierFilter) {
+	tierMap, err := config.LoadTiers(path)
+	if err == nil && len(tierMap) == 0 {
+		// Most likely a save caught mid-write; an empty map would unhide every tool.
+		err = fmt.Errorf("tier config %s lists no tools", path)
+	}
+	if err != nil {
+		slog.Error("tier config reload failed — keeping previous config", "path", path, "error", err)
+		return
+	}
+
+	shown, hidden := filter.Update(tierMap)
+	reannotated := filter.UpdateHints(tierMap)
+	slog.Info("tier config reloaded", "path", path, "shown", shown, "hidden", hidden, "reannotated", reannotated)
+	if len(shown) == 0 && len(hidden) == 0 && len(reannotated) == 0 {
+		return
+	}
+	notifyToolListChanged(server)
+}
+
+// notifyToolListChanged makes the server send notifications/tools/list_changed
+// to every session by (re-)adding the hidden placeholder tool.
+func notifyToolListChanged(server *mcp.Server) {
+	server.AddTool(&mcp.Tool{
+		Name:        listChangedTool,
+		Description: "Internal placeholder used to signal tool tier reloads.",
+		InputSchema: map[string]any{"type": "object"},
+	}, func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, fmt.Errorf("tool %q is internal and cannot be called", listChangedTool)
+	})
+}
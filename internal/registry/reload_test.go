@@ -0,0 +1,126 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/evert/google-workspace-mcp-go/internal/config"
+)
+
+func TestTierFilterUpdate(t *testing.T) {
+	cfg := &config.Config{ToolTier: "extended"}
+	filter := NewTierFilter(cfg, map[string]config.ToolInfo{
+		"search_gmail":   {Tier: "core", Service: "gmail"},
+		"draft_gmail":    {Tier: "extended", Service: "gmail"},
+		"delete_filter":  {Tier: "complete", Service: "gmail"},
+		"batch_modify":   {Tier: "complete", Service: "gmail"},
+		"list_calendars": {Tier: "core", Service: "calendar"},
+	})
+
+	if !filter.Excluded("delete_filter") || filter.Excluded("draft_gmail") {
+		t.Fatal("initial filter does not match tier config")
+	}
+
+	shown, hidden := filter.Update(map[string]config.ToolInfo{
+		"search_gmail":   {Tier: "core", Service: "gmail"},
+		"draft_gmail":    {Tier: "complete", Service: "gmail"},
+		"delete_filter":  {Tier: "extended", Service: "gmail"},
+		"batch_modify":   {Tier: "complete", Service: "gmail"},
+		"list_calendars": {Tier: "core", Service: "calendar"},
+	})
+
+	if want := []string{"delete_filter"}; !reflect.DeepEqual(shown, want) {
+		t.Errorf("shown: got %v, want %v", shown, want)
+	}
+	if want := []string{"draft_gmail"}; !reflect.DeepEqual(hidden, want) {
+		t.Errorf("hidden: got %v, want %v", hidden, want)
+	}
+	if filter.Excluded("delete_filter") || !filter.Excluded("draft_gmail") || !filter.Excluded("batch_modify") {
+		t.Error("filter not updated to new tier config")
+	}
+	if !filter.Excluded(listChangedTool) {
+		t.Error("placeholder tool must always be excluded")
+	}
+}
+
+func TestReloadTiersKeepsConfigOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tool_tiers.yaml")
+	if err := os.WriteFile(path, []byte("services: [not, a, map"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{ToolTier: "core"}
+	filter := NewTierFilter(cfg, map[string]config.ToolInfo{"draft_gmail": {Tier: "extended", Service: "gmail"}})
+	reloadTiers(nil, path, filter)
+
+	if !filter.Excluded("draft_gmail") {
+		t.Error("invalid config must not replace the active tier config")
+	}
+}
+
+func TestTierConfigEvent(t *testing.T) {
+	path := "/configs/tool_tiers.yaml"
+	tests := []struct {
+		name string
+		ev   fsnotify.Event
+		want bool
+	}{
+		{"write", fsnotify.Event{Name: "/configs/tool_tiers.yaml", Op: fsnotify.Write}, true},
+		{"atomic save", fsnotify.Event{Name: "/configs/tool_tiers.yaml", Op: fsnotify.Create}, true},
+		{"configmap swap", fsnotify.Event{Name: "/configs/..data", Op: fsnotify.Create}, true},
+		{"chmod only", fsnotify.Event{Name: "/configs/tool_tiers.yaml", Op: fsnotify.Chmod}, false},
+		{"other file", fsnotify.Event{Name: "/configs/other.yaml", Op: fsnotify.Write}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tierConfigEvent(tt.ev, path); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTierFilterAllowDeny(t *testing.T) {
+	tierMap := map[string]config.ToolInfo{
+		"search_gmail_messages": {Tier: "core", Service: "gmail"},
+		"send_gmail_message":    {Tier: "core", Service: "gmail"},
+		"list_calendars":        {Tier: "core", Service: "calendar"},
+	}
+
+	tests := []struct {
+		name         string
+		allow, deny  []string
+		tool         string
+		wantDisabled bool
+	}{
+		{"no lists", nil, nil, "send_gmail_message", false},
+		{"denied", nil, []string{"send_gmail_message"}, "send_gmail_message", true},
+		{"not denied", nil, []string{"send_gmail_message"}, "search_gmail_messages", false},
+		{"allowed", []string{"search_gmail_messages"}, nil, "search_gmail_messages", false},
+		{"not allowed", []string{"search_gmail_messages"}, nil, "list_calendars", true},
+		{"deny wins", []string{"send_gmail_message"}, []string{"send_gmail_message"}, "send_gmail_message", true},
+		{"auth always allowed", []string{"search_gmail_messages"}, nil, "start_google_auth", false},
+		{"auth can be denied", nil, []string{"start_google_auth"}, "start_google_auth", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{ToolTier: "complete"}
+			cfg.Tools.Allow, cfg.Tools.Deny = tt.allow, tt.deny
+			filter := NewTierFilter(cfg, tierMap)
+
+			if got := filter.Disabled(tt.tool); got != tt.wantDisabled {
+				t.Errorf("Disabled(%q) = %v, want %v", tt.tool, got, tt.wantDisabled)
+			}
+			if got := filter.Hidden(tt.tool); got != tt.wantDisabled {
+				t.Errorf("Hidden(%q) = %v, want %v", tt.tool, got, tt.wantDisabled)
+			}
+			if got := ShouldIncludeTool(tt.tool, cfg, tierMap, nil); tt.tool != "start_google_auth" && got == tt.wantDisabled {
+				t.Errorf("ShouldIncludeTool(%q) = %v, want %v", tt.tool, got, !tt.wantDisabled)
+			}
+		})
+	}
+}
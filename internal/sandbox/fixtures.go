@@ -0,0 +1,207 @@
+package sandbox
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// loadFixture decodes an embedded fixture. Fixtures are decoded fresh on
+// every call so handlers may modify the result.
+func loadFixture(name string) map[string]any {
+	data, err := fixtureFS.ReadFile("fixtures/" + name)
+	if err != nil {
+		panic(fmt.Sprintf("sandbox: missing fixture %s", name))
+	}
+	var v map[string]any
+	if err := json.Unmarshal(data, &v); err != nil {
+		panic(fmt.Sprintf("sandbox: invalid fixture %s: %v", name, err))
+	}
+	return v
+}
+
+// fixtureItems returns the objects in a fixture's list field.
+func fixtureItems(name, field string) []map[string]any {
+	raw, _ := loadFixture(name)[field].([]any)
+	items := make([]map[string]any, 0, len(raw))
+	for _, it := range raw {
+		if m, ok := it.(map[string]any); ok {
+			items = append(items, m)
+		}
+	}
+	return items
+}
+
+// fixture serves a fixture file unchanged.
+func fixture(name string) handlerFunc {
+	return func(*http.Request, []string) (int, any) {
+		return http.StatusOK, loadFixture(name)
+	}
+}
+
+// item serves the element of a fixture list whose key equals the captured ID.
+func item(name, field, key string) handlerFunc {
+	return func(r *http.Request, m []string) (int, any) {
+		id, _ := url.PathUnescape(m[1])
+		for _, it := range fixtureItems(name, field) {
+			if it[key] == id {
+				return http.StatusOK, it
+			}
+		}
+		return http.StatusNotFound, notFound(r)
+	}
+}
+
+// document serves a single-object fixture for any ID, so every document,
+// spreadsheet, or presentation ID resolves.
+func document(name, idField string) handlerFunc {
+	return func(r *http.Request, m []string) (int, any) {
+		doc := loadFixture(name)
+		doc[idField], _ = url.PathUnescape(m[1])
+		return http.StatusOK, doc
+	}
+}
+
+func gmailProfile(r *http.Request, _ []string) (int, any) {
+	return http.StatusOK, map[string]any{
+		"emailAddress":  DemoUser,
+		"messagesTotal": len(fixtureItems("gmail_messages.json", "messages")),
+		"threadsTotal":  len(gmailThreadIDs()),
+		"historyId":     "1000",
+	}
+}
+
+func gmailThreadIDs() []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, msg := range fixtureItems("gmail_messages.json", "messages") {
+		if id, _ := msg["threadId"].(string); id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func gmailThreads(*http.Request, []string) (int, any) {
+	threads := []map[string]any{}
+	for _, id := range gmailThreadIDs() {
+		threads = append(threads, map[string]any{"id": id})
+	}
+	return http.StatusOK, map[string]any{"threads": threads, "resultSizeEstimate": len(threads)}
+}
+
+func gmailThread(r *http.Request, m []string) (int, any) {
+	var messages []map[string]any
+	for _, msg := range gmailMessageFixtures() {
+		if msg["threadId"] == m[1] {
+			messages = append(messages, msg)
+		}
+	}
+	if len(messages) == 0 {
+		return http.StatusNotFound, notFound(r)
+	}
+	return http.StatusOK, map[string]any{"id": m[1], "messages": messages}
+}
+
+func driveAbout(*http.Request, []string) (int, any) {
+	return http.StatusOK, map[string]any{
+		"user":               map[string]any{"displayName": "Demo User", "emailAddress": DemoUser},
+		"storageQuota":       map[string]any{"limit": "16106127360", "usage": "2147483648", "usageInDrive": "1610612736", "usageInDriveTrash": "104857600"},
+		"maxUploadSize":      "5242880000000",
+		"canCreateDrives":    true,
+		"importFormats":      map[string]any{},
+		"exportFormats":      map[string]any{},
+		"appInstalled":       false,
+		"folderColorPalette": []string{"#4986e7"},
+	}
+}
+
+// driveFile serves file metadata, or the content for alt=media downloads.
+func driveFile(r *http.Request, m []string) (int, any) {
+	status, body := item("drive_files.json", "files", "id")(r, m)
+	if status != http.StatusOK || r.URL.Query().Get("alt") != "media" {
+		return status, body
+	}
+	name, _ := body.(map[string]any)["name"].(string)
+	return http.StatusOK, []byte("Sandbox content of " + name + ".\n")
+}
+
+// driveExport returns a plain-text rendering for any export format.
+func driveExport(r *http.Request, m []string) (int, any) {
+	status, body := item("drive_files.json", "files", "id")(r, m)
+	if status != http.StatusOK {
+		return status, body
+	}
+	name, _ := body.(map[string]any)["name"].(string)
+	return http.StatusOK, []byte(name + "\n\nThis is sandbox export content. Connect a real account to export actual files.\n")
+}
+
+// sheetValues serves the fixture values for any range, echoing the range.
+func sheetValues(r *http.Request, m []string) (int, any) {
+	values := loadFixture("sheets_values.json")
+	values["range"], _ = url.PathUnescape(m[1])
+	return http.StatusOK, values
+}
+
+// peopleSearch filters the connections by a case-insensitive substring of
+// any name or email address.
+func peopleSearch(r *http.Request, _ []string) (int, any) {
+	query := strings.ToLower(r.URL.Query().Get("query"))
+	results := []map[string]any{}
+	for _, p := range fixtureItems("people_connections.json", "connections") {
+		data, _ := json.Marshal([]any{p["names"], p["emailAddresses"]})
+		if strings.Contains(strings.ToLower(string(data)), query) {
+			results = append(results, map[string]any{"person": p})
+		}
+	}
+	return http.StatusOK, map[string]any{"results": results}
+}
+
+// gmailMessageFixtures returns the sandbox messages in API form. Fixture
+// bodies are written as plain "text" for readability and encoded here.
+func gmailMessageFixtures() []map[string]any {
+	messages := fixtureItems("gmail_messages.json", "messages")
+	for _, msg := range messages {
+		if payload, ok := msg["payload"].(map[string]any); ok {
+			encodeParts(payload)
+		}
+	}
+	return messages
+}
+
+func encodeParts(part map[string]any) {
+	if body, ok := part["body"].(map[string]any); ok {
+		if text, ok := body["text"].(string); ok {
+			body["data"] = base64.URLEncoding.EncodeToString([]byte(text))
+			body["size"] = len(text)
+			delete(body, "text")
+		}
+	}
+	parts, _ := part["parts"].([]any)
+	for _, p := range parts {
+		if child, ok := p.(map[string]any); ok {
+			encodeParts(child)
+		}
+	}
+}
+
+func gmailMessages(*http.Request, []string) (int, any) {
+	refs := []map[string]any{}
+	for _, msg := range gmailMessageFixtures() {
+		refs = append(refs, map[string]any{"id": msg["id"], "threadId": msg["threadId"]})
+	}
+	return http.StatusOK, map[string]any{"messages": refs, "resultSizeEstimate": len(refs)}
+}
+
+func gmailMessage(r *http.Request, m []string) (int, any) {
+	for _, msg := range gmailMessageFixtures() {
+		if msg["id"] == m[1] {
+			return http.StatusOK, msg
+		}
+	}
+	return http.StatusNotFound, notFound(r)
+}
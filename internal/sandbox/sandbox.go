@@ -0,0 +1,173 @@
+// Package sandbox serves synthetic Google Workspace data from embedded
+// fixtures in place of the real Google APIs. The service factory routes all
+// API traffic through Transport in sandbox mode, so every tool runs its real
+// code path without credentials or network access.
+//
+// Reads are answered from the fixtures. Writes are accepted and echoed back
+// with a generated ID but never stored, so the data resets with each call.
+package sandbox
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+//go:embed fixtures/*.json
+var fixtureFS embed.FS
+
+// DemoUser is the account the sandbox data belongs to. Any
+// user_google_email is accepted; this one matches the fixtures.
+const DemoUser = "demo@example.com"
+
+// handlerFunc answers one request; match holds the route's capture groups.
+type handlerFunc func(r *http.Request, match []string) (int, any)
+
+type route struct {
+	method  string
+	pattern *regexp.Regexp // matched against host + path
+	handle  handlerFunc
+}
+
+func get(pattern string, h handlerFunc) route {
+	return route{http.MethodGet, regexp.MustCompile("^" + pattern + "$"), h}
+}
+
+// routes lists the read endpoints backed by fixtures. Anything else falls
+// through to the generic write echo or a 404.
+var routes = []route{
+	get(`gmail\.googleapis\.com/gmail/v1/users/[^/]+/profile`, gmailProfile),
+	get(`gmail\.googleapis\.com/gmail/v1/users/[^/]+/messages`, gmailMessages),
+	get(`gmail\.googleapis\.com/gmail/v1/users/[^/]+/messages/([^/]+)`, gmailMessage),
+	get(`gmail\.googleapis\.com/gmail/v1/users/[^/]+/threads`, gmailThreads),
+	get(`gmail\.googleapis\.com/gmail/v1/users/[^/]+/threads/([^/]+)`, gmailThread),
+	get(`gmail\.googleapis\.com/gmail/v1/users/[^/]+/labels`, fixture("gmail_labels.json")),
+	get(`gmail\.googleapis\.com/gmail/v1/users/[^/]+/labels/([^/]+)`, item("gmail_labels.json", "labels", "id")),
+	get(`gmail\.googleapis\.com/gmail/v1/users/[^/]+/settings/filters`, fixture("gmail_filters.json")),
+
+	get(`www\.googleapis\.com/drive/v3/about`, driveAbout),
+	get(`www\.googleapis\.com/drive/v3/files`, fixture("drive_files.json")),
+	get(`www\.googleapis\.com/drive/v3/files/([^/]+)`, driveFile),
+	get(`www\.googleapis\.com/drive/v3/files/([^/]+)/permissions`, fixture("drive_permissions.json")),
+	get(`www\.googleapis\.com/drive/v3/files/([^/]+)/export`, driveExport),
+
+	get(`www\.googleapis\.com/calendar/v3/users/me/calendarList`, fixture("calendar_list.json")),
+	get(`www\.googleapis\.com/calendar/v3/users/me/calendarList/([^/]+)`, item("calendar_list.json", "items", "id")),
+	get(`www\.googleapis\.com/calendar/v3/calendars/[^/]+/events`, fixture("calendar_events.json")),
+	get(`www\.googleapis\.com/calendar/v3/calendars/[^/]+/events/([^/]+)`, item("calendar_events.json", "items", "id")),
+
+	get(`docs\.googleapis\.com/v1/documents/([^/]+)`, document("docs_document.json", "documentId")),
+	get(`sheets\.googleapis\.com/v4/spreadsheets/([^/]+)`, document("sheets_spreadsheet.json", "spreadsheetId")),
+	get(`sheets\.googleapis\.com/v4/spreadsheets/[^/]+/values/([^/]+)`, sheetValues),
+	get(`slides\.googleapis\.com/v1/presentations/([^/]+)`, document("slides_presentation.json", "presentationId")),
+	get(`forms\.googleapis\.com/v1/forms/([^/]+)`, document("forms_form.json", "formId")),
+
+	get(`people\.googleapis\.com/v1/people/me/connections`, fixture("people_connections.json")),
+	get(`people\.googleapis\.com/v1/people:searchContacts`, peopleSearch),
+	get(`people\.googleapis\.com/v1/(people/[^/:]+)`, item("people_connections.json", "connections", "resourceName")),
+	get(`people\.googleapis\.com/v1/contactGroups`, fixture("people_groups.json")),
+
+	get(`tasks\.googleapis\.com/tasks/v1/users/@me/lists`, fixture("tasks_lists.json")),
+	get(`tasks\.googleapis\.com/tasks/v1/users/@me/lists/([^/]+)`, item("tasks_lists.json", "items", "id")),
+	get(`tasks\.googleapis\.com/tasks/v1/lists/[^/]+/tasks`, fixture("tasks_tasks.json")),
+	get(`tasks\.googleapis\.com/tasks/v1/lists/[^/]+/tasks/([^/]+)`, item("tasks_tasks.json", "items", "id")),
+
+	get(`chat\.googleapis\.com/v1/spaces`, fixture("chat_spaces.json")),
+	get(`chat\.googleapis\.com/v1/spaces/[^/]+/messages`, fixture("chat_messages.json")),
+
+	get(`customsearch\.googleapis\.com/customsearch/v1(?:/siterestrict)?`, fixture("search_results.json")),
+}
+
+// Transport is an http.RoundTripper answering Google API requests from the
+// sandbox fixtures.
+type Transport struct {
+	nextID atomic.Int64
+}
+
+// NewTransport returns a sandbox transport.
+func NewTransport() *Transport {
+	return &Transport{}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+	target := r.URL.Host + strings.TrimSuffix(r.URL.Path, "/")
+
+	status, body := http.StatusNotFound, any(notFound(r))
+	if r.Method == http.MethodGet {
+		for _, rt := range routes {
+			if m := rt.pattern.FindStringSubmatch(target); m != nil {
+				status, body = rt.handle(r, m)
+				break
+			}
+		}
+	} else {
+		status, body = t.write(r)
+	}
+	return respond(r, status, body)
+}
+
+// write echoes a mutating request: JSON bodies come back with a generated
+// ID added, other bodies (uploads, raw messages) as just the ID.
+func (t *Transport) write(r *http.Request) (int, any) {
+	if r.Method == http.MethodDelete {
+		return http.StatusNoContent, nil
+	}
+	id := fmt.Sprintf("sandbox-%d", t.nextID.Add(1))
+	echo := map[string]any{}
+	if r.Body != nil && strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		data, _ := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		_ = json.Unmarshal(data, &echo)
+	}
+	if _, ok := echo["id"]; !ok {
+		echo["id"] = id
+	}
+	return http.StatusOK, echo
+}
+
+func respond(r *http.Request, status int, body any) (*http.Response, error) {
+	var data []byte
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		data = b
+	default:
+		var err error
+		if data, err = json.Marshal(b); err != nil {
+			return nil, fmt.Errorf("sandbox: encoding response: %w", err)
+		}
+	}
+	header := http.Header{}
+	if len(data) > 0 && data[0] == '{' {
+		header.Set("Content-Type", "application/json; charset=UTF-8")
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       r,
+	}, nil
+}
+
+// notFound is a Google-style error body for endpoints without fixtures.
+func notFound(r *http.Request) map[string]any {
+	return map[string]any{"error": map[string]any{
+		"code":    http.StatusNotFound,
+		"status":  "NOT_FOUND",
+		"message": fmt.Sprintf("%s %s has no data in sandbox mode", r.Method, r.URL.Path),
+	}}
+}
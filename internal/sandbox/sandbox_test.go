@@ -0,0 +1,112 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/fs"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	"google.golang.org/api/people/v1"
+)
+
+func sandboxClient() option.ClientOption {
+	return option.WithHTTPClient(&http.Client{Transport: NewTransport()})
+}
+
+func TestFixturesDecode(t *testing.T) {
+	entries, err := fs.ReadDir(fixtureFS, "fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		t.Run(e.Name(), func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Error(r)
+				}
+			}()
+			loadFixture(e.Name())
+		})
+	}
+}
+
+func TestGmail(t *testing.T) {
+	ctx := context.Background()
+	srv, err := gmail.NewService(ctx, sandboxClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := srv.Users.Messages.List("anyone@example.com").Q("is:unread").Do()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(list.Messages) == 0 {
+		t.Fatal("no messages")
+	}
+	msg, err := srv.Users.Messages.Get("me", list.Messages[0].Id).Format("full").Do()
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	body, err := base64.URLEncoding.DecodeString(msg.Payload.Body.Data)
+	if err != nil || !strings.Contains(string(body), "agenda") {
+		t.Errorf("body = %q, %v", body, err)
+	}
+	thread, err := srv.Users.Threads.Get("me", msg.ThreadId).Do()
+	if err != nil || len(thread.Messages) != 2 {
+		t.Errorf("thread = %+v, %v; want 2 messages", thread, err)
+	}
+}
+
+func TestDriveCalendarPeople(t *testing.T) {
+	ctx := context.Background()
+	driveSrv, _ := drive.NewService(ctx, sandboxClient())
+	files, err := driveSrv.Files.List().Q("trashed = false").Do()
+	if err != nil || len(files.Files) == 0 {
+		t.Fatalf("drive list = %v, %v", files, err)
+	}
+	file, err := driveSrv.Files.Get("1sbxBudgetSheet01").Do()
+	if err != nil || file.Name != "Q4 budget" {
+		t.Errorf("drive get = %+v, %v", file, err)
+	}
+
+	calSrv, _ := calendar.NewService(ctx, sandboxClient())
+	event, err := calSrv.Events.Get("primary", "sbxevt003").Do()
+	if err != nil || len(event.Attachments) != 1 {
+		t.Errorf("calendar get = %+v, %v", event, err)
+	}
+
+	peopleSrv, _ := people.NewService(ctx, sandboxClient())
+	found, err := peopleSrv.People.SearchContacts().Query("priya").ReadMask("names").Do()
+	if err != nil || len(found.Results) != 1 {
+		t.Errorf("search = %+v, %v; want 1 result", found, err)
+	}
+}
+
+func TestWritesAndMissingData(t *testing.T) {
+	ctx := context.Background()
+	calSrv, _ := calendar.NewService(ctx, sandboxClient())
+	created, err := calSrv.Events.Insert("primary", &calendar.Event{Summary: "Demo event"}).Do()
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if created.Summary != "Demo event" || !strings.HasPrefix(created.Id, "sandbox-") {
+		t.Errorf("insert echo = %+v", created)
+	}
+	if err := calSrv.Events.Delete("primary", created.Id).Do(); err != nil {
+		t.Errorf("delete: %v", err)
+	}
+
+	_, err = calSrv.Events.Get("primary", "does-not-exist").Do()
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) || gerr.Code != http.StatusNotFound {
+		t.Errorf("missing event error = %v, want 404 googleapi.Error", err)
+	}
+}
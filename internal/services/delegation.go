@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/validate"
+)
+
+// ErrDelegationNotConfigured is returned for APIs that only accept a
+// service account with domain-wide delegation when no key is set.
+var ErrDelegationNotConfigured = errors.New("this tool needs a service account with domain-wide delegation — " +
+	"set WORKSPACE_MCP_GMAIL_DELEGATION_KEY to its JSON key file and grant its client ID the " +
+	gmail.GmailSettingsSharingScope + " scope in the Admin console (see docs/configuration.md)")
+
+// SetDelegation configures the service account used for Gmail calls that
+// Google accepts only with domain-wide delegation, such as mailbox
+// delegates. key is the account's JSON key. Call it before serving requests.
+func (f *Factory) SetDelegation(key []byte) error {
+	conf, err := google.JWTConfigFromJSON(key, gmail.GmailSettingsSharingScope)
+	if err != nil {
+		return fmt.Errorf("parsing service account key: %w", err)
+	}
+	f.delegation = conf
+	return nil
+}
+
+// GmailDelegated returns a Gmail service client that impersonates userEmail
+// through the domain-wide delegation service account. It returns
+// ErrDelegationNotConfigured when SetDelegation was not called.
+func (f *Factory) GmailDelegated(ctx context.Context, userEmail string) (*gmail.Service, error) {
+	if err := validate.Email(userEmail); err != nil {
+		return nil, fmt.Errorf("invalid user email: %w", err)
+	}
+	var client *http.Client
+	switch {
+	case f.sandbox != nil:
+		client = &http.Client{Transport: f.sandbox}
+	case f.delegation == nil:
+		return nil, ErrDelegationNotConfigured
+	default:
+		client = f.delegatedClient(userEmail)
+	}
+	return gmail.NewService(ctx, option.WithHTTPClient(f.wrap(client, "gmail")))
+}
+
+// delegatedClient returns the cached service account client impersonating
+// userEmail. Like clientFor, it uses context.Background() so the client
+// outlives the request.
+func (f *Factory) delegatedClient(userEmail string) *http.Client {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if client, ok := f.delegated[userEmail]; ok {
+		return client
+	}
+	conf := *f.delegation
+	conf.Subject = userEmail
+	bgCtx := context.Background()
+	source := oauth2.ReuseTokenSource(nil, delegationTokenSource{base: conf.TokenSource(bgCtx), user: userEmail})
+	client := oauth2.NewClient(bgCtx, source)
+	f.delegated[userEmail] = client
+	return client
+}
+
+// delegationTokenSource rewrites token errors so they point at the
+// delegation grant instead of the user's OAuth consent, which does not
+// apply to service accounts.
+type delegationTokenSource struct {
+	base oauth2.TokenSource
+	user string
+}
+
+func (s delegationTokenSource) Token() (*oauth2.Token, error) {
+	tok, err := s.base.Token()
+	if err != nil {
+		return nil, fmt.Errorf("service account could not act as %s — check that its client ID is granted %s under domain-wide delegation in the Admin console: %v",
+			s.user, gmail.GmailSettingsSharingScope, err)
+	}
+	return tok, nil
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+)
+
+func TestDelegation(t *testing.T) {
+	f := NewFactory(auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore()))
+
+	if _, err := f.GmailDelegated(context.Background(), "boss@example.com"); !errors.Is(err, ErrDelegationNotConfigured) {
+		t.Fatalf("GmailDelegated without a key = %v, want ErrDelegationNotConfigured", err)
+	}
+	if err := f.SetDelegation([]byte(`{"type":"authorized_user"}`)); err == nil {
+		t.Error("SetDelegation accepted a key that is not a service account")
+	}
+
+	key := `{"type":"service_account","client_email":"mcp@project.iam.gserviceaccount.com","private_key":"unused","token_uri":"https://oauth2.googleapis.com/token"}`
+	if err := f.SetDelegation([]byte(key)); err != nil {
+		t.Fatalf("SetDelegation = %v", err)
+	}
+	if _, err := f.GmailDelegated(context.Background(), "not-an-email"); err == nil {
+		t.Error("GmailDelegated accepted an invalid user email")
+	}
+	a, b := f.delegatedClient("boss@example.com"), f.delegatedClient("boss@example.com")
+	if a != b || a == f.delegatedClient("other@example.com") {
+		t.Error("delegated clients are not cached per impersonated user")
+	}
+	if f.delegation.Subject != "" {
+		t.Errorf("shared config subject = %q, want each client to impersonate on its own copy", f.delegation.Subject)
+	}
+}
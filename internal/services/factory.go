@@ -3,17 +3,30 @@ package services
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"sync"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+	directory "google.golang.org/api/admin/directory/v1"
+	reports "google.golang.org/api/admin/reports/v1"
 	"google.golang.org/api/calendar/v3"
 	"google.golang.org/api/chat/v1"
+	"google.golang.org/api/classroom/v1"
+	"google.golang.org/api/cloudsearch/v1"
 	customsearch "google.golang.org/api/customsearch/v1"
 	"google.golang.org/api/docs/v1"
 	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/driveactivity/v2"
+	"google.golang.org/api/drivelabels/v2"
 	"google.golang.org/api/forms/v1"
 	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/keep/v1"
+	meet "google.golang.org/api/meet/v2"
 	"google.golang.org/api/option"
 	"google.golang.org/api/people/v1"
 	"google.golang.org/api/script/v1"
@@ -22,6 +35,8 @@ import (
 	"google.golang.org/api/tasks/v1"
 
 	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/provenance"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/tracing"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/validate"
 )
 
@@ -32,6 +47,15 @@ type Factory struct {
 	tokenStore  auth.TokenStore
 	mu          sync.RWMutex
 	clients     map[string]*http.Client
+
+	retryDefault RetryPolicy
+	retryService map[string]RetryPolicy
+
+	stampProvenance bool
+	sandbox         http.RoundTripper
+
+	delegation *jwt.Config
+	delegated  map[string]*http.Client
 }
 
 // NewFactory creates a service factory backed by the given OAuth manager.
@@ -40,16 +64,106 @@ func NewFactory(oauthMgr *auth.OAuthManager) *Factory {
 		oauthConfig: oauthMgr.Config(),
 		tokenStore:  oauthMgr.TokenStore(),
 		clients:     make(map[string]*http.Client),
+		delegated:   make(map[string]*http.Client),
+
+		retryDefault: DefaultRetryPolicy,
+	}
+}
+
+// SetRetryPolicies sets the retry policy for transient Google API errors:
+// def applies to every service not listed in perService (keyed by service
+// name as in ENABLED_SERVICES). Call it before serving requests.
+func (f *Factory) SetRetryPolicies(def RetryPolicy, perService map[string]RetryPolicy) {
+	f.retryDefault = def
+	f.retryService = perService
+}
+
+// SetSandbox routes every API call through rt instead of Google and skips
+// credential lookup, so tools work for any user without OAuth. Call it
+// before serving requests.
+func (f *Factory) SetSandbox(rt http.RoundTripper) {
+	f.sandbox = rt
+}
+
+// SetProvenance enables stamping of files, events, and drafts created by
+// tools with provenance metadata. Call it before serving requests.
+func (f *Factory) SetProvenance(enabled bool) {
+	f.stampProvenance = enabled
+}
+
+// Provenance returns the stamp for objects created while handling req, or
+// nil when provenance stamping is disabled.
+func (f *Factory) Provenance(req *mcp.CallToolRequest) *provenance.Stamp {
+	if !f.stampProvenance {
+		return nil
 	}
+	stamp := provenance.For(req)
+	return &stamp
 }
 
-// clientFor returns a cached, auto-refreshing HTTP client for the user.
+// StampDriveFile stamps an already created Drive-backed object (a Doc,
+// Sheet, Slides deck, or Form) whose own API cannot set appProperties. A nil
+// stamp is a no-op. Failures are logged rather than returned because the
+// object was created and the tool call succeeded.
+func (f *Factory) StampDriveFile(ctx context.Context, userEmail, fileID string, stamp *provenance.Stamp) {
+	if stamp == nil {
+		return
+	}
+	srv, err := f.Drive(ctx, userEmail)
+	if err == nil {
+		err = stamp.StampDriveFile(ctx, srv, fileID)
+	}
+	if err != nil {
+		slog.Warn("provenance stamping failed", "file_id", fileID, "error", err)
+	}
+}
+
+// serviceClient returns the user's cached client wrapped with the service's
+// retry policy and API request tracing. The wrappers are cheap, so they are
+// built per service call.
+func (f *Factory) serviceClient(ctx context.Context, userEmail, service string) (*http.Client, error) {
+	_, span := tracing.Tracer().Start(ctx, "factory."+service, trace.WithAttributes(
+		tracing.AttrGoogleService.String(service),
+		tracing.AttrUser.String(userEmail),
+	))
+	defer span.End()
+
+	client, cached, err := f.clientFor(ctx, userEmail)
+	span.SetAttributes(tracing.AttrClientCached.Bool(cached))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "client unavailable")
+		return nil, err
+	}
+
+	return f.wrap(client, service), nil
+}
+
+// wrap adds API request tracing and the service's retry policy to client.
+func (f *Factory) wrap(client *http.Client, service string) *http.Client {
+	// Tracing sits below retries so every attempt gets its own span.
+	transport := tracing.Transport(client.Transport, service)
+	policy, ok := f.retryService[service]
+	if !ok {
+		policy = f.retryDefault
+	}
+	if policy.MaxRetries > 0 {
+		transport = &retryTransport{base: transport, policy: policy, sleep: sleepContext}
+	}
+	return &http.Client{Transport: transport, Timeout: client.Timeout}
+}
+
+// clientFor returns a cached, auto-refreshing HTTP client for the user and
+// whether it came from the cache.
 // IMPORTANT: Uses context.Background() for the cached HTTP client/token source
 // so they outlive any single request context. Individual API calls pass their
 // own request context via .Context(ctx) on each Google API call.
-func (f *Factory) clientFor(ctx context.Context, userEmail string) (*http.Client, error) {
+func (f *Factory) clientFor(ctx context.Context, userEmail string) (*http.Client, bool, error) {
 	if err := validate.Email(userEmail); err != nil {
-		return nil, fmt.Errorf("invalid user email: %w", err)
+		return nil, false, fmt.Errorf("invalid user email: %w", err)
+	}
+	if f.sandbox != nil {
+		return &http.Client{Transport: f.sandbox}, true, nil
 	}
 
 	// Fast path: check cache
@@ -57,7 +171,7 @@ func (f *Factory) clientFor(ctx context.Context, userEmail string) (*http.Client
 	client, ok := f.clients[userEmail]
 	f.mu.RUnlock()
 	if ok {
-		return client, nil
+		return client, true, nil
 	}
 
 	// Slow path: create new client
@@ -66,12 +180,12 @@ func (f *Factory) clientFor(ctx context.Context, userEmail string) (*http.Client
 
 	// Double-check after acquiring write lock
 	if client, ok := f.clients[userEmail]; ok {
-		return client, nil
+		return client, true, nil
 	}
 
 	token, err := f.tokenStore.Load(userEmail)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	// Use context.Background() for the token source and HTTP client so they
@@ -88,7 +202,7 @@ func (f *Factory) clientFor(ctx context.Context, userEmail string) (*http.Client
 
 	client = oauth2.NewClient(bgCtx, reuseSource)
 	f.clients[userEmail] = client
-	return client, nil
+	return client, false, nil
 }
 
 // InvalidateClient removes the cached HTTP client for a user, forcing the
@@ -100,9 +214,20 @@ func (f *Factory) InvalidateClient(userEmail string) {
 	delete(f.clients, userEmail)
 }
 
+// HTTPClient returns the user's authenticated HTTP client for fetching
+// Google-hosted content outside a service API, such as a Slides thumbnail
+// URL. service selects the retry policy and tracing attributes.
+func (f *Factory) HTTPClient(ctx context.Context, userEmail, service string) (*http.Client, error) {
+	client, err := f.serviceClient(ctx, userEmail, service)
+	if err != nil {
+		return nil, fmt.Errorf("%s client for %s: %w", service, userEmail, err)
+	}
+	return client, nil
+}
+
 // Gmail returns a Gmail service client for the given user.
 func (f *Factory) Gmail(ctx context.Context, userEmail string) (*gmail.Service, error) {
-	client, err := f.clientFor(ctx, userEmail)
+	client, err := f.serviceClient(ctx, userEmail, "gmail")
 	if err != nil {
 		return nil, fmt.Errorf("gmail client for %s: %w", userEmail, err)
 	}
@@ -111,16 +236,34 @@ func (f *Factory) Gmail(ctx context.Context, userEmail string) (*gmail.Service,
 
 // Drive returns a Drive service client for the given user.
 func (f *Factory) Drive(ctx context.Context, userEmail string) (*drive.Service, error) {
-	client, err := f.clientFor(ctx, userEmail)
+	client, err := f.serviceClient(ctx, userEmail, "drive")
 	if err != nil {
 		return nil, fmt.Errorf("drive client for %s: %w", userEmail, err)
 	}
 	return drive.NewService(ctx, option.WithHTTPClient(client))
 }
 
+// DriveActivity returns a Drive Activity service client for the given user.
+func (f *Factory) DriveActivity(ctx context.Context, userEmail string) (*driveactivity.Service, error) {
+	client, err := f.serviceClient(ctx, userEmail, "drive")
+	if err != nil {
+		return nil, fmt.Errorf("drive activity client for %s: %w", userEmail, err)
+	}
+	return driveactivity.NewService(ctx, option.WithHTTPClient(client))
+}
+
+// DriveLabels returns a Drive Labels service client for the given user.
+func (f *Factory) DriveLabels(ctx context.Context, userEmail string) (*drivelabels.Service, error) {
+	client, err := f.serviceClient(ctx, userEmail, "drive")
+	if err != nil {
+		return nil, fmt.Errorf("drive labels client for %s: %w", userEmail, err)
+	}
+	return drivelabels.NewService(ctx, option.WithHTTPClient(client))
+}
+
 // Calendar returns a Calendar service client for the given user.
 func (f *Factory) Calendar(ctx context.Context, userEmail string) (*calendar.Service, error) {
-	client, err := f.clientFor(ctx, userEmail)
+	client, err := f.serviceClient(ctx, userEmail, "calendar")
 	if err != nil {
 		return nil, fmt.Errorf("calendar client for %s: %w", userEmail, err)
 	}
@@ -129,7 +272,7 @@ func (f *Factory) Calendar(ctx context.Context, userEmail string) (*calendar.Ser
 
 // Docs returns a Docs service client for the given user.
 func (f *Factory) Docs(ctx context.Context, userEmail string) (*docs.Service, error) {
-	client, err := f.clientFor(ctx, userEmail)
+	client, err := f.serviceClient(ctx, userEmail, "docs")
 	if err != nil {
 		return nil, fmt.Errorf("docs client for %s: %w", userEmail, err)
 	}
@@ -138,7 +281,7 @@ func (f *Factory) Docs(ctx context.Context, userEmail string) (*docs.Service, er
 
 // Sheets returns a Sheets service client for the given user.
 func (f *Factory) Sheets(ctx context.Context, userEmail string) (*sheets.Service, error) {
-	client, err := f.clientFor(ctx, userEmail)
+	client, err := f.serviceClient(ctx, userEmail, "sheets")
 	if err != nil {
 		return nil, fmt.Errorf("sheets client for %s: %w", userEmail, err)
 	}
@@ -147,7 +290,7 @@ func (f *Factory) Sheets(ctx context.Context, userEmail string) (*sheets.Service
 
 // Slides returns a Slides service client for the given user.
 func (f *Factory) Slides(ctx context.Context, userEmail string) (*slides.Service, error) {
-	client, err := f.clientFor(ctx, userEmail)
+	client, err := f.serviceClient(ctx, userEmail, "slides")
 	if err != nil {
 		return nil, fmt.Errorf("slides client for %s: %w", userEmail, err)
 	}
@@ -156,7 +299,7 @@ func (f *Factory) Slides(ctx context.Context, userEmail string) (*slides.Service
 
 // Chat returns a Chat service client for the given user.
 func (f *Factory) Chat(ctx context.Context, userEmail string) (*chat.Service, error) {
-	client, err := f.clientFor(ctx, userEmail)
+	client, err := f.serviceClient(ctx, userEmail, "chat")
 	if err != nil {
 		return nil, fmt.Errorf("chat client for %s: %w", userEmail, err)
 	}
@@ -165,7 +308,7 @@ func (f *Factory) Chat(ctx context.Context, userEmail string) (*chat.Service, er
 
 // Forms returns a Forms service client for the given user.
 func (f *Factory) Forms(ctx context.Context, userEmail string) (*forms.Service, error) {
-	client, err := f.clientFor(ctx, userEmail)
+	client, err := f.serviceClient(ctx, userEmail, "forms")
 	if err != nil {
 		return nil, fmt.Errorf("forms client for %s: %w", userEmail, err)
 	}
@@ -174,16 +317,43 @@ func (f *Factory) Forms(ctx context.Context, userEmail string) (*forms.Service,
 
 // Tasks returns a Tasks service client for the given user.
 func (f *Factory) Tasks(ctx context.Context, userEmail string) (*tasks.Service, error) {
-	client, err := f.clientFor(ctx, userEmail)
+	client, err := f.serviceClient(ctx, userEmail, "tasks")
 	if err != nil {
 		return nil, fmt.Errorf("tasks client for %s: %w", userEmail, err)
 	}
 	return tasks.NewService(ctx, option.WithHTTPClient(client))
 }
 
+// Keep returns a Google Keep service client for the given user.
+func (f *Factory) Keep(ctx context.Context, userEmail string) (*keep.Service, error) {
+	client, err := f.serviceClient(ctx, userEmail, "keep")
+	if err != nil {
+		return nil, fmt.Errorf("keep client for %s: %w", userEmail, err)
+	}
+	return keep.NewService(ctx, option.WithHTTPClient(client))
+}
+
+// Classroom returns a Google Classroom service client for the given user.
+func (f *Factory) Classroom(ctx context.Context, userEmail string) (*classroom.Service, error) {
+	client, err := f.serviceClient(ctx, userEmail, "classroom")
+	if err != nil {
+		return nil, fmt.Errorf("classroom client for %s: %w", userEmail, err)
+	}
+	return classroom.NewService(ctx, option.WithHTTPClient(client))
+}
+
+// Meet returns a Google Meet service client for the given user.
+func (f *Factory) Meet(ctx context.Context, userEmail string) (*meet.Service, error) {
+	client, err := f.serviceClient(ctx, userEmail, "meet")
+	if err != nil {
+		return nil, fmt.Errorf("meet client for %s: %w", userEmail, err)
+	}
+	return meet.NewService(ctx, option.WithHTTPClient(client))
+}
+
 // People returns a People service client for the given user (Contacts).
 func (f *Factory) People(ctx context.Context, userEmail string) (*people.Service, error) {
-	client, err := f.clientFor(ctx, userEmail)
+	client, err := f.serviceClient(ctx, userEmail, "contacts")
 	if err != nil {
 		return nil, fmt.Errorf("people client for %s: %w", userEmail, err)
 	}
@@ -192,18 +362,45 @@ func (f *Factory) People(ctx context.Context, userEmail string) (*people.Service
 
 // CustomSearch returns a Custom Search service client for the given user.
 func (f *Factory) CustomSearch(ctx context.Context, userEmail string) (*customsearch.Service, error) {
-	client, err := f.clientFor(ctx, userEmail)
+	client, err := f.serviceClient(ctx, userEmail, "search")
 	if err != nil {
 		return nil, fmt.Errorf("customsearch client for %s: %w", userEmail, err)
 	}
 	return customsearch.NewService(ctx, option.WithHTTPClient(client))
 }
 
+// CloudSearch returns a Cloud Search service client for the given user.
+func (f *Factory) CloudSearch(ctx context.Context, userEmail string) (*cloudsearch.Service, error) {
+	client, err := f.serviceClient(ctx, userEmail, "search")
+	if err != nil {
+		return nil, fmt.Errorf("cloudsearch client for %s: %w", userEmail, err)
+	}
+	return cloudsearch.NewService(ctx, option.WithHTTPClient(client))
+}
+
 // Script returns an Apps Script service client for the given user.
 func (f *Factory) Script(ctx context.Context, userEmail string) (*script.Service, error) {
-	client, err := f.clientFor(ctx, userEmail)
+	client, err := f.serviceClient(ctx, userEmail, "appscript")
 	if err != nil {
 		return nil, fmt.Errorf("script client for %s: %w", userEmail, err)
 	}
 	return script.NewService(ctx, option.WithHTTPClient(client))
 }
+
+// Directory returns an Admin SDK Directory service client for the given user.
+func (f *Factory) Directory(ctx context.Context, userEmail string) (*directory.Service, error) {
+	client, err := f.serviceClient(ctx, userEmail, "admin")
+	if err != nil {
+		return nil, fmt.Errorf("directory client for %s: %w", userEmail, err)
+	}
+	return directory.NewService(ctx, option.WithHTTPClient(client))
+}
+
+// Reports returns an Admin SDK Reports service client for the given user.
+func (f *Factory) Reports(ctx context.Context, userEmail string) (*reports.Service, error) {
+	client, err := f.serviceClient(ctx, userEmail, "admin")
+	if err != nil {
+		return nil, fmt.Errorf("reports client for %s: %w", userEmail, err)
+	}
+	return reports.NewService(ctx, option.WithHTTPClient(client))
+}
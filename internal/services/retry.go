@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls automatic retries of Google API requests that fail
+// with a transient status (429, 500, 503).
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero
+	// disables retries.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry; it doubles with each
+	// further retry and is randomized with full jitter.
+	BaseDelay time.Duration
+	// MaxWait caps a single backoff. A Retry-After longer than MaxWait is not
+	// waited out — the error is returned to the caller instead.
+	MaxWait time.Duration
+}
+
+// errNotReplayable marks a request whose body cannot be sent again.
+var errNotReplayable = errors.New("request body cannot be replayed")
+
+// DefaultRetryPolicy is used for services without an explicit policy.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxWait: 30 * time.Second}
+
+// retryTransport retries transient Google API failures with exponential
+// backoff, honoring Retry-After.
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || attempt >= t.policy.MaxRetries || !retryable(req, resp.StatusCode) {
+			return resp, err
+		}
+		delay, ok := t.delay(resp, attempt)
+		if !ok {
+			return resp, nil
+		}
+		retryReq, err := rewind(req)
+		if err != nil {
+			return resp, nil
+		}
+
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		resp.Body.Close()
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		req = retryReq
+	}
+}
+
+// retryable reports whether a response status is worth retrying. 429 and 503
+// mean the request was not processed; 500 may have been partially applied,
+// so it is only retried for idempotent methods.
+func retryable(req *http.Request, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError:
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt, and false when the
+// server asks for a longer wait than the policy allows.
+func (t *retryTransport) delay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if d, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		return d, d <= t.policy.MaxWait
+	}
+	backoff := t.policy.BaseDelay << attempt
+	if backoff <= 0 || backoff > t.policy.MaxWait {
+		backoff = t.policy.MaxWait
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+	return rand.N(backoff) + 1, true
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// rewind returns a copy of req with a fresh body for another attempt. Bodies
+// that cannot be replayed (streamed uploads) are not retried.
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	if req.GetBody == nil {
+		return nil, errNotReplayable
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
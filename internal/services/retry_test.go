@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		statuses   []int
+		retryAfter string
+		wantStatus int
+		wantCalls  int
+	}{
+		{"success", http.MethodGet, []int{200}, "", 200, 1},
+		{"rate limited then ok", http.MethodPost, []int{429, 429, 200}, "", 200, 3},
+		{"unavailable then ok", http.MethodPost, []int{503, 200}, "", 200, 2},
+		{"500 on GET retried", http.MethodGet, []int{500, 200}, "", 200, 2},
+		{"500 on POST not retried", http.MethodPost, []int{500, 200}, "", 500, 1},
+		{"not found not retried", http.MethodGet, []int{404, 200}, "", 404, 1},
+		{"gives up after max retries", http.MethodGet, []int{503, 503, 503, 503, 503}, "", 503, 4},
+		{"retry-after too long", http.MethodGet, []int{429, 200}, "120", 429, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if body, _ := io.ReadAll(r.Body); r.Method == http.MethodPost && string(body) != "payload" {
+					t.Errorf("attempt %d got body %q", calls+1, body)
+				}
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statuses[calls])
+				calls++
+			}))
+			defer srv.Close()
+
+			var slept []time.Duration
+			client := &http.Client{Transport: &retryTransport{
+				base:   http.DefaultTransport,
+				policy: RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxWait: time.Minute},
+				sleep: func(_ context.Context, d time.Duration) error {
+					slept = append(slept, d)
+					return nil
+				},
+			}}
+
+			req, _ := http.NewRequest(tt.method, srv.URL, strings.NewReader("payload"))
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus || calls != tt.wantCalls {
+				t.Errorf("got status %d after %d calls, want %d after %d", resp.StatusCode, calls, tt.wantStatus, tt.wantCalls)
+			}
+			if len(slept) != tt.wantCalls-1 {
+				t.Errorf("slept %d times, want %d", len(slept), tt.wantCalls-1)
+			}
+		})
+	}
+}
+
+func TestRetryTransportDelay(t *testing.T) {
+	rt := &retryTransport{policy: RetryPolicy{MaxRetries: 5, BaseDelay: time.Second, MaxWait: 4 * time.Second}}
+
+	for attempt := range 5 {
+		d, ok := rt.delay(&http.Response{Header: http.Header{}}, attempt)
+		limit := min(time.Second<<attempt, 4*time.Second)
+		if !ok || d <= 0 || d > limit {
+			t.Errorf("attempt %d: delay %v outside (0, %v]", attempt, d, limit)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if d, ok := rt.delay(resp, 0); !ok || d != 3*time.Second {
+		t.Errorf("Retry-After: got %v, %v", d, ok)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"7", 7 * time.Second, true},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := retryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRetryTransportContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := &http.Client{Transport: &retryTransport{
+		base:   http.DefaultTransport,
+		policy: RetryPolicy{MaxRetries: 3, BaseDelay: time.Hour, MaxWait: time.Hour},
+		sleep:  sleepContext,
+	}}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected error for canceled context")
+	}
+}
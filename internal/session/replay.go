@@ -0,0 +1,93 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// jsonrpcMessage is the part of a JSON-RPC message the resumer reads and
+// replays.
+type jsonrpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      any             `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcMessage encodes a replayed message: the initialize request, which needs
+// an ID, or a notification.
+func rpcMessage(method string, params json.RawMessage) []byte {
+	msg := jsonrpcMessage{JSONRPC: "2.0", Method: method, Params: params}
+	if method == "initialize" {
+		msg.ID = "session-restore"
+	}
+	data, _ := json.Marshal(msg)
+	return data
+}
+
+// initializeParams returns the params of body when it is an initialize
+// request.
+func initializeParams(body []byte) (json.RawMessage, bool) {
+	var msg jsonrpcMessage
+	if json.Unmarshal(body, &msg) != nil || msg.Method != "initialize" || len(msg.Params) == 0 {
+		return nil, false
+	}
+	return msg.Params, true
+}
+
+// protocolVersion returns the protocol version the client asked for.
+func protocolVersion(params json.RawMessage) string {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	json.Unmarshal(params, &p)
+	return p.ProtocolVersion
+}
+
+// replay serves body to next as a POST from the session's client, and
+// returns the response status and the session ID the response names.
+func replay(ctx context.Context, next http.Handler, id, version string, body []byte) (int, string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", bytes.NewReader(body))
+	if err != nil {
+		return http.StatusInternalServerError, ""
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if id != "" {
+		req.Header.Set(sessionIDHeader, id)
+	}
+	if version != "" {
+		req.Header.Set(protocolVersionHeader, version)
+	}
+	w := &discardWriter{header: make(http.Header)}
+	next.ServeHTTP(w, req)
+	return w.statusCode(), w.header.Get(sessionIDHeader)
+}
+
+// discardWriter is a ResponseWriter that keeps only the status and headers.
+type discardWriter struct {
+	header http.Header
+	status int
+}
+
+func (w *discardWriter) Header() http.Header { return w.header }
+
+func (w *discardWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *discardWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return len(p), nil
+}
+
+func (w *discardWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
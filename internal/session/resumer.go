@@ -0,0 +1,211 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+// Header names of the streamable HTTP transport.
+const (
+	sessionIDHeader       = "Mcp-Session-Id"
+	protocolVersionHeader = "Mcp-Protocol-Version"
+)
+
+// Resumer persists the sessions of a streamable HTTP handler and restores
+// them after a restart. Install SessionID as the server's
+// ServerOptions.GetSessionID and wrap the handler with Wrap.
+//
+// Only the initialize handshake is restored. Requests in flight when the
+// server stopped are lost, and the session's log level resets.
+type Resumer struct {
+	store *store
+
+	// initMu serializes the handoff of session IDs: it is held from just
+	// before a request reaches the handler until the handler takes the
+	// pending ID in SessionID, so each ID goes to the request it was meant
+	// for. The rest of the request runs unlocked.
+	initMu sync.Mutex
+
+	// restoreMu serializes restores, so a session is replayed only once.
+	restoreMu sync.Mutex
+
+	mu      sync.Mutex
+	pending string          // ID for the session being created
+	live    map[string]bool // sessions the handler holds in this process
+}
+
+// NewResumer loads the session store at path. Sessions unused for longer
+// than ttl are not restored.
+func NewResumer(path string, ttl time.Duration) (*Resumer, error) {
+	s, err := loadStore(path, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return &Resumer{store: s, live: make(map[string]bool)}, nil
+}
+
+// SessionID returns the ID for the session being created, as handed off by
+// Wrap, and releases initMu for the next session. Outside Wrap it returns a
+// new random ID.
+func (r *Resumer) SessionID() string {
+	r.mu.Lock()
+	id := r.pending
+	r.pending = ""
+	r.mu.Unlock()
+	if id == "" {
+		return rand.Text()
+	}
+	r.initMu.Unlock()
+	return id
+}
+
+// handOff serves req with id as the ID of any session next creates. Unless
+// next takes the ID in SessionID, initMu is released when next returns.
+func (r *Resumer) handOff(w http.ResponseWriter, req *http.Request, next http.Handler, id string) {
+	r.initMu.Lock()
+	r.mu.Lock()
+	r.pending = id
+	r.mu.Unlock()
+
+	next.ServeHTTP(w, req)
+
+	r.mu.Lock()
+	unused := r.pending == id
+	if unused {
+		r.pending = ""
+	}
+	r.mu.Unlock()
+	if unused {
+		r.initMu.Unlock()
+	}
+}
+
+// Wrap returns next with session persistence: new sessions are recorded,
+// deleted ones forgotten, and a request for a stored session that next does
+// not hold re-creates it first.
+func (r *Resumer) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get(sessionIDHeader)
+		switch {
+		case id == "":
+			r.create(w, req, next)
+		case req.Method == http.MethodDelete:
+			next.ServeHTTP(w, req)
+			r.forget(id)
+		default:
+			if err := r.restore(req.Context(), id, next); err != nil {
+				// next answers 404 and the client starts a new session.
+				slog.Warn("could not restore MCP session", "session", id, "error", err)
+			}
+			r.store.touch(id)
+			next.ServeHTTP(w, req)
+		}
+	})
+}
+
+// create serves a request that starts a session and records the session's
+// initialize parameters under the ID the handler assigned.
+func (r *Resumer) create(w http.ResponseWriter, req *http.Request, next http.Handler) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.handOff(w, req, next, rand.Text())
+
+	id := w.Header().Get(sessionIDHeader)
+	params, ok := initializeParams(body)
+	if id == "" || !ok {
+		return
+	}
+	r.setLive(id, true)
+	if err := r.store.put(id, params, subject(req.Context())); err != nil {
+		slog.Warn("could not persist MCP session", "session", id, "error", err)
+	}
+}
+
+// restore re-creates a stored session that next does not hold by replaying
+// its initialize handshake under the stored ID, as the bearer subject that
+// created it. Unknown sessions, and sessions presented by another subject,
+// are left for next to reject.
+func (r *Resumer) restore(ctx context.Context, id string, next http.Handler) error {
+	if r.isLive(id) {
+		return nil
+	}
+	rec, ok := r.store.get(id)
+	if !ok {
+		return nil
+	}
+	if got := subject(ctx); got != rec.Subject {
+		return fmt.Errorf("session belongs to another caller")
+	}
+	params := rec.Params
+
+	r.restoreMu.Lock()
+	defer r.restoreMu.Unlock()
+	if r.isLive(id) { // restored by a concurrent request
+		return nil
+	}
+	status, got := replay(ctx, r.withID(next, id), "", "", rpcMessage("initialize", params))
+	if status != http.StatusOK || got != id {
+		return fmt.Errorf("replaying initialize: status %d", status)
+	}
+	version := protocolVersion(params)
+	if status, _ := replay(ctx, next, id, version, rpcMessage("notifications/initialized", nil)); status != http.StatusAccepted {
+		return fmt.Errorf("replaying initialized: status %d", status)
+	}
+	r.setLive(id, true)
+	slog.Info("restored MCP session", "session", id)
+	return nil
+}
+
+// subject returns the bearer subject authenticated for a request, or ""
+// when bearer authentication is off.
+func subject(ctx context.Context) string {
+	if info := auth.TokenInfoFromContext(ctx); info != nil {
+		return info.UserID
+	}
+	return ""
+}
+
+// withID returns next with id handed off to the session it creates.
+func (r *Resumer) withID(next http.Handler, id string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.handOff(w, req, next, id)
+	})
+}
+
+// forget drops a session the client deleted.
+func (r *Resumer) forget(id string) {
+	r.setLive(id, false)
+	if err := r.store.remove(id); err != nil {
+		slog.Warn("could not update session store", "session", id, "error", err)
+	}
+}
+
+func (r *Resumer) isLive(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.live[id]
+}
+
+func (r *Resumer) setLive(id string, live bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if live {
+		r.live[id] = true
+	} else {
+		delete(r.live, id)
+	}
+}
@@ -0,0 +1,173 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// newHandler starts a fresh server and resumer on the store at path, as a
+// restarted process would.
+func newHandler(t *testing.T, path string) http.Handler {
+	t.Helper()
+	r, err := NewResumer(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, &mcp.ServerOptions{GetSessionID: r.SessionID})
+	return r.Wrap(mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil))
+}
+
+// withBearer authenticates requests whose bearer token is the subject's name,
+// as the server's bearer middleware would.
+func withBearer(h http.Handler) http.Handler {
+	return auth.RequireBearerToken(func(_ context.Context, token string, _ *http.Request) (*auth.TokenInfo, error) {
+		return &auth.TokenInfo{UserID: token, Expiration: time.Now().Add(time.Hour)}, nil
+	}, nil)(h)
+}
+
+func post(h http.Handler, method, id, body string) *httptest.ResponseRecorder {
+	return postAs(h, "", method, id, body)
+}
+
+// postAs is post with a bearer token for subject, if set.
+func postAs(h http.Handler, subject, method, id, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/mcp", strings.NewReader(body))
+	if subject != "" {
+		req.Header.Set("Authorization", "Bearer "+subject)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if id != "" {
+		req.Header.Set(sessionIDHeader, id)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+const (
+	initializeBody  = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"c","version":"1"}}}`
+	initializedBody = `{"jsonrpc":"2.0","method":"notifications/initialized"}`
+	pingBody        = `{"jsonrpc":"2.0","id":2,"method":"ping"}`
+)
+
+func TestResumerRestoresSessionAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+
+	first := newHandler(t, path)
+	id := post(first, http.MethodPost, "", initializeBody).Header().Get(sessionIDHeader)
+	if id == "" {
+		t.Fatal("initialize returned no session ID")
+	}
+	post(first, http.MethodPost, id, initializedBody)
+
+	restarted := newHandler(t, path)
+	rec := post(restarted, http.MethodPost, id, pingBody)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"result"`) {
+		t.Fatalf("ping on restored session = %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := post(restarted, http.MethodPost, "unknown", pingBody); rec.Code != http.StatusNotFound {
+		t.Errorf("ping on unknown session = %d, want 404", rec.Code)
+	}
+
+	post(restarted, http.MethodDelete, id, "")
+	if rec := post(newHandler(t, path), http.MethodPost, id, pingBody); rec.Code != http.StatusNotFound {
+		t.Errorf("ping on deleted session after restart = %d, want 404", rec.Code)
+	}
+}
+
+func TestResumerRestoresOnlyForCreatingSubject(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+
+	first := withBearer(newHandler(t, path))
+	id := postAs(first, "alice", http.MethodPost, "", initializeBody).Header().Get(sessionIDHeader)
+	if id == "" {
+		t.Fatal("initialize returned no session ID")
+	}
+	postAs(first, "alice", http.MethodPost, id, initializedBody)
+
+	restarted := withBearer(newHandler(t, path))
+	if rec := postAs(restarted, "mallory", http.MethodPost, id, pingBody); rec.Code != http.StatusNotFound {
+		t.Errorf("ping by another subject = %d, want 404", rec.Code)
+	}
+	if rec := postAs(restarted, "alice", http.MethodPost, id, pingBody); rec.Code != http.StatusOK {
+		t.Errorf("ping by the creating subject = %d %q, want 200", rec.Code, rec.Body.String())
+	}
+}
+
+func TestResumerCreateDoesNotHoldLockWhileServing(t *testing.T) {
+	r, err := NewResumer(filepath.Join(t.TempDir(), "sessions.json"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first request takes its ID and then blocks, as a slow initialize
+	// or a long-lived stream would.
+	release := make(chan struct{})
+	ids := make(chan string, 2)
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := r.SessionID()
+		ids <- id
+		w.Header().Set(sessionIDHeader, id)
+		if req.Header.Get("X-Block") != "" {
+			<-release
+		}
+	})
+	h := r.Wrap(next)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(initializeBody))
+		req.Header.Set("X-Block", "1")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}()
+	first := <-ids
+
+	second := make(chan string)
+	go func() {
+		post(h, http.MethodPost, "", initializeBody)
+		second <- <-ids
+	}()
+	select {
+	case id := <-second:
+		if id == first {
+			t.Errorf("both sessions got ID %q", id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("second session waited for the first request to finish")
+	}
+	close(release)
+	<-done
+}
+
+func TestLoadStoreDropsExpiredSessions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	s, err := loadStore(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.records["old"] = &record{Params: []byte(`{}`), LastUsed: time.Now().Add(-2 * time.Hour)}
+	if err := s.put("new", []byte(`{}`), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := loadStore(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reloaded.get("new"); !ok {
+		t.Error("unexpired session not reloaded")
+	}
+	if _, ok := reloaded.get("old"); ok {
+		t.Error("expired session reloaded")
+	}
+}
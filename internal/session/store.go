@@ -0,0 +1,132 @@
+// Package session lets streamable HTTP clients keep their MCP session ID
+// across server restarts. Each session's initialize parameters are written
+// to a file; after a restart, a request naming a stored session re-creates
+// it under the same ID before the request is handled.
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// touchInterval limits how often use of a session rewrites the store file.
+const touchInterval = time.Minute
+
+// record is the persisted state of one session.
+type record struct {
+	Params   json.RawMessage `json:"initialize_params"`
+	Subject  string          `json:"subject,omitempty"` // bearer subject that created the session
+	LastUsed time.Time       `json:"last_used"`
+}
+
+// store is a JSON file of session records keyed by session ID. Records
+// unused for longer than ttl are dropped.
+type store struct {
+	path string
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	records map[string]*record
+}
+
+// loadStore reads the store file at path. A missing file is an empty store.
+func loadStore(path string, ttl time.Duration) (*store, error) {
+	s := &store{path: path, ttl: ttl, records: make(map[string]*record)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading session store: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.records); err != nil {
+		return nil, fmt.Errorf("parsing session store %s: %w", path, err)
+	}
+	s.prune(time.Now())
+	return s, nil
+}
+
+// get returns the record of an unexpired session.
+func (s *store) get(id string) (record, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.records[id]
+	if !ok || time.Since(rec.LastUsed) > s.ttl {
+		return record{}, false
+	}
+	return *rec, true
+}
+
+// put records a new session created by subject and saves the store.
+func (s *store) put(id string, params json.RawMessage, subject string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[id] = &record{Params: params, Subject: subject, LastUsed: time.Now()}
+	return s.save()
+}
+
+// touch marks a session as used, saving at most once per touchInterval.
+func (s *store) touch(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.records[id]
+	if !ok || time.Since(rec.LastUsed) < touchInterval {
+		return
+	}
+	rec.LastUsed = time.Now()
+	if err := s.save(); err != nil {
+		slog.Warn("could not save session store", "error", err)
+	}
+}
+
+// remove forgets a session and saves the store.
+func (s *store) remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.records[id]; !ok {
+		return nil
+	}
+	delete(s.records, id)
+	return s.save()
+}
+
+// prune drops expired records. The caller holds s.mu or owns s.
+func (s *store) prune(now time.Time) {
+	for id, rec := range s.records {
+		if now.Sub(rec.LastUsed) > s.ttl {
+			delete(s.records, id)
+		}
+	}
+}
+
+// save writes the unexpired records to a temporary file and renames it over
+// the store, so a crash never leaves a partial file. The caller holds s.mu.
+func (s *store) save() error {
+	s.prune(time.Now())
+	data, err := json.Marshal(s.records)
+	if err != nil {
+		return fmt.Errorf("encoding session store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".sessions-*")
+	if err != nil {
+		return fmt.Errorf("writing session store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing session store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing session store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("writing session store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// Package admin implements Admin SDK tools for a Google Workspace
+// organization: Directory tools manage users, groups, and group memberships,
+// and Reports tools query the audit logs. The service is opt-in (WORKSPACE_MCP_ADMIN_TOOLS) and its tools only work for
+// Workspace administrators.
+package admin
+
+import (
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/ptr"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+var serviceIcons = []mcp.Icon{{
+	Source:   "https://www.gstatic.com/images/branding/product/1x/admin_48dp.png",
+	MIMEType: "image/png",
+	Sizes:    []string{"48x48"},
+}}
+
+// Register registers all Admin Directory and Reports tools (core + extended + complete)
+// with the MCP server.
+func Register(server *mcp.Server, factory *services.Factory) {
+	// --- Core tools (6) ---
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_directory_users",
+		Icons:       serviceIcons,
+		Description: "List users in the Workspace organization, optionally filtered with a Directory search query (e.g. \"orgUnitPath=/Sales\", \"isSuspended=true\", \"name:Jane*\"). Requires a Workspace administrator.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Directory Users",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListUsersHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_directory_user",
+		Icons:       serviceIcons,
+		Description: "Get a Workspace user's account details: name, org unit, admin and suspension status, 2-Step Verification, and last login.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Directory User",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetUserHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_directory_groups",
+		Icons:       serviceIcons,
+		Description: "List groups in the Workspace organization, or the groups one user belongs to.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Directory Groups",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListGroupsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_directory_group_members",
+		Icons:       serviceIcons,
+		Description: "List the members of a Workspace group with their role (owner, manager, member), type, and status.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Group Members",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListMembersHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_audit_events",
+		Icons:       serviceIcons,
+		Description: "Query the Admin audit logs (login, drive, admin, token, groups, and more) by time range, actor, event name, IP address, or event parameter filters. Each event lists its parameters, e.g. doc_title or login_type. Requires a Workspace administrator.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Audit Events",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListAuditEventsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_external_shares",
+		Icons:       serviceIcons,
+		Description: "List Drive files shared with people outside the organization in a time range (default: last 7 days): who shared which file, with whom, and with what role. Optionally limit to one user.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List External Shares",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListExternalSharesHandler(factory))
+
+	// --- Extended tools (5) ---
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_directory_user",
+		Icons:       serviceIcons,
+		Description: "Create a Workspace user account. Without a password a random temporary one is generated and returned once; the user must change it at first sign-in.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Create Directory User",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createCreateUserHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "suspend_directory_user",
+		Icons:       serviceIcons,
+		Description: "Suspend a Workspace user, blocking sign-in while keeping their data, or restore a suspended user with unsuspend=true.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Suspend Directory User",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createSuspendUserHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_directory_group",
+		Icons:       serviceIcons,
+		Description: "Create a Workspace group with an email address, name, and optional description.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Create Directory Group",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createCreateGroupHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "add_directory_group_member",
+		Icons:       serviceIcons,
+		Description: "Add a user or group to a Workspace group as owner, manager, or member (default member).",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Add Group Member",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createAddMemberHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "remove_directory_group_member",
+		Icons:       serviceIcons,
+		Description: "Remove a member from a Workspace group.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Remove Group Member",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createRemoveMemberHandler(factory))
+
+	// --- Complete tools (1) ---
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_directory_group",
+		Icons:       serviceIcons,
+		Description: "Permanently delete a Workspace group. Its members lose access to anything shared with the group.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Delete Directory Group",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createDeleteGroupHandler(factory))
+}
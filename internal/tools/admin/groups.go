@@ -0,0 +1,263 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	directory "google.golang.org/api/admin/directory/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- list_directory_groups (core) ---
+
+type ListGroupsInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The administrator's Google email address"`
+	MemberKey string `json:"member_key,omitempty" jsonschema_description:"Only list groups this user or group belongs to (email or ID)"`
+	PageSize  int    `json:"page_size,omitempty" jsonschema_description:"Maximum groups to return (default 50, max 200)"`
+	PageToken string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type ListGroupsOutput struct {
+	Groups        []GroupSummary `json:"groups"`
+	NextPageToken string         `json:"next_page_token,omitempty"`
+}
+
+func createListGroupsHandler(factory *services.Factory) mcp.ToolHandlerFor[ListGroupsInput, ListGroupsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListGroupsInput) (*mcp.CallToolResult, ListGroupsOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 50
+		}
+
+		srv, err := factory.Directory(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListGroupsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Groups.List().MaxResults(int64(input.PageSize)).Context(ctx)
+		// The API takes either a customer or a member, not both.
+		if input.MemberKey != "" {
+			call = call.UserKey(input.MemberKey)
+		} else {
+			call = call.Customer(customerID)
+		}
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListGroupsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		groups := make([]GroupSummary, 0, len(result.Groups))
+		rb := response.New()
+		rb.Header("Directory Groups")
+		rb.KeyValue("Count", len(result.Groups))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+
+		for _, g := range result.Groups {
+			gs := groupToSummary(g)
+			groups = append(groups, gs)
+			rb.Item("%s <%s> (%d members)", gs.Name, gs.Email, gs.MemberCount)
+			if gs.Description != "" {
+				rb.Line("    %s", gs.Description)
+			}
+		}
+
+		return rb.TextResult(), ListGroupsOutput{Groups: groups, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// --- create_directory_group (extended) ---
+
+type CreateGroupInput struct {
+	UserEmail   string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The administrator's Google email address"`
+	Email       string `json:"email" jsonschema:"required" jsonschema_description:"The group's email address in one of the organization's domains"`
+	Name        string `json:"name" jsonschema:"required" jsonschema_description:"The group's display name"`
+	Description string `json:"description,omitempty" jsonschema_description:"What the group is for"`
+}
+
+type CreateGroupOutput struct {
+	Group GroupSummary `json:"group"`
+}
+
+func createCreateGroupHandler(factory *services.Factory) mcp.ToolHandlerFor[CreateGroupInput, CreateGroupOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateGroupInput) (*mcp.CallToolResult, CreateGroupOutput, error) {
+		srv, err := factory.Directory(ctx, input.UserEmail)
+		if err != nil {
+			return nil, CreateGroupOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		group, err := srv.Groups.Insert(&directory.Group{
+			Email:       input.Email,
+			Name:        input.Name,
+			Description: input.Description,
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, CreateGroupOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		gs := groupToSummary(group)
+		rb := response.New()
+		rb.Header("Directory Group Created")
+		rb.KeyValue("Email", gs.Email)
+		rb.KeyValue("Name", gs.Name)
+		rb.KeyValue("ID", gs.ID)
+
+		return rb.TextResult(), CreateGroupOutput{Group: gs}, nil
+	}
+}
+
+// --- delete_directory_group (complete) ---
+
+type DeleteGroupInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The administrator's Google email address"`
+	GroupKey  string `json:"group_key" jsonschema:"required" jsonschema_description:"The group's email address, alias, or unique ID"`
+}
+
+func createDeleteGroupHandler(factory *services.Factory) mcp.ToolHandlerFor[DeleteGroupInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DeleteGroupInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Directory(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		if err := srv.Groups.Delete(input.GroupKey).Context(ctx).Do(); err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Directory Group Deleted")
+		rb.KeyValue("Group", input.GroupKey)
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// --- list_directory_group_members (core) ---
+
+type ListMembersInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The administrator's Google email address"`
+	GroupKey  string `json:"group_key" jsonschema:"required" jsonschema_description:"The group's email address, alias, or unique ID"`
+	PageSize  int    `json:"page_size,omitempty" jsonschema_description:"Maximum members to return (default 100, max 200)"`
+	PageToken string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type ListMembersOutput struct {
+	Members       []MemberSummary `json:"members"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+}
+
+func createListMembersHandler(factory *services.Factory) mcp.ToolHandlerFor[ListMembersInput, ListMembersOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListMembersInput) (*mcp.CallToolResult, ListMembersOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 100
+		}
+
+		srv, err := factory.Directory(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListMembersOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Members.List(input.GroupKey).MaxResults(int64(input.PageSize)).Context(ctx)
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListMembersOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		members := make([]MemberSummary, 0, len(result.Members))
+		rb := response.New()
+		rb.Header("Group Members")
+		rb.KeyValue("Group", input.GroupKey)
+		rb.KeyValue("Count", len(result.Members))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+
+		for _, m := range result.Members {
+			ms := memberToSummary(m)
+			members = append(members, ms)
+			rb.Item("%s — %s (%s)", ms.Email, ms.Role, ms.Type)
+		}
+
+		return rb.TextResult(), ListMembersOutput{Members: members, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// --- add_directory_group_member (extended) ---
+
+type AddMemberInput struct {
+	UserEmail   string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The administrator's Google email address"`
+	GroupKey    string `json:"group_key" jsonschema:"required" jsonschema_description:"The group's email address, alias, or unique ID"`
+	MemberEmail string `json:"member_email" jsonschema:"required" jsonschema_description:"Email address of the user or group to add"`
+	Role        string `json:"role,omitempty" jsonschema_description:"Role in the group: owner, manager, or member (default member),enum=owner,enum=manager,enum=member"`
+}
+
+func createAddMemberHandler(factory *services.Factory) mcp.ToolHandlerFor[AddMemberInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input AddMemberInput) (*mcp.CallToolResult, any, error) {
+		role, err := groupRole(input.Role)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		srv, err := factory.Directory(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		member, err := srv.Members.Insert(input.GroupKey, &directory.Member{
+			Email: input.MemberEmail,
+			Role:  role,
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Group Member Added")
+		rb.KeyValue("Group", input.GroupKey)
+		rb.KeyValue("Member", member.Email)
+		rb.KeyValue("Role", member.Role)
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// --- remove_directory_group_member (extended) ---
+
+type RemoveMemberInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The administrator's Google email address"`
+	GroupKey  string `json:"group_key" jsonschema:"required" jsonschema_description:"The group's email address, alias, or unique ID"`
+	MemberKey string `json:"member_key" jsonschema:"required" jsonschema_description:"The member's email address or unique ID"`
+}
+
+func createRemoveMemberHandler(factory *services.Factory) mcp.ToolHandlerFor[RemoveMemberInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input RemoveMemberInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Directory(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		if err := srv.Members.Delete(input.GroupKey, input.MemberKey).Context(ctx).Do(); err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Group Member Removed")
+		rb.KeyValue("Group", input.GroupKey)
+		rb.KeyValue("Member", input.MemberKey)
+
+		return rb.TextResult(), nil, nil
+	}
+}
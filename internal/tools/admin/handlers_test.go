@@ -0,0 +1,388 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// fakeAPI answers Admin SDK requests in-process from a ServeMux, so the
+// handlers run against canned Directory responses.
+type fakeAPI struct{ *http.ServeMux }
+
+func (f fakeAPI) RoundTrip(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	f.ServeHTTP(rec, r)
+	resp := rec.Result()
+	resp.Request = r
+	return resp, nil
+}
+
+func fakeFactory(mux *http.ServeMux) *services.Factory {
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(fakeAPI{mux})
+	return factory
+}
+
+func replyJSON(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	}
+}
+
+func replyError(code int, message string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		fmt.Fprintf(w, `{"error":{"code":%d,"message":%q}}`, code, message)
+	}
+}
+
+func resultText(res *mcp.CallToolResult) string {
+	var b strings.Builder
+	for _, c := range res.Content {
+		if tc, ok := c.(*mcp.TextContent); ok {
+			b.WriteString(tc.Text)
+		}
+	}
+	return b.String()
+}
+
+const admin = "admin@example.com"
+
+func TestListUsersHandler(t *testing.T) {
+	var query string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/directory/v1/users", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		replyJSON(`{"users":[
+			{"id":"1","primaryEmail":"jane@example.com","name":{"fullName":"Jane Doe"},"isAdmin":true,"orgUnitPath":"/Sales"},
+			{"id":"2","primaryEmail":"bob@example.com","suspended":true,"orgUnitPath":"/"}
+		],"nextPageToken":"p2"}`)(w, r)
+	})
+
+	res, out, err := createListUsersHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, ListUsersInput{
+		UserEmail: admin,
+		Query:     "orgUnitPath=/Sales",
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	for _, want := range []string{"customer=my_customer", "maxResults=50", "orderBy=email", "query=orgUnitPath"} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query %q missing %q", query, want)
+		}
+	}
+	if len(out.Users) != 2 || out.NextPageToken != "p2" || !out.Users[0].IsAdmin || !out.Users[1].Suspended {
+		t.Errorf("output = %+v", out)
+	}
+	text := resultText(res)
+	for _, want := range []string{"Count: 2", "Next page token: p2", "Jane Doe <jane@example.com> [admin]", "bob@example.com [suspended]"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestGetUserHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/directory/v1/users/{key}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("key") != "jane@example.com" {
+			t.Errorf("user key = %q", r.PathValue("key"))
+		}
+		replyJSON(`{"id":"1","primaryEmail":"jane@example.com","suspended":true,"suspensionReason":"ADMIN","lastLoginTime":"1970-01-01T00:00:00.000Z"}`)(w, r)
+	})
+
+	res, out, err := createGetUserHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, GetUserInput{UserEmail: admin, UserKey: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.User.ID != "1" || !out.User.Suspended || out.User.LastLoginTime != "" {
+		t.Errorf("output = %+v", out)
+	}
+	text := resultText(res)
+	for _, want := range []string{"Suspension reason: ADMIN", "Last login: never"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestCreateUserHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		password      string
+		wantErr       string
+		wantGenerated bool
+	}{
+		{name: "generated password", wantGenerated: true},
+		{name: "given password", password: "correct-horse"},
+		{name: "short password", password: "short", wantErr: "8-100 characters"},
+		{name: "long password", password: strings.Repeat("x", 101), wantErr: "8-100 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /admin/directory/v1/users", func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+				replyJSON(`{"id":"9","primaryEmail":"new@example.com","orgUnitPath":"/"}`)(w, r)
+			})
+
+			res, out, err := createCreateUserHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, CreateUserInput{
+				UserEmail:    admin,
+				PrimaryEmail: "new@example.com",
+				GivenName:    "New",
+				FamilyName:   "User",
+				Password:     tt.password,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want %q", err, tt.wantErr)
+				}
+				if body != "" {
+					t.Errorf("invalid input reached the API: %s", body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+			if out.User.ID != "9" {
+				t.Errorf("output = %+v", out)
+			}
+			if got := strings.Contains(body, `"changePasswordAtNextLogin":true`); got != tt.wantGenerated {
+				t.Errorf("request body = %s, want changePasswordAtNextLogin %v", body, tt.wantGenerated)
+			}
+			if (out.TemporaryPassword != "") != tt.wantGenerated {
+				t.Errorf("temporary password = %q, want one only when generated", out.TemporaryPassword)
+			}
+			if tt.wantGenerated && !strings.Contains(resultText(res), "Temporary password: "+out.TemporaryPassword) {
+				t.Errorf("text does not show the generated password:\n%s", resultText(res))
+			}
+		})
+	}
+}
+
+func TestSuspendUserHandler(t *testing.T) {
+	for _, unsuspend := range []bool{false, true} {
+		var body string
+		mux := http.NewServeMux()
+		mux.HandleFunc("PATCH /admin/directory/v1/users/{key}", func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			fmt.Fprintf(w, `{"primaryEmail":"bob@example.com","suspended":%v}`, !unsuspend)
+		})
+
+		res, _, err := createSuspendUserHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, SuspendUserInput{
+			UserEmail: admin,
+			UserKey:   "bob@example.com",
+			Unsuspend: unsuspend,
+		})
+		if err != nil {
+			t.Fatalf("unsuspend=%v: handler error = %v", unsuspend, err)
+		}
+		if want := fmt.Sprintf(`"suspended":%v`, !unsuspend); !strings.Contains(body, want) {
+			t.Errorf("unsuspend=%v: request body = %s, want %s sent explicitly", unsuspend, body, want)
+		}
+		want := "Directory User Suspended"
+		if unsuspend {
+			want = "Directory User Unsuspended"
+		}
+		if !strings.Contains(resultText(res), want) {
+			t.Errorf("unsuspend=%v: text = %q, want %q", unsuspend, resultText(res), want)
+		}
+	}
+}
+
+func TestListGroupsHandler(t *testing.T) {
+	tests := []struct {
+		memberKey string
+		want      string
+		notWant   string
+	}{
+		{"", "customer=my_customer", "userKey="},
+		{"jane@example.com", "userKey=jane%40example.com", "customer="},
+	}
+	for _, tt := range tests {
+		var query string
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /admin/directory/v1/groups", func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			replyJSON(`{"groups":[{"id":"g1","email":"sales@example.com","name":"Sales","description":"Sales team","directMembersCount":"12"}]}`)(w, r)
+		})
+
+		res, out, err := createListGroupsHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, ListGroupsInput{UserEmail: admin, MemberKey: tt.memberKey})
+		if err != nil {
+			t.Fatalf("member %q: handler error = %v", tt.memberKey, err)
+		}
+		if !strings.Contains(query, tt.want) || strings.Contains(query, tt.notWant) {
+			t.Errorf("member %q: query = %q, want %q without %q", tt.memberKey, query, tt.want, tt.notWant)
+		}
+		if len(out.Groups) != 1 || out.Groups[0].MemberCount != 12 {
+			t.Errorf("member %q: output = %+v", tt.memberKey, out)
+		}
+		if !strings.Contains(resultText(res), "Sales <sales@example.com> (12 members)") {
+			t.Errorf("member %q: text = %q", tt.memberKey, resultText(res))
+		}
+	}
+}
+
+func TestCreateGroupHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/directory/v1/groups", replyJSON(`{"id":"g2","email":"ops@example.com","name":"Ops"}`))
+
+	res, out, err := createCreateGroupHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, CreateGroupInput{UserEmail: admin, Email: "ops@example.com", Name: "Ops"})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.Group.ID != "g2" || !strings.Contains(resultText(res), "ID: g2") {
+		t.Errorf("output = %+v, text = %q", out, resultText(res))
+	}
+}
+
+func TestGroupMemberHandlers(t *testing.T) {
+	var calls []string
+	var insertBody string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/directory/v1/groups/{group}/members", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "list "+r.PathValue("group")+" "+r.URL.Query().Get("maxResults"))
+		replyJSON(`{"members":[{"email":"jane@example.com","role":"OWNER","type":"USER"}],"nextPageToken":"m2"}`)(w, r)
+	})
+	mux.HandleFunc("POST /admin/directory/v1/groups/{group}/members", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		insertBody = string(data)
+		calls = append(calls, "insert "+r.PathValue("group"))
+		replyJSON(`{"email":"bob@example.com","role":"MANAGER"}`)(w, r)
+	})
+	mux.HandleFunc("DELETE /admin/directory/v1/groups/{group}/members/{member}", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "delete "+r.PathValue("group")+" "+r.PathValue("member"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /admin/directory/v1/groups/{group}", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "delete group "+r.PathValue("group"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	factory := fakeFactory(mux)
+	ctx := context.Background()
+
+	res, out, err := createListMembersHandler(factory)(ctx, &mcp.CallToolRequest{}, ListMembersInput{UserEmail: admin, GroupKey: "sales@example.com"})
+	if err != nil {
+		t.Fatalf("list members error = %v", err)
+	}
+	if len(out.Members) != 1 || out.Members[0].Role != "OWNER" || out.NextPageToken != "m2" {
+		t.Errorf("list members output = %+v", out)
+	}
+	if !strings.Contains(resultText(res), "jane@example.com — OWNER (USER)") {
+		t.Errorf("list members text = %q", resultText(res))
+	}
+
+	if _, _, err := createAddMemberHandler(factory)(ctx, &mcp.CallToolRequest{}, AddMemberInput{UserEmail: admin, GroupKey: "sales@example.com", MemberEmail: "bob@example.com", Role: "admin"}); err == nil || !strings.Contains(err.Error(), "invalid role") {
+		t.Errorf("add member with role admin error = %v, want invalid role", err)
+	}
+	res, _, err = createAddMemberHandler(factory)(ctx, &mcp.CallToolRequest{}, AddMemberInput{UserEmail: admin, GroupKey: "sales@example.com", MemberEmail: "bob@example.com", Role: "manager"})
+	if err != nil {
+		t.Fatalf("add member error = %v", err)
+	}
+	if !strings.Contains(insertBody, `"role":"MANAGER"`) || !strings.Contains(resultText(res), "Role: MANAGER") {
+		t.Errorf("add member body = %s, text = %q", insertBody, resultText(res))
+	}
+
+	if _, _, err := createRemoveMemberHandler(factory)(ctx, &mcp.CallToolRequest{}, RemoveMemberInput{UserEmail: admin, GroupKey: "sales@example.com", MemberKey: "bob@example.com"}); err != nil {
+		t.Fatalf("remove member error = %v", err)
+	}
+	if _, _, err := createDeleteGroupHandler(factory)(ctx, &mcp.CallToolRequest{}, DeleteGroupInput{UserEmail: admin, GroupKey: "ops@example.com"}); err != nil {
+		t.Fatalf("delete group error = %v", err)
+	}
+
+	want := []string{
+		"list sales@example.com 100",
+		"insert sales@example.com",
+		"delete sales@example.com bob@example.com",
+		"delete group ops@example.com",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calls =\n%s\nwant\n%s", strings.Join(calls, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestDirectoryHandlersAPIErrors(t *testing.T) {
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	handlers := map[string]func(*services.Factory) error{
+		"list_directory_users": func(f *services.Factory) error {
+			_, _, err := createListUsersHandler(f)(ctx, req, ListUsersInput{UserEmail: admin})
+			return err
+		},
+		"get_directory_user": func(f *services.Factory) error {
+			_, _, err := createGetUserHandler(f)(ctx, req, GetUserInput{UserEmail: admin, UserKey: "jane@example.com"})
+			return err
+		},
+		"create_directory_user": func(f *services.Factory) error {
+			_, _, err := createCreateUserHandler(f)(ctx, req, CreateUserInput{UserEmail: admin, PrimaryEmail: "new@example.com"})
+			return err
+		},
+		"suspend_directory_user": func(f *services.Factory) error {
+			_, _, err := createSuspendUserHandler(f)(ctx, req, SuspendUserInput{UserEmail: admin, UserKey: "jane@example.com"})
+			return err
+		},
+		"list_directory_groups": func(f *services.Factory) error {
+			_, _, err := createListGroupsHandler(f)(ctx, req, ListGroupsInput{UserEmail: admin})
+			return err
+		},
+		"create_directory_group": func(f *services.Factory) error {
+			_, _, err := createCreateGroupHandler(f)(ctx, req, CreateGroupInput{UserEmail: admin, Email: "ops@example.com"})
+			return err
+		},
+		"delete_directory_group": func(f *services.Factory) error {
+			_, _, err := createDeleteGroupHandler(f)(ctx, req, DeleteGroupInput{UserEmail: admin, GroupKey: "ops@example.com"})
+			return err
+		},
+		"list_directory_group_members": func(f *services.Factory) error {
+			_, _, err := createListMembersHandler(f)(ctx, req, ListMembersInput{UserEmail: admin, GroupKey: "ops@example.com"})
+			return err
+		},
+		"add_directory_group_member": func(f *services.Factory) error {
+			_, _, err := createAddMemberHandler(f)(ctx, req, AddMemberInput{UserEmail: admin, GroupKey: "ops@example.com", MemberEmail: "bob@example.com"})
+			return err
+		},
+		"remove_directory_group_member": func(f *services.Factory) error {
+			_, _, err := createRemoveMemberHandler(f)(ctx, req, RemoveMemberInput{UserEmail: admin, GroupKey: "ops@example.com", MemberKey: "bob@example.com"})
+			return err
+		},
+	}
+	statuses := []struct {
+		code int
+		want string
+	}{
+		{http.StatusForbidden, "permission denied"},
+		{http.StatusNotFound, "resource not found"},
+	}
+	for _, st := range statuses {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", replyError(st.code, "Not Authorized to access this resource/api"))
+		factory := fakeFactory(mux)
+		for name, call := range handlers {
+			if err := call(factory); err == nil || !strings.Contains(err.Error(), st.want) {
+				t.Errorf("%s on %d: error = %v, want %q", name, st.code, err, st.want)
+			}
+		}
+	}
+
+	_, _, err := createListUsersHandler(fakeFactory(http.NewServeMux()))(ctx, req, ListUsersInput{UserEmail: "not-an-email"})
+	if err == nil || !strings.Contains(err.Error(), "invalid user email") {
+		t.Errorf("invalid admin email error = %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package admin
+
+import (
+	"fmt"
+	"strings"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+// customerID addresses the customer account of the authenticated admin.
+const customerID = "my_customer"
+
+// UserSummary is the structured form of a Directory user.
+type UserSummary struct {
+	ID            string `json:"id"`
+	PrimaryEmail  string `json:"primary_email"`
+	Name          string `json:"name,omitempty"`
+	OrgUnitPath   string `json:"org_unit_path,omitempty"`
+	IsAdmin       bool   `json:"is_admin"`
+	Suspended     bool   `json:"suspended"`
+	Enrolled2SV   bool   `json:"enrolled_in_2sv"`
+	LastLoginTime string `json:"last_login_time,omitempty"`
+	CreationTime  string `json:"creation_time,omitempty"`
+}
+
+// GroupSummary is the structured form of a Directory group.
+type GroupSummary struct {
+	ID          string `json:"id"`
+	Email       string `json:"email"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	MemberCount int64  `json:"member_count"`
+}
+
+// MemberSummary is the structured form of a group member.
+type MemberSummary struct {
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+	Type   string `json:"type"`
+	Status string `json:"status,omitempty"`
+}
+
+func userToSummary(u *directory.User) UserSummary {
+	us := UserSummary{
+		ID:           u.Id,
+		PrimaryEmail: u.PrimaryEmail,
+		OrgUnitPath:  u.OrgUnitPath,
+		IsAdmin:      u.IsAdmin,
+		Suspended:    u.Suspended,
+		Enrolled2SV:  u.IsEnrolledIn2Sv,
+		CreationTime: u.CreationTime,
+	}
+	if u.Name != nil {
+		us.Name = u.Name.FullName
+		if us.Name == "" {
+			us.Name = strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+		}
+	}
+	// Users who never signed in report the Unix epoch.
+	if !strings.HasPrefix(u.LastLoginTime, "1970-01-01") {
+		us.LastLoginTime = u.LastLoginTime
+	}
+	return us
+}
+
+func groupToSummary(g *directory.Group) GroupSummary {
+	return GroupSummary{
+		ID:          g.Id,
+		Email:       g.Email,
+		Name:        g.Name,
+		Description: g.Description,
+		MemberCount: g.DirectMembersCount,
+	}
+}
+
+func memberToSummary(m *directory.Member) MemberSummary {
+	return MemberSummary{Email: m.Email, Role: m.Role, Type: m.Type, Status: m.Status}
+}
+
+// formatUserLine renders a user as "Name <email>" with status flags.
+func formatUserLine(u UserSummary) string {
+	line := u.PrimaryEmail
+	if u.Name != "" {
+		line = fmt.Sprintf("%s <%s>", u.Name, u.PrimaryEmail)
+	}
+	if u.IsAdmin {
+		line += " [admin]"
+	}
+	if u.Suspended {
+		line += " [suspended]"
+	}
+	return line
+}
+
+// groupRole returns the Directory role for a role argument, defaulting to
+// MEMBER.
+func groupRole(role string) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(role)) {
+	case "", "MEMBER":
+		return "MEMBER", nil
+	case "MANAGER":
+		return "MANAGER", nil
+	case "OWNER":
+		return "OWNER", nil
+	default:
+		return "", fmt.Errorf("invalid role %q: use owner, manager, or member", role)
+	}
+}
@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"testing"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestGroupRole(t *testing.T) {
+	tests := []struct {
+		role    string
+		want    string
+		wantErr bool
+	}{
+		{"", "MEMBER", false},
+		{"member", "MEMBER", false},
+		{" Manager ", "MANAGER", false},
+		{"OWNER", "OWNER", false},
+		{"admin", "", true},
+	}
+	for _, tt := range tests {
+		got, err := groupRole(tt.role)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("groupRole(%q) = %q, %v; want %q, error %v", tt.role, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestUserToSummary(t *testing.T) {
+	tests := []struct {
+		name      string
+		user      *directory.User
+		wantName  string
+		wantLogin string
+	}{
+		{
+			name:      "full name",
+			user:      &directory.User{Name: &directory.UserName{FullName: "Jane Doe", GivenName: "J"}, LastLoginTime: "2026-03-01T09:00:00.000Z"},
+			wantName:  "Jane Doe",
+			wantLogin: "2026-03-01T09:00:00.000Z",
+		},
+		{
+			name:     "given and family name",
+			user:     &directory.User{Name: &directory.UserName{GivenName: "Jane", FamilyName: "Doe"}},
+			wantName: "Jane Doe",
+		},
+		{
+			name: "never signed in",
+			user: &directory.User{LastLoginTime: "1970-01-01T00:00:00.000Z"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := userToSummary(tt.user)
+			if got.Name != tt.wantName || got.LastLoginTime != tt.wantLogin {
+				t.Errorf("userToSummary() name %q, last login %q; want %q, %q", got.Name, got.LastLoginTime, tt.wantName, tt.wantLogin)
+			}
+		})
+	}
+}
+
+func TestFormatUserLine(t *testing.T) {
+	tests := map[string]UserSummary{
+		"bob@example.com":                       {PrimaryEmail: "bob@example.com"},
+		"Jane Doe <jane@example.com> [admin]":   {PrimaryEmail: "jane@example.com", Name: "Jane Doe", IsAdmin: true},
+		"carol@example.com [admin] [suspended]": {PrimaryEmail: "carol@example.com", IsAdmin: true, Suspended: true},
+		"Dan Roe <dan@example.com> [suspended]": {PrimaryEmail: "dan@example.com", Name: "Dan Roe", Suspended: true},
+	}
+	for want, u := range tests {
+		if got := formatUserLine(u); got != want {
+			t.Errorf("formatUserLine(%+v) = %q, want %q", u, got, want)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	reports "google.golang.org/api/admin/reports/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// defaultAuditWindow is how far back audit queries look without a start time.
+const defaultAuditWindow = 7 * 24 * time.Hour
+
+// AuditEvent is one event of an audit activity record.
+type AuditEvent struct {
+	Time        string            `json:"time"`
+	Application string            `json:"application"`
+	Actor       string            `json:"actor"`
+	IPAddress   string            `json:"ip_address,omitempty"`
+	Type        string            `json:"type,omitempty"`
+	Name        string            `json:"name"`
+	Parameters  map[string]string `json:"parameters,omitempty"`
+}
+
+// --- list_audit_events (core) ---
+
+type ListAuditEventsInput struct {
+	UserEmail   string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The administrator's Google email address"`
+	Application string `json:"application" jsonschema:"required" jsonschema_description:"Audit log to query: login, drive, admin, token, groups, user_accounts, calendar, meet, chat, or saml,enum=login,enum=drive,enum=admin,enum=token,enum=groups,enum=user_accounts,enum=calendar,enum=meet,enum=chat,enum=saml"`
+	ActorEmail  string `json:"actor_email,omitempty" jsonschema_description:"Only events by this user (default: all users)"`
+	StartTime   string `json:"start_time,omitempty" jsonschema_description:"Start of time range (RFC3339, default: 7 days ago)"`
+	EndTime     string `json:"end_time,omitempty" jsonschema_description:"End of time range (RFC3339, default: now)"`
+	EventName   string `json:"event_name,omitempty" jsonschema_description:"Only this event, e.g. login_failure, change_user_access, CREATE_USER"`
+	Filters     string `json:"filters,omitempty" jsonschema_description:"Event parameter filters, comma-separated (e.g. visibility==shared_externally, doc_type==spreadsheet)"`
+	IPAddress   string `json:"ip_address,omitempty" jsonschema_description:"Only events from this IP address"`
+	PageSize    int    `json:"page_size,omitempty" jsonschema_description:"Maximum activity records to return (default 50, max 1000)"`
+	PageToken   string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type ListAuditEventsOutput struct {
+	Events        []AuditEvent `json:"events"`
+	NextPageToken string       `json:"next_page_token,omitempty"`
+}
+
+func createListAuditEventsHandler(factory *services.Factory) mcp.ToolHandlerFor[ListAuditEventsInput, ListAuditEventsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListAuditEventsInput) (*mcp.CallToolResult, ListAuditEventsOutput, error) {
+		start, end, err := auditTimeRange(input.StartTime, input.EndTime, time.Now())
+		if err != nil {
+			return nil, ListAuditEventsOutput{}, err
+		}
+		if input.PageSize == 0 {
+			input.PageSize = 50
+		}
+
+		srv, err := factory.Reports(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListAuditEventsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Activities.List(auditActor(input.ActorEmail), input.Application).
+			StartTime(start).
+			EndTime(end).
+			MaxResults(int64(input.PageSize)).
+			Context(ctx)
+		if input.EventName != "" {
+			call = call.EventName(input.EventName)
+		}
+		if input.Filters != "" {
+			call = call.Filters(input.Filters)
+		}
+		if input.IPAddress != "" {
+			call = call.ActorIpAddress(input.IPAddress)
+		}
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListAuditEventsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		events := flattenActivities(result.Items)
+		rb := response.New()
+		rb.Header("Audit Events")
+		rb.KeyValue("Application", input.Application)
+		rb.KeyValue("Range", start+" → "+end)
+		rb.KeyValue("Count", len(events))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+		for _, e := range events {
+			rb.Item("%s %s — %s", e.Time, e.Actor, e.Name)
+			if params := formatParameters(e.Parameters); params != "" {
+				rb.Line("    %s", params)
+			}
+		}
+
+		return rb.TextResult(), ListAuditEventsOutput{Events: events, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// --- list_external_shares (core) ---
+
+type ListExternalSharesInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The administrator's Google email address"`
+	ActorEmail string `json:"actor_email,omitempty" jsonschema_description:"Only shares made by this user (default: all users)"`
+	StartTime  string `json:"start_time,omitempty" jsonschema_description:"Start of time range (RFC3339, default: 7 days ago)"`
+	EndTime    string `json:"end_time,omitempty" jsonschema_description:"End of time range (RFC3339, default: now)"`
+	PageSize   int    `json:"page_size,omitempty" jsonschema_description:"Maximum activity records to return (default 100, max 1000)"`
+	PageToken  string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+// ExternalShare is a Drive sharing change that gave someone outside the
+// organization access to a file.
+type ExternalShare struct {
+	Time     string `json:"time"`
+	Actor    string `json:"actor"`
+	DocTitle string `json:"doc_title"`
+	DocID    string `json:"doc_id"`
+	Target   string `json:"target,omitempty"`
+	Role     string `json:"role,omitempty"`
+}
+
+type ListExternalSharesOutput struct {
+	Shares        []ExternalShare `json:"shares"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+}
+
+func createListExternalSharesHandler(factory *services.Factory) mcp.ToolHandlerFor[ListExternalSharesInput, ListExternalSharesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListExternalSharesInput) (*mcp.CallToolResult, ListExternalSharesOutput, error) {
+		start, end, err := auditTimeRange(input.StartTime, input.EndTime, time.Now())
+		if err != nil {
+			return nil, ListExternalSharesOutput{}, err
+		}
+		if input.PageSize == 0 {
+			input.PageSize = 100
+		}
+
+		srv, err := factory.Reports(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListExternalSharesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Activities.List(auditActor(input.ActorEmail), "drive").
+			EventName("change_user_access").
+			Filters("visibility==shared_externally").
+			StartTime(start).
+			EndTime(end).
+			MaxResults(int64(input.PageSize)).
+			Context(ctx)
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListExternalSharesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		shares := externalShares(flattenActivities(result.Items))
+		rb := response.New()
+		rb.Header("External Drive Shares")
+		rb.KeyValue("Range", start+" → "+end)
+		rb.KeyValue("Count", len(shares))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+		for _, s := range shares {
+			rb.Item("%s %s shared %q with %s (%s)", s.Time, s.Actor, s.DocTitle, s.Target, s.Role)
+			rb.Line("    File ID: %s", s.DocID)
+		}
+
+		return rb.TextResult(), ListExternalSharesOutput{Shares: shares, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// auditTimeRange validates an RFC3339 time range, defaulting to the
+// defaultAuditWindow before now.
+func auditTimeRange(start, end string, now time.Time) (string, string, error) {
+	to := now.UTC()
+	if end != "" {
+		t, err := time.Parse(time.RFC3339, end)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid end_time %q — expected RFC3339 (e.g. 2025-06-30T00:00:00Z): %w", end, err)
+		}
+		to = t
+	}
+	from := to.Add(-defaultAuditWindow)
+	if start != "" {
+		t, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid start_time %q — expected RFC3339 (e.g. 2025-06-02T00:00:00Z): %w", start, err)
+		}
+		from = t
+	}
+	if !to.After(from) {
+		return "", "", fmt.Errorf("end_time must be after start_time")
+	}
+	return from.Format(time.RFC3339), to.Format(time.RFC3339), nil
+}
+
+// auditActor returns the Reports userKey for an optional actor filter.
+func auditActor(email string) string {
+	if email == "" {
+		return "all"
+	}
+	return email
+}
+
+// flattenActivities returns one AuditEvent per event of each activity.
+func flattenActivities(items []*reports.Activity) []AuditEvent {
+	var events []AuditEvent
+	for _, a := range items {
+		base := AuditEvent{IPAddress: a.IpAddress}
+		if a.Id != nil {
+			base.Time, base.Application = a.Id.Time, a.Id.ApplicationName
+		}
+		if a.Actor != nil {
+			base.Actor = a.Actor.Email
+			if base.Actor == "" {
+				base.Actor = a.Actor.CallerType
+			}
+		}
+		for _, ev := range a.Events {
+			e := base
+			e.Type, e.Name = ev.Type, ev.Name
+			e.Parameters = eventParameters(ev.Parameters)
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// eventParameters flattens event parameters to strings.
+func eventParameters(params []*reports.ActivityEventsParameters) map[string]string {
+	if len(params) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(params))
+	for _, p := range params {
+		switch {
+		case p.Value != "":
+			out[p.Name] = p.Value
+		case len(p.MultiValue) > 0:
+			out[p.Name] = strings.Join(p.MultiValue, ", ")
+		case p.IntValue != 0:
+			out[p.Name] = fmt.Sprint(p.IntValue)
+		default:
+			out[p.Name] = fmt.Sprint(p.BoolValue)
+		}
+	}
+	return out
+}
+
+// formatParameters renders parameters as "name=value" pairs sorted by name.
+func formatParameters(params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + params[name]
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// externalShares converts Drive change_user_access events to shares.
+func externalShares(events []AuditEvent) []ExternalShare {
+	shares := make([]ExternalShare, 0, len(events))
+	for _, e := range events {
+		shares = append(shares, ExternalShare{
+			Time:     e.Time,
+			Actor:    e.Actor,
+			DocTitle: e.Parameters["doc_title"],
+			DocID:    e.Parameters["doc_id"],
+			Target:   e.Parameters["target_user"],
+			Role:     e.Parameters["new_value"],
+		})
+	}
+	return shares
+}
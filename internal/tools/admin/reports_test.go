@@ -0,0 +1,82 @@
+package admin
+
+import (
+	"testing"
+	"time"
+
+	reports "google.golang.org/api/admin/reports/v1"
+)
+
+func TestAuditTimeRange(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{"defaults to last 7 days", "", "", "2026-03-03T12:00:00Z", "2026-03-10T12:00:00Z", false},
+		{"start only", "2026-03-01T00:00:00Z", "", "2026-03-01T00:00:00Z", "2026-03-10T12:00:00Z", false},
+		{"end only", "", "2026-02-08T00:00:00Z", "2026-02-01T00:00:00Z", "2026-02-08T00:00:00Z", false},
+		{"both", "2026-03-01T00:00:00Z", "2026-03-02T00:00:00Z", "2026-03-01T00:00:00Z", "2026-03-02T00:00:00Z", false},
+		{"invalid start", "last week", "", "", "", true},
+		{"end before start", "2026-03-02T00:00:00Z", "2026-03-01T00:00:00Z", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := auditTimeRange(tt.start, tt.end, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("auditTimeRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("auditTimeRange() = %s, %s; want %s, %s", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestFlattenActivities(t *testing.T) {
+	items := []*reports.Activity{{
+		Id:        &reports.ActivityId{Time: "2026-03-09T08:00:00Z", ApplicationName: "drive"},
+		Actor:     &reports.ActivityActor{Email: "alice@example.com"},
+		IpAddress: "203.0.113.7",
+		Events: []*reports.ActivityEvents{{
+			Type: "acl_change",
+			Name: "change_user_access",
+			Parameters: []*reports.ActivityEventsParameters{
+				{Name: "doc_title", Value: "Budget"},
+				{Name: "doc_id", Value: "abc"},
+				{Name: "target_user", Value: "bob@partner.com"},
+				{Name: "new_value", MultiValue: []string{"can_edit"}},
+				{Name: "primary_event", BoolValue: true},
+			},
+		}, {
+			Name: "view",
+		}},
+	}, {
+		Actor:  &reports.ActivityActor{CallerType: "KEY"},
+		Events: []*reports.ActivityEvents{{Name: "authorize"}},
+	}}
+
+	events := flattenActivities(items)
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+	if e := events[0]; e.Actor != "alice@example.com" || e.Application != "drive" || e.IPAddress != "203.0.113.7" {
+		t.Errorf("first event = %+v", e)
+	}
+	if got := formatParameters(events[0].Parameters); got != "doc_id=abc, doc_title=Budget, new_value=can_edit, primary_event=true, target_user=bob@partner.com" {
+		t.Errorf("formatParameters() = %q", got)
+	}
+	if events[2].Actor != "KEY" {
+		t.Errorf("actor without email = %q, want caller type", events[2].Actor)
+	}
+
+	shares := externalShares(events[:1])
+	want := ExternalShare{Time: "2026-03-09T08:00:00Z", Actor: "alice@example.com", DocTitle: "Budget", DocID: "abc", Target: "bob@partner.com", Role: "can_edit"}
+	if len(shares) != 1 || shares[0] != want {
+		t.Errorf("externalShares() = %+v, want %+v", shares, want)
+	}
+}
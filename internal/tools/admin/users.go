@@ -0,0 +1,216 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	directory "google.golang.org/api/admin/directory/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- list_directory_users (core) ---
+
+type ListUsersInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The administrator's Google email address"`
+	Query     string `json:"query,omitempty" jsonschema_description:"Directory search query (e.g. orgUnitPath=/Sales, isSuspended=true, name:Jane*)"`
+	PageSize  int    `json:"page_size,omitempty" jsonschema_description:"Maximum users to return (default 50, max 500)"`
+	PageToken string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type ListUsersOutput struct {
+	Users         []UserSummary `json:"users"`
+	NextPageToken string        `json:"next_page_token,omitempty"`
+}
+
+func createListUsersHandler(factory *services.Factory) mcp.ToolHandlerFor[ListUsersInput, ListUsersOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListUsersInput) (*mcp.CallToolResult, ListUsersOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 50
+		}
+
+		srv, err := factory.Directory(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListUsersOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Users.List().
+			Customer(customerID).
+			OrderBy("email").
+			MaxResults(int64(input.PageSize)).
+			Context(ctx)
+		if input.Query != "" {
+			call = call.Query(input.Query)
+		}
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListUsersOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		users := make([]UserSummary, 0, len(result.Users))
+		rb := response.New()
+		rb.Header("Directory Users")
+		rb.KeyValue("Count", len(result.Users))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+
+		for _, u := range result.Users {
+			us := userToSummary(u)
+			users = append(users, us)
+			rb.Item("%s", formatUserLine(us))
+			rb.Line("    Org unit: %s", us.OrgUnitPath)
+		}
+
+		return rb.TextResult(), ListUsersOutput{Users: users, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// --- get_directory_user (core) ---
+
+type GetUserInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The administrator's Google email address"`
+	UserKey   string `json:"user_key" jsonschema:"required" jsonschema_description:"The user's primary email, alias, or unique ID"`
+}
+
+type GetUserOutput struct {
+	User UserSummary `json:"user"`
+}
+
+func createGetUserHandler(factory *services.Factory) mcp.ToolHandlerFor[GetUserInput, GetUserOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetUserInput) (*mcp.CallToolResult, GetUserOutput, error) {
+		srv, err := factory.Directory(ctx, input.UserEmail)
+		if err != nil {
+			return nil, GetUserOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		user, err := srv.Users.Get(input.UserKey).Context(ctx).Do()
+		if err != nil {
+			return nil, GetUserOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		us := userToSummary(user)
+		rb := response.New()
+		rb.Header("Directory User")
+		rb.KeyValue("Name", us.Name)
+		rb.KeyValue("Email", us.PrimaryEmail)
+		rb.KeyValue("ID", us.ID)
+		rb.KeyValue("Org unit", us.OrgUnitPath)
+		rb.KeyValue("Admin", us.IsAdmin)
+		rb.KeyValue("Suspended", us.Suspended)
+		if user.SuspensionReason != "" {
+			rb.KeyValue("Suspension reason", user.SuspensionReason)
+		}
+		rb.KeyValue("2-Step Verification", us.Enrolled2SV)
+		rb.KeyValue("Created", us.CreationTime)
+		if us.LastLoginTime != "" {
+			rb.KeyValue("Last login", us.LastLoginTime)
+		} else {
+			rb.KeyValue("Last login", "never")
+		}
+
+		return rb.TextResult(), GetUserOutput{User: us}, nil
+	}
+}
+
+// --- create_directory_user (extended) ---
+
+type CreateUserInput struct {
+	UserEmail    string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The administrator's Google email address"`
+	PrimaryEmail string `json:"primary_email" jsonschema:"required" jsonschema_description:"The new user's primary email address in one of the organization's domains"`
+	GivenName    string `json:"given_name" jsonschema:"required" jsonschema_description:"First name"`
+	FamilyName   string `json:"family_name" jsonschema:"required" jsonschema_description:"Last name"`
+	OrgUnitPath  string `json:"org_unit_path,omitempty" jsonschema_description:"Organizational unit (default /)"`
+	Password     string `json:"password,omitempty" jsonschema_description:"Initial password (8-100 characters). Omit to generate a temporary one"`
+}
+
+type CreateUserOutput struct {
+	User              UserSummary `json:"user"`
+	TemporaryPassword string      `json:"temporary_password,omitempty"`
+}
+
+func createCreateUserHandler(factory *services.Factory) mcp.ToolHandlerFor[CreateUserInput, CreateUserOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateUserInput) (*mcp.CallToolResult, CreateUserOutput, error) {
+		password, generated := input.Password, false
+		if password == "" {
+			password, generated = rand.Text(), true
+		}
+		if len(password) < 8 || len(password) > 100 {
+			return nil, CreateUserOutput{}, fmt.Errorf("password must be 8-100 characters")
+		}
+
+		srv, err := factory.Directory(ctx, input.UserEmail)
+		if err != nil {
+			return nil, CreateUserOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		user, err := srv.Users.Insert(&directory.User{
+			PrimaryEmail:              input.PrimaryEmail,
+			Name:                      &directory.UserName{GivenName: input.GivenName, FamilyName: input.FamilyName},
+			OrgUnitPath:               input.OrgUnitPath,
+			Password:                  password,
+			ChangePasswordAtNextLogin: generated,
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, CreateUserOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := CreateUserOutput{User: userToSummary(user)}
+		rb := response.New()
+		rb.Header("Directory User Created")
+		rb.KeyValue("Email", out.User.PrimaryEmail)
+		rb.KeyValue("ID", out.User.ID)
+		rb.KeyValue("Org unit", out.User.OrgUnitPath)
+		if generated {
+			out.TemporaryPassword = password
+			rb.KeyValue("Temporary password", password)
+			rb.Line("Share the password securely; it is not shown again and must be changed at first sign-in.")
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- suspend_directory_user (extended) ---
+
+type SuspendUserInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The administrator's Google email address"`
+	UserKey   string `json:"user_key" jsonschema:"required" jsonschema_description:"The user's primary email, alias, or unique ID"`
+	Unsuspend bool   `json:"unsuspend,omitempty" jsonschema_description:"Restore a suspended user instead of suspending"`
+}
+
+func createSuspendUserHandler(factory *services.Factory) mcp.ToolHandlerFor[SuspendUserInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SuspendUserInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Directory(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		// Suspended is omitted when false unless forced.
+		patch := &directory.User{Suspended: !input.Unsuspend, ForceSendFields: []string{"Suspended"}}
+		user, err := srv.Users.Patch(input.UserKey, patch).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		if input.Unsuspend {
+			rb.Header("Directory User Unsuspended")
+		} else {
+			rb.Header("Directory User Suspended")
+		}
+		rb.KeyValue("Email", user.PrimaryEmail)
+		rb.KeyValue("Suspended", user.Suspended)
+
+		return rb.TextResult(), nil, nil
+	}
+}
@@ -1,5 +1,6 @@
-// Package auth implements the start_google_auth MCP tool for legacy OAuth 2.0 authentication.
-// This tool is filtered out when MCP_ENABLE_OAUTH21 is true.
+// Package auth implements the start_google_auth and revoke_google_credentials
+// MCP tools for legacy OAuth 2.0 authentication. These tools are filtered out
+// when MCP_ENABLE_OAUTH21 is true.
 package auth
 
 import (
@@ -7,12 +8,16 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	iauth "github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/ptr"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/validate"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
 )
 
 var serviceIcons = []mcp.Icon{{
@@ -21,8 +26,10 @@ var serviceIcons = []mcp.Icon{{
 	Sizes:    []string{"48x48"},
 }}
 
-// Register registers the start_google_auth tool with the MCP server.
-func Register(server *mcp.Server, oauthMgr *iauth.OAuthManager) {
+// Register registers the auth tools with the MCP server. Unless local (the
+// stdio transport, where the only caller is the operator), callers may only
+// revoke the credentials of the identity their bearer token is bound to.
+func Register(server *mcp.Server, oauthMgr *iauth.OAuthManager, factory *services.Factory, local bool) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "start_google_auth",
 		Icons:       serviceIcons,
@@ -32,6 +39,18 @@ func Register(server *mcp.Server, oauthMgr *iauth.OAuthManager) {
 			OpenWorldHint: ptr.Bool(true),
 		},
 	}, createStartAuthHandler(oauthMgr))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "revoke_google_credentials",
+		Icons:       serviceIcons,
+		Description: "Revoke a user's Google OAuth credentials at Google and delete them from this server's token store (e.g. when offboarding). Over HTTP, only the caller's own credentials can be revoked. The user must call start_google_auth again before any further tool use. This action cannot be undone.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Revoke Google Credentials",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createRevokeCredentialsHandler(oauthMgr, factory, local))
 }
 
 type StartAuthInput struct {
@@ -47,8 +66,8 @@ type StartAuthOutput struct {
 
 func createStartAuthHandler(oauthMgr *iauth.OAuthManager) mcp.ToolHandlerFor[StartAuthInput, StartAuthOutput] {
 	return func(ctx context.Context, req *mcp.CallToolRequest, input StartAuthInput) (*mcp.CallToolResult, StartAuthOutput, error) {
-		// Generate auth URL with user email as state
-		authURL := oauthMgr.GetAuthURL(input.UserEmail)
+		// Generate auth URL with a signed, single-use state bound to this session
+		authURL := middleware.AuthURL(oauthMgr, req, input.UserEmail)
 
 		// Duplicate on stderr: stdio MCP uses stdout for the protocol; Cursor and similar clients
 		// often show MCP server stderr in logs while hiding tool output in the chat UI.
@@ -72,3 +91,47 @@ func createStartAuthHandler(oauthMgr *iauth.OAuthManager) mcp.ToolHandlerFor[Sta
 		return rb.TextResult(), StartAuthOutput{AuthURL: authURL, UserEmail: input.UserEmail}, nil
 	}
 }
+
+type RevokeCredentialsInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address whose credentials should be revoked"`
+}
+
+func createRevokeCredentialsHandler(oauthMgr *iauth.OAuthManager, factory *services.Factory, local bool) mcp.ToolHandlerFor[RevokeCredentialsInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input RevokeCredentialsInput) (*mcp.CallToolResult, any, error) {
+		if err := validate.Email(input.UserEmail); err != nil {
+			return nil, nil, fmt.Errorf("invalid user email: %w", err)
+		}
+		if !local {
+			if err := checkRevokeCaller(req, input.UserEmail); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		if err := oauthMgr.RevokeCredentials(ctx, input.UserEmail, iauth.RevokeReasonRequested); err != nil {
+			return nil, nil, err
+		}
+		factory.InvalidateClient(input.UserEmail)
+
+		rb := response.New()
+		rb.Header("Credentials Revoked")
+		rb.KeyValue("User", input.UserEmail)
+		rb.Line("The token was revoked at Google and removed from this server.")
+		rb.Line("Call start_google_auth to authenticate this user again.")
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// checkRevokeCaller allows a revocation only when the request's bearer token
+// is bound to userEmail, so one caller of a shared server cannot sign out
+// another user.
+func checkRevokeCaller(req *mcp.CallToolRequest, userEmail string) error {
+	var caller string
+	if req != nil && req.Extra != nil && req.Extra.TokenInfo != nil {
+		caller = req.Extra.TokenInfo.UserID
+	}
+	if caller == "" || !strings.EqualFold(caller, userEmail) {
+		return fmt.Errorf("revoking credentials for %s requires a bearer token issued to that user — over HTTP, callers can only revoke their own credentials; the operator can revoke others over stdio or let TOKEN_TTL expire them", userEmail)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"testing"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestCheckRevokeCaller(t *testing.T) {
+	withCaller := func(userID string) *mcp.CallToolRequest {
+		return &mcp.CallToolRequest{Extra: &mcp.RequestExtra{TokenInfo: &sdkauth.TokenInfo{UserID: userID}}}
+	}
+
+	tests := []struct {
+		name    string
+		req     *mcp.CallToolRequest
+		wantErr bool
+	}{
+		{"own credentials", withCaller("alice@example.com"), false},
+		{"case-insensitive", withCaller("Alice@Example.com"), false},
+		{"other user", withCaller("bob@example.com"), true},
+		{"static key subject", withCaller("api-key#1"), true},
+		{"no token info", &mcp.CallToolRequest{Extra: &mcp.RequestExtra{}}, true},
+		{"no extra", &mcp.CallToolRequest{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRevokeCaller(tt.req, "alice@example.com")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkRevokeCaller: got err %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package calendar
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// interval is a half-open time span [start, end).
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+func (iv interval) duration() time.Duration { return iv.end.Sub(iv.start) }
+
+// MeetingLoadOptions controls how meeting-load metrics are computed.
+type MeetingLoadOptions struct {
+	Location         *time.Location
+	WorkdayStartHour int
+	WorkdayEndHour   int
+	IncludeWeekends  bool
+	MinFocus         time.Duration     // shortest free gap that counts as focus time
+	BackToBackGap    time.Duration     // max gap between meetings that still counts as back-to-back
+	Holidays         map[string]string // date (2006-01-02) → holiday name; no working hours that day
+}
+
+// WeeklyMeetingLoad summarizes one ISO week (weeks start on Monday).
+type WeeklyMeetingLoad struct {
+	WeekStart         string  `json:"week_start"`
+	MeetingCount      int     `json:"meeting_count"`
+	MeetingHours      float64 `json:"meeting_hours"`
+	FocusHours        float64 `json:"focus_hours"`
+	BackToBackStreaks int     `json:"back_to_back_streaks"`
+}
+
+// MeetingStreak is a run of two or more meetings with no meaningful break between them.
+type MeetingStreak struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Meetings int    `json:"meetings"`
+	Minutes  int    `json:"minutes"`
+}
+
+// FocusGap is an uninterrupted free block inside working hours.
+type FocusGap struct {
+	Start   string `json:"start"`
+	End     string `json:"end"`
+	Minutes int    `json:"minutes"`
+}
+
+// MeetingLoadMetrics is the structured result of analyze_meeting_load.
+type MeetingLoadMetrics struct {
+	MeetingCount           int                 `json:"meeting_count"`
+	MeetingHours           float64             `json:"meeting_hours"`
+	AvgMeetingHoursPerWeek float64             `json:"avg_meeting_hours_per_week"`
+	BackToBackStreaks      int                 `json:"back_to_back_streaks"`
+	LongestStreak          *MeetingStreak      `json:"longest_streak,omitempty"`
+	FocusGapCount          int                 `json:"focus_gap_count"`
+	FocusHours             float64             `json:"focus_hours"`
+	LongestFocusGap        *FocusGap           `json:"longest_focus_gap,omitempty"`
+	Weeks                  []WeeklyMeetingLoad `json:"weeks"`
+}
+
+// meetingIntervals extracts busy, timed meetings from events. All-day events,
+// events the user declined, events marked "free" (transparent), and
+// non-meeting event types (focus time, out of office, working location) are skipped.
+func meetingIntervals(events []*calendar.Event) []interval {
+	out := make([]interval, 0, len(events))
+	for _, e := range events {
+		if e.Start == nil || e.End == nil || e.Start.DateTime == "" || e.End.DateTime == "" {
+			continue
+		}
+		if e.Status == "cancelled" || e.Transparency == "transparent" {
+			continue
+		}
+		switch e.EventType {
+		case "focusTime", "outOfOffice", "workingLocation":
+			continue
+		}
+		if selfDeclined(e) {
+			continue
+		}
+		start, err1 := time.Parse(time.RFC3339, e.Start.DateTime)
+		end, err2 := time.Parse(time.RFC3339, e.End.DateTime)
+		if err1 != nil || err2 != nil || !end.After(start) {
+			continue
+		}
+		out = append(out, interval{start: start, end: end})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].start.Before(out[j].start) })
+	return out
+}
+
+// selfDeclined reports whether the calendar owner declined the event.
+func selfDeclined(e *calendar.Event) bool {
+	for _, a := range e.Attendees {
+		if a.Self && a.ResponseStatus == "declined" {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeIntervals merges overlapping or touching intervals. Input must be sorted by start.
+func mergeIntervals(in []interval) []interval {
+	var out []interval
+	for _, iv := range in {
+		if n := len(out); n > 0 && !iv.start.After(out[n-1].end) {
+			if iv.end.After(out[n-1].end) {
+				out[n-1].end = iv.end
+			}
+			continue
+		}
+		out = append(out, iv)
+	}
+	return out
+}
+
+// workingWindows returns the working-hours window for each day in [from, to),
+// skipping weekends (unless included) and holidays.
+func workingWindows(from, to time.Time, opts MeetingLoadOptions) []interval {
+	var out []interval
+	local := from.In(opts.Location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, opts.Location)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !opts.IncludeWeekends && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday) {
+			continue
+		}
+		if opts.Holidays[day.Format(time.DateOnly)] != "" {
+			continue
+		}
+		ws := time.Date(day.Year(), day.Month(), day.Day(), opts.WorkdayStartHour, 0, 0, 0, opts.Location)
+		we := time.Date(day.Year(), day.Month(), day.Day(), opts.WorkdayEndHour, 0, 0, 0, opts.Location)
+		if ws.Before(from) {
+			ws = from
+		}
+		if we.After(to) {
+			we = to
+		}
+		if we.After(ws) {
+			out = append(out, interval{start: ws, end: we})
+		}
+	}
+	return out
+}
+
+// freeGaps returns the portions of each window not covered by busy that are at
+// least minLen long. busy must be merged and sorted.
+func freeGaps(busy, windows []interval, minLen time.Duration) []interval {
+	var out []interval
+	for _, w := range windows {
+		cursor := w.start
+		for _, b := range busy {
+			if !b.end.After(cursor) || !b.start.Before(w.end) {
+				continue
+			}
+			if b.start.After(cursor) && b.start.Sub(cursor) >= minLen {
+				out = append(out, interval{start: cursor, end: b.start})
+			}
+			if b.end.After(cursor) {
+				cursor = b.end
+			}
+		}
+		if w.end.Sub(cursor) >= minLen {
+			out = append(out, interval{start: cursor, end: w.end})
+		}
+	}
+	return out
+}
+
+// meetingStreaks groups sorted meetings into back-to-back runs of two or more.
+func meetingStreaks(meetings []interval, gap time.Duration) []MeetingStreak {
+	var out []MeetingStreak
+	i := 0
+	for i < len(meetings) {
+		run := interval{start: meetings[i].start, end: meetings[i].end}
+		count := 1
+		j := i + 1
+		for ; j < len(meetings) && meetings[j].start.Sub(run.end) <= gap; j++ {
+			if meetings[j].end.After(run.end) {
+				run.end = meetings[j].end
+			}
+			count++
+		}
+		if count >= 2 {
+			out = append(out, MeetingStreak{
+				Start:    run.start.Format(time.RFC3339),
+				End:      run.end.Format(time.RFC3339),
+				Meetings: count,
+				Minutes:  int(run.duration().Minutes()),
+			})
+		}
+		i = j
+	}
+	return out
+}
+
+// weekStart returns midnight on the Monday of t's week in loc.
+func weekStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	offset := (int(t.Weekday()) + 6) % 7 // Monday = 0
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, loc)
+}
+
+// analyzeMeetingLoad computes meeting-load metrics for events within [from, to).
+func analyzeMeetingLoad(events []*calendar.Event, from, to time.Time, opts MeetingLoadOptions) MeetingLoadMetrics {
+	meetings := meetingIntervals(events)
+	busy := mergeIntervals(meetings)
+	gaps := freeGaps(busy, workingWindows(from, to, opts), opts.MinFocus)
+	streaks := meetingStreaks(meetings, opts.BackToBackGap)
+
+	weeks := make(map[time.Time]*WeeklyMeetingLoad)
+	var order []time.Time
+	for ws := weekStart(from, opts.Location); ws.Before(to); ws = ws.AddDate(0, 0, 7) {
+		weeks[ws] = &WeeklyMeetingLoad{WeekStart: ws.Format("2006-01-02")}
+		order = append(order, ws)
+	}
+	week := func(t time.Time) *WeeklyMeetingLoad {
+		return weeks[weekStart(t, opts.Location)]
+	}
+
+	m := MeetingLoadMetrics{MeetingCount: len(meetings), BackToBackStreaks: len(streaks), FocusGapCount: len(gaps)}
+	for _, mt := range meetings {
+		if w := week(mt.start); w != nil {
+			w.MeetingCount++
+		}
+	}
+	for _, b := range busy {
+		m.MeetingHours += b.duration().Hours()
+		if w := week(b.start); w != nil {
+			w.MeetingHours += b.duration().Hours()
+		}
+	}
+	for i := range streaks {
+		start, _ := time.Parse(time.RFC3339, streaks[i].Start)
+		if w := week(start); w != nil {
+			w.BackToBackStreaks++
+		}
+		if m.LongestStreak == nil || streaks[i].Minutes > m.LongestStreak.Minutes {
+			m.LongestStreak = &streaks[i]
+		}
+	}
+	for _, g := range gaps {
+		m.FocusHours += g.duration().Hours()
+		if w := week(g.start); w != nil {
+			w.FocusHours += g.duration().Hours()
+		}
+	}
+	m.LongestFocusGap = longestGap(gaps, opts.Location)
+
+	m.MeetingHours = round2(m.MeetingHours)
+	m.FocusHours = round2(m.FocusHours)
+	if len(order) > 0 {
+		m.AvgMeetingHoursPerWeek = round2(m.MeetingHours / float64(len(order)))
+	}
+	m.Weeks = make([]WeeklyMeetingLoad, 0, len(order))
+	for _, ws := range order {
+		w := weeks[ws]
+		w.MeetingHours = round2(w.MeetingHours)
+		w.FocusHours = round2(w.FocusHours)
+		m.Weeks = append(m.Weeks, *w)
+	}
+	return m
+}
+
+// longestGap returns the longest free gap, or nil when there are none.
+func longestGap(gaps []interval, loc *time.Location) *FocusGap {
+	var best *interval
+	for i := range gaps {
+		if best == nil || gaps[i].duration() > best.duration() {
+			best = &gaps[i]
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return &FocusGap{
+		Start:   best.start.In(loc).Format(time.RFC3339),
+		End:     best.end.In(loc).Format(time.RFC3339),
+		Minutes: int(best.duration().Minutes()),
+	}
+}
+
+// round2 rounds to two decimal places for readable hour figures.
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,132 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	gcal "google.golang.org/api/calendar/v3"
+)
+
+func timedEvent(start, end string) *gcal.Event {
+	return &gcal.Event{
+		Start: &gcal.EventDateTime{DateTime: start},
+		End:   &gcal.EventDateTime{DateTime: end},
+	}
+}
+
+func TestMeetingIntervals_Filters(t *testing.T) {
+	declined := timedEvent("2025-06-02T10:00:00Z", "2025-06-02T11:00:00Z")
+	declined.Attendees = []*gcal.EventAttendee{{Email: "me@example.com", Self: true, ResponseStatus: "declined"}}
+	free := timedEvent("2025-06-02T12:00:00Z", "2025-06-02T13:00:00Z")
+	free.Transparency = "transparent"
+	focus := timedEvent("2025-06-02T14:00:00Z", "2025-06-02T15:00:00Z")
+	focus.EventType = "focusTime"
+	allDay := &gcal.Event{Start: &gcal.EventDateTime{Date: "2025-06-02"}, End: &gcal.EventDateTime{Date: "2025-06-03"}}
+	kept := timedEvent("2025-06-02T09:00:00Z", "2025-06-02T09:30:00Z")
+
+	got := meetingIntervals([]*gcal.Event{declined, free, focus, allDay, kept})
+	if len(got) != 1 {
+		t.Fatalf("expected 1 meeting, got %d", len(got))
+	}
+	if got[0].duration() != 30*time.Minute {
+		t.Errorf("duration = %v, want 30m", got[0].duration())
+	}
+}
+
+func TestMergeIntervals(t *testing.T) {
+	base := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	in := []interval{
+		{base, base.Add(time.Hour)},
+		{base.Add(30 * time.Minute), base.Add(90 * time.Minute)},
+		{base.Add(90 * time.Minute), base.Add(2 * time.Hour)},
+		{base.Add(3 * time.Hour), base.Add(4 * time.Hour)},
+	}
+	got := mergeIntervals(in)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 merged intervals, got %d", len(got))
+	}
+	if got[0].duration() != 2*time.Hour {
+		t.Errorf("first merged duration = %v, want 2h", got[0].duration())
+	}
+}
+
+func TestMeetingStreaks(t *testing.T) {
+	base := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	meetings := []interval{
+		{base, base.Add(30 * time.Minute)},
+		{base.Add(35 * time.Minute), base.Add(time.Hour)},
+		{base.Add(time.Hour), base.Add(90 * time.Minute)},
+		{base.Add(3 * time.Hour), base.Add(4 * time.Hour)},
+	}
+
+	got := meetingStreaks(meetings, 5*time.Minute)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 streak, got %d", len(got))
+	}
+	if got[0].Meetings != 3 || got[0].Minutes != 90 {
+		t.Errorf("streak = %+v, want 3 meetings / 90 min", got[0])
+	}
+}
+
+func TestAnalyzeMeetingLoad(t *testing.T) {
+	// Monday 2025-06-02 through Monday 2025-06-09, UTC, 9-17 working hours.
+	from := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)
+	opts := MeetingLoadOptions{
+		Location:         time.UTC,
+		WorkdayStartHour: 9,
+		WorkdayEndHour:   17,
+		MinFocus:         time.Hour,
+		BackToBackGap:    5 * time.Minute,
+	}
+
+	events := []*gcal.Event{
+		timedEvent("2025-06-02T09:00:00Z", "2025-06-02T10:00:00Z"),
+		timedEvent("2025-06-02T10:00:00Z", "2025-06-02T11:00:00Z"),
+		timedEvent("2025-06-02T10:30:00Z", "2025-06-02T11:00:00Z"), // overlaps; hours not double-counted
+		timedEvent("2025-06-03T13:00:00Z", "2025-06-03T14:00:00Z"),
+	}
+
+	m := analyzeMeetingLoad(events, from, to, opts)
+
+	if m.MeetingCount != 4 {
+		t.Errorf("MeetingCount = %d, want 4", m.MeetingCount)
+	}
+	if m.MeetingHours != 3 {
+		t.Errorf("MeetingHours = %v, want 3", m.MeetingHours)
+	}
+	if m.BackToBackStreaks != 1 {
+		t.Errorf("BackToBackStreaks = %d, want 1", m.BackToBackStreaks)
+	}
+	if len(m.Weeks) != 1 || m.Weeks[0].WeekStart != "2025-06-02" {
+		t.Fatalf("Weeks = %+v, want a single week starting 2025-06-02", m.Weeks)
+	}
+	// 5 workdays x 8h = 40h, minus 3h of meetings = 37h of free time, all in gaps >= 1h.
+	if m.FocusHours != 37 {
+		t.Errorf("FocusHours = %v, want 37", m.FocusHours)
+	}
+	// Mon 11-17, Tue 9-13 and 14-17, Wed/Thu/Fri 9-17.
+	if m.FocusGapCount != 6 {
+		t.Errorf("FocusGapCount = %d, want 6", m.FocusGapCount)
+	}
+	if m.LongestFocusGap == nil || m.LongestFocusGap.Minutes != 480 {
+		t.Errorf("LongestFocusGap = %+v, want 480 min", m.LongestFocusGap)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		in   time.Time
+		want string
+	}{
+		{time.Date(2025, 6, 2, 15, 0, 0, 0, time.UTC), "2025-06-02"}, // Monday
+		{time.Date(2025, 6, 8, 23, 0, 0, 0, time.UTC), "2025-06-02"}, // Sunday
+		{time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), "2025-05-26"}, // previous Sunday
+	}
+	for _, tt := range tests {
+		got := weekStart(tt.in, time.UTC).Format("2006-01-02")
+		if got != tt.want {
+			t.Errorf("weekStart(%v) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
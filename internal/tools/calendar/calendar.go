@@ -3,6 +3,7 @@ package calendar
 import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/ptr"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
 )
@@ -13,8 +14,10 @@ var serviceIcons = []mcp.Icon{{
 	Sizes:    []string{"48x48"},
 }}
 
-// Register registers all core Calendar tools with the MCP server.
-func Register(server *mcp.Server, factory *services.Factory) {
+// Register registers all core Calendar tools with the MCP server, and
+// events as gcal://{user}/event/{id} resources.
+func Register(server *mcp.Server, factory *services.Factory, res *middleware.Resources) {
+	registerResources(server, factory, res)
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "list_calendars",
 		Icons:       serviceIcons,
@@ -40,7 +43,7 @@ func Register(server *mcp.Server, factory *services.Factory) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "create_event",
 		Icons:       serviceIcons,
-		Description: "Create a new calendar event with optional attendees, location, reminders, and Google Meet link.",
+		Description: "Create a new calendar event with optional attendees, location, reminders, and Google Meet link. Meetings with attendees are refused outside the user's working hours or on public holidays unless allow_outside_working_hours is set.",
 		Annotations: &mcp.ToolAnnotations{
 			Title:         "Create Calendar Event",
 			OpenWorldHint: ptr.Bool(true),
@@ -81,4 +84,115 @@ func Register(server *mcp.Server, factory *services.Factory) {
 			OpenWorldHint: ptr.Bool(true),
 		},
 	}, createQueryFreeBusyHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "analyze_meeting_load",
+		Icons:       serviceIcons,
+		Description: "Analyze meeting load over a period: hours in meetings per week, back-to-back meeting streaks, and focus-time gaps within working hours. Declined, free (transparent), all-day, and focus/out-of-office events are excluded. Returns structured metrics ready to narrate.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Analyze Meeting Load",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createAnalyzeMeetingLoadHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "schedule_focus_time",
+		Icons:       serviceIcons,
+		Description: "Find free gaps within working hours and book focus-time blocks on the primary calendar to reach a weekly target (e.g. 2 × 2h per week). Existing focus-time events count towards the target; blocks are spread across days. Focus-time events auto-decline conflicting invitations where the account supports them, otherwise plain busy holds are created. Use dry_run to preview.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Schedule Focus Time",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createScheduleFocusTimeHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "attach_doc_to_event",
+		Icons:       serviceIcons,
+		Description: "Attach a Drive file (e.g. meeting notes Doc) to a calendar event so attendees see it in the event. Already-attached files are left unchanged.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Attach Document to Event",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createAttachDocToEventHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_event_attachments",
+		Icons:       serviceIcons,
+		Description: "Find the documents linked to meetings — event attachments plus Docs/Sheets/Slides/Drive links in the description — for one event or for recent events matching a search. Answers \"where are the notes for yesterday's sync?\" in one call.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Event Attachments",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetEventAttachmentsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "subscribe_calendar",
+		Icons:       serviceIcons,
+		Description: "Subscribe the user to a calendar: a public holidays calendar by country code (holiday_region), a Google Calendar ICS/embed URL, or a calendar ID. Non-Google ICS feeds cannot be added through the API; the error names the page where the user can add them.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Subscribe to Calendar",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createSubscribeCalendarHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_calendar_subscriptions",
+		Icons:       serviceIcons,
+		Description: "List the read-only calendars the user is subscribed to, such as holiday and other people's public calendars.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Calendar Subscriptions",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListCalendarSubscriptionsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "unsubscribe_calendar",
+		Icons:       serviceIcons,
+		Description: "Remove a subscribed read-only calendar from the user's calendar list. The calendar itself is not affected and can be subscribed to again. Owned and editable calendars are refused.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Unsubscribe from Calendar",
+			DestructiveHint: ptr.Bool(false),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createUnsubscribeCalendarHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "is_working_time",
+		Icons:       serviceIcons,
+		Description: "Check whether a time (and optionally a meeting of a given length) falls within the user's working hours, in their calendar timezone, and is not a weekend or public holiday from their holiday calendars. Returns the reason and the next working time when it is not.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Is Working Time",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createIsWorkingTimeHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "next_working_slot",
+		Icons:       serviceIcons,
+		Description: "Find the next free slot of a given length on the primary calendar that lies within working hours and avoids weekends and public holidays. Use it before booking meetings.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Next Working Slot",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createNextWorkingSlotHandler(factory))
+
+	// --- Complete tools ---
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_events_to_sheet",
+		Icons:       serviceIcons,
+		Description: "Export calendar events in a date range from one or more calendars to a Google Sheet, one row per event with attendees, duration, and Meet link. Creates a new spreadsheet unless spreadsheet_id is given. Useful for time-tracking and meeting-load analysis.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Export Events to Sheet",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createExportEventsToSheetHandler(factory))
 }
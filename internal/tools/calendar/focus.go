@@ -0,0 +1,114 @@
+package calendar
+
+import (
+	"sort"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// focusWeek is the scheduling plan for one week of schedule_focus_time.
+type focusWeek struct {
+	start    time.Time
+	existing int        // focus-time events already on the calendar
+	planned  []interval // new blocks to create
+}
+
+// busyIntervals returns every timed interval that blocks the calendar owner,
+// including existing focus-time and out-of-office events (unlike
+// meetingIntervals, which only counts meetings). The result is sorted and merged.
+func busyIntervals(events []*calendar.Event) []interval {
+	var out []interval
+	for _, e := range events {
+		if e.Start == nil || e.End == nil || e.Start.DateTime == "" || e.End.DateTime == "" {
+			continue
+		}
+		if e.Status == "cancelled" || e.Transparency == "transparent" || e.EventType == "workingLocation" {
+			continue
+		}
+		if selfDeclined(e) {
+			continue
+		}
+		start, err1 := time.Parse(time.RFC3339, e.Start.DateTime)
+		end, err2 := time.Parse(time.RFC3339, e.End.DateTime)
+		if err1 != nil || err2 != nil || !end.After(start) {
+			continue
+		}
+		out = append(out, interval{start: start, end: end})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].start.Before(out[j].start) })
+	return mergeIntervals(out)
+}
+
+// existingFocusBlocks counts focus-time events at least minLen long, keyed by week start.
+func existingFocusBlocks(events []*calendar.Event, minLen time.Duration, loc *time.Location) map[time.Time]int {
+	out := make(map[time.Time]int)
+	for _, e := range events {
+		if e.EventType != "focusTime" || e.Status == "cancelled" || e.Start == nil || e.End == nil {
+			continue
+		}
+		start, err1 := time.Parse(time.RFC3339, e.Start.DateTime)
+		end, err2 := time.Parse(time.RFC3339, e.End.DateTime)
+		if err1 != nil || err2 != nil || end.Sub(start) < minLen {
+			continue
+		}
+		out[weekStart(start, loc)]++
+	}
+	return out
+}
+
+// planFocusTime picks up to perWeek blocks of length block in each week of
+// [from, to), counting existing focus-time events towards the target.
+func planFocusTime(events []*calendar.Event, from, to time.Time, block time.Duration, perWeek int, opts MeetingLoadOptions) []focusWeek {
+	busy := busyIntervals(events)
+	existing := existingFocusBlocks(events, block, opts.Location)
+
+	var weeks []focusWeek
+	for ws := weekStart(from, opts.Location); ws.Before(to); ws = ws.AddDate(0, 0, 7) {
+		wFrom, wTo := ws, ws.AddDate(0, 0, 7)
+		if wFrom.Before(from) {
+			wFrom = from
+		}
+		if wTo.After(to) {
+			wTo = to
+		}
+		fw := focusWeek{start: ws, existing: existing[ws]}
+		if need := perWeek - fw.existing; need > 0 {
+			fw.planned = pickFocusBlocks(busy, workingWindows(wFrom, wTo, opts), block, need, opts.Location)
+		}
+		weeks = append(weeks, fw)
+	}
+	return weeks
+}
+
+// pickFocusBlocks places need blocks into free gaps, preferring at most one
+// block per day so focus time is spread across the week, then filling any
+// shortfall from the remaining free time. Blocks start at the beginning of a gap.
+func pickFocusBlocks(busy, windows []interval, block time.Duration, need int, loc *time.Location) []interval {
+	var picked []interval
+	usedDay := make(map[string]bool)
+	for _, g := range freeGaps(busy, windows, block) {
+		if len(picked) == need {
+			return picked
+		}
+		day := g.start.In(loc).Format("2006-01-02")
+		if usedDay[day] {
+			continue
+		}
+		usedDay[day] = true
+		picked = append(picked, interval{start: g.start, end: g.start.Add(block)})
+	}
+	if len(picked) == need {
+		return picked
+	}
+
+	taken := append(append([]interval(nil), busy...), picked...)
+	sort.Slice(taken, func(i, j int) bool { return taken[i].start.Before(taken[j].start) })
+	for _, g := range freeGaps(mergeIntervals(taken), windows, block) {
+		for start := g.start; !start.Add(block).After(g.end) && len(picked) < need; start = start.Add(block) {
+			picked = append(picked, interval{start: start, end: start.Add(block)})
+		}
+	}
+	sort.Slice(picked, func(i, j int) bool { return picked[i].start.Before(picked[j].start) })
+	return picked
+}
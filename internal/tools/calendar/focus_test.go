@@ -0,0 +1,125 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	gcal "google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+)
+
+func TestPlanFocusTime(t *testing.T) {
+	opts := MeetingLoadOptions{Location: time.UTC, WorkdayStartHour: 9, WorkdayEndHour: 17}
+	// Monday 2025-06-02 through Friday 2025-06-06.
+	from := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		events     []*gcal.Event
+		perWeek    int
+		wantBlocks int
+		wantFirst  string
+	}{
+		{
+			name:       "empty calendar spreads blocks over days",
+			perWeek:    2,
+			wantBlocks: 2,
+			wantFirst:  "2025-06-02T09:00:00Z",
+		},
+		{
+			name: "skips busy morning",
+			events: []*gcal.Event{
+				timedEvent("2025-06-02T09:00:00Z", "2025-06-02T12:00:00Z"),
+			},
+			perWeek:    1,
+			wantBlocks: 1,
+			wantFirst:  "2025-06-02T12:00:00Z",
+		},
+		{
+			name: "existing focus time counts towards target",
+			events: []*gcal.Event{func() *gcal.Event {
+				e := timedEvent("2025-06-03T09:00:00Z", "2025-06-03T11:00:00Z")
+				e.EventType = "focusTime"
+				return e
+			}()},
+			perWeek:    2,
+			wantBlocks: 1,
+			wantFirst:  "2025-06-02T09:00:00Z",
+		},
+		{
+			name: "declined meetings do not block",
+			events: []*gcal.Event{func() *gcal.Event {
+				e := timedEvent("2025-06-02T09:00:00Z", "2025-06-02T17:00:00Z")
+				e.Attendees = []*gcal.EventAttendee{{Self: true, ResponseStatus: "declined"}}
+				return e
+			}()},
+			perWeek:    1,
+			wantBlocks: 1,
+			wantFirst:  "2025-06-02T09:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weeks := planFocusTime(tt.events, from, to, 2*time.Hour, tt.perWeek, opts)
+			if len(weeks) != 1 {
+				t.Fatalf("expected 1 week, got %d", len(weeks))
+			}
+			got := weeks[0].planned
+			if len(got) != tt.wantBlocks {
+				t.Fatalf("expected %d blocks, got %d", tt.wantBlocks, len(got))
+			}
+			if first := got[0].start.Format(time.RFC3339); first != tt.wantFirst {
+				t.Errorf("first block starts %s, want %s", first, tt.wantFirst)
+			}
+			if len(got) > 1 && got[0].start.YearDay() == got[1].start.YearDay() {
+				t.Error("expected blocks on different days")
+			}
+		})
+	}
+}
+
+func TestPickFocusBlocks_FillsShortfallOnSameDay(t *testing.T) {
+	// A single working day with room for three 2h blocks.
+	day := interval{
+		start: time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC),
+		end:   time.Date(2025, 6, 2, 15, 0, 0, 0, time.UTC),
+	}
+
+	got := pickFocusBlocks(nil, []interval{day}, 2*time.Hour, 3, time.UTC)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(got))
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].start.Before(got[i-1].end) {
+			t.Errorf("blocks %d and %d overlap", i-1, i)
+		}
+	}
+}
+
+func TestFocusTimeUnsupported(t *testing.T) {
+	restricted := &googleapi.Error{Code: 400, Errors: []googleapi.ErrorItem{{Reason: "eventTypeRestriction"}}}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"event type restriction", restricted, true},
+		{"wrapped", fmt.Errorf("insert: %w", restricted), true},
+		{"other bad request", &googleapi.Error{Code: 400, Errors: []googleapi.ErrorItem{{Reason: "invalid"}}}, false},
+		{"bad request without reason", &googleapi.Error{Code: 400}, false},
+		{"forbidden", &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "eventTypeRestriction"}}}, false},
+		{"not an API error", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := focusTimeUnsupported(tt.err); got != tt.want {
+				t.Errorf("focusTimeUnsupported = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
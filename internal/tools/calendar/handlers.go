@@ -3,6 +3,7 @@ package calendar
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"google.golang.org/api/calendar/v3"
@@ -171,6 +172,8 @@ type CreateEventInput struct {
 	Timezone    string   `json:"timezone,omitempty" jsonschema_description:"Timezone (e.g. America/New_York)"`
 	Reminders   string   `json:"reminders,omitempty" jsonschema_description:"JSON array of reminders [{method: popup/email, minutes: N}]"`
 	AddMeet     bool     `json:"add_google_meet,omitempty" jsonschema_description:"Add a Google Meet video conference"`
+
+	AllowOutsideWorkingHours bool `json:"allow_outside_working_hours,omitempty" jsonschema_description:"Book a meeting with attendees outside the user's working hours or on a public holiday. Only set after the user confirms the time"`
 }
 
 func createCreateEventHandler(factory *services.Factory) mcp.ToolHandlerFor[CreateEventInput, any] {
@@ -184,6 +187,11 @@ func createCreateEventHandler(factory *services.Factory) mcp.ToolHandlerFor[Crea
 		if calID == "" {
 			calID = "primary"
 		}
+		if len(input.Attendees) > 0 && !input.AllowOutsideWorkingHours {
+			if err := checkMeetingTime(ctx, srv, input); err != nil {
+				return nil, nil, err
+			}
+		}
 
 		event := &calendar.Event{
 			Summary:     input.Summary,
@@ -217,6 +225,10 @@ func createCreateEventHandler(factory *services.Factory) mcp.ToolHandlerFor[Crea
 			}
 		}
 
+		if stamp := factory.Provenance(req); stamp != nil {
+			event.ExtendedProperties = &calendar.EventExtendedProperties{Private: stamp.Properties()}
+		}
+
 		call := srv.Events.Insert(calID, event).Context(ctx)
 		if input.AddMeet {
 			call = call.ConferenceDataVersion(1)
@@ -248,6 +260,24 @@ func createCreateEventHandler(factory *services.Factory) mcp.ToolHandlerFor[Crea
 	}
 }
 
+// checkMeetingTime refuses a timed meeting outside the user's working hours
+// or on a public holiday. All-day events are not checked.
+func checkMeetingTime(ctx context.Context, srv *calendar.Service, input CreateEventInput) error {
+	start, err1 := time.Parse(time.RFC3339, input.StartTime)
+	end, err2 := time.Parse(time.RFC3339, input.EndTime)
+	if err1 != nil || err2 != nil || !end.After(start) {
+		return nil
+	}
+	opts, err := workingHours(ctx, srv, workingHoursParams{Timezone: input.Timezone}, start, end.AddDate(0, 0, 1))
+	if err != nil {
+		return middleware.HandleGoogleAPIError(err)
+	}
+	if ok, reason := fitsWorkingHours(interval{start: start, end: end}, opts); !ok {
+		return fmt.Errorf("meeting not booked — its time is not working time: %s. Find a time with next_working_slot, or set allow_outside_working_hours=true if the user confirms this time", reason)
+	}
+	return nil
+}
+
 // --- modify_event ---
 
 type ModifyEventInput struct {
@@ -0,0 +1,167 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- export_events_to_sheet (complete) ---
+
+type ExportEventsToSheetInput struct {
+	UserEmail     string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	TimeMin       string   `json:"time_min" jsonschema:"required" jsonschema_description:"Start of time range (RFC3339 e.g. 2025-06-01T00:00:00Z)"`
+	TimeMax       string   `json:"time_max" jsonschema:"required" jsonschema_description:"End of time range (RFC3339)"`
+	CalendarIDs   []string `json:"calendar_ids,omitempty" jsonschema_description:"Calendar IDs to export (default: primary)"`
+	SpreadsheetID string   `json:"spreadsheet_id,omitempty" jsonschema_description:"Existing spreadsheet to write into. If omitted a new spreadsheet is created."`
+	SheetName     string   `json:"sheet_name,omitempty" jsonschema_description:"Sheet tab to write rows to (default: Events). Created if missing; its existing values are cleared before writing."`
+	Title         string   `json:"title,omitempty" jsonschema_description:"Title for a newly created spreadsheet (default: Calendar export <time_min> – <time_max>)"`
+}
+
+func createExportEventsToSheetHandler(factory *services.Factory) mcp.ToolHandlerFor[ExportEventsToSheetInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ExportEventsToSheetInput) (*mcp.CallToolResult, any, error) {
+		calSrv, err := factory.Calendar(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		sheetsSrv, err := factory.Sheets(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		calIDs := input.CalendarIDs
+		if len(calIDs) == 0 {
+			calIDs = []string{"primary"}
+		}
+		sheetName := input.SheetName
+		if sheetName == "" {
+			sheetName = "Events"
+		}
+
+		rows := [][]any{eventSheetHeader}
+		p := progress.New(ctx, req).Begin("Reading calendar", len(calIDs))
+		for _, calID := range calIDs {
+			if err := p.Next(); err != nil {
+				return nil, nil, fmt.Errorf("%w — nothing was exported", err)
+			}
+			events, err := listEventsInRange(ctx, calSrv, calID, input.TimeMin, input.TimeMax)
+			if err != nil {
+				return nil, nil, middleware.HandleGoogleAPIError(err)
+			}
+			for _, e := range events {
+				rows = append(rows, eventToSheetRow(calID, e))
+			}
+		}
+
+		p.Begin("Writing sheet", 1)
+		if err := p.Next(); err != nil {
+			return nil, nil, fmt.Errorf("%w — nothing was exported", err)
+		}
+		spreadsheetID, spreadsheetURL, err := prepareExportSheet(ctx, sheetsSrv, input, sheetName)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		if input.SpreadsheetID == "" {
+			factory.StampDriveFile(ctx, input.UserEmail, spreadsheetID, factory.Provenance(req))
+		}
+
+		// Clear the tab first so a shorter export leaves no rows from an
+		// earlier one behind.
+		sheet := quoteSheet(sheetName)
+		_, err = sheetsSrv.Spreadsheets.Values.Clear(spreadsheetID, sheet, &sheets.ClearValuesRequest{}).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		_, err = sheetsSrv.Spreadsheets.Values.Update(spreadsheetID, sheet+"!A1", &sheets.ValueRange{
+			Values: rows,
+		}).ValueInputOption("RAW").Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		p.Done()
+
+		rb := response.New()
+		rb.Header("Events Exported to Sheet")
+		rb.KeyValue("Time Range", fmt.Sprintf("%s → %s", input.TimeMin, input.TimeMax))
+		rb.KeyValue("Calendars", len(calIDs))
+		rb.KeyValue("Events", len(rows)-1)
+		rb.KeyValue("Spreadsheet ID", spreadsheetID)
+		rb.KeyValue("Sheet", sheetName)
+		if spreadsheetURL != "" {
+			rb.KeyValue("URL", spreadsheetURL)
+		}
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// listEventsInRange returns all expanded event instances on a calendar within
+// the given range, following pagination.
+func listEventsInRange(ctx context.Context, srv *calendar.Service, calID, timeMin, timeMax string) ([]*calendar.Event, error) {
+	var events []*calendar.Event
+	call := srv.Events.List(calID).
+		TimeMin(timeMin).
+		TimeMax(timeMax).
+		SingleEvents(true).
+		OrderBy("startTime").
+		MaxResults(2500)
+
+	err := call.Pages(ctx, func(page *calendar.Events) error {
+		events = append(events, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing events on calendar %s: %w", calID, err)
+	}
+	return events, nil
+}
+
+// prepareExportSheet returns the target spreadsheet ID and URL. It creates a
+// new spreadsheet when none is given, or adds the sheet tab to an existing
+// spreadsheet if it does not exist yet.
+func prepareExportSheet(ctx context.Context, srv *sheets.Service, input ExportEventsToSheetInput, sheetName string) (string, string, error) {
+	if input.SpreadsheetID == "" {
+		title := input.Title
+		if title == "" {
+			title = fmt.Sprintf("Calendar export %s – %s", input.TimeMin, input.TimeMax)
+		}
+		created, err := srv.Spreadsheets.Create(&sheets.Spreadsheet{
+			Properties: &sheets.SpreadsheetProperties{Title: title},
+			Sheets: []*sheets.Sheet{
+				{Properties: &sheets.SheetProperties{Title: sheetName}},
+			},
+		}).Context(ctx).Do()
+		if err != nil {
+			return "", "", fmt.Errorf("creating export spreadsheet: %w", err)
+		}
+		return created.SpreadsheetId, created.SpreadsheetUrl, nil
+	}
+
+	existing, err := srv.Spreadsheets.Get(input.SpreadsheetID).Fields("spreadsheetUrl,sheets.properties.title").Context(ctx).Do()
+	if err != nil {
+		return "", "", fmt.Errorf("getting spreadsheet %s: %w", input.SpreadsheetID, err)
+	}
+	for _, s := range existing.Sheets {
+		if s.Properties != nil && s.Properties.Title == sheetName {
+			return input.SpreadsheetID, existing.SpreadsheetUrl, nil
+		}
+	}
+
+	_, err = srv.Spreadsheets.BatchUpdate(input.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: sheetName}}},
+		},
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", "", fmt.Errorf("adding sheet %q: %w", sheetName, err)
+	}
+	return input.SpreadsheetID, existing.SpreadsheetUrl, nil
+}
@@ -0,0 +1,513 @@
+package calendar
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/provenance"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/rollback"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- analyze_meeting_load (extended) ---
+
+type AnalyzeMeetingLoadInput struct {
+	UserEmail        string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	TimeMin          string `json:"time_min" jsonschema:"required" jsonschema_description:"Start of the analysis period (RFC3339 e.g. 2025-06-02T00:00:00Z)"`
+	TimeMax          string `json:"time_max" jsonschema:"required" jsonschema_description:"End of the analysis period (RFC3339)"`
+	CalendarID       string `json:"calendar_id,omitempty" jsonschema_description:"Calendar ID (default: primary)"`
+	Timezone         string `json:"timezone,omitempty" jsonschema_description:"IANA timezone for working hours and week boundaries (default: the calendar's timezone)"`
+	WorkdayStartHour int    `json:"workday_start_hour,omitempty" jsonschema_description:"Start of the working day, 0-23 (default 9)"`
+	WorkdayEndHour   int    `json:"workday_end_hour,omitempty" jsonschema_description:"End of the working day, 1-24 (default 17)"`
+	IncludeWeekends  bool   `json:"include_weekends,omitempty" jsonschema_description:"Treat Saturday and Sunday as working days when computing focus gaps"`
+	MinFocusMinutes  int    `json:"min_focus_minutes,omitempty" jsonschema_description:"Shortest free gap inside working hours that counts as focus time (default 60)"`
+	BackToBackMins   int    `json:"back_to_back_gap_minutes,omitempty" jsonschema_description:"Maximum break between meetings that still counts as back-to-back (default 5)"`
+}
+
+type AnalyzeMeetingLoadOutput struct {
+	CalendarID string             `json:"calendar_id"`
+	TimeZone   string             `json:"time_zone"`
+	TimeMin    string             `json:"time_min"`
+	TimeMax    string             `json:"time_max"`
+	Metrics    MeetingLoadMetrics `json:"metrics"`
+}
+
+func createAnalyzeMeetingLoadHandler(factory *services.Factory) mcp.ToolHandlerFor[AnalyzeMeetingLoadInput, AnalyzeMeetingLoadOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input AnalyzeMeetingLoadInput) (*mcp.CallToolResult, AnalyzeMeetingLoadOutput, error) {
+		from, to, err := parseTimeRange(input.TimeMin, input.TimeMax)
+		if err != nil {
+			return nil, AnalyzeMeetingLoadOutput{}, err
+		}
+
+		srv, err := factory.Calendar(ctx, input.UserEmail)
+		if err != nil {
+			return nil, AnalyzeMeetingLoadOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		calID := input.CalendarID
+		if calID == "" {
+			calID = "primary"
+		}
+
+		tz, err := calendarTimeZone(ctx, srv, calID, input.Timezone)
+		if err != nil {
+			return nil, AnalyzeMeetingLoadOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		opts, err := meetingLoadOptions(input, tz)
+		if err != nil {
+			return nil, AnalyzeMeetingLoadOutput{}, err
+		}
+
+		events, err := listEventsInRange(ctx, srv, calID, input.TimeMin, input.TimeMax)
+		if err != nil {
+			return nil, AnalyzeMeetingLoadOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		metrics := analyzeMeetingLoad(events, from, to, opts)
+
+		rb := response.New()
+		rb.Header("Meeting Load Analysis")
+		rb.KeyValue("Calendar", calID)
+		rb.KeyValue("Period", fmt.Sprintf("%s → %s (%s)", input.TimeMin, input.TimeMax, opts.Location))
+		formatMeetingLoad(rb, metrics)
+
+		return rb.TextResult(), AnalyzeMeetingLoadOutput{
+			CalendarID: calID,
+			TimeZone:   opts.Location.String(),
+			TimeMin:    input.TimeMin,
+			TimeMax:    input.TimeMax,
+			Metrics:    metrics,
+		}, nil
+	}
+}
+
+// parseTimeRange parses an RFC3339 time range and checks that it is non-empty.
+func parseTimeRange(timeMin, timeMax string) (time.Time, time.Time, error) {
+	from, err := time.Parse(time.RFC3339, timeMin)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time_min %q — expected RFC3339 (e.g. 2025-06-02T00:00:00Z): %w", timeMin, err)
+	}
+	to, err := time.Parse(time.RFC3339, timeMax)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time_max %q — expected RFC3339 (e.g. 2025-06-30T00:00:00Z): %w", timeMax, err)
+	}
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("time_max must be after time_min")
+	}
+	return from, to, nil
+}
+
+// calendarTimeZone returns tz when set, otherwise the calendar's own timezone.
+func calendarTimeZone(ctx context.Context, srv *calendar.Service, calID, tz string) (string, error) {
+	if tz != "" {
+		return tz, nil
+	}
+	cal, err := srv.Calendars.Get(calID).Fields("timeZone").Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	return cal.TimeZone, nil
+}
+
+// meetingLoadOptions applies defaults and validates the analysis options.
+func meetingLoadOptions(input AnalyzeMeetingLoadInput, tz string) (MeetingLoadOptions, error) {
+	loc := time.UTC
+	if tz != "" {
+		l, err := time.LoadLocation(tz)
+		if err != nil {
+			return MeetingLoadOptions{}, fmt.Errorf("invalid timezone %q — use an IANA name like America/New_York: %w", tz, err)
+		}
+		loc = l
+	}
+
+	opts := MeetingLoadOptions{
+		Location:         loc,
+		WorkdayStartHour: input.WorkdayStartHour,
+		WorkdayEndHour:   input.WorkdayEndHour,
+		IncludeWeekends:  input.IncludeWeekends,
+		MinFocus:         time.Duration(input.MinFocusMinutes) * time.Minute,
+		BackToBackGap:    time.Duration(input.BackToBackMins) * time.Minute,
+	}
+	if opts.WorkdayStartHour == 0 && opts.WorkdayEndHour == 0 {
+		opts.WorkdayStartHour, opts.WorkdayEndHour = 9, 17
+	}
+	if opts.WorkdayStartHour < 0 || opts.WorkdayEndHour > 24 || opts.WorkdayEndHour <= opts.WorkdayStartHour {
+		return MeetingLoadOptions{}, fmt.Errorf("invalid working hours %d-%d — start must be 0-23 and end must be after start (max 24)", opts.WorkdayStartHour, opts.WorkdayEndHour)
+	}
+	if opts.MinFocus == 0 {
+		opts.MinFocus = time.Hour
+	}
+	if opts.BackToBackGap == 0 {
+		opts.BackToBackGap = 5 * time.Minute
+	}
+	return opts, nil
+}
+
+// formatMeetingLoad writes meeting-load metrics to the response builder.
+func formatMeetingLoad(rb *response.Builder, m MeetingLoadMetrics) {
+	rb.KeyValue("Meetings", m.MeetingCount)
+	rb.KeyValue("Meeting hours", m.MeetingHours)
+	rb.KeyValue("Avg meeting hours/week", m.AvgMeetingHoursPerWeek)
+	rb.KeyValue("Back-to-back streaks", m.BackToBackStreaks)
+	if m.LongestStreak != nil {
+		rb.KeyValue("Longest streak", fmt.Sprintf("%d meetings, %d min (%s → %s)",
+			m.LongestStreak.Meetings, m.LongestStreak.Minutes, m.LongestStreak.Start, m.LongestStreak.End))
+	}
+	rb.KeyValue("Focus gaps", m.FocusGapCount)
+	rb.KeyValue("Focus hours", m.FocusHours)
+	if m.LongestFocusGap != nil {
+		rb.KeyValue("Longest focus gap", fmt.Sprintf("%d min (%s → %s)",
+			m.LongestFocusGap.Minutes, m.LongestFocusGap.Start, m.LongestFocusGap.End))
+	}
+
+	rb.Blank()
+	rb.Section("By Week")
+	for _, w := range m.Weeks {
+		rb.Item("Week of %s: %d meetings, %.2fh meetings, %.2fh focus, %d back-to-back streaks",
+			w.WeekStart, w.MeetingCount, w.MeetingHours, w.FocusHours, w.BackToBackStreaks)
+	}
+}
+
+// --- schedule_focus_time (extended) ---
+
+type ScheduleFocusTimeInput struct {
+	UserEmail        string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	TimeMin          string `json:"time_min" jsonschema:"required" jsonschema_description:"Start of the scheduling period (RFC3339 e.g. 2025-06-02T00:00:00Z)"`
+	TimeMax          string `json:"time_max" jsonschema:"required" jsonschema_description:"End of the scheduling period (RFC3339)"`
+	BlockMinutes     int    `json:"block_minutes,omitempty" jsonschema_description:"Length of each focus block in minutes (default 120)"`
+	BlocksPerWeek    int    `json:"blocks_per_week,omitempty" jsonschema_description:"Target number of focus blocks per week, including existing focus-time events (default 2)"`
+	Title            string `json:"title,omitempty" jsonschema_description:"Event title (default: Focus time)"`
+	Timezone         string `json:"timezone,omitempty" jsonschema_description:"IANA timezone for working hours (default: the calendar's timezone)"`
+	WorkdayStartHour int    `json:"workday_start_hour,omitempty" jsonschema_description:"Start of the working day, 0-23 (default 9)"`
+	WorkdayEndHour   int    `json:"workday_end_hour,omitempty" jsonschema_description:"End of the working day, 1-24 (default 17)"`
+	IncludeWeekends  bool   `json:"include_weekends,omitempty" jsonschema_description:"Allow focus blocks on Saturday and Sunday"`
+	HolidayRegion    string `json:"holiday_region,omitempty" jsonschema_description:"Also skip this country's public holidays (e.g. us, gb, de); subscribed holiday calendars are always skipped"`
+	AutoDecline      string `json:"auto_decline,omitempty" jsonschema_description:"Decline conflicting invitations: new (default, only new invites), all, or none"`
+	DeclineMessage   string `json:"decline_message,omitempty" jsonschema_description:"Message sent with auto-declined invitations"`
+	DryRun           bool   `json:"dry_run,omitempty" jsonschema_description:"Report the planned blocks without creating events"`
+}
+
+// autoDeclineModes maps auto_decline values to Calendar API focus-time modes.
+var autoDeclineModes = map[string]string{
+	"":     "declineOnlyNewConflictingInvitations",
+	"new":  "declineOnlyNewConflictingInvitations",
+	"all":  "declineAllConflictingInvitations",
+	"none": "declineNone",
+}
+
+func createScheduleFocusTimeHandler(factory *services.Factory) mcp.ToolHandlerFor[ScheduleFocusTimeInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ScheduleFocusTimeInput) (*mcp.CallToolResult, any, error) {
+		from, to, err := parseTimeRange(input.TimeMin, input.TimeMax)
+		if err != nil {
+			return nil, nil, err
+		}
+		declineMode, ok := autoDeclineModes[input.AutoDecline]
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid auto_decline %q — must be one of: new, all, none", input.AutoDecline)
+		}
+		block := time.Duration(input.BlockMinutes) * time.Minute
+		if block == 0 {
+			block = 2 * time.Hour
+		}
+		perWeek := input.BlocksPerWeek
+		if perWeek == 0 {
+			perWeek = 2
+		}
+		if block < 15*time.Minute || perWeek < 0 {
+			return nil, nil, fmt.Errorf("block_minutes must be at least 15 and blocks_per_week must not be negative")
+		}
+
+		srv, err := factory.Calendar(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		opts, err := workingHours(ctx, srv, workingHoursParams{
+			Timezone: input.Timezone, WorkdayStartHour: input.WorkdayStartHour, WorkdayEndHour: input.WorkdayEndHour,
+			IncludeWeekends: input.IncludeWeekends, HolidayRegion: input.HolidayRegion,
+		}, from, to)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		events, err := listEventsInRange(ctx, srv, "primary", input.TimeMin, input.TimeMax)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		weeks := planFocusTime(events, from, to, block, perWeek, opts)
+
+		rb := response.New()
+		if input.DryRun {
+			rb.Header("Focus Time Plan (dry run)")
+		} else {
+			rb.Header("Focus Time Scheduled")
+		}
+		rb.KeyValue("Target", fmt.Sprintf("%d × %d min per week (%s)", perWeek, int(block.Minutes()), opts.Location))
+		if len(opts.Holidays) > 0 {
+			rb.KeyValue("Holidays skipped", len(opts.Holidays))
+		}
+
+		booker := &focusBooker{srv: srv, input: input, declineMode: declineMode, loc: opts.Location, focusType: true, stamp: factory.Provenance(req)}
+		for _, w := range weeks {
+			if err := booker.bookWeek(ctx, rb, w, perWeek); err != nil {
+				// Remove blocks from earlier weeks rather than leave a partial schedule.
+				return nil, nil, booker.tx.Fail(ctx, middleware.HandleGoogleAPIError(err))
+			}
+		}
+		booker.tx.Commit()
+		if !booker.focusType {
+			rb.Blank()
+			rb.Line("Note: Calendar rejected the focus-time event type for this account (eventTypeRestriction); blocks were created as busy holds without auto-decline.")
+		}
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// focusBooker creates focus blocks, falling back to plain busy holds when
+// the account rejects the focusTime event type (e.g. consumer Gmail
+// accounts). Other errors fail the booking.
+type focusBooker struct {
+	srv         *calendar.Service
+	input       ScheduleFocusTimeInput
+	declineMode string
+	loc         *time.Location
+	focusType   bool
+	stamp       *provenance.Stamp // nil unless provenance stamping is enabled
+	tx          rollback.Tx
+}
+
+// bookWeek creates the planned blocks for one week and reports them.
+func (b *focusBooker) bookWeek(ctx context.Context, rb *response.Builder, w focusWeek, perWeek int) error {
+	rb.Blank()
+	rb.Section("Week of %s", w.start.Format("2006-01-02"))
+	rb.KeyValue("Existing focus blocks", w.existing)
+	for _, iv := range w.planned {
+		label := fmt.Sprintf("%s → %s", iv.start.In(b.loc).Format("Mon Jan 2 15:04"), iv.end.In(b.loc).Format("15:04"))
+		if b.input.DryRun {
+			rb.Item("%s (planned)", label)
+			continue
+		}
+		created, err := b.create(ctx, iv)
+		if err != nil {
+			return err
+		}
+		b.tx.Record("focus block "+created.Id, rollback.DeleteCalendarEvent(b.srv, "primary", created.Id))
+		if !b.focusType {
+			label += " — busy hold"
+		}
+		rb.Item("%s (ID: %s)", label, created.Id)
+	}
+	if short := perWeek - w.existing - len(w.planned); short > 0 {
+		rb.Item("Could not find free time for %d more block(s)", short)
+	}
+	return nil
+}
+
+// create inserts one focus block on the primary calendar.
+func (b *focusBooker) create(ctx context.Context, iv interval) (*calendar.Event, error) {
+	title := b.input.Title
+	if title == "" {
+		title = "Focus time"
+	}
+	event := &calendar.Event{
+		Summary:      title,
+		Start:        &calendar.EventDateTime{DateTime: iv.start.Format(time.RFC3339), TimeZone: b.loc.String()},
+		End:          &calendar.EventDateTime{DateTime: iv.end.Format(time.RFC3339), TimeZone: b.loc.String()},
+		Transparency: "opaque",
+	}
+	if b.focusType {
+		event.EventType = "focusTime"
+		event.FocusTimeProperties = &calendar.EventFocusTimeProperties{
+			AutoDeclineMode: b.declineMode,
+			DeclineMessage:  b.input.DeclineMessage,
+			ChatStatus:      "doNotDisturb",
+		}
+	}
+	if b.stamp != nil {
+		event.ExtendedProperties = &calendar.EventExtendedProperties{Private: b.stamp.Properties()}
+	}
+
+	created, err := b.srv.Events.Insert("primary", event).Context(ctx).Do()
+	if b.focusType && focusTimeUnsupported(err) {
+		b.focusType = false
+		return b.create(ctx, iv)
+	}
+	return created, err
+}
+
+// focusTimeUnsupported reports whether err is Calendar rejecting the
+// focusTime event type for the account, as opposed to any other bad request.
+func focusTimeUnsupported(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != 400 {
+		return false
+	}
+	for _, item := range apiErr.Errors {
+		if item.Reason == "eventTypeRestriction" {
+			return true
+		}
+	}
+	return false
+}
+
+// --- attach_doc_to_event (extended) ---
+
+type AttachDocToEventInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	EventID    string `json:"event_id" jsonschema:"required" jsonschema_description:"The event to attach the document to"`
+	FileID     string `json:"file_id" jsonschema:"required" jsonschema_description:"Drive file ID of the document (e.g. meeting notes)"`
+	CalendarID string `json:"calendar_id,omitempty" jsonschema_description:"Calendar ID (default: primary)"`
+}
+
+func createAttachDocToEventHandler(factory *services.Factory) mcp.ToolHandlerFor[AttachDocToEventInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input AttachDocToEventInput) (*mcp.CallToolResult, any, error) {
+		calSrv, err := factory.Calendar(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		driveSrv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		calID := input.CalendarID
+		if calID == "" {
+			calID = "primary"
+		}
+
+		file, err := driveSrv.Files.Get(input.FileID).
+			Fields("id, name, mimeType, webViewLink, iconLink").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		event, err := calSrv.Events.Get(calID, input.EventID).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Document Attached to Event")
+		rb.KeyValue("Event", event.Summary)
+		rb.KeyValue("Document", file.Name)
+		rb.KeyValue("Link", file.WebViewLink)
+		if hasAttachment(event, file.Id) {
+			rb.Line("The document was already attached — nothing changed.")
+			return rb.TextResult(), nil, nil
+		}
+		if len(event.Attachments) >= maxEventAttachments {
+			return nil, nil, fmt.Errorf("event already has the maximum of %d attachments", maxEventAttachments)
+		}
+
+		attachments := append(event.Attachments, &calendar.EventAttachment{
+			FileId: file.Id, FileUrl: file.WebViewLink, Title: file.Name, MimeType: file.MimeType, IconLink: file.IconLink,
+		})
+		_, err = calSrv.Events.Patch(calID, input.EventID, &calendar.Event{Attachments: attachments}).
+			SupportsAttachments(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// --- get_event_attachments (extended) ---
+
+type GetEventAttachmentsInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	EventID    string `json:"event_id,omitempty" jsonschema_description:"A specific event; otherwise events are found with query and the time range"`
+	Query      string `json:"query,omitempty" jsonschema_description:"Free-text event search, e.g. 'weekly sync'"`
+	TimeMin    string `json:"time_min,omitempty" jsonschema_description:"Start of time range (RFC3339, default: 7 days ago)"`
+	TimeMax    string `json:"time_max,omitempty" jsonschema_description:"End of time range (RFC3339, default: now)"`
+	CalendarID string `json:"calendar_id,omitempty" jsonschema_description:"Calendar ID (default: primary)"`
+	MaxEvents  int    `json:"max_events,omitempty" jsonschema_description:"Maximum events to inspect (default 10)"`
+}
+
+type GetEventAttachmentsOutput struct {
+	Events []EventNotes `json:"events"`
+}
+
+func createGetEventAttachmentsHandler(factory *services.Factory) mcp.ToolHandlerFor[GetEventAttachmentsInput, GetEventAttachmentsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetEventAttachmentsInput) (*mcp.CallToolResult, GetEventAttachmentsOutput, error) {
+		srv, err := factory.Calendar(ctx, input.UserEmail)
+		if err != nil {
+			return nil, GetEventAttachmentsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		events, err := findNotesEvents(ctx, srv, input)
+		if err != nil {
+			return nil, GetEventAttachmentsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := GetEventAttachmentsOutput{Events: make([]EventNotes, 0, len(events))}
+		rb := response.New()
+		rb.Header("Event Documents")
+		for _, e := range events {
+			notes := EventNotes{EventID: e.Id, Summary: e.Summary, Start: formatEventTime(e.Start), Documents: eventDocuments(e)}
+			out.Events = append(out.Events, notes)
+
+			rb.Section("%s — %s", notes.Summary, notes.Start)
+			if len(notes.Documents) == 0 {
+				rb.Line("  (no linked documents)")
+			}
+			for _, d := range notes.Documents {
+				rb.Item("%s [%s] %s", cmp.Or(d.Title, d.FileID), d.Source, d.URL)
+			}
+		}
+		if len(events) == 0 {
+			rb.Line("No matching events found.")
+		}
+		return rb.TextResult(), out, nil
+	}
+}
+
+// findNotesEvents returns the single requested event, or the most recent
+// events matching the query in the time range (newest first).
+func findNotesEvents(ctx context.Context, srv *calendar.Service, input GetEventAttachmentsInput) ([]*calendar.Event, error) {
+	calID := cmp.Or(input.CalendarID, "primary")
+	if input.EventID != "" {
+		e, err := srv.Events.Get(calID, input.EventID).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		return []*calendar.Event{e}, nil
+	}
+
+	now := time.Now()
+	timeMin := cmp.Or(input.TimeMin, now.AddDate(0, 0, -7).Format(time.RFC3339))
+	timeMax := cmp.Or(input.TimeMax, now.Format(time.RFC3339))
+	call := srv.Events.List(calID).
+		TimeMin(timeMin).
+		TimeMax(timeMax).
+		SingleEvents(true).
+		OrderBy("startTime").
+		MaxResults(250).
+		Context(ctx)
+	if input.Query != "" {
+		call = call.Q(input.Query)
+	}
+	result, err := call.Do()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := input.MaxEvents
+	if limit <= 0 {
+		limit = 10
+	}
+	events := result.Items
+	slices.Reverse(events)
+	return events[:min(limit, len(events))], nil
+}
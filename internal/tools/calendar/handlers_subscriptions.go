@@ -0,0 +1,131 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- subscribe_calendar (extended) ---
+
+type SubscribeCalendarInput struct {
+	UserEmail     string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	CalendarID    string `json:"calendar_id,omitempty" jsonschema_description:"ID of a public or shared Google calendar to subscribe to"`
+	URL           string `json:"url,omitempty" jsonschema_description:"Google Calendar ICS or embed URL (calendar.google.com/calendar/ical/.../basic.ics)"`
+	HolidayRegion string `json:"holiday_region,omitempty" jsonschema_description:"Subscribe to public holidays for a country code such as us, gb, de, or jp"`
+	Language      string `json:"language,omitempty" jsonschema_description:"Language of holiday names, e.g. en or de (default en)"`
+	ColorID       string `json:"color_id,omitempty" jsonschema_description:"Calendar color ID from the Calendar colors palette"`
+}
+
+func createSubscribeCalendarHandler(factory *services.Factory) mcp.ToolHandlerFor[SubscribeCalendarInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SubscribeCalendarInput) (*mcp.CallToolResult, any, error) {
+		calID, err := subscriptionCalendarID(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		srv, err := factory.Calendar(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		entry, err := srv.CalendarList.Insert(&calendar.CalendarListEntry{Id: calID, ColorId: input.ColorID}).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, fmt.Errorf("subscribing to calendar %s: %w", calID, middleware.HandleGoogleAPIError(err))
+		}
+
+		rb := response.New()
+		rb.Header("Calendar Subscribed")
+		rb.KeyValue("Calendar", entry.Summary)
+		rb.KeyValue("ID", entry.Id)
+		rb.KeyValue("Access", entry.AccessRole)
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// --- list_calendar_subscriptions (extended) ---
+
+type ListCalendarSubscriptionsInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+}
+
+type ListCalendarSubscriptionsOutput struct {
+	Subscriptions []CalendarSummary `json:"subscriptions"`
+}
+
+func createListCalendarSubscriptionsHandler(factory *services.Factory) mcp.ToolHandlerFor[ListCalendarSubscriptionsInput, ListCalendarSubscriptionsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListCalendarSubscriptionsInput) (*mcp.CallToolResult, ListCalendarSubscriptionsOutput, error) {
+		srv, err := factory.Calendar(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListCalendarSubscriptionsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := ListCalendarSubscriptionsOutput{Subscriptions: []CalendarSummary{}}
+		err = srv.CalendarList.List().Context(ctx).Pages(ctx, func(page *calendar.CalendarList) error {
+			for _, c := range page.Items {
+				if isSubscription(c.AccessRole, c.Primary) {
+					out.Subscriptions = append(out.Subscriptions, calendarToSummary(c))
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, ListCalendarSubscriptionsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Calendar Subscriptions")
+		rb.KeyValue("Count", len(out.Subscriptions))
+		rb.Blank()
+		for _, c := range out.Subscriptions {
+			holiday := ""
+			if strings.HasSuffix(c.ID, holidaySuffix) {
+				holiday = " (holidays)"
+			}
+			rb.Item("%s%s", c.Summary, holiday)
+			rb.Line("    ID: %s", c.ID)
+		}
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- unsubscribe_calendar (extended) ---
+
+type UnsubscribeCalendarInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	CalendarID string `json:"calendar_id" jsonschema:"required" jsonschema_description:"ID of the subscribed calendar to remove from the user's list"`
+}
+
+func createUnsubscribeCalendarHandler(factory *services.Factory) mcp.ToolHandlerFor[UnsubscribeCalendarInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UnsubscribeCalendarInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Calendar(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		entry, err := srv.CalendarList.Get(input.CalendarID).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		// Removing an owned or editable calendar from the list only hides
+		// it, which is rarely what "unsubscribe" means.
+		if !isSubscription(entry.AccessRole, entry.Primary) {
+			return nil, nil, fmt.Errorf("calendar %q is not a subscription (access: %s) — only read-only calendars can be unsubscribed", entry.Summary, entry.AccessRole)
+		}
+		if err := srv.CalendarList.Delete(input.CalendarID).Context(ctx).Do(); err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Calendar Unsubscribed")
+		rb.KeyValue("Calendar", entry.Summary)
+		rb.KeyValue("ID", entry.Id)
+		return rb.TextResult(), nil, nil
+	}
+}
@@ -0,0 +1,158 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- is_working_time (extended) ---
+
+type IsWorkingTimeInput struct {
+	UserEmail        string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Time             string `json:"time,omitempty" jsonschema_description:"Time to check (RFC3339; default now)"`
+	DurationMinutes  int    `json:"duration_minutes,omitempty" jsonschema_description:"Check that a meeting of this length starting at time also ends within working hours"`
+	Timezone         string `json:"timezone,omitempty" jsonschema_description:"IANA timezone for working hours (default: the primary calendar's timezone)"`
+	WorkdayStartHour int    `json:"workday_start_hour,omitempty" jsonschema_description:"Start of the working day, 0-23 (default 9)"`
+	WorkdayEndHour   int    `json:"workday_end_hour,omitempty" jsonschema_description:"End of the working day, 1-24 (default 17)"`
+	IncludeWeekends  bool   `json:"include_weekends,omitempty" jsonschema_description:"Treat Saturday and Sunday as working days"`
+	HolidayRegion    string `json:"holiday_region,omitempty" jsonschema_description:"Also treat this country's public holidays as days off (e.g. us, gb, de); subscribed holiday calendars always count"`
+}
+
+type IsWorkingTimeOutput struct {
+	Time            string `json:"time"`
+	TimeZone        string `json:"time_zone"`
+	Working         bool   `json:"working"`
+	Reason          string `json:"reason,omitempty"`
+	NextWorkingTime string `json:"next_working_time,omitempty"`
+}
+
+func createIsWorkingTimeHandler(factory *services.Factory) mcp.ToolHandlerFor[IsWorkingTimeInput, IsWorkingTimeOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input IsWorkingTimeInput) (*mcp.CallToolResult, IsWorkingTimeOutput, error) {
+		at, err := parseOptionalTime("time", input.Time)
+		if err != nil {
+			return nil, IsWorkingTimeOutput{}, err
+		}
+		length := max(time.Duration(input.DurationMinutes)*time.Minute, time.Minute)
+		srv, err := factory.Calendar(ctx, input.UserEmail)
+		if err != nil {
+			return nil, IsWorkingTimeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		horizon := at.AddDate(0, 0, workingSearchDays)
+		opts, err := workingHours(ctx, srv, workingHoursParams{
+			Timezone: input.Timezone, WorkdayStartHour: input.WorkdayStartHour, WorkdayEndHour: input.WorkdayEndHour,
+			IncludeWeekends: input.IncludeWeekends, HolidayRegion: input.HolidayRegion,
+		}, at, horizon)
+		if err != nil {
+			return nil, IsWorkingTimeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := IsWorkingTimeOutput{Time: at.In(opts.Location).Format(time.RFC3339), TimeZone: opts.Location.String()}
+		out.Working, out.Reason = fitsWorkingHours(interval{start: at, end: at.Add(length)}, opts)
+		if !out.Working {
+			if next, ok := nextWorkingSlot(at, horizon, length, nil, opts); ok {
+				out.NextWorkingTime = next.start.In(opts.Location).Format(time.RFC3339)
+			}
+		}
+
+		rb := response.New()
+		rb.Header("Working Time Check")
+		rb.KeyValue("Time", out.Time)
+		rb.KeyValue("Working time", out.Working)
+		if !out.Working {
+			rb.KeyValue("Reason", out.Reason)
+			if out.NextWorkingTime != "" {
+				rb.KeyValue("Next working time", out.NextWorkingTime)
+			}
+		}
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- next_working_slot (extended) ---
+
+type NextWorkingSlotInput struct {
+	UserEmail        string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DurationMinutes  int    `json:"duration_minutes,omitempty" jsonschema_description:"Length of the slot in minutes (default 30)"`
+	After            string `json:"after,omitempty" jsonschema_description:"Earliest start (RFC3339; default now)"`
+	WithinDays       int    `json:"within_days,omitempty" jsonschema_description:"How many days ahead to search (default 14, max 60)"`
+	Timezone         string `json:"timezone,omitempty" jsonschema_description:"IANA timezone for working hours (default: the primary calendar's timezone)"`
+	WorkdayStartHour int    `json:"workday_start_hour,omitempty" jsonschema_description:"Start of the working day, 0-23 (default 9)"`
+	WorkdayEndHour   int    `json:"workday_end_hour,omitempty" jsonschema_description:"End of the working day, 1-24 (default 17)"`
+	IncludeWeekends  bool   `json:"include_weekends,omitempty" jsonschema_description:"Treat Saturday and Sunday as working days"`
+	HolidayRegion    string `json:"holiday_region,omitempty" jsonschema_description:"Also skip this country's public holidays (e.g. us, gb, de); subscribed holiday calendars always count"`
+}
+
+type NextWorkingSlotOutput struct {
+	Found    bool   `json:"found"`
+	Start    string `json:"start,omitempty"`
+	End      string `json:"end,omitempty"`
+	TimeZone string `json:"time_zone"`
+}
+
+func createNextWorkingSlotHandler(factory *services.Factory) mcp.ToolHandlerFor[NextWorkingSlotInput, NextWorkingSlotOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input NextWorkingSlotInput) (*mcp.CallToolResult, NextWorkingSlotOutput, error) {
+		after, err := parseOptionalTime("after", input.After)
+		if err != nil {
+			return nil, NextWorkingSlotOutput{}, err
+		}
+		length := time.Duration(clampDefault(input.DurationMinutes, 30, 24*60)) * time.Minute
+		horizon := after.AddDate(0, 0, clampDefault(input.WithinDays, workingSearchDays, 60))
+		srv, err := factory.Calendar(ctx, input.UserEmail)
+		if err != nil {
+			return nil, NextWorkingSlotOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		opts, err := workingHours(ctx, srv, workingHoursParams{
+			Timezone: input.Timezone, WorkdayStartHour: input.WorkdayStartHour, WorkdayEndHour: input.WorkdayEndHour,
+			IncludeWeekends: input.IncludeWeekends, HolidayRegion: input.HolidayRegion,
+		}, after, horizon)
+		if err != nil {
+			return nil, NextWorkingSlotOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		events, err := listEventsInRange(ctx, srv, "primary", after.Format(time.RFC3339), horizon.Format(time.RFC3339))
+		if err != nil {
+			return nil, NextWorkingSlotOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := NextWorkingSlotOutput{TimeZone: opts.Location.String()}
+		rb := response.New()
+		rb.Header("Next Working Slot")
+		slot, ok := nextWorkingSlot(after, horizon, length, busyIntervals(events), opts)
+		if !ok {
+			rb.Line("No free %d-minute slot within working hours before %s.", int(length.Minutes()), horizon.In(opts.Location).Format("Mon Jan 2"))
+			return rb.TextResult(), out, nil
+		}
+		out.Found = true
+		out.Start = slot.start.In(opts.Location).Format(time.RFC3339)
+		out.End = slot.end.In(opts.Location).Format(time.RFC3339)
+		rb.KeyValue("Start", out.Start)
+		rb.KeyValue("End", out.End)
+		return rb.TextResult(), out, nil
+	}
+}
+
+// parseOptionalTime parses an RFC3339 time, defaulting to now.
+func parseOptionalTime(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q — expected RFC3339 (e.g. 2025-06-02T09:00:00Z): %w", field, value, err)
+	}
+	return t, nil
+}
+
+// clampDefault returns def when v is unset and caps v at limit.
+func clampDefault(v, def, limit int) int {
+	if v <= 0 {
+		return def
+	}
+	return min(v, limit)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"google.golang.org/api/calendar/v3"
 )
@@ -15,6 +16,7 @@ type CalendarSummary struct {
 	Description string `json:"description,omitempty"`
 	Primary     bool   `json:"primary,omitempty"`
 	TimeZone    string `json:"time_zone,omitempty"`
+	AccessRole  string `json:"access_role,omitempty"`
 }
 
 // EventSummary is a compact representation of a calendar event.
@@ -39,6 +41,7 @@ func calendarToSummary(c *calendar.CalendarListEntry) CalendarSummary {
 		Description: c.Description,
 		Primary:     c.Primary,
 		TimeZone:    c.TimeZone,
+		AccessRole:  c.AccessRole,
 	}
 }
 
@@ -148,3 +151,83 @@ func buildAttendees(emails []string) []*calendar.EventAttendee {
 	}
 	return attendees
 }
+
+// eventSheetHeader is the header row written by export_events_to_sheet.
+var eventSheetHeader = []any{
+	"Calendar", "Event ID", "Summary", "Start", "End", "Duration (min)", "All Day",
+	"Organizer", "Attendees", "Attendee Count", "Meet Link", "Location", "Status",
+}
+
+// quoteSheet quotes a sheet title for use in an A1 range, doubling any
+// single quotes in it.
+func quoteSheet(title string) string {
+	return "'" + strings.ReplaceAll(title, "'", "''") + "'"
+}
+
+// eventToSheetRow flattens an event into a spreadsheet row matching eventSheetHeader.
+func eventToSheetRow(calID string, e *calendar.Event) []any {
+	emails := make([]string, 0, len(e.Attendees))
+	for _, a := range e.Attendees {
+		emails = append(emails, a.Email)
+	}
+
+	var organizer string
+	if e.Organizer != nil {
+		organizer = e.Organizer.Email
+	}
+
+	allDay := e.Start != nil && e.Start.Date != ""
+
+	return []any{
+		calID,
+		e.Id,
+		e.Summary,
+		formatEventTime(e.Start),
+		formatEventTime(e.End),
+		eventDurationMinutes(e),
+		allDay,
+		organizer,
+		strings.Join(emails, "; "),
+		len(emails),
+		eventMeetLink(e),
+		e.Location,
+		e.Status,
+	}
+}
+
+// eventDurationMinutes returns the event length in whole minutes, or 0 when
+// the start or end time cannot be parsed. All-day events count full days.
+func eventDurationMinutes(e *calendar.Event) int {
+	if e.Start == nil || e.End == nil {
+		return 0
+	}
+
+	var start, end time.Time
+	var errStart, errEnd error
+	if e.Start.Date != "" {
+		start, errStart = time.Parse("2006-01-02", e.Start.Date)
+		end, errEnd = time.Parse("2006-01-02", e.End.Date)
+	} else {
+		start, errStart = time.Parse(time.RFC3339, e.Start.DateTime)
+		end, errEnd = time.Parse(time.RFC3339, e.End.DateTime)
+	}
+	if errStart != nil || errEnd != nil || end.Before(start) {
+		return 0
+	}
+	return int(end.Sub(start).Minutes())
+}
+
+// eventMeetLink returns the Google Meet (or other video) join URL for an event.
+func eventMeetLink(e *calendar.Event) string {
+	if e.HangoutLink != "" {
+		return e.HangoutLink
+	}
+	if e.ConferenceData != nil {
+		for _, ep := range e.ConferenceData.EntryPoints {
+			if ep.EntryPointType == "video" {
+				return ep.Uri
+			}
+		}
+	}
+	return ""
+}
@@ -146,3 +146,130 @@ func TestEventToSummary(t *testing.T) {
 		t.Errorf("Organizer = %q", s.Organizer)
 	}
 }
+
+func TestEventDurationMinutes(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *gcal.Event
+		want  int
+	}{
+		{
+			"timed",
+			&gcal.Event{
+				Start: &gcal.EventDateTime{DateTime: "2025-06-15T10:00:00Z"},
+				End:   &gcal.EventDateTime{DateTime: "2025-06-15T11:30:00Z"},
+			},
+			90,
+		},
+		{
+			"all-day",
+			&gcal.Event{
+				Start: &gcal.EventDateTime{Date: "2025-06-15"},
+				End:   &gcal.EventDateTime{Date: "2025-06-16"},
+			},
+			1440,
+		},
+		{
+			"mixed offsets",
+			&gcal.Event{
+				Start: &gcal.EventDateTime{DateTime: "2025-06-15T10:00:00-07:00"},
+				End:   &gcal.EventDateTime{DateTime: "2025-06-15T18:30:00Z"},
+			},
+			90,
+		},
+		{"missing end", &gcal.Event{Start: &gcal.EventDateTime{DateTime: "2025-06-15T10:00:00Z"}}, 0},
+		{
+			"unparseable",
+			&gcal.Event{
+				Start: &gcal.EventDateTime{DateTime: "tomorrow"},
+				End:   &gcal.EventDateTime{DateTime: "later"},
+			},
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := eventDurationMinutes(tt.event)
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventMeetLink(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *gcal.Event
+		want  string
+	}{
+		{"none", &gcal.Event{}, ""},
+		{"hangout link", &gcal.Event{HangoutLink: "https://meet.google.com/abc-defg-hij"}, "https://meet.google.com/abc-defg-hij"},
+		{
+			"conference entry point",
+			&gcal.Event{ConferenceData: &gcal.ConferenceData{EntryPoints: []*gcal.EntryPoint{
+				{EntryPointType: "phone", Uri: "tel:+1-555-0100"},
+				{EntryPointType: "video", Uri: "https://meet.google.com/xyz-uvwx-rst"},
+			}}},
+			"https://meet.google.com/xyz-uvwx-rst",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := eventMeetLink(tt.event)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventToSheetRow(t *testing.T) {
+	e := &gcal.Event{
+		Id:          "evt1",
+		Summary:     "Standup",
+		Start:       &gcal.EventDateTime{DateTime: "2025-06-15T09:00:00Z"},
+		End:         &gcal.EventDateTime{DateTime: "2025-06-15T09:15:00Z"},
+		HangoutLink: "https://meet.google.com/abc-defg-hij",
+		Organizer:   &gcal.EventOrganizer{Email: "alice@example.com"},
+		Attendees: []*gcal.EventAttendee{
+			{Email: "alice@example.com"},
+			{Email: "bob@example.com"},
+		},
+	}
+
+	row := eventToSheetRow("primary", e)
+	if len(row) != len(eventSheetHeader) {
+		t.Fatalf("row has %d columns, header has %d", len(row), len(eventSheetHeader))
+	}
+	if row[5] != 15 {
+		t.Errorf("duration = %v, want 15", row[5])
+	}
+	if row[8] != "alice@example.com; bob@example.com" {
+		t.Errorf("attendees = %v", row[8])
+	}
+	if row[9] != 2 {
+		t.Errorf("attendee count = %v, want 2", row[9])
+	}
+	if row[10] != "https://meet.google.com/abc-defg-hij" {
+		t.Errorf("meet link = %v", row[10])
+	}
+}
+
+func TestQuoteSheet(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Events", "'Events'"},
+		{"Q1 Events", "'Q1 Events'"},
+		{"Bob's Events", "'Bob''s Events'"},
+	}
+	for _, tt := range tests {
+		if got := quoteSheet(tt.title); got != tt.want {
+			t.Errorf("quoteSheet(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
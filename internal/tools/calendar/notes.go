@@ -0,0 +1,78 @@
+package calendar
+
+import (
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// maxEventAttachments is the Calendar API's limit on attachments per event.
+const maxEventAttachments = 25
+
+// driveLinkRE matches links to Docs editors files and Drive files in event
+// descriptions, capturing the file ID.
+var driveLinkRE = regexp.MustCompile(`https://(?:docs\.google\.com/(?:document|spreadsheets|presentation|forms)/(?:u/\d+/)?d/|drive\.google\.com/(?:file/d/|open\?id=))([A-Za-z0-9_-]{10,})[^\s"'<>)\]]*`)
+
+// EventDocument is a Drive file linked to an event.
+type EventDocument struct {
+	FileID   string `json:"file_id"`
+	Title    string `json:"title,omitempty"`
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type,omitempty"`
+	Source   string `json:"source"` // "attachment" or "description"
+}
+
+// EventNotes lists the documents linked to one event.
+type EventNotes struct {
+	EventID   string          `json:"event_id"`
+	Summary   string          `json:"summary"`
+	Start     string          `json:"start"`
+	Documents []EventDocument `json:"documents"`
+}
+
+// eventDocuments returns the event's Drive attachments followed by Drive
+// links found in its description, without duplicates.
+func eventDocuments(e *calendar.Event) []EventDocument {
+	docs := []EventDocument{}
+	seen := make(map[string]bool)
+	for _, a := range e.Attachments {
+		id := a.FileId
+		if id == "" {
+			id = a.FileUrl
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		docs = append(docs, EventDocument{FileID: a.FileId, Title: a.Title, URL: a.FileUrl, MimeType: a.MimeType, Source: "attachment"})
+	}
+	for _, d := range parseDocLinks(e.Description) {
+		if !seen[d.FileID] {
+			seen[d.FileID] = true
+			docs = append(docs, d)
+		}
+	}
+	return docs
+}
+
+// parseDocLinks extracts Drive and Docs editors links from an event
+// description. Descriptions may be HTML, so the pattern stops at quotes and
+// tag brackets and encoded ampersands are decoded first.
+func parseDocLinks(description string) []EventDocument {
+	var docs []EventDocument
+	for _, m := range driveLinkRE.FindAllStringSubmatch(strings.ReplaceAll(description, "&amp;", "&"), -1) {
+		docs = append(docs, EventDocument{FileID: m[1], URL: m[0], Source: "description"})
+	}
+	return docs
+}
+
+// hasAttachment reports whether the event already has fileID attached.
+func hasAttachment(e *calendar.Event, fileID string) bool {
+	for _, a := range e.Attachments {
+		if a.FileId == fileID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package calendar
+
+import (
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestParseDocLinks(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		wantIDs     []string
+	}{
+		{"empty", "", nil},
+		{"plain doc link", "Notes: https://docs.google.com/document/d/1AbCdEfGhIjKlMn/edit", []string{"1AbCdEfGhIjKlMn"}},
+		{"html anchor", `<a href="https://docs.google.com/spreadsheets/u/0/d/1SheetIdXYZ123/edit#gid=0">sheet</a>`, []string{"1SheetIdXYZ123"}},
+		{"drive open link", "https://drive.google.com/open?id=1DriveFileId999&amp;usp=sharing", []string{"1DriveFileId999"}},
+		{"drive file link", "see https://drive.google.com/file/d/1FileIdABCDEF/view)", []string{"1FileIdABCDEF"}},
+		{"unrelated link", "https://example.com/d/1AbCdEfGhIjKlMn", nil},
+		{"two links", "https://docs.google.com/presentation/d/1SlidesId0001/edit and https://docs.google.com/forms/d/1FormsId00002/viewform", []string{"1SlidesId0001", "1FormsId00002"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseDocLinks(tt.description)
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("got %d links %+v, want %v", len(got), got, tt.wantIDs)
+			}
+			for i, id := range tt.wantIDs {
+				if got[i].FileID != id || got[i].Source != "description" {
+					t.Errorf("link %d = %+v, want file ID %q", i, got[i], id)
+				}
+			}
+		})
+	}
+}
+
+func TestEventDocuments(t *testing.T) {
+	e := &calendar.Event{
+		Attachments: []*calendar.EventAttachment{
+			{FileId: "1AttachedDoc01", FileUrl: "https://docs.google.com/document/d/1AttachedDoc01/edit", Title: "Notes"},
+		},
+		Description: "Agenda: https://docs.google.com/document/d/1AttachedDoc01/edit\n" +
+			"Deck: https://docs.google.com/presentation/d/1DeckId000001/edit\n" +
+			"Again: https://docs.google.com/presentation/d/1DeckId000001/edit",
+	}
+
+	docs := eventDocuments(e)
+	if len(docs) != 2 {
+		t.Fatalf("got %d documents %+v, want 2", len(docs), docs)
+	}
+	if docs[0].Source != "attachment" || docs[0].Title != "Notes" {
+		t.Errorf("first document = %+v, want the attachment", docs[0])
+	}
+	if docs[1].FileID != "1DeckId000001" || docs[1].Source != "description" {
+		t.Errorf("second document = %+v, want the deck link", docs[1])
+	}
+	if !hasAttachment(e, "1AttachedDoc01") || hasAttachment(e, "1DeckId000001") {
+		t.Error("hasAttachment mismatch")
+	}
+	if got := eventDocuments(&calendar.Event{}); got == nil || len(got) != 0 {
+		t.Errorf("empty event documents = %v, want empty non-nil slice", got)
+	}
+}
@@ -0,0 +1,52 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// eventURITemplate addresses one event on an account's primary calendar, so
+// an event can be linked in a conversation and re-read lazily.
+const eventURITemplate = "gcal://{+user}/event/{id}"
+
+// registerResources adds the Calendar event resource template.
+func registerResources(server *mcp.Server, factory *services.Factory, res *middleware.Resources) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "calendar_event",
+		Title:       "Calendar Event",
+		URITemplate: eventURITemplate,
+		Description: "One event on the account's primary calendar: time, location, description, organizer and attendees. {user} is the account's email address, {id} the event ID from get_events.",
+		MIMEType:    "text/plain",
+		Icons:       serviceIcons,
+	}, readEventResource(factory, res))
+}
+
+// readEventResource reads a gcal:// event resource as the account the URI
+// names.
+func readEventResource(factory *services.Factory, res *middleware.Resources) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		email, id, err := res.UserResource(uri, "gcal", "event")
+		if err != nil {
+			return nil, err
+		}
+		srv, err := factory.Calendar(ctx, email)
+		if err != nil {
+			return nil, middleware.HandleGoogleAPIError(err)
+		}
+		event, err := srv.Events.Get("primary", id).Context(ctx).Do()
+		if err != nil {
+			return nil, middleware.ResourceError(err, uri)
+		}
+
+		rb := response.New()
+		rb.Header("Calendar Event")
+		formatEventDetail(rb, eventToSummary(event))
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{URI: uri, Text: rb.Build()}}}, nil
+	}
+}
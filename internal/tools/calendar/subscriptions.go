@@ -0,0 +1,98 @@
+package calendar
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// addByURLPage is where users subscribe to non-Google ICS feeds, which the
+// Calendar API cannot add.
+const addByURLPage = "https://calendar.google.com/calendar/r/settings/addbyurl"
+
+// holidaySuffix ends the ID of every Google public holidays calendar.
+const holidaySuffix = "#holiday@group.v.calendar.google.com"
+
+// holidayRegions maps country codes to the region names Google uses in
+// holiday calendar IDs. Other values are used as region names directly.
+var holidayRegions = map[string]string{
+	"au": "australian", "at": "austrian", "be": "be", "br": "brazilian",
+	"ca": "canadian", "ch": "ch", "cn": "china", "de": "german",
+	"dk": "danish", "es": "spain", "fi": "finnish", "fr": "french",
+	"gb": "uk", "ie": "irish", "in": "indian", "it": "italian",
+	"jp": "japanese", "mx": "mexican", "nl": "dutch", "no": "norwegian",
+	"nz": "new_zealand", "pl": "polish", "pt": "portuguese", "se": "swedish",
+	"sg": "singapore", "uk": "uk", "us": "usa", "za": "sa",
+}
+
+// holidayCalendarID returns the ID of the public holidays calendar for a
+// region, such as "us" or "german", with names in the given language.
+func holidayCalendarID(region, language string) (string, error) {
+	region = strings.ToLower(strings.TrimSpace(region))
+	if region == "" || strings.ContainsAny(region, "#@ ") {
+		return "", fmt.Errorf("invalid holiday_region %q — use a country code such as us, gb, or de", region)
+	}
+	if name, ok := holidayRegions[region]; ok {
+		region = name
+	}
+	if language == "" {
+		language = "en"
+	}
+	return strings.ToLower(language) + "." + region + holidaySuffix, nil
+}
+
+// calendarIDFromURL extracts the calendar ID from a Google Calendar ICS or
+// embed URL. Other feeds cannot be subscribed to through the API, so they
+// return an error naming the page where the user can add them.
+func calendarIDFromURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid calendar URL %q", raw)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" && u.Scheme != "webcal" {
+		return "", fmt.Errorf("invalid calendar URL %q — expected https:// or webcal://", raw)
+	}
+	if strings.EqualFold(u.Host, "calendar.google.com") || strings.EqualFold(u.Host, "www.google.com") {
+		if id := u.Query().Get("src"); id != "" {
+			return id, nil
+		}
+		parts := strings.Split(u.EscapedPath(), "/")
+		for i, p := range parts {
+			if p == "ical" && i+1 < len(parts) {
+				if id, err := url.PathUnescape(parts[i+1]); err == nil && id != "" {
+					return id, nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("the Calendar API can only subscribe to Google calendars; "+
+		"ask the user to add %s at %s (Other calendars → From URL)", raw, addByURLPage)
+}
+
+// subscriptionCalendarID resolves the calendar to subscribe to from exactly
+// one of a calendar ID, an ICS URL, or a holiday region.
+func subscriptionCalendarID(input SubscribeCalendarInput) (string, error) {
+	set := 0
+	for _, v := range []string{input.CalendarID, input.URL, input.HolidayRegion} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return "", fmt.Errorf("provide exactly one of calendar_id, url, or holiday_region")
+	}
+	switch {
+	case input.CalendarID != "":
+		return input.CalendarID, nil
+	case input.URL != "":
+		return calendarIDFromURL(input.URL)
+	default:
+		return holidayCalendarID(input.HolidayRegion, input.Language)
+	}
+}
+
+// isSubscription reports whether a calendar list entry is a calendar the user
+// follows rather than one they own or can edit.
+func isSubscription(accessRole string, primary bool) bool {
+	return !primary && (accessRole == "reader" || accessRole == "freeBusyReader")
+}
@@ -0,0 +1,63 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSubscriptionCalendarID(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   SubscribeCalendarInput
+		want    string
+		wantErr string
+	}{
+		{"calendar id", SubscribeCalendarInput{CalendarID: "team@group.calendar.google.com"}, "team@group.calendar.google.com", ""},
+		{"holiday country code", SubscribeCalendarInput{HolidayRegion: "US"}, "en.usa#holiday@group.v.calendar.google.com", ""},
+		{"holiday region name and language", SubscribeCalendarInput{HolidayRegion: "german", Language: "de"}, "de.german#holiday@group.v.calendar.google.com", ""},
+		{"ical url", SubscribeCalendarInput{URL: "https://calendar.google.com/calendar/ical/en.uk%23holiday%40group.v.calendar.google.com/public/basic.ics"}, "en.uk#holiday@group.v.calendar.google.com", ""},
+		{"webcal ical url", SubscribeCalendarInput{URL: "webcal://calendar.google.com/calendar/ical/team%40example.com/public/basic.ics"}, "team@example.com", ""},
+		{"embed url", SubscribeCalendarInput{URL: "https://calendar.google.com/calendar/embed?src=team%40example.com&ctz=UTC"}, "team@example.com", ""},
+		{"external ics", SubscribeCalendarInput{URL: "https://example.com/feed.ics"}, "", "From URL"},
+		{"bad scheme", SubscribeCalendarInput{URL: "ftp://example.com/feed.ics"}, "", "webcal://"},
+		{"bad region", SubscribeCalendarInput{HolidayRegion: "us#x"}, "", "invalid holiday_region"},
+		{"none", SubscribeCalendarInput{}, "", "exactly one"},
+		{"two", SubscribeCalendarInput{CalendarID: "a", HolidayRegion: "us"}, "", "exactly one"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := subscriptionCalendarID(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("subscriptionCalendarID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSubscription(t *testing.T) {
+	tests := []struct {
+		role    string
+		primary bool
+		want    bool
+	}{
+		{"reader", false, true},
+		{"freeBusyReader", false, true},
+		{"writer", false, false},
+		{"owner", false, false},
+		{"reader", true, false},
+	}
+	for _, tt := range tests {
+		if got := isSubscription(tt.role, tt.primary); got != tt.want {
+			t.Errorf("isSubscription(%q, %v) = %v, want %v", tt.role, tt.primary, got, tt.want)
+		}
+	}
+}
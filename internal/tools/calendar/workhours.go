@@ -0,0 +1,146 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// workingSearchDays is how far ahead the next working time is searched.
+const workingSearchDays = 14
+
+// workingHoursParams are the working-hours settings shared by the tools
+// that consult working time.
+type workingHoursParams struct {
+	Timezone         string
+	WorkdayStartHour int
+	WorkdayEndHour   int
+	IncludeWeekends  bool
+	HolidayRegion    string
+	Language         string
+}
+
+// workingHours resolves the user's working hours for [from, to): the primary
+// calendar's timezone unless one is given, the requested or default
+// (9-17, Monday to Friday) hours, and the public holidays on the user's
+// holiday calendars plus the one for params.HolidayRegion.
+func workingHours(ctx context.Context, srv *calendar.Service, params workingHoursParams, from, to time.Time) (MeetingLoadOptions, error) {
+	tz, err := calendarTimeZone(ctx, srv, "primary", params.Timezone)
+	if err != nil {
+		return MeetingLoadOptions{}, fmt.Errorf("reading calendar timezone: %w", err)
+	}
+	opts, err := meetingLoadOptions(AnalyzeMeetingLoadInput{
+		WorkdayStartHour: params.WorkdayStartHour,
+		WorkdayEndHour:   params.WorkdayEndHour,
+		IncludeWeekends:  params.IncludeWeekends,
+	}, tz)
+	if err != nil {
+		return MeetingLoadOptions{}, err
+	}
+	calIDs, err := holidayCalendarIDs(ctx, srv, params.HolidayRegion, params.Language)
+	if err != nil {
+		return MeetingLoadOptions{}, err
+	}
+	opts.Holidays = make(map[string]string)
+	for _, id := range calIDs {
+		events, err := listEventsInRange(ctx, srv, id, from.Format(time.RFC3339), to.Format(time.RFC3339))
+		if err != nil {
+			return MeetingLoadOptions{}, err
+		}
+		holidayDates(events, opts.Holidays)
+	}
+	return opts, nil
+}
+
+// holidayCalendarIDs returns the public holiday calendars the user is
+// subscribed to, plus the one for region when given.
+func holidayCalendarIDs(ctx context.Context, srv *calendar.Service, region, language string) ([]string, error) {
+	var ids []string
+	err := srv.CalendarList.List().Context(ctx).Pages(ctx, func(page *calendar.CalendarList) error {
+		for _, c := range page.Items {
+			if strings.HasSuffix(c.Id, holidaySuffix) {
+				ids = append(ids, c.Id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing holiday calendars: %w", err)
+	}
+	if region != "" {
+		id, err := holidayCalendarID(region, language)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// holidayDates adds every date covered by the all-day public holidays in
+// events to into. Observances, which Google's holiday calendars mark in the
+// description, are not days off and are skipped.
+func holidayDates(events []*calendar.Event, into map[string]string) {
+	for _, e := range events {
+		if e.Start == nil || e.End == nil || e.Start.Date == "" || e.Status == "cancelled" {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(e.Description), "observance") {
+			continue
+		}
+		start, err1 := time.Parse(time.DateOnly, e.Start.Date)
+		end, err2 := time.Parse(time.DateOnly, e.End.Date)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+			into[d.Format(time.DateOnly)] = e.Summary
+		}
+	}
+}
+
+// workingTime reports whether t is within working hours and, if not, why.
+func workingTime(t time.Time, opts MeetingLoadOptions) (bool, string) {
+	local := t.In(opts.Location)
+	if name := opts.Holidays[local.Format(time.DateOnly)]; name != "" {
+		return false, "public holiday (" + name + ")"
+	}
+	if !opts.IncludeWeekends && (local.Weekday() == time.Saturday || local.Weekday() == time.Sunday) {
+		return false, "weekend"
+	}
+	minute := local.Hour()*60 + local.Minute()
+	if minute < opts.WorkdayStartHour*60 || minute >= opts.WorkdayEndHour*60 {
+		return false, fmt.Sprintf("outside working hours (%02d:00-%02d:00 %s)", opts.WorkdayStartHour, opts.WorkdayEndHour, opts.Location)
+	}
+	return true, ""
+}
+
+// fitsWorkingHours reports whether all of iv lies within one working day and,
+// if not, why.
+func fitsWorkingHours(iv interval, opts MeetingLoadOptions) (bool, string) {
+	if ok, reason := workingTime(iv.start, opts); !ok {
+		return false, reason
+	}
+	windows := workingWindows(iv.start, iv.end, opts)
+	if len(windows) != 1 || !windows[0].start.Equal(iv.start) || !windows[0].end.Equal(iv.end) {
+		return false, fmt.Sprintf("ends after working hours (%02d:00 %s)", opts.WorkdayEndHour, opts.Location)
+	}
+	return true, ""
+}
+
+// nextWorkingSlot returns the first slot of length d at or after from that
+// lies within working hours and outside busy (merged and sorted), searching
+// until horizon.
+func nextWorkingSlot(from, horizon time.Time, d time.Duration, busy []interval, opts MeetingLoadOptions) (interval, bool) {
+	gaps := freeGaps(busy, workingWindows(from, horizon, opts), d)
+	if len(gaps) == 0 {
+		return interval{}, false
+	}
+	return interval{start: gaps[0].start, end: gaps[0].start.Add(d)}, true
+}
@@ -0,0 +1,82 @@
+package calendar
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestHolidayDates(t *testing.T) {
+	events := []*calendar.Event{
+		{Summary: "Christmas Day", Description: "Public holiday", Start: &calendar.EventDateTime{Date: "2025-12-25"}, End: &calendar.EventDateTime{Date: "2025-12-26"}},
+		{Summary: "Easter", Start: &calendar.EventDateTime{Date: "2025-04-18"}, End: &calendar.EventDateTime{Date: "2025-04-20"}},
+		{Summary: "Valentine's Day", Description: "Observance\nTo hide observances, go to Google Calendar Settings", Start: &calendar.EventDateTime{Date: "2025-02-14"}, End: &calendar.EventDateTime{Date: "2025-02-15"}},
+		{Summary: "Timed", Start: &calendar.EventDateTime{DateTime: "2025-03-03T10:00:00Z"}, End: &calendar.EventDateTime{DateTime: "2025-03-03T11:00:00Z"}},
+	}
+	got := map[string]string{}
+	holidayDates(events, got)
+	want := map[string]string{"2025-12-25": "Christmas Day", "2025-04-18": "Easter", "2025-04-19": "Easter"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("holidayDates() = %v, want %v", got, want)
+	}
+}
+
+func TestWorkingTime(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone data unavailable")
+	}
+	opts := MeetingLoadOptions{Location: loc, WorkdayStartHour: 9, WorkdayEndHour: 17, Holidays: map[string]string{"2025-06-09": "Whit Monday"}}
+	at := func(day, hour, minute int) time.Time { return time.Date(2025, 6, day, hour, minute, 0, 0, loc) }
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       bool
+		reason     string
+	}{
+		{"working", at(10, 10, 0), at(10, 11, 0), true, ""},
+		{"3 a.m.", at(10, 3, 0), at(10, 4, 0), false, "outside working hours (09:00-17:00 Europe/Berlin)"},
+		{"ends after hours", at(10, 16, 30), at(10, 17, 30), false, "ends after working hours (17:00 Europe/Berlin)"},
+		{"weekend", at(7, 10, 0), at(7, 11, 0), false, "weekend"},
+		{"holiday", at(9, 10, 0), at(9, 11, 0), false, "public holiday (Whit Monday)"},
+		{"utc input", at(10, 9, 0).UTC(), at(10, 10, 0).UTC(), true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, reason := fitsWorkingHours(interval{start: tt.start, end: tt.end}, opts)
+			if ok != tt.want || reason != tt.reason {
+				t.Errorf("fitsWorkingHours() = %v, %q; want %v, %q", ok, reason, tt.want, tt.reason)
+			}
+		})
+	}
+}
+
+func TestNextWorkingSlot(t *testing.T) {
+	opts := MeetingLoadOptions{Location: time.UTC, WorkdayStartHour: 9, WorkdayEndHour: 17, Holidays: map[string]string{"2025-06-09": "Whit Monday"}}
+	at := func(day, hour int) time.Time { return time.Date(2025, 6, day, hour, 0, 0, 0, time.UTC) }
+	busy := []interval{{start: at(10, 9), end: at(10, 12)}}
+
+	tests := []struct {
+		name string
+		from time.Time
+		want time.Time
+	}{
+		{"friday evening skips weekend and holiday to the first free time", at(6, 18), at(10, 12)},
+		{"within working hours", time.Date(2025, 6, 11, 13, 15, 0, 0, time.UTC), time.Date(2025, 6, 11, 13, 15, 0, 0, time.UTC)},
+		{"too late in the day", time.Date(2025, 6, 11, 16, 45, 0, 0, time.UTC), at(12, 9)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot, ok := nextWorkingSlot(tt.from, tt.from.AddDate(0, 0, 14), 30*time.Minute, busy, opts)
+			if !ok || !slot.start.Equal(tt.want) || slot.duration() != 30*time.Minute {
+				t.Errorf("nextWorkingSlot() = %v %v, want start %v", slot, ok, tt.want)
+			}
+		})
+	}
+	if _, ok := nextWorkingSlot(at(7, 0), at(8, 23), 30*time.Minute, nil, opts); ok {
+		t.Error("found a slot over a weekend")
+	}
+}
@@ -0,0 +1,76 @@
+// Package classroom implements Google Classroom tools for teachers: courses,
+// coursework, student submissions, assignments, and announcements.
+package classroom
+
+import (
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/ptr"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+var serviceIcons = []mcp.Icon{{
+	Source:   "https://www.gstatic.com/images/branding/product/1x/classroom_48dp.png",
+	MIMEType: "image/png",
+	Sizes:    []string{"48x48"},
+}}
+
+// Register registers all Classroom tools (core + extended) with the MCP server.
+func Register(server *mcp.Server, factory *services.Factory) {
+	// --- Core tools (3) ---
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_courses",
+		Icons:       serviceIcons,
+		Description: "List Google Classroom courses the user teaches or attends (active courses by default).",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Courses",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListCoursesHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_coursework",
+		Icons:       serviceIcons,
+		Description: "List the coursework (assignments and questions) of a Classroom course with due dates and points.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Coursework",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListCourseworkHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_submissions",
+		Icons:       serviceIcons,
+		Description: "List student submissions for a coursework item, or for all coursework of a course, with state, lateness, and grades. Filter by state or late submissions only.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Submissions",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListSubmissionsHandler(factory))
+
+	// --- Extended tools (2) ---
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_assignment",
+		Icons:       serviceIcons,
+		Description: "Create an assignment in a Classroom course with optional due time, points, and links. Saved as a draft unless publish=true, which notifies students.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Create Assignment",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createCreateAssignmentHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "post_announcement",
+		Icons:       serviceIcons,
+		Description: "Post an announcement to a Classroom course stream, optionally with links. Set draft=true to save it without posting.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Post Announcement",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createPostAnnouncementHandler(factory))
+}
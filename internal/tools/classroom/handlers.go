@@ -0,0 +1,326 @@
+package classroom
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/classroom/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- list_courses (core) ---
+
+type ListCoursesInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Role      string `json:"role,omitempty" jsonschema_description:"Only courses the user teaches or attends: teacher or student (default: both),enum=teacher,enum=student"`
+	State     string `json:"state,omitempty" jsonschema_description:"Course state: ACTIVE ARCHIVED PROVISIONED DECLINED SUSPENDED (default ACTIVE)"`
+	PageSize  int    `json:"page_size,omitempty" jsonschema_description:"Maximum courses to return (default 20)"`
+	PageToken string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type ListCoursesOutput struct {
+	Courses       []CourseSummary `json:"courses"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+}
+
+func createListCoursesHandler(factory *services.Factory) mcp.ToolHandlerFor[ListCoursesInput, ListCoursesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListCoursesInput) (*mcp.CallToolResult, ListCoursesOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 20
+		}
+		if input.State == "" {
+			input.State = "ACTIVE"
+		}
+
+		srv, err := factory.Classroom(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListCoursesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Courses.List().CourseStates(strings.ToUpper(input.State)).PageSize(int64(input.PageSize)).Context(ctx)
+		switch input.Role {
+		case "teacher":
+			call = call.TeacherId("me")
+		case "student":
+			call = call.StudentId("me")
+		case "":
+		default:
+			return nil, ListCoursesOutput{}, fmt.Errorf("invalid role %q: use teacher or student", input.Role)
+		}
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListCoursesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		courses := make([]CourseSummary, 0, len(result.Courses))
+		rb := response.New()
+		rb.Header("Classroom Courses")
+		rb.KeyValue("Count", len(result.Courses))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+		for _, c := range result.Courses {
+			cs := courseToSummary(c)
+			courses = append(courses, cs)
+			rb.Item("%s (ID: %s)", cs.Name, cs.ID)
+			if cs.Section != "" {
+				rb.Line("    Section: %s", cs.Section)
+			}
+		}
+
+		return rb.TextResult(), ListCoursesOutput{Courses: courses, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// --- list_coursework (core) ---
+
+type ListCourseworkInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	CourseID  string `json:"course_id" jsonschema:"required" jsonschema_description:"The course ID"`
+	PageSize  int    `json:"page_size,omitempty" jsonschema_description:"Maximum items to return (default 20)"`
+	PageToken string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type ListCourseworkOutput struct {
+	Coursework    []CourseworkSummary `json:"coursework"`
+	NextPageToken string              `json:"next_page_token,omitempty"`
+}
+
+func createListCourseworkHandler(factory *services.Factory) mcp.ToolHandlerFor[ListCourseworkInput, ListCourseworkOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListCourseworkInput) (*mcp.CallToolResult, ListCourseworkOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 20
+		}
+
+		srv, err := factory.Classroom(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListCourseworkOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Courses.CourseWork.List(input.CourseID).
+			CourseWorkStates("PUBLISHED", "DRAFT").
+			OrderBy("updateTime desc").
+			PageSize(int64(input.PageSize)).
+			Context(ctx)
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListCourseworkOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		work := make([]CourseworkSummary, 0, len(result.CourseWork))
+		rb := response.New()
+		rb.Header("Coursework")
+		rb.KeyValue("Course", input.CourseID)
+		rb.KeyValue("Count", len(result.CourseWork))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+		for _, w := range result.CourseWork {
+			ws := courseworkToSummary(w)
+			work = append(work, ws)
+			rb.Item("%s (ID: %s) — %s, %s", ws.Title, ws.ID, ws.WorkType, ws.State)
+			if ws.Due != "" {
+				rb.Line("    Due: %s", ws.Due)
+			}
+		}
+
+		return rb.TextResult(), ListCourseworkOutput{Coursework: work, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// --- list_submissions (core) ---
+
+type ListSubmissionsInput struct {
+	UserEmail    string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	CourseID     string `json:"course_id" jsonschema:"required" jsonschema_description:"The course ID"`
+	CourseworkID string `json:"coursework_id,omitempty" jsonschema_description:"The coursework ID (default: all coursework in the course)"`
+	State        string `json:"state,omitempty" jsonschema_description:"Only submissions in this state: NEW CREATED TURNED_IN RETURNED RECLAIMED_BY_STUDENT"`
+	LateOnly     bool   `json:"late_only,omitempty" jsonschema_description:"Only late submissions"`
+	PageSize     int    `json:"page_size,omitempty" jsonschema_description:"Maximum submissions to return (default 50)"`
+	PageToken    string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type ListSubmissionsOutput struct {
+	Submissions   []SubmissionSummary `json:"submissions"`
+	NextPageToken string              `json:"next_page_token,omitempty"`
+}
+
+func createListSubmissionsHandler(factory *services.Factory) mcp.ToolHandlerFor[ListSubmissionsInput, ListSubmissionsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListSubmissionsInput) (*mcp.CallToolResult, ListSubmissionsOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 50
+		}
+		if input.CourseworkID == "" {
+			input.CourseworkID = "-" // all coursework in the course
+		}
+
+		srv, err := factory.Classroom(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListSubmissionsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Courses.CourseWork.StudentSubmissions.List(input.CourseID, input.CourseworkID).
+			PageSize(int64(input.PageSize)).
+			Context(ctx)
+		if input.State != "" {
+			call = call.States(strings.ToUpper(input.State))
+		}
+		if input.LateOnly {
+			call = call.Late("LATE_ONLY")
+		}
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListSubmissionsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		return submissionsResult(input.CourseID, result)
+	}
+}
+
+func submissionsResult(courseID string, result *classroom.ListStudentSubmissionsResponse) (*mcp.CallToolResult, ListSubmissionsOutput, error) {
+	subs := make([]SubmissionSummary, 0, len(result.StudentSubmissions))
+	rb := response.New()
+	rb.Header("Student Submissions")
+	rb.KeyValue("Course", courseID)
+	rb.KeyValue("Count", len(result.StudentSubmissions))
+	if result.NextPageToken != "" {
+		rb.KeyValue("Next page token", result.NextPageToken)
+	}
+	rb.Blank()
+	for _, s := range result.StudentSubmissions {
+		ss := submissionToSummary(s)
+		subs = append(subs, ss)
+		line := fmt.Sprintf("Student %s — %s", ss.UserID, ss.State)
+		if ss.Late {
+			line += " (late)"
+		}
+		if ss.Grade != nil {
+			line += fmt.Sprintf(", grade %g", *ss.Grade)
+		}
+		rb.Item("%s", line)
+		rb.Line("    Coursework: %s, Submission: %s", ss.CourseworkID, ss.ID)
+	}
+
+	return rb.TextResult(), ListSubmissionsOutput{Submissions: subs, NextPageToken: result.NextPageToken}, nil
+}
+
+// --- create_assignment (extended) ---
+
+type CreateAssignmentInput struct {
+	UserEmail   string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	CourseID    string   `json:"course_id" jsonschema:"required" jsonschema_description:"The course ID"`
+	Title       string   `json:"title" jsonschema:"required" jsonschema_description:"Assignment title"`
+	Description string   `json:"description,omitempty" jsonschema_description:"Instructions for students"`
+	Due         string   `json:"due,omitempty" jsonschema_description:"Due time (RFC3339, e.g. 2025-06-02T17:00:00Z)"`
+	MaxPoints   float64  `json:"max_points,omitempty" jsonschema_description:"Maximum points (default: ungraded)"`
+	Links       []string `json:"links,omitempty" jsonschema_description:"URLs to attach as materials"`
+	Publish     bool     `json:"publish,omitempty" jsonschema_description:"Publish to students immediately (default: save as draft)"`
+}
+
+type CreateAssignmentOutput struct {
+	Coursework CourseworkSummary `json:"coursework"`
+}
+
+func createCreateAssignmentHandler(factory *services.Factory) mcp.ToolHandlerFor[CreateAssignmentInput, CreateAssignmentOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateAssignmentInput) (*mcp.CallToolResult, CreateAssignmentOutput, error) {
+		work := &classroom.CourseWork{
+			Title:       input.Title,
+			Description: input.Description,
+			WorkType:    "ASSIGNMENT",
+			MaxPoints:   input.MaxPoints,
+			Materials:   linkMaterials(input.Links),
+			State:       "DRAFT",
+		}
+		if input.Publish {
+			work.State = "PUBLISHED"
+		}
+		if input.Due != "" {
+			var err error
+			if work.DueDate, work.DueTime, err = dueDateTime(input.Due); err != nil {
+				return nil, CreateAssignmentOutput{}, err
+			}
+		}
+
+		srv, err := factory.Classroom(ctx, input.UserEmail)
+		if err != nil {
+			return nil, CreateAssignmentOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		created, err := srv.Courses.CourseWork.Create(input.CourseID, work).Context(ctx).Do()
+		if err != nil {
+			return nil, CreateAssignmentOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		ws := courseworkToSummary(created)
+		rb := response.New()
+		rb.Header("Assignment Created")
+		rb.KeyValue("Title", ws.Title)
+		rb.KeyValue("ID", ws.ID)
+		rb.KeyValue("State", ws.State)
+		if ws.Due != "" {
+			rb.KeyValue("Due", ws.Due)
+		}
+		rb.KeyValue("Link", ws.Link)
+
+		return rb.TextResult(), CreateAssignmentOutput{Coursework: ws}, nil
+	}
+}
+
+// --- post_announcement (extended) ---
+
+type PostAnnouncementInput struct {
+	UserEmail string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	CourseID  string   `json:"course_id" jsonschema:"required" jsonschema_description:"The course ID"`
+	Text      string   `json:"text" jsonschema:"required" jsonschema_description:"Announcement text"`
+	Links     []string `json:"links,omitempty" jsonschema_description:"URLs to attach as materials"`
+	Draft     bool     `json:"draft,omitempty" jsonschema_description:"Save as a draft instead of posting"`
+}
+
+func createPostAnnouncementHandler(factory *services.Factory) mcp.ToolHandlerFor[PostAnnouncementInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input PostAnnouncementInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Classroom(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		announcement := &classroom.Announcement{Text: input.Text, Materials: linkMaterials(input.Links), State: "PUBLISHED"}
+		if input.Draft {
+			announcement.State = "DRAFT"
+		}
+		created, err := srv.Courses.Announcements.Create(input.CourseID, announcement).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		if input.Draft {
+			rb.Header("Announcement Draft Saved")
+		} else {
+			rb.Header("Announcement Posted")
+		}
+		rb.KeyValue("Course", created.CourseId)
+		rb.KeyValue("ID", created.Id)
+		rb.KeyValue("Link", created.AlternateLink)
+
+		return rb.TextResult(), nil, nil
+	}
+}
@@ -0,0 +1,308 @@
+package classroom
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// fakeAPI answers Classroom requests in-process from a ServeMux, so the
+// handlers run against canned API responses.
+type fakeAPI struct{ *http.ServeMux }
+
+func (f fakeAPI) RoundTrip(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	f.ServeHTTP(rec, r)
+	resp := rec.Result()
+	resp.Request = r
+	return resp, nil
+}
+
+func fakeFactory(mux *http.ServeMux) *services.Factory {
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(fakeAPI{mux})
+	return factory
+}
+
+func replyJSON(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	}
+}
+
+func replyError(code int, message string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		fmt.Fprintf(w, `{"error":{"code":%d,"message":%q}}`, code, message)
+	}
+}
+
+func resultText(res *mcp.CallToolResult) string {
+	var b strings.Builder
+	for _, c := range res.Content {
+		if tc, ok := c.(*mcp.TextContent); ok {
+			b.WriteString(tc.Text)
+		}
+	}
+	return b.String()
+}
+
+const teacher = "teacher@example.com"
+
+func TestListCoursesHandler(t *testing.T) {
+	tests := []struct {
+		role    string
+		want    []string
+		wantErr string
+	}{
+		{role: "", want: []string{"courseStates=ACTIVE", "pageSize=20"}},
+		{role: "teacher", want: []string{"teacherId=me"}},
+		{role: "student", want: []string{"studentId=me"}},
+		{role: "parent", wantErr: "invalid role"},
+	}
+	for _, tt := range tests {
+		var query string
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /v1/courses", func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			replyJSON(`{"courses":[{"id":"c1","name":"Biology","section":"Period 2","courseState":"ACTIVE"}],"nextPageToken":"n2"}`)(w, r)
+		})
+
+		res, out, err := createListCoursesHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, ListCoursesInput{UserEmail: teacher, Role: tt.role})
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("role %q: error = %v, want %q", tt.role, err, tt.wantErr)
+			}
+			if query != "" {
+				t.Errorf("role %q: invalid input reached the API", tt.role)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("role %q: handler error = %v", tt.role, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(query, want) {
+				t.Errorf("role %q: query %q missing %q", tt.role, query, want)
+			}
+		}
+		if len(out.Courses) != 1 || out.Courses[0].Section != "Period 2" || out.NextPageToken != "n2" {
+			t.Errorf("role %q: output = %+v", tt.role, out)
+		}
+		text := resultText(res)
+		if !strings.Contains(text, "Biology (ID: c1)") || !strings.Contains(text, "Section: Period 2") {
+			t.Errorf("role %q: text = %q", tt.role, text)
+		}
+	}
+}
+
+func TestListCourseworkHandler(t *testing.T) {
+	var query string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/courses/{course}/courseWork", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		replyJSON(`{"courseWork":[{"id":"w1","title":"Lab report","workType":"ASSIGNMENT","state":"PUBLISHED",
+			"dueDate":{"year":2026,"month":6,"day":2},"dueTime":{"hours":17}}]}`)(w, r)
+	})
+
+	res, out, err := createListCourseworkHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, ListCourseworkInput{UserEmail: teacher, CourseID: "c1"})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	for _, want := range []string{"courseWorkStates=PUBLISHED", "courseWorkStates=DRAFT", "orderBy=updateTime", "pageSize=20"} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query %q missing %q", query, want)
+		}
+	}
+	if len(out.Coursework) != 1 || out.Coursework[0].Due != "2026-06-02 17:00 UTC" {
+		t.Errorf("output = %+v", out)
+	}
+	if text := resultText(res); !strings.Contains(text, "Lab report (ID: w1) — ASSIGNMENT, PUBLISHED") || !strings.Contains(text, "Due: 2026-06-02 17:00 UTC") {
+		t.Errorf("text = %q", text)
+	}
+}
+
+func TestListSubmissionsHandler(t *testing.T) {
+	var path, query string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/courses/{course}/courseWork/{work}/studentSubmissions", func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.PathValue("course")+"/"+r.PathValue("work"), r.URL.RawQuery
+		replyJSON(`{"studentSubmissions":[
+			{"id":"s1","courseWorkId":"w1","userId":"u1","state":"RETURNED","late":true},
+			{"id":"s2","courseWorkId":"w1","userId":"u2","state":"TURNED_IN"}
+		]}`)(w, r)
+	})
+
+	res, out, err := createListSubmissionsHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, ListSubmissionsInput{
+		UserEmail: teacher,
+		CourseID:  "c1",
+		State:     "returned",
+		LateOnly:  true,
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if path != "c1/-" {
+		t.Errorf("path = %q, want every coursework of c1", path)
+	}
+	for _, want := range []string{"states=RETURNED", "late=LATE_ONLY", "pageSize=50"} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query %q missing %q", query, want)
+		}
+	}
+	if len(out.Submissions) != 2 || out.Submissions[0].Grade == nil || *out.Submissions[0].Grade != 0 || out.Submissions[1].Grade != nil {
+		t.Errorf("output = %+v", out)
+	}
+	if text := resultText(res); !strings.Contains(text, "Student u1 — RETURNED (late), grade 0") || !strings.Contains(text, "Student u2 — TURNED_IN\n") {
+		t.Errorf("text = %q", text)
+	}
+}
+
+func TestCreateAssignmentHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   CreateAssignmentInput
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "draft",
+			input: CreateAssignmentInput{Title: "Essay", Links: []string{" https://example.com/rubric ", ""}},
+			want:  []string{`"state":"DRAFT"`, `"url":"https://example.com/rubric"`},
+		},
+		{
+			name:  "published with due time",
+			input: CreateAssignmentInput{Title: "Essay", Publish: true, Due: "2026-06-02T19:30:00+02:00", MaxPoints: 10},
+			want:  []string{`"state":"PUBLISHED"`, `"dueDate":{"day":2,"month":6,"year":2026}`, `"dueTime":{"hours":17,"minutes":30}`, `"maxPoints":10`},
+		},
+		{
+			name:    "invalid due",
+			input:   CreateAssignmentInput{Title: "Essay", Due: "tomorrow"},
+			wantErr: "expected RFC3339",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /v1/courses/c1/courseWork", func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+				replyJSON(`{"id":"w9","title":"Essay","state":"DRAFT","alternateLink":"https://classroom.google.com/w9"}`)(w, r)
+			})
+
+			tt.input.UserEmail, tt.input.CourseID = teacher, "c1"
+			res, out, err := createCreateAssignmentHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want %q", err, tt.wantErr)
+				}
+				if body != "" {
+					t.Errorf("invalid input reached the API: %s", body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("request body %s missing %s", body, want)
+				}
+			}
+			if out.Coursework.ID != "w9" || !strings.Contains(resultText(res), "Link: https://classroom.google.com/w9") {
+				t.Errorf("output = %+v, text = %q", out, resultText(res))
+			}
+		})
+	}
+}
+
+func TestPostAnnouncementHandler(t *testing.T) {
+	for _, draft := range []bool{false, true} {
+		var body string
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /v1/courses/c1/announcements", func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			replyJSON(`{"id":"a1","courseId":"c1","alternateLink":"https://classroom.google.com/a1"}`)(w, r)
+		})
+
+		res, _, err := createPostAnnouncementHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, PostAnnouncementInput{
+			UserEmail: teacher,
+			CourseID:  "c1",
+			Text:      "No class Friday",
+			Draft:     draft,
+		})
+		if err != nil {
+			t.Fatalf("draft=%v: handler error = %v", draft, err)
+		}
+		state, header := "PUBLISHED", "Announcement Posted"
+		if draft {
+			state, header = "DRAFT", "Announcement Draft Saved"
+		}
+		if !strings.Contains(body, `"state":"`+state+`"`) || !strings.Contains(resultText(res), header) {
+			t.Errorf("draft=%v: body = %s, text = %q", draft, body, resultText(res))
+		}
+	}
+}
+
+func TestClassroomHandlersAPIErrors(t *testing.T) {
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	handlers := map[string]func(*services.Factory) error{
+		"list_courses": func(f *services.Factory) error {
+			_, _, err := createListCoursesHandler(f)(ctx, req, ListCoursesInput{UserEmail: teacher})
+			return err
+		},
+		"list_coursework": func(f *services.Factory) error {
+			_, _, err := createListCourseworkHandler(f)(ctx, req, ListCourseworkInput{UserEmail: teacher, CourseID: "c1"})
+			return err
+		},
+		"list_submissions": func(f *services.Factory) error {
+			_, _, err := createListSubmissionsHandler(f)(ctx, req, ListSubmissionsInput{UserEmail: teacher, CourseID: "c1"})
+			return err
+		},
+		"create_assignment": func(f *services.Factory) error {
+			_, _, err := createCreateAssignmentHandler(f)(ctx, req, CreateAssignmentInput{UserEmail: teacher, CourseID: "c1", Title: "Essay"})
+			return err
+		},
+		"post_announcement": func(f *services.Factory) error {
+			_, _, err := createPostAnnouncementHandler(f)(ctx, req, PostAnnouncementInput{UserEmail: teacher, CourseID: "c1", Text: "Hi"})
+			return err
+		},
+	}
+	statuses := []struct {
+		code int
+		want string
+	}{
+		{http.StatusForbidden, "permission denied"},
+		{http.StatusNotFound, "resource not found"},
+	}
+	for _, st := range statuses {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", replyError(st.code, "The caller does not have permission"))
+		factory := fakeFactory(mux)
+		for name, call := range handlers {
+			if err := call(factory); err == nil || !strings.Contains(err.Error(), st.want) {
+				t.Errorf("%s on %d: error = %v, want %q", name, st.code, err, st.want)
+			}
+		}
+	}
+
+	_, _, err := createListCoursesHandler(fakeFactory(http.NewServeMux()))(ctx, req, ListCoursesInput{UserEmail: "not-an-email"})
+	if err == nil || !strings.Contains(err.Error(), "invalid user email") {
+		t.Errorf("invalid user email error = %v", err)
+	}
+}
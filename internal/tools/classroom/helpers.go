@@ -0,0 +1,110 @@
+package classroom
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/classroom/v1"
+)
+
+// CourseSummary is a compact representation of a course.
+type CourseSummary struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Section string `json:"section,omitempty"`
+	State   string `json:"state"`
+	Link    string `json:"link,omitempty"`
+}
+
+// CourseworkSummary is a compact representation of a coursework item.
+type CourseworkSummary struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	WorkType  string  `json:"work_type"`
+	State     string  `json:"state"`
+	Due       string  `json:"due,omitempty"`
+	MaxPoints float64 `json:"max_points,omitempty"`
+	Link      string  `json:"link,omitempty"`
+}
+
+// SubmissionSummary is a compact representation of a student submission.
+type SubmissionSummary struct {
+	ID           string   `json:"id"`
+	CourseworkID string   `json:"coursework_id"`
+	UserID       string   `json:"user_id"`
+	State        string   `json:"state"`
+	Late         bool     `json:"late,omitempty"`
+	Grade        *float64 `json:"grade,omitempty"`
+	Link         string   `json:"link,omitempty"`
+}
+
+func courseToSummary(c *classroom.Course) CourseSummary {
+	return CourseSummary{ID: c.Id, Name: c.Name, Section: c.Section, State: c.CourseState, Link: c.AlternateLink}
+}
+
+func courseworkToSummary(w *classroom.CourseWork) CourseworkSummary {
+	return CourseworkSummary{
+		ID:        w.Id,
+		Title:     w.Title,
+		WorkType:  w.WorkType,
+		State:     w.State,
+		Due:       formatDue(w.DueDate, w.DueTime),
+		MaxPoints: w.MaxPoints,
+		Link:      w.AlternateLink,
+	}
+}
+
+func submissionToSummary(s *classroom.StudentSubmission) SubmissionSummary {
+	ss := SubmissionSummary{
+		ID:           s.Id,
+		CourseworkID: s.CourseWorkId,
+		UserID:       s.UserId,
+		State:        s.State,
+		Late:         s.Late,
+		Link:         s.AlternateLink,
+	}
+	// A returned submission's grade may legitimately be 0, so only the state
+	// tells whether a grade was assigned.
+	if s.State == "RETURNED" {
+		grade := s.AssignedGrade
+		ss.Grade = &grade
+	}
+	return ss
+}
+
+// dueDateTime converts an RFC3339 due time to Classroom's UTC date and
+// time of day.
+func dueDateTime(due string) (*classroom.Date, *classroom.TimeOfDay, error) {
+	t, err := time.Parse(time.RFC3339, due)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid due %q — expected RFC3339 (e.g. 2025-06-02T17:00:00Z): %w", due, err)
+	}
+	t = t.UTC()
+	date := &classroom.Date{Year: int64(t.Year()), Month: int64(t.Month()), Day: int64(t.Day())}
+	tod := &classroom.TimeOfDay{Hours: int64(t.Hour()), Minutes: int64(t.Minute()), ForceSendFields: []string{"Hours", "Minutes"}}
+	return date, tod, nil
+}
+
+// formatDue renders a Classroom due date and time (UTC).
+func formatDue(d *classroom.Date, t *classroom.TimeOfDay) string {
+	if d == nil {
+		return ""
+	}
+	s := fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+	if t != nil {
+		s += fmt.Sprintf(" %02d:%02d UTC", t.Hours, t.Minutes)
+	}
+	return s
+}
+
+// linkMaterials turns URLs into link materials.
+func linkMaterials(urls []string) []*classroom.Material {
+	var materials []*classroom.Material
+	for _, u := range urls {
+		if u = strings.TrimSpace(u); u != "" {
+			materials = append(materials, &classroom.Material{Link: &classroom.Link{Url: u}})
+		}
+	}
+	return materials
+}
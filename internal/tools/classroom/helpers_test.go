@@ -0,0 +1,50 @@
+package classroom
+
+import (
+	"testing"
+
+	"google.golang.org/api/classroom/v1"
+)
+
+func TestDueDateTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		due     string
+		want    string
+		wantErr bool
+	}{
+		{"utc", "2026-03-10T17:00:00Z", "2026-03-10 17:00 UTC", false},
+		{"offset converted to utc", "2026-03-10T23:30:00-02:00", "2026-03-11 01:30 UTC", false},
+		{"midnight", "2026-03-10T00:00:00Z", "2026-03-10 00:00 UTC", false},
+		{"date only", "2026-03-10", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, tod, err := dueDateTime(tt.due)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("dueDateTime(%q) error = %v, wantErr %v", tt.due, err, tt.wantErr)
+			}
+			if got := formatDue(date, tod); got != tt.want {
+				t.Errorf("dueDateTime(%q) = %q, want %q", tt.due, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubmissionToSummaryGrade(t *testing.T) {
+	returned := submissionToSummary(&classroom.StudentSubmission{State: "RETURNED"})
+	if returned.Grade == nil || *returned.Grade != 0 {
+		t.Errorf("returned submission grade = %v, want 0", returned.Grade)
+	}
+	turnedIn := submissionToSummary(&classroom.StudentSubmission{State: "TURNED_IN", DraftGrade: 8})
+	if turnedIn.Grade != nil {
+		t.Errorf("turned-in submission grade = %v, want none", *turnedIn.Grade)
+	}
+}
+
+func TestLinkMaterials(t *testing.T) {
+	materials := linkMaterials([]string{"https://example.com/a", " ", "https://example.com/b "})
+	if len(materials) != 2 || materials[1].Link.Url != "https://example.com/b" {
+		t.Errorf("linkMaterials() = %+v", materials)
+	}
+}
@@ -29,6 +29,17 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createSearchContactsHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "search_directory_people",
+		Icons:       serviceIcons,
+		Description: "Look up coworkers in the Google Workspace directory by name or email: email addresses, job title, department, manager, phone, and desk location. Unlike search_contacts, this covers everyone in the organization.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Search Directory",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createSearchDirectoryHandler(factory))
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_contact",
 		Icons:       serviceIcons,
@@ -183,4 +194,26 @@ func Register(server *mcp.Server, factory *services.Factory) {
 			OpenWorldHint:  ptr.Bool(true),
 		},
 	}, createModifyGroupMembersHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_contact_graph",
+		Icons:       serviceIcons,
+		Description: "Export the user's correspondence network as nodes and a weighted edge list (JSON or CSV): who they email with, how often in each direction, and who appears together on messages. Reads only Gmail address headers and saved contacts, with strict caps. Requires the user's explicit consent — call with confirm=true only after they agree.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Export Contact Graph",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createExportContactGraphHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_contacts",
+		Icons:       serviceIcons,
+		Description: "Export contacts as newline-delimited JSON for CRM sync. Select fields, page through large address books with next_page_token, and pass the returned next_sync_token on the next run to get only contacts changed or deleted since then.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Export Contacts",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createExportContactsHandler(factory))
 }
@@ -0,0 +1,157 @@
+package contacts
+
+import (
+	"context"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/people/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// directoryReadMask is the field mask for directory profiles.
+const directoryReadMask = "names,emailAddresses,phoneNumbers,organizations,relations,locations"
+
+// DirectoryPerson is a coworker's profile from the Workspace directory.
+type DirectoryPerson struct {
+	ResourceName string   `json:"resource_name"`
+	DisplayName  string   `json:"display_name,omitempty"`
+	Emails       []string `json:"emails,omitempty"`
+	Title        string   `json:"title,omitempty"`
+	Department   string   `json:"department,omitempty"`
+	Manager      string   `json:"manager,omitempty"`
+	Phones       []string `json:"phones,omitempty"`
+	Location     string   `json:"location,omitempty"`
+}
+
+// personToDirectory converts a directory Person, preferring current
+// organization and desk entries.
+func personToDirectory(p *people.Person) DirectoryPerson {
+	dp := DirectoryPerson{ResourceName: p.ResourceName}
+	if len(p.Names) > 0 {
+		dp.DisplayName = p.Names[0].DisplayName
+	}
+	for _, e := range p.EmailAddresses {
+		dp.Emails = append(dp.Emails, e.Value)
+	}
+	for _, ph := range p.PhoneNumbers {
+		dp.Phones = append(dp.Phones, ph.Value)
+	}
+	for i, org := range p.Organizations {
+		if i == 0 || org.Current {
+			dp.Title, dp.Department = org.Title, org.Department
+		}
+		if org.Current {
+			break
+		}
+	}
+	for _, r := range p.Relations {
+		if r.Type == "manager" {
+			dp.Manager = r.Person
+			break
+		}
+	}
+	for i, l := range p.Locations {
+		if i == 0 || l.Current {
+			dp.Location = formatLocation(l)
+		}
+		if l.Current {
+			break
+		}
+	}
+	return dp
+}
+
+// formatLocation renders a location as "value, building, floor, desk".
+func formatLocation(l *people.Location) string {
+	var parts []string
+	for _, s := range []string{l.Value, l.BuildingId, l.Floor, l.DeskCode} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// --- search_directory_people (core) ---
+
+type SearchDirectoryInput struct {
+	UserEmail     string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Query         string `json:"query" jsonschema:"required" jsonschema_description:"Name, email, or other profile text to match (prefix matching)"`
+	IncludeShared bool   `json:"include_shared_contacts,omitempty" jsonschema_description:"Also search the domain's shared external contacts"`
+	PageSize      int    `json:"page_size,omitempty" jsonschema_description:"Maximum people to return (default 10, max 500)"`
+	PageToken     string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type SearchDirectoryOutput struct {
+	People        []DirectoryPerson `json:"people"`
+	NextPageToken string            `json:"next_page_token,omitempty"`
+}
+
+func createSearchDirectoryHandler(factory *services.Factory) mcp.ToolHandlerFor[SearchDirectoryInput, SearchDirectoryOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SearchDirectoryInput) (*mcp.CallToolResult, SearchDirectoryOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 10
+		}
+
+		srv, err := factory.People(ctx, input.UserEmail)
+		if err != nil {
+			return nil, SearchDirectoryOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		sources := []string{"DIRECTORY_SOURCE_TYPE_DOMAIN_PROFILE"}
+		if input.IncludeShared {
+			sources = append(sources, "DIRECTORY_SOURCE_TYPE_DOMAIN_CONTACT")
+		}
+		call := srv.People.SearchDirectoryPeople().
+			Query(input.Query).
+			ReadMask(directoryReadMask).
+			Sources(sources...).
+			PageSize(int64(input.PageSize)).
+			Context(ctx)
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, SearchDirectoryOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		found := make([]DirectoryPerson, 0, len(result.People))
+		rb := response.New()
+		rb.Header("Directory Search Results")
+		rb.KeyValue("Query", input.Query)
+		rb.KeyValue("Results", len(result.People))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+		for _, p := range result.People {
+			dp := personToDirectory(p)
+			found = append(found, dp)
+			line := dp.DisplayName
+			if len(dp.Emails) > 0 {
+				line += " <" + dp.Emails[0] + ">"
+			}
+			rb.Item("%s", line)
+			if dp.Title != "" || dp.Department != "" {
+				rb.Line("    %s", strings.Trim(dp.Title+" — "+dp.Department, " —"))
+			}
+			if dp.Manager != "" {
+				rb.Line("    Manager: %s", dp.Manager)
+			}
+			if len(dp.Phones) > 0 {
+				rb.Line("    Phone: %s", strings.Join(dp.Phones, ", "))
+			}
+			if dp.Location != "" {
+				rb.Line("    Location: %s", dp.Location)
+			}
+		}
+
+		return rb.TextResult(), SearchDirectoryOutput{People: found, NextPageToken: result.NextPageToken}, nil
+	}
+}
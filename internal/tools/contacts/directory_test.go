@@ -0,0 +1,34 @@
+package contacts
+
+import (
+	"testing"
+
+	"google.golang.org/api/people/v1"
+)
+
+func TestPersonToDirectory(t *testing.T) {
+	p := &people.Person{
+		ResourceName:   "people/1",
+		Names:          []*people.Name{{DisplayName: "Ada Lovelace"}},
+		EmailAddresses: []*people.EmailAddress{{Value: "ada@example.com"}},
+		Organizations: []*people.Organization{
+			{Title: "Analyst", Department: "Research"},
+			{Title: "Lead Engineer", Department: "Engines", Current: true},
+		},
+		Relations: []*people.Relation{
+			{Type: "assistant", Person: "amy@example.com"},
+			{Type: "manager", Person: "charles@example.com"},
+		},
+		Locations: []*people.Location{{Value: "London", BuildingId: "HQ", Floor: "3", DeskCode: "3-12", Current: true}},
+	}
+	dp := personToDirectory(p)
+	if dp.Title != "Lead Engineer" || dp.Department != "Engines" {
+		t.Errorf("organization = %q / %q, want current one", dp.Title, dp.Department)
+	}
+	if dp.Manager != "charles@example.com" {
+		t.Errorf("manager = %q", dp.Manager)
+	}
+	if dp.Location != "London, HQ, 3, 3-12" {
+		t.Errorf("location = %q", dp.Location)
+	}
+}
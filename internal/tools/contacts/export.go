@@ -0,0 +1,159 @@
+package contacts
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+)
+
+// Limits for export_contacts. The People API returns at most 1000
+// connections per page.
+const (
+	exportPageSize       = 1000
+	exportDefaultRecords = 1000
+	exportMaxRecords     = 10000
+)
+
+// exportFields maps export_contacts field names to People API person fields.
+var exportFields = map[string]string{
+	"names":         "names",
+	"emails":        "emailAddresses",
+	"phones":        "phoneNumbers",
+	"organizations": "organizations",
+	"addresses":     "addresses",
+	"birthdays":     "birthdays",
+	"urls":          "urls",
+	"notes":         "biographies",
+	"groups":        "memberships",
+}
+
+// defaultExportFields are exported when no fields are selected.
+var defaultExportFields = []string{"names", "emails", "phones", "organizations"}
+
+// exportPersonFields validates the selected fields and returns them, sorted
+// and de-duplicated, with the People API field mask that reads them.
+func exportPersonFields(fields []string) ([]string, string, error) {
+	if len(fields) == 0 {
+		fields = defaultExportFields
+	}
+	selected := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if _, ok := exportFields[f]; !ok {
+			return nil, "", fmt.Errorf("unknown field %q — choose from %s", f, strings.Join(slices.Sorted(maps.Keys(exportFields)), ", "))
+		}
+		if !slices.Contains(selected, f) {
+			selected = append(selected, f)
+		}
+	}
+	slices.Sort(selected)
+	mask := []string{"metadata"} // always read, for deletions and update times
+	for _, f := range selected {
+		mask = append(mask, exportFields[f])
+	}
+	return selected, strings.Join(mask, ","), nil
+}
+
+// ContactRecord is one line of the export_contacts NDJSON stream. Deleted
+// records (from an incremental export) carry only the resource name.
+type ContactRecord struct {
+	ResourceName  string            `json:"resource_name"`
+	ETag          string            `json:"etag,omitempty"`
+	Deleted       bool              `json:"deleted,omitempty"`
+	UpdateTime    string            `json:"update_time,omitempty"`
+	DisplayName   string            `json:"display_name,omitempty"`
+	GivenName     string            `json:"given_name,omitempty"`
+	FamilyName    string            `json:"family_name,omitempty"`
+	Emails        []TypedValue      `json:"emails,omitempty"`
+	Phones        []TypedValue      `json:"phones,omitempty"`
+	Organizations []OrganizationRef `json:"organizations,omitempty"`
+	Addresses     []TypedValue      `json:"addresses,omitempty"`
+	Birthday      string            `json:"birthday,omitempty"`
+	URLs          []TypedValue      `json:"urls,omitempty"`
+	Notes         string            `json:"notes,omitempty"`
+	Groups        []string          `json:"groups,omitempty"`
+}
+
+// TypedValue is a contact value with its label, such as a work email.
+type TypedValue struct {
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// OrganizationRef is a contact's employer and role.
+type OrganizationRef struct {
+	Name       string `json:"name,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Department string `json:"department,omitempty"`
+}
+
+// personToRecord converts a person to an export record holding only the
+// fields the People API returned, which are the selected ones.
+func personToRecord(p *people.Person) ContactRecord {
+	r := ContactRecord{ResourceName: p.ResourceName, ETag: p.Etag}
+	if p.Metadata != nil {
+		r.Deleted = p.Metadata.Deleted
+		for _, s := range p.Metadata.Sources {
+			r.UpdateTime = max(r.UpdateTime, s.UpdateTime) // RFC 3339 sorts lexically
+		}
+	}
+	if r.Deleted {
+		return ContactRecord{ResourceName: p.ResourceName, Deleted: true}
+	}
+	if len(p.Names) > 0 {
+		r.DisplayName, r.GivenName, r.FamilyName = p.Names[0].DisplayName, p.Names[0].GivenName, p.Names[0].FamilyName
+	}
+	for _, e := range p.EmailAddresses {
+		r.Emails = append(r.Emails, TypedValue{Value: e.Value, Type: e.Type})
+	}
+	for _, ph := range p.PhoneNumbers {
+		r.Phones = append(r.Phones, TypedValue{Value: ph.Value, Type: ph.Type})
+	}
+	for _, o := range p.Organizations {
+		r.Organizations = append(r.Organizations, OrganizationRef{Name: o.Name, Title: o.Title, Department: o.Department})
+	}
+	for _, a := range p.Addresses {
+		r.Addresses = append(r.Addresses, TypedValue{Value: a.FormattedValue, Type: a.Type})
+	}
+	if len(p.Birthdays) > 0 && p.Birthdays[0].Date != nil {
+		d := p.Birthdays[0].Date
+		r.Birthday = formatBirthday(d.Year, d.Month, d.Day)
+	}
+	for _, u := range p.Urls {
+		r.URLs = append(r.URLs, TypedValue{Value: u.Value, Type: u.Type})
+	}
+	if len(p.Biographies) > 0 {
+		r.Notes = p.Biographies[0].Value
+	}
+	for _, m := range p.Memberships {
+		if m.ContactGroupMembership != nil {
+			r.Groups = append(r.Groups, m.ContactGroupMembership.ContactGroupResourceName)
+		}
+	}
+	return r
+}
+
+// formatBirthday formats a birthday as YYYY-MM-DD, or --MM-DD without a year.
+func formatBirthday(year, month, day int64) string {
+	if year == 0 {
+		return fmt.Sprintf("--%02d-%02d", month, day)
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+}
+
+// encodeNDJSON writes one JSON object per line.
+func encodeNDJSON(records []ContactRecord) (string, error) {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return "", fmt.Errorf("encoding contact %s: %w", r.ResourceName, err)
+		}
+	}
+	return b.String(), nil
+}
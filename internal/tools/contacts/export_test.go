@@ -0,0 +1,105 @@
+package contacts
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/people/v1"
+)
+
+func TestExportPersonFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		fields     []string
+		wantFields []string
+		wantMask   string
+		wantErr    bool
+	}{
+		{"default", nil, []string{"emails", "names", "organizations", "phones"}, "metadata,emailAddresses,names,organizations,phoneNumbers", false},
+		{"renamed and duplicated", []string{"Notes", "groups", "notes "}, []string{"groups", "notes"}, "metadata,memberships,biographies", false},
+		{"unknown", []string{"names", "salary"}, nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, mask, err := exportPersonFields(tt.fields)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) && !tt.wantErr {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+			if mask != tt.wantMask {
+				t.Errorf("mask = %q, want %q", mask, tt.wantMask)
+			}
+		})
+	}
+}
+
+func TestPersonToRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		person *people.Person
+		want   ContactRecord
+	}{
+		{
+			name: "full",
+			person: &people.Person{
+				ResourceName: "people/c1", Etag: "e1",
+				Metadata: &people.PersonMetadata{Sources: []*people.Source{
+					{UpdateTime: "2026-01-02T00:00:00Z"}, {UpdateTime: "2026-03-04T00:00:00Z"},
+				}},
+				Names:          []*people.Name{{DisplayName: "Ada Lovelace", GivenName: "Ada", FamilyName: "Lovelace"}},
+				EmailAddresses: []*people.EmailAddress{{Value: "ada@example.com", Type: "work"}},
+				Organizations:  []*people.Organization{{Name: "Analytical", Title: "Engineer"}},
+				Birthdays:      []*people.Birthday{{Date: &people.Date{Month: 12, Day: 10}}},
+				Memberships: []*people.Membership{
+					{ContactGroupMembership: &people.ContactGroupMembership{ContactGroupResourceName: "contactGroups/friends"}},
+					{DomainMembership: &people.DomainMembership{}},
+				},
+			},
+			want: ContactRecord{
+				ResourceName: "people/c1", ETag: "e1", UpdateTime: "2026-03-04T00:00:00Z",
+				DisplayName: "Ada Lovelace", GivenName: "Ada", FamilyName: "Lovelace",
+				Emails:        []TypedValue{{Value: "ada@example.com", Type: "work"}},
+				Organizations: []OrganizationRef{{Name: "Analytical", Title: "Engineer"}},
+				Birthday:      "--12-10",
+				Groups:        []string{"contactGroups/friends"},
+			},
+		},
+		{
+			name: "deleted",
+			person: &people.Person{
+				ResourceName: "people/c2", Etag: "e2",
+				Metadata: &people.PersonMetadata{Deleted: true},
+				Names:    []*people.Name{{DisplayName: "Gone"}},
+			},
+			want: ContactRecord{ResourceName: "people/c2", Deleted: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := personToRecord(tt.person); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("personToRecord() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeNDJSON(t *testing.T) {
+	got, err := encodeNDJSON([]ContactRecord{
+		{ResourceName: "people/c1", DisplayName: "Tom & Jerry"},
+		{ResourceName: "people/c2", Deleted: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"resource_name":"people/c1","display_name":"Tom & Jerry"}` + "\n" +
+		`{"resource_name":"people/c2","deleted":true}` + "\n"
+	if got != want {
+		t.Errorf("encodeNDJSON() =\n%s\nwant\n%s", got, want)
+	}
+	if empty, _ := encodeNDJSON(nil); strings.TrimSpace(empty) != "" {
+		t.Errorf("encodeNDJSON(nil) = %q, want empty", empty)
+	}
+}
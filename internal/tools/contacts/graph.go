@@ -0,0 +1,208 @@
+package contacts
+
+import (
+	"cmp"
+	"encoding/csv"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Caps for export_contact_graph. Messages are fetched one metadata request
+// at a time, so the message cap also bounds the tool's latency.
+const (
+	graphDefaultDays     = 90
+	graphMaxDays         = 365
+	graphDefaultMessages = 200
+	graphMaxMessages     = 1000
+	graphDefaultNodes    = 100
+	graphMaxNodes        = 500
+	graphMaxContacts     = 2000
+)
+
+// Edge kinds in the exported graph.
+const (
+	edgeDirect      = "direct"       // the user and a correspondent
+	edgeCoRecipient = "co_recipient" // two correspondents on the same message
+)
+
+// GraphNode is one correspondent. Sent and Received count messages from the
+// user to them and from them to the user.
+type GraphNode struct {
+	Email       string `json:"email"`
+	Name        string `json:"name,omitempty"`
+	InContacts  bool   `json:"in_contacts"`
+	Sent        int    `json:"sent"`
+	Received    int    `json:"received"`
+	LastContact string `json:"last_contact,omitempty"`
+}
+
+// GraphEdge is one weighted, undirected edge. Weight is the number of
+// messages linking Source and Target.
+type GraphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Kind   string `json:"kind"`
+	Weight int    `json:"weight"`
+}
+
+// graphMessage is the address metadata of one message.
+type graphMessage struct {
+	From       string
+	Recipients []string
+	Date       time.Time
+}
+
+type pairKey struct{ a, b string }
+
+// graphBuilder accumulates a correspondence graph centred on the user.
+type graphBuilder struct {
+	self     map[string]bool   // the user's own addresses
+	contacts map[string]string // lowercased email -> display name
+	nodes    map[string]*GraphNode
+	pairs    map[pairKey]int
+}
+
+func newGraphBuilder(selfEmail string, contacts map[string]string) *graphBuilder {
+	return &graphBuilder{
+		self:     map[string]bool{strings.ToLower(selfEmail): true},
+		contacts: contacts,
+		nodes:    make(map[string]*GraphNode),
+		pairs:    make(map[pairKey]int),
+	}
+}
+
+// add records one message. Messages the user neither sent nor received
+// directly are still used for co-recipient edges.
+func (g *graphBuilder) add(m graphMessage) {
+	from := normalizeAddress(m.From)
+	var others []string
+	for _, r := range m.Recipients {
+		if addr := normalizeAddress(r); addr != "" && !g.self[addr] && !isAutomatedAddress(addr) && !slices.Contains(others, addr) {
+			others = append(others, addr)
+		}
+	}
+
+	switch {
+	case g.self[from]:
+		for _, addr := range others {
+			g.node(addr, m.Date).Sent++
+		}
+	case from != "" && !isAutomatedAddress(from):
+		g.node(from, m.Date).Received++
+		others = append(others, from)
+	default:
+		return
+	}
+
+	for i, a := range others {
+		for _, b := range others[i+1:] {
+			g.pairs[orderedPair(a, b)]++
+		}
+	}
+}
+
+func (g *graphBuilder) node(addr string, when time.Time) *GraphNode {
+	n, ok := g.nodes[addr]
+	if !ok {
+		name, inContacts := g.contacts[addr]
+		n = &GraphNode{Email: addr, Name: name, InContacts: inContacts}
+		g.nodes[addr] = n
+	}
+	if day := when.Format(time.DateOnly); !when.IsZero() && day > n.LastContact {
+		n.LastContact = day
+	}
+	return n
+}
+
+// build returns the strongest maxNodes correspondents (optionally only
+// saved contacts) and the edges among them and the user, strongest first.
+func (g *graphBuilder) build(selfEmail string, maxNodes int, contactsOnly bool) ([]GraphNode, []GraphEdge) {
+	nodes := make([]GraphNode, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		if n.Sent+n.Received > 0 && (!contactsOnly || n.InContacts) {
+			nodes = append(nodes, *n)
+		}
+	}
+	slices.SortFunc(nodes, func(a, b GraphNode) int {
+		return cmp.Or(cmp.Compare(b.Sent+b.Received, a.Sent+a.Received), strings.Compare(a.Email, b.Email))
+	})
+	nodes = nodes[:min(maxNodes, len(nodes))]
+
+	kept := make(map[string]bool, len(nodes))
+	edges := make([]GraphEdge, 0, len(nodes))
+	for _, n := range nodes {
+		kept[n.Email] = true
+		edges = append(edges, GraphEdge{Source: selfEmail, Target: n.Email, Kind: edgeDirect, Weight: n.Sent + n.Received})
+	}
+	for p, w := range g.pairs {
+		if kept[p.a] && kept[p.b] {
+			edges = append(edges, GraphEdge{Source: p.a, Target: p.b, Kind: edgeCoRecipient, Weight: w})
+		}
+	}
+	slices.SortFunc(edges, func(a, b GraphEdge) int {
+		return cmp.Or(cmp.Compare(b.Weight, a.Weight), strings.Compare(a.Kind, b.Kind),
+			strings.Compare(a.Source, b.Source), strings.Compare(a.Target, b.Target))
+	})
+	// Co-recipient edges grow quadratically; keep the strongest.
+	return nodes, edges[:min(len(edges), maxNodes*5)]
+}
+
+func orderedPair(a, b string) pairKey {
+	if a > b {
+		a, b = b, a
+	}
+	return pairKey{a, b}
+}
+
+// parseAddressHeader returns the addresses in a From/To/Cc header value,
+// skipping entries that do not parse.
+func parseAddressHeader(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	if list, err := mail.ParseAddressList(value); err == nil {
+		out := make([]string, 0, len(list))
+		for _, a := range list {
+			out = append(out, a.Address)
+		}
+		return out
+	}
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		if a, err := mail.ParseAddress(strings.TrimSpace(part)); err == nil {
+			out = append(out, a.Address)
+		}
+	}
+	return out
+}
+
+func normalizeAddress(addr string) string {
+	return strings.ToLower(strings.TrimSpace(addr))
+}
+
+// isAutomatedAddress reports whether addr looks like a machine sender
+// rather than a person.
+func isAutomatedAddress(addr string) bool {
+	local, _, _ := strings.Cut(addr, "@")
+	for _, marker := range []string{"noreply", "no-reply", "donotreply", "do-not-reply", "mailer-daemon", "postmaster", "notifications"} {
+		if strings.Contains(local, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// graphCSV renders edges as a CSV edge list with a header row.
+func graphCSV(edges []GraphEdge) string {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	_ = w.Write([]string{"source", "target", "kind", "weight"})
+	for _, e := range edges {
+		_ = w.Write([]string{e.Source, e.Target, e.Kind, strconv.Itoa(e.Weight)})
+	}
+	w.Flush()
+	return sb.String()
+}
@@ -0,0 +1,103 @@
+package contacts
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+)
+
+func TestParseAddressHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{"empty", "", nil},
+		{"single", "Bob <bob@example.com>", []string{"bob@example.com"}},
+		{"list", `"Doe, Jane" <jane@example.com>, carol@example.org`, []string{"jane@example.com", "carol@example.org"}},
+		{"one malformed entry", "bob@example.com, not an address", []string{"bob@example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseAddressHeader(tt.value)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseAddressHeader(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsAutomatedAddress(t *testing.T) {
+	tests := map[string]bool{
+		"noreply@example.com":                true,
+		"calendar-notifications@example.com": true,
+		"mailer-daemon@example.com":          true,
+		"bob@example.com":                    false,
+		"bob@noreply.example.com":            false,
+	}
+	for addr, want := range tests {
+		if got := isAutomatedAddress(addr); got != want {
+			t.Errorf("isAutomatedAddress(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestGraphBuilder(t *testing.T) {
+	day := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	g := newGraphBuilder("Me@example.com", map[string]string{"bob@example.com": "Bob Smith"})
+	g.add(graphMessage{From: "me@example.com", Recipients: []string{"bob@example.com", "carol@example.com", "me@example.com"}, Date: day})
+	g.add(graphMessage{From: "Bob@Example.com", Recipients: []string{"me@example.com", "carol@example.com"}, Date: day.AddDate(0, 0, 1)})
+	g.add(graphMessage{From: "noreply@example.com", Recipients: []string{"me@example.com"}, Date: day})
+	g.add(graphMessage{From: "dave@example.com", Recipients: []string{"me@example.com", "no-reply@example.com"}, Date: day})
+
+	nodes, edges := g.build("me@example.com", 10, false)
+	if len(nodes) != 3 {
+		t.Fatalf("got %d nodes %+v, want 3", len(nodes), nodes)
+	}
+	bob := nodes[0]
+	if bob.Email != "bob@example.com" || bob.Sent != 1 || bob.Received != 1 || !bob.InContacts || bob.Name != "Bob Smith" || bob.LastContact != "2026-03-03" {
+		t.Errorf("top node = %+v", bob)
+	}
+	if edges[0].Kind != edgeCoRecipient || edges[0].Source != "bob@example.com" || edges[0].Target != "carol@example.com" || edges[0].Weight != 2 {
+		t.Errorf("strongest edge = %+v, want bob–carol co_recipient weight 2", edges[0])
+	}
+	if len(edges) != 4 {
+		t.Errorf("got %d edges %+v, want 3 direct + 1 co-recipient", len(edges), edges)
+	}
+
+	nodes, _ = g.build("me@example.com", 10, true)
+	if len(nodes) != 1 || nodes[0].Email != "bob@example.com" {
+		t.Errorf("contacts-only nodes = %+v", nodes)
+	}
+	nodes, edges = g.build("me@example.com", 1, false)
+	if len(nodes) != 1 || len(edges) != 1 || edges[0].Kind != edgeDirect {
+		t.Errorf("capped build = %+v, %+v", nodes, edges)
+	}
+}
+
+func TestToGraphMessage(t *testing.T) {
+	msg := &gmail.Message{InternalDate: 1767225600000, Payload: &gmail.MessagePart{Headers: []*gmail.MessagePartHeader{
+		{Name: "From", Value: "Bob <bob@example.com>"},
+		{Name: "To", Value: "me@example.com"},
+		{Name: "Cc", Value: "carol@example.com"},
+	}}}
+	m, ok := toGraphMessage(msg)
+	if !ok || m.From != "bob@example.com" || len(m.Recipients) != 2 || m.Date.Year() != 2026 {
+		t.Errorf("toGraphMessage = %+v, %v", m, ok)
+	}
+
+	msg.Payload.Headers = append(msg.Payload.Headers, &gmail.MessagePartHeader{Name: "List-Id", Value: "<news.example.com>"})
+	if _, ok := toGraphMessage(msg); ok {
+		t.Error("list mail should be skipped")
+	}
+}
+
+func TestGraphCSV(t *testing.T) {
+	got := graphCSV([]GraphEdge{{Source: "me@example.com", Target: "bob@example.com", Kind: edgeDirect, Weight: 3}})
+	want := "source,target,kind,weight\nme@example.com,bob@example.com,direct,3\n"
+	if got != want {
+		t.Errorf("graphCSV = %q, want %q", got, want)
+	}
+}
@@ -3,13 +3,18 @@ package contacts
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/people/v1"
 
 	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
 )
@@ -53,10 +58,15 @@ func createBatchCreateContactsHandler(factory *services.Factory) mcp.ToolHandler
 			})
 		}
 
+		p := progress.New(ctx, req).Begin("Creating contact batch", 1)
+		if err := p.Next(); err != nil {
+			return nil, nil, err
+		}
 		result, err := srv.People.BatchCreateContacts(batchReq).Context(ctx).Do()
 		if err != nil {
 			return nil, nil, middleware.HandleGoogleAPIError(err)
 		}
+		p.Done()
 
 		rb := response.New()
 		rb.Header("Batch Contacts Created")
@@ -112,10 +122,15 @@ func createBatchUpdateContactsHandler(factory *services.Factory) mcp.ToolHandler
 			batchReq.Contacts[rn] = p
 		}
 
+		p := progress.New(ctx, req).Begin("Updating contact batch", 1)
+		if err := p.Next(); err != nil {
+			return nil, nil, err
+		}
 		result, err := srv.People.BatchUpdateContacts(batchReq).Context(ctx).Do()
 		if err != nil {
 			return nil, nil, middleware.HandleGoogleAPIError(err)
 		}
+		p.Done()
 
 		rb := response.New()
 		rb.Header("Batch Contacts Updated")
@@ -143,10 +158,15 @@ func createBatchDeleteContactsHandler(factory *services.Factory) mcp.ToolHandler
 			ResourceNames: input.ResourceNames,
 		}
 
+		p := progress.New(ctx, req).Begin("Deleting contact batch", 1)
+		if err := p.Next(); err != nil {
+			return nil, nil, err
+		}
 		_, err = srv.People.BatchDeleteContacts(batchReq).Context(ctx).Do()
 		if err != nil {
 			return nil, nil, middleware.HandleGoogleAPIError(err)
 		}
+		p.Done()
 
 		rb := response.New()
 		rb.Header("Batch Contacts Deleted")
@@ -308,3 +328,350 @@ func createModifyGroupMembersHandler(factory *services.Factory) mcp.ToolHandlerF
 		return rb.TextResult(), nil, nil
 	}
 }
+
+// --- export_contact_graph (complete) ---
+
+type ExportContactGraphInput struct {
+	UserEmail    string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Days         int    `json:"days,omitempty" jsonschema_description:"Look-back period in days (default 90, max 365)"`
+	MaxMessages  int    `json:"max_messages,omitempty" jsonschema_description:"Most recent messages to inspect (default 200, max 1000)"`
+	MaxNodes     int    `json:"max_nodes,omitempty" jsonschema_description:"Strongest correspondents to keep (default 100, max 500)"`
+	ContactsOnly bool   `json:"contacts_only,omitempty" jsonschema_description:"Only include people saved in the user's contacts"`
+	Format       string `json:"format,omitempty" jsonschema_description:"Text output format (structured output always has nodes and edges),enum=json,enum=csv"`
+	Confirm      bool   `json:"confirm,omitempty" jsonschema_description:"Must be true, after the user has agreed to have their correspondence network mapped; otherwise the scope is only described"`
+}
+
+type ExportContactGraphOutput struct {
+	Days             int         `json:"days"`
+	MessagesScanned  int         `json:"messages_scanned"`
+	Nodes            []GraphNode `json:"nodes"`
+	Edges            []GraphEdge `json:"edges"`
+	ConsentRequested bool        `json:"consent_requested,omitempty"`
+	Partial          bool        `json:"partial,omitempty"`
+}
+
+func createExportContactGraphHandler(factory *services.Factory) mcp.ToolHandlerFor[ExportContactGraphInput, ExportContactGraphOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ExportContactGraphInput) (*mcp.CallToolResult, ExportContactGraphOutput, error) {
+		input.Days = clampDefault(input.Days, graphDefaultDays, graphMaxDays)
+		input.MaxMessages = clampDefault(input.MaxMessages, graphDefaultMessages, graphMaxMessages)
+		input.MaxNodes = clampDefault(input.MaxNodes, graphDefaultNodes, graphMaxNodes)
+		if input.Format != "" && input.Format != "json" && input.Format != "csv" {
+			return nil, ExportContactGraphOutput{}, fmt.Errorf("invalid format %q — use json or csv", input.Format)
+		}
+
+		if !input.Confirm {
+			rb := response.New()
+			rb.Header("Contact Graph Pending Consent")
+			rb.Line("This maps who %s corresponds with. It reads the From/To/Cc headers (never bodies) of up to %d messages from the last %d days, plus saved contacts, and returns up to %d people with message counts.",
+				input.UserEmail, input.MaxMessages, input.Days, input.MaxNodes)
+			rb.Blank()
+			rb.Line("Nothing was read. Ask the user to consent, then call again with confirm=true.")
+			return rb.TextResult(), ExportContactGraphOutput{Days: input.Days, Nodes: []GraphNode{}, Edges: []GraphEdge{}, ConsentRequested: true}, nil
+		}
+
+		p := progress.New(ctx, req)
+		contacts, err := loadContactEmails(ctx, factory, input.UserEmail, p)
+		if err != nil {
+			return nil, ExportContactGraphOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		messages, err := loadGraphMessages(ctx, factory, input, p)
+		if err != nil {
+			return nil, ExportContactGraphOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		p.Done()
+
+		g := newGraphBuilder(input.UserEmail, contacts)
+		for _, m := range messages {
+			g.add(m)
+		}
+		self := normalizeAddress(input.UserEmail)
+		nodes, edges := g.build(self, input.MaxNodes, input.ContactsOnly)
+		out := ExportContactGraphOutput{Days: input.Days, MessagesScanned: len(messages), Nodes: nodes, Edges: edges, Partial: p.Cancelled()}
+
+		rb := response.New()
+		rb.Header("Contact Graph")
+		rb.KeyValue("Period", fmt.Sprintf("last %d days", input.Days))
+		rb.KeyValue("Messages scanned", len(messages))
+		if out.Partial {
+			rb.KeyValue("Partial", p.Partial())
+		}
+		rb.KeyValue("People", len(nodes))
+		rb.KeyValue("Edges", len(edges))
+		rb.Blank()
+		if input.Format == "csv" {
+			rb.Raw(graphCSV(edges))
+			return rb.TextResult(), out, nil
+		}
+		data, err := json.MarshalIndent(struct {
+			Nodes []GraphNode `json:"nodes"`
+			Edges []GraphEdge `json:"edges"`
+		}{nodes, edges}, "", "  ")
+		if err != nil {
+			return nil, ExportContactGraphOutput{}, fmt.Errorf("encoding contact graph: %w", err)
+		}
+		rb.Raw(string(data))
+		return rb.TextResult(), out, nil
+	}
+}
+
+// clampDefault returns def when v is unset and caps v at limit.
+func clampDefault(v, def, limit int) int {
+	if v <= 0 {
+		return def
+	}
+	return min(v, limit)
+}
+
+// loadContactEmails maps each saved contact's email (lowercased) to its
+// display name, reading at most graphMaxContacts contacts.
+func loadContactEmails(ctx context.Context, factory *services.Factory, userEmail string, p *progress.Reporter) (map[string]string, error) {
+	srv, err := factory.People(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+	contacts := make(map[string]string)
+	seen := 0
+	p.Begin("Reading contacts page", 0)
+	err = srv.People.Connections.List("people/me").
+		PersonFields("names,emailAddresses").
+		PageSize(1000).
+		Context(ctx).
+		Pages(ctx, func(resp *people.ListConnectionsResponse) error {
+			if err := p.Next(); err != nil {
+				return err
+			}
+			for _, person := range resp.Connections {
+				cs := personToSummary(person)
+				for _, e := range cs.Emails {
+					contacts[normalizeAddress(e)] = cs.DisplayName
+				}
+			}
+			if seen += len(resp.Connections); seen >= graphMaxContacts {
+				return errStopPaging
+			}
+			return nil
+		})
+	if err != nil && !errors.Is(err, errStopPaging) {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// errStopPaging ends a Pages iteration early once a cap is reached.
+var errStopPaging = errors.New("page cap reached")
+
+// loadGraphMessages reads the address headers of the most recent messages in
+// the period, skipping chats and mailing-list mail. On cancellation it
+// returns the messages read so far.
+func loadGraphMessages(ctx context.Context, factory *services.Factory, input ExportContactGraphInput, p *progress.Reporter) ([]graphMessage, error) {
+	srv, err := factory.Gmail(ctx, input.UserEmail)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := listGraphMessageIDs(ctx, srv, input, p)
+	if err != nil {
+		if p.Cancelled() {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	messages := make([]graphMessage, 0, len(ids))
+	p.Begin("Reading message", len(ids))
+	for _, id := range ids {
+		if p.Next() != nil {
+			break
+		}
+		msg, err := srv.Users.Messages.Get(input.UserEmail, id).
+			Format("metadata").
+			MetadataHeaders("From", "To", "Cc", "List-Id").
+			Fields("internalDate,payload/headers").
+			Context(ctx).
+			Do()
+		if err != nil {
+			if p.Cancelled() {
+				break
+			}
+			return nil, err
+		}
+		if m, ok := toGraphMessage(msg); ok {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+// listGraphMessageIDs returns the IDs of up to input.MaxMessages recent
+// messages in the period, excluding chats.
+func listGraphMessageIDs(ctx context.Context, srv *gmail.Service, input ExportContactGraphInput, p *progress.Reporter) ([]string, error) {
+	var ids []string
+	p.Begin("Listing messages page", 0)
+	err := srv.Users.Messages.List(input.UserEmail).
+		Q(fmt.Sprintf("newer_than:%dd -in:chats", input.Days)).
+		MaxResults(int64(min(input.MaxMessages, 500))).
+		Pages(ctx, func(resp *gmail.ListMessagesResponse) error {
+			if err := p.Next(); err != nil {
+				return err
+			}
+			for _, m := range resp.Messages {
+				ids = append(ids, m.Id)
+			}
+			if len(ids) >= input.MaxMessages {
+				return errStopPaging
+			}
+			return nil
+		})
+	if err != nil && !errors.Is(err, errStopPaging) {
+		return nil, err
+	}
+	return ids[:min(len(ids), input.MaxMessages)], nil
+}
+
+// toGraphMessage extracts the participants of a message; list mail is
+// reported as not ok.
+func toGraphMessage(msg *gmail.Message) (graphMessage, bool) {
+	m := graphMessage{Date: time.UnixMilli(msg.InternalDate).UTC()}
+	if msg.Payload == nil {
+		return m, false
+	}
+	for _, h := range msg.Payload.Headers {
+		switch strings.ToLower(h.Name) {
+		case "list-id":
+			return m, false
+		case "from":
+			if addrs := parseAddressHeader(h.Value); len(addrs) > 0 {
+				m.From = addrs[0]
+			}
+		case "to", "cc":
+			m.Recipients = append(m.Recipients, parseAddressHeader(h.Value)...)
+		}
+	}
+	return m, m.From != ""
+}
+
+// --- export_contacts (complete) ---
+
+type ExportContactsInput struct {
+	UserEmail  string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	SyncToken  string   `json:"sync_token,omitempty" jsonschema_description:"next_sync_token from a previous complete export; only contacts changed or deleted since then are returned"`
+	PageToken  string   `json:"page_token,omitempty" jsonschema_description:"next_page_token from an export stopped at max_records; pass the same sync_token and fields"`
+	Fields     []string `json:"fields,omitempty" jsonschema_description:"Fields to export: names, emails, phones, organizations, addresses, birthdays, urls, notes, groups (default names, emails, phones, organizations)"`
+	MaxRecords int      `json:"max_records,omitempty" jsonschema_description:"Stop after the page that reaches this many records (default 1000, max 10000)"`
+}
+
+type ExportContactsOutput struct {
+	Records       int      `json:"records"`
+	Deleted       int      `json:"deleted"`
+	Fields        []string `json:"fields"`
+	Incremental   bool     `json:"incremental"`
+	NextPageToken string   `json:"next_page_token,omitempty"`
+	NextSyncToken string   `json:"next_sync_token,omitempty"`
+	NDJSON        string   `json:"ndjson"`
+	Partial       bool     `json:"partial,omitempty"`
+}
+
+func createExportContactsHandler(factory *services.Factory) mcp.ToolHandlerFor[ExportContactsInput, ExportContactsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ExportContactsInput) (*mcp.CallToolResult, ExportContactsOutput, error) {
+		fields, mask, err := exportPersonFields(input.Fields)
+		if err != nil {
+			return nil, ExportContactsOutput{}, err
+		}
+		srv, err := factory.People(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ExportContactsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		p := progress.New(ctx, req)
+		page, err := readContactPages(ctx, srv, input, mask, clampDefault(input.MaxRecords, exportDefaultRecords, exportMaxRecords), p)
+		if err != nil {
+			return nil, ExportContactsOutput{}, err
+		}
+		p.Done()
+		ndjson, err := encodeNDJSON(page.records)
+		if err != nil {
+			return nil, ExportContactsOutput{}, err
+		}
+		out := ExportContactsOutput{
+			Records: len(page.records), Fields: fields, Incremental: input.SyncToken != "",
+			NextPageToken: page.nextPageToken, NextSyncToken: page.nextSyncToken, NDJSON: ndjson, Partial: p.Cancelled(),
+		}
+		for _, r := range page.records {
+			if r.Deleted {
+				out.Deleted++
+			}
+		}
+
+		rb := response.New()
+		rb.Header("Contacts Export")
+		rb.KeyValue("Mode", map[bool]string{true: "incremental", false: "full"}[out.Incremental])
+		rb.KeyValue("Records", out.Records)
+		rb.KeyValue("Deleted", out.Deleted)
+		rb.KeyValue("Fields", strings.Join(fields, ", "))
+		if out.Partial {
+			rb.KeyValue("Partial", p.Partial())
+		}
+		if out.NextPageToken != "" {
+			rb.KeyValue("Next page token", out.NextPageToken)
+		}
+		if out.NextSyncToken != "" {
+			rb.KeyValue("Next sync token", out.NextSyncToken)
+		}
+		rb.Blank()
+		rb.Raw(strings.TrimSuffix(ndjson, "\n"))
+		return rb.TextResult(), out, nil
+	}
+}
+
+// contactPages is the result of reading connections page by page.
+type contactPages struct {
+	records       []ContactRecord
+	nextPageToken string // set when stopped at the record cap
+	nextSyncToken string // set once the last page was read
+}
+
+// readContactPages reads connections until the last page, or until the
+// page that reaches limit. On cancellation it returns what was read, with
+// no tokens, since the export must then be repeated.
+func readContactPages(ctx context.Context, srv *people.Service, input ExportContactsInput, mask string, limit int, p *progress.Reporter) (contactPages, error) {
+	var out contactPages
+	call := srv.People.Connections.List("people/me").
+		PersonFields(mask).
+		PageSize(int64(min(exportPageSize, limit))).
+		RequestSyncToken(true).
+		Context(ctx)
+	if input.SyncToken != "" {
+		call = call.SyncToken(input.SyncToken)
+	}
+	p.Begin("Reading contacts page", 0)
+	for token := input.PageToken; ; {
+		if p.Next() != nil {
+			return contactPages{records: out.records}, nil
+		}
+		resp, err := call.PageToken(token).Do()
+		if err != nil {
+			return contactPages{}, exportContactsError(err)
+		}
+		for _, person := range resp.Connections {
+			out.records = append(out.records, personToRecord(person))
+		}
+		switch {
+		case resp.NextPageToken == "":
+			out.nextSyncToken = resp.NextSyncToken
+			return out, nil
+		case len(out.records) >= limit:
+			out.nextPageToken = resp.NextPageToken
+			return out, nil
+		}
+		token = resp.NextPageToken
+	}
+}
+
+// exportContactsError explains an expired sync token, which the People API
+// issues after seven days, and translates other errors as usual.
+func exportContactsError(err error) error {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && strings.Contains(strings.ToUpper(apiErr.Error()), "EXPIRED_SYNC_TOKEN") {
+		return fmt.Errorf("sync token expired — sync tokens are valid for 7 days. Run a full export without sync_token and replace the stored data")
+	}
+	return middleware.HandleGoogleAPIError(err)
+}
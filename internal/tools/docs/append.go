@@ -0,0 +1,151 @@
+package docs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	docspb "google.golang.org/api/docs/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// appendAttempts bounds how often append_doc_text re-reads the document when
+// someone else edits it between the read and the write.
+const appendAttempts = 3
+
+// namedStyles are the paragraph named style types a caller may apply.
+var namedStyles = map[string]bool{
+	"NORMAL_TEXT": true, "TITLE": true, "SUBTITLE": true,
+	"HEADING_1": true, "HEADING_2": true, "HEADING_3": true,
+	"HEADING_4": true, "HEADING_5": true, "HEADING_6": true,
+}
+
+// --- append_doc_text (extended) ---
+
+type AppendDocTextInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID string `json:"document_id" jsonschema:"required" jsonschema_description:"The document ID"`
+	Text       string `json:"text" jsonschema:"required" jsonschema_description:"Text to add at the end of the document; newlines separate paragraphs"`
+	Style      string `json:"style,omitempty" jsonschema_description:"Named style for the new paragraphs: NORMAL_TEXT (default) HEADING_1 HEADING_2 HEADING_3 HEADING_4 HEADING_5 HEADING_6 TITLE SUBTITLE"`
+}
+
+type AppendDocTextOutput struct {
+	DocumentID string `json:"document_id"`
+	StartIndex int64  `json:"start_index"`
+	EndIndex   int64  `json:"end_index"`
+	Style      string `json:"style"`
+}
+
+func createAppendDocTextHandler(factory *services.Factory) mcp.ToolHandlerFor[AppendDocTextInput, AppendDocTextOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input AppendDocTextInput) (*mcp.CallToolResult, AppendDocTextOutput, error) {
+		input.Style = strings.ToUpper(input.Style)
+		if input.Style == "" {
+			input.Style = "NORMAL_TEXT"
+		}
+		if !namedStyles[input.Style] {
+			return nil, AppendDocTextOutput{}, fmt.Errorf("invalid style %q — use NORMAL_TEXT, TITLE, SUBTITLE, or HEADING_1 through HEADING_6", input.Style)
+		}
+		text := strings.TrimRight(input.Text, "\n")
+		if text == "" {
+			return nil, AppendDocTextOutput{}, fmt.Errorf("text is empty — nothing to append")
+		}
+
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, AppendDocTextOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		// The write is pinned to the revision the end index was read from, so
+		// a concurrent edit makes it fail instead of landing mid-document.
+		var start, end int64
+		for attempt := 1; ; attempt++ {
+			doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+			if err != nil {
+				return nil, AppendDocTextOutput{}, middleware.HandleGoogleAPIError(err)
+			}
+			var requests []*docspb.Request
+			requests, start, end = appendRequests(doc, text, input.Style)
+			_, err = srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{
+				Requests:     requests,
+				WriteControl: &docspb.WriteControl{RequiredRevisionId: doc.RevisionId},
+			}).Context(ctx).Do()
+			if err == nil {
+				break
+			}
+			if attempt == appendAttempts || !isRevisionConflict(err) {
+				return nil, AppendDocTextOutput{}, middleware.HandleGoogleAPIError(err)
+			}
+		}
+
+		out := AppendDocTextOutput{DocumentID: input.DocumentID, StartIndex: start, EndIndex: end, Style: input.Style}
+		rb := response.New()
+		rb.Header("Text Appended")
+		rb.KeyValue("Document ID", out.DocumentID)
+		rb.KeyValue("Style", out.Style)
+		rb.KeyValue("Range", fmt.Sprintf("%d-%d", out.StartIndex, out.EndIndex))
+		return rb.TextResult(), out, nil
+	}
+}
+
+// appendRequests returns the requests that add text as new paragraphs after
+// the last paragraph of doc's body, with the range the text occupies. The
+// new paragraphs get style and lose any bullet, rather than inheriting the
+// formatting of the paragraph they are split from.
+func appendRequests(doc *docspb.Document, text, style string) ([]*docspb.Request, int64, int64) {
+	// The body always ends with a paragraph whose final newline cannot be
+	// edited, so text goes just before it.
+	var last *docspb.StructuralElement
+	if doc.Body != nil && len(doc.Body.Content) > 0 {
+		last = doc.Body.Content[len(doc.Body.Content)-1]
+	}
+	at, empty := int64(1), true
+	if last != nil {
+		at = last.EndIndex - 1
+		empty = last.Paragraph == nil || last.StartIndex == at
+	}
+
+	start := at
+	if !empty {
+		// Start a new paragraph instead of extending the last one.
+		text = "\n" + text
+		start++
+	}
+	end := at + utf16Len(text)
+
+	requests := []*docspb.Request{
+		{InsertText: &docspb.InsertTextRequest{Text: text, Location: &docspb.Location{Index: at}}},
+		{UpdateParagraphStyle: &docspb.UpdateParagraphStyleRequest{
+			Range:          &docspb.Range{StartIndex: start, EndIndex: end},
+			ParagraphStyle: &docspb.ParagraphStyle{NamedStyleType: style},
+			Fields:         "namedStyleType",
+		}},
+		// "*" with an empty style drops character formatting carried over
+		// from the end of the previous paragraph.
+		{UpdateTextStyle: &docspb.UpdateTextStyleRequest{
+			Range:     &docspb.Range{StartIndex: start, EndIndex: end},
+			TextStyle: &docspb.TextStyle{},
+			Fields:    "*",
+		}},
+	}
+	if last != nil && last.Paragraph != nil && last.Paragraph.Bullet != nil {
+		requests = append(requests, &docspb.Request{DeleteParagraphBullets: &docspb.DeleteParagraphBulletsRequest{
+			Range: &docspb.Range{StartIndex: start, EndIndex: end},
+		}})
+	}
+	return requests, start, end
+}
+
+// isRevisionConflict reports whether a batch update failed because the
+// document changed after the revision named in its WriteControl.
+func isRevisionConflict(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusBadRequest &&
+		strings.Contains(strings.ToLower(apiErr.Message), "revision")
+}
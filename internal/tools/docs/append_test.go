@@ -0,0 +1,83 @@
+package docs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	docspb "google.golang.org/api/docs/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func TestAppendRequests(t *testing.T) {
+	item := bullet("ul", 0, "Last item")
+	item.StartIndex, item.EndIndex = 8, 18
+	doc := &docspb.Document{Body: &docspb.Body{Content: []*docspb.StructuralElement{
+		{EndIndex: 1, SectionBreak: &docspb.SectionBreak{}},
+		{StartIndex: 1, EndIndex: 8, Paragraph: &docspb.Paragraph{}},
+		item,
+	}}}
+
+	requests, start, end := appendRequests(doc, "Next\nSteps", "HEADING_2")
+	if start != 18 || end != 28 {
+		t.Errorf("range = %d-%d, want 18-28", start, end)
+	}
+	want := []*docspb.Request{
+		{InsertText: &docspb.InsertTextRequest{Text: "\nNext\nSteps", Location: &docspb.Location{Index: 17}}},
+		{UpdateParagraphStyle: &docspb.UpdateParagraphStyleRequest{
+			Range:          &docspb.Range{StartIndex: 18, EndIndex: 28},
+			ParagraphStyle: &docspb.ParagraphStyle{NamedStyleType: "HEADING_2"},
+			Fields:         "namedStyleType",
+		}},
+		{UpdateTextStyle: &docspb.UpdateTextStyleRequest{
+			Range:     &docspb.Range{StartIndex: 18, EndIndex: 28},
+			TextStyle: &docspb.TextStyle{},
+			Fields:    "*",
+		}},
+		{DeleteParagraphBullets: &docspb.DeleteParagraphBulletsRequest{
+			Range: &docspb.Range{StartIndex: 18, EndIndex: 28},
+		}},
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests =\n%+v\nwant\n%+v", requests, want)
+	}
+}
+
+func TestAppendRequestsEmptyDoc(t *testing.T) {
+	doc := &docspb.Document{Body: &docspb.Body{Content: []*docspb.StructuralElement{
+		{EndIndex: 1, SectionBreak: &docspb.SectionBreak{}},
+		{StartIndex: 1, EndIndex: 2, Paragraph: &docspb.Paragraph{}},
+	}}}
+
+	requests, start, end := appendRequests(doc, "Hello", "NORMAL_TEXT")
+	if start != 1 || end != 6 {
+		t.Errorf("range = %d-%d, want 1-6", start, end)
+	}
+	if got := requests[0].InsertText; got.Text != "Hello" || got.Location.Index != 1 {
+		t.Errorf("insert = %q at %d, want %q at 1", got.Text, got.Location.Index, "Hello")
+	}
+	if len(requests) != 3 {
+		t.Errorf("got %d requests, want 3 (no bullet removal)", len(requests))
+	}
+}
+
+func TestIsRevisionConflict(t *testing.T) {
+	conflict := &googleapi.Error{Code: http.StatusBadRequest, Message: "The required revision ID 'abc' does not match the latest revision."}
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{conflict, true},
+		{fmt.Errorf("batch update: %w", conflict), true},
+		{&googleapi.Error{Code: http.StatusBadRequest, Message: "Invalid requests[0].insertText: Index 40 must be less than the end index"}, false},
+		{&googleapi.Error{Code: http.StatusForbidden, Message: "revision"}, false},
+		{errors.New("revision"), false},
+	}
+	for _, tt := range tests {
+		if got := isRevisionConflict(tt.err); got != tt.want {
+			t.Errorf("isRevisionConflict(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -62,6 +62,69 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createExportDocToPDFHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_doc_to_html",
+		Icons:       serviceIcons,
+		Description: "Export a Google Doc as clean, self-contained, mobile-friendly HTML for publishing to wikis or CMSs. Google styling is stripped to semantic markup; images are inlined as data URIs or uploaded to a Drive folder.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Export Document to HTML",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createExportDocToHTMLHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_doc_to_markdown",
+		Icons:       serviceIcons,
+		Description: "Export a Google Doc as Markdown, keeping headings, bulleted and numbered lists, tables, links, bold, italic, strikethrough, and footnotes. Use instead of get_doc_content when document structure matters.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Export Document to Markdown",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createExportDocToMarkdownHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_doc_outline",
+		Icons:       serviceIcons,
+		Description: "Get the heading hierarchy of a Google Doc with each section's start and end index and word count, without the full content. Use to navigate long documents and target edits to a section.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Document Outline",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetDocOutlineHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_doc_suggestions",
+		Icons:       serviceIcons,
+		Description: "List pending suggestions (tracked changes) in a Google Doc: suggested insertions, deletions, and formatting changes with their text and index ranges in the body, headers, footers, and footnotes. The Docs API does not report suggestion authors.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Document Suggestions",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListDocSuggestionsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_doc_from_markdown",
+		Icons:       serviceIcons,
+		Description: "Create a new Google Doc from Markdown. Headings become heading styles, lists become bulleted or numbered lists, and pipe tables become Docs tables, with links, bold, italic, strikethrough, and code formatting kept. Use instead of create_doc plus batch edits to author a structured document.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Create Document from Markdown",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createCreateDocFromMarkdownHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_doc_from_template",
+		Icons:       serviceIcons,
+		Description: "Create a Google Doc by copying a template and filling its {{placeholders}} with text or images in one call. Reports how often each placeholder was replaced and any placeholders left unfilled.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Create Document from Template",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createCreateDocFromTemplateHandler(factory))
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "search_docs",
 		Icons:       serviceIcons,
@@ -94,10 +157,20 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createListDocsInFolderHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "append_doc_text",
+		Icons:       serviceIcons,
+		Description: "Append text to the end of a Google Doc as new paragraphs, optionally as a heading. Finds the end index itself and fails safe if the document changes mid-write; use instead of inspect_doc_structure plus modify_doc_text.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Append Document Text",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createAppendDocTextHandler(factory))
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "insert_doc_elements",
 		Icons:       serviceIcons,
-		Description: "Insert paragraphs or list items into a Google Doc at specified positions.",
+		Description: "Insert paragraphs or list items into a Google Doc at specified positions. Consecutive list items become one bulleted, numbered, or checkbox list with nesting levels.",
 		Annotations: &mcp.ToolAnnotations{
 			Title:         "Insert Document Elements",
 			OpenWorldHint: ptr.Bool(true),
@@ -115,6 +188,59 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createUpdateParagraphStyleHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_doc_named_range",
+		Icons:       serviceIcons,
+		Description: "Tag a span of a Google Doc with a named range, located by text or by index. Named ranges move with the text as the document changes, so later edits can target the section by name instead of character indexes.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Create Named Range",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createCreateNamedRangeHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_doc_named_ranges",
+		Icons:       serviceIcons,
+		Description: "List the named ranges in a Google Doc with their current indexes and text, plus the document's headings and heading IDs for use as internal link targets.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Named Ranges",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListNamedRangesHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_doc_named_range",
+		Icons:       serviceIcons,
+		Description: "Remove a named range from a Google Doc by name (every range with that name) or by ID. The tagged text is left unchanged.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Delete Named Range",
+			DestructiveHint: ptr.Bool(true),
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createDeleteNamedRangeHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "replace_doc_named_range_text",
+		Icons:       serviceIcons,
+		Description: "Replace the content of a named range in a Google Doc with new text, keeping the range so it can be updated again. Use to rewrite a tagged section without computing indexes.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Replace Named Range Text",
+			DestructiveHint: ptr.Bool(true),
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createReplaceNamedRangeTextHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "insert_doc_internal_link",
+		Icons:       serviceIcons,
+		Description: "Link text in a Google Doc to a heading or existing bookmark in the same document. The link text can be a named range, the first match of some text, or new text inserted at an index. The Docs API cannot create bookmarks; use named ranges to tag sections.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Insert Internal Link",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createInsertInternalLinkHandler(factory))
+
 	// --- Complete tools ---
 
 	mcp.AddTool(server, &mcp.Tool{
@@ -130,7 +256,7 @@ func Register(server *mcp.Server, factory *services.Factory) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "update_doc_headers_footers",
 		Icons:       serviceIcons,
-		Description: "Add, update, or remove headers and footers in a Google Doc.",
+		Description: "Add, replace, or remove the default header and footer text in a Google Doc. Missing headers and footers are created before text is added.",
 		Annotations: &mcp.ToolAnnotations{
 			Title:          "Update Headers/Footers",
 			IdempotentHint: true,
@@ -138,6 +264,26 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createUpdateHeadersFootersHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "insert_doc_footnote",
+		Icons:       serviceIcons,
+		Description: "Add a footnote to a Google Doc, placing its reference after a given piece of body text or at an index, and fill in the footnote text.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Insert Footnote",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createInsertDocFootnoteHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_doc_page_setup",
+		Icons:       serviceIcons,
+		Description: "Update a Google Doc's page setup for print-ready output: paper size and orientation, margins, header and footer margins, first page number, a distinct first-page header and footer, and section breaks. The Docs API cannot insert page number fields; set page_number_start for fields already in a header or footer.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Update Page Setup",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createUpdateDocPageSetupHandler(factory))
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "batch_update_doc",
 		Icons:       serviceIcons,
@@ -180,6 +326,17 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createDebugTableStructureHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "modify_doc_table",
+		Icons:       serviceIcons,
+		Description: "Change an existing table in a Google Doc: insert or delete a row or column, merge or unmerge cells, or set cell background color and borders. Tables and cells are addressed by position, as shown by debug_table_structure.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Modify Document Table",
+			DestructiveHint: ptr.Bool(true),
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createModifyDocTableHandler(factory))
+
 	// --- Comment tools (via shared Drive API) ---
 	comments.Register(server, factory, "document", serviceIcons)
 }
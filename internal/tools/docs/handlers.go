@@ -1,6 +1,7 @@
 package docs
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"strings"
@@ -9,7 +10,9 @@ import (
 	docspb "google.golang.org/api/docs/v1"
 
 	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/htmlutil"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/rollback"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/validate"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
 )
@@ -17,18 +20,24 @@ import (
 // --- get_doc_content (core) ---
 
 type GetDocContentInput struct {
-	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
-	DocumentID string `json:"document_id" jsonschema:"required" jsonschema_description:"The Google Docs document ID"`
+	UserEmail           string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID          string `json:"document_id" jsonschema:"required" jsonschema_description:"The Google Docs document ID"`
+	SuggestionsViewMode string `json:"suggestions_view_mode,omitempty" jsonschema_description:"How to render pending suggestions: DEFAULT_FOR_CURRENT_ACCESS (default) SUGGESTIONS_INLINE PREVIEW_SUGGESTIONS_ACCEPTED PREVIEW_WITHOUT_SUGGESTIONS"`
 }
 
 func createGetDocContentHandler(factory *services.Factory) mcp.ToolHandlerFor[GetDocContentInput, DocContentOutput] {
 	return func(ctx context.Context, req *mcp.CallToolRequest, input GetDocContentInput) (*mcp.CallToolResult, DocContentOutput, error) {
+		mode, err := parseSuggestionsViewMode(input.SuggestionsViewMode)
+		if err != nil {
+			return nil, DocContentOutput{}, err
+		}
+
 		srv, err := factory.Docs(ctx, input.UserEmail)
 		if err != nil {
 			return nil, DocContentOutput{}, middleware.HandleGoogleAPIError(err)
 		}
 
-		doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+		doc, err := getDoc(ctx, srv, input.DocumentID, mode)
 		if err != nil {
 			return nil, DocContentOutput{}, middleware.HandleGoogleAPIError(err)
 		}
@@ -65,10 +74,12 @@ func createCreateDocHandler(factory *services.Factory) mcp.ToolHandlerFor[Create
 			Title: input.Title,
 		}
 
+		var tx rollback.Tx
 		created, err := srv.Documents.Create(doc).Context(ctx).Do()
 		if err != nil {
 			return nil, nil, middleware.HandleGoogleAPIError(err)
 		}
+		tx.Record("document "+created.DocumentId, trashDoc(factory, input.UserEmail, created.DocumentId))
 
 		// If initial content was provided, insert it
 		if input.Content != "" {
@@ -86,9 +97,12 @@ func createCreateDocHandler(factory *services.Factory) mcp.ToolHandlerFor[Create
 			}
 			_, err = srv.Documents.BatchUpdate(created.DocumentId, insertReq).Context(ctx).Do()
 			if err != nil {
-				return nil, nil, middleware.HandleGoogleAPIError(err)
+				// Don't leave an empty document behind when the content step fails.
+				return nil, nil, tx.Fail(ctx, middleware.HandleGoogleAPIError(err))
 			}
 		}
+		tx.Commit()
+		factory.StampDriveFile(ctx, input.UserEmail, created.DocumentId, factory.Provenance(req))
 
 		rb := response.New()
 		rb.Header("Document Created")
@@ -100,6 +114,18 @@ func createCreateDocHandler(factory *services.Factory) mcp.ToolHandlerFor[Create
 	}
 }
 
+// trashDoc returns an UndoFunc that trashes a document through the Drive API,
+// since the Docs API cannot delete documents.
+func trashDoc(factory *services.Factory, userEmail, docID string) rollback.UndoFunc {
+	return func(ctx context.Context) error {
+		srv, err := factory.Drive(ctx, userEmail)
+		if err != nil {
+			return err
+		}
+		return rollback.TrashDriveFile(srv, docID)(ctx)
+	}
+}
+
 // --- modify_doc_text (core) ---
 
 type ModifyDocTextInput struct {
@@ -234,6 +260,166 @@ func createExportDocToPDFHandler(factory *services.Factory) mcp.ToolHandlerFor[E
 	}
 }
 
+// --- export_doc_to_html (extended) ---
+
+type ExportDocToHTMLInput struct {
+	UserEmail     string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID    string `json:"document_id" jsonschema:"required" jsonschema_description:"The document ID to export"`
+	Images        string `json:"images,omitempty" jsonschema_description:"How to publish images: inline (embedded data URIs, default) or drive (uploaded to image_folder_id and linked),enum=inline,enum=drive"`
+	ImageFolderID string `json:"image_folder_id,omitempty" jsonschema_description:"Drive folder for uploaded images when images=drive; its sharing settings decide who can see them"`
+	BodyOnly      bool   `json:"body_only,omitempty" jsonschema_description:"Return only the body markup for pasting into a wiki or CMS instead of a standalone page"`
+}
+
+type ExportDocToHTMLOutput struct {
+	DocumentID string `json:"document_id"`
+	Title      string `json:"title"`
+	HTML       string `json:"html"`
+	Images     int    `json:"images"`
+	ImageMode  string `json:"image_mode"`
+}
+
+func createExportDocToHTMLHandler(factory *services.Factory) mcp.ToolHandlerFor[ExportDocToHTMLInput, ExportDocToHTMLOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ExportDocToHTMLInput) (*mcp.CallToolResult, ExportDocToHTMLOutput, error) {
+		mode := cmp.Or(input.Images, imagesInline)
+		if mode != imagesInline && mode != imagesDrive {
+			return nil, ExportDocToHTMLOutput{}, fmt.Errorf("invalid images %q — use inline or drive", input.Images)
+		}
+		if mode == imagesDrive && input.ImageFolderID == "" {
+			return nil, ExportDocToHTMLOutput{}, fmt.Errorf("image_folder_id is required when images=drive")
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ExportDocToHTMLOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		export, err := downloadHTMLExport(ctx, srv, input.DocumentID)
+		if err != nil {
+			return nil, ExportDocToHTMLOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		var tx rollback.Tx
+		pub := &imagePublisher{ctx: ctx, images: export.Images, mode: mode, srv: srv, folderID: input.ImageFolderID, prefix: input.DocumentID, stamp: factory.Provenance(req), tx: &tx}
+		cleaned, err := htmlutil.Clean(export.HTML, pub.resolve)
+		if err != nil {
+			return nil, ExportDocToHTMLOutput{}, tx.Fail(ctx, middleware.HandleGoogleAPIError(err))
+		}
+		tx.Commit()
+
+		out := ExportDocToHTMLOutput{DocumentID: input.DocumentID, Title: cleaned.Title, HTML: cleaned.Page(), Images: pub.count, ImageMode: mode}
+		if input.BodyOnly {
+			out.HTML = cleaned.Body
+		}
+
+		rb := response.New()
+		rb.Header("Document Exported as HTML")
+		rb.KeyValue("Title", out.Title)
+		rb.KeyValue("Document ID", out.DocumentID)
+		rb.KeyValue("Images", fmt.Sprintf("%d (%s)", out.Images, mode))
+		rb.KeyValue("Size", fmt.Sprintf("%d bytes", len(out.HTML)))
+		rb.Blank()
+		rb.Raw(out.HTML)
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- export_doc_to_markdown (extended) ---
+
+type ExportDocToMarkdownInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID string `json:"document_id" jsonschema:"required" jsonschema_description:"The document ID to export"`
+}
+
+type ExportDocToMarkdownOutput struct {
+	DocumentID string `json:"document_id"`
+	Title      string `json:"title"`
+	Markdown   string `json:"markdown"`
+	Images     int    `json:"images"`
+}
+
+func createExportDocToMarkdownHandler(factory *services.Factory) mcp.ToolHandlerFor[ExportDocToMarkdownInput, ExportDocToMarkdownOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ExportDocToMarkdownInput) (*mcp.CallToolResult, ExportDocToMarkdownOutput, error) {
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ExportDocToMarkdownOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+		if err != nil {
+			return nil, ExportDocToMarkdownOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		markdown, images := docToMarkdown(doc)
+		out := ExportDocToMarkdownOutput{DocumentID: doc.DocumentId, Title: doc.Title, Markdown: markdown, Images: images}
+
+		rb := response.New()
+		rb.Header("Document Exported as Markdown")
+		rb.KeyValue("Title", out.Title)
+		rb.KeyValue("Document ID", out.DocumentID)
+		if out.Images > 0 {
+			// Docs image content URIs are signed and short-lived.
+			rb.KeyValue("Images", fmt.Sprintf("%d (links expire after about 30 minutes; use export_doc_to_html to keep them)", out.Images))
+		}
+		rb.Blank()
+		rb.Raw(out.Markdown)
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- create_doc_from_markdown (extended) ---
+
+type CreateDocFromMarkdownInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Title     string `json:"title" jsonschema:"required" jsonschema_description:"Title for the new document"`
+	Markdown  string `json:"markdown" jsonschema:"required" jsonschema_description:"Document body as Markdown: headings, paragraphs, bulleted and numbered lists, tables, fenced code blocks, block quotes, links, bold, italic, strikethrough, and inline code"`
+}
+
+type CreateDocFromMarkdownOutput struct {
+	DocumentID string `json:"document_id"`
+	Title      string `json:"title"`
+	Link       string `json:"link"`
+}
+
+func createCreateDocFromMarkdownHandler(factory *services.Factory) mcp.ToolHandlerFor[CreateDocFromMarkdownInput, CreateDocFromMarkdownOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateDocFromMarkdownInput) (*mcp.CallToolResult, CreateDocFromMarkdownOutput, error) {
+		blocks := parseMarkdown(input.Markdown)
+		if len(blocks) == 0 {
+			return nil, CreateDocFromMarkdownOutput{}, fmt.Errorf("markdown has no content — use create_doc for an empty document")
+		}
+
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, CreateDocFromMarkdownOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		var tx rollback.Tx
+		created, err := srv.Documents.Create(&docspb.Document{Title: input.Title}).Context(ctx).Do()
+		if err != nil {
+			return nil, CreateDocFromMarkdownOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		tx.Record("document "+created.DocumentId, trashDoc(factory, input.UserEmail, created.DocumentId))
+
+		if err := writeMarkdown(ctx, srv, created.DocumentId, blocks); err != nil {
+			// A half-written document is worse than none.
+			return nil, CreateDocFromMarkdownOutput{}, tx.Fail(ctx, middleware.HandleGoogleAPIError(err))
+		}
+		tx.Commit()
+		factory.StampDriveFile(ctx, input.UserEmail, created.DocumentId, factory.Provenance(req))
+
+		out := CreateDocFromMarkdownOutput{
+			DocumentID: created.DocumentId,
+			Title:      created.Title,
+			Link:       fmt.Sprintf("https://docs.google.com/document/d/%s/edit", created.DocumentId),
+		}
+		rb := response.New()
+		rb.Header("Document Created from Markdown")
+		rb.KeyValue("Title", out.Title)
+		rb.KeyValue("Document ID", out.DocumentID)
+		rb.KeyValue("Blocks", len(blocks))
+		rb.KeyValue("Link", out.Link)
+		return rb.TextResult(), out, nil
+	}
+}
+
 // --- search_docs (extended) ---
 
 type SearchDocsInput struct {
@@ -427,34 +613,52 @@ type InsertDocElementsInput struct {
 
 // DocElement represents a document element to insert.
 type DocElement struct {
-	Type  string `json:"type" jsonschema:"required" jsonschema_description:"Element type: paragraph or list_item,enum=paragraph,enum=list_item"`
-	Text  string `json:"text" jsonschema:"required" jsonschema_description:"Text content"`
-	Index int64  `json:"index" jsonschema:"required" jsonschema_description:"Insertion index (1-based)"`
+	Type         string `json:"type" jsonschema:"required" jsonschema_description:"Element type: paragraph or list_item,enum=paragraph,enum=list_item"`
+	Text         string `json:"text" jsonschema:"required" jsonschema_description:"Text content"`
+	Index        int64  `json:"index" jsonschema:"required" jsonschema_description:"Insertion index (1-based)"`
+	ListStyle    string `json:"list_style,omitempty" jsonschema_description:"For list_item: bullet (default), numbered, checkbox, or a Docs bullet preset such as NUMBERED_UPPERALPHA_ALPHA_ROMAN"`
+	NestingLevel int    `json:"nesting_level,omitempty" jsonschema_description:"For list_item: nesting level from 0 (top) to 8"`
 }
 
+// listStyles maps list_style shorthands to Docs bullet presets.
+var listStyles = map[string]string{
+	"BULLET":   "BULLET_DISC_CIRCLE_SQUARE",
+	"NUMBERED": "NUMBERED_DECIMAL_ALPHA_ROMAN",
+	"CHECKBOX": "BULLET_CHECKBOX",
+}
+
+// bulletPresets are the presets CreateParagraphBulletsRequest accepts.
+var bulletPresets = map[string]bool{
+	"BULLET_DISC_CIRCLE_SQUARE":              true,
+	"BULLET_DIAMONDX_ARROW3D_SQUARE":         true,
+	"BULLET_CHECKBOX":                        true,
+	"BULLET_ARROW_DIAMOND_DISC":              true,
+	"BULLET_STAR_CIRCLE_SQUARE":              true,
+	"BULLET_ARROW3D_CIRCLE_SQUARE":           true,
+	"BULLET_LEFTTRIANGLE_DIAMOND_DISC":       true,
+	"BULLET_DIAMONDX_HOLLOWDIAMOND_SQUARE":   true,
+	"BULLET_DIAMOND_CIRCLE_SQUARE":           true,
+	"NUMBERED_DECIMAL_ALPHA_ROMAN":           true,
+	"NUMBERED_DECIMAL_ALPHA_ROMAN_PARENS":    true,
+	"NUMBERED_DECIMAL_NESTED":                true,
+	"NUMBERED_UPPERALPHA_ALPHA_ROMAN":        true,
+	"NUMBERED_UPPERROMAN_UPPERALPHA_DECIMAL": true,
+	"NUMBERED_ZERODECIMAL_ALPHA_ROMAN":       true,
+}
+
+// maxNestingLevel is the deepest list level Docs supports.
+const maxNestingLevel = 8
+
 func createInsertDocElementsHandler(factory *services.Factory) mcp.ToolHandlerFor[InsertDocElementsInput, any] {
 	return func(ctx context.Context, req *mcp.CallToolRequest, input InsertDocElementsInput) (*mcp.CallToolResult, any, error) {
-		srv, err := factory.Docs(ctx, input.UserEmail)
+		requests, err := docElementRequests(input.Elements)
 		if err != nil {
-			return nil, nil, middleware.HandleGoogleAPIError(err)
+			return nil, nil, err
 		}
 
-		// Build requests in reverse order to maintain correct indices
-		requests := make([]*docspb.Request, 0, len(input.Elements))
-		for i := len(input.Elements) - 1; i >= 0; i-- {
-			elem := input.Elements[i]
-			text := elem.Text
-			if !strings.HasSuffix(text, "\n") {
-				text += "\n"
-			}
-			requests = append(requests, &docspb.Request{
-				InsertText: &docspb.InsertTextRequest{
-					Text: text,
-					Location: &docspb.Location{
-						Index: elem.Index,
-					},
-				},
-			})
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
 		}
 
 		_, err = srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{
@@ -473,6 +677,84 @@ func createInsertDocElementsHandler(factory *services.Factory) mcp.ToolHandlerFo
 	}
 }
 
+// elementRun is text inserted with one request: a paragraph, or consecutive
+// list items of the same style that become one list.
+type elementRun struct {
+	index  int64
+	text   strings.Builder
+	length int64 // as the caller counts it, without nesting tabs
+	preset string
+}
+
+// docElementRequests builds the requests for elements. Consecutive list
+// items with the same style that follow on from each other — at the same
+// index or where the previous item ends — are inserted together and turned
+// into one list, so numbering continues and nesting levels apply. Nesting is
+// expressed as leading tabs, which CreateParagraphBullets converts and
+// removes. Runs are emitted last to first so earlier indexes stay valid.
+func docElementRequests(elements []DocElement) ([]*docspb.Request, error) {
+	var runs []*elementRun
+	for i, elem := range elements {
+		text := elem.Text
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+
+		var preset string
+		switch elem.Type {
+		case "paragraph":
+		case "list_item":
+			style := strings.ToUpper(cmp.Or(elem.ListStyle, "bullet"))
+			preset = cmp.Or(listStyles[style], style)
+			if !bulletPresets[preset] {
+				return nil, fmt.Errorf("elements[%d]: invalid list_style %q — use bullet, numbered, checkbox, or a Docs bullet preset", i, elem.ListStyle)
+			}
+			if elem.NestingLevel < 0 || elem.NestingLevel > maxNestingLevel {
+				return nil, fmt.Errorf("elements[%d]: invalid nesting_level %d — use 0 to %d", i, elem.NestingLevel, maxNestingLevel)
+			}
+			if strings.Contains(strings.TrimSuffix(text, "\n"), "\n") {
+				return nil, fmt.Errorf("elements[%d]: list item text contains a newline — use one element per item", i)
+			}
+		default:
+			return nil, fmt.Errorf("elements[%d]: invalid type %q — use paragraph or list_item", i, elem.Type)
+		}
+
+		if n := len(runs); preset != "" && n > 0 && runs[n-1].preset == preset {
+			if prev := runs[n-1]; elem.Index == prev.index || elem.Index == prev.index+prev.length {
+				prev.text.WriteString(strings.Repeat("\t", elem.NestingLevel) + text)
+				prev.length += utf16Len(text)
+				continue
+			}
+		}
+		run := &elementRun{index: elem.Index, length: utf16Len(text), preset: preset}
+		run.text.WriteString(strings.Repeat("\t", elem.NestingLevel) + text)
+		runs = append(runs, run)
+	}
+
+	requests := make([]*docspb.Request, 0, len(runs)*2)
+	for i := len(runs) - 1; i >= 0; i-- {
+		run := runs[i]
+		text := run.text.String()
+		requests = append(requests, &docspb.Request{
+			InsertText: &docspb.InsertTextRequest{
+				Text: text,
+				Location: &docspb.Location{
+					Index: run.index,
+				},
+			},
+		})
+		if run.preset != "" {
+			requests = append(requests, &docspb.Request{
+				CreateParagraphBullets: &docspb.CreateParagraphBulletsRequest{
+					Range:        &docspb.Range{StartIndex: run.index, EndIndex: run.index + utf16Len(text)},
+					BulletPreset: run.preset,
+				},
+			})
+		}
+	}
+	return requests, nil
+}
+
 // --- update_paragraph_style (extended) ---
 
 type UpdateParagraphStyleInput struct {
@@ -1,6 +1,7 @@
 package docs
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/evert/google-workspace-mcp-go/internal/middleware"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/rollback"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
 )
 
@@ -78,14 +80,19 @@ func createInsertDocImageHandler(factory *services.Factory) mcp.ToolHandlerFor[I
 type UpdateHeadersFootersInput struct {
 	UserEmail    string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
 	DocumentID   string `json:"document_id" jsonschema:"required" jsonschema_description:"The Google Doc document ID"`
-	HeaderText   string `json:"header_text,omitempty" jsonschema_description:"Text to insert into the default header"`
-	FooterText   string `json:"footer_text,omitempty" jsonschema_description:"Text to insert into the default footer"`
+	HeaderText   string `json:"header_text,omitempty" jsonschema_description:"Text to insert into the default header, which is created if missing"`
+	FooterText   string `json:"footer_text,omitempty" jsonschema_description:"Text to insert into the default footer, which is created if missing"`
+	Replace      bool   `json:"replace,omitempty" jsonschema_description:"Replace the existing header or footer text instead of inserting before it"`
 	RemoveHeader bool   `json:"remove_header,omitempty" jsonschema_description:"Remove the default header"`
 	RemoveFooter bool   `json:"remove_footer,omitempty" jsonschema_description:"Remove the default footer"`
 }
 
 func createUpdateHeadersFootersHandler(factory *services.Factory) mcp.ToolHandlerFor[UpdateHeadersFootersInput, any] {
 	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateHeadersFootersInput) (*mcp.CallToolResult, any, error) {
+		if !input.RemoveHeader && !input.RemoveFooter && input.HeaderText == "" && input.FooterText == "" {
+			return nil, nil, fmt.Errorf("no header/footer changes specified - set header_text, footer_text, remove_header, or remove_footer")
+		}
+
 		srv, err := factory.Docs(ctx, input.UserEmail)
 		if err != nil {
 			return nil, nil, middleware.HandleGoogleAPIError(err)
@@ -96,96 +103,184 @@ func createUpdateHeadersFootersHandler(factory *services.Factory) mcp.ToolHandle
 		if err != nil {
 			return nil, nil, middleware.HandleGoogleAPIError(err)
 		}
+		style := doc.DocumentStyle
+		if style == nil {
+			style = &docspb.DocumentStyle{}
+		}
+		headerID, footerID := style.DefaultHeaderId, style.DefaultFooterId
+
+		// Headers and footers to add text to must exist first, and their IDs
+		// are only known from the reply, so they are created in a batch of
+		// their own.
+		var create []*docspb.Request
+		if !input.RemoveHeader && input.HeaderText != "" && headerID == "" {
+			create = append(create, &docspb.Request{CreateHeader: &docspb.CreateHeaderRequest{Type: "DEFAULT"}})
+		}
+		if !input.RemoveFooter && input.FooterText != "" && footerID == "" {
+			create = append(create, &docspb.Request{CreateFooter: &docspb.CreateFooterRequest{Type: "DEFAULT"}})
+		}
+		if len(create) > 0 {
+			resp, err := srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{Requests: create}).Context(ctx).Do()
+			if err != nil {
+				return nil, nil, middleware.HandleGoogleAPIError(err)
+			}
+			for _, reply := range resp.Replies {
+				switch {
+				case reply.CreateHeader != nil:
+					headerID = reply.CreateHeader.HeaderId
+				case reply.CreateFooter != nil:
+					footerID = reply.CreateFooter.FooterId
+				}
+			}
+		}
 
 		var requests []*docspb.Request
+		switch {
+		case input.RemoveHeader && headerID != "":
+			requests = append(requests, &docspb.Request{DeleteHeader: &docspb.DeleteHeaderRequest{HeaderId: headerID}})
+		case !input.RemoveHeader && input.HeaderText != "":
+			requests = append(requests, segmentTextRequests(headerID, doc.Headers[headerID].Content, input.HeaderText, input.Replace)...)
+		}
+		switch {
+		case input.RemoveFooter && footerID != "":
+			requests = append(requests, &docspb.Request{DeleteFooter: &docspb.DeleteFooterRequest{FooterId: footerID}})
+		case !input.RemoveFooter && input.FooterText != "":
+			requests = append(requests, segmentTextRequests(footerID, doc.Footers[footerID].Content, input.FooterText, input.Replace)...)
+		}
 
-		// Handle header
-		if input.RemoveHeader {
-			if doc.DocumentStyle != nil && doc.DocumentStyle.DefaultHeaderId != "" {
-				requests = append(requests, &docspb.Request{
-					DeleteHeader: &docspb.DeleteHeaderRequest{
-						HeaderId: doc.DocumentStyle.DefaultHeaderId,
-					},
-				})
-			}
-		} else if input.HeaderText != "" {
-			headerID := ""
-			if doc.DocumentStyle != nil {
-				headerID = doc.DocumentStyle.DefaultHeaderId
-			}
-			if headerID == "" {
-				// Create a header first
-				requests = append(requests, &docspb.Request{
-					CreateHeader: &docspb.CreateHeaderRequest{
-						Type: "DEFAULT",
-					},
-				})
-			} else {
-				// Insert text into existing header
-				requests = append(requests, &docspb.Request{
-					InsertText: &docspb.InsertTextRequest{
-						Text: input.HeaderText,
-						Location: &docspb.Location{
-							SegmentId: headerID,
-							Index:     0,
-						},
-					},
-				})
+		if len(requests) > 0 {
+			_, err = srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{Requests: requests}).Context(ctx).Do()
+			if err != nil {
+				return nil, nil, middleware.HandleGoogleAPIError(err)
 			}
 		}
 
-		// Handle footer
-		if input.RemoveFooter {
-			if doc.DocumentStyle != nil && doc.DocumentStyle.DefaultFooterId != "" {
-				requests = append(requests, &docspb.Request{
-					DeleteFooter: &docspb.DeleteFooterRequest{
-						FooterId: doc.DocumentStyle.DefaultFooterId,
-					},
-				})
-			}
-		} else if input.FooterText != "" {
-			footerID := ""
-			if doc.DocumentStyle != nil {
-				footerID = doc.DocumentStyle.DefaultFooterId
-			}
-			if footerID == "" {
-				requests = append(requests, &docspb.Request{
-					CreateFooter: &docspb.CreateFooterRequest{
-						Type: "DEFAULT",
-					},
-				})
-			} else {
-				requests = append(requests, &docspb.Request{
-					InsertText: &docspb.InsertTextRequest{
-						Text: input.FooterText,
-						Location: &docspb.Location{
-							SegmentId: footerID,
-							Index:     0,
-						},
-					},
-				})
-			}
+		rb := response.New()
+		rb.Header("Headers/Footers Updated")
+		rb.KeyValue("Document ID", input.DocumentID)
+		rb.KeyValue("Changes Applied", len(create)+len(requests))
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// segmentTextRequests inserts text at the start of a header, footer, or
+// footnote segment, first deleting its current text when replace is set.
+// Segment indexes start at 0, and the final newline cannot be deleted.
+// content is nil for a segment created since the document was read, which
+// holds only that newline.
+func segmentTextRequests(segmentID string, content []*docspb.StructuralElement, text string, replace bool) []*docspb.Request {
+	var start, end int64
+	if len(content) > 0 {
+		start = content[0].StartIndex
+		end = content[len(content)-1].EndIndex - 1
+	}
+	var requests []*docspb.Request
+	if replace && end > start {
+		requests = append(requests, &docspb.Request{DeleteContentRange: &docspb.DeleteContentRangeRequest{
+			Range: &docspb.Range{SegmentId: segmentID, StartIndex: start, EndIndex: end},
+		}})
+	}
+	return append(requests, &docspb.Request{InsertText: &docspb.InsertTextRequest{
+		Text:     text,
+		Location: &docspb.Location{SegmentId: segmentID, Index: start},
+	}})
+}
+
+// --- insert_doc_footnote (complete) ---
+
+type InsertDocFootnoteInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID string `json:"document_id" jsonschema:"required" jsonschema_description:"The Google Doc document ID"`
+	Text       string `json:"text" jsonschema:"required" jsonschema_description:"Footnote text"`
+	MatchText  string `json:"match_text,omitempty" jsonschema_description:"Place the footnote reference right after the first occurrence of this body text"`
+	Index      int64  `json:"index,omitempty" jsonschema_description:"Body index for the footnote reference (1-based); use instead of match_text"`
+}
+
+type InsertDocFootnoteOutput struct {
+	DocumentID string `json:"document_id"`
+	FootnoteID string `json:"footnote_id"`
+	Index      int64  `json:"index"`
+}
+
+func createInsertDocFootnoteHandler(factory *services.Factory) mcp.ToolHandlerFor[InsertDocFootnoteInput, InsertDocFootnoteOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input InsertDocFootnoteInput) (*mcp.CallToolResult, InsertDocFootnoteOutput, error) {
+		if input.Text == "" {
+			return nil, InsertDocFootnoteOutput{}, fmt.Errorf("text is empty - a footnote needs text")
+		}
+		if (input.MatchText == "") == (input.Index == 0) {
+			return nil, InsertDocFootnoteOutput{}, fmt.Errorf("set exactly one of match_text or index")
 		}
 
-		if len(requests) == 0 {
-			return nil, nil, fmt.Errorf("no header/footer changes specified - set header_text, footer_text, remove_header, or remove_footer")
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, InsertDocFootnoteOutput{}, middleware.HandleGoogleAPIError(err)
 		}
 
-		batchReq := &docspb.BatchUpdateDocumentRequest{
-			Requests: requests,
+		index := input.Index
+		if input.MatchText != "" {
+			doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+			if err != nil {
+				return nil, InsertDocFootnoteOutput{}, middleware.HandleGoogleAPIError(err)
+			}
+			rng, err := findText(doc, input.MatchText, 1)
+			if err != nil {
+				return nil, InsertDocFootnoteOutput{}, err
+			}
+			index = rng.EndIndex
 		}
 
-		_, err = srv.Documents.BatchUpdate(input.DocumentID, batchReq).Context(ctx).Do()
+		var tx rollback.Tx
+		resp, err := srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{
+			Requests: []*docspb.Request{{CreateFootnote: &docspb.CreateFootnoteRequest{Location: &docspb.Location{Index: index}}}},
+		}).Context(ctx).Do()
 		if err != nil {
-			return nil, nil, middleware.HandleGoogleAPIError(err)
+			return nil, InsertDocFootnoteOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		if len(resp.Replies) == 0 || resp.Replies[0].CreateFootnote == nil {
+			return nil, InsertDocFootnoteOutput{}, fmt.Errorf("creating the footnote returned no footnote ID")
 		}
+		footnoteID := resp.Replies[0].CreateFootnote.FootnoteId
+		// Deleting the reference in the body deletes the footnote with it.
+		tx.Record("footnote "+footnoteID, func(ctx context.Context) error {
+			_, err := srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{
+				Requests: []*docspb.Request{{DeleteContentRange: &docspb.DeleteContentRangeRequest{
+					Range: &docspb.Range{StartIndex: index, EndIndex: index + 1},
+				}}},
+			}).Context(ctx).Do()
+			return err
+		})
+
+		// A new footnote already holds a leading space and a newline; its
+		// text goes before the newline.
+		doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+		if err != nil {
+			return nil, InsertDocFootnoteOutput{}, tx.Fail(ctx, middleware.HandleGoogleAPIError(err))
+		}
+		at := int64(1)
+		if content := doc.Footnotes[footnoteID].Content; len(content) > 0 {
+			at = content[len(content)-1].EndIndex - 1
+		}
+		_, err = srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{
+			Requests: []*docspb.Request{{InsertText: &docspb.InsertTextRequest{
+				Text:     input.Text,
+				Location: &docspb.Location{SegmentId: footnoteID, Index: at},
+			}}},
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, InsertDocFootnoteOutput{}, tx.Fail(ctx, middleware.HandleGoogleAPIError(err))
+		}
+		tx.Commit()
 
+		out := InsertDocFootnoteOutput{DocumentID: input.DocumentID, FootnoteID: footnoteID, Index: index}
 		rb := response.New()
-		rb.Header("Headers/Footers Updated")
-		rb.KeyValue("Document ID", input.DocumentID)
-		rb.KeyValue("Changes Applied", len(requests))
+		rb.Header("Footnote Inserted")
+		rb.KeyValue("Document ID", out.DocumentID)
+		rb.KeyValue("Footnote ID", out.FootnoteID)
+		rb.KeyValue("Reference Index", out.Index)
+		rb.Line("Body indexes after the reference have shifted by 1.")
 
-		return rb.TextResult(), nil, nil
+		return rb.TextResult(), out, nil
 	}
 }
 
@@ -230,18 +325,24 @@ func createBatchUpdateDocHandler(factory *services.Factory) mcp.ToolHandlerFor[B
 // --- inspect_doc_structure (complete) ---
 
 type InspectDocStructureInput struct {
-	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
-	DocumentID string `json:"document_id" jsonschema:"required" jsonschema_description:"The Google Doc document ID"`
+	UserEmail           string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID          string `json:"document_id" jsonschema:"required" jsonschema_description:"The Google Doc document ID"`
+	SuggestionsViewMode string `json:"suggestions_view_mode,omitempty" jsonschema_description:"How to render pending suggestions: DEFAULT_FOR_CURRENT_ACCESS (default) SUGGESTIONS_INLINE PREVIEW_SUGGESTIONS_ACCEPTED PREVIEW_WITHOUT_SUGGESTIONS"`
 }
 
 func createInspectDocStructureHandler(factory *services.Factory) mcp.ToolHandlerFor[InspectDocStructureInput, DocStructureOutput] {
 	return func(ctx context.Context, req *mcp.CallToolRequest, input InspectDocStructureInput) (*mcp.CallToolResult, DocStructureOutput, error) {
+		mode, err := parseSuggestionsViewMode(input.SuggestionsViewMode)
+		if err != nil {
+			return nil, DocStructureOutput{}, err
+		}
+
 		srv, err := factory.Docs(ctx, input.UserEmail)
 		if err != nil {
 			return nil, DocStructureOutput{}, middleware.HandleGoogleAPIError(err)
 		}
 
-		doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+		doc, err := getDoc(ctx, srv, input.DocumentID, mode)
 		if err != nil {
 			return nil, DocStructureOutput{}, middleware.HandleGoogleAPIError(err)
 		}
@@ -411,21 +512,9 @@ func createDebugTableStructureHandler(factory *services.Factory) mcp.ToolHandler
 			return nil, DebugTableOutput{}, middleware.HandleGoogleAPIError(err)
 		}
 
-		// Find the nth table
-		tableIdx := 0
-		var tableElem *docspb.StructuralElement
-		for _, elem := range doc.Body.Content {
-			if elem.Table != nil {
-				if tableIdx == input.TableIndex {
-					tableElem = elem
-					break
-				}
-				tableIdx++
-			}
-		}
-
-		if tableElem == nil || tableElem.Table == nil {
-			return nil, DebugTableOutput{}, fmt.Errorf("table at index %d not found in document - verify the table_index parameter", input.TableIndex)
+		tableElem, err := findTable(doc, input.TableIndex)
+		if err != nil {
+			return nil, DebugTableOutput{}, err
 		}
 
 		table := tableElem.Table
@@ -478,3 +567,168 @@ func createDebugTableStructureHandler(factory *services.Factory) mcp.ToolHandler
 		return rb.TextResult(), output, nil
 	}
 }
+
+// findTable returns the nth table (0-based) in the document body.
+func findTable(doc *docspb.Document, n int) (*docspb.StructuralElement, error) {
+	tableIdx := 0
+	if doc.Body != nil {
+		for _, elem := range doc.Body.Content {
+			if elem.Table != nil {
+				if tableIdx == n {
+					return elem, nil
+				}
+				tableIdx++
+			}
+		}
+	}
+	return nil, fmt.Errorf("table at index %d not found in document - verify the table_index parameter", n)
+}
+
+// --- modify_doc_table (complete) ---
+
+type ModifyDocTableInput struct {
+	UserEmail       string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID      string   `json:"document_id" jsonschema:"required" jsonschema_description:"The Google Doc document ID"`
+	TableIndex      int      `json:"table_index,omitempty" jsonschema_description:"Which table to modify (0-based default 0 for the first table)"`
+	Action          string   `json:"action" jsonschema:"required" jsonschema_description:"What to do,enum=insert_row,enum=insert_column,enum=delete_row,enum=delete_column,enum=merge_cells,enum=unmerge_cells,enum=style_cells"`
+	Row             int      `json:"row,omitempty" jsonschema_description:"Row of the target cell (0-based); the reference row for inserts and the first row of a cell range"`
+	Column          int      `json:"column,omitempty" jsonschema_description:"Column of the target cell (0-based); the reference column for inserts and the first column of a cell range"`
+	RowSpan         int      `json:"row_span,omitempty" jsonschema_description:"Rows in the cell range for merge_cells, unmerge_cells, and style_cells (default 1)"`
+	ColumnSpan      int      `json:"column_span,omitempty" jsonschema_description:"Columns in the cell range for merge_cells, unmerge_cells, and style_cells (default 1)"`
+	After           bool     `json:"after,omitempty" jsonschema_description:"For insert_row and insert_column: insert below or right of the reference cell instead of above or left"`
+	BackgroundColor string   `json:"background_color,omitempty" jsonschema_description:"For style_cells: cell background as #RRGGBB"`
+	BorderColor     string   `json:"border_color,omitempty" jsonschema_description:"For style_cells: color of all four cell borders as #RRGGBB (default #000000 when border_width is set)"`
+	BorderWidth     *float64 `json:"border_width,omitempty" jsonschema_description:"For style_cells: width of all four cell borders in points; 0 hides them (default 1 when border_color is set)"`
+}
+
+func createModifyDocTableHandler(factory *services.Factory) mcp.ToolHandlerFor[ModifyDocTableInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ModifyDocTableInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		tableElem, err := findTable(doc, input.TableIndex)
+		if err != nil {
+			return nil, nil, err
+		}
+		tableReq, err := tableRequest(input, tableElem)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		_, err = srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{
+			Requests: []*docspb.Request{tableReq},
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Table Modified")
+		rb.KeyValue("Document ID", input.DocumentID)
+		rb.KeyValue("Table Index", input.TableIndex)
+		rb.KeyValue("Action", input.Action)
+		switch input.Action {
+		case "insert_row", "delete_row":
+			rb.KeyValue("Row", input.Row)
+		case "insert_column", "delete_column":
+			rb.KeyValue("Column", input.Column)
+		default:
+			rb.KeyValue("Cells", fmt.Sprintf("[%d,%d] %dx%d", input.Row, input.Column, max(input.RowSpan, 1), max(input.ColumnSpan, 1)))
+		}
+		rb.Line("Cell indexes after the change have shifted; run debug_table_structure before further index-based edits.")
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// tableRequest builds the single request that applies input to the table
+// starting at tableElem, checking the target cells exist first.
+func tableRequest(input ModifyDocTableInput, tableElem *docspb.StructuralElement) (*docspb.Request, error) {
+	table := tableElem.Table
+	rowSpan, colSpan := max(input.RowSpan, 1), max(input.ColumnSpan, 1)
+	if input.Row < 0 || input.Column < 0 || input.Row+rowSpan > int(table.Rows) || input.Column+colSpan > int(table.Columns) {
+		return nil, fmt.Errorf("cells [%d,%d] %dx%d are outside the %dx%d table - run debug_table_structure to check its size",
+			input.Row, input.Column, rowSpan, colSpan, table.Rows, table.Columns)
+	}
+
+	location := &docspb.TableCellLocation{
+		TableStartLocation: &docspb.Location{Index: tableElem.StartIndex},
+		RowIndex:           int64(input.Row),
+		ColumnIndex:        int64(input.Column),
+	}
+	cellRange := &docspb.TableRange{TableCellLocation: location, RowSpan: int64(rowSpan), ColumnSpan: int64(colSpan)}
+
+	switch input.Action {
+	case "insert_row":
+		return &docspb.Request{InsertTableRow: &docspb.InsertTableRowRequest{TableCellLocation: location, InsertBelow: input.After}}, nil
+	case "insert_column":
+		return &docspb.Request{InsertTableColumn: &docspb.InsertTableColumnRequest{TableCellLocation: location, InsertRight: input.After}}, nil
+	case "delete_row":
+		return &docspb.Request{DeleteTableRow: &docspb.DeleteTableRowRequest{TableCellLocation: location}}, nil
+	case "delete_column":
+		return &docspb.Request{DeleteTableColumn: &docspb.DeleteTableColumnRequest{TableCellLocation: location}}, nil
+	case "merge_cells":
+		if rowSpan*colSpan < 2 {
+			return nil, fmt.Errorf("merge_cells needs a range of at least two cells - set row_span or column_span")
+		}
+		return &docspb.Request{MergeTableCells: &docspb.MergeTableCellsRequest{TableRange: cellRange}}, nil
+	case "unmerge_cells":
+		return &docspb.Request{UnmergeTableCells: &docspb.UnmergeTableCellsRequest{TableRange: cellRange}}, nil
+	case "style_cells":
+		style, fields, err := tableCellStyle(input)
+		if err != nil {
+			return nil, err
+		}
+		return &docspb.Request{UpdateTableCellStyle: &docspb.UpdateTableCellStyleRequest{
+			TableRange:     cellRange,
+			TableCellStyle: style,
+			Fields:         fields,
+		}}, nil
+	default:
+		return nil, fmt.Errorf("invalid action %q - use insert_row, insert_column, delete_row, delete_column, merge_cells, unmerge_cells, or style_cells", input.Action)
+	}
+}
+
+// tableCellStyle builds the cell style and field mask for style_cells.
+// Docs requires every field of a border, so color and width default each
+// other in.
+func tableCellStyle(input ModifyDocTableInput) (*docspb.TableCellStyle, string, error) {
+	style := &docspb.TableCellStyle{}
+	var fields []string
+	if input.BackgroundColor != "" {
+		if style.BackgroundColor = parseColor(input.BackgroundColor); style.BackgroundColor == nil {
+			return nil, "", fmt.Errorf("invalid background_color %q - use #RRGGBB", input.BackgroundColor)
+		}
+		fields = append(fields, "backgroundColor")
+	}
+	if input.BorderColor != "" || input.BorderWidth != nil {
+		borderColor := parseColor(cmp.Or(input.BorderColor, "#000000"))
+		if borderColor == nil {
+			return nil, "", fmt.Errorf("invalid border_color %q - use #RRGGBB", input.BorderColor)
+		}
+		width := 1.0
+		if input.BorderWidth != nil {
+			if width = *input.BorderWidth; width < 0 {
+				return nil, "", fmt.Errorf("invalid border_width %g - use 0 or more points", width)
+			}
+		}
+		border := &docspb.TableCellBorder{
+			Color:     borderColor,
+			DashStyle: "SOLID",
+			// A zero width must be sent to hide the border.
+			Width: &docspb.Dimension{Magnitude: width, Unit: "PT", ForceSendFields: []string{"Magnitude"}},
+		}
+		style.BorderTop, style.BorderBottom, style.BorderLeft, style.BorderRight = border, border, border, border
+		fields = append(fields, "borderTop", "borderBottom", "borderLeft", "borderRight")
+	}
+	if len(fields) == 0 {
+		return nil, "", fmt.Errorf("style_cells needs background_color, border_color, or border_width")
+	}
+	return style, strings.Join(fields, ","), nil
+}
@@ -0,0 +1,114 @@
+package docs
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	docspb "google.golang.org/api/docs/v1"
+)
+
+func TestTableRequest(t *testing.T) {
+	tableElem := &docspb.StructuralElement{StartIndex: 12, Table: &docspb.Table{Rows: 3, Columns: 2}}
+	width := 0.0
+
+	tests := []struct {
+		name  string
+		input ModifyDocTableInput
+		want  string
+	}{
+		{
+			"insert row below",
+			ModifyDocTableInput{Action: "insert_row", Row: 2, After: true},
+			`{"insertTableRow":{"insertBelow":true,"tableCellLocation":{"rowIndex":2,"tableStartLocation":{"index":12}}}}`,
+		},
+		{
+			"delete column",
+			ModifyDocTableInput{Action: "delete_column", Column: 1},
+			`{"deleteTableColumn":{"tableCellLocation":{"columnIndex":1,"tableStartLocation":{"index":12}}}}`,
+		},
+		{
+			"merge header",
+			ModifyDocTableInput{Action: "merge_cells", ColumnSpan: 2},
+			`{"mergeTableCells":{"tableRange":{"columnSpan":2,"rowSpan":1,"tableCellLocation":{"tableStartLocation":{"index":12}}}}}`,
+		},
+		{
+			"hide borders",
+			ModifyDocTableInput{Action: "style_cells", Row: 1, RowSpan: 2, BackgroundColor: "#FF0000", BorderWidth: &width},
+			`{"updateTableCellStyle":{"fields":"backgroundColor,borderTop,borderBottom,borderLeft,borderRight",` +
+				`"tableCellStyle":{"backgroundColor":{"color":{"rgbColor":{"red":1}}},` +
+				`"borderBottom":{"color":{"color":{"rgbColor":{}}},"dashStyle":"SOLID","width":{"magnitude":0,"unit":"PT"}},` +
+				`"borderLeft":{"color":{"color":{"rgbColor":{}}},"dashStyle":"SOLID","width":{"magnitude":0,"unit":"PT"}},` +
+				`"borderRight":{"color":{"color":{"rgbColor":{}}},"dashStyle":"SOLID","width":{"magnitude":0,"unit":"PT"}},` +
+				`"borderTop":{"color":{"color":{"rgbColor":{}}},"dashStyle":"SOLID","width":{"magnitude":0,"unit":"PT"}}},` +
+				`"tableRange":{"columnSpan":1,"rowSpan":2,"tableCellLocation":{"rowIndex":1,"tableStartLocation":{"index":12}}}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tableRequest(tt.input, tableElem)
+			if err != nil {
+				t.Fatalf("tableRequest() error = %v", err)
+			}
+			got, err := json.Marshal(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("tableRequest() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableRequestInvalid(t *testing.T) {
+	tableElem := &docspb.StructuralElement{StartIndex: 12, Table: &docspb.Table{Rows: 3, Columns: 2}}
+	tests := []struct {
+		input ModifyDocTableInput
+		want  string
+	}{
+		{ModifyDocTableInput{Action: "delete_row", Row: 3}, "outside the 3x2 table"},
+		{ModifyDocTableInput{Action: "style_cells", Column: 1, ColumnSpan: 2, BackgroundColor: "#fff"}, "outside the 3x2 table"},
+		{ModifyDocTableInput{Action: "merge_cells"}, "at least two cells"},
+		{ModifyDocTableInput{Action: "style_cells"}, "needs background_color"},
+		{ModifyDocTableInput{Action: "style_cells", BorderColor: "blue"}, "invalid border_color"},
+		{ModifyDocTableInput{Action: "split_cells"}, "invalid action"},
+	}
+	for _, tt := range tests {
+		_, err := tableRequest(tt.input, tableElem)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("tableRequest(%+v) error = %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestSegmentTextRequests(t *testing.T) {
+	content := []*docspb.StructuralElement{
+		{StartIndex: 0, EndIndex: 8, Paragraph: &docspb.Paragraph{}},
+		{StartIndex: 8, EndIndex: 15, Paragraph: &docspb.Paragraph{}},
+	}
+	insert := &docspb.Request{InsertText: &docspb.InsertTextRequest{
+		Text:     "Draft",
+		Location: &docspb.Location{SegmentId: "kix.h1", Index: 0},
+	}}
+
+	got := segmentTextRequests("kix.h1", content, "Draft", true)
+	want := []*docspb.Request{
+		{DeleteContentRange: &docspb.DeleteContentRangeRequest{
+			Range: &docspb.Range{SegmentId: "kix.h1", StartIndex: 0, EndIndex: 14},
+		}},
+		insert,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("replace: requests =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := segmentTextRequests("kix.h1", content, "Draft", false); !reflect.DeepEqual(got, []*docspb.Request{insert}) {
+		t.Errorf("insert: requests = %+v, want only the insert", got)
+	}
+	// A header created in the same call has no content yet.
+	if got := segmentTextRequests("kix.h1", nil, "Draft", true); !reflect.DeepEqual(got, []*docspb.Request{insert}) {
+		t.Errorf("new segment: requests = %+v, want only the insert", got)
+	}
+}
@@ -0,0 +1,59 @@
+package docs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	docspb "google.golang.org/api/docs/v1"
+)
+
+func TestDocElementRequests(t *testing.T) {
+	requests, err := docElementRequests([]DocElement{
+		{Type: "paragraph", Text: "Intro", Index: 1},
+		{Type: "list_item", Text: "First", Index: 7, ListStyle: "numbered"},
+		{Type: "list_item", Text: "Nested", Index: 13, ListStyle: "numbered", NestingLevel: 1},
+		{Type: "list_item", Text: "Second\n", Index: 20, ListStyle: "NUMBERED_DECIMAL_ALPHA_ROMAN"},
+		{Type: "list_item", Text: "Todo", Index: 40, ListStyle: "checkbox"},
+	})
+	if err != nil {
+		t.Fatalf("docElementRequests() error = %v", err)
+	}
+
+	insert := func(text string, index int64) *docspb.Request {
+		return &docspb.Request{InsertText: &docspb.InsertTextRequest{Text: text, Location: &docspb.Location{Index: index}}}
+	}
+	bullets := func(start, end int64, preset string) *docspb.Request {
+		return &docspb.Request{CreateParagraphBullets: &docspb.CreateParagraphBulletsRequest{
+			Range: &docspb.Range{StartIndex: start, EndIndex: end}, BulletPreset: preset,
+		}}
+	}
+	want := []*docspb.Request{
+		insert("Todo\n", 40),
+		bullets(40, 45, "BULLET_CHECKBOX"),
+		insert("First\n\tNested\nSecond\n", 7),
+		bullets(7, 28, "NUMBERED_DECIMAL_ALPHA_ROMAN"),
+		insert("Intro\n", 1),
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests =\n%+v\nwant\n%+v", requests, want)
+	}
+}
+
+func TestDocElementRequestsInvalid(t *testing.T) {
+	tests := []struct {
+		elem DocElement
+		want string
+	}{
+		{DocElement{Type: "table", Text: "x", Index: 1}, "invalid type"},
+		{DocElement{Type: "list_item", Text: "x", Index: 1, ListStyle: "roman"}, "invalid list_style"},
+		{DocElement{Type: "list_item", Text: "x", Index: 1, NestingLevel: 9}, "invalid nesting_level"},
+		{DocElement{Type: "list_item", Text: "a\nb", Index: 1}, "contains a newline"},
+	}
+	for _, tt := range tests {
+		_, err := docElementRequests([]DocElement{tt.elem})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("docElementRequests(%+v) error = %v, want %q", tt.elem, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package docs
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/provenance"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/rollback"
+)
+
+// maxHTMLExportSize caps the zipped HTML export read into memory. Drive
+// refuses exports above 10 MB, so this is only a safety net.
+const maxHTMLExportSize = 20 << 20
+
+// Image publishing modes for export_doc_to_html.
+const (
+	imagesInline = "inline"
+	imagesDrive  = "drive"
+)
+
+// htmlExport is a Google Doc exported as zipped HTML: the page and the image
+// files it references by relative path (e.g. "images/image1.png").
+type htmlExport struct {
+	HTML   string
+	Images map[string][]byte
+}
+
+// downloadHTMLExport exports a Doc as application/zip and unpacks it.
+func downloadHTMLExport(ctx context.Context, srv *drive.Service, docID string) (htmlExport, error) {
+	resp, err := srv.Files.Export(docID, "application/zip").Context(ctx).Download()
+	if err != nil {
+		return htmlExport{}, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxHTMLExportSize+1))
+	if err != nil {
+		return htmlExport{}, fmt.Errorf("reading HTML export: %w", err)
+	}
+	if len(data) > maxHTMLExportSize {
+		return htmlExport{}, fmt.Errorf("HTML export exceeds %d MB", maxHTMLExportSize>>20)
+	}
+	return readHTMLExport(data)
+}
+
+// readHTMLExport unpacks the zip produced by a Docs HTML export.
+func readHTMLExport(data []byte) (htmlExport, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return htmlExport{}, fmt.Errorf("reading HTML export archive: %w", err)
+	}
+
+	export := htmlExport{Images: make(map[string][]byte)}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		content, err := readZipFile(f)
+		if err != nil {
+			return htmlExport{}, err
+		}
+		if strings.EqualFold(path.Ext(f.Name), ".html") && export.HTML == "" {
+			export.HTML = string(content)
+		} else {
+			export.Images[f.Name] = content
+		}
+	}
+	if export.HTML == "" {
+		return htmlExport{}, fmt.Errorf("HTML export archive contains no HTML page")
+	}
+	return export, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("opening %s in HTML export: %w", f.Name, err)
+	}
+	defer rc.Close()
+	content, err := io.ReadAll(io.LimitReader(rc, maxHTMLExportSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s in HTML export: %w", f.Name, err)
+	}
+	return content, nil
+}
+
+// imagePublisher makes exported images available to the cleaned page,
+// either as data URIs or as files uploaded to a Drive folder. Uploads are
+// recorded in tx so they can be removed if the export fails.
+type imagePublisher struct {
+	ctx      context.Context
+	images   map[string][]byte
+	mode     string
+	srv      *drive.Service
+	folderID string
+	prefix   string // file name prefix for uploads
+	stamp    *provenance.Stamp
+	tx       *rollback.Tx
+	count    int
+}
+
+// resolve implements htmlutil.ImageResolver. Images not in the export are
+// kept when they are absolute HTTPS URLs and dropped otherwise.
+func (p *imagePublisher) resolve(src string) (string, error) {
+	data, ok := p.images[src]
+	if !ok {
+		if strings.HasPrefix(src, "https://") {
+			return src, nil
+		}
+		return "", nil
+	}
+	p.count++
+	if p.mode == imagesDrive {
+		return p.upload(src, data)
+	}
+	return "data:" + imageMIMEType(src, data) + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// upload stores one image in the target folder and returns a URL that
+// displays it. Who can see it is governed by the folder's sharing.
+func (p *imagePublisher) upload(src string, data []byte) (string, error) {
+	file := &drive.File{
+		Name:     p.prefix + " - " + path.Base(src),
+		MimeType: imageMIMEType(src, data),
+		Parents:  []string{p.folderID},
+	}
+	if p.stamp != nil {
+		file.AppProperties = p.stamp.Properties()
+	}
+	created, err := p.srv.Files.Create(file).
+		Media(bytes.NewReader(data)).
+		SupportsAllDrives(true).
+		Fields("id").
+		Context(p.ctx).
+		Do()
+	if err != nil {
+		return "", fmt.Errorf("uploading image %s: %w", path.Base(src), err)
+	}
+	p.tx.Record("image file "+created.Id, rollback.TrashDriveFile(p.srv, created.Id))
+	return "https://drive.google.com/uc?export=view&id=" + created.Id, nil
+}
+
+// imageMIMEType guesses an image's MIME type from its name, then its content.
+func imageMIMEType(name string, data []byte) string {
+	if t := mime.TypeByExtension(path.Ext(name)); strings.HasPrefix(t, "image/") {
+		return t
+	}
+	return http.DetectContentType(data)
+}
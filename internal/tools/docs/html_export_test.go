@@ -0,0 +1,75 @@
+package docs
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadHTMLExport(t *testing.T) {
+	data := zipArchive(t, map[string]string{
+		"LaunchPlan.html":   "<html><body>hi</body></html>",
+		"images/image1.png": "\x89PNG\r\n\x1a\n",
+	})
+
+	export, err := readHTMLExport(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(export.HTML, "hi") {
+		t.Errorf("HTML = %q", export.HTML)
+	}
+	if _, ok := export.Images["images/image1.png"]; !ok || len(export.Images) != 1 {
+		t.Errorf("images = %v", export.Images)
+	}
+
+	if _, err := readHTMLExport(zipArchive(t, map[string]string{"images/a.png": "x"})); err == nil {
+		t.Error("expected error for archive without HTML")
+	}
+	if _, err := readHTMLExport([]byte("not a zip")); err == nil {
+		t.Error("expected error for invalid archive")
+	}
+}
+
+func TestImagePublisherInline(t *testing.T) {
+	pub := &imagePublisher{images: map[string][]byte{"images/image1.png": []byte("png")}, mode: imagesInline}
+
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"images/image1.png", "data:image/png;base64,cG5n"},
+		{"https://example.com/logo.png", "https://example.com/logo.png"},
+		{"images/missing.png", ""},
+		{"http://example.com/insecure.png", ""},
+	}
+	for _, tt := range tests {
+		got, err := pub.resolve(tt.src)
+		if err != nil || got != tt.want {
+			t.Errorf("resolve(%q) = %q, %v; want %q", tt.src, got, err, tt.want)
+		}
+	}
+	if pub.count != 1 {
+		t.Errorf("count = %d, want 1", pub.count)
+	}
+}
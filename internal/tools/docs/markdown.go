@@ -0,0 +1,312 @@
+package docs
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strings"
+
+	docspb "google.golang.org/api/docs/v1"
+)
+
+// markdownSpecial are the characters escaped anywhere in Markdown text.
+var markdownSpecial = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`, `<`, `\<`,
+)
+
+// orderedListStart matches text that Markdown would read as an ordered list
+// item, such as "1. " or "2) ".
+var orderedListStart = regexp.MustCompile(`^(\d+)([.)])`)
+
+// markdownWriter converts a Document's body to Markdown. It keeps headings,
+// lists, tables, links, bold, italic, strikethrough, images, and footnotes;
+// other styling has no Markdown equivalent and is dropped.
+type markdownWriter struct {
+	doc       *docspb.Document
+	sb        strings.Builder
+	lastList  string // ListId of the previous block when it was a list item
+	footnotes []string
+	seen      map[string]bool // footnote IDs already collected
+	images    int
+}
+
+// docToMarkdown renders doc as Markdown and returns it with the number of
+// images it links to.
+func docToMarkdown(doc *docspb.Document) (string, int) {
+	if doc.Body == nil {
+		return "", 0
+	}
+	w := &markdownWriter{doc: doc, seen: make(map[string]bool)}
+	for _, elem := range doc.Body.Content {
+		switch {
+		case elem.Paragraph != nil:
+			w.paragraph(elem.Paragraph)
+		case elem.Table != nil:
+			w.table(elem.Table)
+		}
+	}
+	if len(w.footnotes) > 0 {
+		w.block(strings.Join(w.footnotes, "\n"), "")
+	}
+	if w.sb.Len() == 0 {
+		return "", w.images
+	}
+	return w.sb.String() + "\n", w.images
+}
+
+// block appends one block, separated from the previous one by a blank line
+// unless both are items of the same list.
+func (w *markdownWriter) block(text, listID string) {
+	if w.sb.Len() > 0 {
+		if listID != "" && listID == w.lastList {
+			w.sb.WriteString("\n")
+		} else {
+			w.sb.WriteString("\n\n")
+		}
+	}
+	w.sb.WriteString(text)
+	w.lastList = listID
+}
+
+func (w *markdownWriter) paragraph(p *docspb.Paragraph) {
+	var prefix, listID string
+	if p.Bullet != nil {
+		listID = p.Bullet.ListId
+		level := p.Bullet.NestingLevel
+		// Four spaces per level nests under both "- " and "1. " markers.
+		prefix = strings.Repeat("    ", int(level)) + w.listMarker(listID, level)
+	} else if p.ParagraphStyle != nil {
+		prefix = headingPrefix(p.ParagraphStyle.NamedStyleType)
+	}
+
+	text := w.inline(p.Elements, "\\\n"+strings.Repeat(" ", len(prefix)))
+	if strings.TrimSpace(text) == "" {
+		for _, pe := range p.Elements {
+			if pe.HorizontalRule != nil {
+				w.block("---", "")
+				return
+			}
+		}
+		return
+	}
+	w.block(prefix+escapeBlockStart(text), listID)
+}
+
+// headingPrefix returns the Markdown heading marker for a named paragraph
+// style, or "" for body text.
+func headingPrefix(style string) string {
+	switch style {
+	case "TITLE":
+		return "# "
+	case "SUBTITLE":
+		return "## "
+	}
+	var level int
+	if _, err := fmt.Sscanf(style, "HEADING_%d", &level); err != nil || level < 1 {
+		return ""
+	}
+	return strings.Repeat("#", min(level, 6)) + " "
+}
+
+// listMarker returns "1. " for numbered list levels and "- " otherwise.
+func (w *markdownWriter) listMarker(listID string, level int64) string {
+	list, ok := w.doc.Lists[listID]
+	if !ok || list.ListProperties == nil || int(level) >= len(list.ListProperties.NestingLevels) {
+		return "- "
+	}
+	switch list.ListProperties.NestingLevels[level].GlyphType {
+	case "", "GLYPH_TYPE_UNSPECIFIED", "NONE":
+		return "- "
+	default:
+		return "1. "
+	}
+}
+
+// escapeBlockStart escapes a leading character that would otherwise turn a
+// paragraph into a heading, quote, list item, or rule.
+func escapeBlockStart(text string) string {
+	if text == "" {
+		return text
+	}
+	switch text[0] {
+	case '#', '>', '-', '+', '=':
+		return `\` + text
+	}
+	return orderedListStart.ReplaceAllString(text, `$1\$2`)
+}
+
+func (w *markdownWriter) table(t *docspb.Table) {
+	rows := make([][]string, 0, len(t.TableRows))
+	cols := 0
+	for _, row := range t.TableRows {
+		cells := make([]string, 0, len(row.TableCells))
+		for _, cell := range row.TableCells {
+			cells = append(cells, w.cell(cell))
+		}
+		cols = max(cols, len(cells))
+		rows = append(rows, cells)
+	}
+	if cols == 0 {
+		return
+	}
+
+	// Markdown tables need a header row, so the first row is used as one.
+	lines := make([]string, 0, len(rows)+1)
+	for i, cells := range rows {
+		for len(cells) < cols {
+			cells = append(cells, "")
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", cols))
+		}
+	}
+	w.block(strings.Join(lines, "\n"), "")
+}
+
+// cell renders a table cell on one line, joining its paragraphs with <br>.
+// Tables nested in cells have no Markdown form and are dropped.
+func (w *markdownWriter) cell(cell *docspb.TableCell) string {
+	var parts []string
+	for _, elem := range cell.Content {
+		if elem.Paragraph == nil {
+			continue
+		}
+		if text := strings.TrimSpace(w.inline(elem.Paragraph.Elements, "<br>")); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.ReplaceAll(strings.Join(parts, "<br>"), "|", `\|`)
+}
+
+// span is a run of text with uniform Markdown formatting. Raw spans are
+// already Markdown and are neither escaped nor merged.
+type span struct {
+	text                 string
+	bold, italic, strike bool
+	link                 string
+	raw                  bool
+}
+
+func (s span) sameStyle(o span) bool {
+	return !s.raw && !o.raw && s.bold == o.bold && s.italic == o.italic && s.strike == o.strike && s.link == o.link
+}
+
+// inline renders paragraph elements as Markdown. br replaces line breaks
+// within the paragraph.
+func (w *markdownWriter) inline(elems []*docspb.ParagraphElement, br string) string {
+	var spans []span
+	add := func(s span) {
+		if n := len(spans); n > 0 && spans[n-1].sameStyle(s) {
+			spans[n-1].text += s.text
+			return
+		}
+		spans = append(spans, s)
+	}
+
+	for _, pe := range elems {
+		switch {
+		case pe.TextRun != nil:
+			s := span{text: strings.ReplaceAll(pe.TextRun.Content, "\n", "")}
+			if st := pe.TextRun.TextStyle; st != nil {
+				s.bold, s.italic, s.strike = st.Bold, st.Italic, st.Strikethrough
+				if st.Link != nil {
+					s.link = st.Link.Url
+				}
+			}
+			add(s)
+		case pe.InlineObjectElement != nil:
+			if img := w.image(pe.InlineObjectElement.InlineObjectId); img != "" {
+				add(span{text: img, raw: true})
+			}
+		case pe.FootnoteReference != nil:
+			add(span{text: w.footnote(pe.FootnoteReference), raw: true})
+		case pe.Person != nil && pe.Person.PersonProperties != nil:
+			add(span{text: cmp.Or(pe.Person.PersonProperties.Name, pe.Person.PersonProperties.Email)})
+		case pe.RichLink != nil && pe.RichLink.RichLinkProperties != nil:
+			props := pe.RichLink.RichLinkProperties
+			add(span{text: cmp.Or(props.Title, props.Uri), link: props.Uri})
+		}
+	}
+
+	var sb strings.Builder
+	for _, s := range spans {
+		if s.raw {
+			sb.WriteString(s.text)
+			continue
+		}
+		sb.WriteString(formatSpan(s, br))
+	}
+	return sb.String()
+}
+
+// formatSpan escapes a span and wraps it in emphasis and link markup.
+// Surrounding spaces stay outside the markers, where Markdown requires them.
+func formatSpan(s span, br string) string {
+	escape := func(text string) string {
+		return strings.ReplaceAll(markdownSpecial.Replace(text), "\v", br)
+	}
+	core := strings.TrimSpace(s.text)
+	if core == "" {
+		return escape(s.text)
+	}
+	lead := s.text[:strings.Index(s.text, core)]
+	trail := s.text[len(lead)+len(core):]
+
+	core = escape(core)
+	if s.strike {
+		core = "~~" + core + "~~"
+	}
+	if s.italic {
+		core = "*" + core + "*"
+	}
+	if s.bold {
+		core = "**" + core + "**"
+	}
+	if s.link != "" {
+		core = "[" + core + "](" + markdownURL(s.link) + ")"
+	}
+	return escape(lead) + core + escape(trail)
+}
+
+// markdownURL escapes the characters that would end a link destination.
+func markdownURL(u string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(u)
+}
+
+// image returns Markdown for an inline image, or "" for other objects.
+func (w *markdownWriter) image(objectID string) string {
+	obj, ok := w.doc.InlineObjects[objectID]
+	if !ok || obj.InlineObjectProperties == nil || obj.InlineObjectProperties.EmbeddedObject == nil {
+		return ""
+	}
+	embedded := obj.InlineObjectProperties.EmbeddedObject
+	if embedded.ImageProperties == nil || embedded.ImageProperties.ContentUri == "" {
+		return ""
+	}
+	w.images++
+	alt := cmp.Or(embedded.Title, embedded.Description, "image")
+	return "![" + markdownSpecial.Replace(alt) + "](" + markdownURL(embedded.ImageProperties.ContentUri) + ")"
+}
+
+// footnote returns the reference marker for a footnote and, the first time
+// it is seen, collects its text for the end of the document.
+func (w *markdownWriter) footnote(ref *docspb.FootnoteReference) string {
+	label := "[^" + cmp.Or(ref.FootnoteNumber, ref.FootnoteId) + "]"
+	if w.seen[ref.FootnoteId] {
+		return label
+	}
+	w.seen[ref.FootnoteId] = true
+
+	var parts []string
+	for _, elem := range w.doc.Footnotes[ref.FootnoteId].Content {
+		if elem.Paragraph == nil {
+			continue
+		}
+		if text := strings.TrimSpace(w.inline(elem.Paragraph.Elements, " ")); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	w.footnotes = append(w.footnotes, label+": "+strings.Join(parts, " "))
+	return label
+}
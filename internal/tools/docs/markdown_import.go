@@ -0,0 +1,635 @@
+package docs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	docspb "google.golang.org/api/docs/v1"
+)
+
+// codeFontFamily is the font used for inline code and code blocks.
+const codeFontFamily = "Roboto Mono"
+
+// Markdown block kinds understood by create_doc_from_markdown.
+const (
+	mdParagraph = "paragraph"
+	mdHeading   = "heading"
+	mdList      = "list"
+	mdCode      = "code"
+	mdQuote     = "quote"
+	mdTable     = "table"
+)
+
+var (
+	mdHeadingLine  = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	mdFenceLine    = regexp.MustCompile("^ {0,3}(```+|~~~+)")
+	mdListLine     = regexp.MustCompile(`^([ \t]*)([-*+]|\d{1,9}[.)])[ \t]+(.*)$`)
+	mdRuleLine     = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdQuoteLine    = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	mdTableDivider = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+)
+
+// mdBlock is one block-level element of a Markdown document.
+type mdBlock struct {
+	kind    string
+	level   int        // heading level
+	text    string     // inline Markdown of a paragraph, heading, or quote
+	lines   []string   // code block lines
+	items   []mdItem   // list items
+	ordered bool       // whether a list is numbered
+	rows    [][]string // table cells as inline Markdown, header row first
+}
+
+// mdItem is one list item; depth 0 is the outermost level.
+type mdItem struct {
+	depth int
+	text  string
+}
+
+// parseMarkdown splits CommonMark-style Markdown into blocks. It covers the
+// subset agents write: ATX headings, paragraphs, nested bulleted and
+// numbered lists, fenced code blocks, block quotes, and GFM pipe tables.
+// Thematic breaks have no Docs equivalent and are dropped.
+func parseMarkdown(src string) []mdBlock {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	var blocks []mdBlock
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "" || mdRuleLine.MatchString(line):
+			i++
+		case mdFenceLine.MatchString(line):
+			fence := mdFenceLine.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			i++ // closing fence
+			blocks = append(blocks, mdBlock{kind: mdCode, lines: code})
+		case mdHeadingLine.MatchString(line):
+			m := mdHeadingLine.FindStringSubmatch(line)
+			blocks = append(blocks, mdBlock{kind: mdHeading, level: len(m[1]), text: m[2]})
+			i++
+		case i+1 < len(lines) && strings.Contains(line, "|") && mdTableDivider.MatchString(lines[i+1]):
+			b := mdBlock{kind: mdTable, rows: [][]string{splitTableRow(line)}}
+			for i += 2; i < len(lines) && strings.Contains(lines[i], "|") && strings.TrimSpace(lines[i]) != ""; i++ {
+				b.rows = append(b.rows, splitTableRow(lines[i]))
+			}
+			blocks = append(blocks, b)
+		case mdListLine.MatchString(line):
+			var b mdBlock
+			b, i = parseList(lines, i)
+			blocks = append(blocks, b)
+		case mdQuoteLine.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && mdQuoteLine.MatchString(lines[i]); i++ {
+				quoted = append(quoted, strings.TrimSpace(mdQuoteLine.FindStringSubmatch(lines[i])[1]))
+			}
+			blocks = append(blocks, mdBlock{kind: mdQuote, text: strings.Join(quoted, " ")})
+		default:
+			var para []string
+			for ; i < len(lines) && !startsBlock(lines, i); i++ {
+				para = append(para, strings.TrimSpace(lines[i]))
+			}
+			blocks = append(blocks, mdBlock{kind: mdParagraph, text: strings.Join(para, " ")})
+		}
+	}
+	return blocks
+}
+
+// startsBlock reports whether lines[i] ends the paragraph before it.
+func startsBlock(lines []string, i int) bool {
+	line := lines[i]
+	return strings.TrimSpace(line) == "" ||
+		mdRuleLine.MatchString(line) ||
+		mdFenceLine.MatchString(line) ||
+		mdHeadingLine.MatchString(line) ||
+		mdListLine.MatchString(line) ||
+		mdQuoteLine.MatchString(line) ||
+		(i+1 < len(lines) && strings.Contains(line, "|") && mdTableDivider.MatchString(lines[i+1]))
+}
+
+// parseList reads the list starting at lines[i] and returns it with the
+// index of the first line after it. Nesting follows indentation; indented
+// lines that are not items continue the previous item.
+func parseList(lines []string, i int) (mdBlock, int) {
+	b := mdBlock{kind: mdList}
+	var indents []int
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		m := mdListLine.FindStringSubmatch(line)
+		if m == nil {
+			if strings.TrimSpace(line) == "" || len(b.items) == 0 || !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+				break
+			}
+			last := &b.items[len(b.items)-1]
+			last.text += " " + strings.TrimSpace(line)
+			continue
+		}
+		if mdRuleLine.MatchString(line) {
+			break
+		}
+		indent := len(strings.ReplaceAll(m[1], "\t", "    "))
+		for len(indents) > 0 && indents[len(indents)-1] > indent {
+			indents = indents[:len(indents)-1]
+		}
+		if len(indents) == 0 || indents[len(indents)-1] < indent {
+			indents = append(indents, indent)
+		}
+		if len(b.items) == 0 {
+			b.ordered = m[2] != "-" && m[2] != "*" && m[2] != "+"
+		}
+		b.items = append(b.items, mdItem{depth: len(indents) - 1, text: m[3]})
+	}
+	return b, i
+}
+
+// splitTableRow splits a pipe table row into cells, honoring \| escapes.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// inlineStyle is the character formatting Markdown can express.
+type inlineStyle struct {
+	bold, italic, strike, code bool
+	link                       string
+}
+
+// inlineSpan is a run of plain text with one style.
+type inlineSpan struct {
+	text  string
+	style inlineStyle
+}
+
+// parseInline turns inline Markdown into styled text spans, handling
+// emphasis, strikethrough, code spans, links, autolinks, images (as links),
+// and backslash escapes.
+func parseInline(s string) []inlineSpan {
+	var spans []inlineSpan
+	parseInlineInto(&spans, s, inlineStyle{})
+	return spans
+}
+
+func parseInlineInto(spans *[]inlineSpan, s string, st inlineStyle) {
+	var buf strings.Builder
+	flush := func() {
+		parseLiteral(spans, buf.String(), st)
+		buf.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte("\\`*_{}[]()#+-.!|~<>", s[i+1]) >= 0:
+			buf.WriteByte(s[i+1])
+			i += 2
+			continue
+		case c == '`':
+			n := runLength(s, i, '`')
+			if end := strings.Index(s[i+n:], s[i:i+n]); end >= 0 {
+				flush()
+				code := s[i+n : i+n+end]
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+					code = code[1 : len(code)-1]
+				}
+				coded := st
+				coded.code = true
+				parseLiteral(spans, code, coded)
+				i += n + end + n
+				continue
+			}
+		case c == '[' || (c == '!' && i+1 < len(s) && s[i+1] == '['):
+			start := i
+			if c == '!' {
+				start++
+			}
+			if text, url, end, ok := parseLink(s, start); ok {
+				flush()
+				linked := st
+				linked.link = url
+				if text == "" {
+					text = url
+				}
+				parseInlineInto(spans, text, linked)
+				i = end
+				continue
+			}
+		case c == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 1 {
+				url := s[i+1 : i+end]
+				if !strings.ContainsAny(url, " \t") && (strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "mailto:")) {
+					flush()
+					linked := st
+					linked.link = url
+					parseLiteral(spans, strings.TrimPrefix(url, "mailto:"), linked)
+					i += end + 1
+					continue
+				}
+			}
+		case c == '~' && strings.HasPrefix(s[i:], "~~"):
+			if end := closingDelimiter(s, i+2, "~~"); end > i+2 {
+				flush()
+				struck := st
+				struck.strike = true
+				parseInlineInto(spans, s[i+2:end], struck)
+				i = end + 2
+				continue
+			}
+		case c == '*' || c == '_':
+			n := min(runLength(s, i, c), 3)
+			delim := strings.Repeat(string(c), n)
+			leftFlanking := i+n < len(s) && s[i+n] != ' '
+			if c == '_' && i > 0 && isWordByte(s[i-1]) {
+				leftFlanking = false
+			}
+			if leftFlanking {
+				if end := closingDelimiter(s, i+n, delim); end > i+n {
+					flush()
+					emph := st
+					emph.italic = emph.italic || n != 2
+					emph.bold = emph.bold || n >= 2
+					parseInlineInto(spans, s[i+n:end], emph)
+					i = end + n
+					continue
+				}
+			}
+			buf.WriteString(delim)
+			i += n
+			continue
+		}
+		buf.WriteByte(c)
+		i++
+	}
+	flush()
+}
+
+// parseLiteral appends text with no further Markdown parsing.
+func parseLiteral(spans *[]inlineSpan, text string, st inlineStyle) {
+	if text == "" {
+		return
+	}
+	if n := len(*spans); n > 0 && (*spans)[n-1].style == st {
+		(*spans)[n-1].text += text
+		return
+	}
+	*spans = append(*spans, inlineSpan{text: text, style: st})
+}
+
+// runLength counts consecutive c bytes starting at s[i].
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b >= utf8.RuneSelf
+}
+
+// closingDelimiter returns the index of the delimiter run that closes one
+// opened just before from: exactly delim, not preceded by a space, or -1.
+func closingDelimiter(s string, from int, delim string) int {
+	c := delim[0]
+	for j := from; j < len(s); {
+		k := strings.Index(s[j:], delim)
+		if k < 0 {
+			return -1
+		}
+		k += j
+		end := k + len(delim)
+		switch {
+		case s[k-1] == ' ' || s[k-1] == '\\' || (k > from && s[k-1] == c):
+		case end < len(s) && s[end] == c:
+			// Part of a longer run: skip past it.
+			k += runLength(s, k, c) - 1
+		case c == '_' && end < len(s) && isWordByte(s[end]):
+		default:
+			return k
+		}
+		j = k + 1
+	}
+	return -1
+}
+
+// parseLink parses "[text](url)" starting at s[i] == '[' and returns the
+// link text, destination, and the index just past the closing parenthesis.
+func parseLink(s string, i int) (text, url string, end int, ok bool) {
+	depth := 0
+	j := i
+	for ; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+			continue
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+	}
+	if j >= len(s) || j+1 >= len(s) || s[j+1] != '(' {
+		return "", "", 0, false
+	}
+	text = s[i+1 : j]
+	depth = 0
+	k := j + 1
+	for ; k < len(s); k++ {
+		switch s[k] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+	}
+	if k >= len(s) {
+		return "", "", 0, false
+	}
+	dest := strings.TrimSpace(s[j+2 : k])
+	if fields := strings.Fields(dest); len(fields) > 0 {
+		dest = strings.Trim(fields[0], "<>")
+	}
+	if dest == "" {
+		return "", "", 0, false
+	}
+	return text, dest, k + 1, true
+}
+
+// spansText concatenates the text of spans.
+func spansText(spans []inlineSpan) string {
+	var sb strings.Builder
+	for _, s := range spans {
+		sb.WriteString(s.text)
+	}
+	return sb.String()
+}
+
+// utf16Len is the length of s in UTF-16 code units, the unit of Docs indexes.
+func utf16Len(s string) int64 {
+	var n int64
+	for _, r := range s {
+		n++
+		if r >= 0x10000 {
+			n++
+		}
+	}
+	return n
+}
+
+// docPlan accumulates batchUpdate requests that append Markdown blocks to a
+// document body. index is where the next block goes: the start of the
+// body's final, empty paragraph, which keeps the default style so inserted
+// text inherits none.
+type docPlan struct {
+	index    int64
+	requests []*docspb.Request
+}
+
+func (p *docPlan) add(r ...*docspb.Request) {
+	p.requests = append(p.requests, r...)
+}
+
+func (p *docPlan) insertAt(index int64, text string) {
+	p.add(&docspb.Request{InsertText: &docspb.InsertTextRequest{Text: text, Location: &docspb.Location{Index: index}}})
+}
+
+// block appends every kind of block except tables, which need the
+// document's indexes after insertion; see writeMarkdown.
+func (p *docPlan) block(b mdBlock) {
+	switch b.kind {
+	case mdHeading:
+		p.paragraph(parseInline(b.text), &docspb.ParagraphStyle{NamedStyleType: fmt.Sprintf("HEADING_%d", b.level)}, "namedStyleType")
+	case mdQuote:
+		indent := &docspb.Dimension{Magnitude: 36, Unit: "PT"}
+		p.paragraph(parseInline(b.text), &docspb.ParagraphStyle{IndentStart: indent, IndentFirstLine: indent}, "indentStart,indentFirstLine")
+	case mdCode:
+		p.code(b.lines)
+	case mdList:
+		p.list(b)
+	default:
+		p.paragraph(parseInline(b.text), nil, "")
+	}
+}
+
+func (p *docPlan) paragraph(spans []inlineSpan, style *docspb.ParagraphStyle, fields string) {
+	text := spansText(spans)
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	start := p.index
+	p.insertAt(start, text+"\n")
+	p.index += utf16Len(text) + 1
+	if style != nil {
+		p.add(&docspb.Request{UpdateParagraphStyle: &docspb.UpdateParagraphStyleRequest{
+			Range:          &docspb.Range{StartIndex: start, EndIndex: p.index},
+			ParagraphStyle: style,
+			Fields:         fields,
+		}})
+	}
+	p.textStyles(start, spans, false)
+}
+
+func (p *docPlan) code(lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	text := strings.Join(lines, "\n")
+	start := p.index
+	p.insertAt(start, text+"\n")
+	p.index += utf16Len(text) + 1
+	p.add(&docspb.Request{UpdateParagraphStyle: &docspb.UpdateParagraphStyleRequest{
+		Range: &docspb.Range{StartIndex: start, EndIndex: p.index},
+		ParagraphStyle: &docspb.ParagraphStyle{Shading: &docspb.Shading{
+			BackgroundColor: &docspb.OptionalColor{Color: &docspb.Color{RgbColor: &docspb.RgbColor{Red: 0.95, Green: 0.95, Blue: 0.95}}},
+		}},
+		Fields: "shading.backgroundColor",
+	}})
+	if text != "" {
+		p.add(&docspb.Request{UpdateTextStyle: &docspb.UpdateTextStyleRequest{
+			Range:     &docspb.Range{StartIndex: start, EndIndex: start + utf16Len(text)},
+			TextStyle: &docspb.TextStyle{WeightedFontFamily: &docspb.WeightedFontFamily{FontFamily: codeFontFamily}},
+			Fields:    "weightedFontFamily",
+		}})
+	}
+}
+
+// list inserts the items with one leading tab per nesting level, which
+// CreateParagraphBullets turns into nesting and removes; text styles are
+// applied afterwards at the tab-free indexes.
+func (p *docPlan) list(b mdBlock) {
+	type item struct {
+		start int64
+		spans []inlineSpan
+	}
+	var text strings.Builder
+	items := make([]item, 0, len(b.items))
+	start, next := p.index, p.index
+	for _, it := range b.items {
+		spans := parseInline(it.text)
+		t := spansText(spans)
+		text.WriteString(strings.Repeat("\t", it.depth) + t + "\n")
+		items = append(items, item{start: next, spans: spans})
+		next += utf16Len(t) + 1
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	preset := "BULLET_DISC_CIRCLE_SQUARE"
+	if b.ordered {
+		preset = "NUMBERED_DECIMAL_ALPHA_ROMAN"
+	}
+	p.insertAt(start, text.String())
+	p.add(&docspb.Request{CreateParagraphBullets: &docspb.CreateParagraphBulletsRequest{
+		Range:        &docspb.Range{StartIndex: start, EndIndex: start + utf16Len(text.String())},
+		BulletPreset: preset,
+	}})
+	p.index = next
+	for _, it := range items {
+		p.textStyles(it.start, it.spans, false)
+	}
+}
+
+// table fills the cells of a table already in the document. Cells are
+// filled last to first so each insertion leaves the indexes of the cells
+// still to fill unchanged. end is the table's end index before filling.
+func (p *docPlan) table(t *docspb.Table, rows [][]string, end int64) {
+	var added int64
+	for r := min(len(rows), len(t.TableRows)) - 1; r >= 0; r-- {
+		cells := t.TableRows[r].TableCells
+		for c := min(len(rows[r]), len(cells)) - 1; c >= 0; c-- {
+			spans := parseInline(rows[r][c])
+			text := spansText(spans)
+			if text == "" || len(cells[c].Content) == 0 {
+				continue
+			}
+			start := cells[c].Content[0].StartIndex
+			p.insertAt(start, text)
+			p.textStyles(start, spans, r == 0)
+			added += utf16Len(text)
+		}
+	}
+	p.index = end + added
+}
+
+// textStyles applies the character formatting of spans laid out from start.
+// bold forces bold on all of them, for table header cells.
+func (p *docPlan) textStyles(start int64, spans []inlineSpan, bold bool) {
+	for _, s := range spans {
+		n := utf16Len(s.text)
+		style := &docspb.TextStyle{}
+		var fields []string
+		if s.style.bold || bold {
+			style.Bold = true
+			fields = append(fields, "bold")
+		}
+		if s.style.italic {
+			style.Italic = true
+			fields = append(fields, "italic")
+		}
+		if s.style.strike {
+			style.Strikethrough = true
+			fields = append(fields, "strikethrough")
+		}
+		if s.style.code {
+			style.WeightedFontFamily = &docspb.WeightedFontFamily{FontFamily: codeFontFamily}
+			fields = append(fields, "weightedFontFamily")
+		}
+		if s.style.link != "" {
+			style.Link = &docspb.Link{Url: s.style.link}
+			fields = append(fields, "link")
+		}
+		if len(fields) > 0 {
+			p.add(&docspb.Request{UpdateTextStyle: &docspb.UpdateTextStyleRequest{
+				Range:     &docspb.Range{StartIndex: start, EndIndex: start + n},
+				TextStyle: style,
+				Fields:    strings.Join(fields, ","),
+			}})
+		}
+		start += n
+	}
+}
+
+// flush sends the accumulated requests.
+func (p *docPlan) flush(ctx context.Context, srv *docspb.Service, docID string) error {
+	if len(p.requests) == 0 {
+		return nil
+	}
+	_, err := srv.Documents.BatchUpdate(docID, &docspb.BatchUpdateDocumentRequest{Requests: p.requests}).Context(ctx).Do()
+	p.requests = nil
+	return err
+}
+
+// writeMarkdown appends blocks to the empty document docID. Text blocks are
+// batched; each table is inserted on its own and the document re-read to
+// find its cell indexes, so a document with n tables takes n+1 batch
+// updates and n reads.
+func writeMarkdown(ctx context.Context, srv *docspb.Service, docID string, blocks []mdBlock) error {
+	// A new document's body is a single empty paragraph starting at index 1.
+	p := &docPlan{index: 1}
+	for _, b := range blocks {
+		if b.kind != mdTable {
+			p.block(b)
+			continue
+		}
+		cols := 0
+		for _, row := range b.rows {
+			cols = max(cols, len(row))
+		}
+		// Docs inserts a newline before the table, so it starts one past at.
+		at := p.index
+		p.add(&docspb.Request{InsertTable: &docspb.InsertTableRequest{
+			Rows:     int64(len(b.rows)),
+			Columns:  int64(cols),
+			Location: &docspb.Location{Index: at},
+		}})
+		if err := p.flush(ctx, srv, docID); err != nil {
+			return err
+		}
+		doc, err := srv.Documents.Get(docID).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		var table *docspb.StructuralElement
+		for _, elem := range doc.Body.Content {
+			if elem.Table != nil && elem.StartIndex > at {
+				table = elem
+				break
+			}
+		}
+		if table == nil {
+			return fmt.Errorf("inserted table not found in document %s", docID)
+		}
+		p.table(table.Table, b.rows, table.EndIndex)
+	}
+	return p.flush(ctx, srv, docID)
+}
@@ -0,0 +1,160 @@
+package docs
+
+import (
+	"reflect"
+	"testing"
+
+	docspb "google.golang.org/api/docs/v1"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	src := "# Launch Plan ##\r\n" +
+		"Ship on time\nand under budget.\n" +
+		"\n" +
+		"---\n" +
+		"- Design\n" +
+		"  - Mockups\n" +
+		"    continued\n" +
+		"- Build\n" +
+		"\n" +
+		"2. First\n" +
+		"3. Second\n" +
+		"> Quoted\n" +
+		"> text\n" +
+		"```go\n" +
+		"x := 1\n" +
+		"\n" +
+		"```\n" +
+		"| Owner | Task |\n" +
+		"|:---|---:|\n" +
+		"| Ana | a \\| b |\n"
+
+	want := []mdBlock{
+		{kind: mdHeading, level: 1, text: "Launch Plan"},
+		{kind: mdParagraph, text: "Ship on time and under budget."},
+		{kind: mdList, items: []mdItem{{0, "Design"}, {1, "Mockups continued"}, {0, "Build"}}},
+		{kind: mdList, ordered: true, items: []mdItem{{0, "First"}, {0, "Second"}}},
+		{kind: mdQuote, text: "Quoted text"},
+		{kind: mdCode, lines: []string{"x := 1", ""}},
+		{kind: mdTable, rows: [][]string{{"Owner", "Task"}, {"Ana", "a | b"}}},
+	}
+	if got := parseMarkdown(src); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseMarkdown() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseInline(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []inlineSpan
+	}{
+		{"plain", []inlineSpan{{"plain", inlineStyle{}}}},
+		{"a **b *c* d** e", []inlineSpan{
+			{"a ", inlineStyle{}},
+			{"b ", inlineStyle{bold: true}},
+			{"c", inlineStyle{bold: true, italic: true}},
+			{" d", inlineStyle{bold: true}},
+			{" e", inlineStyle{}},
+		}},
+		{"***both*** ~~gone~~", []inlineSpan{
+			{"both", inlineStyle{bold: true, italic: true}},
+			{" ", inlineStyle{}},
+			{"gone", inlineStyle{strike: true}},
+		}},
+		{"snake_case_name and _em_", []inlineSpan{
+			{"snake_case_name and ", inlineStyle{}},
+			{"em", inlineStyle{italic: true}},
+		}},
+		{"use `*x*` now", []inlineSpan{
+			{"use ", inlineStyle{}},
+			{"*x*", inlineStyle{code: true}},
+			{" now", inlineStyle{}},
+		}},
+		{"see [the **brief**](https://example.com/a_(b) \"t\")!", []inlineSpan{
+			{"see ", inlineStyle{}},
+			{"the ", inlineStyle{link: "https://example.com/a_(b)"}},
+			{"brief", inlineStyle{bold: true, link: "https://example.com/a_(b)"}},
+			{"!", inlineStyle{}},
+		}},
+		{"<https://example.com> ![](https://img) \\*lit\\* 2 * 3", []inlineSpan{
+			{"https://example.com", inlineStyle{link: "https://example.com"}},
+			{" ", inlineStyle{}},
+			{"https://img", inlineStyle{link: "https://img"}},
+			{" *lit* 2 * 3", inlineStyle{}},
+		}},
+	}
+	for _, tt := range tests {
+		if got := parseInline(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseInline(%q) =\n%+v\nwant\n%+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDocPlanList(t *testing.T) {
+	p := &docPlan{index: 1}
+	p.block(mdBlock{kind: mdHeading, level: 2, text: "Tasks 🚀"})
+	p.block(mdBlock{kind: mdList, items: []mdItem{{0, "one"}, {1, "**two**"}}})
+
+	// "Tasks 🚀\n" is 9 UTF-16 units; the list's tab is removed by the
+	// bullets request, so "two" starts at 10+4.
+	want := []*docspb.Request{
+		{InsertText: &docspb.InsertTextRequest{Text: "Tasks 🚀\n", Location: &docspb.Location{Index: 1}}},
+		{UpdateParagraphStyle: &docspb.UpdateParagraphStyleRequest{
+			Range:          &docspb.Range{StartIndex: 1, EndIndex: 10},
+			ParagraphStyle: &docspb.ParagraphStyle{NamedStyleType: "HEADING_2"},
+			Fields:         "namedStyleType",
+		}},
+		{InsertText: &docspb.InsertTextRequest{Text: "one\n\ttwo\n", Location: &docspb.Location{Index: 10}}},
+		{CreateParagraphBullets: &docspb.CreateParagraphBulletsRequest{
+			Range:        &docspb.Range{StartIndex: 10, EndIndex: 19},
+			BulletPreset: "BULLET_DISC_CIRCLE_SQUARE",
+		}},
+		{UpdateTextStyle: &docspb.UpdateTextStyleRequest{
+			Range:     &docspb.Range{StartIndex: 14, EndIndex: 17},
+			TextStyle: &docspb.TextStyle{Bold: true},
+			Fields:    "bold",
+		}},
+	}
+	if !reflect.DeepEqual(p.requests, want) {
+		t.Errorf("requests =\n%+v\nwant\n%+v", p.requests, want)
+	}
+	if p.index != 18 {
+		t.Errorf("index = %d, want 18", p.index)
+	}
+}
+
+func TestDocPlanTable(t *testing.T) {
+	cell := func(start int64) *docspb.TableCell {
+		return &docspb.TableCell{Content: []*docspb.StructuralElement{{StartIndex: start}}}
+	}
+	// An empty 2x2 table inserted at index 1: each row opens at +1 and each
+	// cell holds one newline.
+	table := &docspb.Table{TableRows: []*docspb.TableRow{
+		{TableCells: []*docspb.TableCell{cell(4), cell(6)}},
+		{TableCells: []*docspb.TableCell{cell(9), cell(11)}},
+	}}
+
+	p := &docPlan{}
+	p.table(table, [][]string{{"Owner", "Task"}, {"", "*Ship*"}}, 13)
+
+	want := []*docspb.Request{
+		{InsertText: &docspb.InsertTextRequest{Text: "Ship", Location: &docspb.Location{Index: 11}}},
+		{UpdateTextStyle: &docspb.UpdateTextStyleRequest{
+			Range: &docspb.Range{StartIndex: 11, EndIndex: 15}, TextStyle: &docspb.TextStyle{Italic: true}, Fields: "italic",
+		}},
+		{InsertText: &docspb.InsertTextRequest{Text: "Task", Location: &docspb.Location{Index: 6}}},
+		{UpdateTextStyle: &docspb.UpdateTextStyleRequest{
+			Range: &docspb.Range{StartIndex: 6, EndIndex: 10}, TextStyle: &docspb.TextStyle{Bold: true}, Fields: "bold",
+		}},
+		{InsertText: &docspb.InsertTextRequest{Text: "Owner", Location: &docspb.Location{Index: 4}}},
+		{UpdateTextStyle: &docspb.UpdateTextStyleRequest{
+			Range: &docspb.Range{StartIndex: 4, EndIndex: 9}, TextStyle: &docspb.TextStyle{Bold: true}, Fields: "bold",
+		}},
+	}
+	if !reflect.DeepEqual(p.requests, want) {
+		t.Errorf("requests =\n%+v\nwant\n%+v", p.requests, want)
+	}
+	if p.index != 26 {
+		t.Errorf("index = %d, want 26", p.index)
+	}
+}
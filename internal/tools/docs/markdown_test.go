@@ -0,0 +1,122 @@
+package docs
+
+import (
+	"testing"
+
+	docspb "google.golang.org/api/docs/v1"
+)
+
+func textRun(text string, style *docspb.TextStyle) *docspb.ParagraphElement {
+	return &docspb.ParagraphElement{TextRun: &docspb.TextRun{Content: text, TextStyle: style}}
+}
+
+func para(style string, elems ...*docspb.ParagraphElement) *docspb.StructuralElement {
+	return &docspb.StructuralElement{Paragraph: &docspb.Paragraph{
+		Elements:       elems,
+		ParagraphStyle: &docspb.ParagraphStyle{NamedStyleType: style},
+	}}
+}
+
+func bullet(listID string, level int64, text string) *docspb.StructuralElement {
+	elem := para("NORMAL_TEXT", textRun(text+"\n", nil))
+	elem.Paragraph.Bullet = &docspb.Bullet{ListId: listID, NestingLevel: level}
+	return elem
+}
+
+func tableCell(text string) *docspb.TableCell {
+	return &docspb.TableCell{Content: []*docspb.StructuralElement{para("NORMAL_TEXT", textRun(text+"\n", nil))}}
+}
+
+func TestDocToMarkdown(t *testing.T) {
+	doc := &docspb.Document{
+		Body: &docspb.Body{Content: []*docspb.StructuralElement{
+			{SectionBreak: &docspb.SectionBreak{}},
+			para("TITLE", textRun("Launch Plan\n", nil)),
+			para("HEADING_2", textRun("Goals\n", nil)),
+			para("NORMAL_TEXT",
+				textRun("Ship ", nil),
+				textRun("on time ", &docspb.TextStyle{Bold: true}),
+				textRun("and see ", nil),
+				textRun("the brief", &docspb.TextStyle{Italic: true, Link: &docspb.Link{Url: "https://example.com/brief (v2)"}}),
+				textRun(".", nil),
+				&docspb.ParagraphElement{FootnoteReference: &docspb.FootnoteReference{FootnoteId: "fn1", FootnoteNumber: "1"}},
+				textRun("\n", nil),
+			),
+			bullet("ul", 0, "Design"),
+			bullet("ul", 1, "Mockups"),
+			bullet("ol", 0, "First"),
+			bullet("ol", 0, "Second"),
+			para("NORMAL_TEXT", textRun("\n", nil)),
+			para("NORMAL_TEXT", textRun("# not a heading, *not* emphasis\n", nil)),
+			para("NORMAL_TEXT", textRun("Line one\vLine two\n", nil)),
+			{Table: &docspb.Table{TableRows: []*docspb.TableRow{
+				{TableCells: []*docspb.TableCell{tableCell("Owner"), tableCell("Task")}},
+				{TableCells: []*docspb.TableCell{tableCell("Ana"), tableCell("a | b")}},
+			}}},
+			para("NORMAL_TEXT", &docspb.ParagraphElement{HorizontalRule: &docspb.HorizontalRule{}}, textRun("\n", nil)),
+			para("NORMAL_TEXT", &docspb.ParagraphElement{InlineObjectElement: &docspb.InlineObjectElement{InlineObjectId: "img1"}}, textRun("\n", nil)),
+		}},
+		Lists: map[string]docspb.List{
+			"ol": {ListProperties: &docspb.ListProperties{NestingLevels: []*docspb.NestingLevel{{GlyphType: "DECIMAL"}}}},
+			"ul": {ListProperties: &docspb.ListProperties{NestingLevels: []*docspb.NestingLevel{{GlyphSymbol: "●"}, {GlyphSymbol: "○"}}}},
+		},
+		Footnotes: map[string]docspb.Footnote{
+			"fn1": {Content: []*docspb.StructuralElement{para("NORMAL_TEXT", textRun(" Approved in March.\n", nil))}},
+		},
+		InlineObjects: map[string]docspb.InlineObject{
+			"img1": {InlineObjectProperties: &docspb.InlineObjectProperties{EmbeddedObject: &docspb.EmbeddedObject{
+				Title:           "Timeline",
+				ImageProperties: &docspb.ImageProperties{ContentUri: "https://lh3.googleusercontent.com/x"},
+			}}},
+		},
+	}
+
+	got, images := docToMarkdown(doc)
+	want := `# Launch Plan
+
+## Goals
+
+Ship **on time** and see [*the brief*](https://example.com/brief%20%28v2%29).[^1]
+
+- Design
+    - Mockups
+
+1. First
+1. Second
+
+\# not a heading, \*not\* emphasis
+
+Line one\
+Line two
+
+| Owner | Task |
+| --- | --- |
+| Ana | a \| b |
+
+---
+
+![Timeline](https://lh3.googleusercontent.com/x)
+
+[^1]: Approved in March.
+`
+	if got != want {
+		t.Errorf("docToMarkdown =\n%s\nwant\n%s", got, want)
+	}
+	if images != 1 {
+		t.Errorf("images = %d, want 1", images)
+	}
+}
+
+func TestEscapeBlockStart(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"1. First", `1\. First`},
+		{"2024) review", `2024\) review`},
+		{"- dash", `\- dash`},
+		{"plain", "plain"},
+	}
+	for _, tt := range tests {
+		if got := escapeBlockStart(tt.in); got != tt.want {
+			t.Errorf("escapeBlockStart(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,574 @@
+package docs
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	docspb "google.golang.org/api/docs/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// Named ranges tag a span of a document by name. Docs keeps them attached
+// to the text as the document is edited, so they are a stable handle for
+// "the section tagged X" where character indexes go stale after every edit.
+// The Docs API cannot create bookmarks; links can target existing bookmarks
+// and headings, and named ranges take the bookmark's place as a stable tag.
+
+// maxRangePreview bounds the text shown for each named range.
+const maxRangePreview = 80
+
+// objectReplacement stands in for inline objects in body text.
+const objectReplacement = '\uFFFC'
+
+// --- create_doc_named_range (extended) ---
+
+type CreateNamedRangeInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID string `json:"document_id" jsonschema:"required" jsonschema_description:"The document ID"`
+	Name       string `json:"name" jsonschema:"required" jsonschema_description:"Name for the range (1-256 characters); names need not be unique"`
+	MatchText  string `json:"match_text,omitempty" jsonschema_description:"Tag the first occurrence of this text (within one paragraph); use instead of start_index and end_index"`
+	Occurrence int    `json:"occurrence,omitempty" jsonschema_description:"With match_text: which occurrence to tag (default 1)"`
+	StartIndex int64  `json:"start_index,omitempty" jsonschema_description:"Start of the range to tag"`
+	EndIndex   int64  `json:"end_index,omitempty" jsonschema_description:"End of the range to tag (exclusive)"`
+}
+
+type CreateNamedRangeOutput struct {
+	DocumentID   string `json:"document_id"`
+	NamedRangeID string `json:"named_range_id"`
+	Name         string `json:"name"`
+	StartIndex   int64  `json:"start_index"`
+	EndIndex     int64  `json:"end_index"`
+}
+
+func createCreateNamedRangeHandler(factory *services.Factory) mcp.ToolHandlerFor[CreateNamedRangeInput, CreateNamedRangeOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateNamedRangeInput) (*mcp.CallToolResult, CreateNamedRangeOutput, error) {
+		if n := len([]rune(input.Name)); n == 0 || n > 256 {
+			return nil, CreateNamedRangeOutput{}, fmt.Errorf("invalid name %q — use 1 to 256 characters", input.Name)
+		}
+		byText := input.MatchText != ""
+		if byText == (input.StartIndex != 0 || input.EndIndex != 0) {
+			return nil, CreateNamedRangeOutput{}, fmt.Errorf("set either match_text or start_index and end_index")
+		}
+		if !byText && input.EndIndex <= input.StartIndex {
+			return nil, CreateNamedRangeOutput{}, fmt.Errorf("invalid range %d-%d — end_index must be after start_index", input.StartIndex, input.EndIndex)
+		}
+
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, CreateNamedRangeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		rng := &docspb.Range{StartIndex: input.StartIndex, EndIndex: input.EndIndex}
+		if byText {
+			doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+			if err != nil {
+				return nil, CreateNamedRangeOutput{}, middleware.HandleGoogleAPIError(err)
+			}
+			if rng, err = findText(doc, input.MatchText, input.Occurrence); err != nil {
+				return nil, CreateNamedRangeOutput{}, err
+			}
+		}
+
+		resp, err := srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{
+			Requests: []*docspb.Request{{CreateNamedRange: &docspb.CreateNamedRangeRequest{Name: input.Name, Range: rng}}},
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, CreateNamedRangeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := CreateNamedRangeOutput{DocumentID: input.DocumentID, Name: input.Name, StartIndex: rng.StartIndex, EndIndex: rng.EndIndex}
+		if len(resp.Replies) > 0 && resp.Replies[0].CreateNamedRange != nil {
+			out.NamedRangeID = resp.Replies[0].CreateNamedRange.NamedRangeId
+		}
+
+		rb := response.New()
+		rb.Header("Named Range Created")
+		rb.KeyValue("Name", out.Name)
+		rb.KeyValue("ID", out.NamedRangeID)
+		rb.KeyValue("Range", fmt.Sprintf("%d-%d", out.StartIndex, out.EndIndex))
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- list_doc_named_ranges (extended) ---
+
+type ListNamedRangesInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID string `json:"document_id" jsonschema:"required" jsonschema_description:"The document ID"`
+}
+
+// NamedRangeSummary is one named range with the text it currently covers.
+type NamedRangeSummary struct {
+	Name         string       `json:"name"`
+	NamedRangeID string       `json:"named_range_id"`
+	Ranges       []IndexRange `json:"ranges"`
+	Text         string       `json:"text"`
+}
+
+// IndexRange is a span of document indexes, end exclusive.
+type IndexRange struct {
+	StartIndex int64 `json:"start_index"`
+	EndIndex   int64 `json:"end_index"`
+}
+
+// HeadingSummary is a heading that internal links can target.
+type HeadingSummary struct {
+	HeadingID string `json:"heading_id"`
+	Style     string `json:"style"`
+	Text      string `json:"text"`
+}
+
+type ListNamedRangesOutput struct {
+	DocumentID  string              `json:"document_id"`
+	NamedRanges []NamedRangeSummary `json:"named_ranges"`
+	Headings    []HeadingSummary    `json:"headings"`
+}
+
+func createListNamedRangesHandler(factory *services.Factory) mcp.ToolHandlerFor[ListNamedRangesInput, ListNamedRangesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListNamedRangesInput) (*mcp.CallToolResult, ListNamedRangesOutput, error) {
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListNamedRangesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+		if err != nil {
+			return nil, ListNamedRangesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := ListNamedRangesOutput{
+			DocumentID:  doc.DocumentId,
+			NamedRanges: namedRangeSummaries(doc),
+			Headings:    headings(doc),
+		}
+
+		rb := response.New()
+		rb.Header("Named Ranges")
+		rb.KeyValue("Document", doc.Title)
+		rb.KeyValue("Count", len(out.NamedRanges))
+		rb.Blank()
+		for _, nr := range out.NamedRanges {
+			spans := make([]string, 0, len(nr.Ranges))
+			for _, r := range nr.Ranges {
+				spans = append(spans, fmt.Sprintf("%d-%d", r.StartIndex, r.EndIndex))
+			}
+			rb.Item("%s (%s)", nr.Name, strings.Join(spans, ", "))
+			rb.Line("    ID: %s", nr.NamedRangeID)
+			if nr.Text != "" {
+				rb.Line("    Text: %q", nr.Text)
+			}
+		}
+		if len(out.Headings) > 0 {
+			rb.Section("Headings (link targets)")
+			for _, h := range out.Headings {
+				rb.Item("%s [%s] %s", h.Text, h.Style, h.HeadingID)
+			}
+		}
+		return rb.TextResult(), out, nil
+	}
+}
+
+// namedRangeSummaries lists doc's named ranges sorted by name, then position.
+func namedRangeSummaries(doc *docspb.Document) []NamedRangeSummary {
+	runes := bodyRunes(doc)
+	summaries := make([]NamedRangeSummary, 0, len(doc.NamedRanges))
+	for _, group := range doc.NamedRanges {
+		for _, nr := range group.NamedRanges {
+			s := NamedRangeSummary{Name: nr.Name, NamedRangeID: nr.NamedRangeId, Ranges: make([]IndexRange, 0, len(nr.Ranges))}
+			var text []string
+			for _, r := range nr.Ranges {
+				s.Ranges = append(s.Ranges, IndexRange{StartIndex: r.StartIndex, EndIndex: r.EndIndex})
+				text = append(text, runesInRange(runes, r.StartIndex, r.EndIndex))
+			}
+			s.Text = truncateRunes(strings.Join(text, " … "), maxRangePreview)
+			summaries = append(summaries, s)
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return len(a.Ranges) > 0 && len(b.Ranges) > 0 && a.Ranges[0].StartIndex < b.Ranges[0].StartIndex
+	})
+	return summaries
+}
+
+// headings lists the document's headings in order.
+func headings(doc *docspb.Document) []HeadingSummary {
+	var out []HeadingSummary
+	walkParagraphs(doc, func(p *docspb.Paragraph) {
+		if p.ParagraphStyle == nil || p.ParagraphStyle.HeadingId == "" {
+			return
+		}
+		out = append(out, HeadingSummary{
+			HeadingID: p.ParagraphStyle.HeadingId,
+			Style:     p.ParagraphStyle.NamedStyleType,
+			Text:      strings.TrimSpace(paragraphText(p)),
+		})
+	})
+	return out
+}
+
+// --- delete_doc_named_range (extended) ---
+
+type DeleteNamedRangeInput struct {
+	UserEmail    string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID   string `json:"document_id" jsonschema:"required" jsonschema_description:"The document ID"`
+	Name         string `json:"name,omitempty" jsonschema_description:"Delete every named range with this name"`
+	NamedRangeID string `json:"named_range_id,omitempty" jsonschema_description:"Delete only the named range with this ID"`
+}
+
+func createDeleteNamedRangeHandler(factory *services.Factory) mcp.ToolHandlerFor[DeleteNamedRangeInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DeleteNamedRangeInput) (*mcp.CallToolResult, any, error) {
+		if (input.Name == "") == (input.NamedRangeID == "") {
+			return nil, nil, fmt.Errorf("set exactly one of name or named_range_id")
+		}
+
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		_, err = srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{
+			Requests: []*docspb.Request{{DeleteNamedRange: &docspb.DeleteNamedRangeRequest{Name: input.Name, NamedRangeId: input.NamedRangeID}}},
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Named Range Deleted")
+		rb.KeyValue("Document ID", input.DocumentID)
+		if input.Name != "" {
+			rb.KeyValue("Name", input.Name)
+		} else {
+			rb.KeyValue("ID", input.NamedRangeID)
+		}
+		rb.Line("Only the tag was removed; the text it covered is unchanged.")
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// --- replace_doc_named_range_text (extended) ---
+
+type ReplaceNamedRangeTextInput struct {
+	UserEmail    string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID   string `json:"document_id" jsonschema:"required" jsonschema_description:"The document ID"`
+	Name         string `json:"name,omitempty" jsonschema_description:"Replace the content of every named range with this name"`
+	NamedRangeID string `json:"named_range_id,omitempty" jsonschema_description:"Replace the content of only the named range with this ID"`
+	Text         string `json:"text" jsonschema:"required" jsonschema_description:"New content; the named range is kept and covers it"`
+}
+
+func createReplaceNamedRangeTextHandler(factory *services.Factory) mcp.ToolHandlerFor[ReplaceNamedRangeTextInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ReplaceNamedRangeTextInput) (*mcp.CallToolResult, any, error) {
+		if (input.Name == "") == (input.NamedRangeID == "") {
+			return nil, nil, fmt.Errorf("set exactly one of name or named_range_id")
+		}
+		if input.Text == "" {
+			return nil, nil, fmt.Errorf("text is empty — to remove the tag use delete_doc_named_range")
+		}
+
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		// A name that matches nothing is a silent no-op in the API, so check.
+		doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		count := 0
+		for _, s := range namedRangeSummaries(doc) {
+			if s.Name == input.Name || s.NamedRangeID == input.NamedRangeID {
+				count++
+			}
+		}
+		if count == 0 {
+			return nil, nil, fmt.Errorf("no named range %q in document %s — use list_doc_named_ranges to see them", cmp.Or(input.Name, input.NamedRangeID), input.DocumentID)
+		}
+
+		_, err = srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{
+			Requests: []*docspb.Request{{ReplaceNamedRangeContent: &docspb.ReplaceNamedRangeContentRequest{
+				NamedRangeName: input.Name,
+				NamedRangeId:   input.NamedRangeID,
+				Text:           input.Text,
+			}}},
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Named Range Content Replaced")
+		rb.KeyValue("Document ID", input.DocumentID)
+		rb.KeyValue("Named range", cmp.Or(input.Name, input.NamedRangeID))
+		rb.KeyValue("Ranges replaced", count)
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// --- insert_doc_internal_link (extended) ---
+
+type InsertInternalLinkInput struct {
+	UserEmail   string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID  string `json:"document_id" jsonschema:"required" jsonschema_description:"The document ID"`
+	HeadingText string `json:"heading_text,omitempty" jsonschema_description:"Link to the first heading with this text (case-insensitive)"`
+	HeadingID   string `json:"heading_id,omitempty" jsonschema_description:"Link to the heading with this ID, from list_doc_named_ranges"`
+	BookmarkID  string `json:"bookmark_id,omitempty" jsonschema_description:"Link to an existing bookmark, such as id.abc123 from a #bookmark= URL"`
+	NamedRange  string `json:"named_range,omitempty" jsonschema_description:"Turn the text of every named range with this name into the link"`
+	MatchText   string `json:"match_text,omitempty" jsonschema_description:"Turn the first occurrence of this text into the link"`
+	Text        string `json:"text,omitempty" jsonschema_description:"Insert this text as the link at index"`
+	Index       int64  `json:"index,omitempty" jsonschema_description:"Where to insert text (1-based)"`
+}
+
+func createInsertInternalLinkHandler(factory *services.Factory) mcp.ToolHandlerFor[InsertInternalLinkInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input InsertInternalLinkInput) (*mcp.CallToolResult, any, error) {
+		if countSet(input.HeadingText, input.HeadingID, input.BookmarkID) != 1 {
+			return nil, nil, fmt.Errorf("set exactly one of heading_text, heading_id, or bookmark_id")
+		}
+		if countSet(input.NamedRange, input.MatchText, input.Text) != 1 {
+			return nil, nil, fmt.Errorf("set exactly one of named_range, match_text, or text with index")
+		}
+		if input.Text != "" && input.Index < 1 {
+			return nil, nil, fmt.Errorf("invalid index %d — text needs an insertion index of 1 or more", input.Index)
+		}
+
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		link, target, err := internalLink(doc, input)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var requests []*docspb.Request
+		var ranges []*docspb.Range
+		switch {
+		case input.Text != "":
+			requests = append(requests, &docspb.Request{InsertText: &docspb.InsertTextRequest{
+				Text:     input.Text,
+				Location: &docspb.Location{Index: input.Index},
+			}})
+			ranges = append(ranges, &docspb.Range{StartIndex: input.Index, EndIndex: input.Index + utf16Len(input.Text)})
+		case input.MatchText != "":
+			rng, err := findText(doc, input.MatchText, 1)
+			if err != nil {
+				return nil, nil, err
+			}
+			ranges = append(ranges, rng)
+		default:
+			group, ok := doc.NamedRanges[input.NamedRange]
+			if !ok {
+				return nil, nil, fmt.Errorf("no named range %q in document %s — use list_doc_named_ranges to see them", input.NamedRange, input.DocumentID)
+			}
+			for _, nr := range group.NamedRanges {
+				for _, r := range nr.Ranges {
+					ranges = append(ranges, &docspb.Range{StartIndex: r.StartIndex, EndIndex: r.EndIndex})
+				}
+			}
+		}
+		for _, rng := range ranges {
+			requests = append(requests, &docspb.Request{UpdateTextStyle: &docspb.UpdateTextStyleRequest{
+				Range:     rng,
+				TextStyle: &docspb.TextStyle{Link: link},
+				Fields:    "link",
+			}})
+		}
+
+		_, err = srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{Requests: requests}).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Internal Link Added")
+		rb.KeyValue("Document ID", input.DocumentID)
+		rb.KeyValue("Target", target)
+		rb.KeyValue("Links", len(ranges))
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// internalLink resolves the link target of input against doc and returns
+// the link with a description of its target.
+func internalLink(doc *docspb.Document, input InsertInternalLinkInput) (*docspb.Link, string, error) {
+	switch {
+	case input.BookmarkID != "":
+		return &docspb.Link{Bookmark: &docspb.BookmarkLink{Id: input.BookmarkID}}, "bookmark " + input.BookmarkID, nil
+	case input.HeadingID != "":
+		for _, h := range headings(doc) {
+			if h.HeadingID == input.HeadingID {
+				return &docspb.Link{Heading: &docspb.HeadingLink{Id: h.HeadingID}}, fmt.Sprintf("heading %q", h.Text), nil
+			}
+		}
+		return nil, "", fmt.Errorf("no heading with ID %q — use list_doc_named_ranges to see heading IDs", input.HeadingID)
+	default:
+		for _, h := range headings(doc) {
+			if strings.EqualFold(h.Text, strings.TrimSpace(input.HeadingText)) {
+				return &docspb.Link{Heading: &docspb.HeadingLink{Id: h.HeadingID}}, fmt.Sprintf("heading %q", h.Text), nil
+			}
+		}
+		return nil, "", fmt.Errorf("no heading with text %q — use list_doc_named_ranges to see headings", input.HeadingText)
+	}
+}
+
+// countSet returns how many of values are non-empty.
+func countSet(values ...string) int {
+	n := 0
+	for _, v := range values {
+		if v != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// docRune is one character of body text with its document index.
+type docRune struct {
+	r     rune
+	index int64
+}
+
+// bodyRunes returns the body text one paragraph per slice, including
+// paragraphs in table cells. Inline objects and other non-text elements
+// appear as U+FFFC so indexes stay aligned.
+func bodyRunes(doc *docspb.Document) [][]docRune {
+	var paras [][]docRune
+	walkParagraphs(doc, func(p *docspb.Paragraph) {
+		var runes []docRune
+		for _, pe := range p.Elements {
+			if pe.TextRun == nil {
+				for i := pe.StartIndex; i < pe.EndIndex; i++ {
+					runes = append(runes, docRune{r: objectReplacement, index: i})
+				}
+				continue
+			}
+			index := pe.StartIndex
+			for _, r := range pe.TextRun.Content {
+				runes = append(runes, docRune{r: r, index: index})
+				index += utf16Len(string(r))
+			}
+		}
+		paras = append(paras, runes)
+	})
+	return paras
+}
+
+// walkParagraphs calls fn for every body paragraph in document order,
+// descending into table cells.
+func walkParagraphs(doc *docspb.Document, fn func(*docspb.Paragraph)) {
+	if doc.Body == nil {
+		return
+	}
+	var walk func([]*docspb.StructuralElement)
+	walk = func(content []*docspb.StructuralElement) {
+		for _, elem := range content {
+			switch {
+			case elem.Paragraph != nil:
+				fn(elem.Paragraph)
+			case elem.Table != nil:
+				for _, row := range elem.Table.TableRows {
+					for _, cell := range row.TableCells {
+						walk(cell.Content)
+					}
+				}
+			}
+		}
+	}
+	walk(doc.Body.Content)
+}
+
+// paragraphText returns the text of a paragraph's text runs.
+func paragraphText(p *docspb.Paragraph) string {
+	var sb strings.Builder
+	for _, pe := range p.Elements {
+		if pe.TextRun != nil {
+			sb.WriteString(pe.TextRun.Content)
+		}
+	}
+	return sb.String()
+}
+
+// findText returns the range of the nth (1-based, default 1) occurrence of
+// text in the body. Matches do not span paragraphs.
+func findText(doc *docspb.Document, text string, n int) (*docspb.Range, error) {
+	n = max(n, 1)
+	matches := findAllText(doc, text)
+	switch {
+	case len(matches) == 0:
+		return nil, fmt.Errorf("text %q not found in document — matches must fall within one paragraph", text)
+	case n > len(matches):
+		return nil, fmt.Errorf("text %q occurs %d times, not %d", text, len(matches), n)
+	}
+	return matches[n-1], nil
+}
+
+// findAllText returns the ranges of every non-overlapping occurrence of
+// text in the body, in document order.
+func findAllText(doc *docspb.Document, text string) []*docspb.Range {
+	want := []rune(text)
+	if len(want) == 0 {
+		return nil
+	}
+	var matches []*docspb.Range
+	for _, para := range bodyRunes(doc) {
+		for i := 0; i+len(want) <= len(para); i++ {
+			match := true
+			for j, r := range want {
+				if para[i+j].r != r {
+					match = false
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+			last := para[i+len(want)-1]
+			matches = append(matches, &docspb.Range{StartIndex: para[i].index, EndIndex: last.index + utf16Len(string(last.r))})
+			i += len(want) - 1
+		}
+	}
+	return matches
+}
+
+// runesInRange returns the body text between start and end, dropping
+// object placeholders and paragraph breaks.
+func runesInRange(paras [][]docRune, start, end int64) string {
+	var sb strings.Builder
+	for _, para := range paras {
+		for _, dr := range para {
+			if dr.index >= start && dr.index < end && dr.r != objectReplacement {
+				if dr.r == '\n' {
+					sb.WriteByte(' ')
+					continue
+				}
+				sb.WriteRune(dr.r)
+			}
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// truncateRunes shortens s to at most n runes, marking the cut with "...".
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "..."
+}
@@ -0,0 +1,108 @@
+package docs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	docspb "google.golang.org/api/docs/v1"
+)
+
+// indexedPara builds a body paragraph whose elements start at index.
+func indexedPara(index int64, style *docspb.ParagraphStyle, texts ...string) *docspb.StructuralElement {
+	p := &docspb.Paragraph{ParagraphStyle: style}
+	start := index
+	for _, text := range texts {
+		elem := &docspb.ParagraphElement{StartIndex: index}
+		if text == "" {
+			// An inline object occupies one index and has no text run.
+			elem.InlineObjectElement = &docspb.InlineObjectElement{InlineObjectId: "img"}
+			index++
+		} else {
+			elem.TextRun = &docspb.TextRun{Content: text}
+			index += utf16Len(text)
+		}
+		elem.EndIndex = index
+		p.Elements = append(p.Elements, elem)
+	}
+	return &docspb.StructuralElement{StartIndex: start, EndIndex: index, Paragraph: p}
+}
+
+func namedRangesDoc() *docspb.Document {
+	return &docspb.Document{
+		Title: "Plan",
+		Body: &docspb.Body{Content: []*docspb.StructuralElement{
+			{EndIndex: 1, SectionBreak: &docspb.SectionBreak{}},
+			indexedPara(1, &docspb.ParagraphStyle{NamedStyleType: "HEADING_1", HeadingId: "h.goals"}, "Goals\n"),
+			indexedPara(7, nil, "Ship 🚀 ", "", "on time, ", "ship\n"),
+			{StartIndex: 30, EndIndex: 44, Table: &docspb.Table{TableRows: []*docspb.TableRow{{TableCells: []*docspb.TableCell{
+				{Content: []*docspb.StructuralElement{indexedPara(32, nil, "ship it\n")}},
+			}}}}},
+			indexedPara(44, &docspb.ParagraphStyle{NamedStyleType: "HEADING_2", HeadingId: "h.risks"}, "Risks\n"),
+		}},
+		NamedRanges: map[string]docspb.NamedRanges{
+			"status": {Name: "status", NamedRanges: []*docspb.NamedRange{
+				{Name: "status", NamedRangeId: "kix.2", Ranges: []*docspb.Range{{StartIndex: 32, EndIndex: 39}}},
+				{Name: "status", NamedRangeId: "kix.1", Ranges: []*docspb.Range{{StartIndex: 7, EndIndex: 30}}},
+			}},
+		},
+	}
+}
+
+func TestFindText(t *testing.T) {
+	doc := namedRangesDoc()
+	tests := []struct {
+		text       string
+		n          int
+		start, end int64
+	}{
+		// The emoji is two UTF-16 units and the image one index.
+		{"on time", 0, 16, 23},
+		{"🚀", 1, 12, 14},
+		{"ship", 2, 32, 36},
+		{"Risks", 1, 44, 49},
+	}
+	for _, tt := range tests {
+		got, err := findText(doc, tt.text, tt.n)
+		if err != nil {
+			t.Errorf("findText(%q, %d) error = %v", tt.text, tt.n, err)
+			continue
+		}
+		if got.StartIndex != tt.start || got.EndIndex != tt.end {
+			t.Errorf("findText(%q, %d) = %d-%d, want %d-%d", tt.text, tt.n, got.StartIndex, got.EndIndex, tt.start, tt.end)
+		}
+	}
+
+	if _, err := findText(doc, "time, ship\nit", 1); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("findText across paragraphs error = %v, want not found", err)
+	}
+	if _, err := findText(doc, "ship", 3); err == nil || !strings.Contains(err.Error(), "occurs 2 times") {
+		t.Errorf("findText(ship, 3) error = %v, want occurs 2 times", err)
+	}
+}
+
+func TestNamedRangeSummaries(t *testing.T) {
+	want := []NamedRangeSummary{
+		{Name: "status", NamedRangeID: "kix.1", Ranges: []IndexRange{{7, 30}}, Text: "Ship 🚀 on time, ship"},
+		{Name: "status", NamedRangeID: "kix.2", Ranges: []IndexRange{{32, 39}}, Text: "ship it"},
+	}
+	if got := namedRangeSummaries(namedRangesDoc()); !reflect.DeepEqual(got, want) {
+		t.Errorf("namedRangeSummaries() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestInternalLink(t *testing.T) {
+	doc := namedRangesDoc()
+
+	link, target, err := internalLink(doc, InsertInternalLinkInput{HeadingText: " risks "})
+	if err != nil || link.Heading == nil || link.Heading.Id != "h.risks" || target != `heading "Risks"` {
+		t.Errorf("internalLink(heading_text) = %+v, %q, %v", link, target, err)
+	}
+	link, _, err = internalLink(doc, InsertInternalLinkInput{BookmarkID: "id.x1"})
+	if err != nil || link.Bookmark == nil || link.Bookmark.Id != "id.x1" {
+		t.Errorf("internalLink(bookmark_id) = %+v, %v", link, err)
+	}
+	if _, _, err := internalLink(doc, InsertInternalLinkInput{HeadingID: "h.missing"}); err == nil {
+		t.Error("internalLink(unknown heading_id) error = nil, want error")
+	}
+}
@@ -0,0 +1,161 @@
+package docs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	docspb "google.golang.org/api/docs/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- get_doc_outline (extended) ---
+
+type GetDocOutlineInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID string `json:"document_id" jsonschema:"required" jsonschema_description:"The document ID"`
+	MaxLevel   int    `json:"max_level,omitempty" jsonschema_description:"Deepest heading level to include, 1-6 (default 6); deeper sections still count toward their parent's words"`
+}
+
+// OutlineSection is one heading and the section it opens, which runs to the
+// next heading of the same or a higher level.
+type OutlineSection struct {
+	Level      int    `json:"level"` // 0 for the title and subtitle, n for HEADING_n
+	Style      string `json:"style"`
+	Text       string `json:"text"`
+	HeadingID  string `json:"heading_id,omitempty"`
+	StartIndex int64  `json:"start_index"`
+	EndIndex   int64  `json:"end_index"`
+	Words      int    `json:"words"` // in the section, including subsections but not its own heading
+}
+
+type DocOutlineOutput struct {
+	DocumentID    string           `json:"document_id"`
+	Title         string           `json:"title"`
+	TotalWords    int              `json:"total_words"`    // including headings
+	PreambleWords int              `json:"preamble_words"` // before the first heading
+	Sections      []OutlineSection `json:"sections"`
+}
+
+func createGetDocOutlineHandler(factory *services.Factory) mcp.ToolHandlerFor[GetDocOutlineInput, DocOutlineOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetDocOutlineInput) (*mcp.CallToolResult, DocOutlineOutput, error) {
+		if input.MaxLevel == 0 {
+			input.MaxLevel = 6
+		}
+		if input.MaxLevel < 1 || input.MaxLevel > 6 {
+			return nil, DocOutlineOutput{}, fmt.Errorf("invalid max_level %d — use 1 to 6", input.MaxLevel)
+		}
+
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, DocOutlineOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+		if err != nil {
+			return nil, DocOutlineOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := docOutline(doc, input.MaxLevel)
+
+		rb := response.New()
+		rb.Header("Document Outline")
+		rb.KeyValue("Title", out.Title)
+		rb.KeyValue("Document ID", out.DocumentID)
+		rb.KeyValue("Words", out.TotalWords)
+		rb.KeyValue("Sections", len(out.Sections))
+		rb.Blank()
+		if out.PreambleWords > 0 {
+			rb.Line("(before first heading) %d words", out.PreambleWords)
+		}
+		for _, s := range out.Sections {
+			rb.Line("%s%s [%d-%d] %d words", strings.Repeat("  ", s.Level), s.Text, s.StartIndex, s.EndIndex, s.Words)
+		}
+		return rb.TextResult(), out, nil
+	}
+}
+
+// headingLevel returns the outline level of a named style, or -1 for
+// styles that are not headings.
+func headingLevel(style string) int {
+	switch style {
+	case "TITLE", "SUBTITLE":
+		return 0
+	}
+	var level int
+	if _, err := fmt.Sscanf(style, "HEADING_%d", &level); err != nil || level < 1 || level > 6 {
+		return -1
+	}
+	return level
+}
+
+// docOutline builds the outline of doc's body from its top-level heading
+// paragraphs; headings inside tables do not open sections.
+func docOutline(doc *docspb.Document, maxLevel int) DocOutlineOutput {
+	out := DocOutlineOutput{DocumentID: doc.DocumentId, Title: doc.Title, Sections: []OutlineSection{}}
+	if doc.Body == nil || len(doc.Body.Content) == 0 {
+		return out
+	}
+	content := doc.Body.Content
+	bodyEnd := content[len(content)-1].EndIndex
+
+	// own[i] counts the words between heading i and the next heading.
+	var all []OutlineSection
+	var own []int
+	for _, elem := range content {
+		var text string
+		switch {
+		case elem.Paragraph != nil:
+			text = paragraphText(elem.Paragraph)
+			if ps := elem.Paragraph.ParagraphStyle; ps != nil && strings.TrimSpace(text) != "" {
+				if level := headingLevel(ps.NamedStyleType); level >= 0 {
+					all = append(all, OutlineSection{
+						Level:      level,
+						Style:      ps.NamedStyleType,
+						Text:       strings.TrimSpace(text),
+						HeadingID:  ps.HeadingId,
+						StartIndex: elem.StartIndex,
+					})
+					own = append(own, 0)
+					out.TotalWords += len(strings.Fields(text))
+					continue
+				}
+			}
+		case elem.Table != nil:
+			var cells []string
+			for _, row := range elem.Table.TableRows {
+				for _, cell := range row.TableCells {
+					cells = append(cells, extractCellText(cell))
+				}
+			}
+			text = strings.Join(cells, " ")
+		}
+		words := len(strings.Fields(text))
+		out.TotalWords += words
+		if len(own) == 0 {
+			out.PreambleWords += words
+		} else {
+			own[len(own)-1] += words
+		}
+	}
+
+	for i := range all {
+		all[i].EndIndex = bodyEnd
+		all[i].Words = own[i]
+		for j := i + 1; j < len(all); j++ {
+			if all[j].Level <= all[i].Level {
+				all[i].EndIndex = all[j].StartIndex
+				break
+			}
+			all[i].Words += own[j]
+		}
+		if all[i].Level <= maxLevel {
+			out.Sections = append(out.Sections, all[i])
+		}
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package docs
+
+import (
+	"reflect"
+	"testing"
+
+	docspb "google.golang.org/api/docs/v1"
+)
+
+func TestDocOutline(t *testing.T) {
+	heading := func(index int64, style, id, text string) *docspb.StructuralElement {
+		return indexedPara(index, &docspb.ParagraphStyle{NamedStyleType: style, HeadingId: id}, text)
+	}
+	doc := &docspb.Document{
+		DocumentId: "doc1",
+		Title:      "Plan",
+		Body: &docspb.Body{Content: []*docspb.StructuralElement{
+			{EndIndex: 1, SectionBreak: &docspb.SectionBreak{}},
+			indexedPara(1, nil, "Draft for review\n"),    // 1-18, 3 words
+			heading(18, "HEADING_1", "h.a", "Goals\n"),   // 18-24
+			indexedPara(24, nil, "Ship on time\n"),       // 24-37, 3 words
+			heading(37, "HEADING_2", "h.b", "Stretch\n"), // 37-45
+			indexedPara(45, nil, "Add dark mode\n"),      // 45-59, 3 words
+			heading(59, "HEADING_3", "h.c", "Later\n"),   // 59-65
+			{StartIndex: 65, EndIndex: 80, Table: &docspb.Table{TableRows: []*docspb.TableRow{
+				{TableCells: []*docspb.TableCell{tableCell("one two"), tableCell("three")}},
+			}}},
+			heading(80, "HEADING_1", "h.d", "Risks\n"),                                 // 80-86
+			indexedPara(86, &docspb.ParagraphStyle{NamedStyleType: "HEADING_2"}, "\n"), // empty heading
+			indexedPara(87, nil, "None\n"),                                             // 87-92, 1 word
+		}},
+	}
+
+	got := docOutline(doc, 2)
+	want := DocOutlineOutput{
+		DocumentID:    "doc1",
+		Title:         "Plan",
+		TotalWords:    17,
+		PreambleWords: 3,
+		Sections: []OutlineSection{
+			{Level: 1, Style: "HEADING_1", Text: "Goals", HeadingID: "h.a", StartIndex: 18, EndIndex: 80, Words: 9},
+			{Level: 2, Style: "HEADING_2", Text: "Stretch", HeadingID: "h.b", StartIndex: 37, EndIndex: 80, Words: 6},
+			{Level: 1, Style: "HEADING_1", Text: "Risks", HeadingID: "h.d", StartIndex: 80, EndIndex: 92, Words: 1},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("docOutline() =\n%+v\nwant\n%+v", got, want)
+	}
+}
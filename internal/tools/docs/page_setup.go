@@ -0,0 +1,269 @@
+package docs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	docspb "google.golang.org/api/docs/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// pageSizes are portrait page dimensions in points.
+var pageSizes = map[string][2]float64{
+	"LETTER":  {612, 792},
+	"LEGAL":   {612, 1008},
+	"TABLOID": {792, 1224},
+	"A3":      {841.89, 1190.55},
+	"A4":      {595.28, 841.89},
+	"A5":      {419.53, 595.28},
+}
+
+// --- update_doc_page_setup (complete) ---
+
+type UpdateDocPageSetupInput struct {
+	UserEmail          string              `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID         string              `json:"document_id" jsonschema:"required" jsonschema_description:"The document ID"`
+	PageSize           string              `json:"page_size,omitempty" jsonschema_description:"Paper size: LETTER LEGAL TABLOID A3 A4 A5"`
+	Orientation        string              `json:"orientation,omitempty" jsonschema_description:"PORTRAIT or LANDSCAPE; applies to page_size, or to the current size when page_size is omitted"`
+	MarginTop          *float64            `json:"margin_top,omitempty" jsonschema_description:"Top margin in points (72 points = 1 inch)"`
+	MarginBottom       *float64            `json:"margin_bottom,omitempty" jsonschema_description:"Bottom margin in points"`
+	MarginLeft         *float64            `json:"margin_left,omitempty" jsonschema_description:"Left margin in points"`
+	MarginRight        *float64            `json:"margin_right,omitempty" jsonschema_description:"Right margin in points"`
+	MarginHeader       *float64            `json:"margin_header,omitempty" jsonschema_description:"Distance from the top of the page to the header, in points"`
+	MarginFooter       *float64            `json:"margin_footer,omitempty" jsonschema_description:"Distance from the bottom of the page to the footer, in points"`
+	PageNumberStart    *int                `json:"page_number_start,omitempty" jsonschema_description:"Number of the first page, for page number fields already in headers or footers"`
+	DifferentFirstPage *bool               `json:"different_first_page,omitempty" jsonschema_description:"Give the first page its own header and footer, such as none on a cover page"`
+	SectionBreaks      []SectionBreakInput `json:"section_breaks,omitempty" jsonschema_description:"Section breaks to insert in the body"`
+}
+
+// SectionBreakInput is a section break to insert.
+type SectionBreakInput struct {
+	Index int64  `json:"index" jsonschema:"required" jsonschema_description:"Body index to insert the break at; the break starts a new paragraph"`
+	Type  string `json:"type,omitempty" jsonschema_description:"NEXT_PAGE (default) starts the section on a new page; CONTINUOUS starts it on the same page"`
+}
+
+// PageSetupOutput is the page setup after the update.
+type PageSetupOutput struct {
+	DocumentID    string   `json:"document_id"`
+	PageWidth     float64  `json:"page_width"`
+	PageHeight    float64  `json:"page_height"`
+	Orientation   string   `json:"orientation"`
+	MarginTop     float64  `json:"margin_top"`
+	MarginBottom  float64  `json:"margin_bottom"`
+	MarginLeft    float64  `json:"margin_left"`
+	MarginRight   float64  `json:"margin_right"`
+	Fields        []string `json:"fields,omitempty"`
+	SectionBreaks int      `json:"section_breaks"`
+}
+
+func createUpdateDocPageSetupHandler(factory *services.Factory) mcp.ToolHandlerFor[UpdateDocPageSetupInput, PageSetupOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateDocPageSetupInput) (*mcp.CallToolResult, PageSetupOutput, error) {
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, PageSetupOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		doc, err := srv.Documents.Get(input.DocumentID).Context(ctx).Do()
+		if err != nil {
+			return nil, PageSetupOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		requests, style, fields, err := pageSetupRequests(doc, input)
+		if err != nil {
+			return nil, PageSetupOutput{}, err
+		}
+
+		_, err = srv.Documents.BatchUpdate(input.DocumentID, &docspb.BatchUpdateDocumentRequest{Requests: requests}).Context(ctx).Do()
+		if err != nil {
+			return nil, PageSetupOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := PageSetupOutput{
+			DocumentID:    input.DocumentID,
+			PageWidth:     dimensionPoints(style.PageSize.Width),
+			PageHeight:    dimensionPoints(style.PageSize.Height),
+			Orientation:   "PORTRAIT",
+			MarginTop:     dimensionPoints(style.MarginTop),
+			MarginBottom:  dimensionPoints(style.MarginBottom),
+			MarginLeft:    dimensionPoints(style.MarginLeft),
+			MarginRight:   dimensionPoints(style.MarginRight),
+			Fields:        fields,
+			SectionBreaks: len(input.SectionBreaks),
+		}
+		if out.PageWidth > out.PageHeight {
+			out.Orientation = "LANDSCAPE"
+		}
+
+		rb := response.New()
+		rb.Header("Page Setup Updated")
+		rb.KeyValue("Document ID", out.DocumentID)
+		rb.KeyValue("Page", fmt.Sprintf("%gx%g pt (%s)", out.PageWidth, out.PageHeight, strings.ToLower(out.Orientation)))
+		rb.KeyValue("Margins", fmt.Sprintf("top %g, bottom %g, left %g, right %g pt", out.MarginTop, out.MarginBottom, out.MarginLeft, out.MarginRight))
+		if len(fields) > 0 {
+			rb.KeyValue("Fields", strings.Join(fields, ", "))
+		}
+		if out.SectionBreaks > 0 {
+			rb.KeyValue("Section Breaks", out.SectionBreaks)
+		}
+		return rb.TextResult(), out, nil
+	}
+}
+
+// pageSetupRequests validates input against doc and returns the requests
+// that apply it, the document style they result in, and the style fields
+// they update. Section breaks are inserted last to first so each index
+// refers to the document as read.
+func pageSetupRequests(doc *docspb.Document, input UpdateDocPageSetupInput) ([]*docspb.Request, *docspb.DocumentStyle, []string, error) {
+	current := doc.DocumentStyle
+	if current == nil {
+		current = &docspb.DocumentStyle{}
+	}
+	result := *current
+	update := &docspb.DocumentStyle{}
+	var fields []string
+
+	pageSize := strings.ToUpper(input.PageSize)
+	orientation := strings.ToUpper(input.Orientation)
+	if orientation != "" && orientation != "PORTRAIT" && orientation != "LANDSCAPE" {
+		return nil, nil, nil, fmt.Errorf("invalid orientation %q — use PORTRAIT or LANDSCAPE", input.Orientation)
+	}
+	var width, height float64
+	if current.PageSize != nil {
+		width, height = dimensionPoints(current.PageSize.Width), dimensionPoints(current.PageSize.Height)
+	}
+	if pageSize != "" {
+		size, ok := pageSizes[pageSize]
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("invalid page_size %q — use LETTER, LEGAL, TABLOID, A3, A4, or A5", input.PageSize)
+		}
+		width, height = size[0], size[1]
+		if orientation == "" && current.PageSize != nil && dimensionPoints(current.PageSize.Width) > dimensionPoints(current.PageSize.Height) {
+			// A new paper size keeps the document's orientation.
+			orientation = "LANDSCAPE"
+		}
+	}
+	if pageSize != "" || orientation != "" {
+		if width == 0 || height == 0 {
+			return nil, nil, nil, fmt.Errorf("document has no page size to orient — set page_size as well")
+		}
+		if (orientation == "LANDSCAPE" && width < height) || (orientation == "PORTRAIT" && width > height) {
+			width, height = height, width
+		}
+		update.PageSize = &docspb.Size{Width: points(width), Height: points(height)}
+		result.PageSize = update.PageSize
+		fields = append(fields, "pageSize")
+	}
+	if result.PageSize == nil {
+		result.PageSize = &docspb.Size{}
+	}
+
+	margins := []struct {
+		name  string
+		field string
+		value *float64
+		dst   **docspb.Dimension
+		res   **docspb.Dimension
+	}{
+		{"margin_top", "marginTop", input.MarginTop, &update.MarginTop, &result.MarginTop},
+		{"margin_bottom", "marginBottom", input.MarginBottom, &update.MarginBottom, &result.MarginBottom},
+		{"margin_left", "marginLeft", input.MarginLeft, &update.MarginLeft, &result.MarginLeft},
+		{"margin_right", "marginRight", input.MarginRight, &update.MarginRight, &result.MarginRight},
+		{"margin_header", "marginHeader", input.MarginHeader, &update.MarginHeader, &result.MarginHeader},
+		{"margin_footer", "marginFooter", input.MarginFooter, &update.MarginFooter, &result.MarginFooter},
+	}
+	for _, m := range margins {
+		if m.value == nil {
+			continue
+		}
+		if *m.value < 0 {
+			return nil, nil, nil, fmt.Errorf("invalid %s %g — margins cannot be negative", m.name, *m.value)
+		}
+		*m.dst = points(*m.value)
+		*m.res = *m.dst
+		fields = append(fields, m.field)
+	}
+	if input.MarginHeader != nil || input.MarginFooter != nil {
+		// Header and footer margins only apply with custom margins enabled.
+		update.UseCustomHeaderFooterMargins = true
+		fields = append(fields, "useCustomHeaderFooterMargins")
+	}
+	pageWidth, pageHeight := dimensionPoints(result.PageSize.Width), dimensionPoints(result.PageSize.Height)
+	if pageWidth > 0 && dimensionPoints(result.MarginLeft)+dimensionPoints(result.MarginRight) >= pageWidth {
+		return nil, nil, nil, fmt.Errorf("left and right margins leave no room on a %g pt wide page — reduce the margins", pageWidth)
+	}
+	if pageHeight > 0 && dimensionPoints(result.MarginTop)+dimensionPoints(result.MarginBottom) >= pageHeight {
+		return nil, nil, nil, fmt.Errorf("top and bottom margins leave no room on a %g pt high page — reduce the margins", pageHeight)
+	}
+
+	if input.PageNumberStart != nil {
+		if *input.PageNumberStart < 1 {
+			return nil, nil, nil, fmt.Errorf("invalid page_number_start %d — use 1 or more", *input.PageNumberStart)
+		}
+		update.PageNumberStart = int64(*input.PageNumberStart)
+		fields = append(fields, "pageNumberStart")
+	}
+	if input.DifferentFirstPage != nil {
+		update.UseFirstPageHeaderFooter = *input.DifferentFirstPage
+		update.ForceSendFields = append(update.ForceSendFields, "UseFirstPageHeaderFooter")
+		fields = append(fields, "useFirstPageHeaderFooter")
+	}
+
+	var requests []*docspb.Request
+	if len(fields) > 0 {
+		requests = append(requests, &docspb.Request{UpdateDocumentStyle: &docspb.UpdateDocumentStyleRequest{
+			DocumentStyle: update,
+			Fields:        strings.Join(fields, ","),
+		}})
+	}
+
+	var bodyEnd int64
+	if doc.Body != nil && len(doc.Body.Content) > 0 {
+		bodyEnd = doc.Body.Content[len(doc.Body.Content)-1].EndIndex
+	}
+	breaks := make([]SectionBreakInput, len(input.SectionBreaks))
+	for i, b := range input.SectionBreaks {
+		b.Type = strings.ToUpper(b.Type)
+		if b.Type == "" {
+			b.Type = "NEXT_PAGE"
+		}
+		if b.Type != "NEXT_PAGE" && b.Type != "CONTINUOUS" {
+			return nil, nil, nil, fmt.Errorf("invalid section break type %q — use NEXT_PAGE or CONTINUOUS", b.Type)
+		}
+		if b.Index < 1 || b.Index >= bodyEnd {
+			return nil, nil, nil, fmt.Errorf("section break index %d is outside the body (1 to %d) — use inspect_doc_structure to find indexes", b.Index, bodyEnd-1)
+		}
+		breaks[i] = b
+	}
+	sort.SliceStable(breaks, func(i, j int) bool { return breaks[i].Index > breaks[j].Index })
+	for _, b := range breaks {
+		requests = append(requests, &docspb.Request{InsertSectionBreak: &docspb.InsertSectionBreakRequest{
+			Location:    &docspb.Location{Index: b.Index},
+			SectionType: b.Type,
+		}})
+	}
+
+	if len(requests) == 0 {
+		return nil, nil, nil, fmt.Errorf("no page setup changes specified — set page_size, orientation, a margin, page_number_start, different_first_page, or section_breaks")
+	}
+	return requests, &result, fields, nil
+}
+
+// points returns a Dimension of n points.
+func points(n float64) *docspb.Dimension {
+	return &docspb.Dimension{Magnitude: n, Unit: "PT"}
+}
+
+// dimensionPoints returns d in points; Docs reports page dimensions in
+// points, and a missing dimension is 0.
+func dimensionPoints(d *docspb.Dimension) float64 {
+	if d == nil {
+		return 0
+	}
+	return d.Magnitude
+}
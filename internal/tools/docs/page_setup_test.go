@@ -0,0 +1,92 @@
+package docs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	docspb "google.golang.org/api/docs/v1"
+)
+
+func pageSetupDoc() *docspb.Document {
+	return &docspb.Document{
+		DocumentStyle: &docspb.DocumentStyle{
+			PageSize:     &docspb.Size{Width: points(612), Height: points(792)},
+			MarginTop:    points(72),
+			MarginBottom: points(72),
+			MarginLeft:   points(72),
+			MarginRight:  points(72),
+		},
+		Body: &docspb.Body{Content: []*docspb.StructuralElement{
+			{EndIndex: 1, SectionBreak: &docspb.SectionBreak{}},
+			indexedPara(1, nil, "Cover\n"),
+			indexedPara(7, nil, "Report\n"),
+		}},
+	}
+}
+
+func TestPageSetupRequests(t *testing.T) {
+	margin, start, first := 36.0, 2, true
+	requests, style, fields, err := pageSetupRequests(pageSetupDoc(), UpdateDocPageSetupInput{
+		PageSize:           "a4",
+		Orientation:        "landscape",
+		MarginLeft:         &margin,
+		PageNumberStart:    &start,
+		DifferentFirstPage: &first,
+		SectionBreaks:      []SectionBreakInput{{Index: 7}, {Index: 12, Type: "continuous"}},
+	})
+	if err != nil {
+		t.Fatalf("pageSetupRequests() error = %v", err)
+	}
+
+	got, _ := json.Marshal(requests)
+	want := `[{"updateDocumentStyle":{"documentStyle":{"marginLeft":{"magnitude":36,"unit":"PT"},"pageNumberStart":2,"pageSize":{"height":{"magnitude":595.28,"unit":"PT"},"width":{"magnitude":841.89,"unit":"PT"}},"useFirstPageHeaderFooter":true},"fields":"pageSize,marginLeft,pageNumberStart,useFirstPageHeaderFooter"}},` +
+		`{"insertSectionBreak":{"location":{"index":12},"sectionType":"CONTINUOUS"}},` +
+		`{"insertSectionBreak":{"location":{"index":7},"sectionType":"NEXT_PAGE"}}]`
+	if string(got) != want {
+		t.Errorf("requests =\n%s\nwant\n%s", got, want)
+	}
+	if len(fields) != 4 {
+		t.Errorf("fields = %v, want 4", fields)
+	}
+	if w, l := dimensionPoints(style.PageSize.Width), dimensionPoints(style.MarginLeft); w != 841.89 || l != 36 {
+		t.Errorf("resulting width, left margin = %g, %g; want 841.89, 36", w, l)
+	}
+}
+
+func TestPageSetupRequestsKeepsOrientation(t *testing.T) {
+	doc := pageSetupDoc()
+	doc.DocumentStyle.PageSize = &docspb.Size{Width: points(792), Height: points(612)}
+	_, style, _, err := pageSetupRequests(doc, UpdateDocPageSetupInput{PageSize: "LEGAL"})
+	if err != nil {
+		t.Fatalf("pageSetupRequests() error = %v", err)
+	}
+	if w, h := dimensionPoints(style.PageSize.Width), dimensionPoints(style.PageSize.Height); w != 1008 || h != 612 {
+		t.Errorf("page = %gx%g, want 1008x612", w, h)
+	}
+}
+
+func TestPageSetupRequestsErrors(t *testing.T) {
+	big, negative := 400.0, -1.0
+	tests := []struct {
+		name  string
+		input UpdateDocPageSetupInput
+		want  string
+	}{
+		{"nothing", UpdateDocPageSetupInput{}, "no page setup changes"},
+		{"page size", UpdateDocPageSetupInput{PageSize: "B5"}, "invalid page_size"},
+		{"orientation", UpdateDocPageSetupInput{Orientation: "sideways"}, "invalid orientation"},
+		{"negative margin", UpdateDocPageSetupInput{MarginTop: &negative}, "invalid margin_top"},
+		{"margins too wide", UpdateDocPageSetupInput{MarginLeft: &big, MarginRight: &big}, "no room"},
+		{"break type", UpdateDocPageSetupInput{SectionBreaks: []SectionBreakInput{{Index: 3, Type: "EVEN_PAGE"}}}, "invalid section break type"},
+		{"break index", UpdateDocPageSetupInput{SectionBreaks: []SectionBreakInput{{Index: 14}}}, "outside the body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := pageSetupRequests(pageSetupDoc(), tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package docs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	docspb "google.golang.org/api/docs/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// Suggestions are edits made in suggesting mode that are not yet accepted.
+// The Docs API returns them tagged with suggestion IDs but not with who made
+// them or when; accepting and rejecting them is only possible in the editor.
+
+// maxSuggestionPreview bounds the inserted and deleted text shown per
+// suggestion in the text output.
+const maxSuggestionPreview = 120
+
+// suggestionsViewModes are the ways Documents.Get can render suggestions.
+var suggestionsViewModes = map[string]bool{
+	"DEFAULT_FOR_CURRENT_ACCESS":   true,
+	"SUGGESTIONS_INLINE":           true,
+	"PREVIEW_SUGGESTIONS_ACCEPTED": true,
+	"PREVIEW_WITHOUT_SUGGESTIONS":  true,
+}
+
+// parseSuggestionsViewMode validates a suggestions_view_mode input, returning
+// "" when the API default applies.
+func parseSuggestionsViewMode(mode string) (string, error) {
+	mode = strings.ToUpper(strings.TrimSpace(mode))
+	if mode == "" || suggestionsViewModes[mode] {
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid suggestions_view_mode %q — use DEFAULT_FOR_CURRENT_ACCESS, SUGGESTIONS_INLINE, PREVIEW_SUGGESTIONS_ACCEPTED, or PREVIEW_WITHOUT_SUGGESTIONS", mode)
+}
+
+// getDoc fetches a document, rendering suggestions as mode asks.
+func getDoc(ctx context.Context, srv *docspb.Service, documentID, mode string) (*docspb.Document, error) {
+	call := srv.Documents.Get(documentID).Context(ctx)
+	if mode != "" {
+		call = call.SuggestionsViewMode(mode)
+	}
+	return call.Do()
+}
+
+// --- list_doc_suggestions (extended) ---
+
+type ListDocSuggestionsInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DocumentID string `json:"document_id" jsonschema:"required" jsonschema_description:"The document ID"`
+}
+
+// DocSuggestion is one pending suggestion. Type is insertion, deletion,
+// replacement (both), or formatting (text or paragraph style only).
+type DocSuggestion struct {
+	ID             string `json:"id"`
+	Type           string `json:"type"`
+	Segment        string `json:"segment"` // body, or the header, footer, or footnote ID
+	StartIndex     int64  `json:"start_index"`
+	EndIndex       int64  `json:"end_index"`
+	Inserted       string `json:"inserted,omitempty"`
+	Deleted        string `json:"deleted,omitempty"`
+	TextStyle      bool   `json:"text_style,omitempty"`
+	ParagraphStyle bool   `json:"paragraph_style,omitempty"`
+}
+
+type ListDocSuggestionsOutput struct {
+	DocumentID  string          `json:"document_id"`
+	Title       string          `json:"title"`
+	Insertions  int             `json:"insertions"`
+	Deletions   int             `json:"deletions"`
+	Suggestions []DocSuggestion `json:"suggestions"`
+}
+
+func createListDocSuggestionsHandler(factory *services.Factory) mcp.ToolHandlerFor[ListDocSuggestionsInput, ListDocSuggestionsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListDocSuggestionsInput) (*mcp.CallToolResult, ListDocSuggestionsOutput, error) {
+		srv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListDocSuggestionsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		// Only the inline view carries suggestion IDs on the content.
+		doc, err := getDoc(ctx, srv, input.DocumentID, "SUGGESTIONS_INLINE")
+		if err != nil {
+			return nil, ListDocSuggestionsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := ListDocSuggestionsOutput{DocumentID: doc.DocumentId, Title: doc.Title, Suggestions: docSuggestions(doc)}
+		for _, s := range out.Suggestions {
+			if s.Inserted != "" {
+				out.Insertions++
+			}
+			if s.Deleted != "" {
+				out.Deletions++
+			}
+		}
+
+		rb := response.New()
+		rb.Header("Document Suggestions")
+		rb.KeyValue("Title", out.Title)
+		rb.KeyValue("Document ID", out.DocumentID)
+		rb.KeyValue("Pending", len(out.Suggestions))
+		if len(out.Suggestions) == 0 {
+			rb.Line("No pending suggestions.")
+			return rb.TextResult(), out, nil
+		}
+		rb.Blank()
+		for _, s := range out.Suggestions {
+			rb.Item("%s %s in %s [%d-%d]", s.ID, s.Type, s.Segment, s.StartIndex, s.EndIndex)
+			if s.Deleted != "" {
+				rb.Line("    - %q", truncateRunes(s.Deleted, maxSuggestionPreview))
+			}
+			if s.Inserted != "" {
+				rb.Line("    + %q", truncateRunes(s.Inserted, maxSuggestionPreview))
+			}
+		}
+		rb.Line("Suggestion authors are not available through the Docs API; accept or reject suggestions in the editor.")
+		return rb.TextResult(), out, nil
+	}
+}
+
+// docSuggestions collects the suggestions in a document fetched with
+// SUGGESTIONS_INLINE, in order of first appearance: the body, then headers,
+// footers, and footnotes sorted by ID. A suggestion spanning several runs or
+// paragraphs is reported once, covering all of them.
+func docSuggestions(doc *docspb.Document) []DocSuggestion {
+	var out []DocSuggestion
+	byID := make(map[string]int)
+	get := func(id, segment string, start, end int64) *DocSuggestion {
+		i, ok := byID[id]
+		if !ok {
+			i = len(out)
+			byID[id] = i
+			out = append(out, DocSuggestion{ID: id, Segment: segment, StartIndex: start, EndIndex: end})
+		}
+		s := &out[i]
+		s.StartIndex = min(s.StartIndex, start)
+		s.EndIndex = max(s.EndIndex, end)
+		return s
+	}
+
+	var walk func(segment string, content []*docspb.StructuralElement)
+	walk = func(segment string, content []*docspb.StructuralElement) {
+		for _, elem := range content {
+			switch {
+			case elem.Paragraph != nil:
+				p := elem.Paragraph
+				for _, id := range sortedKeys(p.SuggestedParagraphStyleChanges) {
+					get(id, segment, elem.StartIndex, elem.EndIndex).ParagraphStyle = true
+				}
+				for _, pe := range p.Elements {
+					run := pe.TextRun
+					if run == nil {
+						continue
+					}
+					for _, id := range run.SuggestedInsertionIds {
+						s := get(id, segment, pe.StartIndex, pe.EndIndex)
+						s.Inserted += run.Content
+					}
+					for _, id := range run.SuggestedDeletionIds {
+						s := get(id, segment, pe.StartIndex, pe.EndIndex)
+						s.Deleted += run.Content
+					}
+					for _, id := range sortedKeys(run.SuggestedTextStyleChanges) {
+						get(id, segment, pe.StartIndex, pe.EndIndex).TextStyle = true
+					}
+				}
+			case elem.Table != nil:
+				for _, row := range elem.Table.TableRows {
+					for _, cell := range row.TableCells {
+						walk(segment, cell.Content)
+					}
+				}
+			}
+		}
+	}
+
+	if doc.Body != nil {
+		walk("body", doc.Body.Content)
+	}
+	for _, id := range sortedKeys(doc.Headers) {
+		walk(id, doc.Headers[id].Content)
+	}
+	for _, id := range sortedKeys(doc.Footers) {
+		walk(id, doc.Footers[id].Content)
+	}
+	for _, id := range sortedKeys(doc.Footnotes) {
+		walk(id, doc.Footnotes[id].Content)
+	}
+
+	for i := range out {
+		s := &out[i]
+		switch {
+		case s.Inserted != "" && s.Deleted != "":
+			s.Type = "replacement"
+		case s.Inserted != "":
+			s.Type = "insertion"
+		case s.Deleted != "":
+			s.Type = "deletion"
+		default:
+			s.Type = "formatting"
+		}
+	}
+	if out == nil {
+		out = []DocSuggestion{}
+	}
+	return out
+}
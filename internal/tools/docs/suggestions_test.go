@@ -0,0 +1,67 @@
+package docs
+
+import (
+	"reflect"
+	"testing"
+
+	docspb "google.golang.org/api/docs/v1"
+)
+
+func TestParseSuggestionsViewMode(t *testing.T) {
+	for in, want := range map[string]string{
+		"":                              "",
+		"suggestions_inline":            "SUGGESTIONS_INLINE",
+		" PREVIEW_WITHOUT_SUGGESTIONS ": "PREVIEW_WITHOUT_SUGGESTIONS",
+	} {
+		got, err := parseSuggestionsViewMode(in)
+		if err != nil || got != want {
+			t.Errorf("parseSuggestionsViewMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := parseSuggestionsViewMode("accepted"); err == nil {
+		t.Error("parseSuggestionsViewMode(\"accepted\") succeeded, want error")
+	}
+}
+
+func TestDocSuggestions(t *testing.T) {
+	para := indexedPara(1, nil, "Ship ", "soon", "today", " and ", "loudly", "\n")
+	runs := para.Paragraph.Elements
+	runs[1].TextRun.SuggestedDeletionIds = []string{"suggest.a"}
+	runs[2].TextRun.SuggestedInsertionIds = []string{"suggest.a"}
+	runs[4].TextRun.SuggestedTextStyleChanges = map[string]docspb.SuggestedTextStyle{"suggest.b": {}}
+
+	cell := indexedPara(30, nil, "new row\n")
+	cell.Paragraph.Elements[0].TextRun.SuggestedInsertionIds = []string{"suggest.c"}
+
+	heading := indexedPara(40, &docspb.ParagraphStyle{NamedStyleType: "HEADING_1"}, "Risks\n")
+	heading.Paragraph.SuggestedParagraphStyleChanges = map[string]docspb.SuggestedParagraphStyle{"suggest.d": {}}
+
+	footer := indexedPara(0, nil, "Draft\n")
+	footer.Paragraph.Elements[0].TextRun.SuggestedDeletionIds = []string{"suggest.e"}
+
+	doc := &docspb.Document{
+		Body: &docspb.Body{Content: []*docspb.StructuralElement{
+			{EndIndex: 1, SectionBreak: &docspb.SectionBreak{}},
+			para,
+			{StartIndex: 27, EndIndex: 39, Table: &docspb.Table{TableRows: []*docspb.TableRow{{TableCells: []*docspb.TableCell{
+				{Content: []*docspb.StructuralElement{cell}},
+			}}}}},
+			heading,
+		}},
+		Footers: map[string]docspb.Footer{"kix.f1": {Content: []*docspb.StructuralElement{footer}}},
+	}
+
+	want := []DocSuggestion{
+		{ID: "suggest.a", Type: "replacement", Segment: "body", StartIndex: 6, EndIndex: 15, Inserted: "today", Deleted: "soon"},
+		{ID: "suggest.b", Type: "formatting", Segment: "body", StartIndex: 20, EndIndex: 26, TextStyle: true},
+		{ID: "suggest.c", Type: "insertion", Segment: "body", StartIndex: 30, EndIndex: 38, Inserted: "new row\n"},
+		{ID: "suggest.d", Type: "formatting", Segment: "body", StartIndex: 40, EndIndex: 46, ParagraphStyle: true},
+		{ID: "suggest.e", Type: "deletion", Segment: "kix.f1", StartIndex: 0, EndIndex: 6, Deleted: "Draft\n"},
+	}
+	if got := docSuggestions(doc); !reflect.DeepEqual(got, want) {
+		t.Errorf("docSuggestions() =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := docSuggestions(&docspb.Document{}); got == nil || len(got) != 0 {
+		t.Errorf("docSuggestions(empty) = %#v, want empty slice", got)
+	}
+}
@@ -0,0 +1,266 @@
+package docs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	docspb "google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/rollback"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/validate"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// googleDocMimeType is the Drive MIME type of a Google Doc.
+const googleDocMimeType = "application/vnd.google-apps.document"
+
+// placeholderPattern finds {{placeholders}} left in a filled document.
+var placeholderPattern = regexp.MustCompile(`\{\{[^{}\n]+\}\}`)
+
+// --- create_doc_from_template (extended) ---
+
+type CreateDocFromTemplateInput struct {
+	UserEmail         string            `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	TemplateID        string            `json:"template_id" jsonschema:"required" jsonschema_description:"ID of the Google Doc to copy"`
+	Title             string            `json:"title" jsonschema:"required" jsonschema_description:"Title for the new document"`
+	FolderID          string            `json:"folder_id,omitempty" jsonschema_description:"Folder for the new document (default: the template's folder)"`
+	Replacements      map[string]string `json:"replacements,omitempty" jsonschema_description:"Placeholder to text, such as {\"client\": \"Acme\"}; keys without braces match {{client}}. Applies to the body, headers, and footers"`
+	ImageReplacements map[string]string `json:"image_replacements,omitempty" jsonschema_description:"Placeholder to public image URL; each occurrence in the body is replaced by the image"`
+}
+
+// PlaceholderResult is how often one placeholder was replaced.
+type PlaceholderResult struct {
+	Placeholder string `json:"placeholder"`
+	Occurrences int64  `json:"occurrences"`
+}
+
+type CreateDocFromTemplateOutput struct {
+	DocumentID   string              `json:"document_id"`
+	Title        string              `json:"title"`
+	Link         string              `json:"link"`
+	Replacements []PlaceholderResult `json:"replacements"`
+	Unfilled     []string            `json:"unfilled,omitempty"`
+}
+
+func createCreateDocFromTemplateHandler(factory *services.Factory) mcp.ToolHandlerFor[CreateDocFromTemplateInput, CreateDocFromTemplateOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateDocFromTemplateInput) (*mcp.CallToolResult, CreateDocFromTemplateOutput, error) {
+		if len(input.Replacements)+len(input.ImageReplacements) == 0 {
+			return nil, CreateDocFromTemplateOutput{}, fmt.Errorf("no replacements given — use copy_drive_file to copy a document unchanged")
+		}
+		texts, images, err := normalizePlaceholders(input.Replacements, input.ImageReplacements)
+		if err != nil {
+			return nil, CreateDocFromTemplateOutput{}, err
+		}
+		if input.FolderID != "" {
+			if err := validate.DriveID(input.FolderID); err != nil {
+				return nil, CreateDocFromTemplateOutput{}, err
+			}
+		}
+
+		driveSrv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, CreateDocFromTemplateOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		docsSrv, err := factory.Docs(ctx, input.UserEmail)
+		if err != nil {
+			return nil, CreateDocFromTemplateOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		template, err := driveSrv.Files.Get(input.TemplateID).
+			Fields("id, name, mimeType").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, CreateDocFromTemplateOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		if template.MimeType != googleDocMimeType {
+			return nil, CreateDocFromTemplateOutput{}, fmt.Errorf("template %q is %s, not a Google Doc — convert it with import_to_google_doc first", template.Name, template.MimeType)
+		}
+
+		copied := &drive.File{Name: input.Title}
+		if input.FolderID != "" {
+			copied.Parents = []string{input.FolderID}
+		}
+		if stamp := factory.Provenance(req); stamp != nil {
+			copied.AppProperties = stamp.Properties()
+		}
+		var tx rollback.Tx
+		created, err := driveSrv.Files.Copy(template.Id, copied).
+			Fields("id, name, webViewLink").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, CreateDocFromTemplateOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		tx.Record("document "+created.Id, rollback.TrashDriveFile(driveSrv, created.Id))
+
+		doc, err := docsSrv.Documents.Get(created.Id).Context(ctx).Do()
+		if err != nil {
+			return nil, CreateDocFromTemplateOutput{}, tx.Fail(ctx, middleware.HandleGoogleAPIError(err))
+		}
+		plan := planTemplate(doc, texts, images)
+		resp := &docspb.BatchUpdateDocumentResponse{}
+		if len(plan.requests) > 0 {
+			resp, err = docsSrv.Documents.BatchUpdate(created.Id, &docspb.BatchUpdateDocumentRequest{Requests: plan.requests}).Context(ctx).Do()
+			if err != nil {
+				// A half-filled copy is worse than none.
+				return nil, CreateDocFromTemplateOutput{}, tx.Fail(ctx, middleware.HandleGoogleAPIError(err))
+			}
+		}
+		tx.Commit()
+
+		out := CreateDocFromTemplateOutput{
+			DocumentID: created.Id,
+			Title:      created.Name,
+			Link:       created.WebViewLink,
+			Unfilled:   plan.unfilled,
+		}
+		// Replies line up with requests; the ReplaceAllText requests come last.
+		replies := resp.Replies[max(len(resp.Replies)-len(plan.textKeys), 0):]
+		for i, key := range plan.textKeys {
+			var n int64
+			if i < len(replies) && replies[i] != nil && replies[i].ReplaceAllText != nil {
+				n = replies[i].ReplaceAllText.OccurrencesChanged
+			}
+			out.Replacements = append(out.Replacements, PlaceholderResult{Placeholder: key, Occurrences: n})
+		}
+		for _, key := range sortedKeys(images) {
+			out.Replacements = append(out.Replacements, PlaceholderResult{Placeholder: key, Occurrences: int64(plan.imageCounts[key])})
+		}
+		sort.Slice(out.Replacements, func(i, j int) bool { return out.Replacements[i].Placeholder < out.Replacements[j].Placeholder })
+
+		rb := response.New()
+		rb.Header("Document Created from Template")
+		rb.KeyValue("Title", out.Title)
+		rb.KeyValue("Document ID", out.DocumentID)
+		if out.Link != "" {
+			rb.KeyValue("Link", out.Link)
+		}
+		rb.Section("Replacements")
+		for _, r := range out.Replacements {
+			if r.Occurrences == 0 {
+				rb.Item("%s: not found in template", r.Placeholder)
+				continue
+			}
+			rb.Item("%s: %d", r.Placeholder, r.Occurrences)
+		}
+		if len(out.Unfilled) > 0 {
+			rb.Line("Placeholders still in the document: %s", strings.Join(out.Unfilled, ", "))
+		}
+		return rb.TextResult(), out, nil
+	}
+}
+
+// normalizePlaceholders wraps bare keys in {{ }} and rejects empty keys,
+// keys that collide once wrapped, and image URLs Docs cannot fetch.
+func normalizePlaceholders(texts, images map[string]string) (map[string]string, map[string]string, error) {
+	seen := make(map[string]string)
+	normalize := func(m map[string]string, isImage bool) (map[string]string, error) {
+		out := make(map[string]string, len(m))
+		for key, value := range m {
+			k := strings.TrimSpace(key)
+			if k == "" || k == "{{}}" {
+				return nil, fmt.Errorf("empty placeholder — use keys such as client or {{client}}")
+			}
+			if !strings.HasPrefix(k, "{{") {
+				k = "{{" + k + "}}"
+			}
+			if prev, ok := seen[k]; ok {
+				return nil, fmt.Errorf("placeholders %q and %q both match %s — give each placeholder once", prev, key, k)
+			}
+			seen[k] = key
+			if isImage && !strings.HasPrefix(value, "https://") && !strings.HasPrefix(value, "http://") {
+				return nil, fmt.Errorf("invalid image URL %q for %s — use a public http(s) URL", value, k)
+			}
+			out[k] = value
+		}
+		return out, nil
+	}
+	t, err := normalize(texts, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	i, err := normalize(images, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, i, nil
+}
+
+// templatePlan is the single batch update that fills a template copy.
+type templatePlan struct {
+	requests    []*docspb.Request
+	textKeys    []string       // placeholders of the trailing ReplaceAllText requests, in order
+	imageCounts map[string]int // body occurrences of each image placeholder
+	unfilled    []string       // placeholders in the document that no replacement covers
+}
+
+// planTemplate builds the requests that fill doc. Image placeholders are
+// replaced last to first so earlier indexes stay valid, and before the
+// index-free text replacements that would shift them.
+func planTemplate(doc *docspb.Document, texts, images map[string]string) templatePlan {
+	plan := templatePlan{imageCounts: make(map[string]int)}
+
+	type imageAt struct {
+		rng *docspb.Range
+		uri string
+	}
+	var found []imageAt
+	for key, uri := range images {
+		ranges := findAllText(doc, key)
+		plan.imageCounts[key] = len(ranges)
+		for _, rng := range ranges {
+			found = append(found, imageAt{rng: rng, uri: uri})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].rng.StartIndex > found[j].rng.StartIndex })
+	for _, img := range found {
+		plan.requests = append(plan.requests,
+			&docspb.Request{DeleteContentRange: &docspb.DeleteContentRangeRequest{Range: img.rng}},
+			&docspb.Request{InsertInlineImage: &docspb.InsertInlineImageRequest{
+				Uri:      img.uri,
+				Location: &docspb.Location{Index: img.rng.StartIndex},
+			}},
+		)
+	}
+
+	plan.textKeys = sortedKeys(texts)
+	for _, key := range plan.textKeys {
+		plan.requests = append(plan.requests, &docspb.Request{ReplaceAllText: &docspb.ReplaceAllTextRequest{
+			ContainsText: &docspb.SubstringMatchCriteria{Text: key, MatchCase: true},
+			ReplaceText:  texts[key],
+			// An empty value deletes the placeholder, so it must be sent.
+			ForceSendFields: []string{"ReplaceText"},
+		}})
+	}
+
+	for _, p := range placeholderPattern.FindAllString(extractDocText(doc), -1) {
+		if _, ok := texts[p]; ok {
+			continue
+		}
+		if _, ok := images[p]; ok || slices.Contains(plan.unfilled, p) {
+			continue
+		}
+		plan.unfilled = append(plan.unfilled, p)
+	}
+	return plan
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,81 @@
+package docs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	docspb "google.golang.org/api/docs/v1"
+)
+
+func TestNormalizePlaceholders(t *testing.T) {
+	texts, images, err := normalizePlaceholders(
+		map[string]string{"client": "Acme", " {{date}} ": "May 1"},
+		map[string]string{"logo": "https://example.com/logo.png"},
+	)
+	if err != nil {
+		t.Fatalf("normalizePlaceholders() error = %v", err)
+	}
+	if want := map[string]string{"{{client}}": "Acme", "{{date}}": "May 1"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("texts = %v, want %v", texts, want)
+	}
+	if want := map[string]string{"{{logo}}": "https://example.com/logo.png"}; !reflect.DeepEqual(images, want) {
+		t.Errorf("images = %v, want %v", images, want)
+	}
+
+	tests := []struct {
+		texts, images map[string]string
+		want          string
+	}{
+		{map[string]string{"": "x"}, nil, "empty placeholder"},
+		{map[string]string{"client": "a", "{{client}}": "b"}, nil, "both match {{client}}"},
+		{map[string]string{"logo": "a"}, map[string]string{"logo": "https://x"}, "both match {{logo}}"},
+		{nil, map[string]string{"logo": "file:///etc/passwd"}, "invalid image URL"},
+	}
+	for _, tt := range tests {
+		_, _, err := normalizePlaceholders(tt.texts, tt.images)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("normalizePlaceholders(%v, %v) error = %v, want %q", tt.texts, tt.images, err, tt.want)
+		}
+	}
+}
+
+func TestPlanTemplate(t *testing.T) {
+	doc := &docspb.Document{Body: &docspb.Body{Content: []*docspb.StructuralElement{
+		{EndIndex: 1, SectionBreak: &docspb.SectionBreak{}},
+		indexedPara(1, nil, "{{logo}} Dear {{client}},\n"),
+		indexedPara(27, nil, "Signed {{signer}} {{logo}}\n"),
+	}}}
+
+	plan := planTemplate(doc,
+		map[string]string{"{{client}}": "Acme", "{{date}}": ""},
+		map[string]string{"{{logo}}": "https://example.com/logo.png"},
+	)
+
+	image := func(start, end int64) []*docspb.Request {
+		return []*docspb.Request{
+			{DeleteContentRange: &docspb.DeleteContentRangeRequest{Range: &docspb.Range{StartIndex: start, EndIndex: end}}},
+			{InsertInlineImage: &docspb.InsertInlineImageRequest{Uri: "https://example.com/logo.png", Location: &docspb.Location{Index: start}}},
+		}
+	}
+	replace := func(key, value string) *docspb.Request {
+		return &docspb.Request{ReplaceAllText: &docspb.ReplaceAllTextRequest{
+			ContainsText:    &docspb.SubstringMatchCriteria{Text: key, MatchCase: true},
+			ReplaceText:     value,
+			ForceSendFields: []string{"ReplaceText"},
+		}}
+	}
+	want := append(append(image(45, 53), image(1, 9)...), replace("{{client}}", "Acme"), replace("{{date}}", ""))
+	if !reflect.DeepEqual(plan.requests, want) {
+		t.Errorf("requests =\n%+v\nwant\n%+v", plan.requests, want)
+	}
+	if want := []string{"{{client}}", "{{date}}"}; !reflect.DeepEqual(plan.textKeys, want) {
+		t.Errorf("textKeys = %v, want %v", plan.textKeys, want)
+	}
+	if plan.imageCounts["{{logo}}"] != 2 {
+		t.Errorf("imageCounts = %v, want {{logo}}: 2", plan.imageCounts)
+	}
+	if want := []string{"{{signer}}"}; !reflect.DeepEqual(plan.unfilled, want) {
+		t.Errorf("unfilled = %v, want %v", plan.unfilled, want)
+	}
+}
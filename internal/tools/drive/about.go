@@ -0,0 +1,103 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// quotaWarnPercent is the share of the storage limit above which
+// get_drive_storage_info warns that uploads may fail.
+const quotaWarnPercent = 90
+
+// --- get_drive_storage_info (extended) ---
+
+type StorageInfoInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+}
+
+// StorageInfoOutput is the user's storage quota in bytes. The quota is shared
+// by Drive, Gmail, and Google Photos; UsageOtherServices is what Gmail and
+// Photos use. Limit and Available are 0 when storage is unlimited.
+type StorageInfoOutput struct {
+	DisplayName        string  `json:"display_name,omitempty"`
+	EmailAddress       string  `json:"email_address,omitempty"`
+	PhotoLink          string  `json:"photo_link,omitempty"`
+	Unlimited          bool    `json:"unlimited"`
+	Limit              int64   `json:"limit,omitempty"`
+	Usage              int64   `json:"usage"`
+	UsageInDrive       int64   `json:"usage_in_drive"`
+	UsageInDriveTrash  int64   `json:"usage_in_drive_trash"`
+	UsageOtherServices int64   `json:"usage_other_services"`
+	Available          int64   `json:"available,omitempty"`
+	PercentUsed        float64 `json:"percent_used,omitempty"`
+	MaxUploadSize      int64   `json:"max_upload_size,omitempty"`
+}
+
+func createStorageInfoHandler(factory *services.Factory) mcp.ToolHandlerFor[StorageInfoInput, StorageInfoOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input StorageInfoInput) (*mcp.CallToolResult, StorageInfoOutput, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, StorageInfoOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		about, err := srv.About.Get().
+			Fields("user(displayName, emailAddress, photoLink), storageQuota, maxUploadSize").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, StorageInfoOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := StorageInfoOutput{MaxUploadSize: about.MaxUploadSize}
+		if about.User != nil {
+			out.DisplayName = about.User.DisplayName
+			out.EmailAddress = about.User.EmailAddress
+			out.PhotoLink = about.User.PhotoLink
+		}
+		if q := about.StorageQuota; q != nil {
+			out.Limit = q.Limit
+			out.Usage = q.Usage
+			out.UsageInDrive = q.UsageInDrive
+			out.UsageInDriveTrash = q.UsageInDriveTrash
+			out.UsageOtherServices = max(q.Usage-q.UsageInDrive, 0)
+		}
+		out.Unlimited = out.Limit == 0
+		if !out.Unlimited {
+			out.Available = max(out.Limit-out.Usage, 0)
+			out.PercentUsed = float64(out.Usage) * 100 / float64(out.Limit)
+		}
+
+		rb := response.New()
+		rb.Header("Drive Storage")
+		if out.DisplayName != "" {
+			rb.KeyValue("User", out.DisplayName)
+		}
+		if out.EmailAddress != "" {
+			rb.KeyValue("Email", out.EmailAddress)
+		}
+		if out.Unlimited {
+			rb.KeyValue("Used", formatSize(out.Usage)+" (unlimited storage)")
+		} else {
+			rb.KeyValue("Used", fmt.Sprintf("%s of %s (%.1f%%)", formatSize(out.Usage), formatSize(out.Limit), out.PercentUsed))
+			rb.KeyValue("Available", formatSize(out.Available))
+		}
+		rb.KeyValue("Drive", formatSize(out.UsageInDrive))
+		rb.KeyValue("Drive trash", formatSize(out.UsageInDriveTrash))
+		rb.KeyValue("Gmail and Photos", formatSize(out.UsageOtherServices))
+		if out.MaxUploadSize > 0 {
+			rb.KeyValue("Max upload size", formatSize(out.MaxUploadSize))
+		}
+		if !out.Unlimited && out.PercentUsed >= quotaWarnPercent {
+			rb.Blank()
+			rb.Line("Storage is almost full: uploads larger than %s will fail. Emptying the Drive trash frees %s.", formatSize(out.Available), formatSize(out.UsageInDriveTrash))
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
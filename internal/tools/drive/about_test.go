@@ -0,0 +1,28 @@
+package drive
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestStorageInfoSandbox(t *testing.T) {
+	result, out, err := createStorageInfoHandler(sandboxFactory())(context.Background(), &mcp.CallToolRequest{}, StorageInfoInput{
+		UserEmail: "user@example.com",
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.Unlimited || out.Limit != 16106127360 || out.Usage != 2147483648 {
+		t.Errorf("quota = %+v", out)
+	}
+	if out.UsageOtherServices != out.Usage-out.UsageInDrive || out.Available != out.Limit-out.Usage {
+		t.Errorf("derived values = %+v", out)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if strings.Contains(text, "almost full") {
+		t.Errorf("unexpected quota warning:\n%s", text)
+	}
+}
@@ -0,0 +1,283 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/driveactivity/v2"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// activityActions maps the action names accepted by get_drive_file_activity
+// to Drive Activity action detail cases.
+var activityActions = map[string]string{
+	"create":            "CREATE",
+	"edit":              "EDIT",
+	"move":              "MOVE",
+	"rename":            "RENAME",
+	"delete":            "DELETE",
+	"restore":           "RESTORE",
+	"permission_change": "PERMISSION_CHANGE",
+	"comment":           "COMMENT",
+}
+
+// ActivityEntry is one consolidated Drive activity on a file.
+type ActivityEntry struct {
+	Time   string   `json:"time"`
+	Action string   `json:"action"`
+	Detail string   `json:"detail,omitempty"`
+	Actors []string `json:"actors"`
+}
+
+// activityFilter builds a Drive Activity filter for an optional time range
+// (RFC3339) and action names.
+func activityFilter(startTime, endTime string, actions []string) (string, error) {
+	var terms []string
+	for _, bound := range []struct{ name, value, op string }{
+		{"start_time", startTime, ">="},
+		{"end_time", endTime, "<"},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s %q — expected RFC3339 (e.g. 2025-06-02T00:00:00Z): %w", bound.name, bound.value, err)
+		}
+		terms = append(terms, fmt.Sprintf("time %s %d", bound.op, t.UnixMilli()))
+	}
+	if len(actions) > 0 {
+		cases := make([]string, 0, len(actions))
+		for _, a := range actions {
+			c, ok := activityActions[strings.ToLower(strings.TrimSpace(a))]
+			if !ok {
+				return "", fmt.Errorf("invalid action %q — use create, edit, move, rename, delete, restore, permission_change, or comment", a)
+			}
+			cases = append(cases, c)
+		}
+		terms = append(terms, "detail.action_detail_case:("+strings.Join(cases, " ")+")")
+	}
+	return strings.Join(terms, " AND "), nil
+}
+
+// describeAction returns the action name and a short description of d.
+func describeAction(d *driveactivity.ActionDetail, people map[string]string) (string, string) {
+	switch {
+	case d == nil:
+		return "unknown", ""
+	case d.Create != nil:
+		switch {
+		case d.Create.Upload != nil:
+			return "create", "uploaded"
+		case d.Create.Copy != nil:
+			return "create", "copied"
+		}
+		return "create", ""
+	case d.Edit != nil:
+		return "edit", ""
+	case d.Move != nil:
+		return "move", ""
+	case d.Rename != nil:
+		return "rename", fmt.Sprintf("%q → %q", d.Rename.OldTitle, d.Rename.NewTitle)
+	case d.Delete != nil:
+		return "delete", strings.ToLower(d.Delete.Type)
+	case d.Restore != nil:
+		return "restore", ""
+	case d.PermissionChange != nil:
+		var parts []string
+		for _, p := range d.PermissionChange.AddedPermissions {
+			parts = append(parts, "added "+describePermission(p, people))
+		}
+		for _, p := range d.PermissionChange.RemovedPermissions {
+			parts = append(parts, "removed "+describePermission(p, people))
+		}
+		return "permission_change", strings.Join(parts, "; ")
+	case d.Comment != nil:
+		switch c := d.Comment; {
+		case c.Post != nil:
+			return "comment", strings.ToLower(c.Post.Subtype)
+		case c.Assignment != nil:
+			return "comment", "assignment " + strings.ToLower(c.Assignment.Subtype)
+		case c.Suggestion != nil:
+			return "comment", "suggestion " + strings.ToLower(c.Suggestion.Subtype)
+		}
+		return "comment", ""
+	case d.SettingsChange != nil:
+		return "settings_change", ""
+	case d.DlpChange != nil:
+		return "dlp_change", strings.ToLower(d.DlpChange.Type)
+	case d.Reference != nil:
+		return "reference", strings.ToLower(d.Reference.Type)
+	case d.AppliedLabelChange != nil:
+		return "label_change", ""
+	}
+	return "unknown", ""
+}
+
+// describePermission renders a permission as "role for grantee".
+func describePermission(p *driveactivity.Permission, people map[string]string) string {
+	grantee := "unknown"
+	switch {
+	case p.User != nil:
+		grantee = describeUser(p.User, people)
+	case p.Group != nil:
+		grantee = "group " + p.Group.Email
+	case p.Domain != nil:
+		grantee = "domain " + p.Domain.Name
+	case p.Anyone != nil:
+		grantee = "anyone with the link"
+	}
+	return strings.ToLower(p.Role) + " for " + grantee
+}
+
+// describeActor renders who performed an activity.
+func describeActor(a *driveactivity.Actor, people map[string]string) string {
+	switch {
+	case a.User != nil:
+		return describeUser(a.User, people)
+	case a.Anonymous != nil:
+		return "anonymous user"
+	case a.Administrator != nil:
+		return "administrator"
+	case a.System != nil:
+		return "system"
+	case a.Impersonation != nil:
+		return "impersonated user"
+	}
+	return "unknown"
+}
+
+// describeUser renders a user, resolving people/ IDs through people when known.
+func describeUser(u *driveactivity.User, people map[string]string) string {
+	switch {
+	case u.KnownUser != nil:
+		if u.KnownUser.IsCurrentUser {
+			return "you"
+		}
+		if name, ok := people[u.KnownUser.PersonName]; ok {
+			return name
+		}
+		return u.KnownUser.PersonName
+	case u.DeletedUser != nil:
+		return "deleted user"
+	}
+	return "unknown user"
+}
+
+// --- get_drive_file_activity (extended) ---
+
+type GetFileActivityInput struct {
+	UserEmail string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID    string   `json:"file_id" jsonschema:"required" jsonschema_description:"The Drive file or folder ID"`
+	StartTime string   `json:"start_time,omitempty" jsonschema_description:"Only activity at or after this time (RFC3339)"`
+	EndTime   string   `json:"end_time,omitempty" jsonschema_description:"Only activity before this time (RFC3339)"`
+	Actions   []string `json:"actions,omitempty" jsonschema_description:"Only these actions: create edit move rename delete restore permission_change comment (default: all)"`
+	PageSize  int      `json:"page_size,omitempty" jsonschema_description:"Maximum activities to return (default 25)"`
+	PageToken string   `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type GetFileActivityOutput struct {
+	FileID        string          `json:"file_id"`
+	Activities    []ActivityEntry `json:"activities"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+}
+
+func createGetFileActivityHandler(factory *services.Factory) mcp.ToolHandlerFor[GetFileActivityInput, GetFileActivityOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetFileActivityInput) (*mcp.CallToolResult, GetFileActivityOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 25
+		}
+		filter, err := activityFilter(input.StartTime, input.EndTime, input.Actions)
+		if err != nil {
+			return nil, GetFileActivityOutput{}, err
+		}
+
+		srv, err := factory.DriveActivity(ctx, input.UserEmail)
+		if err != nil {
+			return nil, GetFileActivityOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		result, err := srv.Activity.Query(&driveactivity.QueryDriveActivityRequest{
+			ItemName:  "items/" + input.FileID,
+			Filter:    filter,
+			PageSize:  int64(input.PageSize),
+			PageToken: input.PageToken,
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, GetFileActivityOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		people := filePeople(ctx, factory, input.UserEmail, input.FileID)
+
+		out := GetFileActivityOutput{
+			FileID:        input.FileID,
+			Activities:    make([]ActivityEntry, 0, len(result.Activities)),
+			NextPageToken: result.NextPageToken,
+		}
+		rb := response.New()
+		rb.Header("Drive File Activity")
+		rb.KeyValue("File ID", input.FileID)
+		rb.KeyValue("Activities", len(result.Activities))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+		for _, a := range result.Activities {
+			action, detail := describeAction(a.PrimaryActionDetail, people)
+			entry := ActivityEntry{Time: a.Timestamp, Action: action, Detail: detail, Actors: []string{}}
+			if entry.Time == "" && a.TimeRange != nil {
+				entry.Time = a.TimeRange.EndTime
+			}
+			for _, actor := range a.Actors {
+				entry.Actors = append(entry.Actors, describeActor(actor, people))
+			}
+			out.Activities = append(out.Activities, entry)
+
+			rb.Item("%s — %s by %s", entry.Time, entry.Action, strings.Join(entry.Actors, ", "))
+			if entry.Detail != "" {
+				rb.Line("    %s", entry.Detail)
+			}
+		}
+		if len(result.Activities) == 0 {
+			rb.Line("No activity found. Drive Activity does not record views; view history is in the admin audit log (list_audit_events with application drive).")
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// filePeople maps people/ IDs to names and emails using the file's
+// permissions, so activity by collaborators is shown by name. A user's
+// permission ID is their account ID. Lookup failures leave IDs unresolved.
+func filePeople(ctx context.Context, factory *services.Factory, userEmail, fileID string) map[string]string {
+	people := make(map[string]string)
+	srv, err := factory.Drive(ctx, userEmail)
+	if err != nil {
+		return people
+	}
+	result, err := srv.Permissions.List(fileID).
+		Fields("permissions(id, type, emailAddress, displayName)").
+		SupportsAllDrives(true).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return people
+	}
+	for _, p := range result.Permissions {
+		if p.Type != "user" {
+			continue
+		}
+		name := p.EmailAddress
+		if p.DisplayName != "" {
+			name = fmt.Sprintf("%s <%s>", p.DisplayName, p.EmailAddress)
+		}
+		people["people/"+p.Id] = name
+	}
+	return people
+}
@@ -0,0 +1,77 @@
+package drive
+
+import (
+	"testing"
+
+	"google.golang.org/api/driveactivity/v2"
+)
+
+func TestActivityFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   string
+		end     string
+		actions []string
+		want    string
+		wantErr bool
+	}{
+		{"empty", "", "", nil, "", false},
+		{"range", "2026-03-01T00:00:00Z", "2026-03-02T00:00:00Z", nil, "time >= 1772323200000 AND time < 1772409600000", false},
+		{"actions", "", "", []string{"edit", " Permission_Change"}, "detail.action_detail_case:(EDIT PERMISSION_CHANGE)", false},
+		{"start and action", "2026-03-01T00:00:00Z", "", []string{"comment"}, "time >= 1772323200000 AND detail.action_detail_case:(COMMENT)", false},
+		{"view is not an action", "", "", []string{"view"}, "", true},
+		{"invalid time", "yesterday", "", nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := activityFilter(tt.start, tt.end, tt.actions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("activityFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("activityFilter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeAction(t *testing.T) {
+	people := map[string]string{"people/42": "Ada <ada@example.com>"}
+	tests := []struct {
+		name       string
+		detail     *driveactivity.ActionDetail
+		wantAction string
+		wantDetail string
+	}{
+		{"edit", &driveactivity.ActionDetail{Edit: &driveactivity.Edit{}}, "edit", ""},
+		{"rename", &driveactivity.ActionDetail{Rename: &driveactivity.Rename{OldTitle: "a", NewTitle: "b"}}, "rename", `"a" → "b"`},
+		{"share", &driveactivity.ActionDetail{PermissionChange: &driveactivity.PermissionChange{
+			AddedPermissions: []*driveactivity.Permission{
+				{Role: "EDITOR", User: &driveactivity.User{KnownUser: &driveactivity.KnownUser{PersonName: "people/42"}}},
+				{Role: "VIEWER", Anyone: &driveactivity.Anyone{}},
+			},
+			RemovedPermissions: []*driveactivity.Permission{{Role: "COMMENTER", Domain: &driveactivity.Domain{Name: "example.com"}}},
+		}}, "permission_change", "added editor for Ada <ada@example.com>; added viewer for anyone with the link; removed commenter for domain example.com"},
+		{"comment", &driveactivity.ActionDetail{Comment: &driveactivity.Comment{Post: &driveactivity.Post{Subtype: "ADDED"}}}, "comment", "added"},
+		{"missing", nil, "unknown", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, detail := describeAction(tt.detail, people)
+			if action != tt.wantAction || detail != tt.wantDetail {
+				t.Errorf("describeAction() = %q, %q, want %q, %q", action, detail, tt.wantAction, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestDescribeActor(t *testing.T) {
+	me := &driveactivity.Actor{User: &driveactivity.User{KnownUser: &driveactivity.KnownUser{IsCurrentUser: true, PersonName: "people/1"}}}
+	if got := describeActor(me, nil); got != "you" {
+		t.Errorf("current user = %q, want you", got)
+	}
+	other := &driveactivity.Actor{User: &driveactivity.User{KnownUser: &driveactivity.KnownUser{PersonName: "people/7"}}}
+	if got := describeActor(other, nil); got != "people/7" {
+		t.Errorf("unresolved user = %q, want people/7", got)
+	}
+}
@@ -0,0 +1,223 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/provenance"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// maxDraftScan bounds how many recent drafts are inspected for the
+// provenance header, since Gmail cannot search by arbitrary headers.
+const maxDraftScan = 50
+
+// agentItemSources are the places stamped objects are looked up, in order.
+var agentItemSources = []string{"drive", "calendar", "gmail"}
+
+// --- list_agent_created_items (extended) ---
+
+type ListAgentCreatedItemsInput struct {
+	UserEmail string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Sources   []string `json:"sources,omitempty" jsonschema_description:"Where to look: drive, calendar (primary calendar), gmail (recent drafts). Default: all"`
+	Session   string   `json:"session,omitempty" jsonschema_description:"Only items created in this MCP session ID"`
+	PageSize  int      `json:"page_size,omitempty" jsonschema_description:"Maximum items per source (default 25)"`
+}
+
+// AgentCreatedItem is one object stamped by this server.
+type AgentCreatedItem struct {
+	Source    string `json:"source"`
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Type      string `json:"type,omitempty"`
+	Link      string `json:"link,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	Session   string `json:"session,omitempty"`
+}
+
+// ListAgentCreatedItemsOutput is the structured output for list_agent_created_items.
+type ListAgentCreatedItemsOutput struct {
+	Items   []AgentCreatedItem `json:"items"`
+	Errors  []string           `json:"errors,omitempty"`
+	Partial bool               `json:"partial,omitempty"`
+}
+
+func createListAgentCreatedItemsHandler(factory *services.Factory) mcp.ToolHandlerFor[ListAgentCreatedItemsInput, ListAgentCreatedItemsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListAgentCreatedItemsInput) (*mcp.CallToolResult, ListAgentCreatedItemsOutput, error) {
+		sources := input.Sources
+		if len(sources) == 0 {
+			sources = agentItemSources
+		}
+		for _, s := range sources {
+			if !slices.Contains(agentItemSources, s) {
+				return nil, ListAgentCreatedItemsOutput{}, fmt.Errorf("invalid source %q — use drive, calendar, or gmail", s)
+			}
+		}
+		if input.PageSize <= 0 {
+			input.PageSize = 25
+		}
+
+		finders := map[string]func(context.Context, *services.Factory, ListAgentCreatedItemsInput) ([]AgentCreatedItem, error){
+			"drive":    findAgentFiles,
+			"calendar": findAgentEvents,
+			"gmail":    findAgentDrafts,
+		}
+		out := ListAgentCreatedItemsOutput{Items: []AgentCreatedItem{}}
+		rb := response.New()
+		rb.Header("Items Created by This Server")
+		p := progress.New(ctx, req).Begin("Searching source", len(sources))
+		for _, source := range sources {
+			if p.Next() != nil {
+				break
+			}
+			items, err := finders[source](ctx, factory, input)
+			if err != nil && p.Cancelled() {
+				break
+			}
+			if err != nil {
+				// One unavailable service should not hide the others' results.
+				msg := fmt.Sprintf("%s: %v", source, middleware.HandleGoogleAPIError(err))
+				out.Errors = append(out.Errors, msg)
+				rb.Item("ERROR — %s", msg)
+				continue
+			}
+			out.Items = append(out.Items, items...)
+			writeAgentItems(rb, source, items)
+		}
+		p.Done()
+		if out.Partial = p.Cancelled(); out.Partial {
+			rb.Blank()
+			rb.KeyValue("Partial", p.Partial())
+		}
+		return rb.TextResult(), out, nil
+	}
+}
+
+func writeAgentItems(rb *response.Builder, source string, items []AgentCreatedItem) {
+	rb.Section("%s (%d)", source, len(items))
+	for _, it := range items {
+		rb.Item("%s [%s] created %s", it.Title, it.ID, it.CreatedAt)
+		if it.Link != "" {
+			rb.Line("    %s", it.Link)
+		}
+	}
+}
+
+// findAgentFiles lists stamped Drive files, newest first.
+func findAgentFiles(ctx context.Context, factory *services.Factory, input ListAgentCreatedItemsInput) ([]AgentCreatedItem, error) {
+	srv, err := factory.Drive(ctx, input.UserEmail)
+	if err != nil {
+		return nil, err
+	}
+	q := provenance.DriveQuery + " and trashed = false"
+	if input.Session != "" {
+		q += fmt.Sprintf(" and appProperties has { key='%s' and value='%s' }", provenance.KeySession, escapeQueryValue(input.Session))
+	}
+	list, err := srv.Files.List().
+		Q(q).
+		OrderBy("createdTime desc").
+		PageSize(int64(input.PageSize)).
+		Fields("files(id, name, mimeType, webViewLink, appProperties)").
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]AgentCreatedItem, 0, len(list.Files))
+	for _, f := range list.Files {
+		items = append(items, AgentCreatedItem{
+			Source: "drive", ID: f.Id, Title: f.Name, Type: formatFileType(f.MimeType), Link: f.WebViewLink,
+			CreatedAt: f.AppProperties[provenance.KeyCreatedAt], Session: f.AppProperties[provenance.KeySession],
+		})
+	}
+	return items, nil
+}
+
+// findAgentEvents lists stamped events on the user's primary calendar.
+func findAgentEvents(ctx context.Context, factory *services.Factory, input ListAgentCreatedItemsInput) ([]AgentCreatedItem, error) {
+	srv, err := factory.Calendar(ctx, input.UserEmail)
+	if err != nil {
+		return nil, err
+	}
+	filters := []string{provenance.CalendarFilter}
+	if input.Session != "" {
+		filters = append(filters, provenance.KeySession+"="+input.Session)
+	}
+	list, err := srv.Events.List("primary").
+		PrivateExtendedProperty(filters...).
+		MaxResults(int64(input.PageSize)).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]AgentCreatedItem, 0, len(list.Items))
+	for _, e := range list.Items {
+		item := AgentCreatedItem{Source: "calendar", ID: e.Id, Title: e.Summary, Type: "event", Link: e.HtmlLink}
+		if e.ExtendedProperties != nil {
+			item.CreatedAt = e.ExtendedProperties.Private[provenance.KeyCreatedAt]
+			item.Session = e.ExtendedProperties.Private[provenance.KeySession]
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// findAgentDrafts inspects the most recent drafts for the provenance header.
+func findAgentDrafts(ctx context.Context, factory *services.Factory, input ListAgentCreatedItemsInput) ([]AgentCreatedItem, error) {
+	srv, err := factory.Gmail(ctx, input.UserEmail)
+	if err != nil {
+		return nil, err
+	}
+	list, err := srv.Users.Messages.List(input.UserEmail).Q("in:drafts").MaxResults(maxDraftScan).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []AgentCreatedItem
+	for _, m := range list.Messages {
+		if len(items) >= input.PageSize {
+			break
+		}
+		msg, err := srv.Users.Messages.Get(input.UserEmail, m.Id).
+			Format("metadata").
+			MetadataHeaders(provenance.Header, "Subject").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, err
+		}
+		var subject, stampHeader string
+		if msg.Payload != nil {
+			for _, h := range msg.Payload.Headers {
+				switch {
+				case strings.EqualFold(h.Name, "Subject"):
+					subject = h.Value
+				case strings.EqualFold(h.Name, provenance.Header):
+					stampHeader = h.Value
+				}
+			}
+		}
+		stamp, ok := provenance.ParseHeaderValue(stampHeader)
+		if !ok || (input.Session != "" && stamp.Session != input.Session) {
+			continue
+		}
+		items = append(items, AgentCreatedItem{
+			Source: "gmail", ID: m.Id, Title: subject, Type: "draft",
+			CreatedAt: stamp.CreatedAt.Format(time.RFC3339), Session: stamp.Session,
+		})
+	}
+	return items, nil
+}
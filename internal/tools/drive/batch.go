@@ -0,0 +1,223 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// batchConcurrency bounds the Drive writes one batch tool call makes at
+// once. Drive throttles sustained writes per user well before its query
+// quota, so this stays low.
+var batchConcurrency = 4
+
+// maxBatchFiles caps the file IDs one batch move or copy accepts.
+const maxBatchFiles = 100
+
+// runBatch calls apply for each file ID with up to batchConcurrency calls in
+// flight and returns the outcomes in input order. Once the call is cancelled
+// no further IDs start, so the result is cut short after the last one that
+// did; calls already running finish or fail with the context error.
+func runBatch(ctx context.Context, p *progress.Reporter, ids []string, apply func(ctx context.Context, id string) (BatchFileOutcome, error)) []BatchFileOutcome {
+	outcomes := make([]BatchFileOutcome, len(ids))
+	attempted := 0
+	slots := make(chan struct{}, batchConcurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		slots <- struct{}{}
+		if p.Next() != nil {
+			<-slots
+			break
+		}
+		attempted++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			o, err := apply(ctx, id)
+			o.FileID = id
+			if err != nil {
+				o.Error = middleware.HandleGoogleAPIError(err).Error()
+			}
+			outcomes[i] = o
+		}()
+	}
+	wg.Wait()
+	return outcomes[:attempted]
+}
+
+// BatchFileOutcome is the result of moving or copying one file.
+type BatchFileOutcome struct {
+	FileID string `json:"file_id"`
+	Name   string `json:"name,omitempty"`
+	CopyID string `json:"copy_id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BatchFilesOutput struct {
+	FolderID     string             `json:"folder_id"`
+	Succeeded    int                `json:"succeeded"`
+	Failed       int                `json:"failed"`
+	NotAttempted int                `json:"not_attempted,omitempty"`
+	Files        []BatchFileOutcome `json:"files"`
+	Partial      bool               `json:"partial,omitempty"`
+}
+
+// validateBatch checks the file list and that the destination is a folder
+// the user can see, so a bad folder ID fails once instead of per file.
+func validateBatch(ctx context.Context, srv *drive.Service, fileIDs []string, folderID string) (*drive.File, error) {
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("file_ids cannot be empty")
+	}
+	if len(fileIDs) > maxBatchFiles {
+		return nil, fmt.Errorf("too many file_ids (%d) — maximum %d per call", len(fileIDs), maxBatchFiles)
+	}
+	folder, err := srv.Files.Get(folderID).
+		Fields("id, name, mimeType").
+		SupportsAllDrives(true).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, middleware.HandleGoogleAPIError(err)
+	}
+	if folder.MimeType != folderMimeType {
+		return nil, fmt.Errorf("folder_id %q is a %s, not a folder", folder.Name, formatFileType(folder.MimeType))
+	}
+	return folder, nil
+}
+
+// batchResult builds the summary shared by the batch move and copy tools.
+func batchResult(header, verb string, folder *drive.File, outcomes []BatchFileOutcome, total int, p *progress.Reporter) (*mcp.CallToolResult, BatchFilesOutput) {
+	out := BatchFilesOutput{FolderID: folder.Id, Files: outcomes, NotAttempted: total - len(outcomes), Partial: p.Cancelled()}
+	var failures []BatchFileOutcome
+	for _, o := range outcomes {
+		if o.Error != "" {
+			failures = append(failures, o)
+			continue
+		}
+		out.Succeeded++
+	}
+	out.Failed = len(failures)
+
+	rb := response.New()
+	rb.Header("%s", header)
+	rb.KeyValue("Folder", fmt.Sprintf("%s (%s)", folder.Name, folder.Id))
+	rb.KeyValue(verb, out.Succeeded)
+	rb.KeyValue("Failed", out.Failed)
+	if out.Partial {
+		// A file interrupted mid-request may still have been changed.
+		rb.KeyValue("Not attempted", out.NotAttempted)
+		rb.KeyValue("Partial", p.Partial())
+	}
+	if len(failures) > 0 {
+		rb.Blank()
+		rb.Section("Errors (%d)", len(failures))
+		for _, o := range failures {
+			rb.Item("%s: %s", o.FileID, o.Error)
+		}
+	}
+	return rb.TextResult(), out
+}
+
+// --- batch_move_drive_files (extended) ---
+
+type BatchMoveInput struct {
+	UserEmail string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileIDs   []string `json:"file_ids" jsonschema:"required" jsonschema_description:"IDs of the files or folders to move (maximum 100)"`
+	FolderID  string   `json:"folder_id" jsonschema:"required" jsonschema_description:"Destination folder ID"`
+}
+
+func createBatchMoveHandler(factory *services.Factory) mcp.ToolHandlerFor[BatchMoveInput, BatchFilesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input BatchMoveInput) (*mcp.CallToolResult, BatchFilesOutput, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, BatchFilesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		folder, err := validateBatch(ctx, srv, input.FileIDs, input.FolderID)
+		if err != nil {
+			return nil, BatchFilesOutput{}, err
+		}
+
+		p := progress.New(ctx, req).Begin("Moving file", len(input.FileIDs))
+		outcomes := runBatch(ctx, p, input.FileIDs, func(ctx context.Context, id string) (BatchFileOutcome, error) {
+			file, err := srv.Files.Get(id).Fields("id, name, parents").SupportsAllDrives(true).Context(ctx).Do()
+			if err != nil {
+				return BatchFileOutcome{}, err
+			}
+			moved, err := srv.Files.Update(id, &drive.File{}).
+				AddParents(folder.Id).
+				RemoveParents(strings.Join(file.Parents, ",")).
+				Fields("id, name").
+				SupportsAllDrives(true).
+				Context(ctx).
+				Do()
+			if err != nil {
+				return BatchFileOutcome{Name: file.Name}, err
+			}
+			return BatchFileOutcome{Name: moved.Name}, nil
+		})
+		p.Done()
+
+		result, out := batchResult("Batch Move Complete", "Moved", folder, outcomes, len(input.FileIDs), p)
+		return result, out, nil
+	}
+}
+
+// --- batch_copy_drive_files (extended) ---
+
+type BatchCopyInput struct {
+	UserEmail string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileIDs   []string `json:"file_ids" jsonschema:"required" jsonschema_description:"IDs of the files to copy (maximum 100; folders cannot be copied)"`
+	FolderID  string   `json:"folder_id" jsonschema:"required" jsonschema_description:"Destination folder ID"`
+}
+
+func createBatchCopyHandler(factory *services.Factory) mcp.ToolHandlerFor[BatchCopyInput, BatchFilesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input BatchCopyInput) (*mcp.CallToolResult, BatchFilesOutput, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, BatchFilesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		folder, err := validateBatch(ctx, srv, input.FileIDs, input.FolderID)
+		if err != nil {
+			return nil, BatchFilesOutput{}, err
+		}
+		var stamp map[string]string
+		if s := factory.Provenance(req); s != nil {
+			stamp = s.Properties()
+		}
+
+		p := progress.New(ctx, req).Begin("Copying file", len(input.FileIDs))
+		outcomes := runBatch(ctx, p, input.FileIDs, func(ctx context.Context, id string) (BatchFileOutcome, error) {
+			file, err := srv.Files.Get(id).Fields("id, name, mimeType").SupportsAllDrives(true).Context(ctx).Do()
+			if err != nil {
+				return BatchFileOutcome{}, err
+			}
+			if file.MimeType == folderMimeType {
+				return BatchFileOutcome{Name: file.Name}, fmt.Errorf("%q is a folder — Drive cannot copy folders", file.Name)
+			}
+			// Without a name Drive would call each copy "Copy of ...".
+			copied, err := srv.Files.Copy(id, &drive.File{Name: file.Name, Parents: []string{folder.Id}, AppProperties: stamp}).
+				Fields("id, name").
+				SupportsAllDrives(true).
+				Context(ctx).
+				Do()
+			if err != nil {
+				return BatchFileOutcome{Name: file.Name}, err
+			}
+			return BatchFileOutcome{Name: copied.Name, CopyID: copied.Id}, nil
+		})
+		p.Done()
+
+		result, out := batchResult("Batch Copy Complete", "Copied", folder, outcomes, len(input.FileIDs), p)
+		return result, out, nil
+	}
+}
@@ -0,0 +1,146 @@
+package drive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// batchServer fakes Drive with a folder dest, a subfolder sub, files a and b
+// in folder old, and a locked file that cannot be changed. It records the
+// query of each successful move.
+type batchServer struct {
+	mu    sync.Mutex
+	moves []string
+}
+
+func (s *batchServer) RoundTrip(r *http.Request) (*http.Response, error) {
+	reply := func(status int, body string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/drive/v3/files/")
+	id = strings.TrimSuffix(id, "/copy")
+	switch {
+	case id == "locked" && r.Method != http.MethodGet:
+		return reply(http.StatusForbidden, `{"error":{"code":403,"message":"The user does not have sufficient permissions for this file."}}`)
+	case r.Method == http.MethodPatch:
+		s.mu.Lock()
+		s.moves = append(s.moves, id+"?"+r.URL.RawQuery)
+		s.mu.Unlock()
+		return reply(http.StatusOK, `{"id":"`+id+`","name":"File `+id+`"}`)
+	case r.Method == http.MethodPost:
+		return reply(http.StatusOK, `{"id":"copy-`+id+`","name":"File `+id+`"}`)
+	case id == "dest" || id == "sub":
+		return reply(http.StatusOK, `{"id":"`+id+`","name":"Folder `+id+`","mimeType":"`+folderMimeType+`"}`)
+	default:
+		return reply(http.StatusOK, `{"id":"`+id+`","name":"File `+id+`","mimeType":"application/pdf","parents":["old"]}`)
+	}
+}
+
+func TestBatchMoveFiles(t *testing.T) {
+	srv := &batchServer{}
+	_, out, err := createBatchMoveHandler(fakeDriveFactory(srv))(context.Background(), &mcp.CallToolRequest{}, BatchMoveInput{
+		UserEmail: "user@example.com",
+		FileIDs:   []string{"a", "locked", "b"},
+		FolderID:  "dest",
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.Succeeded != 2 || out.Failed != 1 || len(out.Files) != 3 {
+		t.Fatalf("output = %+v", out)
+	}
+	for i, want := range []string{"a", "locked", "b"} {
+		if out.Files[i].FileID != want {
+			t.Errorf("Files[%d] = %+v, want file %s", i, out.Files[i], want)
+		}
+	}
+	if !strings.Contains(out.Files[1].Error, "permission") {
+		t.Errorf("locked error = %q", out.Files[1].Error)
+	}
+	for _, q := range srv.moves {
+		if !strings.Contains(q, "addParents=dest") || !strings.Contains(q, "removeParents=old") {
+			t.Errorf("move query = %s", q)
+		}
+	}
+}
+
+func TestBatchCopyFiles(t *testing.T) {
+	_, out, err := createBatchCopyHandler(fakeDriveFactory(&batchServer{}))(context.Background(), &mcp.CallToolRequest{}, BatchCopyInput{
+		UserEmail: "user@example.com",
+		FileIDs:   []string{"a", "sub"},
+		FolderID:  "dest",
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.Succeeded != 1 || out.Files[0].CopyID != "copy-a" {
+		t.Errorf("output = %+v", out)
+	}
+	if !strings.Contains(out.Files[1].Error, "cannot copy folders") {
+		t.Errorf("folder error = %q", out.Files[1].Error)
+	}
+}
+
+func TestBatchValidation(t *testing.T) {
+	handler := createBatchMoveHandler(fakeDriveFactory(&batchServer{}))
+	tests := []struct {
+		name    string
+		ids     []string
+		folder  string
+		wantErr string
+	}{
+		{"empty", nil, "dest", "cannot be empty"},
+		{"too many", make([]string, maxBatchFiles+1), "dest", "maximum 100"},
+		{"not a folder", []string{"a"}, "a", "not a folder"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, BatchMoveInput{UserEmail: "user@example.com", FileIDs: tt.ids, FolderID: tt.folder})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBatchMovePartialOnCancel(t *testing.T) {
+	defer func(n int) { batchConcurrency = n }(batchConcurrency)
+	batchConcurrency = 1
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method == http.MethodPatch {
+			if calls++; calls == 2 {
+				cancel()
+			}
+		}
+		return (&batchServer{}).RoundTrip(r)
+	})
+	_, out, err := createBatchMoveHandler(fakeDriveFactory(rt))(ctx, &mcp.CallToolRequest{}, BatchMoveInput{
+		UserEmail: "user@example.com",
+		FileIDs:   []string{"a", "b", "c", "d"},
+		FolderID:  "dest",
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if !out.Partial || out.NotAttempted != 2 || len(out.Files) != 2 {
+		t.Errorf("output = %+v", out)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
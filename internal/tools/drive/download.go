@@ -0,0 +1,309 @@
+package drive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/provenance"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// maxLocalDownloadSize bounds the files download_drive_file writes to the
+// download directory, so one call cannot fill the server's disk.
+const maxLocalDownloadSize = 1 << 30
+
+// exportFormats are the export_format values download tools accept, in the
+// order exportExtension searches them.
+var exportFormats = []string{"pdf", "docx", "xlsx", "csv", "pptx"}
+
+// exportExtension returns the file extension for an export MIME type, or ""
+// when it is not one of exportFormats.
+func exportExtension(mimeType string) string {
+	for _, f := range exportFormats {
+		if exportFormatToMime(f) == mimeType {
+			return "." + f
+		}
+	}
+	return ""
+}
+
+// safeFileName turns a Drive file name into a single path element so a
+// download cannot escape the download directory.
+func safeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r < 0x20 {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." {
+		return "download"
+	}
+	return name
+}
+
+// createUniqueFile creates name in dir, adding " (2)", " (3)", ... before the
+// extension instead of overwriting an existing file.
+func createUniqueFile(dir, name string) (*os.File, error) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 1; i <= 100; i++ {
+		candidate := name
+		if i > 1 {
+			candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+		}
+		f, err := os.OpenFile(filepath.Join(dir, candidate), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		return f, err
+	}
+	return nil, fmt.Errorf("too many files named %q in the download directory", name)
+}
+
+// --- download_drive_file (extended) ---
+
+type DownloadFileInput struct {
+	UserEmail    string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID       string `json:"file_id" jsonschema:"required" jsonschema_description:"The Google Drive file ID"`
+	Destination  string `json:"destination,omitempty" jsonschema_description:"Where to put the copy: local writes it to the server's configured download directory, drive saves it into a Drive folder (default local when the server runs over stdio with a download directory configured, else drive),enum=local,enum=drive"`
+	ExportFormat string `json:"export_format,omitempty" jsonschema_description:"Format for Google Docs, Sheets, and Slides (default pdf for Docs and Slides, xlsx for Sheets),enum=pdf,enum=docx,enum=xlsx,enum=csv,enum=pptx"`
+	FolderID     string `json:"folder_id,omitempty" jsonschema_description:"Drive folder for destination=drive (default: My Drive root)"`
+	FileName     string `json:"file_name,omitempty" jsonschema_description:"Name for the copy (default: the file's name, with the export extension for Google files)"`
+}
+
+type DownloadFileOutput struct {
+	FileName    string `json:"file_name"`
+	MimeType    string `json:"mime_type"`
+	Destination string `json:"destination"`
+	Size        int64  `json:"size,omitempty"`
+	LocalPath   string `json:"local_path,omitempty"`
+	DriveFileID string `json:"drive_file_id,omitempty"`
+	WebViewLink string `json:"web_view_link,omitempty"`
+}
+
+func createDownloadFileHandler(factory *services.Factory, downloadDir string, local bool) mcp.ToolHandlerFor[DownloadFileInput, DownloadFileOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DownloadFileInput) (*mcp.CallToolResult, DownloadFileOutput, error) {
+		if err := validateDownloadInput(&input, downloadDir, local); err != nil {
+			return nil, DownloadFileOutput{}, err
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, DownloadFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		file, err := srv.Files.Get(input.FileID).
+			Fields("id, name, mimeType, size").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, DownloadFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out, native, err := downloadTarget(input, file)
+		if err != nil {
+			return nil, DownloadFileOutput{}, err
+		}
+		stamp := factory.Provenance(req)
+
+		// Binary files copied within Drive never leave Google's servers.
+		if input.Destination == "drive" && !native {
+			err = copyWithinDrive(ctx, srv, file.Id, input.FolderID, stamp, &out)
+			if err != nil {
+				return nil, DownloadFileOutput{}, err
+			}
+			return downloadResult(out), out, nil
+		}
+		if input.Destination == "local" && file.Size > maxLocalDownloadSize {
+			return nil, DownloadFileOutput{}, fmt.Errorf("%q is %s, over the %s limit for local downloads — use destination=drive", file.Name, formatSize(file.Size), formatSize(maxLocalDownloadSize))
+		}
+
+		body, err := openDownload(ctx, srv, file.Id, out.MimeType, native)
+		if err != nil {
+			return nil, DownloadFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		defer body.Close()
+
+		if input.Destination == "local" {
+			out.LocalPath, out.Size, err = writeLocalCopy(downloadDir, out.FileName, body, maxLocalDownloadSize)
+			out.FileName = filepath.Base(out.LocalPath)
+		} else {
+			err = uploadToDrive(ctx, srv, body, input.FolderID, stamp, &out)
+		}
+		if err != nil {
+			return nil, DownloadFileOutput{}, err
+		}
+		return downloadResult(out), out, nil
+	}
+}
+
+// openDownload starts downloading a file's content, exporting Google files
+// as mimeType.
+func openDownload(ctx context.Context, srv *drive.Service, fileID, mimeType string, native bool) (io.ReadCloser, error) {
+	var resp *http.Response
+	var err error
+	if native {
+		resp, err = srv.Files.Export(fileID, mimeType).Context(ctx).Download()
+	} else {
+		resp, err = srv.Files.Get(fileID).SupportsAllDrives(true).Context(ctx).Download()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// validateDownloadInput checks the destination and options of a download,
+// defaulting the destination to local only for stdio servers with a
+// download directory; over HTTP the caller is not on the server's machine.
+func validateDownloadInput(input *DownloadFileInput, downloadDir string, local bool) error {
+	switch input.Destination {
+	case "":
+		input.Destination = "drive"
+		if local && downloadDir != "" {
+			input.Destination = "local"
+		}
+	case "local":
+		if downloadDir == "" {
+			return fmt.Errorf("destination=local needs a download directory — set WORKSPACE_MCP_DOWNLOAD_DIR, or use destination=drive")
+		}
+	case "drive":
+	default:
+		return fmt.Errorf("invalid destination %q — use local or drive", input.Destination)
+	}
+	if input.FolderID != "" && input.Destination != "drive" {
+		return fmt.Errorf("folder_id applies only to destination=drive")
+	}
+	if input.ExportFormat != "" && exportFormatToMime(input.ExportFormat) == "" {
+		return fmt.Errorf("invalid export_format %q — use one of: %s", input.ExportFormat, strings.Join(exportFormats, ", "))
+	}
+	return nil
+}
+
+// downloadTarget works out the MIME type and name of the copy of file, and
+// whether it is a Google file that has to be exported.
+func downloadTarget(input DownloadFileInput, file *drive.File) (DownloadFileOutput, bool, error) {
+	out := DownloadFileOutput{FileName: input.FileName, MimeType: file.MimeType, Destination: input.Destination}
+	native := isGoogleNativeType(file.MimeType)
+	if native {
+		out.MimeType = mimeTypeForDownloadURL(file.MimeType)
+		if input.ExportFormat != "" {
+			out.MimeType = exportFormatToMime(input.ExportFormat)
+		}
+		if out.MimeType == "" {
+			return out, native, fmt.Errorf("%s files cannot be downloaded — only Docs, Sheets, and Slides export", formatFileType(file.MimeType))
+		}
+	} else if input.ExportFormat != "" {
+		return out, native, fmt.Errorf("export_format applies only to Google Docs, Sheets, and Slides — %q is a %s", file.Name, formatFileType(file.MimeType))
+	}
+	if out.FileName == "" {
+		out.FileName = file.Name
+		if ext := exportExtension(out.MimeType); native && !strings.EqualFold(filepath.Ext(out.FileName), ext) {
+			out.FileName += ext
+		}
+	}
+	return out, native, nil
+}
+
+// copyWithinDrive copies a binary file into folderID (or My Drive) under
+// out.FileName, recording the copy in out.
+func copyWithinDrive(ctx context.Context, srv *drive.Service, fileID, folderID string, stamp *provenance.Stamp, out *DownloadFileOutput) error {
+	copied := &drive.File{Name: out.FileName}
+	if folderID != "" {
+		copied.Parents = []string{folderID}
+	}
+	if stamp != nil {
+		copied.AppProperties = stamp.Properties()
+	}
+	created, err := srv.Files.Copy(fileID, copied).
+		Fields("id, name, size, webViewLink").
+		SupportsAllDrives(true).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return middleware.HandleGoogleAPIError(err)
+	}
+	out.Size, out.DriveFileID, out.WebViewLink = created.Size, created.Id, created.WebViewLink
+	return nil
+}
+
+// uploadToDrive stores downloaded content as a new file in folderID (or My
+// Drive), recording it in out.
+func uploadToDrive(ctx context.Context, srv *drive.Service, body io.Reader, folderID string, stamp *provenance.Stamp, out *DownloadFileOutput) error {
+	exported := &drive.File{Name: out.FileName, MimeType: out.MimeType}
+	if folderID != "" {
+		exported.Parents = []string{folderID}
+	}
+	if stamp != nil {
+		exported.AppProperties = stamp.Properties()
+	}
+	created, err := srv.Files.Create(exported).
+		Media(body).
+		Fields("id, name, size, webViewLink").
+		SupportsAllDrives(true).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return middleware.HandleGoogleAPIError(err)
+	}
+	out.Size, out.DriveFileID, out.WebViewLink = created.Size, created.Id, created.WebViewLink
+	return nil
+}
+
+// writeLocalCopy writes body to a new file named name in dir, failing and
+// removing the partial file once more than limit bytes arrive. Drive does
+// not report sizes for exports, so the limit is enforced while copying.
+func writeLocalCopy(dir, name string, body io.Reader, limit int64) (string, int64, error) {
+	f, err := createUniqueFile(dir, safeFileName(name))
+	if err != nil {
+		return "", 0, fmt.Errorf("creating download file: %w", err)
+	}
+	n, err := io.Copy(f, io.LimitReader(body, limit+1))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n > limit {
+		err = fmt.Errorf("file is over the %s limit for local downloads — use destination=drive", formatSize(limit))
+	} else if err != nil {
+		err = fmt.Errorf("writing download file: %w", err)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", 0, err
+	}
+	return f.Name(), n, nil
+}
+
+func downloadResult(out DownloadFileOutput) *mcp.CallToolResult {
+	rb := response.New()
+	rb.Header("Drive File Downloaded")
+	rb.KeyValue("File", out.FileName)
+	rb.KeyValue("Type", formatFileType(out.MimeType))
+	if out.Size > 0 {
+		rb.KeyValue("Size", formatSize(out.Size))
+	}
+	if out.LocalPath != "" {
+		rb.KeyValue("Saved to", out.LocalPath)
+	} else {
+		rb.KeyValue("Drive File ID", out.DriveFileID)
+		if out.WebViewLink != "" {
+			rb.KeyValue("Link", out.WebViewLink)
+		}
+	}
+	return rb.TextResult()
+}
@@ -0,0 +1,124 @@
+package drive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/sandbox"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+func sandboxFactory() *services.Factory {
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(sandbox.NewTransport())
+	return factory
+}
+
+func TestSafeFileName(t *testing.T) {
+	tests := map[string]string{
+		"report.pdf":       "report.pdf",
+		"../../etc/passwd": ".._.._etc_passwd",
+		`a\b`:              "a_b",
+		"..":               "download",
+		"  ":               "download",
+	}
+	for in, want := range tests {
+		if got := safeFileName(in); got != want {
+			t.Errorf("safeFileName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCreateUniqueFile(t *testing.T) {
+	dir := t.TempDir()
+	var names []string
+	for range 3 {
+		f, err := createUniqueFile(dir, "notes.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		names = append(names, filepath.Base(f.Name()))
+	}
+	want := []string{"notes.txt", "notes (2).txt", "notes (3).txt"}
+	if strings.Join(names, "|") != strings.Join(want, "|") {
+		t.Errorf("names = %q, want %q", names, want)
+	}
+}
+
+func TestDownloadFileLocal(t *testing.T) {
+	dir := t.TempDir()
+	handler := createDownloadFileHandler(sandboxFactory(), dir, true)
+
+	_, out, err := handler(context.Background(), &mcp.CallToolRequest{}, DownloadFileInput{
+		UserEmail: sandbox.DemoUser,
+		FileID:    "1sbxPlanDoc0001",
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.Destination != "local" || out.FileName != "Offsite agenda (draft).pdf" || out.MimeType != "application/pdf" {
+		t.Errorf("output = %+v", out)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, out.FileName))
+	if err != nil || int64(len(data)) != out.Size || !strings.Contains(string(data), "sandbox export content") {
+		t.Errorf("downloaded file = %q, %v; want %d bytes of export content", data, err, out.Size)
+	}
+}
+
+func TestDownloadFileDefaultsToDriveOverHTTP(t *testing.T) {
+	input := DownloadFileInput{}
+	if err := validateDownloadInput(&input, t.TempDir(), false); err != nil {
+		t.Fatal(err)
+	}
+	if input.Destination != "drive" {
+		t.Errorf("destination = %q, want drive", input.Destination)
+	}
+}
+
+func TestWriteLocalCopyLimit(t *testing.T) {
+	dir := t.TempDir()
+
+	path, n, err := writeLocalCopy(dir, "ok.txt", strings.NewReader("12345"), 5)
+	if err != nil || n != 5 || filepath.Base(path) != "ok.txt" {
+		t.Fatalf("at limit: path=%q n=%d err=%v", path, n, err)
+	}
+
+	if _, _, err := writeLocalCopy(dir, "big.txt", strings.NewReader("123456"), 5); err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Fatalf("over limit: err = %v, want limit error", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "big.txt")); !os.IsNotExist(err) {
+		t.Errorf("partial file left behind: %v", err)
+	}
+}
+
+func TestDownloadFileValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		dir    string
+		input  DownloadFileInput
+		errMsg string
+	}{
+		{"local without dir", "", DownloadFileInput{Destination: "local"}, "WORKSPACE_MCP_DOWNLOAD_DIR"},
+		{"bad destination", "", DownloadFileInput{Destination: "email"}, "invalid destination"},
+		{"folder for local", "/tmp", DownloadFileInput{FolderID: "f"}, "folder_id applies only"},
+		{"bad format", "", DownloadFileInput{ExportFormat: "odt"}, "invalid export_format"},
+		{"format for binary", "", DownloadFileInput{UserEmail: sandbox.DemoUser, FileID: "1sbxInvoicePdf4471", ExportFormat: "docx"}, "applies only to Google Docs"},
+		{"folder", "", DownloadFileInput{UserEmail: sandbox.DemoUser, FileID: "1sbxFolderPlanning"}, "cannot be downloaded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := createDownloadFileHandler(sandboxFactory(), tt.dir, true)(context.Background(), &mcp.CallToolRequest{}, tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("error = %v, want containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
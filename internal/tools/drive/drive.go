@@ -3,6 +3,7 @@ package drive
 import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/ptr"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
 )
@@ -13,8 +14,15 @@ var serviceIcons = []mcp.Icon{{
 	Sizes:    []string{"48x48"},
 }}
 
-// Register registers all core Drive tools with the MCP server.
-func Register(server *mcp.Server, factory *services.Factory) {
+// Register registers all core Drive tools with the MCP server, and Drive
+// files and folders as gdrive:// resources. localFiles lets upload_drive_file
+// read from the server's filesystem, which is only safe over stdio;
+// downloadDir, if set, is where download_drive_file writes local copies,
+// which it only defaults to when localFiles is set.
+func Register(server *mcp.Server, factory *services.Factory, res *middleware.Resources, localFiles bool, downloadDir string) {
+	watcher := newFileWatcher(factory, server, watchPollInterval)
+	registerResources(server, factory, res, watcher)
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "search_drive_files",
 		Icons:       serviceIcons,
@@ -58,6 +66,16 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createCreateFileHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "upload_drive_file",
+		Icons:       serviceIcons,
+		Description: "Upload a binary file to Google Drive from base64 content or, when the server runs over stdio, a local file path. Files over 5 MB use a resumable upload with progress notifications.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Upload Drive File",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createUploadFileHandler(factory, localFiles))
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "import_to_google_doc",
 		Icons:       serviceIcons,
@@ -103,6 +121,74 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createListDriveItemsHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_drive_folder",
+		Icons:       serviceIcons,
+		Description: "Create a Drive folder, or a nested path of folders such as Projects/2026/Q1. Folders that already exist along the path are reused, so repeating the call is safe. Rename or move folders with update_drive_file.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Create Drive Folder",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createCreateFolderHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_drive_folder_tree",
+		Icons:       serviceIcons,
+		Description: "Get the folder hierarchy under a Drive folder, depth-limited, with file counts and total sizes per folder and optionally the files themselves. Lists at most 200 folders per call.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Drive Folder Tree",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetFolderTreeHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "search_drive_content",
+		Icons:       serviceIcons,
+		Description: "Search inside file contents (full-text) and return short snippets around each match, so you can tell which file actually contains a phrase. Downloads at most 10 files per call; use search_drive_files for metadata-only queries.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Search Drive Content",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createSearchDriveContentHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "watch_drive_file",
+		Icons:       serviceIcons,
+		Description: "Watch a Drive file for modifications. The server polls the Drive changes feed and sends a notification to this MCP session (as a log message from logger \"drive-watch\") whenever the file's content is modified, trashed, or removed. Use for standing requests like \"tell me when the contract doc changes\". Watches last for the session.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Watch Drive File",
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createWatchFileHandler(factory, watcher))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "unwatch_drive_file",
+		Icons:       serviceIcons,
+		Description: "Stop watching a Drive file previously registered with watch_drive_file in this session.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Unwatch Drive File",
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(false),
+		},
+	}, createUnwatchFileHandler(watcher))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_drive_storage_info",
+		Icons:       serviceIcons,
+		Description: "Get the user's storage quota: usage and limit, with the split between Drive, Drive trash, and Gmail/Photos, plus the maximum upload size. Check it before large uploads.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Drive Storage Info",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createStorageInfoHandler(factory))
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "copy_drive_file",
 		Icons:       serviceIcons,
@@ -113,6 +199,26 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createCopyFileHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "download_drive_file",
+		Icons:       serviceIcons,
+		Description: "Download a Drive file, or a PDF/DOCX/XLSX/CSV/PPTX export of a Google Doc, Sheet, or Slides deck, into the server's configured download directory or into another Drive folder. Use when you need the file itself rather than a download URL.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Download Drive File",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createDownloadFileHandler(factory, downloadDir, localFiles))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "ocr_drive_file",
+		Icons:       serviceIcons,
+		Description: "Extract text from an image or scanned PDF in Drive with Drive's OCR, by converting it to a Google Doc. The Doc is deleted afterwards unless keep_doc=true.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "OCR Drive File",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createOCRFileHandler(factory))
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "update_drive_file",
 		Icons:       serviceIcons,
@@ -124,6 +230,117 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createUpdateFileHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_drive_shortcut",
+		Icons:       serviceIcons,
+		Description: "Create a Drive shortcut to a file or folder in another folder, e.g. to pin a doc into a project folder without moving or copying it.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Create Drive Shortcut",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createCreateShortcutHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "resolve_drive_shortcut",
+		Icons:       serviceIcons,
+		Description: "Look up the file or folder a Drive shortcut points to, and whether that target is trashed or gone.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Resolve Drive Shortcut",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createResolveShortcutHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "star_drive_file",
+		Icons:       serviceIcons,
+		Description: "Star a Drive file or folder for the user, or remove the star with unstar=true.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Star Drive File",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createStarFileHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "lock_drive_file",
+		Icons:       serviceIcons,
+		Description: "Lock a Drive file read-only with an optional reason, e.g. to freeze a document after sign-off, or remove the lock with unlock=true. Locked files cannot be edited, commented on, or renamed until unlocked.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Lock Drive File",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createLockFileHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_drive_file_properties",
+		Icons:       serviceIcons,
+		Description: "Get a Drive file's custom key-value metadata: public properties and the private app properties set by this server.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Drive File Properties",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetFilePropertiesHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "set_drive_file_properties",
+		Icons:       serviceIcons,
+		Description: "Add, overwrite, or remove custom key-value properties on a Drive file, e.g. to tag files an agent manages so search_drive_by_properties can find them again. Keys not mentioned are kept.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Set Drive File Properties",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createSetFilePropertiesHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "search_drive_by_properties",
+		Icons:       serviceIcons,
+		Description: "Find Drive files whose custom properties or app properties match the given key-value pairs exactly, optionally narrowed by a Drive query.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Search Drive by Properties",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createSearchByPropertiesHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "trash_drive_file",
+		Icons:       serviceIcons,
+		Description: "Move a Drive file or folder to trash, or restore it with untrash=true. Drive deletes trashed files after 30 days.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Trash Drive File",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createTrashFileHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_trashed_files",
+		Icons:       serviceIcons,
+		Description: "List files and folders in the Drive trash, most recently modified first, with when and by whom each was trashed.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Trashed Files",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListTrashedFilesHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_drive_file_permanently",
+		Icons:       serviceIcons,
+		Description: "Permanently delete a Drive file or folder, skipping trash. This cannot be undone; prefer trash_drive_file. Deleting a folder deletes everything in it that the user owns.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Delete Drive File Permanently",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createDeleteFileHandler(factory))
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "update_drive_permission",
 		Icons:       serviceIcons,
@@ -151,8 +368,9 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		Icons:       serviceIcons,
 		Description: "Transfer ownership of a Drive file to another user.",
 		Annotations: &mcp.ToolAnnotations{
-			Title:         "Transfer Drive Ownership",
-			OpenWorldHint: ptr.Bool(true),
+			Title:           "Transfer Drive Ownership",
+			DestructiveHint: ptr.Bool(true),
+			OpenWorldHint:   ptr.Bool(true),
 		},
 	}, createTransferOwnershipHandler(factory))
 
@@ -166,6 +384,204 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createBatchShareHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "batch_move_drive_files",
+		Icons:       serviceIcons,
+		Description: "Move up to 100 Drive files or folders into one folder, several at a time. Reports progress and lists per-file failures without stopping the batch.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Batch Move Drive Files",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createBatchMoveHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "batch_copy_drive_files",
+		Icons:       serviceIcons,
+		Description: "Copy up to 100 Drive files into one folder, keeping their names, several at a time. Reports progress and lists per-file failures without stopping the batch.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Batch Copy Drive Files",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createBatchCopyHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_shared_drives",
+		Icons:       serviceIcons,
+		Description: "List the shared drives the user is a member of, or every shared drive in the domain with use_domain_admin_access (administrators only).",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Shared Drives",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListSharedDrivesHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_shared_drive",
+		Icons:       serviceIcons,
+		Description: "Create a shared drive. The user becomes its organizer. Pass request_id to make retries safe.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Create Shared Drive",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createCreateSharedDriveHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_shared_drive",
+		Icons:       serviceIcons,
+		Description: "Rename a shared drive or change its restrictions: admin-managed restrictions, copy requires writer, domain users only, drive members only.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Update Shared Drive",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createUpdateSharedDriveHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_shared_drive_members",
+		Icons:       serviceIcons,
+		Description: "List the members of a shared drive with their roles.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Shared Drive Members",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListSharedDriveMembersHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "add_shared_drive_member",
+		Icons:       serviceIcons,
+		Description: "Add a user or group to a shared drive with a role (organizer, fileOrganizer, writer, commenter, reader), or change the role of an existing member.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Add Shared Drive Member",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createAddSharedDriveMemberHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "remove_shared_drive_member",
+		Icons:       serviceIcons,
+		Description: "Remove a user or group from a shared drive.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Remove Shared Drive Member",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createRemoveSharedDriveMemberHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_agent_created_items",
+		Icons:       serviceIcons,
+		Description: "Find Drive files, primary-calendar events, and Gmail drafts created by this server, identified by the provenance stamp written when WORKSPACE_MCP_STAMP_PROVENANCE is enabled. Optionally filter to one MCP session.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Agent-Created Items",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListAgentCreatedItemsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_drive_file_activity",
+		Icons:       serviceIcons,
+		Description: "Show what happened to a Drive file over a time range: who created, edited, moved, renamed, deleted, shared, or commented on it, newest first. Views are not recorded by Drive Activity.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get File Activity",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetFileActivityHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_drive_revisions",
+		Icons:       serviceIcons,
+		Description: "List the revisions of a Drive file, oldest first, with who made each and whether it is kept forever. The last revision is the current content.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Drive Revisions",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListRevisionsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_drive_revision_content",
+		Icons:       serviceIcons,
+		Description: "Get the text content of a specific revision of a Drive file, to compare it with the current version before restoring. Supports Google Docs/Sheets/Slides, Office, and text files.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Drive Revision Content",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetRevisionContentHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "keep_drive_revision",
+		Icons:       serviceIcons,
+		Description: "Pin a revision of a binary Drive file so Drive never purges it, or unpin it with unpin=true. Google Docs, Sheets, and Slides keep all revisions and do not support pinning.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Keep Drive Revision",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createKeepRevisionHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "restore_drive_revision",
+		Icons:       serviceIcons,
+		Description: "Restore an old revision of a Drive file as its current content, e.g. to undo an unwanted edit. The replaced content stays available as a revision. Google Docs, Sheets, and Slides round-trip through their Office format, so some formatting may change.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Restore Drive Revision",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createRestoreRevisionHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_drive_labels",
+		Icons:       serviceIcons,
+		Description: "List the organization's published Drive labels (classification taxonomies) with their fields and selection choices. Use the IDs with apply_drive_label.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Drive Labels",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListLabelsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_drive_file_labels",
+		Icons:       serviceIcons,
+		Description: "Get the Drive labels applied to a file with their field values.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get File Labels",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetFileLabelsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "apply_drive_label",
+		Icons:       serviceIcons,
+		Description: "Apply a Drive label to a file, or update field values of a label already applied. Fields can be given by ID or display name, and selection values by choice ID or name.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Apply Drive Label",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createApplyLabelHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "remove_drive_label",
+		Icons:       serviceIcons,
+		Description: "Remove a Drive label and all its field values from a file.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Remove Drive Label",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createRemoveLabelHandler(factory))
+
 	// --- Complete tools ---
 
 	mcp.AddTool(server, &mcp.Tool{
@@ -189,4 +605,16 @@ func Register(server *mcp.Server, factory *services.Factory) {
 			OpenWorldHint: ptr.Bool(true),
 		},
 	}, createCheckPublicAccessHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "empty_drive_trash",
+		Icons:       serviceIcons,
+		Description: "Permanently delete every file in the user's Drive trash, or a shared drive's trash with drive_id. This cannot be undone; review with list_trashed_files first.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Empty Drive Trash",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createEmptyTrashHandler(factory))
 }
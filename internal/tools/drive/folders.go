@@ -0,0 +1,337 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/validate"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// maxTreeFolders caps how many folders one get_drive_folder_tree call lists,
+// one Drive request (or more for large folders) each.
+const maxTreeFolders = 200
+
+// --- create_drive_folder (extended) ---
+
+type CreateFolderInput struct {
+	UserEmail      string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Name           string `json:"name" jsonschema:"required" jsonschema_description:"Folder name; use slashes to create nested folders (e.g. Projects/2026/Q1), reusing any that already exist"`
+	ParentFolderID string `json:"parent_folder_id,omitempty" jsonschema_description:"Folder to create it in (default: My Drive root)"`
+}
+
+type CreateFolderOutput struct {
+	FolderID    string   `json:"folder_id"`
+	Name        string   `json:"name"`
+	WebViewLink string   `json:"web_view_link,omitempty"`
+	Created     []string `json:"created"`
+	Reused      []string `json:"reused,omitempty"`
+}
+
+func createCreateFolderHandler(factory *services.Factory) mcp.ToolHandlerFor[CreateFolderInput, CreateFolderOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateFolderInput) (*mcp.CallToolResult, CreateFolderOutput, error) {
+		var segments []string
+		for _, s := range strings.Split(input.Name, "/") {
+			if s = strings.TrimSpace(s); s != "" {
+				segments = append(segments, s)
+			}
+		}
+		if len(segments) == 0 {
+			return nil, CreateFolderOutput{}, fmt.Errorf("name must contain a folder name")
+		}
+		parentID := input.ParentFolderID
+		if parentID == "" {
+			parentID = "root"
+		}
+		if err := validate.DriveID(parentID); err != nil {
+			return nil, CreateFolderOutput{}, err
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, CreateFolderOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := CreateFolderOutput{Created: []string{}}
+		var folder *drive.File
+		for i, name := range segments {
+			// Intermediate folders are reused so repeated calls build one
+			// tree; the last segment is reused too, making the call idempotent.
+			existing, err := srv.Files.List().
+				Q(fmt.Sprintf("'%s' in parents and name = '%s' and mimeType = '%s' and trashed = false", parentID, escapeQueryValue(name), folderMimeType)).
+				Fields("files(id, name, webViewLink)").
+				PageSize(1).
+				SupportsAllDrives(true).
+				IncludeItemsFromAllDrives(true).
+				Context(ctx).
+				Do()
+			if err != nil {
+				return nil, CreateFolderOutput{}, middleware.HandleGoogleAPIError(err)
+			}
+			path := strings.Join(segments[:i+1], "/")
+			if len(existing.Files) > 0 {
+				folder = existing.Files[0]
+				out.Reused = append(out.Reused, path)
+			} else {
+				meta := &drive.File{Name: name, MimeType: folderMimeType, Parents: []string{parentID}}
+				if stamp := factory.Provenance(req); stamp != nil {
+					meta.AppProperties = stamp.Properties()
+				}
+				folder, err = srv.Files.Create(meta).
+					Fields("id, name, webViewLink").
+					SupportsAllDrives(true).
+					Context(ctx).
+					Do()
+				if err != nil {
+					return nil, CreateFolderOutput{}, middleware.HandleGoogleAPIError(err)
+				}
+				out.Created = append(out.Created, path)
+			}
+			parentID = folder.Id
+		}
+		out.FolderID, out.Name, out.WebViewLink = folder.Id, segments[len(segments)-1], folder.WebViewLink
+
+		rb := response.New()
+		if len(out.Created) > 0 {
+			rb.Header("Folder Created")
+		} else {
+			rb.Header("Folder Already Exists")
+		}
+		rb.KeyValue("Name", out.Name)
+		rb.KeyValue("ID", out.FolderID)
+		if out.WebViewLink != "" {
+			rb.KeyValue("Link", out.WebViewLink)
+		}
+		for _, p := range out.Created {
+			rb.Item("Created %s", p)
+		}
+		for _, p := range out.Reused {
+			rb.Item("Reused %s", p)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- get_drive_folder_tree (extended) ---
+
+type GetFolderTreeInput struct {
+	UserEmail    string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FolderID     string `json:"folder_id,omitempty" jsonschema_description:"Folder to start from (default: My Drive root)"`
+	MaxDepth     int    `json:"max_depth,omitempty" jsonschema_description:"Levels of folders to list, counting the starting folder (default 3, max 10)"`
+	IncludeFiles bool   `json:"include_files,omitempty" jsonschema_description:"List files in each folder, not just counts and sizes"`
+}
+
+// FolderEntry is one folder of a get_drive_folder_tree result. Counts and
+// sizes cover the folders that were listed; Unexpanded folders, cut off by
+// max_depth or the folder limit, were not listed and have none.
+type FolderEntry struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Path        string        `json:"path"`
+	Depth       int           `json:"depth"`
+	ParentID    string        `json:"parent_id,omitempty"`
+	FileCount   int           `json:"file_count"`
+	FolderCount int           `json:"folder_count"`
+	TotalFiles  int           `json:"total_files"`
+	TotalSize   int64         `json:"total_size"`
+	Files       []FileSummary `json:"files,omitempty"`
+	Unexpanded  bool          `json:"unexpanded,omitempty"`
+}
+
+// GetFolderTreeOutput lists the tree depth-first, starting folder first;
+// ParentID and Depth link each folder to its place in the hierarchy.
+type GetFolderTreeOutput struct {
+	Folders       []FolderEntry `json:"folders"`
+	TotalFiles    int           `json:"total_files"`
+	TotalSize     int64         `json:"total_size"`
+	FoldersListed int           `json:"folders_listed"`
+	Truncated     bool          `json:"truncated,omitempty"`
+	Partial       bool          `json:"partial,omitempty"`
+}
+
+// folderNode is a FolderEntry with its subfolders, as the tree is built.
+type folderNode struct {
+	FolderEntry
+	children []*folderNode
+}
+
+// flatten appends node and its subtree to entries depth-first.
+func (node *folderNode) flatten(entries []FolderEntry) []FolderEntry {
+	entries = append(entries, node.FolderEntry)
+	for _, child := range node.children {
+		entries = child.flatten(entries)
+	}
+	return entries
+}
+
+// folderTree lists a folder hierarchy depth-first.
+type folderTree struct {
+	srv          *drive.Service
+	p            *progress.Reporter
+	includeFiles bool
+	listed       int
+	truncated    bool
+}
+
+// expand lists node's children and descends depth more levels. It stops
+// quietly at the folder limit and returns the progress error on cancellation.
+func (t *folderTree) expand(ctx context.Context, node *folderNode, depth int) error {
+	if t.listed >= maxTreeFolders {
+		node.Unexpanded, t.truncated = true, true
+		return nil
+	}
+	if err := t.p.Next(); err != nil {
+		node.Unexpanded = true
+		return err
+	}
+	t.listed++
+
+	var pageToken string
+	for {
+		call := t.srv.Files.List().
+			Q(fmt.Sprintf("'%s' in parents and trashed = false", node.ID)).
+			Fields("nextPageToken, files(id, name, mimeType, size, modifiedTime, webViewLink)").
+			PageSize(1000).
+			OrderBy("folder,name").
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		result, err := call.Do()
+		if err != nil {
+			return err
+		}
+		for _, f := range result.Files {
+			if f.MimeType == folderMimeType {
+				node.children = append(node.children, &folderNode{FolderEntry: FolderEntry{
+					ID:         f.Id,
+					Name:       f.Name,
+					Path:       node.Path + "/" + f.Name,
+					Depth:      node.Depth + 1,
+					ParentID:   node.ID,
+					Unexpanded: true,
+				}})
+				continue
+			}
+			node.FileCount++
+			node.TotalSize += f.Size
+			if t.includeFiles {
+				node.Files = append(node.Files, fileToSummary(f))
+			}
+		}
+		if pageToken = result.NextPageToken; pageToken == "" {
+			break
+		}
+	}
+	node.FolderCount = len(node.children)
+	node.TotalFiles = node.FileCount
+	node.Unexpanded = false
+
+	for _, child := range node.children {
+		if depth > 0 {
+			if err := t.expand(ctx, child, depth-1); err != nil {
+				return err
+			}
+		}
+		node.TotalFiles += child.TotalFiles
+		node.TotalSize += child.TotalSize
+	}
+	return nil
+}
+
+func createGetFolderTreeHandler(factory *services.Factory) mcp.ToolHandlerFor[GetFolderTreeInput, GetFolderTreeOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetFolderTreeInput) (*mcp.CallToolResult, GetFolderTreeOutput, error) {
+		if input.MaxDepth <= 0 {
+			input.MaxDepth = 3
+		}
+		if input.MaxDepth > 10 {
+			return nil, GetFolderTreeOutput{}, fmt.Errorf("max_depth must be at most 10, got %d", input.MaxDepth)
+		}
+		folderID := input.FolderID
+		if folderID == "" {
+			folderID = "root"
+		}
+		if err := validate.DriveID(folderID); err != nil {
+			return nil, GetFolderTreeOutput{}, err
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, GetFolderTreeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		folder, err := srv.Files.Get(folderID).
+			Fields("id, name, mimeType").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, GetFolderTreeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		if folder.MimeType != folderMimeType {
+			return nil, GetFolderTreeOutput{}, fmt.Errorf("%q is a %s, not a folder", folder.Name, formatFileType(folder.MimeType))
+		}
+
+		root := &folderNode{FolderEntry: FolderEntry{ID: folder.Id, Name: folder.Name, Path: folder.Name}}
+		tree := &folderTree{
+			srv:          srv,
+			p:            progress.New(ctx, req).Begin("Listing folder", 0),
+			includeFiles: input.IncludeFiles,
+		}
+		if err := tree.expand(ctx, root, input.MaxDepth-1); err != nil && !tree.p.Cancelled() {
+			return nil, GetFolderTreeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		tree.p.Done()
+
+		out := GetFolderTreeOutput{
+			Folders:       root.flatten(nil),
+			TotalFiles:    root.TotalFiles,
+			TotalSize:     root.TotalSize,
+			FoldersListed: tree.listed,
+			Truncated:     tree.truncated,
+			Partial:       tree.p.Cancelled(),
+		}
+
+		rb := response.New()
+		rb.Header("Drive Folder Tree")
+		rb.KeyValue("Folder", fmt.Sprintf("%s (%s)", root.Name, root.ID))
+		rb.KeyValue("Files", root.TotalFiles)
+		rb.KeyValue("Size", formatSize(root.TotalSize))
+		rb.KeyValue("Folders listed", out.FoldersListed)
+		if out.Truncated {
+			rb.KeyValue("Truncated", fmt.Sprintf("stopped after %d folders; list deeper folders with folder_id", maxTreeFolders))
+		}
+		if out.Partial {
+			rb.KeyValue("Partial", tree.p.Partial())
+		}
+		rb.Blank()
+		for _, f := range out.Folders {
+			writeFolderEntry(rb, f)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// writeFolderEntry renders a folder and its files, indented by depth.
+func writeFolderEntry(rb *response.Builder, f FolderEntry) {
+	indent := strings.Repeat("  ", f.Depth)
+	if f.Unexpanded {
+		rb.Line("%s%s/ [%s] (not listed)", indent, f.Name, f.ID)
+		return
+	}
+	rb.Line("%s%s/ [%s] — %d files, %d folders, %s", indent, f.Name, f.ID, f.TotalFiles, f.FolderCount, formatSize(f.TotalSize))
+	for _, file := range f.Files {
+		rb.Line("%s  - %s (%s) [%s]", indent, file.Name, formatSize(file.Size), file.ID)
+	}
+}
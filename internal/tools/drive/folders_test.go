@@ -0,0 +1,117 @@
+package drive
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// fakeTree answers Drive files.get, files.list, and files.create from a
+// fixed hierarchy, honouring the "'<id>' in parents" and "name = " query terms.
+type fakeTree map[string][]map[string]any
+
+var (
+	parentRE = regexp.MustCompile(`'([^']+)' in parents`)
+	nameRE   = regexp.MustCompile(`name = '([^']+)'`)
+)
+
+func (f fakeTree) RoundTrip(r *http.Request) (*http.Response, error) {
+	var body any
+	switch {
+	case r.Method == http.MethodPost:
+		var meta map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&meta)
+		body = map[string]any{"id": "new-" + meta["name"].(string), "name": meta["name"]}
+	case strings.HasSuffix(r.URL.Path, "/files"):
+		q := r.URL.Query().Get("q")
+		files := []map[string]any{}
+		for _, it := range f[parentRE.FindStringSubmatch(q)[1]] {
+			if m := nameRE.FindStringSubmatch(q); m == nil || it["name"] == m[1] {
+				files = append(files, it)
+			}
+		}
+		body = map[string]any{"files": files}
+	default:
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		body = map[string]any{"id": id, "name": "Top", "mimeType": folderMimeType}
+	}
+	data, _ := json.Marshal(body)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(string(data))),
+		Request:    r,
+	}, nil
+}
+
+func TestCreateFolderPath(t *testing.T) {
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(fakeTree{"top": {{"id": "a", "name": "Projects", "mimeType": folderMimeType}}})
+
+	_, out, err := createCreateFolderHandler(factory)(context.Background(), &mcp.CallToolRequest{}, CreateFolderInput{
+		UserEmail:      "user@example.com",
+		Name:           "Projects/ 2026 /Q1/",
+		ParentFolderID: "top",
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.FolderID != "new-Q1" || out.Name != "Q1" {
+		t.Errorf("folder = %s %q, want new-Q1 Q1", out.FolderID, out.Name)
+	}
+	if strings.Join(out.Reused, "|") != "Projects" || strings.Join(out.Created, "|") != "Projects/2026|Projects/2026/Q1" {
+		t.Errorf("reused %q, created %q", out.Reused, out.Created)
+	}
+}
+
+func TestGetFolderTree(t *testing.T) {
+	folder := func(id string) map[string]any {
+		return map[string]any{"id": id, "name": id, "mimeType": folderMimeType}
+	}
+	file := func(id string, size int) map[string]any {
+		return map[string]any{"id": id, "name": id, "mimeType": "application/pdf", "size": strconv.Itoa(size)}
+	}
+	tree := fakeTree{
+		"top": {folder("a"), folder("b"), file("f1", 100)},
+		"a":   {folder("a1"), file("f2", 10), file("f3", 20)},
+		"a1":  {file("f4", 1)},
+		"b":   {},
+	}
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(tree)
+
+	_, out, err := createGetFolderTreeHandler(factory)(context.Background(), &mcp.CallToolRequest{}, GetFolderTreeInput{
+		UserEmail: "user@example.com",
+		FolderID:  "top",
+		MaxDepth:  2,
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	var paths []string
+	for _, f := range out.Folders {
+		paths = append(paths, f.Path)
+	}
+	if got := strings.Join(paths, "|"); got != "Top|Top/a|Top/a/a1|Top/b" {
+		t.Errorf("folders = %s, want depth-first Top|Top/a|Top/a/a1|Top/b", got)
+	}
+	root := out.Folders[0]
+	if out.FoldersListed != 3 || root.FolderCount != 2 || root.FileCount != 1 || out.TotalFiles != 3 || out.TotalSize != 130 {
+		t.Errorf("root = %+v, listed %d; want 3 folders listed, 3 files, 130 bytes", root, out.FoldersListed)
+	}
+	if a, a1 := out.Folders[1], out.Folders[2]; a.TotalFiles != 2 || a1.ParentID != "a" || a1.Depth != 2 || !a1.Unexpanded {
+		t.Errorf("a = %+v, a1 = %+v; want 2 files under a and a1 unexpanded beyond max_depth", a, a1)
+	}
+}
@@ -128,51 +128,9 @@ func createGetFileContentHandler(factory *services.Factory) mcp.ToolHandlerFor[G
 			return nil, GetFileContentOutput{}, middleware.HandleGoogleAPIError(err)
 		}
 
-		var content string
-
-		if isGoogleNativeType(file.MimeType) {
-			// Export Google native files
-			exportMime := mimeTypeForExport(file.MimeType)
-			if exportMime == "" {
-				return nil, GetFileContentOutput{}, fmt.Errorf("unsupported Google file type %q for text export", file.MimeType)
-			}
-			resp, err := srv.Files.Export(input.FileID, exportMime).Context(ctx).Download()
-			if err != nil {
-				return nil, GetFileContentOutput{}, middleware.HandleGoogleAPIError(err)
-			}
-			defer resp.Body.Close()
-			data, err := io.ReadAll(io.LimitReader(resp.Body, office.MaxFileSize))
-			if err != nil {
-				return nil, GetFileContentOutput{}, fmt.Errorf("reading exported content: %w", err)
-			}
-			content = string(data)
-		} else {
-			// Download binary files
-			resp, err := srv.Files.Get(input.FileID).
-				SupportsAllDrives(true).
-				Context(ctx).
-				Download()
-			if err != nil {
-				return nil, GetFileContentOutput{}, middleware.HandleGoogleAPIError(err)
-			}
-			defer resp.Body.Close()
-			data, err := io.ReadAll(io.LimitReader(resp.Body, office.MaxFileSize))
-			if err != nil {
-				return nil, GetFileContentOutput{}, fmt.Errorf("reading file content: %w", err)
-			}
-
-			// Try Office XML extraction
-			if isOfficeType(file.MimeType) {
-				extracted, extractErr := office.ExtractText(data, file.MimeType)
-				if extractErr == nil {
-					content = extracted
-				} else {
-					content = string(data)
-				}
-			} else {
-				// Try UTF-8 decode
-				content = string(data)
-			}
+		content, err := readFileText(ctx, srv, file)
+		if err != nil {
+			return nil, GetFileContentOutput{}, middleware.HandleGoogleAPIError(err)
 		}
 
 		rb := response.New()
@@ -187,6 +145,49 @@ func createGetFileContentHandler(factory *services.Factory) mcp.ToolHandlerFor[G
 	}
 }
 
+// readFileText returns the text content of a Drive file: Google native files
+// are exported, Office files have their text extracted, and anything else is
+// returned as raw bytes. Reads are capped at office.MaxFileSize.
+func readFileText(ctx context.Context, srv *drive.Service, file *drive.File) (string, error) {
+	if isGoogleNativeType(file.MimeType) {
+		exportMime := mimeTypeForExport(file.MimeType)
+		if exportMime == "" {
+			return "", fmt.Errorf("unsupported Google file type %q for text export", file.MimeType)
+		}
+		resp, err := srv.Files.Export(file.Id, exportMime).Context(ctx).Download()
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		data, err := io.ReadAll(io.LimitReader(resp.Body, office.MaxFileSize))
+		if err != nil {
+			return "", fmt.Errorf("reading exported content: %w", err)
+		}
+		return string(data), nil
+	}
+
+	resp, err := srv.Files.Get(file.Id).
+		SupportsAllDrives(true).
+		Context(ctx).
+		Download()
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, office.MaxFileSize))
+	if err != nil {
+		return "", fmt.Errorf("reading file content: %w", err)
+	}
+
+	// Try Office XML extraction, falling back to the raw bytes
+	if isOfficeType(file.MimeType) {
+		if extracted, extractErr := office.ExtractText(data, file.MimeType); extractErr == nil {
+			return extracted, nil
+		}
+	}
+	return string(data), nil
+}
+
 // --- get_drive_file_download_url ---
 
 type GetDownloadURLInput struct {
@@ -272,6 +273,9 @@ func createCreateFileHandler(factory *services.Factory) mcp.ToolHandlerFor[Creat
 		if input.FolderID != "" {
 			fileMetadata.Parents = []string{input.FolderID}
 		}
+		if stamp := factory.Provenance(req); stamp != nil {
+			fileMetadata.AppProperties = stamp.Properties()
+		}
 
 		var created *drive.File
 		if input.Content != "" {
@@ -348,6 +352,9 @@ func createImportToDocHandler(factory *services.Factory) mcp.ToolHandlerFor[Impo
 		}
 		// Note: if neither folder_id nor original parents are available,
 		// the file is created in the user's My Drive root by default.
+		if stamp := factory.Provenance(req); stamp != nil {
+			copiedFile.AppProperties = stamp.Properties()
+		}
 
 		created, err := srv.Files.Copy(input.FileID, copiedFile).
 			Fields("id, name, mimeType, webViewLink").
@@ -9,6 +9,7 @@ import (
 	"google.golang.org/api/drive/v3"
 
 	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/validate"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
@@ -111,6 +112,9 @@ func createCopyFileHandler(factory *services.Factory) mcp.ToolHandlerFor[CopyFil
 		if input.FolderID != "" {
 			copyFile.Parents = []string{input.FolderID}
 		}
+		if stamp := factory.Provenance(req); stamp != nil {
+			copyFile.AppProperties = stamp.Properties()
+		}
 
 		created, err := srv.Files.Copy(input.FileID, copyFile).
 			Fields("id, name, mimeType, webViewLink").
@@ -318,19 +322,15 @@ func createBatchShareHandler(factory *services.Factory) mcp.ToolHandlerFor[Batch
 		}
 
 		total := len(input.FileIDs)
-		shared := 0
+		shared, attempted := 0, 0
 		var errors []string
 
-		for i, fileID := range input.FileIDs {
-			// Report progress
-			if pt := req.Params.GetProgressToken(); pt != nil {
-				_ = req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
-					ProgressToken: pt,
-					Progress:      float64(i),
-					Total:         float64(total),
-					Message:       fmt.Sprintf("Sharing file %d/%d", i+1, total),
-				})
+		p := progress.New(ctx, req).Begin("Sharing file", total)
+		for _, fileID := range input.FileIDs {
+			if p.Next() != nil {
+				break
 			}
+			attempted++
 
 			_, err := srv.Permissions.Create(fileID, &drive.Permission{
 				Type:         "user",
@@ -345,6 +345,7 @@ func createBatchShareHandler(factory *services.Factory) mcp.ToolHandlerFor[Batch
 			}
 			shared++
 		}
+		p.Done()
 
 		rb := response.New()
 		rb.Header("Batch Share Complete")
@@ -352,6 +353,11 @@ func createBatchShareHandler(factory *services.Factory) mcp.ToolHandlerFor[Batch
 		rb.KeyValue("Role", input.Role)
 		rb.KeyValue("Successful", shared)
 		rb.KeyValue("Failed", len(errors))
+		if p.Cancelled() {
+			// A share interrupted mid-request may still have been applied.
+			rb.KeyValue("Not attempted", total-attempted)
+			rb.KeyValue("Partial", p.Partial())
+		}
 		if len(errors) > 0 {
 			rb.Blank()
 			rb.Section("Errors")
@@ -363,3 +369,200 @@ func createBatchShareHandler(factory *services.Factory) mcp.ToolHandlerFor[Batch
 		return rb.TextResult(), nil, nil
 	}
 }
+
+// --- search_drive_content (extended) ---
+
+// maxContentSearchResults bounds how many files search_drive_content downloads per call.
+const maxContentSearchResults = 10
+
+type SearchDriveContentInput struct {
+	UserEmail       string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Phrase          string `json:"phrase" jsonschema:"required" jsonschema_description:"Text to search for inside file contents"`
+	MaxResults      int    `json:"max_results,omitempty" jsonschema_description:"Maximum files to search and extract snippets from (default 5, max 10)"`
+	SnippetsPerFile int    `json:"snippets_per_file,omitempty" jsonschema_description:"Maximum snippets per file (default 2, max 5)"`
+	ContextChars    int    `json:"context_chars,omitempty" jsonschema_description:"Characters of context on each side of a match (default 120, max 400)"`
+	MimeType        string `json:"mime_type,omitempty" jsonschema_description:"Only search files of this MIME type (e.g. application/vnd.google-apps.document)"`
+	DriveID         string `json:"drive_id,omitempty" jsonschema_description:"ID of a shared drive to search within"`
+}
+
+// ContentMatch is one file returned by search_drive_content with its snippets.
+type ContentMatch struct {
+	File     FileSummary `json:"file"`
+	Snippets []string    `json:"snippets"`
+	Note     string      `json:"note,omitempty"`
+}
+
+type SearchDriveContentOutput struct {
+	Phrase  string         `json:"phrase"`
+	Query   string         `json:"query"`
+	Matches []ContentMatch `json:"matches"`
+	Partial bool           `json:"partial,omitempty"`
+}
+
+func createSearchDriveContentHandler(factory *services.Factory) mcp.ToolHandlerFor[SearchDriveContentInput, SearchDriveContentOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SearchDriveContentInput) (*mcp.CallToolResult, SearchDriveContentOutput, error) {
+		if strings.TrimSpace(input.Phrase) == "" {
+			return nil, SearchDriveContentOutput{}, fmt.Errorf("phrase must not be empty")
+		}
+		input.MaxResults = clampInt(input.MaxResults, 5, maxContentSearchResults)
+		input.SnippetsPerFile = clampInt(input.SnippetsPerFile, 2, 5)
+		input.ContextChars = clampInt(input.ContextChars, 120, 400)
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, SearchDriveContentOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		query := fmt.Sprintf("fullText contains '%s' and trashed = false", escapeQueryValue(input.Phrase))
+		if input.MimeType != "" {
+			query += fmt.Sprintf(" and mimeType = '%s'", escapeQueryValue(input.MimeType))
+		}
+		call := srv.Files.List().
+			Q(query).
+			PageSize(int64(input.MaxResults)).
+			Fields("files(id, name, mimeType, size, modifiedTime, webViewLink)").
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Context(ctx)
+		if input.DriveID != "" {
+			call = call.DriveId(input.DriveID).Corpora("drive")
+		}
+		result, err := call.Do()
+		if err != nil {
+			return nil, SearchDriveContentOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		matches := make([]ContentMatch, 0, len(result.Files))
+		p := progress.New(ctx, req).Begin("Scanning file", len(result.Files))
+		for _, f := range result.Files {
+			if p.Next() != nil {
+				break
+			}
+			m := contentMatch(ctx, srv, f, input)
+			if p.Cancelled() {
+				break // the download was interrupted; m only carries the error
+			}
+			matches = append(matches, m)
+		}
+		p.Done()
+
+		rb := response.New()
+		rb.Header("Drive Content Search")
+		rb.KeyValue("Phrase", input.Phrase)
+		rb.KeyValue("Files", len(matches))
+		if p.Cancelled() {
+			rb.KeyValue("Partial", p.Partial())
+		}
+		for _, m := range matches {
+			rb.Blank()
+			rb.Item("%s (%s)", m.File.Name, formatFileType(m.File.MimeType))
+			rb.Line("    ID: %s", m.File.ID)
+			if m.File.WebViewLink != "" {
+				rb.Line("    Link: %s", m.File.WebViewLink)
+			}
+			for _, s := range m.Snippets {
+				rb.Line("    > %s", s)
+			}
+			if m.Note != "" {
+				rb.Line("    (%s)", m.Note)
+			}
+		}
+
+		return rb.TextResult(), SearchDriveContentOutput{Phrase: input.Phrase, Query: query, Matches: matches, Partial: p.Cancelled()}, nil
+	}
+}
+
+// contentMatch downloads a search hit and extracts snippets around the phrase.
+// Per-file failures are reported in Note rather than failing the whole search.
+func contentMatch(ctx context.Context, srv *drive.Service, f *drive.File, input SearchDriveContentInput) ContentMatch {
+	m := ContentMatch{File: fileToSummary(f), Snippets: []string{}}
+	if !isTextExtractable(f.MimeType) {
+		m.Note = "content of this file type cannot be previewed"
+		return m
+	}
+	text, err := readFileText(ctx, srv, f)
+	if err != nil {
+		m.Note = fmt.Sprintf("could not read content: %v", middleware.HandleGoogleAPIError(err))
+		return m
+	}
+	m.Snippets = extractSnippets(text, input.Phrase, input.SnippetsPerFile, input.ContextChars)
+	if len(m.Snippets) == 0 {
+		m.Snippets = []string{}
+		m.Note = "matched by Drive's index but the phrase was not found in the extracted text"
+	}
+	return m
+}
+
+// clampInt returns def when v is zero or negative, and max when v exceeds it.
+func clampInt(v, def, max int) int {
+	if v <= 0 {
+		return def
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// --- watch_drive_file / unwatch_drive_file (extended) ---
+
+type WatchFileInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID    string `json:"file_id" jsonschema:"required" jsonschema_description:"The Google Drive file ID to watch"`
+}
+
+func createWatchFileHandler(factory *services.Factory, watcher *fileWatcher) mcp.ToolHandlerFor[WatchFileInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input WatchFileInput) (*mcp.CallToolResult, any, error) {
+		if req == nil || req.Session == nil {
+			return nil, nil, fmt.Errorf("watching a file requires an active MCP session")
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		file, err := srv.Files.Get(input.FileID).
+			Fields("id, name, mimeType, modifiedTime").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		if err := watcher.watch(ctx, srv, input.UserEmail, file, req.Session); err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Watching Drive File")
+		rb.KeyValue("File", file.Name)
+		rb.KeyValue("ID", file.Id)
+		rb.KeyValue("Last modified", file.ModifiedTime)
+		rb.KeyValue("Check interval", watchPollInterval)
+		rb.Blank()
+		rb.Line("Changes are sent to this session as MCP log notifications (logger %q, level notice).", watchLogger)
+		rb.Line("The client must enable logging (logging/setLevel) to receive them. Watches end when the session closes.")
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+func createUnwatchFileHandler(watcher *fileWatcher) mcp.ToolHandlerFor[WatchFileInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input WatchFileInput) (*mcp.CallToolResult, any, error) {
+		if req == nil || req.Session == nil {
+			return nil, nil, fmt.Errorf("unwatching a file requires an active MCP session")
+		}
+
+		rb := response.New()
+		if watcher.unwatch(input.UserEmail, input.FileID, req.Session) {
+			rb.Header("Stopped Watching Drive File")
+		} else {
+			rb.Header("Drive File Was Not Watched")
+		}
+		rb.KeyValue("ID", input.FileID)
+
+		return rb.TextResult(), nil, nil
+	}
+}
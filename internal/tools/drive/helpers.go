@@ -3,6 +3,7 @@ package drive
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"google.golang.org/api/drive/v3"
 
@@ -55,6 +56,8 @@ func formatFileType(mimeType string) string {
 		return "Folder"
 	case "application/vnd.google-apps.form":
 		return "Google Form"
+	case "application/vnd.google-apps.shortcut":
+		return "Shortcut"
 	case "application/pdf":
 		return "PDF"
 	default:
@@ -143,3 +146,97 @@ func isGoogleNativeType(mimeType string) bool {
 func isOfficeType(mimeType string) bool {
 	return office.IsOfficeType(mimeType)
 }
+
+// escapeQueryValue escapes a value for use inside a single-quoted Drive query string.
+func escapeQueryValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
+
+// isTextExtractable reports whether readFileText can produce meaningful text for the MIME type.
+func isTextExtractable(mimeType string) bool {
+	if isGoogleNativeType(mimeType) {
+		return mimeTypeForExport(mimeType) != "" && mimeType != "application/vnd.google-apps.drawing"
+	}
+	return isOfficeType(mimeType) ||
+		strings.HasPrefix(mimeType, "text/") ||
+		mimeType == "application/json" ||
+		mimeType == "application/xml"
+}
+
+// extractSnippets returns up to max snippets of text around case-insensitive
+// occurrences of phrase, each with about contextChars characters on either
+// side. Whitespace is collapsed before matching. When the exact phrase is absent (Drive's
+// full-text search also matches on individual terms), the individual terms
+// are tried instead.
+func extractSnippets(text, phrase string, max, contextChars int) []string {
+	text = strings.Join(strings.Fields(text), " ")
+	phrase = strings.Join(strings.Fields(phrase), " ")
+	runes := []rune(text)
+	lower := lowerRunes(text)
+
+	terms := []string{phrase}
+	if fields := strings.Fields(phrase); len(fields) > 1 {
+		terms = append(terms, fields...)
+	}
+	for i, term := range terms {
+		needle := lowerRunes(term)
+		if i > 0 && len(needle) < 3 {
+			continue // skip short individual terms like "a" or "of"
+		}
+		if snippets := snippetsFor(runes, lower, needle, max, contextChars); len(snippets) > 0 {
+			return snippets
+		}
+	}
+	return nil
+}
+
+// lowerRunes lowercases s rune by rune so indexes line up with []rune(s).
+func lowerRunes(s string) []rune {
+	out := []rune(s)
+	for i, r := range out {
+		out[i] = unicode.ToLower(r)
+	}
+	return out
+}
+
+// snippetsFor finds non-overlapping occurrences of needle in lower and cuts
+// the matching windows out of runes.
+func snippetsFor(runes, lower, needle []rune, max, contextChars int) []string {
+	if len(needle) == 0 {
+		return nil
+	}
+	var out []string
+	lastEnd := 0
+	for i := 0; i+len(needle) <= len(lower) && len(out) < max; i++ {
+		if i < lastEnd || !runesEqual(lower[i:i+len(needle)], needle) {
+			continue
+		}
+		start := i - contextChars
+		if start < 0 {
+			start = 0
+		}
+		end := i + len(needle) + contextChars
+		if end > len(runes) {
+			end = len(runes)
+		}
+		snippet := strings.TrimSpace(string(runes[start:end]))
+		if start > 0 {
+			snippet = "…" + snippet
+		}
+		if end < len(runes) {
+			snippet += "…"
+		}
+		out = append(out, snippet)
+		lastEnd = end
+	}
+	return out
+}
+
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
@@ -15,6 +15,7 @@ func TestFormatFileType(t *testing.T) {
 		{"application/vnd.google-apps.spreadsheet", "Google Sheet"},
 		{"application/vnd.google-apps.presentation", "Google Slides"},
 		{"application/vnd.google-apps.folder", "Folder"},
+		{"application/vnd.google-apps.shortcut", "Shortcut"},
 		{"application/pdf", "PDF"},
 		{"image/png", "Image"},
 		{"video/mp4", "Video"},
@@ -115,3 +116,81 @@ func TestMimeTypeForExport(t *testing.T) {
 		t.Errorf("got %q, want empty for non-google type", got)
 	}
 }
+
+func TestEscapeQueryValue(t *testing.T) {
+	got := escapeQueryValue(`O'Brien \ contract`)
+	want := `O\'Brien \\ contract`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestExtractSnippets(t *testing.T) {
+	text := "Intro paragraph.\n\nThe Master Services   Agreement is renewed annually. " +
+		"Termination requires notice. See the master services agreement appendix."
+
+	tests := []struct {
+		name   string
+		phrase string
+		max    int
+		want   []string
+	}{
+		{
+			name:   "case-insensitive with context and collapsed whitespace",
+			phrase: "services agreement",
+			max:    1,
+			want:   []string{"…ster Services Agreement is r…"},
+		},
+		{
+			name:   "multiple matches",
+			phrase: "master",
+			max:    5,
+			want:   []string{"…The Master Serv…", "…the master serv…"},
+		},
+		{
+			name:   "falls back to individual terms",
+			phrase: "termination clause",
+			max:    1,
+			want:   []string{"…lly. Termination requ…"},
+		},
+		{
+			name:   "no match",
+			phrase: "indemnity",
+			max:    2,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractSnippets(text, tt.phrase, tt.max, 5)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d snippets %q, want %d", len(got), got, len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("snippet %d: got %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIsTextExtractable(t *testing.T) {
+	tests := []struct {
+		mime string
+		want bool
+	}{
+		{"application/vnd.google-apps.document", true},
+		{"application/vnd.google-apps.drawing", false},
+		{"application/vnd.google-apps.folder", false},
+		{"text/markdown", true},
+		{"application/pdf", false},
+		{"image/png", false},
+	}
+	for _, tt := range tests {
+		if got := isTextExtractable(tt.mime); got != tt.want {
+			t.Errorf("isTextExtractable(%q) = %v, want %v", tt.mime, got, tt.want)
+		}
+	}
+}
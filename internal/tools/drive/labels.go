@@ -0,0 +1,440 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/drivelabels/v2"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// LabelSummary is a compact representation of a Drive label taxonomy.
+type LabelSummary struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description,omitempty"`
+	Type        string              `json:"type"`
+	Fields      []LabelFieldSummary `json:"fields,omitempty"`
+}
+
+// LabelFieldSummary describes one field of a label.
+type LabelFieldSummary struct {
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Type     string        `json:"type"`
+	Required bool          `json:"required,omitempty"`
+	Choices  []LabelChoice `json:"choices,omitempty"`
+}
+
+// LabelChoice is one option of a selection field.
+type LabelChoice struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AppliedLabel is a label on a file with its field values.
+type AppliedLabel struct {
+	ID     string              `json:"id"`
+	Title  string              `json:"title,omitempty"`
+	Fields map[string][]string `json:"fields,omitempty"`
+}
+
+func labelToSummary(l *drivelabels.GoogleAppsDriveLabelsV2Label) LabelSummary {
+	ls := LabelSummary{ID: l.Id, Type: l.LabelType}
+	if l.Properties != nil {
+		ls.Title, ls.Description = l.Properties.Title, l.Properties.Description
+	}
+	for _, f := range l.Fields {
+		fs := LabelFieldSummary{ID: f.Id, Name: fieldName(f), Type: fieldType(f)}
+		if f.Properties != nil {
+			fs.Required = f.Properties.Required
+		}
+		if f.SelectionOptions != nil {
+			for _, c := range f.SelectionOptions.Choices {
+				fs.Choices = append(fs.Choices, LabelChoice{ID: c.Id, Name: choiceName(c)})
+			}
+		}
+		ls.Fields = append(ls.Fields, fs)
+	}
+	return ls
+}
+
+func fieldName(f *drivelabels.GoogleAppsDriveLabelsV2Field) string {
+	if f.Properties != nil && f.Properties.DisplayName != "" {
+		return f.Properties.DisplayName
+	}
+	return f.Id
+}
+
+func choiceName(c *drivelabels.GoogleAppsDriveLabelsV2FieldSelectionOptionsChoice) string {
+	if c.Properties != nil && c.Properties.DisplayName != "" {
+		return c.Properties.DisplayName
+	}
+	return c.Id
+}
+
+// fieldType returns the value type of a label field.
+func fieldType(f *drivelabels.GoogleAppsDriveLabelsV2Field) string {
+	switch {
+	case f.TextOptions != nil:
+		return "text"
+	case f.IntegerOptions != nil:
+		return "integer"
+	case f.DateOptions != nil:
+		return "date"
+	case f.SelectionOptions != nil:
+		return "selection"
+	case f.UserOptions != nil:
+		return "user"
+	}
+	return "unknown"
+}
+
+// findField returns the label field whose ID or display name matches key.
+func findField(l *drivelabels.GoogleAppsDriveLabelsV2Label, key string) (*drivelabels.GoogleAppsDriveLabelsV2Field, error) {
+	for _, f := range l.Fields {
+		if f.Id == key || strings.EqualFold(fieldName(f), key) {
+			return f, nil
+		}
+	}
+	names := make([]string, 0, len(l.Fields))
+	for _, f := range l.Fields {
+		names = append(names, fieldName(f))
+	}
+	return nil, fmt.Errorf("label has no field %q (fields: %s)", key, strings.Join(names, ", "))
+}
+
+// fieldModification converts user-supplied values to a modification of
+// field f. No values unsets the field. Selection values may be choice IDs
+// or display names; dates are YYYY-MM-DD; users are email addresses.
+func fieldModification(f *drivelabels.GoogleAppsDriveLabelsV2Field, values []string) (*drive.LabelFieldModification, error) {
+	mod := &drive.LabelFieldModification{FieldId: f.Id}
+	if len(values) == 0 {
+		mod.UnsetValues = true
+		return mod, nil
+	}
+	switch fieldType(f) {
+	case "text":
+		mod.SetTextValues = values
+	case "integer":
+		for _, v := range values {
+			n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("field %q expects integers, got %q", fieldName(f), v)
+			}
+			mod.SetIntegerValues = append(mod.SetIntegerValues, n)
+		}
+	case "date":
+		for _, v := range values {
+			if _, err := time.Parse(time.DateOnly, v); err != nil {
+				return nil, fmt.Errorf("field %q expects dates as YYYY-MM-DD, got %q", fieldName(f), v)
+			}
+			mod.SetDateValues = append(mod.SetDateValues, v)
+		}
+	case "selection":
+		for _, v := range values {
+			id, err := choiceID(f, v)
+			if err != nil {
+				return nil, err
+			}
+			mod.SetSelectionValues = append(mod.SetSelectionValues, id)
+		}
+	case "user":
+		mod.SetUserValues = values
+	default:
+		return nil, fmt.Errorf("field %q has an unsupported type", fieldName(f))
+	}
+	return mod, nil
+}
+
+// choiceID resolves a selection value given as a choice ID or display name.
+func choiceID(f *drivelabels.GoogleAppsDriveLabelsV2Field, value string) (string, error) {
+	var names []string
+	for _, c := range f.SelectionOptions.Choices {
+		if c.Id == value || strings.EqualFold(choiceName(c), value) {
+			return c.Id, nil
+		}
+		names = append(names, choiceName(c))
+	}
+	return "", fmt.Errorf("field %q has no choice %q (choices: %s)", fieldName(f), value, strings.Join(names, ", "))
+}
+
+// appliedLabel renders a file's label, naming fields and choices from the
+// label definition when one is available.
+func appliedLabel(l *drive.Label, def *drivelabels.GoogleAppsDriveLabelsV2Label) AppliedLabel {
+	al := AppliedLabel{ID: l.Id, Fields: make(map[string][]string)}
+	defs := make(map[string]*drivelabels.GoogleAppsDriveLabelsV2Field)
+	if def != nil {
+		if def.Properties != nil {
+			al.Title = def.Properties.Title
+		}
+		for _, f := range def.Fields {
+			defs[f.Id] = f
+		}
+	}
+	for id, v := range l.Fields {
+		name := id
+		f := defs[id]
+		if f != nil {
+			name = fieldName(f)
+		}
+		var values []string
+		values = append(values, v.Text...)
+		values = append(values, v.DateString...)
+		for _, n := range v.Integer {
+			values = append(values, strconv.FormatInt(n, 10))
+		}
+		for _, s := range v.Selection {
+			if f != nil && f.SelectionOptions != nil {
+				for _, c := range f.SelectionOptions.Choices {
+					if c.Id == s {
+						s = choiceName(c)
+						break
+					}
+				}
+			}
+			values = append(values, s)
+		}
+		for _, u := range v.User {
+			values = append(values, u.EmailAddress)
+		}
+		al.Fields[name] = values
+	}
+	return al
+}
+
+// writeLabelFields writes an applied label's fields in name order.
+func writeLabelFields(rb *response.Builder, al AppliedLabel) {
+	names := make([]string, 0, len(al.Fields))
+	for name := range al.Fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		rb.Line("    %s: %s", name, strings.Join(al.Fields[name], ", "))
+	}
+}
+
+// --- list_drive_labels (extended) ---
+
+type ListLabelsInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	PageSize  int    `json:"page_size,omitempty" jsonschema_description:"Maximum labels to return (default 50, max 200)"`
+	PageToken string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type ListLabelsOutput struct {
+	Labels        []LabelSummary `json:"labels"`
+	NextPageToken string         `json:"next_page_token,omitempty"`
+}
+
+func createListLabelsHandler(factory *services.Factory) mcp.ToolHandlerFor[ListLabelsInput, ListLabelsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListLabelsInput) (*mcp.CallToolResult, ListLabelsOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 50
+		}
+
+		srv, err := factory.DriveLabels(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Labels.List().
+			PublishedOnly(true).
+			View("LABEL_VIEW_FULL").
+			PageSize(int64(input.PageSize)).
+			Context(ctx)
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		labels := make([]LabelSummary, 0, len(result.Labels))
+		rb := response.New()
+		rb.Header("Drive Labels")
+		rb.KeyValue("Count", len(result.Labels))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+		for _, l := range result.Labels {
+			ls := labelToSummary(l)
+			labels = append(labels, ls)
+			rb.Item("%s (ID: %s) [%s]", ls.Title, ls.ID, ls.Type)
+			for _, f := range ls.Fields {
+				required := ""
+				if f.Required {
+					required = ", required"
+				}
+				rb.Line("    %s (ID: %s) — %s%s", f.Name, f.ID, f.Type, required)
+				for _, c := range f.Choices {
+					rb.Line("        %s (ID: %s)", c.Name, c.ID)
+				}
+			}
+		}
+
+		return rb.TextResult(), ListLabelsOutput{Labels: labels, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// --- get_drive_file_labels (extended) ---
+
+type GetFileLabelsInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID    string `json:"file_id" jsonschema:"required" jsonschema_description:"The Drive file ID"`
+}
+
+type GetFileLabelsOutput struct {
+	FileID string         `json:"file_id"`
+	Labels []AppliedLabel `json:"labels"`
+}
+
+func createGetFileLabelsHandler(factory *services.Factory) mcp.ToolHandlerFor[GetFileLabelsInput, GetFileLabelsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetFileLabelsInput) (*mcp.CallToolResult, GetFileLabelsOutput, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, GetFileLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		result, err := srv.Files.ListLabels(input.FileID).Context(ctx).Do()
+		if err != nil {
+			return nil, GetFileLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		// Label definitions only add names, so a failed lookup still shows IDs.
+		labelSrv, labelErr := factory.DriveLabels(ctx, input.UserEmail)
+
+		out := GetFileLabelsOutput{FileID: input.FileID, Labels: make([]AppliedLabel, 0, len(result.Labels))}
+		rb := response.New()
+		rb.Header("Drive File Labels")
+		rb.KeyValue("File ID", input.FileID)
+		rb.KeyValue("Labels", len(result.Labels))
+		rb.Blank()
+		for _, l := range result.Labels {
+			var def *drivelabels.GoogleAppsDriveLabelsV2Label
+			if labelErr == nil {
+				def, _ = labelSrv.Labels.Get("labels/" + l.Id).View("LABEL_VIEW_FULL").Context(ctx).Do()
+			}
+			al := appliedLabel(l, def)
+			out.Labels = append(out.Labels, al)
+			if al.Title != "" {
+				rb.Item("%s (ID: %s)", al.Title, al.ID)
+			} else {
+				rb.Item("%s", al.ID)
+			}
+			writeLabelFields(rb, al)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- apply_drive_label (extended) ---
+
+// LabelFieldInput sets one label field.
+type LabelFieldInput struct {
+	Field  string   `json:"field" jsonschema:"required" jsonschema_description:"Field ID or display name"`
+	Values []string `json:"values,omitempty" jsonschema_description:"New values: text, integers, dates (YYYY-MM-DD), choice IDs or names, or user emails. Empty clears the field"`
+}
+
+type ApplyLabelInput struct {
+	UserEmail string            `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID    string            `json:"file_id" jsonschema:"required" jsonschema_description:"The Drive file ID"`
+	LabelID   string            `json:"label_id" jsonschema:"required" jsonschema_description:"Label ID from list_drive_labels"`
+	Fields    []LabelFieldInput `json:"fields,omitempty" jsonschema_description:"Field values to set; other fields are left unchanged"`
+}
+
+func createApplyLabelHandler(factory *services.Factory) mcp.ToolHandlerFor[ApplyLabelInput, GetFileLabelsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ApplyLabelInput) (*mcp.CallToolResult, GetFileLabelsOutput, error) {
+		labelSrv, err := factory.DriveLabels(ctx, input.UserEmail)
+		if err != nil {
+			return nil, GetFileLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		def, err := labelSrv.Labels.Get("labels/" + input.LabelID).View("LABEL_VIEW_FULL").Context(ctx).Do()
+		if err != nil {
+			return nil, GetFileLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		mod := &drive.LabelModification{LabelId: input.LabelID}
+		for _, fi := range input.Fields {
+			f, err := findField(def, fi.Field)
+			if err != nil {
+				return nil, GetFileLabelsOutput{}, err
+			}
+			fm, err := fieldModification(f, fi.Values)
+			if err != nil {
+				return nil, GetFileLabelsOutput{}, err
+			}
+			mod.FieldModifications = append(mod.FieldModifications, fm)
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, GetFileLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		result, err := srv.Files.ModifyLabels(input.FileID, &drive.ModifyLabelsRequest{
+			LabelModifications: []*drive.LabelModification{mod},
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, GetFileLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := GetFileLabelsOutput{FileID: input.FileID, Labels: make([]AppliedLabel, 0, len(result.ModifiedLabels))}
+		rb := response.New()
+		rb.Header("Drive Label Applied")
+		rb.KeyValue("File ID", input.FileID)
+		for _, l := range result.ModifiedLabels {
+			al := appliedLabel(l, def)
+			out.Labels = append(out.Labels, al)
+			rb.KeyValue("Label", fmt.Sprintf("%s (ID: %s)", al.Title, al.ID))
+			writeLabelFields(rb, al)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- remove_drive_label (extended) ---
+
+type RemoveLabelInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID    string `json:"file_id" jsonschema:"required" jsonschema_description:"The Drive file ID"`
+	LabelID   string `json:"label_id" jsonschema:"required" jsonschema_description:"Label ID to remove"`
+}
+
+func createRemoveLabelHandler(factory *services.Factory) mcp.ToolHandlerFor[RemoveLabelInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input RemoveLabelInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		_, err = srv.Files.ModifyLabels(input.FileID, &drive.ModifyLabelsRequest{
+			LabelModifications: []*drive.LabelModification{{LabelId: input.LabelID, RemoveLabel: true}},
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Drive Label Removed")
+		rb.KeyValue("File ID", input.FileID)
+		rb.KeyValue("Label ID", input.LabelID)
+		return rb.TextResult(), nil, nil
+	}
+}
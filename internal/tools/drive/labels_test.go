@@ -0,0 +1,89 @@
+package drive
+
+import (
+	"slices"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/drivelabels/v2"
+)
+
+func testLabel() *drivelabels.GoogleAppsDriveLabelsV2Label {
+	return &drivelabels.GoogleAppsDriveLabelsV2Label{
+		Id:         "lbl1",
+		Properties: &drivelabels.GoogleAppsDriveLabelsV2LabelProperties{Title: "Classification"},
+		Fields: []*drivelabels.GoogleAppsDriveLabelsV2Field{
+			{
+				Id:         "sens",
+				Properties: &drivelabels.GoogleAppsDriveLabelsV2FieldProperties{DisplayName: "Sensitivity"},
+				SelectionOptions: &drivelabels.GoogleAppsDriveLabelsV2FieldSelectionOptions{
+					Choices: []*drivelabels.GoogleAppsDriveLabelsV2FieldSelectionOptionsChoice{
+						{Id: "c1", Properties: &drivelabels.GoogleAppsDriveLabelsV2FieldSelectionOptionsChoiceProperties{DisplayName: "Public"}},
+						{Id: "c2", Properties: &drivelabels.GoogleAppsDriveLabelsV2FieldSelectionOptionsChoiceProperties{DisplayName: "Confidential"}},
+					},
+				},
+			},
+			{Id: "score", IntegerOptions: &drivelabels.GoogleAppsDriveLabelsV2FieldIntegerOptions{}},
+			{Id: "review", DateOptions: &drivelabels.GoogleAppsDriveLabelsV2FieldDateOptions{}},
+		},
+	}
+}
+
+func TestFieldModification(t *testing.T) {
+	label := testLabel()
+	tests := []struct {
+		name    string
+		field   string
+		values  []string
+		check   func(*drive.LabelFieldModification) bool
+		wantErr bool
+	}{
+		{"selection by name", "sensitivity", []string{"confidential"}, func(m *drive.LabelFieldModification) bool {
+			return m.FieldId == "sens" && slices.Equal(m.SetSelectionValues, []string{"c2"})
+		}, false},
+		{"selection by id", "sens", []string{"c1"}, func(m *drive.LabelFieldModification) bool {
+			return slices.Equal(m.SetSelectionValues, []string{"c1"})
+		}, false},
+		{"unknown choice", "sens", []string{"secret"}, nil, true},
+		{"integer", "score", []string{" 7 "}, func(m *drive.LabelFieldModification) bool {
+			return len(m.SetIntegerValues) == 1 && m.SetIntegerValues[0] == 7
+		}, false},
+		{"bad integer", "score", []string{"high"}, nil, true},
+		{"date", "review", []string{"2026-09-30"}, func(m *drive.LabelFieldModification) bool {
+			return slices.Equal(m.SetDateValues, []string{"2026-09-30"})
+		}, false},
+		{"bad date", "review", []string{"30/09/2026"}, nil, true},
+		{"unset", "score", nil, func(m *drive.LabelFieldModification) bool { return m.UnsetValues }, false},
+		{"unknown field", "owner", []string{"x"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := findField(label, tt.field)
+			var mod *drive.LabelFieldModification
+			if err == nil {
+				mod, err = fieldModification(f, tt.values)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil && !tt.check(mod) {
+				t.Errorf("unexpected modification %+v", mod)
+			}
+		})
+	}
+}
+
+func TestAppliedLabel(t *testing.T) {
+	l := &drive.Label{Id: "lbl1", Fields: map[string]drive.LabelField{
+		"sens":  {Selection: []string{"c2"}},
+		"score": {Integer: []int64{7}},
+	}}
+	al := appliedLabel(l, testLabel())
+	if al.Title != "Classification" || !slices.Equal(al.Fields["Sensitivity"], []string{"Confidential"}) || !slices.Equal(al.Fields["score"], []string{"7"}) {
+		t.Errorf("appliedLabel() = %+v", al)
+	}
+	bare := appliedLabel(l, nil)
+	if !slices.Equal(bare.Fields["sens"], []string{"c2"}) {
+		t.Errorf("appliedLabel() without definition = %+v", bare)
+	}
+}
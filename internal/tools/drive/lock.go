@@ -0,0 +1,99 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- lock_drive_file (extended) ---
+
+type LockFileInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID    string `json:"file_id" jsonschema:"required" jsonschema_description:"The file ID"`
+	Reason    string `json:"reason,omitempty" jsonschema_description:"Why the file is locked, shown to people who open it (e.g. Approved by legal on 2026-03-01)"`
+	OwnerOnly bool   `json:"owner_only,omitempty" jsonschema_description:"Only the file's owners (or shared drive organizers) can unlock it, not every editor"`
+	Unlock    bool   `json:"unlock,omitempty" jsonschema_description:"Remove the lock instead"`
+}
+
+type LockFileOutput struct {
+	FileID    string `json:"file_id"`
+	Name      string `json:"name"`
+	Locked    bool   `json:"locked"`
+	Reason    string `json:"reason,omitempty"`
+	OwnerOnly bool   `json:"owner_only,omitempty"`
+	LockedBy  string `json:"locked_by,omitempty"`
+	LockedAt  string `json:"locked_at,omitempty"`
+}
+
+func createLockFileHandler(factory *services.Factory) mcp.ToolHandlerFor[LockFileInput, LockFileOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input LockFileInput) (*mcp.CallToolResult, LockFileOutput, error) {
+		if input.Unlock && (input.Reason != "" || input.OwnerOnly) {
+			return nil, LockFileOutput{}, fmt.Errorf("reason and owner_only apply only when locking")
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, LockFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		// ReadOnly must be sent even when false, or unlock is a no-op.
+		restriction := &drive.ContentRestriction{
+			ReadOnly:        !input.Unlock,
+			Reason:          input.Reason,
+			OwnerRestricted: input.OwnerOnly,
+			ForceSendFields: []string{"ReadOnly"},
+		}
+		updated, err := srv.Files.Update(input.FileID, &drive.File{
+			ContentRestrictions: []*drive.ContentRestriction{restriction},
+		}).
+			Fields("id, name, contentRestrictions").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, LockFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := LockFileOutput{FileID: updated.Id, Name: updated.Name}
+		for _, r := range updated.ContentRestrictions {
+			if !r.ReadOnly {
+				continue
+			}
+			out.Locked, out.Reason, out.OwnerOnly, out.LockedAt = true, r.Reason, r.OwnerRestricted, r.RestrictionTime
+			if r.RestrictingUser != nil {
+				out.LockedBy = r.RestrictingUser.EmailAddress
+			}
+		}
+
+		rb := response.New()
+		if out.Locked {
+			rb.Header("Drive File Locked")
+		} else {
+			rb.Header("Drive File Unlocked")
+		}
+		rb.KeyValue("Name", out.Name)
+		rb.KeyValue("ID", out.FileID)
+		if out.Locked {
+			if out.Reason != "" {
+				rb.KeyValue("Reason", out.Reason)
+			}
+			if out.LockedBy != "" {
+				rb.KeyValue("Locked by", out.LockedBy)
+			}
+			if out.OwnerOnly {
+				rb.Line("Only the file's owners can unlock it.")
+			} else {
+				rb.Line("Anyone who can edit the file can unlock it with unlock=true.")
+			}
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
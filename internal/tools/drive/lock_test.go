@@ -0,0 +1,56 @@
+package drive
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestLockFile(t *testing.T) {
+	rec := &recordBody{reply: `{"id":"f1","name":"Contract","contentRestrictions":[{"readOnly":true,"reason":"Signed","ownerRestricted":true,"restrictingUser":{"emailAddress":"user@example.com"}}]}`}
+	_, out, err := createLockFileHandler(fakeDriveFactory(rec))(context.Background(), &mcp.CallToolRequest{}, LockFileInput{
+		UserEmail: "user@example.com",
+		FileID:    "f1",
+		Reason:    "Signed",
+		OwnerOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if strings.TrimSpace(rec.body) != `{"contentRestrictions":[{"ownerRestricted":true,"readOnly":true,"reason":"Signed"}]}` {
+		t.Errorf("request body = %s", rec.body)
+	}
+	if !out.Locked || !out.OwnerOnly || out.Reason != "Signed" || out.LockedBy != "user@example.com" {
+		t.Errorf("output = %+v", out)
+	}
+}
+
+func TestUnlockFileSendsFalse(t *testing.T) {
+	rec := &recordBody{reply: `{"id":"f1","name":"Contract","contentRestrictions":[{"readOnly":false}]}`}
+	_, out, err := createLockFileHandler(fakeDriveFactory(rec))(context.Background(), &mcp.CallToolRequest{}, LockFileInput{
+		UserEmail: "user@example.com",
+		FileID:    "f1",
+		Unlock:    true,
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if !strings.Contains(rec.body, `"readOnly":false`) {
+		t.Errorf("request body = %s, want readOnly:false sent explicitly", rec.body)
+	}
+	if out.Locked {
+		t.Errorf("output = %+v", out)
+	}
+
+	_, _, err = createLockFileHandler(fakeDriveFactory(rec))(context.Background(), &mcp.CallToolRequest{}, LockFileInput{
+		UserEmail: "user@example.com",
+		FileID:    "f1",
+		Unlock:    true,
+		Reason:    "done",
+	})
+	if err == nil || !strings.Contains(err.Error(), "only when locking") {
+		t.Errorf("unlock with reason error = %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/office"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/rollback"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// ocrSupported reports whether Drive can OCR a file of this type when
+// converting it to a Google Doc.
+func ocrSupported(mimeType string) bool {
+	return mimeType == "application/pdf" || strings.HasPrefix(mimeType, "image/")
+}
+
+// --- ocr_drive_file (extended) ---
+
+type OCRFileInput struct {
+	UserEmail   string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID      string `json:"file_id" jsonschema:"required" jsonschema_description:"ID of the image or PDF to read"`
+	OCRLanguage string `json:"ocr_language,omitempty" jsonschema_description:"ISO 639-1 language hint for the text, e.g. en or de (default: detected by Drive)"`
+	KeepDoc     bool   `json:"keep_doc,omitempty" jsonschema_description:"Keep the Google Doc Drive creates from the file (default: delete it once the text is extracted)"`
+	Title       string `json:"title,omitempty" jsonschema_description:"Title of the kept Google Doc (default: the file's name); needs keep_doc=true"`
+	FolderID    string `json:"folder_id,omitempty" jsonschema_description:"Folder for the kept Google Doc (default: My Drive root); needs keep_doc=true"`
+}
+
+type OCRFileOutput struct {
+	SourceName string `json:"source_name"`
+	Text       string `json:"text"`
+	DocID      string `json:"doc_id,omitempty"`
+	DocLink    string `json:"doc_link,omitempty"`
+	// CleanupError is set when the temporary Google Doc could not be
+	// deleted; DocID then names the leftover file.
+	CleanupError string `json:"cleanup_error,omitempty"`
+}
+
+func createOCRFileHandler(factory *services.Factory) mcp.ToolHandlerFor[OCRFileInput, OCRFileOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input OCRFileInput) (*mcp.CallToolResult, OCRFileOutput, error) {
+		if !input.KeepDoc && (input.Title != "" || input.FolderID != "") {
+			return nil, OCRFileOutput{}, fmt.Errorf("title and folder_id apply only with keep_doc=true")
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, OCRFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		source, err := srv.Files.Get(input.FileID).
+			Fields("id, name, mimeType").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, OCRFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		if !ocrSupported(source.MimeType) {
+			return nil, OCRFileOutput{}, fmt.Errorf("%q is a %s — OCR needs an image or PDF; use get_drive_file_content for other files", source.Name, formatFileType(source.MimeType))
+		}
+
+		doc := &drive.File{Name: input.Title, MimeType: "application/vnd.google-apps.document"}
+		if doc.Name == "" {
+			doc.Name = source.Name
+		}
+		if input.FolderID != "" {
+			doc.Parents = []string{input.FolderID}
+		}
+		if stamp := factory.Provenance(req); stamp != nil {
+			doc.AppProperties = stamp.Properties()
+		}
+		call := srv.Files.Copy(source.Id, doc).
+			Fields("id, webViewLink").
+			SupportsAllDrives(true).
+			Context(ctx)
+		if input.OCRLanguage != "" {
+			call = call.OcrLanguage(input.OCRLanguage)
+		}
+		created, err := call.Do()
+		if err != nil {
+			return nil, OCRFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		// The converted Doc is scratch space unless keep_doc is set; delete
+		// rather than trash it so OCR calls do not fill the trash.
+		var tx rollback.Tx
+		tx.Record("Google Doc "+created.Id, func(ctx context.Context) error {
+			return srv.Files.Delete(created.Id).SupportsAllDrives(true).Context(ctx).Do()
+		})
+		text, err := exportPlainText(ctx, srv, created.Id)
+		if err != nil {
+			return nil, OCRFileOutput{}, tx.Fail(ctx, middleware.HandleGoogleAPIError(err))
+		}
+
+		out := OCRFileOutput{SourceName: source.Name, Text: text}
+		if input.KeepDoc {
+			tx.Commit()
+			out.DocID, out.DocLink = created.Id, created.WebViewLink
+		} else if err := tx.Rollback(ctx); err != nil {
+			out.DocID, out.CleanupError = created.Id, err.Error()
+		}
+
+		rb := response.New()
+		rb.Header("OCR Text")
+		rb.KeyValue("Source", source.Name)
+		if input.KeepDoc {
+			rb.KeyValue("Google Doc ID", out.DocID)
+			if out.DocLink != "" {
+				rb.KeyValue("Link", out.DocLink)
+			}
+		}
+		if out.CleanupError != "" {
+			rb.KeyValue("Warning", out.CleanupError)
+		}
+		rb.Blank()
+		if strings.TrimSpace(out.Text) == "" {
+			rb.Line("No text was recognized.")
+		} else {
+			rb.Raw(out.Text)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// exportPlainText exports a Google Doc as plain text without the byte order
+// mark Drive prepends.
+func exportPlainText(ctx context.Context, srv *drive.Service, docID string) (string, error) {
+	resp, err := srv.Files.Export(docID, "text/plain").Context(ctx).Download()
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, office.MaxFileSize))
+	if err != nil {
+		return "", fmt.Errorf("reading OCR text: %w", err)
+	}
+	return strings.TrimPrefix(string(data), "\ufeff"), nil
+}
@@ -0,0 +1,96 @@
+package drive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ocrServer fakes the Drive calls of ocr_drive_file for a PDF p1 and
+// records whether the converted Doc was deleted.
+type ocrServer struct {
+	copyQuery string
+	deleted   bool
+}
+
+func (s *ocrServer) RoundTrip(r *http.Request) (*http.Response, error) {
+	reply := func(body string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}
+	switch {
+	case r.Method == http.MethodPost:
+		s.copyQuery = r.URL.RawQuery
+		return reply(`{"id":"ocr1","webViewLink":"https://docs.google.com/ocr1"}`)
+	case r.Method == http.MethodDelete:
+		s.deleted = true
+		return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody, Request: r}, nil
+	case strings.HasSuffix(r.URL.Path, "/export"):
+		return reply("\ufeffInvoice 4471\r\nTotal: 120.00")
+	default:
+		return reply(`{"id":"p1","name":"scan.pdf","mimeType":"application/pdf"}`)
+	}
+}
+
+func TestOCRFileDeletesDoc(t *testing.T) {
+	srv := &ocrServer{}
+	_, out, err := createOCRFileHandler(fakeDriveFactory(srv))(context.Background(), &mcp.CallToolRequest{}, OCRFileInput{
+		UserEmail:   "user@example.com",
+		FileID:      "p1",
+		OCRLanguage: "en",
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.Text != "Invoice 4471\r\nTotal: 120.00" {
+		t.Errorf("text = %q", out.Text)
+	}
+	if !strings.Contains(srv.copyQuery, "ocrLanguage=en") {
+		t.Errorf("copy query = %s, want ocrLanguage=en", srv.copyQuery)
+	}
+	if !srv.deleted || out.DocID != "" {
+		t.Errorf("deleted = %v, output = %+v", srv.deleted, out)
+	}
+}
+
+func TestOCRFileKeepDoc(t *testing.T) {
+	srv := &ocrServer{}
+	_, out, err := createOCRFileHandler(fakeDriveFactory(srv))(context.Background(), &mcp.CallToolRequest{}, OCRFileInput{
+		UserEmail: "user@example.com",
+		FileID:    "p1",
+		KeepDoc:   true,
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if srv.deleted || out.DocID != "ocr1" || out.DocLink == "" {
+		t.Errorf("deleted = %v, output = %+v", srv.deleted, out)
+	}
+}
+
+func TestOCRFileValidation(t *testing.T) {
+	_, _, err := createOCRFileHandler(sandboxFactory())(context.Background(), &mcp.CallToolRequest{}, OCRFileInput{
+		UserEmail: "user@example.com",
+		FileID:    "1sbxPlanDoc0001",
+	})
+	if err == nil || !strings.Contains(err.Error(), "needs an image or PDF") {
+		t.Errorf("Google Doc error = %v", err)
+	}
+
+	_, _, err = createOCRFileHandler(sandboxFactory())(context.Background(), &mcp.CallToolRequest{}, OCRFileInput{
+		UserEmail: "user@example.com",
+		FileID:    "1sbxInvoicePdf4471",
+		FolderID:  "1sbxFolderPlanning",
+	})
+	if err == nil || !strings.Contains(err.Error(), "keep_doc=true") {
+		t.Errorf("folder without keep_doc error = %v", err)
+	}
+}
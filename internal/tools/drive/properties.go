@@ -0,0 +1,287 @@
+package drive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/provenance"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// maxPropertyBytes is Drive's limit on the combined UTF-8 length of a
+// property's key and value.
+const maxPropertyBytes = 124
+
+// reservedAppProperties are the provenance stamp keys; changing them would
+// hide a file from list_agent_created_items or misattribute it.
+var reservedAppProperties = []string{provenance.KeyCreatedBy, provenance.KeySession, provenance.KeyCreatedAt}
+
+// FilePropertiesOutput is a file's custom key-value metadata. Properties are
+// visible to every app; AppProperties only to this server's OAuth client.
+type FilePropertiesOutput struct {
+	FileID        string            `json:"file_id"`
+	Name          string            `json:"name"`
+	Properties    map[string]string `json:"properties,omitempty"`
+	AppProperties map[string]string `json:"app_properties,omitempty"`
+}
+
+func writeProperties(rb *response.Builder, title string, props map[string]string) {
+	rb.Section("%s (%d)", title, len(props))
+	for _, k := range slices.Sorted(maps.Keys(props)) {
+		rb.Item("%s = %s", k, props[k])
+	}
+}
+
+func propertiesResult(header string, out FilePropertiesOutput) *mcp.CallToolResult {
+	rb := response.New()
+	rb.Header("%s", header)
+	rb.KeyValue("File", out.Name)
+	rb.KeyValue("ID", out.FileID)
+	writeProperties(rb, "Properties", out.Properties)
+	writeProperties(rb, "App properties", out.AppProperties)
+	return rb.TextResult()
+}
+
+// --- get_drive_file_properties (extended) ---
+
+type GetFilePropertiesInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID    string `json:"file_id" jsonschema:"required" jsonschema_description:"The file or folder ID"`
+}
+
+func createGetFilePropertiesHandler(factory *services.Factory) mcp.ToolHandlerFor[GetFilePropertiesInput, FilePropertiesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetFilePropertiesInput) (*mcp.CallToolResult, FilePropertiesOutput, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, FilePropertiesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		file, err := srv.Files.Get(input.FileID).
+			Fields("id, name, properties, appProperties").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, FilePropertiesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := FilePropertiesOutput{FileID: file.Id, Name: file.Name, Properties: file.Properties, AppProperties: file.AppProperties}
+		return propertiesResult("Drive File Properties", out), out, nil
+	}
+}
+
+// --- set_drive_file_properties (extended) ---
+
+type SetFilePropertiesInput struct {
+	UserEmail           string            `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID              string            `json:"file_id" jsonschema:"required" jsonschema_description:"The file or folder ID"`
+	Properties          map[string]string `json:"properties,omitempty" jsonschema_description:"Public properties to add or overwrite, visible to every app with access to the file"`
+	AppProperties       map[string]string `json:"app_properties,omitempty" jsonschema_description:"Private properties to add or overwrite, visible only to this server"`
+	RemoveProperties    []string          `json:"remove_properties,omitempty" jsonschema_description:"Public property keys to delete"`
+	RemoveAppProperties []string          `json:"remove_app_properties,omitempty" jsonschema_description:"Private property keys to delete"`
+}
+
+// propertyPatch merges sets and removals into the JSON object Drive expects,
+// where a null value deletes the key. Keys not mentioned are left alone.
+func propertyPatch(set map[string]string, remove []string, reserved []string) (map[string]any, error) {
+	patch := make(map[string]any, len(set)+len(remove))
+	for k, v := range set {
+		if k == "" {
+			return nil, fmt.Errorf("property keys cannot be empty")
+		}
+		if len(k)+len(v) > maxPropertyBytes {
+			return nil, fmt.Errorf("property %q is too long — key and value together are limited to %d bytes", k, maxPropertyBytes)
+		}
+		patch[k] = v
+	}
+	for _, k := range remove {
+		if _, ok := set[k]; ok {
+			return nil, fmt.Errorf("property %q is both set and removed", k)
+		}
+		patch[k] = nil
+	}
+	for k := range patch {
+		if slices.Contains(reserved, k) {
+			return nil, fmt.Errorf("app property %q is reserved for provenance tracking", k)
+		}
+	}
+	return patch, nil
+}
+
+func createSetFilePropertiesHandler(factory *services.Factory) mcp.ToolHandlerFor[SetFilePropertiesInput, FilePropertiesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SetFilePropertiesInput) (*mcp.CallToolResult, FilePropertiesOutput, error) {
+		props, err := propertyPatch(input.Properties, input.RemoveProperties, nil)
+		if err != nil {
+			return nil, FilePropertiesOutput{}, err
+		}
+		appProps, err := propertyPatch(input.AppProperties, input.RemoveAppProperties, reservedAppProperties)
+		if err != nil {
+			return nil, FilePropertiesOutput{}, err
+		}
+		body := map[string]any{}
+		if len(props) > 0 {
+			body["properties"] = props
+		}
+		if len(appProps) > 0 {
+			body["appProperties"] = appProps
+		}
+		if len(body) == 0 {
+			return nil, FilePropertiesOutput{}, fmt.Errorf("nothing to update — provide properties, app_properties, remove_properties, or remove_app_properties")
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, FilePropertiesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		file, err := patchFileProperties(ctx, factory, srv, input.UserEmail, input.FileID, body)
+		if err != nil {
+			return nil, FilePropertiesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := FilePropertiesOutput{FileID: file.Id, Name: file.Name, Properties: file.Properties, AppProperties: file.AppProperties}
+		return propertiesResult("Drive File Properties Updated", out), out, nil
+	}
+}
+
+// patchFileProperties sends body as a files.update PATCH. The generated
+// client cannot send null map values, which is how Drive deletes a
+// property, so the request is made directly.
+func patchFileProperties(ctx context.Context, factory *services.Factory, srv *drive.Service, userEmail, fileID string, body map[string]any) (*drive.File, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	client, err := factory.HTTPClient(ctx, userEmail, "drive")
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{"supportsAllDrives": {"true"}, "fields": {"id, name, properties, appProperties"}}
+	endpoint := srv.BasePath + "files/" + url.PathEscape(fileID) + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	var file drive.File
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		return nil, fmt.Errorf("decoding updated file: %w", err)
+	}
+	return &file, nil
+}
+
+// --- search_drive_by_properties (extended) ---
+
+type SearchByPropertiesInput struct {
+	UserEmail      string            `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Properties     map[string]string `json:"properties,omitempty" jsonschema_description:"Public properties the file must have, key to exact value"`
+	AppProperties  map[string]string `json:"app_properties,omitempty" jsonschema_description:"Private properties the file must have, key to exact value"`
+	Query          string            `json:"query,omitempty" jsonschema_description:"Additional Drive query to AND with the property filters (e.g. \"mimeType = 'application/pdf'\")"`
+	IncludeTrashed bool              `json:"include_trashed,omitempty" jsonschema_description:"Also match files in the trash"`
+	PageSize       int               `json:"page_size,omitempty" jsonschema_description:"Maximum results (default 25)"`
+	PageToken      string            `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+// PropertyMatch is a file found by search_drive_by_properties with its
+// custom metadata.
+type PropertyMatch struct {
+	FileSummary
+	Properties    map[string]string `json:"properties,omitempty"`
+	AppProperties map[string]string `json:"app_properties,omitempty"`
+}
+
+type SearchByPropertiesOutput struct {
+	Files         []PropertyMatch `json:"files"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+}
+
+// propertiesQuery builds the Drive query clauses matching every key-value
+// pair of props in the given collection, in key order.
+func propertiesQuery(collection string, props map[string]string) []string {
+	clauses := make([]string, 0, len(props))
+	for _, k := range slices.Sorted(maps.Keys(props)) {
+		clauses = append(clauses, fmt.Sprintf("%s has { key='%s' and value='%s' }", collection, escapeQueryValue(k), escapeQueryValue(props[k])))
+	}
+	return clauses
+}
+
+func createSearchByPropertiesHandler(factory *services.Factory) mcp.ToolHandlerFor[SearchByPropertiesInput, SearchByPropertiesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SearchByPropertiesInput) (*mcp.CallToolResult, SearchByPropertiesOutput, error) {
+		clauses := append(propertiesQuery("properties", input.Properties), propertiesQuery("appProperties", input.AppProperties)...)
+		if len(clauses) == 0 {
+			return nil, SearchByPropertiesOutput{}, fmt.Errorf("provide at least one of properties or app_properties")
+		}
+		if input.Query != "" {
+			clauses = append(clauses, "("+input.Query+")")
+		}
+		if !input.IncludeTrashed {
+			clauses = append(clauses, "trashed = false")
+		}
+		if input.PageSize == 0 {
+			input.PageSize = 25
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, SearchByPropertiesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Files.List().
+			Q(strings.Join(clauses, " and ")).
+			PageSize(int64(input.PageSize)).
+			Fields("nextPageToken, files(id, name, mimeType, size, modifiedTime, webViewLink, properties, appProperties)").
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			OrderBy("modifiedTime desc").
+			Context(ctx)
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, SearchByPropertiesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		files := make([]PropertyMatch, 0, len(result.Files))
+		rb := response.New()
+		rb.Header("Drive Files by Property")
+		rb.KeyValue("Count", len(result.Files))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+
+		for _, f := range result.Files {
+			m := PropertyMatch{FileSummary: fileToSummary(f), Properties: f.Properties, AppProperties: f.AppProperties}
+			files = append(files, m)
+			rb.Item("%s (%s)", m.Name, formatFileType(m.MimeType))
+			rb.Line("    ID: %s", m.ID)
+			if m.WebViewLink != "" {
+				rb.Line("    Link: %s", m.WebViewLink)
+			}
+		}
+
+		return rb.TextResult(), SearchByPropertiesOutput{Files: files, NextPageToken: result.NextPageToken}, nil
+	}
+}
@@ -0,0 +1,81 @@
+package drive
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/provenance"
+)
+
+func TestPropertyPatch(t *testing.T) {
+	patch, err := propertyPatch(map[string]string{"project": "apollo"}, []string{"stale"}, nil)
+	if err != nil {
+		t.Fatalf("propertyPatch error = %v", err)
+	}
+	if patch["project"] != "apollo" || patch["stale"] != nil || len(patch) != 2 {
+		t.Errorf("patch = %v", patch)
+	}
+
+	tests := []struct {
+		name     string
+		set      map[string]string
+		remove   []string
+		reserved []string
+		wantErr  string
+	}{
+		{"empty key", map[string]string{"": "x"}, nil, nil, "cannot be empty"},
+		{"too long", map[string]string{"k": strings.Repeat("v", maxPropertyBytes)}, nil, nil, "too long"},
+		{"set and removed", map[string]string{"k": "v"}, []string{"k"}, nil, "both set and removed"},
+		{"reserved set", map[string]string{provenance.KeyCreatedBy: "me"}, nil, reservedAppProperties, "reserved"},
+		{"reserved remove", nil, []string{provenance.KeySession}, reservedAppProperties, "reserved"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := propertyPatch(tt.set, tt.remove, tt.reserved)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetFilePropertiesSendsNullForRemoval(t *testing.T) {
+	rec := &recordBody{reply: `{"id":"f1","name":"Plan","properties":{"project":"apollo"}}`}
+	_, out, err := createSetFilePropertiesHandler(fakeDriveFactory(rec))(context.Background(), &mcp.CallToolRequest{}, SetFilePropertiesInput{
+		UserEmail:        "user@example.com",
+		FileID:           "f1",
+		Properties:       map[string]string{"project": "apollo"},
+		RemoveProperties: []string{"stale"},
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if rec.body != `{"properties":{"project":"apollo","stale":null}}` {
+		t.Errorf("request body = %s", rec.body)
+	}
+	if out.Properties["project"] != "apollo" {
+		t.Errorf("output = %+v", out)
+	}
+
+	_, _, err = createSetFilePropertiesHandler(fakeDriveFactory(rec))(context.Background(), &mcp.CallToolRequest{}, SetFilePropertiesInput{
+		UserEmail: "user@example.com",
+		FileID:    "f1",
+	})
+	if err == nil || !strings.Contains(err.Error(), "nothing to update") {
+		t.Errorf("empty update error = %v", err)
+	}
+}
+
+func TestPropertiesQuery(t *testing.T) {
+	got := propertiesQuery("appProperties", map[string]string{"b": "it's", "a": "1"})
+	want := []string{
+		"appProperties has { key='a' and value='1' }",
+		`appProperties has { key='b' and value='it\'s' }`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("propertiesQuery = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,282 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/office"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// resourceScheme is the URI scheme of Drive resources: gdrive://{fileId}.
+const resourceScheme = "gdrive"
+
+// resourceListPageSize is how many recent files one resources/list page holds.
+const resourceListPageSize = 50
+
+// maxFolderEntries caps the children listed when a folder is read.
+const maxFolderEntries = 100
+
+const folderMimeType = "application/vnd.google-apps.folder"
+
+// resourceURI returns the resource URI of a Drive file.
+func resourceURI(fileID string) string {
+	return resourceScheme + "://" + fileID
+}
+
+// resourceFileID returns the file ID a gdrive:// URI names.
+func resourceFileID(uri string) (string, bool) {
+	id, ok := strings.CutPrefix(uri, resourceScheme+"://")
+	if !ok || id == "" || strings.ContainsAny(id, "/?#") {
+		return "", false
+	}
+	return id, true
+}
+
+// registerResources exposes Drive files and folders as MCP resources: a
+// gdrive://{fileId} template for reads, the session user's recent files for
+// resources/list, and change subscriptions through the watcher.
+func registerResources(server *mcp.Server, factory *services.Factory, res *middleware.Resources, watcher *fileWatcher) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "drive_file",
+		Title:       "Google Drive File or Folder",
+		URITemplate: resourceScheme + "://{fileId}",
+		Description: "A Drive file as text (Google Docs, Sheets and Slides are exported, Office files extracted), or a folder's contents. Other files are returned as binary.",
+		Icons:       serviceIcons,
+	}, readResourceHandler(factory, res))
+	server.AddReceivingMiddleware(resourceListMiddleware(factory, res))
+	res.HandleSubscriptions(resourceScheme, &resourceSubscriber{factory: factory, res: res, watcher: watcher})
+}
+
+// resourceListMiddleware answers resources/list with the session user's
+// most recently used Drive files, paged with the Drive page token as cursor.
+// Sessions without a known user get the server's static list.
+func resourceListMiddleware(factory *services.Factory, res *middleware.Resources) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method != "resources/list" {
+				return next(ctx, method, req)
+			}
+			session, _ := req.GetSession().(*mcp.ServerSession)
+			email, err := res.User(session)
+			if err != nil {
+				return next(ctx, method, req)
+			}
+			var cursor string
+			if params, ok := req.GetParams().(*mcp.ListResourcesParams); ok && params != nil {
+				cursor = params.Cursor
+			}
+			return listRecentFiles(ctx, factory, email, cursor)
+		}
+	}
+}
+
+// listRecentFiles lists one page of the user's recent, untrashed files.
+func listRecentFiles(ctx context.Context, factory *services.Factory, email, cursor string) (*mcp.ListResourcesResult, error) {
+	srv, err := factory.Drive(ctx, email)
+	if err != nil {
+		return nil, middleware.HandleGoogleAPIError(err)
+	}
+	page, err := srv.Files.List().
+		Q("trashed = false").
+		OrderBy("recency desc").
+		PageSize(resourceListPageSize).
+		PageToken(cursor).
+		Fields("nextPageToken, files(id, name, mimeType, size, modifiedTime)").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, middleware.HandleGoogleAPIError(err)
+	}
+
+	result := &mcp.ListResourcesResult{Resources: []*mcp.Resource{}, NextCursor: page.NextPageToken}
+	for _, f := range page.Files {
+		result.Resources = append(result.Resources, fileResource(f))
+	}
+	return result, nil
+}
+
+// fileResource describes a Drive file as an MCP resource, with the MIME type
+// a read returns.
+func fileResource(f *drive.File) *mcp.Resource {
+	r := &mcp.Resource{
+		URI:         resourceURI(f.Id),
+		Name:        f.Name,
+		Title:       f.Name,
+		MIMEType:    resourceMimeType(f.MimeType),
+		Description: fmt.Sprintf("%s, modified %s", formatFileType(f.MimeType), f.ModifiedTime),
+	}
+	if !isGoogleNativeType(f.MimeType) {
+		r.Size = f.Size
+	}
+	return r
+}
+
+// resourceMimeType returns the MIME type of a resource read of a file of
+// the given Drive type.
+func resourceMimeType(mimeType string) string {
+	switch {
+	case mimeType == folderMimeType:
+		return "text/plain"
+	case isGoogleNativeType(mimeType):
+		return mimeTypeForExport(mimeType)
+	case isOfficeType(mimeType):
+		return "text/plain"
+	default:
+		return mimeType
+	}
+}
+
+// readResourceHandler reads a gdrive:// resource as the session's user.
+func readResourceHandler(factory *services.Factory, res *middleware.Resources) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		srv, file, err := resourceFile(ctx, factory, res, req.Session, uri)
+		if err != nil {
+			return nil, err
+		}
+
+		var contents *mcp.ResourceContents
+		switch {
+		case file.MimeType == folderMimeType:
+			contents, err = folderContents(ctx, srv, file)
+		case isTextExtractable(file.MimeType):
+			var text string
+			text, err = readFileText(ctx, srv, file)
+			contents = &mcp.ResourceContents{Text: text}
+		default:
+			contents, err = blobContents(ctx, srv, file)
+		}
+		if err != nil {
+			return nil, middleware.HandleGoogleAPIError(err)
+		}
+		contents.URI, contents.MIMEType = uri, resourceMimeType(file.MimeType)
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{contents}}, nil
+	}
+}
+
+// resourceFile resolves a gdrive:// URI to the file's metadata, read as the
+// session's user. Files the user cannot see are reported as not found.
+func resourceFile(ctx context.Context, factory *services.Factory, res *middleware.Resources, session *mcp.ServerSession, uri string) (*drive.Service, *drive.File, error) {
+	fileID, ok := resourceFileID(uri)
+	if !ok {
+		return nil, nil, mcp.ResourceNotFoundError(uri)
+	}
+	email, err := res.User(session)
+	if err != nil {
+		return nil, nil, err
+	}
+	srv, err := factory.Drive(ctx, email)
+	if err != nil {
+		return nil, nil, middleware.HandleGoogleAPIError(err)
+	}
+	file, err := srv.Files.Get(fileID).
+		Fields("id, name, mimeType, size, modifiedTime").
+		SupportsAllDrives(true).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, nil, middleware.ResourceError(err, uri)
+	}
+	return srv, file, nil
+}
+
+// folderContents lists a folder's children with their resource URIs.
+func folderContents(ctx context.Context, srv *drive.Service, folder *drive.File) (*mcp.ResourceContents, error) {
+	page, err := srv.Files.List().
+		Q(fmt.Sprintf("'%s' in parents and trashed = false", escapeQueryValue(folder.Id))).
+		OrderBy("folder, name").
+		PageSize(maxFolderEntries).
+		Fields("nextPageToken, files(id, name, mimeType)").
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+
+	rb := response.New()
+	rb.Header("Drive Folder")
+	rb.KeyValue("Name", folder.Name)
+	rb.KeyValue("Items", len(page.Files))
+	rb.Blank()
+	for _, f := range page.Files {
+		rb.Item("%s (%s) — %s", f.Name, formatFileType(f.MimeType), resourceURI(f.Id))
+	}
+	if page.NextPageToken != "" {
+		rb.Blank()
+		rb.Line("Only the first %d items are listed — use search_drive_files for the rest.", maxFolderEntries)
+	}
+	return &mcp.ResourceContents{Text: rb.Build()}, nil
+}
+
+// blobContents downloads a binary file, or exports a Google drawing, capped
+// at office.MaxFileSize.
+func blobContents(ctx context.Context, srv *drive.Service, file *drive.File) (*mcp.ResourceContents, error) {
+	if file.Size > office.MaxFileSize {
+		return nil, fmt.Errorf("%s is %s, larger than the %s resource limit — use get_drive_file_download_url instead",
+			file.Name, formatSize(file.Size), formatSize(office.MaxFileSize))
+	}
+	var resp *http.Response
+	var err error
+	if isGoogleNativeType(file.MimeType) {
+		exportMime := mimeTypeForExport(file.MimeType)
+		if exportMime == "" {
+			return nil, fmt.Errorf("unsupported Google file type %q for resource reads", file.MimeType)
+		}
+		resp, err = srv.Files.Export(file.Id, exportMime).Context(ctx).Download()
+	} else {
+		resp, err = srv.Files.Get(file.Id).SupportsAllDrives(true).Context(ctx).Download()
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, office.MaxFileSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading file content: %w", err)
+	}
+	return &mcp.ResourceContents{Blob: data}, nil
+}
+
+// resourceSubscriber turns resources/subscribe on gdrive:// URIs into
+// watches, so changes to the file send resources/updated notifications.
+type resourceSubscriber struct {
+	factory *services.Factory
+	res     *middleware.Resources
+	watcher *fileWatcher
+}
+
+func (s *resourceSubscriber) Subscribe(ctx context.Context, session *mcp.ServerSession, uri string) error {
+	srv, file, err := resourceFile(ctx, s.factory, s.res, session, uri)
+	if err != nil {
+		return err
+	}
+	email, _ := s.res.User(session)
+	if err := s.watcher.subscribe(ctx, srv, email, file, session); err != nil {
+		return middleware.HandleGoogleAPIError(err)
+	}
+	return nil
+}
+
+func (s *resourceSubscriber) Unsubscribe(_ context.Context, session *mcp.ServerSession, uri string) error {
+	fileID, ok := resourceFileID(uri)
+	if !ok {
+		return mcp.ResourceNotFoundError(uri)
+	}
+	email, err := s.res.User(session)
+	if err != nil {
+		return err
+	}
+	s.watcher.unsubscribe(email, fileID, session)
+	return nil
+}
@@ -0,0 +1,36 @@
+package drive
+
+import "testing"
+
+func TestResourceFileID(t *testing.T) {
+	tests := []struct {
+		uri    string
+		want   string
+		wantOK bool
+	}{
+		{"gdrive://1AbC-d_e", "1AbC-d_e", true},
+		{"gdrive://", "", false},
+		{"gdrive://abc/def", "", false},
+		{"gmail://abc", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := resourceFileID(tt.uri)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("resourceFileID(%q) = %q, %v; want %q, %v", tt.uri, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestResourceMimeType(t *testing.T) {
+	tests := map[string]string{
+		folderMimeType: "text/plain",
+		"application/vnd.google-apps.spreadsheet":                                 "text/csv",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document": "text/plain",
+		"image/jpeg": "image/jpeg",
+	}
+	for in, want := range tests {
+		if got := resourceMimeType(in); got != want {
+			t.Errorf("resourceMimeType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/office"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// RevisionSummary is a compact representation of a file revision.
+type RevisionSummary struct {
+	ID           string `json:"id"`
+	ModifiedTime string `json:"modified_time"`
+	ModifiedBy   string `json:"modified_by,omitempty"`
+	Size         int64  `json:"size,omitempty"`
+	KeepForever  bool   `json:"keep_forever,omitempty"`
+}
+
+func revisionToSummary(r *drive.Revision) RevisionSummary {
+	rs := RevisionSummary{ID: r.Id, ModifiedTime: r.ModifiedTime, Size: r.Size, KeepForever: r.KeepForever}
+	if u := r.LastModifyingUser; u != nil {
+		rs.ModifiedBy = u.EmailAddress
+		if rs.ModifiedBy == "" {
+			rs.ModifiedBy = u.DisplayName
+		}
+	}
+	return rs
+}
+
+// restoreExportFormat is the format a Google file's revision is exported in
+// to restore it: the Office format closest to the native one.
+func restoreExportFormat(googleMimeType string) string {
+	switch googleMimeType {
+	case "application/vnd.google-apps.document":
+		return "docx"
+	case "application/vnd.google-apps.spreadsheet":
+		return "xlsx"
+	case "application/vnd.google-apps.presentation":
+		return "pptx"
+	default:
+		return ""
+	}
+}
+
+// openRevision returns the content of revision rev of file. Google files are
+// exported as exportMime through the revision's export link; other files are
+// downloaded as stored.
+func openRevision(ctx context.Context, factory *services.Factory, srv *drive.Service, userEmail string, file *drive.File, rev *drive.Revision, exportMime string) (io.ReadCloser, error) {
+	if !isGoogleNativeType(file.MimeType) {
+		resp, err := srv.Revisions.Get(file.Id, rev.Id).Context(ctx).Download()
+		if err != nil {
+			return nil, err
+		}
+		return resp.Body, nil
+	}
+
+	link := rev.ExportLinks[exportMime]
+	if link == "" {
+		return nil, fmt.Errorf("revision %s of %q cannot be exported as %s", rev.Id, file.Name, exportMime)
+	}
+	client, err := factory.HTTPClient(ctx, userEmail, "drive")
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := googleapi.CheckResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// getFileAndRevision fetches the file and revision metadata the revision
+// tools need.
+func getFileAndRevision(ctx context.Context, srv *drive.Service, fileID, revisionID string) (*drive.File, *drive.Revision, error) {
+	file, err := srv.Files.Get(fileID).
+		Fields("id, name, mimeType").
+		SupportsAllDrives(true).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, nil, err
+	}
+	rev, err := srv.Revisions.Get(fileID, revisionID).
+		Fields("id, modifiedTime, lastModifyingUser(displayName, emailAddress), size, keepForever, exportLinks").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, rev, nil
+}
+
+// --- list_drive_revisions (extended) ---
+
+type ListRevisionsInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID    string `json:"file_id" jsonschema:"required" jsonschema_description:"The Google Drive file ID"`
+	PageSize  int    `json:"page_size,omitempty" jsonschema_description:"Maximum results (default 50)"`
+	PageToken string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type ListRevisionsOutput struct {
+	Revisions     []RevisionSummary `json:"revisions"`
+	NextPageToken string            `json:"next_page_token,omitempty"`
+}
+
+func createListRevisionsHandler(factory *services.Factory) mcp.ToolHandlerFor[ListRevisionsInput, ListRevisionsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListRevisionsInput) (*mcp.CallToolResult, ListRevisionsOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 50
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListRevisionsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Revisions.List(input.FileID).
+			PageSize(int64(input.PageSize)).
+			Fields("nextPageToken, revisions(id, modifiedTime, lastModifyingUser(displayName, emailAddress), size, keepForever)").
+			Context(ctx)
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListRevisionsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		revisions := make([]RevisionSummary, 0, len(result.Revisions))
+		rb := response.New()
+		rb.Header("Drive File Revisions")
+		rb.KeyValue("File ID", input.FileID)
+		rb.KeyValue("Count", len(result.Revisions))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+
+		// Drive lists oldest first; the last revision is the current content.
+		for _, r := range result.Revisions {
+			rs := revisionToSummary(r)
+			revisions = append(revisions, rs)
+			line := fmt.Sprintf("%s — %s", rs.ID, rs.ModifiedTime)
+			if rs.ModifiedBy != "" {
+				line += " by " + rs.ModifiedBy
+			}
+			if rs.KeepForever {
+				line += " (kept forever)"
+			}
+			rb.Item("%s", line)
+		}
+
+		return rb.TextResult(), ListRevisionsOutput{Revisions: revisions, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// --- get_drive_revision_content (extended) ---
+
+type GetRevisionContentInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID     string `json:"file_id" jsonschema:"required" jsonschema_description:"The Google Drive file ID"`
+	RevisionID string `json:"revision_id" jsonschema:"required" jsonschema_description:"The revision ID from list_drive_revisions"`
+}
+
+type GetRevisionContentOutput struct {
+	Revision RevisionSummary `json:"revision"`
+	Content  string          `json:"content"`
+}
+
+func createGetRevisionContentHandler(factory *services.Factory) mcp.ToolHandlerFor[GetRevisionContentInput, GetRevisionContentOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetRevisionContentInput) (*mcp.CallToolResult, GetRevisionContentOutput, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, GetRevisionContentOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		file, rev, err := getFileAndRevision(ctx, srv, input.FileID, input.RevisionID)
+		if err != nil {
+			return nil, GetRevisionContentOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		if !isTextExtractable(file.MimeType) {
+			return nil, GetRevisionContentOutput{}, fmt.Errorf("%q is a %s; only text, Office, and Google Docs/Sheets/Slides revisions can be read as text", file.Name, formatFileType(file.MimeType))
+		}
+
+		body, err := openRevision(ctx, factory, srv, input.UserEmail, file, rev, mimeTypeForExport(file.MimeType))
+		if err != nil {
+			return nil, GetRevisionContentOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		defer body.Close()
+		data, err := io.ReadAll(io.LimitReader(body, office.MaxFileSize))
+		if err != nil {
+			return nil, GetRevisionContentOutput{}, fmt.Errorf("reading revision content: %w", err)
+		}
+		content := string(data)
+		if isOfficeType(file.MimeType) {
+			if extracted, extractErr := office.ExtractText(data, file.MimeType); extractErr == nil {
+				content = extracted
+			}
+		}
+
+		out := GetRevisionContentOutput{Revision: revisionToSummary(rev), Content: content}
+		rb := response.New()
+		rb.Header("Drive Revision Content")
+		rb.KeyValue("File", file.Name)
+		rb.KeyValue("Revision", out.Revision.ID)
+		rb.KeyValue("Modified", out.Revision.ModifiedTime)
+		if out.Revision.ModifiedBy != "" {
+			rb.KeyValue("Modified by", out.Revision.ModifiedBy)
+		}
+		rb.Blank()
+		rb.Raw(content)
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- keep_drive_revision (extended) ---
+
+type KeepRevisionInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID     string `json:"file_id" jsonschema:"required" jsonschema_description:"The Google Drive file ID"`
+	RevisionID string `json:"revision_id" jsonschema:"required" jsonschema_description:"The revision ID from list_drive_revisions"`
+	Unpin      bool   `json:"unpin,omitempty" jsonschema_description:"Let Drive purge the revision again instead"`
+}
+
+func createKeepRevisionHandler(factory *services.Factory) mcp.ToolHandlerFor[KeepRevisionInput, RevisionSummary] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input KeepRevisionInput) (*mcp.CallToolResult, RevisionSummary, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, RevisionSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		// KeepForever must be sent even when false, or unpinning is a no-op.
+		rev, err := srv.Revisions.Update(input.FileID, input.RevisionID, &drive.Revision{
+			KeepForever:     !input.Unpin,
+			ForceSendFields: []string{"KeepForever"},
+		}).
+			Fields("id, modifiedTime, lastModifyingUser(displayName, emailAddress), size, keepForever").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, RevisionSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := revisionToSummary(rev)
+		rb := response.New()
+		if out.KeepForever {
+			rb.Header("Revision Kept Forever")
+		} else {
+			rb.Header("Revision Unpinned")
+		}
+		rb.KeyValue("File ID", input.FileID)
+		rb.KeyValue("Revision", out.ID)
+		rb.KeyValue("Modified", out.ModifiedTime)
+		if !out.KeepForever {
+			rb.Line("Drive may purge it 30 days after a newer revision replaces it.")
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- restore_drive_revision (extended) ---
+
+type RestoreRevisionInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID     string `json:"file_id" jsonschema:"required" jsonschema_description:"The Google Drive file ID"`
+	RevisionID string `json:"revision_id" jsonschema:"required" jsonschema_description:"The revision ID to restore, from list_drive_revisions"`
+}
+
+type RestoreRevisionOutput struct {
+	FileID           string `json:"file_id"`
+	Name             string `json:"name"`
+	RestoredRevision string `json:"restored_revision"`
+	HeadRevision     string `json:"head_revision,omitempty"`
+}
+
+func createRestoreRevisionHandler(factory *services.Factory) mcp.ToolHandlerFor[RestoreRevisionInput, RestoreRevisionOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input RestoreRevisionInput) (*mcp.CallToolResult, RestoreRevisionOutput, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, RestoreRevisionOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		file, rev, err := getFileAndRevision(ctx, srv, input.FileID, input.RevisionID)
+		if err != nil {
+			return nil, RestoreRevisionOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		// Drive has no restore call: the old content is uploaded as a new
+		// head revision. Google files round-trip through their Office
+		// format, which Drive converts back on upload.
+		contentType := file.MimeType
+		if isGoogleNativeType(file.MimeType) {
+			format := restoreExportFormat(file.MimeType)
+			if format == "" {
+				return nil, RestoreRevisionOutput{}, fmt.Errorf("%s revisions cannot be restored — only Docs, Sheets, and Slides", formatFileType(file.MimeType))
+			}
+			contentType = exportFormatToMime(format)
+		}
+		body, err := openRevision(ctx, factory, srv, input.UserEmail, file, rev, contentType)
+		if err != nil {
+			return nil, RestoreRevisionOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		defer body.Close()
+
+		updated, err := srv.Files.Update(file.Id, &drive.File{}).
+			Media(body, googleapi.ContentType(contentType)).
+			Fields("id, name, headRevisionId").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, RestoreRevisionOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := RestoreRevisionOutput{FileID: updated.Id, Name: updated.Name, RestoredRevision: rev.Id, HeadRevision: updated.HeadRevisionId}
+		rb := response.New()
+		rb.Header("Drive Revision Restored")
+		rb.KeyValue("File", out.Name)
+		rb.KeyValue("Restored revision", fmt.Sprintf("%s (%s)", rev.Id, rev.ModifiedTime))
+		if out.HeadRevision != "" {
+			rb.KeyValue("New head revision", out.HeadRevision)
+		}
+		rb.Line("The content before the restore remains available as an earlier revision.")
+
+		return rb.TextResult(), out, nil
+	}
+}
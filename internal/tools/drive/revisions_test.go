@@ -0,0 +1,68 @@
+package drive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// revisionServer fakes the Drive calls of restore_drive_revision for a
+// Google Doc and records the upload.
+type revisionServer struct {
+	uploaded string
+}
+
+func (s *revisionServer) RoundTrip(r *http.Request) (*http.Response, error) {
+	reply := func(ct, body string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {ct}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}
+	switch {
+	case r.URL.Host == "docs.google.com":
+		return reply("application/octet-stream", "old docx bytes")
+	case strings.HasSuffix(r.URL.Path, "/revisions/r1"):
+		return reply("application/json", `{"id":"r1","modifiedTime":"2026-01-01T00:00:00Z","exportLinks":{"`+exportFormatToMime("docx")+`":"https://docs.google.com/feeds/download/documents/export/Export?id=d1&revision=1&exportFormat=docx"}}`)
+	case r.Method == http.MethodPatch:
+		data, _ := io.ReadAll(r.Body)
+		s.uploaded = string(data)
+		return reply("application/json", `{"id":"d1","name":"Plan"}`)
+	default:
+		return reply("application/json", `{"id":"d1","name":"Plan","mimeType":"application/vnd.google-apps.document"}`)
+	}
+}
+
+func TestRestoreRevisionGoogleDoc(t *testing.T) {
+	srv := &revisionServer{}
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(srv)
+
+	_, out, err := createRestoreRevisionHandler(factory)(context.Background(), &mcp.CallToolRequest{}, RestoreRevisionInput{
+		UserEmail:  "user@example.com",
+		FileID:     "d1",
+		RevisionID: "r1",
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.RestoredRevision != "r1" || out.Name != "Plan" {
+		t.Errorf("output = %+v", out)
+	}
+	if !strings.Contains(srv.uploaded, "old docx bytes") {
+		t.Errorf("uploaded %q, want the revision's docx export", srv.uploaded)
+	}
+	if !strings.Contains(srv.uploaded, "Content-Type: "+exportFormatToMime("docx")) {
+		t.Errorf("upload = %q, want the media part typed as docx", srv.uploaded)
+	}
+}
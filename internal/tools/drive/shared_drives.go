@@ -0,0 +1,412 @@
+package drive
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// sharedDriveRoles are the roles a shared drive member can hold, from most
+// to least privileged.
+var sharedDriveRoles = []string{"organizer", "fileOrganizer", "writer", "commenter", "reader"}
+
+const sharedDriveFields = "id, name, createdTime, hidden, restrictions"
+
+// SharedDriveSummary is a compact representation of a shared drive.
+type SharedDriveSummary struct {
+	ID                           string `json:"id"`
+	Name                         string `json:"name"`
+	CreatedTime                  string `json:"created_time,omitempty"`
+	Hidden                       bool   `json:"hidden,omitempty"`
+	AdminManagedRestrictions     bool   `json:"admin_managed_restrictions,omitempty"`
+	CopyRequiresWriterPermission bool   `json:"copy_requires_writer_permission,omitempty"`
+	DomainUsersOnly              bool   `json:"domain_users_only,omitempty"`
+	DriveMembersOnly             bool   `json:"drive_members_only,omitempty"`
+}
+
+func sharedDriveToSummary(d *drive.Drive) SharedDriveSummary {
+	s := SharedDriveSummary{ID: d.Id, Name: d.Name, CreatedTime: d.CreatedTime, Hidden: d.Hidden}
+	if r := d.Restrictions; r != nil {
+		s.AdminManagedRestrictions = r.AdminManagedRestrictions
+		s.CopyRequiresWriterPermission = r.CopyRequiresWriterPermission
+		s.DomainUsersOnly = r.DomainUsersOnly
+		s.DriveMembersOnly = r.DriveMembersOnly
+	}
+	return s
+}
+
+func writeSharedDrive(rb *response.Builder, s SharedDriveSummary) {
+	rb.KeyValue("Name", s.Name)
+	rb.KeyValue("ID", s.ID)
+	var restrictions []string
+	for _, r := range []struct {
+		on   bool
+		name string
+	}{
+		{s.AdminManagedRestrictions, "admin-managed restrictions"},
+		{s.CopyRequiresWriterPermission, "copy requires writer"},
+		{s.DomainUsersOnly, "domain users only"},
+		{s.DriveMembersOnly, "drive members only"},
+	} {
+		if r.on {
+			restrictions = append(restrictions, r.name)
+		}
+	}
+	if len(restrictions) > 0 {
+		rb.KeyValue("Restrictions", strings.Join(restrictions, ", "))
+	}
+}
+
+// --- list_shared_drives (extended) ---
+
+type ListSharedDrivesInput struct {
+	UserEmail            string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Query                string `json:"query,omitempty" jsonschema_description:"Shared drive search query, e.g. name contains 'Project'"`
+	UseDomainAdminAccess bool   `json:"use_domain_admin_access,omitempty" jsonschema_description:"List every shared drive in the domain (requires a Workspace administrator)"`
+	PageSize             int    `json:"page_size,omitempty" jsonschema_description:"Maximum results (default 25, max 100)"`
+	PageToken            string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type ListSharedDrivesOutput struct {
+	Drives        []SharedDriveSummary `json:"drives"`
+	NextPageToken string               `json:"next_page_token,omitempty"`
+}
+
+func createListSharedDrivesHandler(factory *services.Factory) mcp.ToolHandlerFor[ListSharedDrivesInput, ListSharedDrivesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListSharedDrivesInput) (*mcp.CallToolResult, ListSharedDrivesOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 25
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListSharedDrivesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Drives.List().
+			PageSize(int64(input.PageSize)).
+			Fields("nextPageToken, drives(" + sharedDriveFields + ")").
+			UseDomainAdminAccess(input.UseDomainAdminAccess).
+			Context(ctx)
+		if input.Query != "" {
+			call = call.Q(input.Query)
+		}
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListSharedDrivesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		drives := make([]SharedDriveSummary, 0, len(result.Drives))
+		rb := response.New()
+		rb.Header("Shared Drives")
+		rb.KeyValue("Count", len(result.Drives))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+		for _, d := range result.Drives {
+			s := sharedDriveToSummary(d)
+			drives = append(drives, s)
+			rb.Item("%s", s.Name)
+			rb.Line("    ID: %s", s.ID)
+		}
+
+		return rb.TextResult(), ListSharedDrivesOutput{Drives: drives, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// --- create_shared_drive (extended) ---
+
+type CreateSharedDriveInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Name      string `json:"name" jsonschema:"required" jsonschema_description:"Name of the new shared drive"`
+	RequestID string `json:"request_id,omitempty" jsonschema_description:"Idempotency key: retrying with the same request_id never creates a second drive (default: a random key)"`
+}
+
+func createCreateSharedDriveHandler(factory *services.Factory) mcp.ToolHandlerFor[CreateSharedDriveInput, SharedDriveSummary] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateSharedDriveInput) (*mcp.CallToolResult, SharedDriveSummary, error) {
+		if strings.TrimSpace(input.Name) == "" {
+			return nil, SharedDriveSummary{}, fmt.Errorf("name must not be empty")
+		}
+		if input.RequestID == "" {
+			input.RequestID = rand.Text()
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, SharedDriveSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		created, err := srv.Drives.Create(input.RequestID, &drive.Drive{Name: input.Name}).
+			Fields(sharedDriveFields).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, SharedDriveSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := sharedDriveToSummary(created)
+		rb := response.New()
+		rb.Header("Shared Drive Created")
+		writeSharedDrive(rb, out)
+		rb.Line("%s is its organizer; add members with add_shared_drive_member.", input.UserEmail)
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- update_shared_drive (extended) ---
+
+type UpdateSharedDriveInput struct {
+	UserEmail                    string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DriveID                      string `json:"drive_id" jsonschema:"required" jsonschema_description:"The shared drive ID"`
+	Name                         string `json:"name,omitempty" jsonschema_description:"New name"`
+	AdminManagedRestrictions     *bool  `json:"admin_managed_restrictions,omitempty" jsonschema_description:"Whether only administrators can change the restrictions"`
+	CopyRequiresWriterPermission *bool  `json:"copy_requires_writer_permission,omitempty" jsonschema_description:"Whether commenters and readers are blocked from copying, printing, and downloading files"`
+	DomainUsersOnly              *bool  `json:"domain_users_only,omitempty" jsonschema_description:"Whether access is limited to users of the drive's domain"`
+	DriveMembersOnly             *bool  `json:"drive_members_only,omitempty" jsonschema_description:"Whether files can only be shared with drive members"`
+	UseDomainAdminAccess         bool   `json:"use_domain_admin_access,omitempty" jsonschema_description:"Act as a Workspace administrator, for drives the user is not an organizer of"`
+}
+
+func createUpdateSharedDriveHandler(factory *services.Factory) mcp.ToolHandlerFor[UpdateSharedDriveInput, SharedDriveSummary] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateSharedDriveInput) (*mcp.CallToolResult, SharedDriveSummary, error) {
+		update := &drive.Drive{Name: input.Name}
+		restrictions := &drive.DriveRestrictions{}
+		for _, r := range []struct {
+			value *bool
+			field *bool
+			name  string
+		}{
+			{input.AdminManagedRestrictions, &restrictions.AdminManagedRestrictions, "AdminManagedRestrictions"},
+			{input.CopyRequiresWriterPermission, &restrictions.CopyRequiresWriterPermission, "CopyRequiresWriterPermission"},
+			{input.DomainUsersOnly, &restrictions.DomainUsersOnly, "DomainUsersOnly"},
+			{input.DriveMembersOnly, &restrictions.DriveMembersOnly, "DriveMembersOnly"},
+		} {
+			if r.value != nil {
+				// Sent explicitly so that false clears a restriction.
+				*r.field = *r.value
+				restrictions.ForceSendFields = append(restrictions.ForceSendFields, r.name)
+			}
+		}
+		if len(restrictions.ForceSendFields) > 0 {
+			update.Restrictions = restrictions
+		}
+		if update.Name == "" && update.Restrictions == nil {
+			return nil, SharedDriveSummary{}, fmt.Errorf("nothing to update — set name or at least one restriction")
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, SharedDriveSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		updated, err := srv.Drives.Update(input.DriveID, update).
+			Fields(sharedDriveFields).
+			UseDomainAdminAccess(input.UseDomainAdminAccess).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, SharedDriveSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := sharedDriveToSummary(updated)
+		rb := response.New()
+		rb.Header("Shared Drive Updated")
+		writeSharedDrive(rb, out)
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- list_shared_drive_members (extended) ---
+
+type ListSharedDriveMembersInput struct {
+	UserEmail            string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DriveID              string `json:"drive_id" jsonschema:"required" jsonschema_description:"The shared drive ID"`
+	UseDomainAdminAccess bool   `json:"use_domain_admin_access,omitempty" jsonschema_description:"Act as a Workspace administrator, for drives the user is not a member of"`
+}
+
+type ListSharedDriveMembersOutput struct {
+	DriveID string           `json:"drive_id"`
+	Members []PermissionInfo `json:"members"`
+}
+
+// sharedDriveMembers lists every permission on a shared drive.
+func sharedDriveMembers(ctx context.Context, srv *drive.Service, driveID string, asAdmin bool) ([]*drive.Permission, error) {
+	var perms []*drive.Permission
+	err := srv.Permissions.List(driveID).
+		Fields("nextPageToken, permissions(id, type, role, emailAddress, displayName, domain)").
+		SupportsAllDrives(true).
+		UseDomainAdminAccess(asAdmin).
+		PageSize(100).
+		Pages(ctx, func(page *drive.PermissionList) error {
+			perms = append(perms, page.Permissions...)
+			return nil
+		})
+	return perms, err
+}
+
+func createListSharedDriveMembersHandler(factory *services.Factory) mcp.ToolHandlerFor[ListSharedDriveMembersInput, ListSharedDriveMembersOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListSharedDriveMembersInput) (*mcp.CallToolResult, ListSharedDriveMembersOutput, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListSharedDriveMembersOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		perms, err := sharedDriveMembers(ctx, srv, input.DriveID, input.UseDomainAdminAccess)
+		if err != nil {
+			return nil, ListSharedDriveMembersOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := ListSharedDriveMembersOutput{DriveID: input.DriveID, Members: make([]PermissionInfo, 0, len(perms))}
+		rb := response.New()
+		rb.Header("Shared Drive Members")
+		rb.KeyValue("Drive ID", input.DriveID)
+		rb.KeyValue("Count", len(perms))
+		rb.Blank()
+		for _, p := range perms {
+			out.Members = append(out.Members, permissionToInfo(p))
+			rb.Item("%s", formatPermission(p))
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- add_shared_drive_member (extended) ---
+
+type AddSharedDriveMemberInput struct {
+	UserEmail            string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DriveID              string `json:"drive_id" jsonschema:"required" jsonschema_description:"The shared drive ID"`
+	MemberEmail          string `json:"member_email" jsonschema:"required" jsonschema_description:"Email address of the user or group to add"`
+	Role                 string `json:"role,omitempty" jsonschema_description:"Member role: organizer (Manager), fileOrganizer (Content manager), writer (Contributor), commenter, or reader (default writer),enum=organizer,enum=fileOrganizer,enum=writer,enum=commenter,enum=reader"`
+	MemberType           string `json:"member_type,omitempty" jsonschema_description:"Whether member_email is a user or a group (default user),enum=user,enum=group"`
+	SendNotification     bool   `json:"send_notification,omitempty" jsonschema_description:"Email the new member about the invitation"`
+	UseDomainAdminAccess bool   `json:"use_domain_admin_access,omitempty" jsonschema_description:"Act as a Workspace administrator, for drives the user is not an organizer of"`
+}
+
+func createAddSharedDriveMemberHandler(factory *services.Factory) mcp.ToolHandlerFor[AddSharedDriveMemberInput, PermissionInfo] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input AddSharedDriveMemberInput) (*mcp.CallToolResult, PermissionInfo, error) {
+		if input.Role == "" {
+			input.Role = "writer"
+		}
+		if !slices.Contains(sharedDriveRoles, input.Role) {
+			return nil, PermissionInfo{}, fmt.Errorf("invalid role %q — use one of: %s", input.Role, strings.Join(sharedDriveRoles, ", "))
+		}
+		if input.MemberType == "" {
+			input.MemberType = "user"
+		}
+		if input.MemberType != "user" && input.MemberType != "group" {
+			return nil, PermissionInfo{}, fmt.Errorf("invalid member_type %q — use user or group", input.MemberType)
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, PermissionInfo{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		// An existing member gets the new role instead of a duplicate
+		// permission, so the call is safe to repeat.
+		perms, err := sharedDriveMembers(ctx, srv, input.DriveID, input.UseDomainAdminAccess)
+		if err != nil {
+			return nil, PermissionInfo{}, middleware.HandleGoogleAPIError(err)
+		}
+		var perm *drive.Permission
+		if existing := findMember(perms, input.MemberEmail); existing != nil {
+			perm, err = srv.Permissions.Update(input.DriveID, existing.Id, &drive.Permission{Role: input.Role}).
+				Fields("id, type, role, emailAddress, displayName, domain").
+				SupportsAllDrives(true).
+				UseDomainAdminAccess(input.UseDomainAdminAccess).
+				Context(ctx).
+				Do()
+		} else {
+			perm, err = srv.Permissions.Create(input.DriveID, &drive.Permission{
+				Type:         input.MemberType,
+				Role:         input.Role,
+				EmailAddress: input.MemberEmail,
+			}).
+				Fields("id, type, role, emailAddress, displayName, domain").
+				SendNotificationEmail(input.SendNotification).
+				SupportsAllDrives(true).
+				UseDomainAdminAccess(input.UseDomainAdminAccess).
+				Context(ctx).
+				Do()
+		}
+		if err != nil {
+			return nil, PermissionInfo{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := permissionToInfo(perm)
+		rb := response.New()
+		rb.Header("Shared Drive Member Added")
+		rb.KeyValue("Drive ID", input.DriveID)
+		rb.KeyValue("Member", formatPermission(perm))
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// findMember returns the permission of the user or group with email, if any.
+func findMember(perms []*drive.Permission, email string) *drive.Permission {
+	for _, p := range perms {
+		if strings.EqualFold(p.EmailAddress, email) {
+			return p
+		}
+	}
+	return nil
+}
+
+// --- remove_shared_drive_member (extended) ---
+
+type RemoveSharedDriveMemberInput struct {
+	UserEmail            string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DriveID              string `json:"drive_id" jsonschema:"required" jsonschema_description:"The shared drive ID"`
+	MemberEmail          string `json:"member_email" jsonschema:"required" jsonschema_description:"Email address of the user or group to remove"`
+	UseDomainAdminAccess bool   `json:"use_domain_admin_access,omitempty" jsonschema_description:"Act as a Workspace administrator, for drives the user is not an organizer of"`
+}
+
+func createRemoveSharedDriveMemberHandler(factory *services.Factory) mcp.ToolHandlerFor[RemoveSharedDriveMemberInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input RemoveSharedDriveMemberInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		perms, err := sharedDriveMembers(ctx, srv, input.DriveID, input.UseDomainAdminAccess)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		member := findMember(perms, input.MemberEmail)
+		if member == nil {
+			return nil, nil, fmt.Errorf("%s is not a member of shared drive %s", input.MemberEmail, input.DriveID)
+		}
+
+		err = srv.Permissions.Delete(input.DriveID, member.Id).
+			SupportsAllDrives(true).
+			UseDomainAdminAccess(input.UseDomainAdminAccess).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Shared Drive Member Removed")
+		rb.KeyValue("Drive ID", input.DriveID)
+		rb.KeyValue("Member", formatPermission(member))
+
+		return rb.TextResult(), nil, nil
+	}
+}
@@ -0,0 +1,55 @@
+package drive
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/ptr"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+func TestUpdateSharedDriveClearsRestriction(t *testing.T) {
+	rec := &recordBody{reply: `{"id":"0AB","name":"Team","restrictions":{"domainUsersOnly":true}}`}
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(rec)
+
+	_, out, err := createUpdateSharedDriveHandler(factory)(context.Background(), &mcp.CallToolRequest{}, UpdateSharedDriveInput{
+		UserEmail:        "user@example.com",
+		DriveID:          "0AB",
+		DomainUsersOnly:  ptr.Bool(true),
+		DriveMembersOnly: ptr.Bool(false),
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if !strings.Contains(rec.body, `"driveMembersOnly":false`) || !strings.Contains(rec.body, `"domainUsersOnly":true`) {
+		t.Errorf("request body = %s, want both restrictions sent", rec.body)
+	}
+	if strings.Contains(rec.body, "copyRequiresWriterPermission") {
+		t.Errorf("request body = %s, want unset restrictions left out", rec.body)
+	}
+	if !out.DomainUsersOnly || out.Name != "Team" {
+		t.Errorf("output = %+v", out)
+	}
+}
+
+func TestSharedDriveValidation(t *testing.T) {
+	ctx := context.Background()
+	if _, _, err := createUpdateSharedDriveHandler(nil)(ctx, &mcp.CallToolRequest{}, UpdateSharedDriveInput{DriveID: "0AB"}); err == nil || !strings.Contains(err.Error(), "nothing to update") {
+		t.Errorf("empty update error = %v", err)
+	}
+	if _, _, err := createAddSharedDriveMemberHandler(nil)(ctx, &mcp.CallToolRequest{}, AddSharedDriveMemberInput{Role: "owner"}); err == nil || !strings.Contains(err.Error(), "invalid role") {
+		t.Errorf("owner role error = %v", err)
+	}
+	if _, _, err := createAddSharedDriveMemberHandler(nil)(ctx, &mcp.CallToolRequest{}, AddSharedDriveMemberInput{MemberType: "domain"}); err == nil || !strings.Contains(err.Error(), "invalid member_type") {
+		t.Errorf("domain member error = %v", err)
+	}
+	if _, _, err := createCreateSharedDriveHandler(nil)(ctx, &mcp.CallToolRequest{}, CreateSharedDriveInput{Name: " "}); err == nil {
+		t.Error("blank name accepted")
+	}
+}
@@ -0,0 +1,226 @@
+package drive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+const shortcutMimeType = "application/vnd.google-apps.shortcut"
+
+// --- create_drive_shortcut (extended) ---
+
+type CreateShortcutInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	TargetID  string `json:"target_id" jsonschema:"required" jsonschema_description:"ID of the file or folder the shortcut points to"`
+	FolderID  string `json:"folder_id,omitempty" jsonschema_description:"Folder to place the shortcut in (default: My Drive root)"`
+	Name      string `json:"name,omitempty" jsonschema_description:"Shortcut name (default: the target's name)"`
+}
+
+type CreateShortcutOutput struct {
+	ShortcutID  string `json:"shortcut_id"`
+	Name        string `json:"name"`
+	TargetID    string `json:"target_id"`
+	WebViewLink string `json:"web_view_link,omitempty"`
+}
+
+func createCreateShortcutHandler(factory *services.Factory) mcp.ToolHandlerFor[CreateShortcutInput, CreateShortcutOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateShortcutInput) (*mcp.CallToolResult, CreateShortcutOutput, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, CreateShortcutOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		target, err := srv.Files.Get(input.TargetID).
+			Fields("id, name, mimeType").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, CreateShortcutOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		if target.MimeType == shortcutMimeType {
+			return nil, CreateShortcutOutput{}, fmt.Errorf("%q is itself a shortcut — use resolve_drive_shortcut and point at its target", target.Name)
+		}
+
+		name := input.Name
+		if name == "" {
+			name = target.Name
+		}
+		shortcut := &drive.File{
+			Name:            name,
+			MimeType:        shortcutMimeType,
+			ShortcutDetails: &drive.FileShortcutDetails{TargetId: target.Id},
+		}
+		if input.FolderID != "" {
+			shortcut.Parents = []string{input.FolderID}
+		}
+		if stamp := factory.Provenance(req); stamp != nil {
+			shortcut.AppProperties = stamp.Properties()
+		}
+
+		created, err := srv.Files.Create(shortcut).
+			Fields("id, name, webViewLink").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, CreateShortcutOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := CreateShortcutOutput{
+			ShortcutID:  created.Id,
+			Name:        created.Name,
+			TargetID:    target.Id,
+			WebViewLink: created.WebViewLink,
+		}
+		rb := response.New()
+		rb.Header("Drive Shortcut Created")
+		rb.KeyValue("Name", out.Name)
+		rb.KeyValue("Shortcut ID", out.ShortcutID)
+		rb.KeyValue("Target", fmt.Sprintf("%s (%s)", target.Name, formatFileType(target.MimeType)))
+		rb.KeyValue("Target ID", out.TargetID)
+		if out.WebViewLink != "" {
+			rb.KeyValue("Link", out.WebViewLink)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- resolve_drive_shortcut (extended) ---
+
+type ResolveShortcutInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID    string `json:"file_id" jsonschema:"required" jsonschema_description:"The shortcut's file ID"`
+}
+
+type ResolveShortcutOutput struct {
+	ShortcutID   string       `json:"shortcut_id"`
+	ShortcutName string       `json:"shortcut_name"`
+	Target       *FileSummary `json:"target,omitempty"`
+	TargetID     string       `json:"target_id"`
+	// TargetTrashed is set when the target is in the trash; TargetMissing
+	// when it was deleted or the user can no longer see it.
+	TargetTrashed bool `json:"target_trashed,omitempty"`
+	TargetMissing bool `json:"target_missing,omitempty"`
+}
+
+func createResolveShortcutHandler(factory *services.Factory) mcp.ToolHandlerFor[ResolveShortcutInput, ResolveShortcutOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ResolveShortcutInput) (*mcp.CallToolResult, ResolveShortcutOutput, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ResolveShortcutOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		shortcut, err := srv.Files.Get(input.FileID).
+			Fields("id, name, mimeType, shortcutDetails").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, ResolveShortcutOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		if shortcut.MimeType != shortcutMimeType || shortcut.ShortcutDetails == nil {
+			return nil, ResolveShortcutOutput{}, fmt.Errorf("%q is a %s, not a shortcut", shortcut.Name, formatFileType(shortcut.MimeType))
+		}
+
+		out := ResolveShortcutOutput{
+			ShortcutID:   shortcut.Id,
+			ShortcutName: shortcut.Name,
+			TargetID:     shortcut.ShortcutDetails.TargetId,
+		}
+		target, err := srv.Files.Get(out.TargetID).
+			Fields("id, name, mimeType, size, modifiedTime, webViewLink, trashed").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		var apiErr *googleapi.Error
+		switch {
+		case errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound:
+			out.TargetMissing = true
+		case err != nil:
+			return nil, ResolveShortcutOutput{}, middleware.HandleGoogleAPIError(err)
+		default:
+			summary := fileToSummary(target)
+			out.Target = &summary
+			out.TargetTrashed = target.Trashed
+		}
+
+		rb := response.New()
+		rb.Header("Drive Shortcut")
+		rb.KeyValue("Shortcut", out.ShortcutName)
+		rb.KeyValue("Shortcut ID", out.ShortcutID)
+		rb.KeyValue("Target ID", out.TargetID)
+		if out.TargetMissing {
+			rb.Line("The target was deleted or is no longer shared with this user.")
+		} else {
+			rb.KeyValue("Target", fmt.Sprintf("%s (%s)", out.Target.Name, formatFileType(out.Target.MimeType)))
+			if out.Target.WebViewLink != "" {
+				rb.KeyValue("Link", out.Target.WebViewLink)
+			}
+			if out.TargetTrashed {
+				rb.Line("The target is in the trash.")
+			}
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- star_drive_file (extended) ---
+
+type StarFileInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID    string `json:"file_id" jsonschema:"required" jsonschema_description:"The file or folder ID"`
+	Unstar    bool   `json:"unstar,omitempty" jsonschema_description:"Remove the star instead"`
+}
+
+type StarFileOutput struct {
+	FileID  string `json:"file_id"`
+	Name    string `json:"name"`
+	Starred bool   `json:"starred"`
+}
+
+func createStarFileHandler(factory *services.Factory) mcp.ToolHandlerFor[StarFileInput, StarFileOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input StarFileInput) (*mcp.CallToolResult, StarFileOutput, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, StarFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		// Starred must be sent even when false, or unstar is a no-op.
+		updated, err := srv.Files.Update(input.FileID, &drive.File{
+			Starred:         !input.Unstar,
+			ForceSendFields: []string{"Starred"},
+		}).
+			Fields("id, name, starred").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, StarFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := StarFileOutput{FileID: updated.Id, Name: updated.Name, Starred: updated.Starred}
+		rb := response.New()
+		if out.Starred {
+			rb.Header("Drive File Starred")
+		} else {
+			rb.Header("Drive File Unstarred")
+		}
+		rb.KeyValue("Name", out.Name)
+		rb.KeyValue("ID", out.FileID)
+
+		return rb.TextResult(), out, nil
+	}
+}
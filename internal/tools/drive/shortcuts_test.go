@@ -0,0 +1,124 @@
+package drive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// shortcutServer fakes Drive with a doc d1, a shortcut s1 to it, and a
+// shortcut s2 whose target was deleted. It records the last create body.
+type shortcutServer struct {
+	created string
+}
+
+func (s *shortcutServer) RoundTrip(r *http.Request) (*http.Response, error) {
+	reply := func(status int, body string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}
+	switch {
+	case r.Method == http.MethodPost:
+		data, _ := io.ReadAll(r.Body)
+		s.created = string(data)
+		return reply(http.StatusOK, `{"id":"s9","name":"Plan","webViewLink":"https://drive.google.com/s9"}`)
+	case strings.HasSuffix(r.URL.Path, "/files/d1"):
+		return reply(http.StatusOK, `{"id":"d1","name":"Plan","mimeType":"application/vnd.google-apps.document","trashed":true}`)
+	case strings.HasSuffix(r.URL.Path, "/files/s1"):
+		return reply(http.StatusOK, `{"id":"s1","name":"Plan","mimeType":"`+shortcutMimeType+`","shortcutDetails":{"targetId":"d1"}}`)
+	case strings.HasSuffix(r.URL.Path, "/files/s2"):
+		return reply(http.StatusOK, `{"id":"s2","name":"Old","mimeType":"`+shortcutMimeType+`","shortcutDetails":{"targetId":"gone"}}`)
+	default:
+		return reply(http.StatusNotFound, `{"error":{"code":404,"message":"File not found"}}`)
+	}
+}
+
+func fakeDriveFactory(rt http.RoundTripper) *services.Factory {
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(rt)
+	return factory
+}
+
+func TestCreateShortcut(t *testing.T) {
+	srv := &shortcutServer{}
+	_, out, err := createCreateShortcutHandler(fakeDriveFactory(srv))(context.Background(), &mcp.CallToolRequest{}, CreateShortcutInput{
+		UserEmail: "user@example.com",
+		TargetID:  "d1",
+		FolderID:  "project",
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	for _, want := range []string{`"targetId":"d1"`, `"mimeType":"` + shortcutMimeType + `"`, `"parents":["project"]`, `"name":"Plan"`} {
+		if !strings.Contains(srv.created, want) {
+			t.Errorf("create body = %s, want %s", srv.created, want)
+		}
+	}
+	if out.ShortcutID != "s9" || out.TargetID != "d1" {
+		t.Errorf("output = %+v", out)
+	}
+
+	_, _, err = createCreateShortcutHandler(fakeDriveFactory(srv))(context.Background(), &mcp.CallToolRequest{}, CreateShortcutInput{
+		UserEmail: "user@example.com",
+		TargetID:  "s1",
+	})
+	if err == nil || !strings.Contains(err.Error(), "itself a shortcut") {
+		t.Errorf("shortcut to shortcut error = %v", err)
+	}
+}
+
+func TestResolveShortcut(t *testing.T) {
+	factory := fakeDriveFactory(&shortcutServer{})
+	handler := createResolveShortcutHandler(factory)
+
+	_, out, err := handler(context.Background(), &mcp.CallToolRequest{}, ResolveShortcutInput{UserEmail: "user@example.com", FileID: "s1"})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.Target == nil || out.Target.ID != "d1" || !out.TargetTrashed || out.TargetMissing {
+		t.Errorf("output = %+v", out)
+	}
+
+	_, out, err = handler(context.Background(), &mcp.CallToolRequest{}, ResolveShortcutInput{UserEmail: "user@example.com", FileID: "s2"})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.Target != nil || !out.TargetMissing || out.TargetID != "gone" {
+		t.Errorf("missing target output = %+v", out)
+	}
+
+	_, _, err = handler(context.Background(), &mcp.CallToolRequest{}, ResolveShortcutInput{UserEmail: "user@example.com", FileID: "d1"})
+	if err == nil || !strings.Contains(err.Error(), "not a shortcut") {
+		t.Errorf("non-shortcut error = %v", err)
+	}
+}
+
+func TestStarFileUnstarSendsFalse(t *testing.T) {
+	rec := &recordBody{reply: `{"id":"f1","name":"Plan","starred":false}`}
+	_, out, err := createStarFileHandler(fakeDriveFactory(rec))(context.Background(), &mcp.CallToolRequest{}, StarFileInput{
+		UserEmail: "user@example.com",
+		FileID:    "f1",
+		Unstar:    true,
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if !strings.Contains(rec.body, `"starred":false`) {
+		t.Errorf("request body = %s, want starred:false sent explicitly", rec.body)
+	}
+	if out.Starred {
+		t.Errorf("output = %+v", out)
+	}
+}
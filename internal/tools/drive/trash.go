@@ -0,0 +1,197 @@
+package drive
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- trash_drive_file (extended) ---
+
+type TrashFileInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID    string `json:"file_id" jsonschema:"required" jsonschema_description:"The file or folder ID"`
+	Untrash   bool   `json:"untrash,omitempty" jsonschema_description:"Restore the file from trash instead"`
+}
+
+type TrashFileOutput struct {
+	FileID  string `json:"file_id"`
+	Name    string `json:"name"`
+	Trashed bool   `json:"trashed"`
+}
+
+func createTrashFileHandler(factory *services.Factory) mcp.ToolHandlerFor[TrashFileInput, TrashFileOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input TrashFileInput) (*mcp.CallToolResult, TrashFileOutput, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, TrashFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		// Trashed must be sent even when false, or untrash is a no-op.
+		updated, err := srv.Files.Update(input.FileID, &drive.File{
+			Trashed:         !input.Untrash,
+			ForceSendFields: []string{"Trashed"},
+		}).
+			Fields("id, name, trashed").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, TrashFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := TrashFileOutput{FileID: updated.Id, Name: updated.Name, Trashed: updated.Trashed}
+		rb := response.New()
+		if input.Untrash {
+			rb.Header("File Restored from Trash")
+		} else {
+			rb.Header("File Moved to Trash")
+		}
+		rb.KeyValue("Name", out.Name)
+		rb.KeyValue("ID", out.FileID)
+		if !input.Untrash {
+			rb.Line("Drive deletes it permanently after 30 days; set untrash=true to restore it.")
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- list_trashed_files (extended) ---
+
+type ListTrashedFilesInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	PageSize  int    `json:"page_size,omitempty" jsonschema_description:"Maximum results (default 25)"`
+	PageToken string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+// TrashedFile is a file in the trash with when and by whom it was trashed.
+type TrashedFile struct {
+	FileSummary
+	TrashedTime string `json:"trashed_time,omitempty"`
+	TrashedBy   string `json:"trashed_by,omitempty"`
+}
+
+type ListTrashedFilesOutput struct {
+	Files         []TrashedFile `json:"files"`
+	NextPageToken string        `json:"next_page_token,omitempty"`
+}
+
+func createListTrashedFilesHandler(factory *services.Factory) mcp.ToolHandlerFor[ListTrashedFilesInput, ListTrashedFilesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListTrashedFilesInput) (*mcp.CallToolResult, ListTrashedFilesOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 25
+		}
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListTrashedFilesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Files.List().
+			Q("trashed = true").
+			PageSize(int64(input.PageSize)).
+			Fields("nextPageToken, files(id, name, mimeType, size, modifiedTime, webViewLink, trashedTime, trashingUser(emailAddress))").
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			OrderBy("modifiedTime desc").
+			Context(ctx)
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListTrashedFilesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		files := make([]TrashedFile, 0, len(result.Files))
+		rb := response.New()
+		rb.Header("Trashed Drive Files")
+		rb.KeyValue("Count", len(result.Files))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+
+		for _, f := range result.Files {
+			tf := TrashedFile{FileSummary: fileToSummary(f), TrashedTime: f.TrashedTime}
+			if f.TrashingUser != nil {
+				tf.TrashedBy = f.TrashingUser.EmailAddress
+			}
+			files = append(files, tf)
+			rb.Item("%s (%s)", tf.Name, formatFileType(tf.MimeType))
+			rb.Line("    ID: %s", tf.ID)
+			if tf.TrashedTime != "" {
+				rb.Line("    Trashed: %s", tf.TrashedTime)
+			}
+		}
+
+		return rb.TextResult(), ListTrashedFilesOutput{Files: files, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// --- delete_drive_file_permanently (extended) ---
+
+type DeleteFileInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileID    string `json:"file_id" jsonschema:"required" jsonschema_description:"The file or folder ID to delete"`
+}
+
+func createDeleteFileHandler(factory *services.Factory) mcp.ToolHandlerFor[DeleteFileInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DeleteFileInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		if err := srv.Files.Delete(input.FileID).SupportsAllDrives(true).Context(ctx).Do(); err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("File Deleted Permanently")
+		rb.KeyValue("ID", input.FileID)
+		rb.Line("The file was deleted without passing through trash and cannot be restored.")
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// --- empty_drive_trash (complete) ---
+
+type EmptyTrashInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DriveID   string `json:"drive_id,omitempty" jsonschema_description:"Shared drive whose trash to empty (default: the user's My Drive trash)"`
+}
+
+func createEmptyTrashHandler(factory *services.Factory) mcp.ToolHandlerFor[EmptyTrashInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input EmptyTrashInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Files.EmptyTrash().Context(ctx)
+		if input.DriveID != "" {
+			call = call.DriveId(input.DriveID)
+		}
+		if err := call.Do(); err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Drive Trash Emptied")
+		if input.DriveID != "" {
+			rb.KeyValue("Shared drive", input.DriveID)
+		}
+		rb.Line("Every trashed file was deleted permanently and cannot be restored.")
+
+		return rb.TextResult(), nil, nil
+	}
+}
@@ -0,0 +1,55 @@
+package drive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// recordBody answers every request with reply and keeps the last request body.
+type recordBody struct {
+	body  string
+	reply string
+}
+
+func (rec *recordBody) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Body != nil {
+		data, _ := io.ReadAll(r.Body)
+		rec.body = string(data)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(rec.reply)),
+		Request:    r,
+	}, nil
+}
+
+func TestTrashFileUntrashSendsFalse(t *testing.T) {
+	rec := &recordBody{reply: `{"id":"f1","name":"Plan","trashed":false}`}
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(rec)
+
+	_, out, err := createTrashFileHandler(factory)(context.Background(), &mcp.CallToolRequest{}, TrashFileInput{
+		UserEmail: "user@example.com",
+		FileID:    "f1",
+		Untrash:   true,
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if !strings.Contains(rec.body, `"trashed":false`) {
+		t.Errorf("request body = %s, want trashed:false sent explicitly", rec.body)
+	}
+	if out.Trashed || out.Name != "Plan" {
+		t.Errorf("output = %+v", out)
+	}
+}
@@ -0,0 +1,192 @@
+package drive
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// uploadChunkSize is both the threshold above which upload_drive_file
+// switches to a resumable upload and the size of each uploaded chunk. It
+// must be a multiple of 256 KiB.
+const uploadChunkSize = 5 << 20
+
+// --- upload_drive_file ---
+
+type UploadFileInput struct {
+	UserEmail     string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	FileName      string `json:"file_name,omitempty" jsonschema_description:"Name for the new file (default: the base name of local_path; required with content_base64)"`
+	ContentBase64 string `json:"content_base64,omitempty" jsonschema_description:"File content, base64-encoded (standard or URL-safe). Provide exactly one of content_base64 or local_path"`
+	LocalPath     string `json:"local_path,omitempty" jsonschema_description:"Path of a file on the server's filesystem to upload; only available when the server runs over stdio"`
+	FolderID      string `json:"folder_id,omitempty" jsonschema_description:"ID of the parent folder (default: root)"`
+	MimeType      string `json:"mime_type,omitempty" jsonschema_description:"MIME type of the file (default: guessed from the file name, else detected from the content)"`
+}
+
+type UploadFileOutput struct {
+	FileID      string `json:"file_id"`
+	Name        string `json:"name"`
+	MimeType    string `json:"mime_type"`
+	Size        int64  `json:"size"`
+	Resumable   bool   `json:"resumable,omitempty"`
+	WebViewLink string `json:"web_view_link,omitempty"`
+}
+
+// uploadSource is the content of an upload and its size in bytes.
+type uploadSource struct {
+	r    io.Reader
+	size int64
+	name string
+}
+
+// openUploadSource resolves the content of an upload_drive_file call.
+// localFiles reports whether local_path may be read.
+func openUploadSource(input UploadFileInput, localFiles bool) (uploadSource, func(), error) {
+	noop := func() {}
+	if (input.ContentBase64 == "") == (input.LocalPath == "") {
+		return uploadSource{}, noop, fmt.Errorf("provide exactly one of content_base64 or local_path")
+	}
+
+	if input.ContentBase64 != "" {
+		if input.FileName == "" {
+			return uploadSource{}, noop, fmt.Errorf("file_name is required with content_base64")
+		}
+		data, err := decodeBase64(input.ContentBase64)
+		if err != nil {
+			return uploadSource{}, noop, fmt.Errorf("invalid content_base64: %w", err)
+		}
+		return uploadSource{r: bytes.NewReader(data), size: int64(len(data)), name: input.FileName}, noop, nil
+	}
+
+	if !localFiles {
+		return uploadSource{}, noop, fmt.Errorf("local_path is only available when the server runs over stdio — send the file as content_base64 instead")
+	}
+	f, err := os.Open(input.LocalPath)
+	if err != nil {
+		return uploadSource{}, noop, fmt.Errorf("opening local_path: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return uploadSource{}, noop, fmt.Errorf("opening local_path: %w", err)
+	}
+	if info.IsDir() {
+		f.Close()
+		return uploadSource{}, noop, fmt.Errorf("local_path %q is a directory", input.LocalPath)
+	}
+	name := input.FileName
+	if name == "" {
+		name = filepath.Base(input.LocalPath)
+	}
+	return uploadSource{r: f, size: info.Size(), name: name}, func() { f.Close() }, nil
+}
+
+// decodeBase64 accepts standard or URL-safe base64, padded or not.
+func decodeBase64(s string) ([]byte, error) {
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if data, err := enc.DecodeString(s); err == nil {
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("not valid base64")
+}
+
+func createUploadFileHandler(factory *services.Factory, localFiles bool) mcp.ToolHandlerFor[UploadFileInput, UploadFileOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UploadFileInput) (*mcp.CallToolResult, UploadFileOutput, error) {
+		src, closeSrc, err := openUploadSource(input, localFiles)
+		if err != nil {
+			return nil, UploadFileOutput{}, err
+		}
+		defer closeSrc()
+
+		srv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, UploadFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		if input.MimeType == "" {
+			input.MimeType = mime.TypeByExtension(filepath.Ext(src.name))
+		}
+		fileMetadata := &drive.File{Name: src.name, MimeType: input.MimeType}
+		if input.FolderID != "" {
+			fileMetadata.Parents = []string{input.FolderID}
+		}
+		if stamp := factory.Provenance(req); stamp != nil {
+			fileMetadata.AppProperties = stamp.Properties()
+		}
+
+		// Files above one chunk go up as a resumable upload, one progress
+		// step per chunk; smaller ones are a single multipart request.
+		resumable := src.size > uploadChunkSize
+		chunks := 1
+		if resumable {
+			chunks = int((src.size + uploadChunkSize - 1) / uploadChunkSize)
+		}
+		p := progress.New(ctx, req).Begin("Uploading chunk", chunks)
+		if err := p.Next(); err != nil {
+			return nil, UploadFileOutput{}, err
+		}
+		call := srv.Files.Create(fileMetadata).
+			Media(src.r, googleapi.ChunkSize(uploadChunkSize)).
+			Fields("id, name, mimeType, size, webViewLink").
+			SupportsAllDrives(true).
+			Context(ctx)
+		if resumable {
+			call = call.ProgressUpdater(func(current, _ int64) {
+				if current < src.size {
+					_ = p.Next()
+				}
+			})
+		}
+		created, err := call.Do()
+		if err != nil {
+			if p.Cancelled() {
+				return nil, UploadFileOutput{}, fmt.Errorf("upload cancelled — no file was created: %w", ctx.Err())
+			}
+			return nil, UploadFileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		p.Done()
+
+		out := UploadFileOutput{
+			FileID:      created.Id,
+			Name:        created.Name,
+			MimeType:    created.MimeType,
+			Size:        src.size,
+			Resumable:   resumable,
+			WebViewLink: created.WebViewLink,
+		}
+		if out.Name == "" {
+			out.Name = src.name
+		}
+
+		rb := response.New()
+		rb.Header("File Uploaded")
+		rb.KeyValue("Name", out.Name)
+		rb.KeyValue("ID", out.FileID)
+		if out.MimeType != "" {
+			rb.KeyValue("Type", formatFileType(out.MimeType))
+		}
+		rb.KeyValue("Size", formatSize(out.Size))
+		if resumable {
+			rb.KeyValue("Upload", fmt.Sprintf("resumable, %d chunks", chunks))
+		}
+		if out.WebViewLink != "" {
+			rb.KeyValue("Link", out.WebViewLink)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
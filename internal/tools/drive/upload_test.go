@@ -0,0 +1,75 @@
+package drive
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/sandbox"
+)
+
+func TestOpenUploadSource(t *testing.T) {
+	data := []byte("\x89PNG binary \xff")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chart.png")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		input      UploadFileInput
+		localFiles bool
+		wantName   string
+		errMsg     string
+	}{
+		{"base64", UploadFileInput{FileName: "a.bin", ContentBase64: base64.StdEncoding.EncodeToString(data)}, false, "a.bin", ""},
+		{"url-safe base64", UploadFileInput{FileName: "a.bin", ContentBase64: base64.RawURLEncoding.EncodeToString(data)}, false, "a.bin", ""},
+		{"local path", UploadFileInput{LocalPath: path}, true, "chart.png", ""},
+		{"neither", UploadFileInput{FileName: "a.bin"}, true, "", "exactly one of"},
+		{"both", UploadFileInput{FileName: "a.bin", ContentBase64: "AA==", LocalPath: path}, true, "", "exactly one of"},
+		{"base64 without name", UploadFileInput{ContentBase64: "AA=="}, false, "", "file_name is required"},
+		{"bad base64", UploadFileInput{FileName: "a.bin", ContentBase64: "%%%"}, false, "", "invalid content_base64"},
+		{"local path over http", UploadFileInput{LocalPath: path}, false, "", "only available when the server runs over stdio"},
+		{"directory", UploadFileInput{LocalPath: dir}, true, "", "is a directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, closeSrc, err := openUploadSource(tt.input, tt.localFiles)
+			defer closeSrc()
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("error = %v, want containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("openUploadSource() error = %v", err)
+			}
+			got, _ := io.ReadAll(src.r)
+			if src.name != tt.wantName || src.size != int64(len(data)) || string(got) != string(data) {
+				t.Errorf("source = %q, %d bytes %q; want %q, %d bytes", src.name, src.size, got, tt.wantName, len(data))
+			}
+		})
+	}
+}
+
+func TestUploadFileSandbox(t *testing.T) {
+	_, out, err := createUploadFileHandler(sandboxFactory(), false)(context.Background(), &mcp.CallToolRequest{}, UploadFileInput{
+		UserEmail:     sandbox.DemoUser,
+		FileName:      "report.pdf",
+		ContentBase64: base64.StdEncoding.EncodeToString([]byte("%PDF-1.4")),
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.FileID == "" || out.Name != "report.pdf" || out.Size != 8 || out.Resumable {
+		t.Errorf("output = %+v", out)
+	}
+}
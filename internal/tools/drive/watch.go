@@ -0,0 +1,349 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// watchPollInterval is how often each user's Drive changes feed is polled.
+const watchPollInterval = time.Minute
+
+// maxWatchesPerSession bounds how many files a single MCP session can watch.
+const maxWatchesPerSession = 25
+
+// watchLogger is the MCP logger name used for change notifications.
+const watchLogger = "drive-watch"
+
+// watchedFile is one file being watched by one or more MCP sessions, through
+// watch_drive_file (sessions) or a resource subscription (subscribers).
+type watchedFile struct {
+	name         string
+	modifiedTime string
+	sessions     map[*mcp.ServerSession]struct{}
+	subscribers  map[*mcp.ServerSession]struct{}
+}
+
+// userWatch holds a user's watched files and their changes-feed cursor.
+type userWatch struct {
+	pageToken string
+	files     map[string]*watchedFile
+	stop      context.CancelFunc
+}
+
+// fileChange is a detected modification of a watched file.
+type fileChange struct {
+	FileID       string `json:"file_id"`
+	Name         string `json:"name"`
+	ModifiedTime string `json:"modified_time,omitempty"`
+	ModifiedBy   string `json:"modified_by,omitempty"`
+	Removed      bool   `json:"removed,omitempty"`
+}
+
+// fileWatcher polls the Drive changes feed for every user with active
+// watches. It sends an MCP log notification (level "notice", logger
+// "drive-watch") to each session watching a file that changed, and a
+// resources/updated notification for changed files with resource
+// subscribers. A poller runs per user only while that user has at least one
+// watched file.
+type fileWatcher struct {
+	factory  *services.Factory
+	server   *mcp.Server
+	interval time.Duration
+
+	mu      sync.Mutex
+	users   map[string]*userWatch
+	closing map[*mcp.ServerSession]bool // sessions whose end is awaited
+}
+
+func newFileWatcher(factory *services.Factory, server *mcp.Server, interval time.Duration) *fileWatcher {
+	return &fileWatcher{
+		factory:  factory,
+		server:   server,
+		interval: interval,
+		users:    make(map[string]*userWatch),
+		closing:  make(map[*mcp.ServerSession]bool),
+	}
+}
+
+// watch registers session's interest in file, starting the user's poller if needed.
+func (w *fileWatcher) watch(ctx context.Context, srv *drive.Service, userEmail string, file *drive.File, session *mcp.ServerSession) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	wf, err := w.addLocked(ctx, srv, userEmail, file, session)
+	if err != nil {
+		return err
+	}
+	wf.sessions[session] = struct{}{}
+	return nil
+}
+
+// subscribe registers session's resource subscription to file.
+func (w *fileWatcher) subscribe(ctx context.Context, srv *drive.Service, userEmail string, file *drive.File, session *mcp.ServerSession) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	wf, err := w.addLocked(ctx, srv, userEmail, file, session)
+	if err != nil {
+		return err
+	}
+	wf.subscribers[session] = struct{}{}
+	return nil
+}
+
+// addLocked returns the watched entry for file, enforcing the per-session
+// limit and starting the user's poller if needed. Watches and subscriptions
+// alike are dropped when the session ends.
+func (w *fileWatcher) addLocked(ctx context.Context, srv *drive.Service, userEmail string, file *drive.File, session *mcp.ServerSession) (*watchedFile, error) {
+	if w.sessionWatchCountLocked(session) >= maxWatchesPerSession {
+		return nil, fmt.Errorf("this session already watches %d files — call unwatch_drive_file or unsubscribe before adding more", maxWatchesPerSession)
+	}
+
+	uw, ok := w.users[userEmail]
+	if !ok {
+		start, err := srv.Changes.GetStartPageToken().SupportsAllDrives(true).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		pollCtx, stop := context.WithCancel(context.Background())
+		uw = &userWatch{pageToken: start.StartPageToken, files: make(map[string]*watchedFile), stop: stop}
+		w.users[userEmail] = uw
+		go w.poll(pollCtx, userEmail)
+	}
+
+	wf, ok := uw.files[file.Id]
+	if !ok {
+		wf = &watchedFile{
+			name:         file.Name,
+			modifiedTime: file.ModifiedTime,
+			sessions:     make(map[*mcp.ServerSession]struct{}),
+			subscribers:  make(map[*mcp.ServerSession]struct{}),
+		}
+		uw.files[file.Id] = wf
+	}
+	if !w.closing[session] {
+		w.closing[session] = true
+		go w.dropOnClose(session)
+	}
+	return wf, nil
+}
+
+// unwatch removes session's interest in a file and reports whether it was watched.
+func (w *fileWatcher) unwatch(userEmail, fileID string, session *mcp.ServerSession) bool {
+	return w.remove(userEmail, fileID, session, func(wf *watchedFile) map[*mcp.ServerSession]struct{} { return wf.sessions })
+}
+
+// unsubscribe removes session's resource subscription to a file and reports
+// whether it was subscribed.
+func (w *fileWatcher) unsubscribe(userEmail, fileID string, session *mcp.ServerSession) bool {
+	return w.remove(userEmail, fileID, session, func(wf *watchedFile) map[*mcp.ServerSession]struct{} { return wf.subscribers })
+}
+
+// remove deletes session from the set of file that set selects.
+func (w *fileWatcher) remove(userEmail, fileID string, session *mcp.ServerSession, set func(*watchedFile) map[*mcp.ServerSession]struct{}) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	uw, ok := w.users[userEmail]
+	if !ok {
+		return false
+	}
+	wf, ok := uw.files[fileID]
+	if !ok {
+		return false
+	}
+	if _, ok := set(wf)[session]; !ok {
+		return false
+	}
+	delete(set(wf), session)
+	w.pruneLocked(userEmail)
+	return true
+}
+
+// dropOnClose waits for session to end and then forgets all its watches.
+func (w *fileWatcher) dropOnClose(session *mcp.ServerSession) {
+	session.Wait()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.closing, session)
+	for userEmail, uw := range w.users {
+		for _, wf := range uw.files {
+			delete(wf.sessions, session)
+			delete(wf.subscribers, session)
+		}
+		w.pruneLocked(userEmail)
+	}
+}
+
+// pruneLocked drops files nobody watches and stops idle user pollers.
+func (w *fileWatcher) pruneLocked(userEmail string) {
+	uw := w.users[userEmail]
+	for id, wf := range uw.files {
+		if len(wf.sessions) == 0 && len(wf.subscribers) == 0 {
+			delete(uw.files, id)
+		}
+	}
+	if len(uw.files) == 0 {
+		uw.stop()
+		delete(w.users, userEmail)
+	}
+}
+
+func (w *fileWatcher) sessionWatchCountLocked(session *mcp.ServerSession) int {
+	n := 0
+	for _, uw := range w.users {
+		for _, wf := range uw.files {
+			if _, ok := wf.sessions[session]; ok {
+				n++
+			}
+			if _, ok := wf.subscribers[session]; ok {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// poll checks the user's changes feed every interval until ctx is cancelled.
+func (w *fileWatcher) poll(ctx context.Context, userEmail string) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := w.checkChanges(ctx, userEmail); err != nil {
+			slog.Warn("drive watch poll failed", "email", userEmail, "error", err)
+		}
+	}
+}
+
+// checkChanges reads the changes feed from the stored cursor and notifies
+// sessions watching any changed file.
+func (w *fileWatcher) checkChanges(ctx context.Context, userEmail string) error {
+	w.mu.Lock()
+	uw, ok := w.users[userEmail]
+	if !ok {
+		w.mu.Unlock()
+		return nil
+	}
+	token := uw.pageToken
+	w.mu.Unlock()
+
+	srv, err := w.factory.Drive(ctx, userEmail)
+	if err != nil {
+		return err
+	}
+
+	var changes []*drive.Change
+	for token != "" {
+		page, err := srv.Changes.List(token).
+			Fields("nextPageToken, newStartPageToken, changes(fileId, removed, file(name, modifiedTime, trashed, lastModifyingUser(displayName, emailAddress)))").
+			IncludeItemsFromAllDrives(true).
+			SupportsAllDrives(true).
+			PageSize(100).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return err
+		}
+		changes = append(changes, page.Changes...)
+		if page.NewStartPageToken != "" {
+			token = page.NewStartPageToken
+			break
+		}
+		token = page.NextPageToken
+	}
+
+	w.mu.Lock()
+	uw, ok = w.users[userEmail]
+	if !ok {
+		w.mu.Unlock()
+		return nil
+	}
+	uw.pageToken = token
+	notify, updated := matchChanges(uw.files, changes)
+	w.mu.Unlock()
+
+	for session, fcs := range notify {
+		for _, fc := range fcs {
+			w.send(ctx, userEmail, session, fc)
+		}
+	}
+	for _, fileID := range updated {
+		w.server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: resourceURI(fileID)})
+	}
+	return nil
+}
+
+// matchChanges returns, per watching session, the watched files that were
+// modified or removed, and the IDs of those files with resource subscribers.
+// It updates each watched file's last-seen modification time so the same
+// edit is not reported twice.
+func matchChanges(files map[string]*watchedFile, changes []*drive.Change) (map[*mcp.ServerSession][]fileChange, []string) {
+	out := make(map[*mcp.ServerSession][]fileChange)
+	var updated []string
+	for _, c := range changes {
+		wf, ok := files[c.FileId]
+		if !ok {
+			continue
+		}
+		fc := fileChange{FileID: c.FileId, Name: wf.name}
+		switch {
+		case c.Removed || (c.File != nil && c.File.Trashed):
+			fc.Removed = true
+		case c.File != nil && c.File.ModifiedTime != wf.modifiedTime:
+			fc.ModifiedTime = c.File.ModifiedTime
+			if u := c.File.LastModifyingUser; u != nil {
+				fc.ModifiedBy = u.DisplayName
+				if u.EmailAddress != "" {
+					fc.ModifiedBy = fmt.Sprintf("%s <%s>", u.DisplayName, u.EmailAddress)
+				}
+			}
+			wf.modifiedTime = c.File.ModifiedTime
+			if c.File.Name != "" {
+				wf.name, fc.Name = c.File.Name, c.File.Name
+			}
+		default:
+			continue // metadata-only change (e.g. sharing) or already reported
+		}
+		for session := range wf.sessions {
+			out[session] = append(out[session], fc)
+		}
+		if len(wf.subscribers) > 0 {
+			updated = append(updated, c.FileId)
+		}
+	}
+	return out, updated
+}
+
+// send delivers one change notification. Sessions that can no longer be
+// reached are unsubscribed from all of the user's files.
+func (w *fileWatcher) send(ctx context.Context, userEmail string, session *mcp.ServerSession, fc fileChange) {
+	err := session.Log(ctx, &mcp.LoggingMessageParams{
+		Level:  "notice",
+		Logger: watchLogger,
+		Data:   fc,
+	})
+	if err == nil {
+		return
+	}
+
+	slog.Debug("dropping drive watches for unreachable session", "email", userEmail, "error", err)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if uw, ok := w.users[userEmail]; ok {
+		for _, wf := range uw.files {
+			delete(wf.sessions, session)
+		}
+		w.pruneLocked(userEmail)
+	}
+}
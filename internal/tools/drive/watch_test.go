@@ -0,0 +1,273 @@
+package drive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+func TestMatchChanges(t *testing.T) {
+	s1, s2 := &mcp.ServerSession{}, &mcp.ServerSession{}
+	files := map[string]*watchedFile{
+		"doc": {name: "Contract", modifiedTime: "2025-06-01T10:00:00Z", sessions: map[*mcp.ServerSession]struct{}{s1: {}, s2: {}}},
+		"old": {name: "Old notes", modifiedTime: "2025-05-01T10:00:00Z", sessions: map[*mcp.ServerSession]struct{}{s1: {}}, subscribers: map[*mcp.ServerSession]struct{}{s2: {}}},
+	}
+	changes := []*drive.Change{
+		{FileId: "unwatched", File: &drive.File{ModifiedTime: "2025-06-02T00:00:00Z"}},
+		{FileId: "doc", File: &drive.File{
+			Name:              "Contract v2",
+			ModifiedTime:      "2025-06-02T09:00:00Z",
+			LastModifyingUser: &drive.User{DisplayName: "Ana", EmailAddress: "ana@example.com"},
+		}},
+		// Sharing-only change: same modifiedTime, not reported.
+		{FileId: "doc", File: &drive.File{ModifiedTime: "2025-06-02T09:00:00Z"}},
+		{FileId: "old", Removed: true},
+	}
+
+	got, updated := matchChanges(files, changes)
+
+	if len(got[s1]) != 2 {
+		t.Fatalf("session 1: expected 2 changes, got %d", len(got[s1]))
+	}
+	if len(got[s2]) != 1 {
+		t.Fatalf("session 2: expected 1 change, got %d", len(got[s2]))
+	}
+	edit := got[s2][0]
+	if edit.Name != "Contract v2" || edit.ModifiedBy != "Ana <ana@example.com>" {
+		t.Errorf("unexpected edit notification: %+v", edit)
+	}
+	if !got[s1][1].Removed {
+		t.Errorf("expected removal notification, got %+v", got[s1][1])
+	}
+	if len(updated) != 1 || updated[0] != "old" {
+		t.Errorf("resource updates = %v, want [old]", updated)
+	}
+	if files["doc"].modifiedTime != "2025-06-02T09:00:00Z" {
+		t.Errorf("expected last-seen modified time to advance, got %s", files["doc"].modifiedTime)
+	}
+}
+
+// startTokenOnly answers every Drive request with a changes start page token.
+type startTokenOnly struct{}
+
+func (startTokenOnly) RoundTrip(r *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"startPageToken": "1"}`)),
+		Request:    r,
+	}, nil
+}
+
+func TestWatchDroppedOnSessionClose(t *testing.T) {
+	ctx := context.Background()
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(startTokenOnly{})
+	srv, err := factory.Drive(ctx, "user@example.com")
+	if err != nil {
+		t.Fatalf("drive service: %v", err)
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	st, ct := mcp.NewInMemoryTransports()
+	session, err := server.Connect(ctx, st, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	client, err := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"}, nil).Connect(ctx, ct, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+
+	w := newFileWatcher(factory, server, time.Hour)
+	if err := w.watch(ctx, srv, "user@example.com", &drive.File{Id: "doc", Name: "Contract"}, session); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	// Observe the poller being stopped.
+	stopped := make(chan struct{})
+	w.mu.Lock()
+	uw, ok := w.users["user@example.com"]
+	if ok {
+		cancel := uw.stop
+		uw.stop = func() { cancel(); close(stopped) }
+	}
+	w.mu.Unlock()
+	if !ok {
+		t.Fatal("watch did not start a poller")
+	}
+
+	client.Close()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("poller still running after the session closed")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.users) != 0 || len(w.closing) != 0 {
+		t.Errorf("after session close: %d users and %d sessions still tracked", len(w.users), len(w.closing))
+	}
+}
+
+// watchSession connects an in-memory client and returns the server side of
+// the session; the client is closed when the test ends.
+func watchSession(t *testing.T, server *mcp.Server) *mcp.ServerSession {
+	t.Helper()
+	ctx := context.Background()
+	st, ct := mcp.NewInMemoryTransports()
+	session, err := server.Connect(ctx, st, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	client, err := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"}, nil).Connect(ctx, ct, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return session
+}
+
+// watchAPI answers files.get for any ID except "missing" and
+// changes.getStartPageToken with startStatus, when set.
+func watchAPI(startStatus int) roundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		status, body := http.StatusOK, `{}`
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/changes/startPageToken") && startStatus != 0:
+			status, body = startStatus, `{"error":{"code":403,"message":"Insufficient permissions"}}`
+		case strings.HasSuffix(r.URL.Path, "/changes/startPageToken"):
+			body = `{"startPageToken":"1"}`
+		case strings.HasSuffix(r.URL.Path, "/files/missing"):
+			status, body = http.StatusNotFound, `{"error":{"code":404,"message":"File not found: missing"}}`
+		case strings.Contains(r.URL.Path, "/files/"):
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			body = `{"id":"` + id + `","name":"Contract","mimeType":"application/pdf","modifiedTime":"2025-06-01T10:00:00Z"}`
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}
+}
+
+func TestWatchFileHandlers(t *testing.T) {
+	ctx := context.Background()
+	factory := fakeDriveFactory(watchAPI(0))
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	req := &mcp.CallToolRequest{Session: watchSession(t, server)}
+	w := newFileWatcher(factory, server, time.Hour)
+	input := WatchFileInput{UserEmail: "user@example.com", FileID: "doc"}
+
+	res, out, err := createWatchFileHandler(factory, w)(ctx, req, input)
+	if err != nil {
+		t.Fatalf("watch error = %v", err)
+	}
+	if out != nil {
+		t.Errorf("watch structured output = %v, want none", out)
+	}
+	text := res.Content[0].(*mcp.TextContent).Text
+	for _, want := range []string{"Watching Drive File", "File: Contract", "ID: doc", "Last modified: 2025-06-01T10:00:00Z", "Check interval: 1m0s", `logger "drive-watch"`} {
+		if !strings.Contains(text, want) {
+			t.Errorf("watch text missing %q:\n%s", want, text)
+		}
+	}
+	w.mu.Lock()
+	uw := w.users["user@example.com"]
+	watched := uw != nil && uw.pageToken == "1" && uw.files["doc"] != nil && len(uw.files["doc"].sessions) == 1
+	w.mu.Unlock()
+	if !watched {
+		t.Fatal("watch did not register the session for the file")
+	}
+
+	unwatch := createUnwatchFileHandler(w)
+	res, _, err = unwatch(ctx, req, input)
+	if err != nil {
+		t.Fatalf("unwatch error = %v", err)
+	}
+	if text := res.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "Stopped Watching Drive File") || !strings.Contains(text, "ID: doc") {
+		t.Errorf("unwatch text = %q", text)
+	}
+	res, _, err = unwatch(ctx, req, input)
+	if err != nil {
+		t.Fatalf("second unwatch error = %v", err)
+	}
+	if text := res.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "Drive File Was Not Watched") {
+		t.Errorf("second unwatch text = %q", text)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.users) != 0 {
+		t.Errorf("%d users still polled after the last unwatch", len(w.users))
+	}
+}
+
+func TestWatchFileHandlersValidation(t *testing.T) {
+	ctx := context.Background()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	session := watchSession(t, server)
+	input := WatchFileInput{UserEmail: "user@example.com", FileID: "doc"}
+
+	tests := []struct {
+		name        string
+		startStatus int
+		req         *mcp.CallToolRequest
+		input       WatchFileInput
+		want        string
+	}{
+		{"no session", 0, &mcp.CallToolRequest{}, input, "requires an active MCP session"},
+		{"invalid email", 0, &mcp.CallToolRequest{Session: session}, WatchFileInput{UserEmail: "not-an-email", FileID: "doc"}, "invalid user email"},
+		{"file not found", 0, &mcp.CallToolRequest{Session: session}, WatchFileInput{UserEmail: "user@example.com", FileID: "missing"}, "resource not found"},
+		{"changes feed denied", http.StatusForbidden, &mcp.CallToolRequest{Session: session}, input, "permission denied"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := fakeDriveFactory(watchAPI(tt.startStatus))
+			factory.SetRetryPolicies(services.RetryPolicy{}, nil)
+			w := newFileWatcher(factory, server, time.Hour)
+
+			_, _, err := createWatchFileHandler(factory, w)(ctx, tt.req, tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+			if len(w.users) != 0 {
+				t.Error("a failed watch started a poller")
+			}
+		})
+	}
+
+	if _, _, err := createUnwatchFileHandler(newFileWatcher(nil, server, time.Hour))(ctx, &mcp.CallToolRequest{}, input); err == nil || !strings.Contains(err.Error(), "requires an active MCP session") {
+		t.Errorf("unwatch without a session error = %v", err)
+	}
+}
+
+func TestWatchFileHandlerSessionLimit(t *testing.T) {
+	ctx := context.Background()
+	factory := fakeDriveFactory(watchAPI(0))
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	req := &mcp.CallToolRequest{Session: watchSession(t, server)}
+	w := newFileWatcher(factory, server, time.Hour)
+	handler := createWatchFileHandler(factory, w)
+
+	for i := range maxWatchesPerSession {
+		if _, _, err := handler(ctx, req, WatchFileInput{UserEmail: "user@example.com", FileID: fmt.Sprintf("f%d", i)}); err != nil {
+			t.Fatalf("watch %d error = %v", i, err)
+		}
+	}
+	_, _, err := handler(ctx, req, WatchFileInput{UserEmail: "user@example.com", FileID: "one-too-many"})
+	if err == nil || !strings.Contains(err.Error(), "already watches 25 files") {
+		t.Errorf("error = %v, want the per-session limit", err)
+	}
+}
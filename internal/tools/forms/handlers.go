@@ -39,6 +39,7 @@ func createCreateFormHandler(factory *services.Factory) mcp.ToolHandlerFor[Creat
 		if err != nil {
 			return nil, nil, middleware.HandleGoogleAPIError(err)
 		}
+		factory.StampDriveFile(ctx, input.UserEmail, created.FormId, factory.Provenance(req))
 
 		rb := response.New()
 		rb.Header("Form Created")
@@ -0,0 +1,172 @@
+package gmail
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// maxAttachmentsSize is Gmail's 25 MB limit on a message's attachments.
+// Base64 encoding grows the message by a third, which the 35 MB raw upload
+// limit still accommodates.
+const maxAttachmentsSize = 25 << 20
+
+// AttachmentInput is a file to attach to an outgoing message, given either
+// as base64 content or as a Drive file ID.
+type AttachmentInput struct {
+	Filename    string `json:"filename,omitempty" jsonschema_description:"File name shown to recipients (required with content; defaults to the Drive file name)"`
+	Content     string `json:"content,omitempty" jsonschema_description:"Base64-encoded file content (standard or URL-safe alphabet)"`
+	DriveFileID string `json:"drive_file_id,omitempty" jsonschema_description:"Drive file to attach instead of content; Google Docs, Sheets, and Slides are attached as PDF"`
+	MimeType    string `json:"mime_type,omitempty" jsonschema_description:"Content type (default: detected from the file name or content)"`
+}
+
+// mailAttachment is a resolved attachment ready for MIME encoding.
+type mailAttachment struct {
+	Filename string
+	MimeType string
+	Data     []byte
+}
+
+// decodeAttachmentContent accepts standard or URL-safe base64, padded or not.
+func decodeAttachmentContent(s string) ([]byte, error) {
+	s = strings.Map(func(r rune) rune {
+		if r == '\r' || r == '\n' || r == ' ' || r == '\t' {
+			return -1
+		}
+		return r
+	}, s)
+	s = strings.TrimRight(s, "=")
+	if strings.ContainsAny(s, "-_") {
+		return base64.RawURLEncoding.DecodeString(s)
+	}
+	return base64.RawStdEncoding.DecodeString(s)
+}
+
+// detectContentType guesses a content type from the file extension, falling
+// back to sniffing the content.
+func detectContentType(filename string, data []byte) string {
+	if t := mime.TypeByExtension(strings.ToLower(filepath.Ext(filename))); t != "" {
+		return t
+	}
+	return http.DetectContentType(data)
+}
+
+// resolveAttachments decodes inline attachments and downloads Drive ones,
+// enforcing maxAttachmentsSize across all of them.
+func resolveAttachments(ctx context.Context, factory *services.Factory, userEmail string, inputs []AttachmentInput) ([]mailAttachment, error) {
+	var (
+		out   []mailAttachment
+		total int
+		srv   *drive.Service
+	)
+	for i, in := range inputs {
+		var (
+			att mailAttachment
+			err error
+		)
+		switch {
+		case in.Content != "" && in.DriveFileID != "":
+			return nil, fmt.Errorf("attachment %d: set content or drive_file_id, not both", i+1)
+		case in.Content != "":
+			if in.Filename == "" {
+				return nil, fmt.Errorf("attachment %d: filename is required with content", i+1)
+			}
+			att.Filename = in.Filename
+			if att.Data, err = decodeAttachmentContent(in.Content); err != nil {
+				return nil, fmt.Errorf("attachment %q: content is not valid base64: %w", in.Filename, err)
+			}
+		case in.DriveFileID != "":
+			if srv == nil {
+				if srv, err = factory.Drive(ctx, userEmail); err != nil {
+					return nil, err
+				}
+			}
+			if att, err = downloadDriveAttachment(ctx, srv, in.DriveFileID, maxAttachmentsSize-total); err != nil {
+				return nil, err
+			}
+			if in.Filename != "" {
+				att.Filename = in.Filename
+			}
+		default:
+			return nil, fmt.Errorf("attachment %d: set content or drive_file_id", i+1)
+		}
+
+		total += len(att.Data)
+		if total > maxAttachmentsSize {
+			return nil, fmt.Errorf("attachments exceed Gmail's 25 MB limit — share large files as Drive links instead")
+		}
+		switch {
+		case in.MimeType != "":
+			att.MimeType = in.MimeType
+		case att.MimeType == "":
+			att.MimeType = detectContentType(att.Filename, att.Data)
+		}
+		out = append(out, att)
+	}
+	return out, nil
+}
+
+// downloadDriveAttachment fetches a Drive file, exporting Google native files
+// as PDF. At most limit bytes are accepted.
+func downloadDriveAttachment(ctx context.Context, srv *drive.Service, fileID string, limit int) (mailAttachment, error) {
+	file, err := srv.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields("id,name,mimeType,size").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return mailAttachment{}, err
+	}
+
+	att := mailAttachment{Filename: file.Name, MimeType: file.MimeType}
+	var resp *http.Response
+	if strings.HasPrefix(file.MimeType, "application/vnd.google-apps.") {
+		if file.MimeType == "application/vnd.google-apps.folder" || file.MimeType == "application/vnd.google-apps.shortcut" {
+			return mailAttachment{}, fmt.Errorf("drive file %q is a %s and cannot be attached", file.Name, strings.TrimPrefix(file.MimeType, "application/vnd.google-apps."))
+		}
+		att.MimeType = "application/pdf"
+		if !strings.HasSuffix(strings.ToLower(att.Filename), ".pdf") {
+			att.Filename += ".pdf"
+		}
+		resp, err = srv.Files.Export(file.Id, att.MimeType).Context(ctx).Download()
+	} else {
+		if file.Size > int64(limit) {
+			return mailAttachment{}, fmt.Errorf("drive file %q (%d bytes) exceeds Gmail's 25 MB attachment limit — share it as a Drive link instead", file.Name, file.Size)
+		}
+		resp, err = srv.Files.Get(file.Id).SupportsAllDrives(true).Context(ctx).Download()
+	}
+	if err != nil {
+		return mailAttachment{}, err
+	}
+	defer resp.Body.Close()
+
+	att.Data, err = io.ReadAll(io.LimitReader(resp.Body, int64(limit)+1))
+	if err != nil {
+		return mailAttachment{}, fmt.Errorf("reading drive file %q: %w", file.Name, err)
+	}
+	if len(att.Data) > limit {
+		return mailAttachment{}, fmt.Errorf("drive file %q exceeds Gmail's 25 MB attachment limit — share it as a Drive link instead", file.Name)
+	}
+	return att, nil
+}
+
+// writeAttachmentList lists the attachments of a sent or drafted message.
+func writeAttachmentList(rb *response.Builder, attachments []mailAttachment) {
+	if len(attachments) == 0 {
+		return
+	}
+	rb.KeyValue("Attachments", len(attachments))
+	for _, a := range attachments {
+		rb.Line("    • %s (%s, %s)", a.Filename, a.MimeType, formatAttachmentSize(int64(len(a.Data))))
+	}
+}
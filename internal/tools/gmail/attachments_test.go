@@ -0,0 +1,127 @@
+package gmail
+
+import (
+	"context"
+	"encoding/base64"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestDecodeAttachmentContent(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0xfe, 'h', 'i'}
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"standard", base64.StdEncoding.EncodeToString(data)},
+		{"url-safe", base64.URLEncoding.EncodeToString(data)},
+		{"unpadded", base64.RawStdEncoding.EncodeToString(data)},
+		{"wrapped", base64.StdEncoding.EncodeToString(data)[:4] + "\r\n" + base64.StdEncoding.EncodeToString(data)[4:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeAttachmentContent(tt.in)
+			if err != nil {
+				t.Fatalf("decodeAttachmentContent(%q) error: %v", tt.in, err)
+			}
+			if string(got) != string(data) {
+				t.Errorf("decodeAttachmentContent(%q) = %v, want %v", tt.in, got, data)
+			}
+		})
+	}
+	if _, err := decodeAttachmentContent("not base64!"); err == nil {
+		t.Error("expected an error for invalid base64")
+	}
+}
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		filename string
+		data     []byte
+		want     string
+	}{
+		{"report.PDF", nil, "application/pdf"},
+		{"notes", []byte("plain words"), "text/plain; charset=utf-8"},
+		{"image", []byte("\x89PNG\r\n\x1a\n"), "image/png"},
+	}
+	for _, tt := range tests {
+		if got := detectContentType(tt.filename, tt.data); got != tt.want {
+			t.Errorf("detectContentType(%q) = %q, want %q", tt.filename, got, tt.want)
+		}
+	}
+}
+
+func TestResolveAttachmentsValidation(t *testing.T) {
+	big := base64.StdEncoding.EncodeToString(make([]byte, maxAttachmentsSize/2+1))
+	tests := []struct {
+		name   string
+		inputs []AttachmentInput
+		errMsg string
+	}{
+		{"empty", []AttachmentInput{{Filename: "a.txt"}}, "set content or drive_file_id"},
+		{"both", []AttachmentInput{{Filename: "a.txt", Content: "aGk=", DriveFileID: "x"}}, "not both"},
+		{"no filename", []AttachmentInput{{Content: "aGk="}}, "filename is required"},
+		{"bad base64", []AttachmentInput{{Filename: "a.txt", Content: "%%%"}}, "not valid base64"},
+		{"too large", []AttachmentInput{{Filename: "a.bin", Content: big}, {Filename: "b.bin", Content: big}}, "25 MB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveAttachments(context.Background(), nil, "user@example.com", tt.inputs)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("resolveAttachments() error = %v, want containing %q", err, tt.errMsg)
+			}
+		})
+	}
+
+	got, err := resolveAttachments(context.Background(), nil, "user@example.com", []AttachmentInput{{Filename: "a.csv", Content: "YSxi"}})
+	if err != nil {
+		t.Fatalf("resolveAttachments() error: %v", err)
+	}
+	if len(got) != 1 || string(got[0].Data) != "a,b" || !strings.HasPrefix(got[0].MimeType, "text/csv") {
+		t.Errorf("resolveAttachments() = %+v", got)
+	}
+}
+
+func TestBuildRawMessageWithAttachments(t *testing.T) {
+	raw := buildRawMessage("bob@example.com", "Report", "See attached.", "", "", "", "", "", "",
+		mailAttachment{Filename: "résumé.pdf", MimeType: "application/pdf", Data: []byte(strings.Repeat("%PDF", 40))})
+
+	decoded, err := base64.URLEncoding.DecodeString(raw)
+	if err != nil {
+		t.Fatalf("decoding raw message: %v", err)
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(string(decoded)))
+	if err != nil {
+		t.Fatalf("parsing message: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("Content-Type = %q, %v", msg.Header.Get("Content-Type"), err)
+	}
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	body, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("reading body part: %v", err)
+	}
+	if ct := body.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("body Content-Type = %q", ct)
+	}
+
+	att, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("reading attachment part: %v", err)
+	}
+	if att.FileName() != "résumé.pdf" {
+		t.Errorf("attachment filename = %q", att.FileName())
+	}
+	if enc := att.Header.Get("Content-Transfer-Encoding"); enc != "base64" {
+		t.Errorf("attachment encoding = %q", enc)
+	}
+	if _, err := mr.NextPart(); err == nil {
+		t.Error("expected exactly two parts")
+	}
+}
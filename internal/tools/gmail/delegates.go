@@ -0,0 +1,122 @@
+package gmail
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// The delegate tools go through factory.GmailDelegated: Google accepts
+// users.settings.delegates calls only from a service account with
+// domain-wide delegation, never with the user's own OAuth token.
+
+// DelegateInfo is a mailbox delegate and its verification status.
+type DelegateInfo struct {
+	Email  string `json:"email"`
+	Status string `json:"status"`
+}
+
+// --- list_gmail_delegates (complete) ---
+
+type ListDelegatesInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The mailbox owner's Google email address"`
+}
+
+type ListDelegatesOutput struct {
+	Delegates []DelegateInfo `json:"delegates"`
+}
+
+func createListDelegatesHandler(factory *services.Factory) mcp.ToolHandlerFor[ListDelegatesInput, ListDelegatesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListDelegatesInput) (*mcp.CallToolResult, ListDelegatesOutput, error) {
+		srv, err := factory.GmailDelegated(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListDelegatesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		resp, err := srv.Users.Settings.Delegates.List(input.UserEmail).Context(ctx).Do()
+		if err != nil {
+			return nil, ListDelegatesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := ListDelegatesOutput{Delegates: make([]DelegateInfo, 0, len(resp.Delegates))}
+		rb := response.New()
+		rb.Header("Gmail Delegates")
+		rb.KeyValue("Mailbox", input.UserEmail)
+		rb.KeyValue("Delegates", len(resp.Delegates))
+		if len(resp.Delegates) > 0 {
+			rb.Blank()
+		}
+		for _, d := range resp.Delegates {
+			out.Delegates = append(out.Delegates, DelegateInfo{Email: d.DelegateEmail, Status: d.VerificationStatus})
+			rb.Item("%s [%s]", d.DelegateEmail, d.VerificationStatus)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- add_gmail_delegate (complete) ---
+
+type AddDelegateInput struct {
+	UserEmail     string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The mailbox owner's Google email address"`
+	DelegateEmail string `json:"delegate_email" jsonschema:"required" jsonschema_description:"Address of the user who gets access to the mailbox (same organization)"`
+}
+
+func createAddDelegateHandler(factory *services.Factory) mcp.ToolHandlerFor[AddDelegateInput, DelegateInfo] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input AddDelegateInput) (*mcp.CallToolResult, DelegateInfo, error) {
+		srv, err := factory.GmailDelegated(ctx, input.UserEmail)
+		if err != nil {
+			return nil, DelegateInfo{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		d, err := srv.Users.Settings.Delegates.Create(input.UserEmail, &gmail.Delegate{
+			DelegateEmail: input.DelegateEmail,
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, DelegateInfo{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := DelegateInfo{Email: d.DelegateEmail, Status: d.VerificationStatus}
+		rb := response.New()
+		rb.Header("Gmail Delegate Added")
+		rb.KeyValue("Mailbox", input.UserEmail)
+		rb.KeyValue("Delegate", out.Email)
+		rb.KeyValue("Status", out.Status)
+		if out.Status == "pending" {
+			rb.Line("The delegate must accept the invitation email before gaining access.")
+		}
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- remove_gmail_delegate (complete) ---
+
+type RemoveDelegateInput struct {
+	UserEmail     string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The mailbox owner's Google email address"`
+	DelegateEmail string `json:"delegate_email" jsonschema:"required" jsonschema_description:"Address of the delegate to remove"`
+}
+
+func createRemoveDelegateHandler(factory *services.Factory) mcp.ToolHandlerFor[RemoveDelegateInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input RemoveDelegateInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.GmailDelegated(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		err = srv.Users.Settings.Delegates.Delete(input.UserEmail, input.DelegateEmail).Context(ctx).Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Gmail Delegate Removed")
+		rb.KeyValue("Mailbox", input.UserEmail)
+		rb.KeyValue("Delegate", input.DelegateEmail)
+		return rb.TextResult(), nil, nil
+	}
+}
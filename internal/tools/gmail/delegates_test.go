@@ -0,0 +1,131 @@
+package gmail
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// delegateServer fakes users.settings.delegates and records the last
+// request. status, when set, fails every call with that code.
+type delegateServer struct {
+	status int
+	method string
+	path   string
+	body   string
+}
+
+func (s *delegateServer) RoundTrip(r *http.Request) (*http.Response, error) {
+	s.method, s.path = r.Method, r.URL.Path
+	if r.Body != nil {
+		data, _ := io.ReadAll(r.Body)
+		s.body = string(data)
+	}
+	status, body := http.StatusOK, `{}`
+	switch {
+	case s.status != 0:
+		status, body = s.status, `{"error":{"code":403,"message":"Delegation denied for boss@example.com","errors":[{"reason":"forbidden"}]}}`
+	case r.Method == http.MethodGet:
+		body = `{"delegates":[{"delegateEmail":"assistant@example.com","verificationStatus":"accepted"},{"delegateEmail":"deputy@example.com","verificationStatus":"pending"}]}`
+	case r.Method == http.MethodPost:
+		body = `{"delegateEmail":"assistant@example.com","verificationStatus":"pending"}`
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func delegateFactory(rt http.RoundTripper) *services.Factory {
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	if rt != nil {
+		factory.SetSandbox(rt)
+	}
+	return factory
+}
+
+func resultText(res *mcp.CallToolResult) string {
+	var b strings.Builder
+	for _, c := range res.Content {
+		if tc, ok := c.(*mcp.TextContent); ok {
+			b.WriteString(tc.Text)
+		}
+	}
+	return b.String()
+}
+
+func TestDelegateToolsRequireDelegation(t *testing.T) {
+	factory := delegateFactory(nil)
+	ctx := context.Background()
+	user := "boss@example.com"
+
+	_, _, listErr := createListDelegatesHandler(factory)(ctx, &mcp.CallToolRequest{}, ListDelegatesInput{UserEmail: user})
+	_, _, addErr := createAddDelegateHandler(factory)(ctx, &mcp.CallToolRequest{}, AddDelegateInput{UserEmail: user, DelegateEmail: "assistant@example.com"})
+	_, _, removeErr := createRemoveDelegateHandler(factory)(ctx, &mcp.CallToolRequest{}, RemoveDelegateInput{UserEmail: user, DelegateEmail: "assistant@example.com"})
+	for name, err := range map[string]error{"list": listErr, "add": addErr, "remove": removeErr} {
+		if !errors.Is(err, services.ErrDelegationNotConfigured) {
+			t.Errorf("%s error = %v, want ErrDelegationNotConfigured", name, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), "WORKSPACE_MCP_GMAIL_DELEGATION_KEY") {
+			t.Errorf("%s error = %q, want the setting to configure", name, err)
+		}
+	}
+}
+
+func TestDelegateTools(t *testing.T) {
+	srv := &delegateServer{}
+	factory := delegateFactory(srv)
+	ctx := context.Background()
+	user := "boss@example.com"
+
+	res, list, err := createListDelegatesHandler(factory)(ctx, &mcp.CallToolRequest{}, ListDelegatesInput{UserEmail: user})
+	if err != nil {
+		t.Fatalf("list error = %v", err)
+	}
+	if len(list.Delegates) != 2 || list.Delegates[0] != (DelegateInfo{Email: "assistant@example.com", Status: "accepted"}) {
+		t.Errorf("list output = %+v", list)
+	}
+	if text := resultText(res); !strings.Contains(text, "deputy@example.com [pending]") {
+		t.Errorf("list text = %q, want each delegate with its status", text)
+	}
+
+	res, added, err := createAddDelegateHandler(factory)(ctx, &mcp.CallToolRequest{}, AddDelegateInput{UserEmail: user, DelegateEmail: "assistant@example.com"})
+	if err != nil {
+		t.Fatalf("add error = %v", err)
+	}
+	if srv.method != http.MethodPost || !strings.HasSuffix(srv.path, "/users/boss@example.com/settings/delegates") ||
+		!strings.Contains(srv.body, `"delegateEmail":"assistant@example.com"`) {
+		t.Errorf("add request = %s %s %s", srv.method, srv.path, srv.body)
+	}
+	if added.Status != "pending" || !strings.Contains(resultText(res), "accept the invitation") {
+		t.Errorf("add = %+v, %q", added, resultText(res))
+	}
+
+	if _, _, err := createRemoveDelegateHandler(factory)(ctx, &mcp.CallToolRequest{}, RemoveDelegateInput{UserEmail: user, DelegateEmail: "assistant@example.com"}); err != nil {
+		t.Fatalf("remove error = %v", err)
+	}
+	if srv.method != http.MethodDelete || !strings.HasSuffix(srv.path, "/settings/delegates/assistant@example.com") {
+		t.Errorf("remove request = %s %s", srv.method, srv.path)
+	}
+}
+
+func TestDelegateToolsAPIError(t *testing.T) {
+	factory := delegateFactory(&delegateServer{status: http.StatusForbidden})
+
+	_, _, err := createListDelegatesHandler(factory)(context.Background(), &mcp.CallToolRequest{}, ListDelegatesInput{UserEmail: "boss@example.com"})
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("error = %v, want the mapped permission error", err)
+	}
+}
@@ -0,0 +1,294 @@
+package gmail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// DraftSummary is a compact representation of a Gmail draft.
+type DraftSummary struct {
+	DraftID string `json:"draft_id"`
+	MessageSummary
+}
+
+// DraftDetail is a draft with its full message content.
+type DraftDetail struct {
+	DraftID string `json:"draft_id"`
+	MessageDetail
+	BCC string `json:"bcc,omitempty"`
+}
+
+// draftFields holds the editable parts of a draft for update_gmail_draft.
+type draftFields struct {
+	To, CC, BCC, Subject  string
+	Body, HTMLBody        string
+	InReplyTo, References string
+	ThreadID              string
+	Attachments           []mailAttachment
+}
+
+// mergeDraftFields overlays the fields set in input on the existing draft.
+// A new body drops the old HTML version unless body_html replaces it too.
+func mergeDraftFields(existing draftFields, input UpdateDraftInput, body, htmlBody string) draftFields {
+	merged := existing
+	for _, f := range []struct {
+		dst *string
+		src string
+	}{{&merged.To, input.To}, {&merged.CC, input.CC}, {&merged.BCC, input.BCC}, {&merged.Subject, input.Subject}} {
+		if f.src != "" {
+			*f.dst = f.src
+		}
+	}
+	if input.Body != "" || input.BodyHTML != "" {
+		merged.Body, merged.HTMLBody = body, htmlBody
+	}
+	if input.RemoveAttachments {
+		merged.Attachments = nil
+	}
+	return merged
+}
+
+// --- list_gmail_drafts (extended) ---
+
+type ListDraftsInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Query     string `json:"query,omitempty" jsonschema_description:"Gmail search query to filter drafts (e.g. to:alice subject:report)"`
+	PageSize  int    `json:"page_size,omitempty" jsonschema_description:"Maximum drafts to return (default 10)"`
+	PageToken string `json:"page_token,omitempty" jsonschema_description:"Token for retrieving the next page of results"`
+}
+
+type ListDraftsOutput struct {
+	Drafts        []DraftSummary `json:"drafts"`
+	NextPageToken string         `json:"next_page_token,omitempty"`
+}
+
+func createListDraftsHandler(factory *services.Factory) mcp.ToolHandlerFor[ListDraftsInput, ListDraftsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListDraftsInput) (*mcp.CallToolResult, ListDraftsOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 10
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListDraftsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Users.Drafts.List(input.UserEmail).
+			MaxResults(int64(input.PageSize)).
+			PageToken(input.PageToken).
+			Context(ctx)
+		if input.Query != "" {
+			call = call.Q(input.Query)
+		}
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListDraftsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := ListDraftsOutput{Drafts: make([]DraftSummary, 0, len(result.Drafts)), NextPageToken: result.NextPageToken}
+		rb := response.New()
+		rb.Header("Gmail Drafts")
+		rb.KeyValue("Drafts", len(result.Drafts))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+		for _, d := range result.Drafts {
+			draft, err := srv.Users.Drafts.Get(input.UserEmail, d.Id).
+				Format("metadata").
+				Context(ctx).
+				Do()
+			if err != nil || draft.Message == nil {
+				continue
+			}
+			s := DraftSummary{DraftID: draft.Id, MessageSummary: messageToSummary(draft.Message)}
+			out.Drafts = append(out.Drafts, s)
+			rb.Item("Subject: %s", s.Subject)
+			rb.Line("    To: %s", s.To)
+			rb.Line("    Draft ID: %s (Thread: %s)", s.DraftID, s.ThreadID)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- get_gmail_draft (extended) ---
+
+type DraftIDInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DraftID   string `json:"draft_id" jsonschema:"required" jsonschema_description:"The Gmail draft ID"`
+}
+
+func createGetDraftHandler(factory *services.Factory) mcp.ToolHandlerFor[DraftIDInput, DraftDetail] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DraftIDInput) (*mcp.CallToolResult, DraftDetail, error) {
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, DraftDetail{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		draft, err := srv.Users.Drafts.Get(input.UserEmail, input.DraftID).Format("full").Context(ctx).Do()
+		if err != nil {
+			return nil, DraftDetail{}, middleware.HandleGoogleAPIError(err)
+		}
+		if draft.Message == nil {
+			return nil, DraftDetail{}, fmt.Errorf("draft %s has no message", input.DraftID)
+		}
+
+		d := DraftDetail{DraftID: draft.Id, MessageDetail: messageToDetail(draft.Message), BCC: extractHeader(draft.Message, "Bcc")}
+		rb := response.New()
+		rb.Header("Gmail Draft")
+		rb.KeyValue("Draft ID", d.DraftID)
+		if d.BCC != "" {
+			rb.KeyValue("BCC", d.BCC)
+		}
+		rb.KeyValue("Thread ID", d.ThreadID)
+		formatMessageDetail(rb, d.MessageDetail)
+
+		return rb.TextResult(), d, nil
+	}
+}
+
+// --- update_gmail_draft (extended) ---
+
+type UpdateDraftInput struct {
+	UserEmail         string            `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	DraftID           string            `json:"draft_id" jsonschema:"required" jsonschema_description:"The Gmail draft ID"`
+	To                string            `json:"to,omitempty" jsonschema_description:"New recipients (default: unchanged)"`
+	CC                string            `json:"cc,omitempty" jsonschema_description:"New CC addresses (default: unchanged)"`
+	BCC               string            `json:"bcc,omitempty" jsonschema_description:"New BCC addresses (default: unchanged)"`
+	Subject           string            `json:"subject,omitempty" jsonschema_description:"New subject (default: unchanged)"`
+	Body              string            `json:"body,omitempty" jsonschema_description:"New body: plain text, or Markdown with body_format=markdown (default: unchanged)"`
+	BodyHTML          string            `json:"body_html,omitempty" jsonschema_description:"New HTML version of the body (default: unchanged)"`
+	BodyFormat        string            `json:"body_format,omitempty" jsonschema_description:"How to read body: plain text, or Markdown converted to the HTML part,enum=plain,enum=markdown"`
+	Attachments       []AttachmentInput `json:"attachments,omitempty" jsonschema_description:"Files to add, each as base64 content or a Drive file ID"`
+	RemoveAttachments bool              `json:"remove_attachments,omitempty" jsonschema_description:"Drop the draft's existing attachments"`
+}
+
+func createUpdateDraftHandler(factory *services.Factory) mcp.ToolHandlerFor[UpdateDraftInput, DraftSummary] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateDraftInput) (*mcp.CallToolResult, DraftSummary, error) {
+		var body, htmlBody string
+		if input.Body != "" || input.BodyHTML != "" {
+			var err error
+			if body, htmlBody, err = composeBody(input.Body, input.BodyHTML, input.BodyFormat); err != nil {
+				return nil, DraftSummary{}, err
+			}
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, DraftSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		current, err := srv.Users.Drafts.Get(input.UserEmail, input.DraftID).Format("full").Context(ctx).Do()
+		if err != nil {
+			return nil, DraftSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+		if current.Message == nil {
+			return nil, DraftSummary{}, fmt.Errorf("draft %s has no message", input.DraftID)
+		}
+		existing := draftFields{
+			To:         extractHeader(current.Message, "To"),
+			CC:         extractHeader(current.Message, "Cc"),
+			BCC:        extractHeader(current.Message, "Bcc"),
+			Subject:    extractHeader(current.Message, "Subject"),
+			Body:       extractBody(current.Message),
+			InReplyTo:  extractHeader(current.Message, "In-Reply-To"),
+			References: extractHeader(current.Message, "References"),
+			ThreadID:   current.Message.ThreadId,
+		}
+		if current.Message.Payload != nil {
+			existing.HTMLBody = findBodyPart(current.Message.Payload, "text/html")
+		}
+		if !input.RemoveAttachments {
+			if existing.Attachments, err = originalAttachments(ctx, srv, input.UserEmail, current.Message); err != nil {
+				return nil, DraftSummary{}, middleware.HandleGoogleAPIError(err)
+			}
+		}
+		added, err := resolveAttachments(ctx, factory, input.UserEmail, input.Attachments)
+		if err != nil {
+			return nil, DraftSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		f := mergeDraftFields(existing, input, body, htmlBody)
+		f.Attachments = append(f.Attachments, added...)
+		raw := buildRawMessage(f.To, f.Subject, f.Body, f.HTMLBody, f.CC, f.BCC, f.ThreadID, f.InReplyTo, f.References, f.Attachments...)
+		if stamp := factory.Provenance(req); stamp != nil {
+			if raw, err = stamp.StampRawMessage(raw); err != nil {
+				return nil, DraftSummary{}, err
+			}
+		}
+
+		updated, err := srv.Users.Drafts.Update(input.UserEmail, input.DraftID, &gmail.Draft{
+			Id:      input.DraftID,
+			Message: &gmail.Message{Raw: raw, ThreadId: f.ThreadID},
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, DraftSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := DraftSummary{DraftID: updated.Id, MessageSummary: MessageSummary{To: f.To, Subject: f.Subject, ThreadID: f.ThreadID}}
+		if updated.Message != nil {
+			out.ID = updated.Message.Id
+		}
+		rb := response.New()
+		rb.Header("Draft Updated")
+		rb.KeyValue("Draft ID", out.DraftID)
+		rb.KeyValue("To", f.To)
+		rb.KeyValue("Subject", f.Subject)
+		writeAttachmentList(rb, f.Attachments)
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- send_gmail_draft (extended) ---
+
+func createSendDraftHandler(factory *services.Factory) mcp.ToolHandlerFor[DraftIDInput, MessageSummary] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DraftIDInput) (*mcp.CallToolResult, MessageSummary, error) {
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, MessageSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		sent, err := srv.Users.Drafts.Send(input.UserEmail, &gmail.Draft{Id: input.DraftID}).Context(ctx).Do()
+		if err != nil {
+			return nil, MessageSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Draft Sent")
+		rb.KeyValue("Draft ID", input.DraftID)
+		rb.KeyValue("Message ID", sent.Id)
+		rb.KeyValue("Thread ID", sent.ThreadId)
+
+		return rb.TextResult(), MessageSummary{ID: sent.Id, ThreadID: sent.ThreadId, LabelIDs: sent.LabelIds}, nil
+	}
+}
+
+// --- delete_gmail_draft (extended) ---
+
+func createDeleteDraftHandler(factory *services.Factory) mcp.ToolHandlerFor[DraftIDInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DraftIDInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		if err := srv.Users.Drafts.Delete(input.UserEmail, input.DraftID).Context(ctx).Do(); err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Draft Deleted")
+		rb.KeyValue("Draft ID", input.DraftID)
+
+		return rb.TextResult(), nil, nil
+	}
+}
@@ -0,0 +1,82 @@
+package gmail
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestMergeDraftFields(t *testing.T) {
+	existing := draftFields{
+		To:          "alice@example.com",
+		CC:          "carol@example.com",
+		Subject:     "Quarterly report",
+		Body:        "Old body",
+		HTMLBody:    "<p>Old body</p>",
+		InReplyTo:   "<a@example.com>",
+		References:  "<a@example.com>",
+		ThreadID:    "t1",
+		Attachments: []mailAttachment{{Filename: "report.pdf"}},
+	}
+
+	tests := []struct {
+		name     string
+		input    UpdateDraftInput
+		body     string
+		htmlBody string
+		check    func(t *testing.T, got draftFields)
+	}{
+		{
+			name:  "subject only keeps everything else",
+			input: UpdateDraftInput{Subject: "Q3 report"},
+			check: func(t *testing.T, got draftFields) {
+				if got.Subject != "Q3 report" || got.To != existing.To || got.CC != existing.CC {
+					t.Errorf("headers = %q/%q/%q, want new subject and unchanged recipients", got.Subject, got.To, got.CC)
+				}
+				if got.Body != existing.Body || got.HTMLBody != existing.HTMLBody {
+					t.Errorf("body changed: %q / %q", got.Body, got.HTMLBody)
+				}
+				if got.ThreadID != "t1" || got.InReplyTo != existing.InReplyTo || len(got.Attachments) != 1 {
+					t.Errorf("threading or attachments lost: %+v", got)
+				}
+			},
+		},
+		{
+			name:  "plain body drops old html",
+			input: UpdateDraftInput{Body: "New body"},
+			body:  "New body",
+			check: func(t *testing.T, got draftFields) {
+				if got.Body != "New body" || got.HTMLBody != "" {
+					t.Errorf("body = %q / %q, want new plain body only", got.Body, got.HTMLBody)
+				}
+			},
+		},
+		{
+			name:  "remove attachments",
+			input: UpdateDraftInput{RemoveAttachments: true},
+			check: func(t *testing.T, got draftFields) {
+				if len(got.Attachments) != 0 {
+					t.Errorf("attachments = %d, want none", len(got.Attachments))
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, mergeDraftFields(existing, tt.input, tt.body, tt.htmlBody))
+		})
+	}
+}
+
+func TestUpdateDraftRejectsBadBodyFormat(t *testing.T) {
+	_, _, err := createUpdateDraftHandler(nil)(context.Background(), &mcp.CallToolRequest{}, UpdateDraftInput{
+		DraftID:    "d1",
+		Body:       "hi",
+		BodyFormat: "rtf",
+	})
+	if err == nil || !strings.Contains(err.Error(), "rtf") {
+		t.Errorf("error = %v, want invalid body_format", err)
+	}
+}
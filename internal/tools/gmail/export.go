@@ -0,0 +1,154 @@
+package gmail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/mail"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// maxInlineExportSize caps messages returned inline by export_gmail_message;
+// larger ones must be saved to Drive.
+const maxInlineExportSize = 5 << 20
+
+// emlMimeType is the MIME type of a message saved as an .eml file.
+const emlMimeType = "message/rfc822"
+
+// emlFilename derives a file name for an exported message from its subject,
+// dropping characters that are unsafe in file names.
+func emlFilename(subject, messageID string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < 0x20 {
+			return -1
+		}
+		return r
+	}, subject)
+	name = strings.Join(strings.Fields(name), " ")
+	if runes := []rune(name); len(runes) > 100 {
+		name = strings.TrimSpace(string(runes[:100]))
+	}
+	if name == "" {
+		name = "message-" + messageID
+	}
+	return name + ".eml"
+}
+
+// --- export_gmail_message (extended) ---
+
+type ExportMessageInput struct {
+	UserEmail   string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	MessageID   string `json:"message_id" jsonschema:"required" jsonschema_description:"The Gmail message ID"`
+	Destination string `json:"destination,omitempty" jsonschema_description:"Where to put the .eml: inline returns it base64-encoded (max 5 MB), drive saves it as a file (default inline),enum=inline,enum=drive"`
+	FolderID    string `json:"folder_id,omitempty" jsonschema_description:"Drive folder for destination=drive (default: My Drive root)"`
+	Filename    string `json:"filename,omitempty" jsonschema_description:"File name for the .eml (default: the message subject)"`
+}
+
+type ExportMessageOutput struct {
+	MessageID   string `json:"message_id"`
+	Filename    string `json:"filename"`
+	Size        int    `json:"size"`
+	Data        string `json:"data,omitempty"`
+	DriveFileID string `json:"drive_file_id,omitempty"`
+	WebViewLink string `json:"web_view_link,omitempty"`
+}
+
+func createExportMessageHandler(factory *services.Factory) mcp.ToolHandlerFor[ExportMessageInput, ExportMessageOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ExportMessageInput) (*mcp.CallToolResult, ExportMessageOutput, error) {
+		switch input.Destination {
+		case "":
+			input.Destination = "inline"
+		case "inline", "drive":
+		default:
+			return nil, ExportMessageOutput{}, fmt.Errorf("invalid destination %q — use inline or drive", input.Destination)
+		}
+		if input.FolderID != "" && input.Destination != "drive" {
+			return nil, ExportMessageOutput{}, fmt.Errorf("folder_id applies only to destination=drive")
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ExportMessageOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		msg, err := srv.Users.Messages.Get(input.UserEmail, input.MessageID).Format("raw").Context(ctx).Do()
+		if err != nil {
+			return nil, ExportMessageOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		raw, err := base64.URLEncoding.DecodeString(msg.Raw)
+		if err != nil {
+			return nil, ExportMessageOutput{}, fmt.Errorf("decoding raw message: %w", err)
+		}
+
+		out := ExportMessageOutput{MessageID: msg.Id, Filename: input.Filename, Size: len(raw)}
+		if out.Filename == "" {
+			out.Filename = emlFilename(rawSubject(raw), msg.Id)
+		} else if !strings.HasSuffix(strings.ToLower(out.Filename), ".eml") {
+			out.Filename += ".eml"
+		}
+
+		rb := response.New()
+		rb.Header("Gmail Message Exported")
+		rb.KeyValue("Message ID", out.MessageID)
+		rb.KeyValue("Filename", out.Filename)
+		rb.KeyValue("Size", formatAttachmentSize(int64(out.Size)))
+
+		if input.Destination == "inline" {
+			if len(raw) > maxInlineExportSize {
+				return nil, ExportMessageOutput{}, fmt.Errorf("message is %s, above the %s inline limit — use destination=drive", formatAttachmentSize(int64(len(raw))), formatAttachmentSize(maxInlineExportSize))
+			}
+			out.Data = base64.StdEncoding.EncodeToString(raw)
+			rb.Line("The RFC 822 message is in the structured output as base64-encoded data.")
+			return rb.TextResult(), out, nil
+		}
+
+		driveSrv, err := factory.Drive(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ExportMessageOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		file := &drive.File{Name: out.Filename, MimeType: emlMimeType}
+		if input.FolderID != "" {
+			file.Parents = []string{input.FolderID}
+		}
+		if stamp := factory.Provenance(req); stamp != nil {
+			file.AppProperties = stamp.Properties()
+		}
+		created, err := driveSrv.Files.Create(file).
+			Media(bytes.NewReader(raw)).
+			Fields("id, webViewLink").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, ExportMessageOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		out.DriveFileID, out.WebViewLink = created.Id, created.WebViewLink
+		rb.KeyValue("Drive File ID", out.DriveFileID)
+		rb.KeyValue("Link", out.WebViewLink)
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// rawSubject returns the decoded Subject header of an RFC 822 message, or
+// "" when it cannot be read.
+func rawSubject(raw []byte) string {
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return ""
+	}
+	subject := m.Header.Get("Subject")
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(subject); err == nil {
+		return decoded
+	}
+	return subject
+}
@@ -0,0 +1,51 @@
+package gmail
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestEmlFilename(t *testing.T) {
+	tests := []struct {
+		subject, want string
+	}{
+		{"Invoice 2024/03: \"final\"", "Invoice 202403 final.eml"},
+		{"  Re:   lunch?  ", "Re lunch.eml"},
+		{"", "message-m1.eml"},
+		{strings.Repeat("a", 150), strings.Repeat("a", 100) + ".eml"},
+	}
+	for _, tt := range tests {
+		if got := emlFilename(tt.subject, "m1"); got != tt.want {
+			t.Errorf("emlFilename(%q) = %q, want %q", tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestRawSubject(t *testing.T) {
+	raw := "From: a@example.com\r\nSubject: =?UTF-8?B?Q2Fmw6kgbWVudQ==?=\r\n\r\nbody"
+	if got := rawSubject([]byte(raw)); got != "Café menu" {
+		t.Errorf("rawSubject() = %q, want decoded subject", got)
+	}
+	if got := rawSubject([]byte("not a message")); got != "" {
+		t.Errorf("rawSubject(garbage) = %q, want empty", got)
+	}
+}
+
+func TestExportMessageValidation(t *testing.T) {
+	tests := []struct {
+		input  ExportMessageInput
+		errMsg string
+	}{
+		{ExportMessageInput{MessageID: "m", Destination: "s3"}, "invalid destination"},
+		{ExportMessageInput{MessageID: "m", FolderID: "f"}, "destination=drive"},
+	}
+	for _, tt := range tests {
+		_, _, err := createExportMessageHandler(nil)(context.Background(), &mcp.CallToolRequest{}, tt.input)
+		if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("error = %v, want containing %q", err, tt.errMsg)
+		}
+	}
+}
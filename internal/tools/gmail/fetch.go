@@ -0,0 +1,47 @@
+package gmail
+
+import (
+	"context"
+	"sync"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
+)
+
+// fetchConcurrency bounds the Gmail API calls one tool call makes at once.
+// Eight parallel gets cut a 25-message batch from 25 round trips to about
+// four while staying far below Gmail's per-user rate limits.
+var fetchConcurrency = 8
+
+// fetched is the outcome of fetching one ID. An entry with neither ok nor
+// err was never started because the call was cancelled.
+type fetched[T any] struct {
+	value T
+	err   error
+	ok    bool
+}
+
+// fetchAll calls fetch for each ID with up to fetchConcurrency calls in
+// flight and returns the outcomes in ID order. p, when non-nil, reports each
+// ID as it starts. Once ctx is cancelled no further IDs start; fetches
+// already running finish or fail with the context error.
+func fetchAll[T any](ctx context.Context, p *progress.Reporter, ids []string, fetch func(ctx context.Context, id string) (T, error)) []fetched[T] {
+	out := make([]fetched[T], len(ids))
+	slots := make(chan struct{}, fetchConcurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		slots <- struct{}{}
+		if (p != nil && p.Next() != nil) || ctx.Err() != nil {
+			<-slots
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			v, err := fetch(ctx, id)
+			out[i] = fetched[T]{value: v, err: err, ok: err == nil}
+		}()
+	}
+	wg.Wait()
+	return out
+}
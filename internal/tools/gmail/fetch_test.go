@@ -0,0 +1,65 @@
+package gmail
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchAllOrderAndBound(t *testing.T) {
+	ids := make([]string, 30)
+	for i := range ids {
+		ids[i] = string(rune('a' + i%26))
+	}
+	var inFlight, peak atomic.Int32
+	results := fetchAll(context.Background(), nil, ids, func(_ context.Context, id string) (string, error) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		inFlight.Add(-1)
+		if id == "c" {
+			return "", errors.New("not found")
+		}
+		return id, nil
+	})
+
+	if got := peak.Load(); got > int32(fetchConcurrency) {
+		t.Errorf("peak in-flight fetches = %d, want at most %d", got, fetchConcurrency)
+	}
+	for i, f := range results {
+		if ids[i] == "c" {
+			if f.ok || f.err == nil {
+				t.Errorf("results[%d] = %+v, want an error", i, f)
+			}
+			continue
+		}
+		if !f.ok || f.value != ids[i] {
+			t.Errorf("results[%d] = %+v, want %q", i, f, ids[i])
+		}
+	}
+}
+
+func TestFetchAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls atomic.Int32
+	results := fetchAll(ctx, nil, []string{"a", "b"}, func(context.Context, string) (string, error) {
+		calls.Add(1)
+		return "", nil
+	})
+	if calls.Load() != 0 {
+		t.Errorf("fetch called %d times after cancellation", calls.Load())
+	}
+	for i, f := range results {
+		if f.ok || f.err != nil {
+			t.Errorf("results[%d] = %+v, want not started", i, f)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package gmail
+
+import (
+	"fmt"
+	"net/mail"
+	"path"
+	"strings"
+)
+
+// FilingRule sends matching email attachments to a Drive folder. Every
+// condition that is set must match; an empty rule matches everything.
+type FilingRule struct {
+	Sender   string `json:"sender,omitempty" jsonschema_description:"Sender email address to match, case-insensitive"`
+	Domain   string `json:"domain,omitempty" jsonschema_description:"Sender domain to match, including subdomains (e.g. example.com)"`
+	Type     string `json:"type,omitempty" jsonschema_description:"Attachment type: a MIME type (application/pdf), a MIME family (image/*), or a file extension (.xlsx)"`
+	FolderID string `json:"folder_id" jsonschema:"required" jsonschema_description:"Drive folder ID that matching attachments are saved to"`
+}
+
+// validateFilingRules checks that every rule names a folder.
+func validateFilingRules(rules []FilingRule) error {
+	for i, r := range rules {
+		if strings.TrimSpace(r.FolderID) == "" {
+			return fmt.Errorf("rule %d has no folder_id — every rule must name the Drive folder its attachments go to", i+1)
+		}
+	}
+	return nil
+}
+
+// matchFilingRule returns the index of the first rule matching an
+// attachment from sender (a From header value), or -1.
+func matchFilingRule(rules []FilingRule, sender string, a AttachmentInfo) int {
+	address := senderAddress(sender)
+	_, domain, _ := strings.Cut(address, "@")
+	for i, r := range rules {
+		if r.Sender != "" && !strings.EqualFold(r.Sender, address) {
+			continue
+		}
+		if r.Domain != "" && !domainAllowed(domain, []string{strings.ToLower(r.Domain)}) {
+			continue
+		}
+		if r.Type != "" && !attachmentTypeMatches(r.Type, a) {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// senderAddress extracts the lower-cased address from a From header value.
+func senderAddress(from string) string {
+	if a, err := mail.ParseAddress(from); err == nil {
+		return strings.ToLower(a.Address)
+	}
+	return strings.ToLower(strings.Trim(strings.TrimSpace(from), "<>"))
+}
+
+// attachmentTypeMatches reports whether a is of type t: an extension such as
+// ".pdf", a MIME family such as "image/*", or an exact MIME type.
+func attachmentTypeMatches(t string, a AttachmentInfo) bool {
+	t = strings.ToLower(strings.TrimSpace(t))
+	mimeType := strings.ToLower(a.MimeType)
+	switch {
+	case strings.HasPrefix(t, "."):
+		return strings.ToLower(path.Ext(a.Filename)) == t
+	case strings.HasSuffix(t, "/*"):
+		return strings.HasPrefix(mimeType, strings.TrimSuffix(t, "*"))
+	default:
+		return mimeType == t
+	}
+}
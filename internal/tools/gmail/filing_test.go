@@ -0,0 +1,198 @@
+package gmail
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+func TestMatchFilingRule(t *testing.T) {
+	rules := []FilingRule{
+		{Sender: "Billing@Vendor.example", Type: ".pdf", FolderID: "invoices"},
+		{Domain: "bank.example", FolderID: "statements"},
+		{Type: "image/*", FolderID: "photos"},
+		{Type: "text/csv", FolderID: "data"},
+	}
+	pdf := AttachmentInfo{Filename: "March.PDF", MimeType: "application/pdf"}
+	png := AttachmentInfo{Filename: "scan.png", MimeType: "image/png"}
+
+	tests := []struct {
+		name   string
+		sender string
+		a      AttachmentInfo
+		want   int
+	}{
+		{"sender and extension", "Vendor Billing <billing@vendor.example>", pdf, 0},
+		{"sender matches but type does not", "billing@vendor.example", png, 2},
+		{"domain", "alerts@bank.example", pdf, 1},
+		{"subdomain", "Bank <no-reply@mail.bank.example>", png, 1},
+		{"lookalike domain", "x@notbank.example", pdf, -1},
+		{"mime family", "friend@example.com", png, 2},
+		{"exact mime type", "friend@example.com", AttachmentInfo{Filename: "d.csv", MimeType: "text/csv"}, 3},
+		{"no match", "friend@example.com", pdf, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchFilingRule(rules, tt.sender, tt.a); got != tt.want {
+				t.Errorf("matchFilingRule() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFilingRules(t *testing.T) {
+	if err := validateFilingRules([]FilingRule{{Domain: "example.com", FolderID: "f"}}); err != nil {
+		t.Errorf("valid rules: %v", err)
+	}
+	if err := validateFilingRules([]FilingRule{{FolderID: "f"}, {Domain: "example.com"}}); err == nil {
+		t.Error("rule without folder_id: want error")
+	}
+}
+
+const filingPath = "/gmail/v1/users/user@example.com"
+
+// filingAPI fakes the Gmail and Drive calls of file_attachments_by_rules:
+// m1 (thread t1) carries a vendor invoice and an unmatched photo; m2
+// (thread t2) carries a report whose download is denied.
+type filingAPI struct {
+	query    string
+	uploads  []string
+	modified []string
+}
+
+func (f *filingAPI) mux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+filingPath+"/messages", func(w http.ResponseWriter, r *http.Request) {
+		f.query = r.URL.Query().Get("q")
+		replyJSON(`{"messages":[{"id":"m1","threadId":"t1"},{"id":"m2","threadId":"t2"}]}`)(w, r)
+	})
+	mux.Handle("GET "+filingPath+"/messages/m1", replyJSON(`{"id":"m1","threadId":"t1","payload":{"headers":[{"name":"From","value":"Vendor Billing <billing@vendor.example>"}],"parts":[`+
+		`{"filename":"invoice.pdf","mimeType":"application/pdf","body":{"attachmentId":"a1","size":5}},`+
+		`{"filename":"photo.png","mimeType":"image/png","body":{"attachmentId":"a2","size":5}}]}}`))
+	mux.Handle("GET "+filingPath+"/messages/m2", replyJSON(`{"id":"m2","threadId":"t2","payload":{"headers":[{"name":"From","value":"reports@other.example"}],"parts":[`+
+		`{"filename":"report.pdf","mimeType":"application/pdf","body":{"attachmentId":"a3","size":5}}]}}`))
+	mux.Handle("GET "+filingPath+"/messages/m1/attachments/a1", replyJSON(`{"data":"`+base64.URLEncoding.EncodeToString([]byte("%PDF-"))+`"}`))
+	mux.Handle("GET "+filingPath+"/messages/m2/attachments/a3", replyError(http.StatusForbidden, "denied"))
+	mux.HandleFunc("POST /upload/drive/v3/files", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		f.uploads = append(f.uploads, string(data))
+		replyJSON(`{"id":"file1"}`)(w, r)
+	})
+	mux.Handle("GET "+filingPath+"/labels", replyJSON(`{"labels":[{"id":"Label_9","name":"Filed"}]}`))
+	mux.HandleFunc("POST "+filingPath+"/threads/{id}/modify", func(w http.ResponseWriter, r *http.Request) {
+		f.modified = append(f.modified, r.PathValue("id"))
+		replyJSON(`{}`)(w, r)
+	})
+	return mux
+}
+
+var filingTestRules = []FilingRule{
+	{Sender: "billing@vendor.example", Type: ".pdf", FolderID: "invoices"},
+	{Domain: "other.example", FolderID: "reports"},
+}
+
+func TestFileAttachmentsHandler(t *testing.T) {
+	api := &filingAPI{}
+	handler := createFileAttachmentsHandler(fakeFactory(api.mux()), nil)
+
+	res, out, err := handler(context.Background(), &mcp.CallToolRequest{}, FileAttachmentsInput{UserEmail: settingsUser, Query: "newer_than:7d", Rules: filingTestRules})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if api.query != `(newer_than:7d) has:attachment -label:"Filed"` {
+		t.Errorf("search query = %q", api.query)
+	}
+	want := FiledAttachment{MessageID: "m1", ThreadID: "t1", Filename: "invoice.pdf", FolderID: "invoices", FileID: "file1"}
+	if len(out.Filed) != 1 || out.Filed[0] != want || out.Unmatched != 1 || out.Failed != 1 || out.LabeledThreads != 1 || out.DryRun {
+		t.Errorf("output = %+v", out)
+	}
+	if len(api.uploads) != 1 || !strings.Contains(api.uploads[0], `"parents":["invoices"]`) || !strings.Contains(api.uploads[0], "%PDF-") {
+		t.Errorf("uploads = %q", api.uploads)
+	}
+	if len(api.modified) != 1 || api.modified[0] != "t1" {
+		t.Errorf("labeled threads = %v, want only t1 (t2 had a failure)", api.modified)
+	}
+	text := resultText(res)
+	for _, want := range []string{
+		"Attachment Filing Results",
+		"invoice.pdf (from Vendor Billing <billing@vendor.example>) → folder invoices",
+		"photo.png (from Vendor Billing <billing@vendor.example>): no matching rule",
+		"report.pdf (from reports@other.example): ERROR — permission denied",
+		"Filed: 1", "Unmatched: 1", "Failed: 1", "Labeled threads: 1",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestFileAttachmentsHandlerDryRun(t *testing.T) {
+	api := &filingAPI{}
+	handler := createFileAttachmentsHandler(fakeFactory(api.mux()), filingTestRules)
+
+	res, out, err := handler(context.Background(), &mcp.CallToolRequest{}, FileAttachmentsInput{UserEmail: settingsUser, Query: "from:vendor", Label: "Archived", DryRun: true})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if api.query != `(from:vendor) has:attachment -label:"Archived"` {
+		t.Errorf("search query = %q", api.query)
+	}
+	if len(api.uploads) != 0 || len(api.modified) != 0 {
+		t.Errorf("dry run uploaded %d files and labeled %v", len(api.uploads), api.modified)
+	}
+	if !out.DryRun || len(out.Filed) != 2 || out.Filed[1].FolderID != "reports" || out.Filed[1].FileID != "" || out.Failed != 0 {
+		t.Errorf("output = %+v, want both PDFs planned from the server rules", out)
+	}
+	if text := resultText(res); !strings.Contains(text, "Attachment Filing Plan (dry run)") || !strings.Contains(text, "report.pdf (from reports@other.example) → folder reports") {
+		t.Errorf("text = %q", text)
+	}
+}
+
+func TestFileAttachmentsHandlerValidation(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected API call %s %s", r.Method, r.URL.Path)
+	})
+	factory := fakeFactory(mux)
+
+	tests := []struct {
+		name     string
+		defaults []FilingRule
+		input    FileAttachmentsInput
+		want     string
+	}{
+		{"no rules", nil, FileAttachmentsInput{UserEmail: settingsUser, Query: "x"}, "no filing rules"},
+		{"rule without folder", nil, FileAttachmentsInput{UserEmail: settingsUser, Query: "x", Rules: []FilingRule{{Domain: "example.com"}}}, "rule 1 has no folder_id"},
+		{"bad server rule", []FilingRule{{FolderID: "f"}, {Sender: "a@example.com"}}, FileAttachmentsInput{UserEmail: settingsUser, Query: "x"}, "rule 2 has no folder_id"},
+		{"invalid email", nil, FileAttachmentsInput{UserEmail: "not-an-email", Query: "x", DefaultFolderID: "f"}, "invalid user email"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := createFileAttachmentsHandler(factory, tt.defaults)(context.Background(), &mcp.CallToolRequest{}, tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileAttachmentsHandlerAPIErrors(t *testing.T) {
+	for code, want := range map[int]string{http.StatusForbidden: "permission denied", http.StatusNotFound: "resource not found"} {
+		mux := http.NewServeMux()
+		mux.Handle("/", replyError(code, "denied"))
+		factory := fakeFactory(mux)
+		factory.SetRetryPolicies(services.RetryPolicy{}, nil)
+
+		_, _, err := createFileAttachmentsHandler(factory, filingTestRules)(context.Background(), &mcp.CallToolRequest{}, FileAttachmentsInput{UserEmail: settingsUser, Query: "x"})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%d error = %v, want %q", code, err, want)
+		}
+	}
+}
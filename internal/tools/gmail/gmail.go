@@ -3,6 +3,7 @@ package gmail
 import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/ptr"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
 )
@@ -14,11 +15,18 @@ var serviceIcons = []mcp.Icon{{
 }}
 
 // Register registers all core Gmail tools with the MCP server.
-func Register(server *mcp.Server, factory *services.Factory) {
+// unsubscribeDomains, when non-empty, restricts which domains the
+// unsubscribe tools may contact. filingRules are the default rules of
+// file_attachments_by_rules. permanentDelete enables the tools that bypass
+// Trash. watcher backs the push notification tools; when it is nil or not
+// enabled they report how to configure it. Messages are also readable as
+// gmail://{user}/message/{id} resources.
+func Register(server *mcp.Server, factory *services.Factory, unsubscribeDomains []string, filingRules []FilingRule, permanentDelete bool, watcher *MailboxWatcher, res *middleware.Resources) {
+	registerResources(server, factory, res)
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "search_gmail_messages",
 		Icons:       serviceIcons,
-		Description: "Search Gmail messages using standard Gmail search query syntax. Returns message summaries with IDs for further retrieval.",
+		Description: "Search Gmail messages using standard Gmail search query syntax. Returns message summaries with IDs for further retrieval, or one entry per thread with group_by_thread.",
 		Annotations: &mcp.ToolAnnotations{
 			Title:         "Search Gmail Messages",
 			ReadOnlyHint:  true,
@@ -51,19 +59,52 @@ func Register(server *mcp.Server, factory *services.Factory) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "send_gmail_message",
 		Icons:       serviceIcons,
-		Description: "Send an email using the user's Gmail account. Supports new emails and replies with threading.",
+		Description: "Send an email using the user's Gmail account. Supports new emails, replies with threading, HTML or Markdown bodies (sent with a plain-text alternative), and attachments (base64 content or Drive files).",
 		Annotations: &mcp.ToolAnnotations{
 			Title:         "Send Gmail Message",
 			OpenWorldHint: ptr.Bool(true),
 		},
 	}, createSendMessageHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "reply_to_gmail_message",
+		Icons:       serviceIcons,
+		Description: "Reply (or reply-all) to a Gmail message in its thread. Fetches the original to set In-Reply-To, References, the Re: subject, and recipients, and quotes the original below the reply. Set draft=true to save the reply for review instead of sending.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Reply to Gmail Message",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createReplyMessageHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "trash_gmail_message",
+		Icons:       serviceIcons,
+		Description: "Move a Gmail message to Trash. Gmail deletes trashed messages after 30 days; untrash_gmail_message restores them until then.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Trash Gmail Message",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createTrashMessageHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "untrash_gmail_message",
+		Icons:       serviceIcons,
+		Description: "Restore a Gmail message from Trash to its previous labels.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Untrash Gmail Message",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createUntrashMessageHandler(factory))
+
 	// --- Extended tools ---
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_gmail_attachment_content",
 		Icons:       serviceIcons,
-		Description: "Get the content of a Gmail message attachment by attachment ID. Automatically extracts text from Office documents (.docx/.xlsx/.pptx) and text files. Returns images as inline image content for vision-capable models. Use get_gmail_message_content first to discover attachment IDs.",
+		Description: "Get the content of a Gmail message attachment by attachment ID. Automatically extracts text from Office documents (.docx/.xlsx/.pptx) and text files. Returns PNG, JPEG, GIF and WebP images up to 1 MB as inline image content for vision-capable models. Use get_gmail_message_content first to discover attachment IDs.",
 		Annotations: &mcp.ToolAnnotations{
 			Title:         "Get Gmail Attachment",
 			ReadOnlyHint:  true,
@@ -82,6 +123,16 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createGetThreadHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_gmail_message",
+		Icons:       serviceIcons,
+		Description: "Export a Gmail message exactly as received, as an RFC 822 .eml file with all headers and attachments, for archival or legal hold. Returns it base64-encoded (up to 5 MB) or saves it to Drive with destination=drive.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Export Gmail Message",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createExportMessageHandler(factory))
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "modify_gmail_message_labels",
 		Icons:       serviceIcons,
@@ -93,6 +144,40 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createModifyLabelsHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "modify_gmail_thread_labels",
+		Icons:       serviceIcons,
+		Description: "Add or remove labels on every message in a Gmail thread at once.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Modify Thread Labels",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createModifyThreadLabelsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "archive_gmail_thread",
+		Icons:       serviceIcons,
+		Description: "Archive a whole Gmail thread by removing it from the inbox, optionally marking it read. The thread stays searchable under All Mail.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Archive Gmail Thread",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createArchiveThreadHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "trash_gmail_thread",
+		Icons:       serviceIcons,
+		Description: "Move every message in a Gmail thread to Trash, or restore the thread with untrash=true. Gmail deletes trashed messages after 30 days.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Trash Gmail Thread",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createTrashThreadHandler(factory))
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "list_gmail_labels",
 		Icons:       serviceIcons,
@@ -117,13 +202,138 @@ func Register(server *mcp.Server, factory *services.Factory) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "draft_gmail_message",
 		Icons:       serviceIcons,
-		Description: "Create a draft email message that can be edited and sent later.",
+		Description: "Create a draft email message that can be edited and sent later. Supports HTML or Markdown bodies and attachments as base64 content or Drive files.",
 		Annotations: &mcp.ToolAnnotations{
 			Title:         "Draft Gmail Message",
 			OpenWorldHint: ptr.Bool(true),
 		},
 	}, createDraftMessageHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "forward_gmail_message",
+		Icons:       serviceIcons,
+		Description: "Forward a Gmail message with its attachments, an optional note, and the original headers and body below a forwarded-message divider. Keeps the forward in the original thread. Set draft=true to save it for review instead of sending.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Forward Gmail Message",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createForwardMessageHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_gmail_drafts",
+		Icons:       serviceIcons,
+		Description: "List the user's Gmail drafts with draft IDs, recipients, and subjects. Accepts a Gmail search query to filter drafts.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Gmail Drafts",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListDraftsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_gmail_draft",
+		Icons:       serviceIcons,
+		Description: "Get the full content of a Gmail draft including recipients, BCC, body, and attachment metadata.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Gmail Draft",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetDraftHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_gmail_draft",
+		Icons:       serviceIcons,
+		Description: "Revise a Gmail draft. Only the fields provided change; recipients, subject, body, threading, and existing attachments are kept otherwise. Attachments can be added or the existing ones removed.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Update Gmail Draft",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createUpdateDraftHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "send_gmail_draft",
+		Icons:       serviceIcons,
+		Description: "Send an existing Gmail draft as is, for example after a human has reviewed it. The draft is removed once sent.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Send Gmail Draft",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createSendDraftHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_gmail_draft",
+		Icons:       serviceIcons,
+		Description: "Delete a Gmail draft permanently. The draft does not go to Trash.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Delete Gmail Draft",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createDeleteDraftHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "watch_gmail_mailbox",
+		Icons:       serviceIcons,
+		Description: "Watch a mailbox for new mail through Gmail push notifications. New messages (default: INBOX only) are sent to this MCP session as log notifications from logger \"gmail-watch\" and buffered for list_gmail_watch_events. Use for triggers like \"tell me when the invoice arrives\". The watch is renewed automatically until stop_gmail_watch. Requires the server's Pub/Sub push configuration.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Watch Gmail Mailbox",
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createWatchMailboxHandler(factory, watcher))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "stop_gmail_watch",
+		Icons:       serviceIcons,
+		Description: "Stop push notifications for a mailbox started with watch_gmail_mailbox and discard its buffered events.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Stop Gmail Watch",
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createStopWatchHandler(factory, watcher))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_gmail_watch_events",
+		Icons:       serviceIcons,
+		Description: "List new-mail events buffered by watch_gmail_mailbox, oldest first. Pass last_seq from the previous call as after_seq to receive only newer events. Use when the client does not show log notifications.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "List Gmail Watch Events",
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(false),
+		},
+	}, createListWatchEventsHandler(watcher))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_gmail_message_permanently",
+		Icons:       serviceIcons,
+		Description: "Permanently delete a Gmail message, skipping Trash. This cannot be undone; prefer trash_gmail_message. Requires the server to enable permanent deletion (WORKSPACE_MCP_GMAIL_PERMANENT_DELETE).",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Delete Gmail Message Permanently",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createDeleteMessageHandler(factory, permanentDelete))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "batch_trash_gmail_messages",
+		Icons:       serviceIcons,
+		Description: "Move up to 1000 Gmail messages to Trash, restore them with untrash=true, or delete them permanently with permanent=true (when the server enables permanent deletion). Reports per-message failures and progress.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Batch Trash Gmail Messages",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createBatchTrashHandler(factory, permanentDelete))
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "list_gmail_filters",
 		Icons:       serviceIcons,
@@ -156,6 +366,105 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createDeleteFilterHandler(factory))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_gmail_profile",
+		Icons:       serviceIcons,
+		Description: "Get the mailbox profile: the account's email address, total messages and threads, and the current history ID. Use it to confirm which mailbox a token reaches, or as the starting point for history-based sync.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Gmail Profile",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetProfileHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_gmail_vacation",
+		Icons:       serviceIcons,
+		Description: "Get the vacation responder (out-of-office auto-reply): whether it is on, its message, and its schedule.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Vacation Responder",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetVacationHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "set_gmail_vacation",
+		Icons:       serviceIcons,
+		Description: "Turn the vacation responder on or off and set its message, schedule, and audience. The current subject and message are kept unless new ones are given.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Set Vacation Responder",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createSetVacationHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_gmail_forwarding",
+		Icons:       serviceIcons,
+		Description: "List forwarding addresses with their verification status, and show whether automatic forwarding is on.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Gmail Forwarding",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListForwardingHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_gmail_imap_pop_settings",
+		Icons:       serviceIcons,
+		Description: "Get the IMAP and POP access settings of the mailbox.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get IMAP/POP Settings",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetMailAccessHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "report_gmail_spam",
+		Icons:       serviceIcons,
+		Description: "Report messages as spam or phishing by moving them to the Spam folder, which trains Gmail's filters. Set not_spam to move them back to the inbox.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Report Gmail Spam",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createReportSpamHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_gmail_spam",
+		Icons:       serviceIcons,
+		Description: "List messages in the Spam folder, optionally narrowed with Gmail search operators. Shows how each sender can be unsubscribed from. Admin quarantine is not visible through the Gmail API.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Gmail Spam",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListSpamHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "bulk_unsubscribe_gmail",
+		Icons:       serviceIcons,
+		Description: "Unsubscribe from mailing lists using the List-Unsubscribe headers of the given messages: RFC 8058 one-click HTTPS requests where supported, otherwise an unsubscribe email for mailto targets. Web-page-only lists are reported for the user to open. Use dry_run to preview.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Bulk Unsubscribe",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createBulkUnsubscribeHandler(factory, unsubscribeDomains))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "perform_unsubscribe",
+		Icons:       serviceIcons,
+		Description: "Unsubscribe from the mailing list that sent a message, using its List-Unsubscribe header (RFC 8058 one-click HTTPS or mailto). Without confirm=true it only describes the target so the user can approve it first.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Perform Unsubscribe",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createPerformUnsubscribeHandler(factory, unsubscribeDomains))
+
 	// --- Complete tools ---
 
 	mcp.AddTool(server, &mcp.Tool{
@@ -172,11 +481,65 @@ func Register(server *mcp.Server, factory *services.Factory) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "batch_modify_gmail_message_labels",
 		Icons:       serviceIcons,
-		Description: "Add or remove labels from multiple Gmail messages in a single batch operation.",
+		Description: "Add or remove labels on up to 1000 Gmail messages in one batch operation, given as message IDs or as a Gmail search query (e.g. archive everything from a sender by removing INBOX). Reports search progress.",
 		Annotations: &mcp.ToolAnnotations{
 			Title:          "Batch Modify Message Labels",
 			IdempotentHint: true,
 			OpenWorldHint:  ptr.Bool(true),
 		},
 	}, createBatchModifyLabelsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "file_attachments_by_rules",
+		Icons:       serviceIcons,
+		Description: "Save the attachments of messages matching a Gmail query to Drive folders chosen by rules (sender, domain, file type), then label the processed threads so reruns skip them. Requires Drive access. Use dry_run to preview.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "File Attachments by Rules",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createFileAttachmentsHandler(factory, filingRules))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "manage_gmail_forwarding",
+		Icons:       serviceIcons,
+		Description: "Add or remove a forwarding address, or turn automatic forwarding to a verified address on or off. Adding an address sends the recipient a verification email.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Manage Gmail Forwarding",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createManageForwardingHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_gmail_delegates",
+		Icons:       serviceIcons,
+		Description: "List the users who can read, send, and delete mail on the mailbox's behalf, with their verification status. Requires a domain-wide delegation service account (WORKSPACE_MCP_GMAIL_DELEGATION_KEY).",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Gmail Delegates",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListDelegatesHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "add_gmail_delegate",
+		Icons:       serviceIcons,
+		Description: "Give another user in the organization delegate access to the mailbox: they can read, send, and delete its mail. Requires a domain-wide delegation service account (WORKSPACE_MCP_GMAIL_DELEGATION_KEY).",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Add Gmail Delegate",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createAddDelegateHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "remove_gmail_delegate",
+		Icons:       serviceIcons,
+		Description: "Revoke a delegate's access to the mailbox. Requires a domain-wide delegation service account (WORKSPACE_MCP_GMAIL_DELEGATION_KEY).",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Remove Gmail Delegate",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createRemoveDelegateHandler(factory))
 }
@@ -8,6 +8,7 @@ import (
 	"google.golang.org/api/gmail/v1"
 
 	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
 )
@@ -20,14 +21,18 @@ type SearchMessagesInput struct {
 	Query     string `json:"query" jsonschema:"required" jsonschema_description:"Gmail search query using standard Gmail search operators"`
 	PageSize  int    `json:"page_size,omitempty" jsonschema_description:"Maximum number of results to return (default 10)"`
 	PageToken string `json:"page_token,omitempty" jsonschema_description:"Token for retrieving the next page of results"`
+
+	GroupByThread bool `json:"group_by_thread,omitempty" jsonschema_description:"Collapse results into one entry per thread with a message count and the latest snippet (default false)"`
 }
 
 // SearchMessagesOutput is the structured output for search_gmail_messages.
+// With group_by_thread, Threads is set instead of Messages.
 type SearchMessagesOutput struct {
-	Messages      []MessageSummary `json:"messages"`
-	Query         string           `json:"query"`
-	NextPageToken string           `json:"next_page_token,omitempty"`
-	ResultCount   int              `json:"result_count"`
+	Messages      []MessageSummary    `json:"messages,omitempty"`
+	Threads       []SearchThreadGroup `json:"threads,omitempty"`
+	Query         string              `json:"query"`
+	NextPageToken string              `json:"next_page_token,omitempty"`
+	ResultCount   int                 `json:"result_count"`
 }
 
 func createSearchMessagesHandler(factory *services.Factory) mcp.ToolHandlerFor[SearchMessagesInput, SearchMessagesOutput] {
@@ -52,43 +57,69 @@ func createSearchMessagesHandler(factory *services.Factory) mcp.ToolHandlerFor[S
 		}
 
 		// Fetch minimal metadata for each message
-		summaries := make([]MessageSummary, 0, len(result.Messages))
-		for _, m := range result.Messages {
-			msg, err := srv.Users.Messages.Get(input.UserEmail, m.Id).
+		ids := make([]string, len(result.Messages))
+		for i, m := range result.Messages {
+			ids[i] = m.Id
+		}
+		summaries := make([]MessageSummary, 0, len(ids))
+		for _, f := range fetchAll(ctx, nil, ids, func(ctx context.Context, id string) (*gmail.Message, error) {
+			return srv.Users.Messages.Get(input.UserEmail, id).
 				Format("metadata").
-				MetadataHeaders("Subject", "From", "To", "Date").
+				MetadataHeaders(summaryHeaders...).
 				Context(ctx).
 				Do()
-			if err != nil {
-				continue
+		}) {
+			if f.ok {
+				summaries = append(summaries, messageToSummary(f.value))
 			}
-			summaries = append(summaries, messageToSummary(msg))
-		}
-
-		// Build text output
-		rb := response.New()
-		rb.Header("Gmail Search Results")
-		rb.KeyValue("Query", input.Query)
-		rb.KeyValue("Results", len(summaries))
-		if result.NextPageToken != "" {
-			rb.KeyValue("Next page token", result.NextPageToken)
-		}
-		rb.Blank()
-		for _, s := range summaries {
-			rb.Item("Subject: %s", s.Subject)
-			rb.Line("    From: %s | Date: %s", s.From, s.Date)
-			rb.Line("    ID: %s (Thread: %s)", s.ID, s.ThreadID)
 		}
 
 		output := SearchMessagesOutput{
-			Messages:      summaries,
 			Query:         input.Query,
 			NextPageToken: result.NextPageToken,
 			ResultCount:   len(summaries),
 		}
+		if input.GroupByThread {
+			output.Threads = groupByThread(summaries)
+		} else {
+			output.Messages = summaries
+		}
+
+		return searchResultText(output).TextResult(), output, nil
+	}
+}
 
-		return rb.TextResult(), output, nil
+// searchResultText renders search_gmail_messages output, either per message
+// or per thread.
+func searchResultText(out SearchMessagesOutput) *response.Builder {
+	rb := response.New()
+	rb.Header("Gmail Search Results")
+	rb.KeyValue("Query", out.Query)
+	rb.KeyValue("Results", out.ResultCount)
+	if out.Threads != nil {
+		rb.KeyValue("Threads", len(out.Threads))
+	}
+	if out.NextPageToken != "" {
+		rb.KeyValue("Next page token", out.NextPageToken)
+	}
+	rb.Blank()
+	for _, s := range out.Messages {
+		rb.Item("Subject: %s", s.Subject)
+		rb.Line("    From: %s | Date: %s", s.From, s.Date)
+		rb.Line("    ID: %s (Thread: %s)", s.ID, s.ThreadID)
+		if s.MailingList != nil && s.MailingList.ListID != "" {
+			rb.Line("    List: %s", s.MailingList.ListID)
+		}
+	}
+	for _, th := range out.Threads {
+		rb.Item("Subject: %s (%d messages)", th.Subject, th.MessageCount)
+		rb.Line("    Latest: %s | %s", th.From, th.Date)
+		if th.Snippet != "" {
+			rb.Line("    %s", th.Snippet)
+		}
+		rb.Line("    Thread: %s (latest message: %s)", th.ThreadID, th.LatestMessageID)
 	}
+	return rb
 }
 
 // --- get_gmail_message_content ---
@@ -123,38 +154,45 @@ func createGetMessageContentHandler(factory *services.Factory) mcp.ToolHandlerFo
 
 		rb := response.New()
 		rb.Header("Gmail Message")
-		rb.KeyValue("Subject", detail.Subject)
-		rb.KeyValue("From", detail.From)
-		rb.KeyValue("To", detail.To)
-		if detail.CC != "" {
-			rb.KeyValue("CC", detail.CC)
-		}
-		rb.KeyValue("Date", detail.Date)
-		rb.KeyValue("Message ID", detail.ID)
-		if detail.MessageID != "" {
-			rb.KeyValue("Message-ID Header", detail.MessageID)
-		}
-		if len(detail.Attachments) > 0 {
-			rb.Blank()
-			rb.Section("Attachments")
-			for _, a := range detail.Attachments {
-				rb.Item("%s (%s, %d bytes)", a.Filename, a.MimeType, a.Size)
-				rb.Line("    Attachment ID: %s", a.AttachmentID)
-			}
+		formatMessageDetail(rb, detail)
+
+		return rb.TextResult(), GetMessageContentOutput{Message: detail}, nil
+	}
+}
+
+// formatMessageDetail writes a message's headers, body and attachments to
+// the response builder.
+func formatMessageDetail(rb *response.Builder, detail MessageDetail) {
+	rb.KeyValue("Subject", detail.Subject)
+	rb.KeyValue("From", detail.From)
+	rb.KeyValue("To", detail.To)
+	if detail.CC != "" {
+		rb.KeyValue("CC", detail.CC)
+	}
+	rb.KeyValue("Date", detail.Date)
+	rb.KeyValue("Message ID", detail.ID)
+	if detail.MessageID != "" {
+		rb.KeyValue("Message-ID Header", detail.MessageID)
+	}
+	writeMailingList(rb, detail.MailingList)
+	if len(detail.Attachments) > 0 {
+		rb.Blank()
+		rb.Section("Attachments")
+		for _, a := range detail.Attachments {
+			rb.Item("%s (%s, %d bytes)", a.Filename, a.MimeType, a.Size)
+			rb.Line("    Attachment ID: %s", a.AttachmentID)
 		}
+	}
+	rb.Blank()
+	rb.Section("Body")
+	rb.Raw(detail.Body)
+	if len(detail.Attachments) > 0 {
 		rb.Blank()
-		rb.Section("Body")
-		rb.Raw(detail.Body)
-		if len(detail.Attachments) > 0 {
-			rb.Blank()
-			rb.Section("Attachments (%d)", len(detail.Attachments))
-			for _, a := range detail.Attachments {
-				rb.Item("%s (%s, %s)", a.Filename, a.MimeType, formatAttachmentSize(a.Size))
-				rb.Line("    Attachment ID: %s", a.AttachmentID)
-			}
+		rb.Section("Attachments (%d)", len(detail.Attachments))
+		for _, a := range detail.Attachments {
+			rb.Item("%s (%s, %s)", a.Filename, a.MimeType, formatAttachmentSize(a.Size))
+			rb.Line("    Attachment ID: %s", a.AttachmentID)
 		}
-
-		return rb.TextResult(), GetMessageContentOutput{Message: detail}, nil
 	}
 }
 
@@ -170,6 +208,14 @@ type BatchGetMessagesInput struct {
 // BatchGetMessagesOutput is the structured output for get_gmail_messages_content_batch.
 type BatchGetMessagesOutput struct {
 	Messages []MessageDetail `json:"messages"`
+	Errors   []MessageError  `json:"errors,omitempty"`
+	Partial  bool            `json:"partial,omitempty"`
+}
+
+// MessageError reports a message a batch call could not retrieve.
+type MessageError struct {
+	MessageID string `json:"message_id"`
+	Error     string `json:"error"`
 }
 
 func createBatchGetMessagesHandler(factory *services.Factory) mcp.ToolHandlerFor[BatchGetMessagesInput, BatchGetMessagesOutput] {
@@ -190,32 +236,45 @@ func createBatchGetMessagesHandler(factory *services.Factory) mcp.ToolHandlerFor
 		total := len(input.MessageIDs)
 		messages := make([]MessageDetail, 0, total)
 
-		for i, id := range input.MessageIDs {
-			// Report progress if client supports it
-			if pt := req.Params.GetProgressToken(); pt != nil {
-				_ = req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
-					ProgressToken: pt,
-					Progress:      float64(i),
-					Total:         float64(total),
-					Message:       fmt.Sprintf("Fetching message %d/%d", i+1, total),
-				})
-			}
-
-			msg, err := srv.Users.Messages.Get(input.UserEmail, id).
+		p := progress.New(ctx, req).Begin("Fetching message", total)
+		results := fetchAll(ctx, p, input.MessageIDs, func(ctx context.Context, id string) (*gmail.Message, error) {
+			return srv.Users.Messages.Get(input.UserEmail, id).
 				Format(input.Format).
 				Context(ctx).
 				Do()
-			if err != nil {
-				// Log but continue — don't fail the whole batch for one bad ID
-				continue
+		})
+		p.Done()
+		var failures []MessageError
+		for i, f := range results {
+			switch {
+			case f.ok:
+				messages = append(messages, messageToDetail(f.value))
+			case f.err != nil && !p.Cancelled():
+				// Report failures per ID — don't fail the whole batch for one bad ID
+				failures = append(failures, MessageError{
+					MessageID: input.MessageIDs[i],
+					Error:     middleware.HandleGoogleAPIError(f.err).Error(),
+				})
 			}
-			messages = append(messages, messageToDetail(msg))
 		}
 
 		rb := response.New()
 		rb.Header("Gmail Batch Messages")
 		rb.KeyValue("Requested", total)
 		rb.KeyValue("Retrieved", len(messages))
+		if len(failures) > 0 {
+			rb.KeyValue("Failed", len(failures))
+		}
+		if p.Cancelled() {
+			rb.KeyValue("Partial", p.Partial())
+		}
+		if len(failures) > 0 {
+			rb.Blank()
+			rb.Section("Errors (%d)", len(failures))
+			for _, e := range failures {
+				rb.Item("%s: %s", e.MessageID, e.Error)
+			}
+		}
 		rb.Blank()
 		for _, m := range messages {
 			rb.Separator()
@@ -242,7 +301,7 @@ func createBatchGetMessagesHandler(factory *services.Factory) mcp.ToolHandlerFor
 			rb.Blank()
 		}
 
-		return rb.TextResult(), BatchGetMessagesOutput{Messages: messages}, nil
+		return rb.TextResult(), BatchGetMessagesOutput{Messages: messages, Errors: failures, Partial: p.Cancelled()}, nil
 	}
 }
 
@@ -250,25 +309,38 @@ func createBatchGetMessagesHandler(factory *services.Factory) mcp.ToolHandlerFor
 
 // SendMessageInput is the input for send_gmail_message.
 type SendMessageInput struct {
-	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
-	To         string `json:"to" jsonschema:"required" jsonschema_description:"Recipient email address"`
-	Subject    string `json:"subject" jsonschema:"required" jsonschema_description:"Email subject"`
-	Body       string `json:"body" jsonschema:"required" jsonschema_description:"Email body content (plain text)"`
-	CC         string `json:"cc,omitempty" jsonschema_description:"CC email address"`
-	BCC        string `json:"bcc,omitempty" jsonschema_description:"BCC email address"`
-	ThreadID   string `json:"thread_id,omitempty" jsonschema_description:"Gmail thread ID to reply within"`
-	InReplyTo  string `json:"in_reply_to,omitempty" jsonschema_description:"Message-ID of the message being replied to"`
-	References string `json:"references,omitempty" jsonschema_description:"Chain of Message-IDs for proper threading"`
+	UserEmail   string            `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	To          string            `json:"to" jsonschema:"required" jsonschema_description:"Recipient email address"`
+	Subject     string            `json:"subject" jsonschema:"required" jsonschema_description:"Email subject"`
+	Body        string            `json:"body,omitempty" jsonschema_description:"Email body content: plain text, or Markdown with body_format=markdown (required unless body_html is set)"`
+	BodyHTML    string            `json:"body_html,omitempty" jsonschema_description:"HTML version of the body; sent as multipart/alternative with body (or text derived from the HTML) as the plain-text part"`
+	BodyFormat  string            `json:"body_format,omitempty" jsonschema_description:"How to read body: plain text, or Markdown converted to the HTML part,enum=plain,enum=markdown"`
+	CC          string            `json:"cc,omitempty" jsonschema_description:"CC email address"`
+	BCC         string            `json:"bcc,omitempty" jsonschema_description:"BCC email address"`
+	ThreadID    string            `json:"thread_id,omitempty" jsonschema_description:"Gmail thread ID to reply within"`
+	InReplyTo   string            `json:"in_reply_to,omitempty" jsonschema_description:"Message-ID of the message being replied to"`
+	References  string            `json:"references,omitempty" jsonschema_description:"Chain of Message-IDs for proper threading"`
+	Attachments []AttachmentInput `json:"attachments,omitempty" jsonschema_description:"Files to attach (25 MB total), each as base64 content or a Drive file ID"`
 }
 
 func createSendMessageHandler(factory *services.Factory) mcp.ToolHandlerFor[SendMessageInput, any] {
 	return func(ctx context.Context, req *mcp.CallToolRequest, input SendMessageInput) (*mcp.CallToolResult, any, error) {
+		body, htmlBody, err := composeBody(input.Body, input.BodyHTML, input.BodyFormat)
+		if err != nil {
+			return nil, nil, err
+		}
+
 		srv, err := factory.Gmail(ctx, input.UserEmail)
 		if err != nil {
 			return nil, nil, middleware.HandleGoogleAPIError(err)
 		}
 
-		rawMsg := buildRawMessage(input.To, input.Subject, input.Body, input.CC, input.BCC, input.ThreadID, input.InReplyTo, input.References)
+		attachments, err := resolveAttachments(ctx, factory, input.UserEmail, input.Attachments)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rawMsg := buildRawMessage(input.To, input.Subject, body, htmlBody, input.CC, input.BCC, input.ThreadID, input.InReplyTo, input.References, attachments...)
 
 		gmailMsg := &gmail.Message{
 			Raw: rawMsg,
@@ -293,6 +365,7 @@ func createSendMessageHandler(factory *services.Factory) mcp.ToolHandlerFor[Send
 		if input.CC != "" {
 			rb.KeyValue("CC", input.CC)
 		}
+		writeAttachmentList(rb, attachments)
 
 		return rb.TextResult(), nil, nil
 	}
@@ -1,13 +1,19 @@
 package gmail
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/drive/v3"
 	gmailpb "google.golang.org/api/gmail/v1"
 
 	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
 )
@@ -22,6 +28,7 @@ type BatchGetThreadsInput struct {
 
 type BatchGetThreadsOutput struct {
 	Threads []ThreadSummary `json:"threads"`
+	Partial bool            `json:"partial,omitempty"`
 }
 
 type ThreadSummary struct {
@@ -53,24 +60,20 @@ func createBatchGetThreadsHandler(factory *services.Factory) mcp.ToolHandlerFor[
 		rb.Blank()
 
 		total := len(input.ThreadIDs)
-		for i, threadID := range input.ThreadIDs {
-			// Report progress
-			if pt := req.Params.GetProgressToken(); pt != nil {
-				_ = req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
-					ProgressToken: pt,
-					Progress:      float64(i),
-					Total:         float64(total),
-					Message:       fmt.Sprintf("Fetching thread %d/%d", i+1, total),
-				})
-			}
-
-			thread, err := srv.Users.Threads.Get(input.UserEmail, threadID).
+		p := progress.New(ctx, req).Begin("Fetching thread", total)
+		results := fetchAll(ctx, p, input.ThreadIDs, func(ctx context.Context, id string) (*gmailpb.Thread, error) {
+			return srv.Users.Threads.Get(input.UserEmail, id).
 				Format(format).
 				Context(ctx).Do()
-			if err != nil {
-				rb.Item("Thread %s: ERROR — %v", threadID, err)
+		})
+		for i, f := range results {
+			if !f.ok {
+				if f.err != nil && !p.Cancelled() {
+					rb.Item("Thread %s: ERROR — %v", input.ThreadIDs[i], f.err)
+				}
 				continue
 			}
+			thread := f.value
 
 			ts := ThreadSummary{
 				ThreadID:     thread.Id,
@@ -87,8 +90,13 @@ func createBatchGetThreadsHandler(factory *services.Factory) mcp.ToolHandlerFor[
 
 			threads = append(threads, ts)
 		}
+		p.Done()
+		if p.Cancelled() {
+			rb.Blank()
+			rb.KeyValue("Partial", p.Partial())
+		}
 
-		return rb.TextResult(), BatchGetThreadsOutput{Threads: threads}, nil
+		return rb.TextResult(), BatchGetThreadsOutput{Threads: threads, Partial: p.Cancelled()}, nil
 	}
 }
 
@@ -96,43 +104,365 @@ func createBatchGetThreadsHandler(factory *services.Factory) mcp.ToolHandlerFor[
 
 type BatchModifyLabelsInput struct {
 	UserEmail      string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
-	MessageIDs     []string `json:"message_ids" jsonschema:"required" jsonschema_description:"Message IDs to modify"`
+	MessageIDs     []string `json:"message_ids,omitempty" jsonschema_description:"Message IDs to modify (max 1000); or use query"`
+	Query          string   `json:"query,omitempty" jsonschema_description:"Gmail search query selecting the messages to modify, instead of message_ids"`
+	MaxMessages    int      `json:"max_messages,omitempty" jsonschema_description:"With query: maximum messages to modify (default and max 1000)"`
 	AddLabelIDs    []string `json:"add_label_ids,omitempty" jsonschema_description:"Label IDs to add to messages"`
 	RemoveLabelIDs []string `json:"remove_label_ids,omitempty" jsonschema_description:"Label IDs to remove from messages"`
 }
 
-func createBatchModifyLabelsHandler(factory *services.Factory) mcp.ToolHandlerFor[BatchModifyLabelsInput, any] {
-	return func(ctx context.Context, req *mcp.CallToolRequest, input BatchModifyLabelsInput) (*mcp.CallToolResult, any, error) {
+type BatchModifyLabelsOutput struct {
+	Modified  int  `json:"modified"`
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+func createBatchModifyLabelsHandler(factory *services.Factory) mcp.ToolHandlerFor[BatchModifyLabelsInput, BatchModifyLabelsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input BatchModifyLabelsInput) (*mcp.CallToolResult, BatchModifyLabelsOutput, error) {
 		if len(input.AddLabelIDs) == 0 && len(input.RemoveLabelIDs) == 0 {
-			return nil, nil, fmt.Errorf("at least one of add_label_ids or remove_label_ids must be specified")
+			return nil, BatchModifyLabelsOutput{}, fmt.Errorf("at least one of add_label_ids or remove_label_ids must be specified")
+		}
+		switch {
+		case (len(input.MessageIDs) == 0) == (input.Query == ""):
+			return nil, BatchModifyLabelsOutput{}, fmt.Errorf("set exactly one of message_ids or query")
+		case len(input.MessageIDs) > maxBatchMessages:
+			return nil, BatchModifyLabelsOutput{}, fmt.Errorf("maximum %d messages per call, got %d - split into multiple calls", maxBatchMessages, len(input.MessageIDs))
+		}
+		if input.MaxMessages <= 0 || input.MaxMessages > maxBatchMessages {
+			input.MaxMessages = maxBatchMessages
 		}
 
 		srv, err := factory.Gmail(ctx, input.UserEmail)
 		if err != nil {
-			return nil, nil, middleware.HandleGoogleAPIError(err)
+			return nil, BatchModifyLabelsOutput{}, middleware.HandleGoogleAPIError(err)
 		}
 
+		p := progress.New(ctx, req)
+		ids, truncated := input.MessageIDs, false
+		if input.Query != "" {
+			if ids, truncated, err = searchMessageIDs(ctx, p, srv, input.UserEmail, input.Query, input.MaxMessages); err != nil {
+				return nil, BatchModifyLabelsOutput{}, err
+			}
+		}
+
+		rb := response.New()
+		rb.Header("Batch Label Modification Complete")
+		if len(ids) == 0 {
+			p.Done()
+			rb.KeyValue("Messages Modified", 0)
+			rb.Line("No messages match the query.")
+			return rb.TextResult(), BatchModifyLabelsOutput{}, nil
+		}
+
+		p.Begin("Modifying batch", 1)
+		if err := p.Next(); err != nil {
+			return nil, BatchModifyLabelsOutput{}, err
+		}
 		modReq := &gmailpb.BatchModifyMessagesRequest{
-			Ids:            input.MessageIDs,
+			Ids:            ids,
 			AddLabelIds:    input.AddLabelIDs,
 			RemoveLabelIds: input.RemoveLabelIDs,
 		}
-
-		err = srv.Users.Messages.BatchModify(input.UserEmail, modReq).Context(ctx).Do()
-		if err != nil {
-			return nil, nil, middleware.HandleGoogleAPIError(err)
+		if err := srv.Users.Messages.BatchModify(input.UserEmail, modReq).Context(ctx).Do(); err != nil {
+			return nil, BatchModifyLabelsOutput{}, middleware.HandleGoogleAPIError(err)
 		}
+		p.Done()
 
-		rb := response.New()
-		rb.Header("Batch Label Modification Complete")
-		rb.KeyValue("Messages Modified", len(input.MessageIDs))
+		rb.KeyValue("Messages Modified", len(ids))
 		if len(input.AddLabelIDs) > 0 {
 			rb.KeyValue("Labels Added", len(input.AddLabelIDs))
 		}
 		if len(input.RemoveLabelIDs) > 0 {
 			rb.KeyValue("Labels Removed", len(input.RemoveLabelIDs))
 		}
+		if truncated {
+			rb.Line("More messages match the query; run again to modify the next %d (messages that no longer match are skipped).", input.MaxMessages)
+		}
+
+		return rb.TextResult(), BatchModifyLabelsOutput{Modified: len(ids), Truncated: truncated}, nil
+	}
+}
+
+// searchMessageIDs pages through the messages matching query, up to limit,
+// reporting each page. truncated is set when more than limit match.
+func searchMessageIDs(ctx context.Context, p *progress.Reporter, srv *gmailpb.Service, userEmail, query string, limit int) (ids []string, truncated bool, err error) {
+	p.Begin("Searching page", 0)
+	pageToken := ""
+	for {
+		if err := p.Next(); err != nil {
+			return nil, false, err
+		}
+		result, err := srv.Users.Messages.List(userEmail).
+			Q(query).
+			MaxResults(int64(min(500, limit-len(ids)))).
+			PageToken(pageToken).
+			Fields("messages/id,nextPageToken").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, false, middleware.HandleGoogleAPIError(err)
+		}
+		for _, m := range result.Messages {
+			ids = append(ids, m.Id)
+		}
+		pageToken = result.NextPageToken
+		if pageToken == "" {
+			return ids, false, nil
+		}
+		if len(ids) >= limit {
+			return ids, true, nil
+		}
+	}
+}
+
+// --- file_attachments_by_rules (complete) ---
+
+type FileAttachmentsInput struct {
+	UserEmail       string       `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Query           string       `json:"query" jsonschema:"required" jsonschema_description:"Gmail search query selecting the messages to process (has:attachment is added)"`
+	Rules           []FilingRule `json:"rules,omitempty" jsonschema_description:"Filing rules, first match wins (default: the server's attachment_rules)"`
+	DefaultFolderID string       `json:"default_folder_id,omitempty" jsonschema_description:"Drive folder for attachments no rule matches (default: leave them unfiled)"`
+	Label           string       `json:"label,omitempty" jsonschema_description:"Label added to processed threads and excluded from the search, so reruns skip them (default Filed)"`
+	MaxMessages     int          `json:"max_messages,omitempty" jsonschema_description:"Maximum messages to process (default 25, max 100)"`
+	DryRun          bool         `json:"dry_run,omitempty" jsonschema_description:"Report where each attachment would go without saving or labeling anything"`
+}
+
+// FiledAttachment is one attachment saved (or, in a dry run, to be saved) to Drive.
+type FiledAttachment struct {
+	MessageID string `json:"message_id"`
+	ThreadID  string `json:"thread_id"`
+	Filename  string `json:"filename"`
+	FolderID  string `json:"folder_id"`
+	FileID    string `json:"file_id,omitempty"`
+}
+
+type FileAttachmentsOutput struct {
+	Filed          []FiledAttachment `json:"filed"`
+	Unmatched      int               `json:"unmatched"`
+	Failed         int               `json:"failed"`
+	LabeledThreads int               `json:"labeled_threads"`
+	DryRun         bool              `json:"dry_run,omitempty"`
+	Partial        bool              `json:"partial,omitempty"`
+}
+
+func createFileAttachmentsHandler(factory *services.Factory, defaultRules []FilingRule) mcp.ToolHandlerFor[FileAttachmentsInput, FileAttachmentsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input FileAttachmentsInput) (*mcp.CallToolResult, FileAttachmentsOutput, error) {
+		f, err := newAttachmentFiler(ctx, factory, req, input, defaultRules)
+		if err != nil {
+			return nil, FileAttachmentsOutput{}, err
+		}
+		list, err := f.gmail.Users.Messages.List(input.UserEmail).
+			Q(fmt.Sprintf("(%s) has:attachment -label:%q", input.Query, f.label)).
+			MaxResults(int64(f.max)).
+			Context(ctx).Do()
+		if err != nil {
+			return nil, FileAttachmentsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		if input.DryRun {
+			rb.Header("Attachment Filing Plan (dry run)")
+		} else {
+			rb.Header("Attachment Filing Results")
+		}
+		rb.KeyValue("Messages", len(list.Messages))
+		rb.Blank()
+		p := progress.New(ctx, req).Begin("Filing message", len(list.Messages))
+		for _, m := range list.Messages {
+			if p.Next() != nil {
+				break
+			}
+			f.fileMessage(ctx, rb, m.Id)
+		}
+		p.Done()
+		if !input.DryRun && !p.Cancelled() {
+			f.labelThreads(ctx, rb)
+		}
+
+		rb.Blank()
+		rb.KeyValue("Filed", len(f.out.Filed))
+		rb.KeyValue("Unmatched", f.out.Unmatched)
+		rb.KeyValue("Failed", f.out.Failed)
+		rb.KeyValue("Labeled threads", f.out.LabeledThreads)
+		if p.Cancelled() {
+			rb.KeyValue("Partial", p.Partial()+"; saved files stay in Drive and no threads were labeled")
+		}
+		f.out.Partial = p.Cancelled()
+		return rb.TextResult(), f.out, nil
+	}
+}
+
+// attachmentFiler saves the attachments of one user's messages to Drive
+// and tracks which threads were fully processed.
+type attachmentFiler struct {
+	gmail     *gmailpb.Service
+	drive     *drive.Service
+	userEmail string
+	rules     []FilingRule
+	fallback  string // folder for unmatched attachments; empty leaves them
+	label     string
+	max       int
+	dryRun    bool
+	stamp     map[string]string // provenance appProperties, if enabled
+
+	threads []string        // threads with every matched attachment saved
+	failed  map[string]bool // threads with at least one failure
+	out     FileAttachmentsOutput
+}
+
+func newAttachmentFiler(ctx context.Context, factory *services.Factory, req *mcp.CallToolRequest, input FileAttachmentsInput, defaultRules []FilingRule) (*attachmentFiler, error) {
+	rules := input.Rules
+	if len(rules) == 0 {
+		rules = defaultRules
+	}
+	if len(rules) == 0 && input.DefaultFolderID == "" {
+		return nil, fmt.Errorf("no filing rules — pass rules or default_folder_id, or configure attachment_rules on the server")
+	}
+	if err := validateFilingRules(rules); err != nil {
+		return nil, err
+	}
+	f := &attachmentFiler{
+		userEmail: input.UserEmail, rules: rules, fallback: input.DefaultFolderID,
+		label: input.Label, max: input.MaxMessages, dryRun: input.DryRun,
+		failed: make(map[string]bool), out: FileAttachmentsOutput{Filed: []FiledAttachment{}, DryRun: input.DryRun},
+	}
+	if f.label == "" {
+		f.label = "Filed"
+	}
+	switch {
+	case f.max <= 0:
+		f.max = 25
+	case f.max > 100:
+		f.max = 100
+	}
+	if stamp := factory.Provenance(req); stamp != nil {
+		f.stamp = stamp.Properties()
+	}
+	var err error
+	if f.gmail, err = factory.Gmail(ctx, input.UserEmail); err != nil {
+		return nil, middleware.HandleGoogleAPIError(err)
+	}
+	if f.drive, err = factory.Drive(ctx, input.UserEmail); err != nil {
+		return nil, middleware.HandleGoogleAPIError(err)
+	}
+	return f, nil
+}
+
+// fileMessage saves the attachments of one message, reporting each as a list item.
+func (f *attachmentFiler) fileMessage(ctx context.Context, rb *response.Builder, messageID string) {
+	msg, err := f.gmail.Users.Messages.Get(f.userEmail, messageID).Format("full").Context(ctx).Do()
+	if err != nil {
+		f.out.Failed++
+		rb.Item("%s: ERROR — %v", messageID, middleware.HandleGoogleAPIError(err))
+		return
+	}
+	from := extractHeader(msg, "From")
+	var attachments []AttachmentInfo
+	if msg.Payload != nil {
+		attachments = extractAttachments(msg.Payload)
+	}
+	saved := false
+	for _, a := range attachments {
+		folder := f.folderFor(from, a)
+		if folder == "" {
+			f.out.Unmatched++
+			rb.Item("%s (from %s): no matching rule — left unfiled", a.Filename, from)
+			continue
+		}
+		fileID, err := f.save(ctx, messageID, folder, a)
+		if err != nil {
+			f.out.Failed++
+			f.failed[msg.ThreadId] = true
+			rb.Item("%s (from %s): ERROR — %v", a.Filename, from, err)
+			continue
+		}
+		saved = true
+		f.out.Filed = append(f.out.Filed, FiledAttachment{MessageID: messageID, ThreadID: msg.ThreadId, Filename: a.Filename, FolderID: folder, FileID: fileID})
+		rb.Item("%s (from %s) → folder %s", a.Filename, from, folder)
+	}
+	if saved && !slices.Contains(f.threads, msg.ThreadId) {
+		f.threads = append(f.threads, msg.ThreadId)
+	}
+}
+
+// folderFor returns the Drive folder for an attachment, or "" to leave it.
+func (f *attachmentFiler) folderFor(from string, a AttachmentInfo) string {
+	if i := matchFilingRule(f.rules, from, a); i >= 0 {
+		return f.rules[i].FolderID
+	}
+	return f.fallback
+}
+
+// save uploads one attachment to folder and returns the new file's ID.
+func (f *attachmentFiler) save(ctx context.Context, messageID, folder string, a AttachmentInfo) (string, error) {
+	if f.dryRun {
+		return "", nil
+	}
+	body, err := f.gmail.Users.Messages.Attachments.Get(f.userEmail, messageID, a.AttachmentID).Context(ctx).Do()
+	if err != nil {
+		return "", middleware.HandleGoogleAPIError(err)
+	}
+	data, err := base64.URLEncoding.DecodeString(body.Data)
+	if err != nil {
+		return "", fmt.Errorf("decoding attachment data: %w", err)
+	}
+	created, err := f.drive.Files.Create(&drive.File{
+		Name:          a.Filename,
+		MimeType:      a.MimeType,
+		Parents:       []string{folder},
+		AppProperties: f.stamp,
+	}).Media(bytes.NewReader(data)).Fields("id").SupportsAllDrives(true).Context(ctx).Do()
+	if err != nil {
+		return "", middleware.HandleGoogleAPIError(err)
+	}
+	return created.Id, nil
+}
 
-		return rb.TextResult(), nil, nil
+// labelThreads adds the processed label to every thread whose matched
+// attachments were all saved. Threads with failures stay unlabeled so a
+// rerun retries them.
+func (f *attachmentFiler) labelThreads(ctx context.Context, rb *response.Builder) {
+	var pending []string
+	for _, id := range f.threads {
+		if !f.failed[id] {
+			pending = append(pending, id)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+	labelID, err := ensureLabel(ctx, f.gmail, f.userEmail, f.label)
+	if err != nil {
+		rb.Item("Could not label processed threads: %v", err)
+		return
+	}
+	for _, id := range pending {
+		_, err := f.gmail.Users.Threads.Modify(f.userEmail, id, &gmailpb.ModifyThreadRequest{AddLabelIds: []string{labelID}}).Context(ctx).Do()
+		if err != nil {
+			rb.Item("Thread %s: could not add label %q — %v", id, f.label, middleware.HandleGoogleAPIError(err))
+			continue
+		}
+		f.out.LabeledThreads++
+	}
+}
+
+// ensureLabel returns the ID of the user label named name, creating it if needed.
+func ensureLabel(ctx context.Context, srv *gmailpb.Service, userEmail, name string) (string, error) {
+	labels, err := srv.Users.Labels.List(userEmail).Context(ctx).Do()
+	if err != nil {
+		return "", middleware.HandleGoogleAPIError(err)
+	}
+	for _, l := range labels.Labels {
+		if strings.EqualFold(l.Name, name) {
+			return l.Id, nil
+		}
+	}
+	label, err := srv.Users.Labels.Create(userEmail, &gmailpb.Label{
+		Name:                  name,
+		LabelListVisibility:   "labelShow",
+		MessageListVisibility: "show",
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", middleware.HandleGoogleAPIError(err)
 	}
+	return label.Id, nil
 }
@@ -5,13 +5,16 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"google.golang.org/api/gmail/v1"
 
 	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/media"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/office"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
 )
@@ -63,13 +66,18 @@ func createGetAttachmentHandler(factory *services.Factory) mcp.ToolHandlerFor[Ge
 		var contentParts []mcp.Content
 
 		switch {
-		case strings.HasPrefix(mimeType, "image/"):
+		case media.InlineImage(mimeType, len(rawData)):
 			rb.Line("Image attachment returned as inline image content.")
 			contentParts = append(contentParts,
 				&mcp.TextContent{Text: rb.Build()},
 				&mcp.ImageContent{Data: rawData, MIMEType: mimeType},
 			)
 
+		case strings.HasPrefix(mimeType, "image/"):
+			rb.Blank()
+			rb.Line("Image not shown inline: only PNG, JPEG, GIF and WebP images up to %s are. Content available in structured output as base64url-encoded data.", formatAttachmentSize(media.MaxInlineImageSize))
+			contentParts = append(contentParts, &mcp.TextContent{Text: rb.Build()})
+
 		case strings.HasPrefix(mimeType, "text/") ||
 			mimeType == "application/json" ||
 			mimeType == "application/xml" ||
@@ -338,23 +346,41 @@ func createManageLabelHandler(factory *services.Factory) mcp.ToolHandlerFor[Mana
 // --- draft_gmail_message (extended) ---
 
 type DraftMessageInput struct {
-	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
-	To        string `json:"to" jsonschema:"required" jsonschema_description:"Recipient email address"`
-	Subject   string `json:"subject" jsonschema:"required" jsonschema_description:"Email subject"`
-	Body      string `json:"body" jsonschema:"required" jsonschema_description:"Email body content"`
-	CC        string `json:"cc,omitempty" jsonschema_description:"CC email address"`
-	BCC       string `json:"bcc,omitempty" jsonschema_description:"BCC email address"`
-	ThreadID  string `json:"thread_id,omitempty" jsonschema_description:"Thread ID to reply in"`
+	UserEmail   string            `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	To          string            `json:"to" jsonschema:"required" jsonschema_description:"Recipient email address"`
+	Subject     string            `json:"subject" jsonschema:"required" jsonschema_description:"Email subject"`
+	Body        string            `json:"body,omitempty" jsonschema_description:"Email body content: plain text, or Markdown with body_format=markdown (required unless body_html is set)"`
+	BodyHTML    string            `json:"body_html,omitempty" jsonschema_description:"HTML version of the body; sent as multipart/alternative with body (or text derived from the HTML) as the plain-text part"`
+	BodyFormat  string            `json:"body_format,omitempty" jsonschema_description:"How to read body: plain text, or Markdown converted to the HTML part,enum=plain,enum=markdown"`
+	CC          string            `json:"cc,omitempty" jsonschema_description:"CC email address"`
+	BCC         string            `json:"bcc,omitempty" jsonschema_description:"BCC email address"`
+	ThreadID    string            `json:"thread_id,omitempty" jsonschema_description:"Thread ID to reply in"`
+	Attachments []AttachmentInput `json:"attachments,omitempty" jsonschema_description:"Files to attach (25 MB total), each as base64 content or a Drive file ID"`
 }
 
 func createDraftMessageHandler(factory *services.Factory) mcp.ToolHandlerFor[DraftMessageInput, any] {
 	return func(ctx context.Context, req *mcp.CallToolRequest, input DraftMessageInput) (*mcp.CallToolResult, any, error) {
+		body, htmlBody, err := composeBody(input.Body, input.BodyHTML, input.BodyFormat)
+		if err != nil {
+			return nil, nil, err
+		}
+
 		srv, err := factory.Gmail(ctx, input.UserEmail)
 		if err != nil {
 			return nil, nil, middleware.HandleGoogleAPIError(err)
 		}
 
-		rawMsg := buildRawMessage(input.To, input.Subject, input.Body, input.CC, input.BCC, input.ThreadID, "", "")
+		attachments, err := resolveAttachments(ctx, factory, input.UserEmail, input.Attachments)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rawMsg := buildRawMessage(input.To, input.Subject, body, htmlBody, input.CC, input.BCC, input.ThreadID, "", "", attachments...)
+		if stamp := factory.Provenance(req); stamp != nil {
+			if rawMsg, err = stamp.StampRawMessage(rawMsg); err != nil {
+				return nil, nil, err
+			}
+		}
 
 		msg := &gmail.Message{Raw: rawMsg}
 		if input.ThreadID != "" {
@@ -376,6 +402,7 @@ func createDraftMessageHandler(factory *services.Factory) mcp.ToolHandlerFor[Dra
 		if draft.Message != nil {
 			rb.KeyValue("Message ID", draft.Message.Id)
 		}
+		writeAttachmentList(rb, attachments)
 
 		return rb.TextResult(), nil, nil
 	}
@@ -536,3 +563,328 @@ func createDeleteFilterHandler(factory *services.Factory) mcp.ToolHandlerFor[Del
 		return rb.TextResult(), nil, nil
 	}
 }
+
+// --- report_gmail_spam (extended) ---
+
+type ReportSpamInput struct {
+	UserEmail  string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	MessageIDs []string `json:"message_ids" jsonschema:"required" jsonschema_description:"Message IDs to report (max 1000)"`
+	Phishing   bool     `json:"phishing,omitempty" jsonschema_description:"The messages are phishing attempts rather than plain spam"`
+	NotSpam    bool     `json:"not_spam,omitempty" jsonschema_description:"Undo a report: move the messages out of Spam and back to the inbox"`
+}
+
+func createReportSpamHandler(factory *services.Factory) mcp.ToolHandlerFor[ReportSpamInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ReportSpamInput) (*mcp.CallToolResult, any, error) {
+		if len(input.MessageIDs) == 0 {
+			return nil, nil, fmt.Errorf("message_ids must contain at least one message ID")
+		}
+		if len(input.MessageIDs) > 1000 {
+			return nil, nil, fmt.Errorf("maximum 1000 messages per request, got %d — split into multiple calls", len(input.MessageIDs))
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		modReq := &gmail.BatchModifyMessagesRequest{
+			Ids:            input.MessageIDs,
+			AddLabelIds:    []string{"SPAM"},
+			RemoveLabelIds: []string{"INBOX"},
+		}
+		if input.NotSpam {
+			modReq.AddLabelIds, modReq.RemoveLabelIds = modReq.RemoveLabelIds, modReq.AddLabelIds
+		}
+		if err := srv.Users.Messages.BatchModify(input.UserEmail, modReq).Context(ctx).Do(); err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		switch {
+		case input.NotSpam:
+			rb.Header("Messages Restored From Spam")
+		case input.Phishing:
+			rb.Header("Phishing Reported")
+		default:
+			rb.Header("Spam Reported")
+		}
+		rb.KeyValue("Messages", len(input.MessageIDs))
+		if input.Phishing && !input.NotSpam {
+			rb.Blank()
+			rb.Line("The messages were moved to Spam, which trains Gmail's filters. The Gmail API has no phishing-report endpoint;")
+			rb.Line("to notify Google's abuse team, use \"Report phishing\" on the message in the Gmail web UI.")
+		}
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// --- list_gmail_spam (extended) ---
+
+type ListSpamInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Query     string `json:"query,omitempty" jsonschema_description:"Additional Gmail search operators to narrow the Spam folder (e.g. from:example.com newer_than:7d)"`
+	PageSize  int    `json:"page_size,omitempty" jsonschema_description:"Maximum number of results to return (default 10)"`
+	PageToken string `json:"page_token,omitempty" jsonschema_description:"Token for retrieving the next page of results"`
+}
+
+func createListSpamHandler(factory *services.Factory) mcp.ToolHandlerFor[ListSpamInput, SearchMessagesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListSpamInput) (*mcp.CallToolResult, SearchMessagesOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 10
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, SearchMessagesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		query := strings.TrimSpace("in:spam " + input.Query)
+		result, err := srv.Users.Messages.List(input.UserEmail).
+			Q(query).
+			IncludeSpamTrash(true).
+			MaxResults(int64(input.PageSize)).
+			PageToken(input.PageToken).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, SearchMessagesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		summaries := make([]MessageSummary, 0, len(result.Messages))
+		rb := response.New()
+		rb.Header("Gmail Spam")
+		rb.KeyValue("Query", query)
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+		for _, m := range result.Messages {
+			msg, err := srv.Users.Messages.Get(input.UserEmail, m.Id).
+				Format("metadata").
+				MetadataHeaders(summaryHeaders...).
+				Context(ctx).
+				Do()
+			if err != nil {
+				continue
+			}
+			s := messageToSummary(msg)
+			summaries = append(summaries, s)
+			rb.Item("Subject: %s", s.Subject)
+			rb.Line("    From: %s | Date: %s", s.From, s.Date)
+			rb.Line("    ID: %s | Unsubscribe: %s", s.ID, unsubscribeTargetFor(msg).method())
+		}
+
+		return rb.TextResult(), SearchMessagesOutput{
+			Messages:      summaries,
+			Query:         query,
+			NextPageToken: result.NextPageToken,
+			ResultCount:   len(summaries),
+		}, nil
+	}
+}
+
+// --- bulk_unsubscribe_gmail (extended) ---
+
+type BulkUnsubscribeInput struct {
+	UserEmail  string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	MessageIDs []string `json:"message_ids" jsonschema:"required" jsonschema_description:"One message from each mailing list to leave (max 25)"`
+	DryRun     bool     `json:"dry_run,omitempty" jsonschema_description:"Only report how each list would be unsubscribed without acting"`
+}
+
+func createBulkUnsubscribeHandler(factory *services.Factory, allowed []string) mcp.ToolHandlerFor[BulkUnsubscribeInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input BulkUnsubscribeInput) (*mcp.CallToolResult, any, error) {
+		if len(input.MessageIDs) == 0 {
+			return nil, nil, fmt.Errorf("message_ids must contain at least one message ID")
+		}
+		if len(input.MessageIDs) > 25 {
+			return nil, nil, fmt.Errorf("maximum 25 messages per request, got %d — split into multiple calls", len(input.MessageIDs))
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		u := &unsubscriber{srv: srv, userEmail: input.UserEmail, dryRun: input.DryRun, allowed: allowed, client: oneClickClient, seen: make(map[string]bool)}
+		rb := response.New()
+		if input.DryRun {
+			rb.Header("Unsubscribe Plan (dry run)")
+		} else {
+			rb.Header("Unsubscribe Results")
+		}
+		rb.KeyValue("Messages", len(input.MessageIDs))
+		rb.Blank()
+		p := progress.New(ctx, req).Begin("Unsubscribing message", len(input.MessageIDs))
+		for _, id := range input.MessageIDs {
+			if p.Next() != nil {
+				break
+			}
+			u.unsubscribe(ctx, rb, id)
+		}
+		p.Done()
+		if p.Cancelled() {
+			// Lists already left stay left; report where processing stopped.
+			rb.Blank()
+			rb.KeyValue("Partial", p.Partial())
+		}
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// unsubscriber leaves mailing lists on behalf of one user, skipping lists
+// already handled earlier in the same request.
+type unsubscriber struct {
+	srv       *gmail.Service
+	userEmail string
+	dryRun    bool
+	allowed   []string // permitted target domains; empty = any
+	client    *http.Client
+	seen      map[string]bool
+}
+
+// unsubscribe handles one message and reports the outcome as a list item.
+func (u *unsubscriber) unsubscribe(ctx context.Context, rb *response.Builder, messageID string) {
+	msg, err := u.srv.Users.Messages.Get(u.userEmail, messageID).
+		Format("metadata").
+		MetadataHeaders("From", "List-Unsubscribe", "List-Unsubscribe-Post").
+		Context(ctx).
+		Do()
+	if err != nil {
+		rb.Item("%s: ERROR — %v", messageID, middleware.HandleGoogleAPIError(err))
+		return
+	}
+	from := extractHeader(msg, "From")
+	target := unsubscribeTargetFor(msg)
+
+	method := target.method()
+	if method == unsubscribeNone {
+		rb.Item("%s: no List-Unsubscribe header — cannot unsubscribe automatically", from)
+		return
+	}
+	key := target.HTTPS + "|" + target.Mailto
+	if u.seen[key] {
+		rb.Item("%s: same list as an earlier message — skipped", from)
+		return
+	}
+	u.seen[key] = true
+
+	switch {
+	case method == unsubscribeManual:
+		rb.Item("%s: sender only offers a web page — open it to unsubscribe: %s", from, target.HTTPS)
+		return
+	case !domainAllowed(target.host(method), u.allowed):
+		rb.Item("%s: blocked — %s is not in UNSUBSCRIBE_ALLOWED_DOMAINS", from, target.host(method))
+		return
+	case u.dryRun:
+		rb.Item("%s: would unsubscribe via %s", from, method)
+		return
+	}
+
+	if err := u.execute(ctx, method, target); err != nil {
+		rb.Item("%s: FAILED via %s — %v", from, method, err)
+		return
+	}
+	rb.Item("%s: unsubscribed via %s", from, method)
+}
+
+// execute performs a one-click POST or sends the mailto unsubscribe email.
+func (u *unsubscriber) execute(ctx context.Context, method unsubscribeMethod, target unsubscribeTarget) error {
+	if method == unsubscribeOneClick {
+		return postOneClick(ctx, u.client, target.HTTPS)
+	}
+	m, err := parseMailto(target.Mailto)
+	if err != nil {
+		return err
+	}
+	raw := buildRawMessage(m.To, m.Subject, m.Body, "", "", "", "", "", "")
+	_, err = u.srv.Users.Messages.Send(u.userEmail, &gmail.Message{Raw: raw}).Context(ctx).Do()
+	return middleware.HandleGoogleAPIError(err)
+}
+
+// --- perform_unsubscribe (extended) ---
+
+type PerformUnsubscribeInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	MessageID string `json:"message_id" jsonschema:"required" jsonschema_description:"A message from the mailing list to leave"`
+	Method    string `json:"method,omitempty" jsonschema_description:"Force a method instead of the best available one,enum=one-click,enum=mailto"`
+	Confirm   bool   `json:"confirm,omitempty" jsonschema_description:"Must be true to unsubscribe; otherwise the target is only described so the user can confirm it"`
+}
+
+func createPerformUnsubscribeHandler(factory *services.Factory, allowed []string) mcp.ToolHandlerFor[PerformUnsubscribeInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input PerformUnsubscribeInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		msg, err := srv.Users.Messages.Get(input.UserEmail, input.MessageID).
+			Format("metadata").
+			MetadataHeaders(summaryHeaders...).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		target := unsubscribeTargetFor(msg)
+		method, err := chooseUnsubscribeMethod(target, unsubscribeMethod(input.Method))
+		if err != nil {
+			return nil, nil, err
+		}
+		host := target.host(method)
+		if !domainAllowed(host, allowed) {
+			return nil, nil, fmt.Errorf("unsubscribe target %s is not in this server's UNSUBSCRIBE_ALLOWED_DOMAINS — the user must unsubscribe manually", host)
+		}
+
+		if input.Confirm {
+			u := &unsubscriber{srv: srv, userEmail: input.UserEmail, client: oneClickClient}
+			if err := u.execute(ctx, method, target); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		rb := response.New()
+		if input.Confirm {
+			rb.Header("Unsubscribed")
+		} else {
+			rb.Header("Unsubscribe Pending Confirmation")
+		}
+		rb.KeyValue("From", extractHeader(msg, "From"))
+		writeMailingList(rb, mailingListInfo(msg))
+		rb.KeyValue("Method", method)
+		rb.KeyValue("Target host", host)
+		if !input.Confirm {
+			rb.Blank()
+			rb.Line("Nothing was done. Ask the user to confirm, then call again with confirm=true.")
+		}
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// chooseUnsubscribeMethod validates a requested method against what the
+// message offers, defaulting to the best available automatic method.
+func chooseUnsubscribeMethod(target unsubscribeTarget, requested unsubscribeMethod) (unsubscribeMethod, error) {
+	switch requested {
+	case "":
+		switch m := target.method(); m {
+		case unsubscribeOneClick, unsubscribeMailto:
+			return m, nil
+		case unsubscribeManual:
+			return "", fmt.Errorf("the sender only offers a web page, which must be opened by the user: %s", target.HTTPS)
+		default:
+			return "", fmt.Errorf("message has no List-Unsubscribe header — it cannot be unsubscribed automatically")
+		}
+	case unsubscribeOneClick:
+		if !target.OneClick {
+			return "", fmt.Errorf("the sender does not support one-click unsubscribe")
+		}
+	case unsubscribeMailto:
+		if target.Mailto == "" {
+			return "", fmt.Errorf("the sender does not offer a mailto unsubscribe address")
+		}
+	default:
+		return "", fmt.Errorf("invalid method %q — use one-click or mailto", requested)
+	}
+	return requested, nil
+}
@@ -0,0 +1,126 @@
+package gmail
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
+	"github.com/evert/google-workspace-mcp-go/internal/sandbox"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// cancelAfter serves sandbox data and cancels the call once n requests have
+// been answered, simulating a client cancelling mid-batch.
+type cancelAfter struct {
+	next   http.RoundTripper
+	n      int
+	cancel context.CancelFunc
+}
+
+func (c *cancelAfter) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := c.next.RoundTrip(r)
+	if c.n--; c.n == 0 {
+		c.cancel()
+	}
+	return resp, err
+}
+
+func TestBatchGetMessagesPartialOnCancel(t *testing.T) {
+	// Fetch one at a time so the cancellation point is deterministic.
+	defer func(n int) { fetchConcurrency = n }(fetchConcurrency)
+	fetchConcurrency = 1
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(&cancelAfter{next: sandbox.NewTransport(), n: 2, cancel: cancel})
+
+	ids := []string{"18f2a1c0d4e5f601", "18f2a1c0d4e5f602", "18f2a1c0d4e5f603", "18f2a1c0d4e5f604"}
+	res, out, err := createBatchGetMessagesHandler(factory)(ctx, &mcp.CallToolRequest{}, BatchGetMessagesInput{
+		UserEmail:  sandbox.DemoUser,
+		MessageIDs: ids,
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v, want partial result", err)
+	}
+	if !out.Partial {
+		t.Error("Partial = false, want true")
+	}
+	if len(out.Messages) != 2 {
+		t.Errorf("got %d messages, want the 2 fetched before cancellation", len(out.Messages))
+	}
+	text := res.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "cancelled at Fetching message 3/4; results are partial") {
+		t.Errorf("result text does not flag the partial result:\n%s", text)
+	}
+}
+
+func TestBatchModifyLabelsValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  BatchModifyLabelsInput
+		errMsg string
+	}{
+		{"no labels", BatchModifyLabelsInput{MessageIDs: []string{"a"}}, "at least one of"},
+		{"no target", BatchModifyLabelsInput{AddLabelIDs: []string{"STARRED"}}, "exactly one of"},
+		{"both targets", BatchModifyLabelsInput{MessageIDs: []string{"a"}, Query: "from:x", AddLabelIDs: []string{"STARRED"}}, "exactly one of"},
+		{"too many", BatchModifyLabelsInput{MessageIDs: make([]string, maxBatchMessages+1), AddLabelIDs: []string{"STARRED"}}, "maximum 1000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := createBatchModifyLabelsHandler(nil)(context.Background(), &mcp.CallToolRequest{}, tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("error = %v, want containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestSearchMessageIDs(t *testing.T) {
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(sandbox.NewTransport())
+	srv, err := factory.Gmail(context.Background(), sandbox.DemoUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids, truncated, err := searchMessageIDs(context.Background(), progress.New(context.Background(), &mcp.CallToolRequest{}), srv, sandbox.DemoUser, "in:inbox", maxBatchMessages)
+	if err != nil {
+		t.Fatalf("searchMessageIDs() error = %v", err)
+	}
+	if len(ids) == 0 || truncated {
+		t.Errorf("searchMessageIDs() = %d ids, truncated %v; want all sandbox messages", len(ids), truncated)
+	}
+}
+
+func TestBatchGetMessagesReportsFailures(t *testing.T) {
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(sandbox.NewTransport())
+
+	res, out, err := createBatchGetMessagesHandler(factory)(context.Background(), &mcp.CallToolRequest{}, BatchGetMessagesInput{
+		UserEmail:  sandbox.DemoUser,
+		MessageIDs: []string{"18f2a1c0d4e5f601", "missing-message"},
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v, want per-ID errors", err)
+	}
+	if len(out.Messages) != 1 {
+		t.Errorf("got %d messages, want 1", len(out.Messages))
+	}
+	if len(out.Errors) != 1 || out.Errors[0].MessageID != "missing-message" || !strings.Contains(out.Errors[0].Error, "not found") {
+		t.Errorf("Errors = %+v, want one not-found error for missing-message", out.Errors)
+	}
+	text := res.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "Failed: 1") || !strings.Contains(text, "missing-message: ") {
+		t.Errorf("result text does not list the failure:\n%s", text)
+	}
+}
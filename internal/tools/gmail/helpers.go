@@ -4,13 +4,18 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
 	"strings"
 
 	"google.golang.org/api/gmail/v1"
 
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/format"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/htmlutil"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
 )
 
 // MessageSummary is a compact representation of a Gmail message.
@@ -23,6 +28,17 @@ type MessageSummary struct {
 	Date     string   `json:"date,omitempty"`
 	Snippet  string   `json:"snippet,omitempty"`
 	LabelIDs []string `json:"label_ids,omitempty"`
+
+	MailingList *MailingListInfo `json:"mailing_list,omitempty"`
+}
+
+// MailingListInfo holds the mailing-list headers of a message. It is only
+// present on messages that carry at least one of them.
+type MailingListInfo struct {
+	ListID      string `json:"list_id,omitempty"`
+	Unsubscribe string `json:"list_unsubscribe,omitempty"`
+	OneClick    bool   `json:"one_click_unsubscribe,omitempty"`
+	Precedence  string `json:"precedence,omitempty"`
 }
 
 // AttachmentInfo describes a single attachment on a Gmail message.
@@ -46,6 +62,45 @@ type MessageDetail struct {
 	Body        string           `json:"body"`
 	LabelIDs    []string         `json:"label_ids,omitempty"`
 	Attachments []AttachmentInfo `json:"attachments,omitempty"`
+	MailingList *MailingListInfo `json:"mailing_list,omitempty"`
+}
+
+// summaryHeaders are the headers requested when fetching message metadata.
+var summaryHeaders = []string{"Subject", "From", "To", "Date", "List-Id", "List-Unsubscribe", "List-Unsubscribe-Post", "Precedence"}
+
+// mailingListInfo extracts List-Id, List-Unsubscribe, and Precedence headers,
+// returning nil for messages that are not list mail.
+func mailingListInfo(msg *gmail.Message) *MailingListInfo {
+	info := MailingListInfo{
+		ListID:      extractHeader(msg, "List-Id"),
+		Unsubscribe: extractHeader(msg, "List-Unsubscribe"),
+		OneClick:    unsubscribeTargetFor(msg).OneClick,
+		Precedence:  extractHeader(msg, "Precedence"),
+	}
+	if info.ListID == "" && info.Unsubscribe == "" && info.Precedence == "" {
+		return nil
+	}
+	return &info
+}
+
+// writeMailingList adds the mailing-list headers of a message to rb.
+func writeMailingList(rb *response.Builder, ml *MailingListInfo) {
+	if ml == nil {
+		return
+	}
+	if ml.ListID != "" {
+		rb.KeyValue("List-Id", ml.ListID)
+	}
+	if ml.Precedence != "" {
+		rb.KeyValue("Precedence", ml.Precedence)
+	}
+	if ml.Unsubscribe != "" {
+		method := parseListUnsubscribe(ml.Unsubscribe, "").method()
+		if ml.OneClick {
+			method = unsubscribeOneClick
+		}
+		rb.KeyValue("Unsubscribe", fmt.Sprintf("%s (%s)", ml.Unsubscribe, method))
+	}
 }
 
 // extractHeader returns the value of a named header from a Gmail message.
@@ -172,9 +227,48 @@ func messageToSummary(msg *gmail.Message) MessageSummary {
 		Date:     extractHeader(msg, "Date"),
 		Snippet:  msg.Snippet,
 		LabelIDs: msg.LabelIds,
+
+		MailingList: mailingListInfo(msg),
 	}
 }
 
+// SearchThreadGroup is one thread in grouped search results: how many of the
+// matching messages belong to it, and the newest of them.
+type SearchThreadGroup struct {
+	ThreadID        string `json:"thread_id"`
+	MessageCount    int    `json:"message_count"`
+	LatestMessageID string `json:"latest_message_id"`
+	Subject         string `json:"subject,omitempty"`
+	From            string `json:"from,omitempty"`
+	Date            string `json:"date,omitempty"`
+	Snippet         string `json:"snippet,omitempty"`
+}
+
+// groupByThread collapses message summaries into one entry per thread, in
+// order of first appearance. Gmail lists matches newest first, so the first
+// message seen for a thread supplies its latest subject and snippet.
+func groupByThread(messages []MessageSummary) []SearchThreadGroup {
+	threads := make([]SearchThreadGroup, 0, len(messages))
+	index := make(map[string]int, len(messages))
+	for _, m := range messages {
+		if i, ok := index[m.ThreadID]; ok {
+			threads[i].MessageCount++
+			continue
+		}
+		index[m.ThreadID] = len(threads)
+		threads = append(threads, SearchThreadGroup{
+			ThreadID:        m.ThreadID,
+			MessageCount:    1,
+			LatestMessageID: m.ID,
+			Subject:         m.Subject,
+			From:            m.From,
+			Date:            m.Date,
+			Snippet:         m.Snippet,
+		})
+	}
+	return threads
+}
+
 // messageToDetail converts a Gmail message to full detail including body.
 func messageToDetail(msg *gmail.Message) MessageDetail {
 	var attachments []AttachmentInfo
@@ -194,6 +288,7 @@ func messageToDetail(msg *gmail.Message) MessageDetail {
 		Body:        extractBody(msg),
 		LabelIDs:    msg.LabelIds,
 		Attachments: attachments,
+		MailingList: mailingListInfo(msg),
 	}
 }
 
@@ -232,7 +327,11 @@ func formatSubjectHeader(subject string) string {
 //   - Subject is RFC 2047 Q-encoded (after BOM/control sanitization).
 //   - Body is declared Content-Transfer-Encoding: 8bit with charset UTF-8,
 //     which tells receiving MTAs to expect raw UTF-8 octets.
-func buildRawMessage(to, subject, body, cc, bcc, threadID, inReplyTo, references string) string {
+//   - With an HTML body the body is multipart/alternative: the plain text
+//     first, then the quoted-printable HTML that clients prefer.
+//   - With attachments the message is multipart/mixed: the body part first,
+//     then one base64 part per attachment.
+func buildRawMessage(to, subject, body, htmlBody, cc, bcc, threadID, inReplyTo, references string, attachments ...mailAttachment) string {
 	var msg strings.Builder
 
 	msg.WriteString(fmt.Sprintf("To: %s\r\n", sanitizeOneLineHeaderValue(to)))
@@ -252,10 +351,94 @@ func buildRawMessage(to, subject, body, cc, bcc, threadID, inReplyTo, references
 	}
 
 	msg.WriteString("MIME-Version: 1.0\r\n")
-	msg.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
-	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
-	msg.WriteString("\r\n")
-	msg.WriteString(body)
+	bodyHeader, bodyContent := bodyEntity(body, htmlBody)
+	if len(attachments) == 0 {
+		for _, key := range []string{"Content-Type", "Content-Transfer-Encoding"} {
+			if v := bodyHeader.Get(key); v != "" {
+				msg.WriteString(key + ": " + v + "\r\n")
+			}
+		}
+		msg.WriteString("\r\n")
+		msg.WriteString(bodyContent)
+		return base64.URLEncoding.EncodeToString([]byte(msg.String()))
+	}
+
+	mw := multipart.NewWriter(&msg)
+	msg.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mw.Boundary()))
+	part, _ := mw.CreatePart(bodyHeader)
+	_, _ = io.WriteString(part, bodyContent)
+	for _, a := range attachments {
+		part, _ = mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(a.MimeType, map[string]string{"name": a.Filename})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		writeBase64Lines(part, a.Data)
+	}
+	_ = mw.Close()
 
 	return base64.URLEncoding.EncodeToString([]byte(msg.String()))
 }
+
+// composeBody resolves the plain-text and HTML versions of a message body.
+// Markdown bodies are converted to HTML and kept as the plain text; an HTML
+// body without plain text gets one derived from the HTML.
+func composeBody(body, bodyHTML, bodyFormat string) (text, htmlBody string, err error) {
+	switch bodyFormat {
+	case "", "plain":
+	case "markdown":
+		if bodyHTML != "" {
+			return "", "", fmt.Errorf("set body_html or body_format=markdown, not both")
+		}
+		bodyHTML = htmlutil.FromMarkdown(body)
+	default:
+		return "", "", fmt.Errorf("invalid body_format %q — use plain or markdown", bodyFormat)
+	}
+	if body == "" {
+		if bodyHTML == "" {
+			return "", "", fmt.Errorf("body or body_html is required")
+		}
+		body = htmlutil.ToPlainText(bodyHTML)
+	}
+	return body, bodyHTML, nil
+}
+
+// bodyEntity returns the MIME headers and content of the message body: plain
+// text alone, or multipart/alternative when there is an HTML version.
+func bodyEntity(text, htmlBody string) (textproto.MIMEHeader, string) {
+	plain := textproto.MIMEHeader{
+		"Content-Type":              {`text/plain; charset="UTF-8"`},
+		"Content-Transfer-Encoding": {"8bit"},
+	}
+	if htmlBody == "" {
+		return plain, text
+	}
+
+	var content strings.Builder
+	mw := multipart.NewWriter(&content)
+	part, _ := mw.CreatePart(plain)
+	_, _ = io.WriteString(part, text)
+	part, _ = mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {`text/html; charset="UTF-8"`},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	qp := quotedprintable.NewWriter(part)
+	_, _ = io.WriteString(qp, htmlBody)
+	_ = qp.Close()
+	_ = mw.Close()
+
+	return textproto.MIMEHeader{
+		"Content-Type": {fmt.Sprintf("multipart/alternative; boundary=%q", mw.Boundary())},
+	}, content.String()
+}
+
+// writeBase64Lines writes data as base64 wrapped at 76 characters, the
+// RFC 2045 line limit.
+func writeBase64Lines(w io.Writer, data []byte) {
+	enc := base64.StdEncoding.EncodeToString(data)
+	for len(enc) > 76 {
+		_, _ = io.WriteString(w, enc[:76]+"\r\n")
+		enc = enc[76:]
+	}
+	_, _ = io.WriteString(w, enc+"\r\n")
+}
@@ -2,6 +2,11 @@ package gmail
 
 import (
 	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -109,11 +114,34 @@ func TestMessageToSummary(t *testing.T) {
 	}
 }
 
+func TestMailingListInfo(t *testing.T) {
+	msg := &gmail.Message{Payload: &gmail.MessagePart{Headers: []*gmail.MessagePartHeader{
+		{Name: "List-Id", Value: "News <news.example.com>"},
+		{Name: "List-Unsubscribe", Value: "<https://example.com/u>"},
+		{Name: "List-Unsubscribe-Post", Value: "List-Unsubscribe=One-Click"},
+		{Name: "Precedence", Value: "bulk"},
+	}}}
+
+	ml := mailingListInfo(msg)
+	if ml == nil || ml.ListID != "News <news.example.com>" || !ml.OneClick || ml.Precedence != "bulk" {
+		t.Errorf("mailingListInfo = %+v", ml)
+	}
+	if summary := messageToSummary(msg); summary.MailingList == nil {
+		t.Error("summary is missing mailing list info")
+	}
+
+	plain := &gmail.Message{Payload: &gmail.MessagePart{Headers: []*gmail.MessagePartHeader{{Name: "Subject", Value: "Hi"}}}}
+	if ml := mailingListInfo(plain); ml != nil {
+		t.Errorf("expected nil for non-list message, got %+v", ml)
+	}
+}
+
 func TestBuildRawMessage(t *testing.T) {
 	raw := buildRawMessage(
 		"bob@example.com",
 		"Test Subject",
 		"Hello Bob!",
+		"",
 		"cc@example.com",
 		"",
 		"",
@@ -145,7 +173,7 @@ func TestBuildRawMessage(t *testing.T) {
 }
 
 func TestBuildRawMessageMinimal(t *testing.T) {
-	raw := buildRawMessage("bob@example.com", "Hi", "Body", "", "", "", "", "")
+	raw := buildRawMessage("bob@example.com", "Hi", "Body", "", "", "", "", "", "")
 	decoded, err := base64.URLEncoding.DecodeString(raw)
 	if err != nil {
 		t.Fatalf("decoding raw message: %v", err)
@@ -418,7 +446,7 @@ func TestMessageToDetailWithoutAttachments(t *testing.T) {
 }
 
 func TestBuildRawMessageSubjectUTF8RFC2047(t *testing.T) {
-	raw := buildRawMessage("bob@example.com", "café", "Body", "", "", "", "", "")
+	raw := buildRawMessage("bob@example.com", "café", "Body", "", "", "", "", "", "")
 	decoded, err := base64.URLEncoding.DecodeString(raw)
 	if err != nil {
 		t.Fatalf("decoding raw message: %v", err)
@@ -430,7 +458,7 @@ func TestBuildRawMessageSubjectUTF8RFC2047(t *testing.T) {
 }
 
 func TestBuildRawMessageSubjectStripsBOM(t *testing.T) {
-	raw := buildRawMessage("bob@example.com", "\ufeffHello", "Body", "", "", "", "", "")
+	raw := buildRawMessage("bob@example.com", "\ufeffHello", "Body", "", "", "", "", "", "")
 	decoded, err := base64.URLEncoding.DecodeString(raw)
 	if err != nil {
 		t.Fatalf("decoding raw message: %v", err)
@@ -443,3 +471,105 @@ func TestBuildRawMessageSubjectStripsBOM(t *testing.T) {
 		t.Error("subject should not Q-encode a BOM; BOM should be removed")
 	}
 }
+
+func TestGroupByThread(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []MessageSummary
+		want     []SearchThreadGroup
+	}{
+		{"empty", nil, []SearchThreadGroup{}},
+		{
+			"busy thread collapses to latest",
+			[]MessageSummary{
+				{ID: "m3", ThreadID: "t1", Subject: "Re: Launch", From: "bob@example.com", Snippet: "ship it"},
+				{ID: "m2", ThreadID: "t2", Subject: "Invoice", Snippet: "attached"},
+				{ID: "m1", ThreadID: "t1", Subject: "Launch", From: "alice@example.com", Snippet: "plan"},
+			},
+			[]SearchThreadGroup{
+				{ThreadID: "t1", MessageCount: 2, LatestMessageID: "m3", Subject: "Re: Launch", From: "bob@example.com", Snippet: "ship it"},
+				{ThreadID: "t2", MessageCount: 1, LatestMessageID: "m2", Subject: "Invoice", Snippet: "attached"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := groupByThread(tt.messages)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groupByThread() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposeBody(t *testing.T) {
+	tests := []struct {
+		name                   string
+		body, bodyHTML, format string
+		wantText, wantHTML     string
+		wantErr                bool
+	}{
+		{name: "plain", body: "Hi", wantText: "Hi"},
+		{name: "html with text", body: "Hi", bodyHTML: "<p>Hi</p>", wantText: "Hi", wantHTML: "<p>Hi</p>"},
+		{name: "html only", bodyHTML: "<p>Hello <b>there</b></p>", wantText: "Hello there", wantHTML: "<p>Hello <b>there</b></p>"},
+		{name: "markdown", body: "**Hi**", format: "markdown", wantText: "**Hi**", wantHTML: "<p><strong>Hi</strong></p>\n"},
+		{name: "markdown and html", body: "Hi", bodyHTML: "<p>Hi</p>", format: "markdown", wantErr: true},
+		{name: "unknown format", body: "Hi", format: "rtf", wantErr: true},
+		{name: "empty", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, html, err := composeBody(tt.body, tt.bodyHTML, tt.format)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if text != tt.wantText || html != tt.wantHTML {
+				t.Errorf("composeBody() = %q, %q, want %q, %q", text, html, tt.wantText, tt.wantHTML)
+			}
+		})
+	}
+}
+
+func TestBuildRawMessageHTML(t *testing.T) {
+	html := `<p style="color:#333">` + strings.Repeat("Quarterly numbers are up. ", 10) + "</p>"
+	raw := buildRawMessage("bob@example.com", "Report", "Numbers are up.", html, "", "", "", "", "")
+	decoded, err := base64.URLEncoding.DecodeString(raw)
+	if err != nil {
+		t.Fatalf("decoding raw message: %v", err)
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(string(decoded)))
+	if err != nil {
+		t.Fatalf("parsing message: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", msg.Header.Get("Content-Type"), err)
+	}
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	var types []string
+	var gotHTML string
+	for {
+		p, err := mr.NextPart()
+		if err != nil {
+			break
+		}
+		types = append(types, p.Header.Get("Content-Type"))
+		data, _ := io.ReadAll(p) // multipart.Reader decodes quoted-printable
+		if strings.HasPrefix(p.Header.Get("Content-Type"), "text/html") {
+			gotHTML = string(data)
+		}
+	}
+	if len(types) != 2 || !strings.HasPrefix(types[0], "text/plain") || !strings.HasPrefix(types[1], "text/html") {
+		t.Errorf("part types = %v, want text/plain then text/html", types)
+	}
+	if gotHTML != html {
+		t.Errorf("html part = %q, want %q", gotHTML, html)
+	}
+}
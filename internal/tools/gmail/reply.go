@@ -0,0 +1,348 @@
+package gmail
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"net/mail"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// prefixSubject adds prefix ("Re:" or "Fwd:") unless the subject already
+// carries it or one of its aliases.
+func prefixSubject(subject, prefix string, aliases ...string) string {
+	lower := strings.ToLower(strings.TrimSpace(subject))
+	for _, p := range append([]string{prefix}, aliases...) {
+		if strings.HasPrefix(lower, strings.ToLower(p)) {
+			return strings.TrimSpace(subject)
+		}
+	}
+	return strings.TrimSpace(prefix + " " + subject)
+}
+
+// replyReferences extends the original References chain with its
+// Message-ID, as RFC 5322 section 3.6.4 describes. Without References the
+// original In-Reply-To starts the chain.
+func replyReferences(references, inReplyTo, messageID string) string {
+	chain := strings.TrimSpace(references)
+	if chain == "" {
+		chain = strings.TrimSpace(inReplyTo)
+	}
+	if messageID == "" || strings.Contains(chain, messageID) {
+		return chain
+	}
+	return strings.TrimSpace(chain + " " + messageID)
+}
+
+// replyRecipients returns who a reply goes to: Reply-To or From, and for a
+// reply-all the original To and Cc as well, without the user and duplicates.
+func replyRecipients(msg *gmail.Message, self string, all bool) (to, cc string) {
+	seen := map[string]bool{strings.ToLower(self): true}
+	collect := func(list string) []string {
+		var out []string
+		addrs, err := mail.ParseAddressList(list)
+		if err != nil {
+			if list = strings.TrimSpace(list); list != "" && !seen[strings.ToLower(list)] {
+				seen[strings.ToLower(list)] = true
+				out = append(out, list)
+			}
+			return out
+		}
+		for _, a := range addrs {
+			if key := strings.ToLower(a.Address); !seen[key] {
+				seen[key] = true
+				out = append(out, a.String())
+			}
+		}
+		return out
+	}
+
+	sender := extractHeader(msg, "Reply-To")
+	if sender == "" {
+		sender = extractHeader(msg, "From")
+	}
+	// A reply to one's own message goes back to its recipients.
+	toList := collect(sender)
+	if len(toList) == 0 {
+		toList = collect(extractHeader(msg, "To"))
+	}
+	if all {
+		toList = append(toList, collect(extractHeader(msg, "To"))...)
+		cc = strings.Join(collect(extractHeader(msg, "Cc")), ", ")
+	}
+	return strings.Join(toList, ", "), cc
+}
+
+// quoteText renders the original message as a quoted plain-text block.
+func quoteText(date, from, body string) string {
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(body, "\r\n", "\n"), "\n"), "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(l, ">") {
+			lines[i] = ">" + l
+		} else {
+			lines[i] = "> " + l
+		}
+	}
+	return fmt.Sprintf("On %s, %s wrote:\n%s", date, from, strings.Join(lines, "\n"))
+}
+
+// quoteHTML renders the original message as a quoted HTML block.
+func quoteHTML(date, from, body string) string {
+	escaped := strings.ReplaceAll(html.EscapeString(strings.TrimRight(body, "\r\n")), "\n", "<br>\n")
+	return fmt.Sprintf(`<div class="gmail_quote">On %s, %s wrote:<br>`+
+		`<blockquote style="margin:0 0 0 .8ex;border-left:1px solid #ccc;padding-left:1ex">%s</blockquote></div>`,
+		html.EscapeString(date), html.EscapeString(from), escaped)
+}
+
+// forwardedHeaders is the header summary above a forwarded message.
+func forwardedHeaders(msg *gmail.Message) string {
+	var b strings.Builder
+	b.WriteString("---------- Forwarded message ---------\n")
+	for _, name := range []string{"From", "Date", "Subject", "To", "Cc"} {
+		if v := extractHeader(msg, name); v != "" {
+			fmt.Fprintf(&b, "%s: %s\n", name, v)
+		}
+	}
+	return b.String()
+}
+
+// appendQuoted places quoted original content below the new text and HTML
+// bodies, separated by a blank line.
+func appendQuoted(text, htmlBody, quotedText, quotedHTML string) (string, string) {
+	if text != "" {
+		text += "\n\n"
+	}
+	text += quotedText
+	if htmlBody != "" {
+		htmlBody += "<br>\n" + quotedHTML
+	}
+	return text, htmlBody
+}
+
+// originalAttachments downloads the attachments of msg for forwarding,
+// enforcing maxAttachmentsSize.
+func originalAttachments(ctx context.Context, srv *gmail.Service, userEmail string, msg *gmail.Message) ([]mailAttachment, error) {
+	if msg.Payload == nil {
+		return nil, nil
+	}
+	var (
+		out   []mailAttachment
+		total int
+	)
+	for _, a := range extractAttachments(msg.Payload) {
+		body, err := srv.Users.Messages.Attachments.Get(userEmail, msg.Id, a.AttachmentID).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		data, err := base64.URLEncoding.DecodeString(body.Data)
+		if err != nil {
+			return nil, fmt.Errorf("decoding attachment %q: %w", a.Filename, err)
+		}
+		if total += len(data); total > maxAttachmentsSize {
+			return nil, fmt.Errorf("the original attachments exceed Gmail's 25 MB limit — forward with exclude_attachments and share the files as Drive links")
+		}
+		out = append(out, mailAttachment{Filename: a.Filename, MimeType: a.MimeType, Data: data})
+	}
+	return out, nil
+}
+
+// ComposeOutput describes a sent reply or forward, or the draft holding it.
+type ComposeOutput struct {
+	MessageID string `json:"message_id"`
+	ThreadID  string `json:"thread_id"`
+	DraftID   string `json:"draft_id,omitempty"`
+	To        string `json:"to"`
+	CC        string `json:"cc,omitempty"`
+	Subject   string `json:"subject"`
+}
+
+// deliverMessage sends raw in threadID, or saves it as a draft there.
+// Drafts carry the provenance stamp like draft_gmail_message.
+func deliverMessage(ctx context.Context, factory *services.Factory, req *mcp.CallToolRequest, srv *gmail.Service, userEmail, raw, threadID string, draft bool) (ComposeOutput, error) {
+	if !draft {
+		sent, err := srv.Users.Messages.Send(userEmail, &gmail.Message{Raw: raw, ThreadId: threadID}).Context(ctx).Do()
+		if err != nil {
+			return ComposeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		return ComposeOutput{MessageID: sent.Id, ThreadID: sent.ThreadId}, nil
+	}
+
+	if stamp := factory.Provenance(req); stamp != nil {
+		var err error
+		if raw, err = stamp.StampRawMessage(raw); err != nil {
+			return ComposeOutput{}, err
+		}
+	}
+	d, err := srv.Users.Drafts.Create(userEmail, &gmail.Draft{
+		Message: &gmail.Message{Raw: raw, ThreadId: threadID},
+	}).Context(ctx).Do()
+	if err != nil {
+		return ComposeOutput{}, middleware.HandleGoogleAPIError(err)
+	}
+	out := ComposeOutput{DraftID: d.Id, ThreadID: threadID}
+	if d.Message != nil {
+		out.MessageID = d.Message.Id
+	}
+	return out, nil
+}
+
+// writeComposeResult renders the outcome of a reply or forward.
+func writeComposeResult(out ComposeOutput, action string, attachments []mailAttachment) *mcp.CallToolResult {
+	rb := response.New()
+	if out.DraftID != "" {
+		rb.Header("%s Draft Created", action)
+		rb.KeyValue("Draft ID", out.DraftID)
+	} else {
+		rb.Header("%s Sent", action)
+	}
+	rb.KeyValue("To", out.To)
+	if out.CC != "" {
+		rb.KeyValue("CC", out.CC)
+	}
+	rb.KeyValue("Subject", out.Subject)
+	rb.KeyValue("Message ID", out.MessageID)
+	rb.KeyValue("Thread ID", out.ThreadID)
+	writeAttachmentList(rb, attachments)
+	return rb.TextResult()
+}
+
+// --- reply_to_gmail_message (core) ---
+
+type ReplyMessageInput struct {
+	UserEmail   string            `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	MessageID   string            `json:"message_id" jsonschema:"required" jsonschema_description:"The Gmail message ID to reply to"`
+	Body        string            `json:"body,omitempty" jsonschema_description:"Reply text: plain text, or Markdown with body_format=markdown (required unless body_html is set)"`
+	BodyHTML    string            `json:"body_html,omitempty" jsonschema_description:"HTML version of the reply; sent as multipart/alternative"`
+	BodyFormat  string            `json:"body_format,omitempty" jsonschema_description:"How to read body: plain text, or Markdown converted to the HTML part,enum=plain,enum=markdown"`
+	ReplyAll    bool              `json:"reply_all,omitempty" jsonschema_description:"Also reply to the original To and Cc recipients"`
+	CC          string            `json:"cc,omitempty" jsonschema_description:"Additional CC addresses, comma-separated"`
+	OmitQuote   bool              `json:"omit_quote,omitempty" jsonschema_description:"Do not quote the original message below the reply"`
+	Attachments []AttachmentInput `json:"attachments,omitempty" jsonschema_description:"Files to attach (25 MB total), each as base64 content or a Drive file ID"`
+	Draft       bool              `json:"draft,omitempty" jsonschema_description:"Save the reply as a draft in the thread instead of sending it"`
+}
+
+func createReplyMessageHandler(factory *services.Factory) mcp.ToolHandlerFor[ReplyMessageInput, ComposeOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ReplyMessageInput) (*mcp.CallToolResult, ComposeOutput, error) {
+		body, htmlBody, err := composeBody(input.Body, input.BodyHTML, input.BodyFormat)
+		if err != nil {
+			return nil, ComposeOutput{}, err
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ComposeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		orig, err := srv.Users.Messages.Get(input.UserEmail, input.MessageID).Format("full").Context(ctx).Do()
+		if err != nil {
+			return nil, ComposeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		attachments, err := resolveAttachments(ctx, factory, input.UserEmail, input.Attachments)
+		if err != nil {
+			return nil, ComposeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		to, cc := replyRecipients(orig, input.UserEmail, input.ReplyAll)
+		if input.CC != "" {
+			cc = strings.TrimPrefix(cc+", "+input.CC, ", ")
+		}
+		subject := prefixSubject(extractHeader(orig, "Subject"), "Re:")
+		messageID := extractHeader(orig, "Message-ID")
+		references := replyReferences(extractHeader(orig, "References"), extractHeader(orig, "In-Reply-To"), messageID)
+		if !input.OmitQuote {
+			date, from, original := extractHeader(orig, "Date"), extractHeader(orig, "From"), extractBody(orig)
+			body, htmlBody = appendQuoted(body, htmlBody, quoteText(date, from, original), quoteHTML(date, from, original))
+		}
+
+		raw := buildRawMessage(to, subject, body, htmlBody, cc, "", orig.ThreadId, messageID, references, attachments...)
+		out, err := deliverMessage(ctx, factory, req, srv, input.UserEmail, raw, orig.ThreadId, input.Draft)
+		if err != nil {
+			return nil, ComposeOutput{}, err
+		}
+		out.To, out.CC, out.Subject = to, cc, subject
+
+		return writeComposeResult(out, "Reply", attachments), out, nil
+	}
+}
+
+// --- forward_gmail_message (extended) ---
+
+type ForwardMessageInput struct {
+	UserEmail          string            `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	MessageID          string            `json:"message_id" jsonschema:"required" jsonschema_description:"The Gmail message ID to forward"`
+	To                 string            `json:"to" jsonschema:"required" jsonschema_description:"Recipient email addresses, comma-separated"`
+	CC                 string            `json:"cc,omitempty" jsonschema_description:"CC email addresses"`
+	BCC                string            `json:"bcc,omitempty" jsonschema_description:"BCC email addresses"`
+	Body               string            `json:"body,omitempty" jsonschema_description:"Note above the forwarded message: plain text, or Markdown with body_format=markdown"`
+	BodyHTML           string            `json:"body_html,omitempty" jsonschema_description:"HTML version of the note; sent as multipart/alternative"`
+	BodyFormat         string            `json:"body_format,omitempty" jsonschema_description:"How to read body: plain text, or Markdown converted to the HTML part,enum=plain,enum=markdown"`
+	ExcludeAttachments bool              `json:"exclude_attachments,omitempty" jsonschema_description:"Do not include the original message's attachments"`
+	Attachments        []AttachmentInput `json:"attachments,omitempty" jsonschema_description:"Additional files to attach, each as base64 content or a Drive file ID"`
+	Draft              bool              `json:"draft,omitempty" jsonschema_description:"Save the forward as a draft instead of sending it"`
+}
+
+func createForwardMessageHandler(factory *services.Factory) mcp.ToolHandlerFor[ForwardMessageInput, ComposeOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ForwardMessageInput) (*mcp.CallToolResult, ComposeOutput, error) {
+		var body, htmlBody string
+		if input.Body != "" || input.BodyHTML != "" {
+			var err error
+			if body, htmlBody, err = composeBody(input.Body, input.BodyHTML, input.BodyFormat); err != nil {
+				return nil, ComposeOutput{}, err
+			}
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ComposeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		orig, err := srv.Users.Messages.Get(input.UserEmail, input.MessageID).Format("full").Context(ctx).Do()
+		if err != nil {
+			return nil, ComposeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		var attachments []mailAttachment
+		if !input.ExcludeAttachments {
+			if attachments, err = originalAttachments(ctx, srv, input.UserEmail, orig); err != nil {
+				return nil, ComposeOutput{}, middleware.HandleGoogleAPIError(err)
+			}
+		}
+		extra, err := resolveAttachments(ctx, factory, input.UserEmail, input.Attachments)
+		if err != nil {
+			return nil, ComposeOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		attachments = append(attachments, extra...)
+		total := 0
+		for _, a := range attachments {
+			total += len(a.Data)
+		}
+		if total > maxAttachmentsSize {
+			return nil, ComposeOutput{}, fmt.Errorf("attachments exceed Gmail's 25 MB limit — share large files as Drive links instead")
+		}
+
+		subject := prefixSubject(extractHeader(orig, "Subject"), "Fwd:", "Fw:")
+		headers, original := forwardedHeaders(orig), extractBody(orig)
+		quotedHTML := strings.ReplaceAll(html.EscapeString(headers+"\n"+original), "\n", "<br>\n")
+		body, htmlBody = appendQuoted(body, htmlBody, headers+"\n"+original, quotedHTML)
+
+		messageID := extractHeader(orig, "Message-ID")
+		references := replyReferences(extractHeader(orig, "References"), extractHeader(orig, "In-Reply-To"), messageID)
+		raw := buildRawMessage(input.To, subject, body, htmlBody, input.CC, input.BCC, orig.ThreadId, messageID, references, attachments...)
+		out, err := deliverMessage(ctx, factory, req, srv, input.UserEmail, raw, orig.ThreadId, input.Draft)
+		if err != nil {
+			return nil, ComposeOutput{}, err
+		}
+		out.To, out.CC, out.Subject = input.To, input.CC, subject
+
+		return writeComposeResult(out, "Forward", attachments), out, nil
+	}
+}
@@ -0,0 +1,102 @@
+package gmail
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+)
+
+func headerMessage(headers map[string]string) *gmail.Message {
+	msg := &gmail.Message{Payload: &gmail.MessagePart{}}
+	for k, v := range headers {
+		msg.Payload.Headers = append(msg.Payload.Headers, &gmail.MessagePartHeader{Name: k, Value: v})
+	}
+	return msg
+}
+
+func TestPrefixSubject(t *testing.T) {
+	tests := []struct {
+		subject, prefix string
+		aliases         []string
+		want            string
+	}{
+		{"Budget", "Re:", nil, "Re: Budget"},
+		{"RE: Budget", "Re:", nil, "RE: Budget"},
+		{"re: Budget", "Re:", nil, "re: Budget"},
+		{"", "Re:", nil, "Re:"},
+		{"Budget", "Fwd:", []string{"Fw:"}, "Fwd: Budget"},
+		{"FW: Budget", "Fwd:", []string{"Fw:"}, "FW: Budget"},
+	}
+	for _, tt := range tests {
+		if got := prefixSubject(tt.subject, tt.prefix, tt.aliases...); got != tt.want {
+			t.Errorf("prefixSubject(%q, %q) = %q, want %q", tt.subject, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestReplyReferences(t *testing.T) {
+	tests := []struct {
+		name                             string
+		references, inReplyTo, messageID string
+		want                             string
+	}{
+		{"first reply", "", "", "<a@x>", "<a@x>"},
+		{"extends chain", "<a@x> <b@x>", "<b@x>", "<c@x>", "<a@x> <b@x> <c@x>"},
+		{"falls back to in-reply-to", "", "<a@x>", "<b@x>", "<a@x> <b@x>"},
+		{"no duplicate", "<a@x>", "", "<a@x>", "<a@x>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replyReferences(tt.references, tt.inReplyTo, tt.messageID); got != tt.want {
+				t.Errorf("replyReferences() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplyRecipients(t *testing.T) {
+	msg := headerMessage(map[string]string{
+		"From": "Alice <alice@example.com>",
+		"To":   "me@example.com, Bob <bob@example.com>",
+		"Cc":   "carol@example.com, ALICE@example.com",
+	})
+	tests := []struct {
+		name   string
+		msg    *gmail.Message
+		all    bool
+		to, cc string
+	}{
+		{"reply", msg, false, `"Alice" <alice@example.com>`, ""},
+		{"reply all", msg, true, `"Alice" <alice@example.com>, "Bob" <bob@example.com>`, "<carol@example.com>"},
+		{"reply-to wins", headerMessage(map[string]string{"From": "alice@example.com", "Reply-To": "list@example.com"}), false, "<list@example.com>", ""},
+		{"own message", headerMessage(map[string]string{"From": "me@example.com", "To": "bob@example.com"}), false, "<bob@example.com>", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to, cc := replyRecipients(tt.msg, "Me@example.com", tt.all)
+			if to != tt.to || cc != tt.cc {
+				t.Errorf("replyRecipients() = %q, %q, want %q, %q", to, cc, tt.to, tt.cc)
+			}
+		})
+	}
+}
+
+func TestQuoteText(t *testing.T) {
+	got := quoteText("Mon, 1 Jun 2026", "Alice", "Hi\r\n> earlier\n")
+	want := "On Mon, 1 Jun 2026, Alice wrote:\n> Hi\n>> earlier"
+	if got != want {
+		t.Errorf("quoteText() = %q, want %q", got, want)
+	}
+	if h := quoteHTML("d", "A <a@x>", "<b>\nline"); !strings.Contains(h, "A &lt;a@x&gt;") || !strings.Contains(h, "&lt;b&gt;<br>\nline") {
+		t.Errorf("quoteHTML() = %q", h)
+	}
+}
+
+func TestForwardedHeaders(t *testing.T) {
+	got := forwardedHeaders(headerMessage(map[string]string{"From": "alice@example.com", "Subject": "Plan"}))
+	want := "---------- Forwarded message ---------\nFrom: alice@example.com\nSubject: Plan\n"
+	if got != want {
+		t.Errorf("forwardedHeaders() = %q, want %q", got, want)
+	}
+}
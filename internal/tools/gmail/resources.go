@@ -0,0 +1,52 @@
+package gmail
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// messageURITemplate addresses one message of one account, so a message can
+// be linked in a conversation and re-read lazily.
+const messageURITemplate = "gmail://{+user}/message/{id}"
+
+// registerResources adds the Gmail message resource template.
+func registerResources(server *mcp.Server, factory *services.Factory, res *middleware.Resources) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "gmail_message",
+		Title:       "Gmail Message",
+		URITemplate: messageURITemplate,
+		Description: "One Gmail message: headers, plain-text body and attachment list. {user} is the account's email address, {id} the message ID from search_gmail_messages.",
+		MIMEType:    "text/plain",
+		Icons:       serviceIcons,
+	}, readMessageResource(factory, res))
+}
+
+// readMessageResource reads a gmail:// message resource as the account the
+// URI names.
+func readMessageResource(factory *services.Factory, res *middleware.Resources) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		email, id, err := res.UserResource(uri, "gmail", "message")
+		if err != nil {
+			return nil, err
+		}
+		srv, err := factory.Gmail(ctx, email)
+		if err != nil {
+			return nil, middleware.HandleGoogleAPIError(err)
+		}
+		msg, err := srv.Users.Messages.Get(email, id).Format("full").Context(ctx).Do()
+		if err != nil {
+			return nil, middleware.ResourceError(err, uri)
+		}
+
+		rb := response.New()
+		rb.Header("Gmail Message")
+		formatMessageDetail(rb, messageToDetail(msg))
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{URI: uri, Text: rb.Build()}}}, nil
+	}
+}
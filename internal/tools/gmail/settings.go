@@ -0,0 +1,427 @@
+package gmail
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// VacationInfo is the vacation responder configuration.
+type VacationInfo struct {
+	Enabled      bool   `json:"enabled"`
+	Subject      string `json:"subject,omitempty"`
+	Body         string `json:"body,omitempty"`
+	HTML         bool   `json:"html,omitempty"`
+	StartTime    string `json:"start_time,omitempty"`
+	EndTime      string `json:"end_time,omitempty"`
+	ContactsOnly bool   `json:"contacts_only,omitempty"`
+	DomainOnly   bool   `json:"domain_only,omitempty"`
+}
+
+func vacationToInfo(v *gmail.VacationSettings) VacationInfo {
+	info := VacationInfo{
+		Enabled:      v.EnableAutoReply,
+		Subject:      v.ResponseSubject,
+		Body:         v.ResponseBodyPlainText,
+		StartTime:    formatMillis(v.StartTime),
+		EndTime:      formatMillis(v.EndTime),
+		ContactsOnly: v.RestrictToContacts,
+		DomainOnly:   v.RestrictToDomain,
+	}
+	if v.ResponseBodyHtml != "" {
+		info.Body = v.ResponseBodyHtml
+		info.HTML = true
+	}
+	return info
+}
+
+// formatMillis renders epoch milliseconds as RFC3339 (UTC), or "" for unset.
+func formatMillis(ms int64) string {
+	if ms == 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
+
+// vacationWindow parses optional RFC3339 start and end times to epoch
+// milliseconds; zero means unbounded.
+func vacationWindow(start, end string) (int64, int64, error) {
+	var startMs, endMs int64
+	for _, bound := range []struct {
+		name, value string
+		ms          *int64
+	}{
+		{"start_time", start, &startMs},
+		{"end_time", end, &endMs},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid %s %q — expected RFC3339 (e.g. 2025-06-02T00:00:00Z): %w", bound.name, bound.value, err)
+		}
+		*bound.ms = t.UnixMilli()
+	}
+	if startMs != 0 && endMs != 0 && endMs <= startMs {
+		return 0, 0, fmt.Errorf("end_time must be after start_time")
+	}
+	return startMs, endMs, nil
+}
+
+func writeVacation(rb *response.Builder, info VacationInfo) {
+	rb.KeyValue("Enabled", info.Enabled)
+	if info.Subject != "" {
+		rb.KeyValue("Subject", info.Subject)
+	}
+	if info.StartTime != "" {
+		rb.KeyValue("Starts", info.StartTime)
+	}
+	if info.EndTime != "" {
+		rb.KeyValue("Ends", info.EndTime)
+	}
+	if info.ContactsOnly {
+		rb.KeyValue("Reply to", "contacts only")
+	} else if info.DomainOnly {
+		rb.KeyValue("Reply to", "organization only")
+	}
+	if info.Body != "" {
+		rb.Blank()
+		rb.Raw(info.Body)
+	}
+}
+
+// --- get_gmail_profile (extended) ---
+
+type GetProfileInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+}
+
+type ProfileOutput struct {
+	EmailAddress  string `json:"email_address"`
+	MessagesTotal int64  `json:"messages_total"`
+	ThreadsTotal  int64  `json:"threads_total"`
+	HistoryID     uint64 `json:"history_id"`
+}
+
+func createGetProfileHandler(factory *services.Factory) mcp.ToolHandlerFor[GetProfileInput, ProfileOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetProfileInput) (*mcp.CallToolResult, ProfileOutput, error) {
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ProfileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		p, err := srv.Users.GetProfile(input.UserEmail).Context(ctx).Do()
+		if err != nil {
+			return nil, ProfileOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := ProfileOutput{
+			EmailAddress:  p.EmailAddress,
+			MessagesTotal: p.MessagesTotal,
+			ThreadsTotal:  p.ThreadsTotal,
+			HistoryID:     p.HistoryId,
+		}
+		rb := response.New()
+		rb.Header("Gmail Profile")
+		rb.KeyValue("Email", out.EmailAddress)
+		rb.KeyValue("Messages", out.MessagesTotal)
+		rb.KeyValue("Threads", out.ThreadsTotal)
+		rb.KeyValue("History ID", out.HistoryID)
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- get_gmail_vacation (extended) ---
+
+type GetVacationInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+}
+
+func createGetVacationHandler(factory *services.Factory) mcp.ToolHandlerFor[GetVacationInput, VacationInfo] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetVacationInput) (*mcp.CallToolResult, VacationInfo, error) {
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, VacationInfo{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		settings, err := srv.Users.Settings.GetVacation(input.UserEmail).Context(ctx).Do()
+		if err != nil {
+			return nil, VacationInfo{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		info := vacationToInfo(settings)
+		rb := response.New()
+		rb.Header("Vacation Responder")
+		writeVacation(rb, info)
+		return rb.TextResult(), info, nil
+	}
+}
+
+// --- set_gmail_vacation (extended) ---
+
+type SetVacationInput struct {
+	UserEmail    string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Enabled      bool   `json:"enabled" jsonschema:"required" jsonschema_description:"Turn the auto-reply on or off"`
+	Subject      string `json:"subject,omitempty" jsonschema_description:"Auto-reply subject (default: keep the current one)"`
+	Body         string `json:"body,omitempty" jsonschema_description:"Auto-reply message (default: keep the current one)"`
+	HTML         bool   `json:"html,omitempty" jsonschema_description:"Treat body as HTML instead of plain text"`
+	StartTime    string `json:"start_time,omitempty" jsonschema_description:"When replies start (RFC3339). Default: immediately"`
+	EndTime      string `json:"end_time,omitempty" jsonschema_description:"When replies stop (RFC3339). Default: until turned off"`
+	ContactsOnly bool   `json:"contacts_only,omitempty" jsonschema_description:"Only reply to senders in the user's contacts"`
+	DomainOnly   bool   `json:"domain_only,omitempty" jsonschema_description:"Only reply to senders in the user's Workspace domain"`
+}
+
+func createSetVacationHandler(factory *services.Factory) mcp.ToolHandlerFor[SetVacationInput, VacationInfo] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SetVacationInput) (*mcp.CallToolResult, VacationInfo, error) {
+		startMs, endMs, err := vacationWindow(input.StartTime, input.EndTime)
+		if err != nil {
+			return nil, VacationInfo{}, err
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, VacationInfo{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		// The update replaces every setting, so start from the current ones to
+		// keep the message when only toggling the responder.
+		settings, err := srv.Users.Settings.GetVacation(input.UserEmail).Context(ctx).Do()
+		if err != nil {
+			return nil, VacationInfo{}, middleware.HandleGoogleAPIError(err)
+		}
+		settings.EnableAutoReply = input.Enabled
+		if input.Subject != "" {
+			settings.ResponseSubject = input.Subject
+		}
+		if input.Body != "" {
+			if input.HTML {
+				settings.ResponseBodyHtml, settings.ResponseBodyPlainText = input.Body, ""
+			} else {
+				settings.ResponseBodyPlainText, settings.ResponseBodyHtml = input.Body, ""
+			}
+		}
+		settings.StartTime, settings.EndTime = startMs, endMs
+		settings.RestrictToContacts = input.ContactsOnly
+		settings.RestrictToDomain = input.DomainOnly
+		settings.ForceSendFields = []string{"EnableAutoReply", "StartTime", "EndTime", "RestrictToContacts", "RestrictToDomain"}
+
+		updated, err := srv.Users.Settings.UpdateVacation(input.UserEmail, settings).Context(ctx).Do()
+		if err != nil {
+			return nil, VacationInfo{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		info := vacationToInfo(updated)
+		rb := response.New()
+		rb.Header("Vacation Responder Updated")
+		writeVacation(rb, info)
+		return rb.TextResult(), info, nil
+	}
+}
+
+// --- list_gmail_forwarding (extended) ---
+
+type ListForwardingInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+}
+
+// ForwardingInfo is a forwarding address and its verification status.
+type ForwardingInfo struct {
+	Email  string `json:"email"`
+	Status string `json:"status"`
+}
+
+type ListForwardingOutput struct {
+	Addresses      []ForwardingInfo `json:"addresses"`
+	AutoForwarding bool             `json:"auto_forwarding"`
+	ForwardTo      string           `json:"forward_to,omitempty"`
+	Disposition    string           `json:"disposition,omitempty"`
+}
+
+func createListForwardingHandler(factory *services.Factory) mcp.ToolHandlerFor[ListForwardingInput, ListForwardingOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListForwardingInput) (*mcp.CallToolResult, ListForwardingOutput, error) {
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListForwardingOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		addresses, err := srv.Users.Settings.ForwardingAddresses.List(input.UserEmail).Context(ctx).Do()
+		if err != nil {
+			return nil, ListForwardingOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		auto, err := srv.Users.Settings.GetAutoForwarding(input.UserEmail).Context(ctx).Do()
+		if err != nil {
+			return nil, ListForwardingOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := ListForwardingOutput{
+			Addresses:      make([]ForwardingInfo, 0, len(addresses.ForwardingAddresses)),
+			AutoForwarding: auto.Enabled,
+		}
+		rb := response.New()
+		rb.Header("Gmail Forwarding")
+		rb.KeyValue("Auto-forwarding", auto.Enabled)
+		if auto.Enabled {
+			out.ForwardTo, out.Disposition = auto.EmailAddress, auto.Disposition
+			rb.KeyValue("Forward to", auto.EmailAddress)
+			rb.KeyValue("Original message", auto.Disposition)
+		}
+		rb.KeyValue("Addresses", len(addresses.ForwardingAddresses))
+		rb.Blank()
+		for _, a := range addresses.ForwardingAddresses {
+			out.Addresses = append(out.Addresses, ForwardingInfo{Email: a.ForwardingEmail, Status: a.VerificationStatus})
+			rb.Item("%s [%s]", a.ForwardingEmail, a.VerificationStatus)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- manage_gmail_forwarding (complete) ---
+
+type ManageForwardingInput struct {
+	UserEmail   string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Action      string `json:"action" jsonschema:"required" jsonschema_description:"Action: add or remove a forwarding address; enable or disable auto-forwarding,enum=add,enum=remove,enum=enable,enum=disable"`
+	Email       string `json:"email,omitempty" jsonschema_description:"Forwarding address (required for add/remove/enable)"`
+	Disposition string `json:"disposition,omitempty" jsonschema_description:"What happens to the original after forwarding (enable only; default leaveInInbox),enum=leaveInInbox,enum=archive,enum=trash,enum=markRead"`
+}
+
+func createManageForwardingHandler(factory *services.Factory) mcp.ToolHandlerFor[ManageForwardingInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ManageForwardingInput) (*mcp.CallToolResult, any, error) {
+		if input.Action != "disable" && input.Email == "" {
+			return nil, nil, fmt.Errorf("email is required for %s", input.Action)
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+
+		switch input.Action {
+		case "add":
+			addr, err := srv.Users.Settings.ForwardingAddresses.Create(input.UserEmail, &gmail.ForwardingAddress{
+				ForwardingEmail: input.Email,
+			}).Context(ctx).Do()
+			if err != nil {
+				return nil, nil, middleware.HandleGoogleAPIError(err)
+			}
+			rb.Header("Forwarding Address Added")
+			rb.KeyValue("Email", addr.ForwardingEmail)
+			rb.KeyValue("Status", addr.VerificationStatus)
+			if addr.VerificationStatus == "pending" {
+				rb.Line("A verification email was sent; forwarding can be enabled once the recipient confirms it.")
+			}
+
+		case "remove":
+			err := srv.Users.Settings.ForwardingAddresses.Delete(input.UserEmail, input.Email).Context(ctx).Do()
+			if err != nil {
+				return nil, nil, middleware.HandleGoogleAPIError(err)
+			}
+			rb.Header("Forwarding Address Removed")
+			rb.KeyValue("Email", input.Email)
+
+		case "enable":
+			if input.Disposition == "" {
+				input.Disposition = "leaveInInbox"
+			}
+			auto, err := srv.Users.Settings.UpdateAutoForwarding(input.UserEmail, &gmail.AutoForwarding{
+				Enabled:      true,
+				EmailAddress: input.Email,
+				Disposition:  input.Disposition,
+			}).Context(ctx).Do()
+			if err != nil {
+				return nil, nil, middleware.HandleGoogleAPIError(err)
+			}
+			rb.Header("Auto-Forwarding Enabled")
+			rb.KeyValue("Forward to", auto.EmailAddress)
+			rb.KeyValue("Original message", auto.Disposition)
+
+		case "disable":
+			_, err := srv.Users.Settings.UpdateAutoForwarding(input.UserEmail, &gmail.AutoForwarding{
+				ForceSendFields: []string{"Enabled"},
+			}).Context(ctx).Do()
+			if err != nil {
+				return nil, nil, middleware.HandleGoogleAPIError(err)
+			}
+			rb.Header("Auto-Forwarding Disabled")
+
+		default:
+			return nil, nil, fmt.Errorf("invalid action %q — use add, remove, enable, or disable", input.Action)
+		}
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// --- get_gmail_imap_pop_settings (extended) ---
+
+type GetMailAccessInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+}
+
+type GetMailAccessOutput struct {
+	IMAPEnabled         bool   `json:"imap_enabled"`
+	IMAPAutoExpunge     bool   `json:"imap_auto_expunge"`
+	IMAPExpungeBehavior string `json:"imap_expunge_behavior,omitempty"`
+	IMAPMaxFolderSize   int64  `json:"imap_max_folder_size,omitempty"`
+	POPAccessWindow     string `json:"pop_access_window"`
+	POPDisposition      string `json:"pop_disposition,omitempty"`
+}
+
+func createGetMailAccessHandler(factory *services.Factory) mcp.ToolHandlerFor[GetMailAccessInput, GetMailAccessOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetMailAccessInput) (*mcp.CallToolResult, GetMailAccessOutput, error) {
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, GetMailAccessOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		imap, err := srv.Users.Settings.GetImap(input.UserEmail).Context(ctx).Do()
+		if err != nil {
+			return nil, GetMailAccessOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		pop, err := srv.Users.Settings.GetPop(input.UserEmail).Context(ctx).Do()
+		if err != nil {
+			return nil, GetMailAccessOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := GetMailAccessOutput{
+			IMAPEnabled:         imap.Enabled,
+			IMAPAutoExpunge:     imap.AutoExpunge,
+			IMAPExpungeBehavior: imap.ExpungeBehavior,
+			IMAPMaxFolderSize:   imap.MaxFolderSize,
+			POPAccessWindow:     pop.AccessWindow,
+			POPDisposition:      pop.Disposition,
+		}
+
+		rb := response.New()
+		rb.Header("IMAP and POP Settings")
+		rb.Section("IMAP")
+		rb.KeyValue("Enabled", imap.Enabled)
+		if imap.Enabled {
+			rb.KeyValue("Auto-expunge", imap.AutoExpunge)
+			rb.KeyValue("Expunge behavior", imap.ExpungeBehavior)
+			if imap.MaxFolderSize > 0 {
+				rb.KeyValue("Max folder size", fmt.Sprintf("%d messages", imap.MaxFolderSize))
+			} else {
+				rb.KeyValue("Max folder size", "unlimited")
+			}
+		}
+		rb.Section("POP")
+		rb.KeyValue("Access", pop.AccessWindow)
+		if pop.AccessWindow != "disabled" {
+			rb.KeyValue("After download", pop.Disposition)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
@@ -0,0 +1,351 @@
+package gmail
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/sandbox"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+func TestVacationWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		wantStart int64
+		wantEnd   int64
+		wantErr   bool
+	}{
+		{"unbounded", "", "", 0, 0, false},
+		{"start only", "2026-07-01T00:00:00Z", "", 1782864000000, 0, false},
+		{"both", "2026-07-01T00:00:00Z", "2026-07-15T00:00:00+02:00", 1782864000000, 1784066400000, false},
+		{"end before start", "2026-07-15T00:00:00Z", "2026-07-01T00:00:00Z", 0, 0, true},
+		{"date only", "2026-07-01", "", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := vacationWindow(tt.start, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("vacationWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("vacationWindow() = %d, %d, want %d, %d", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestVacationToInfo(t *testing.T) {
+	info := vacationToInfo(&gmail.VacationSettings{
+		EnableAutoReply:       true,
+		ResponseBodyPlainText: "plain",
+		ResponseBodyHtml:      "<p>html</p>",
+		StartTime:             1782864000000,
+	})
+	if !info.HTML || info.Body != "<p>html</p>" {
+		t.Errorf("HTML body not preferred: %+v", info)
+	}
+	if info.StartTime != "2026-07-01T00:00:00Z" || info.EndTime != "" {
+		t.Errorf("times = %q, %q", info.StartTime, info.EndTime)
+	}
+}
+
+func TestGetProfile(t *testing.T) {
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(sandbox.NewTransport())
+
+	_, out, err := createGetProfileHandler(factory)(context.Background(), &mcp.CallToolRequest{}, GetProfileInput{UserEmail: sandbox.DemoUser})
+	if err != nil {
+		t.Fatalf("get_gmail_profile error = %v", err)
+	}
+	if out.EmailAddress != sandbox.DemoUser || out.MessagesTotal == 0 || out.HistoryID != 1000 {
+		t.Errorf("profile = %+v, want the sandbox mailbox", out)
+	}
+}
+
+// fakeAPI answers Gmail requests in-process from a ServeMux, so the
+// handlers run against canned API responses.
+type fakeAPI struct{ *http.ServeMux }
+
+func (f fakeAPI) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Body == nil {
+		r.Body = http.NoBody // server handlers may read it
+	}
+	rec := httptest.NewRecorder()
+	f.ServeHTTP(rec, r)
+	resp := rec.Result()
+	resp.Request = r
+	return resp, nil
+}
+
+func fakeFactory(mux *http.ServeMux) *services.Factory {
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(fakeAPI{mux})
+	return factory
+}
+
+func replyJSON(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	}
+}
+
+func replyError(code int, message string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		fmt.Fprintf(w, `{"error":{"code":%d,"message":%q}}`, code, message)
+	}
+}
+
+const settingsUser = "user@example.com"
+
+const settingsPath = "/gmail/v1/users/user@example.com/settings"
+
+func TestGetVacationHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+settingsPath+"/vacation", replyJSON(`{"enableAutoReply":true,"responseSubject":"Away","responseBodyPlainText":"Back Monday","restrictToDomain":true,"endTime":"1784066400000"}`))
+
+	res, out, err := createGetVacationHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, GetVacationInput{UserEmail: settingsUser})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if !out.Enabled || out.Subject != "Away" || !out.DomainOnly || out.EndTime != "2026-07-14T22:00:00Z" {
+		t.Errorf("output = %+v", out)
+	}
+	text := resultText(res)
+	for _, want := range []string{"Enabled: true", "Ends: 2026-07-14T22:00:00Z", "Reply to: organization only", "Back Monday"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestSetVacationHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    SetVacationInput
+		want     []string
+		wantText string
+		wantErr  string
+	}{
+		{
+			name:     "toggle keeps message",
+			input:    SetVacationInput{Enabled: true},
+			want:     []string{`"enableAutoReply":true`, `"responseSubject":"Old subject"`, `"responseBodyPlainText":"Old body"`, `"startTime":"0"`, `"restrictToContacts":false`},
+			wantText: "Subject: Old subject",
+		},
+		{
+			name:     "html body replaces plain text",
+			input:    SetVacationInput{Enabled: true, Subject: "OOO", Body: "<p>Away</p>", HTML: true, ContactsOnly: true, StartTime: "2026-07-01T00:00:00Z"},
+			want:     []string{`"responseSubject":"OOO"`, `"responseBodyHtml":"\u003cp\u003eAway\u003c/p\u003e"`, `"restrictToContacts":true`, `"startTime":"1782864000000"`},
+			wantText: "Reply to: contacts only",
+		},
+		{
+			name:    "disable with bad window",
+			input:   SetVacationInput{StartTime: "2026-07-15T00:00:00Z", EndTime: "2026-07-01T00:00:00Z"},
+			wantErr: "end_time must be after start_time",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET "+settingsPath+"/vacation", replyJSON(`{"enableAutoReply":false,"responseSubject":"Old subject","responseBodyPlainText":"Old body"}`))
+			mux.HandleFunc("PUT "+settingsPath+"/vacation", func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+				replyJSON(body)(w, r)
+			})
+
+			tt.input.UserEmail = settingsUser
+			res, out, err := createSetVacationHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want %q", err, tt.wantErr)
+				}
+				if body != "" {
+					t.Errorf("invalid input reached the API: %s", body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("request body %s missing %s", body, want)
+				}
+			}
+			if tt.input.HTML && (!out.HTML || strings.Contains(body, "Old body")) {
+				t.Errorf("output = %+v, body = %s, want the HTML body only", out, body)
+			}
+			if !strings.Contains(resultText(res), tt.wantText) {
+				t.Errorf("text = %q, want %q", resultText(res), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestListForwardingHandler(t *testing.T) {
+	for _, auto := range []bool{false, true} {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET "+settingsPath+"/forwardingAddresses", replyJSON(`{"forwardingAddresses":[{"forwardingEmail":"me@backup.example","verificationStatus":"accepted"}]}`))
+		mux.HandleFunc("GET "+settingsPath+"/autoForwarding", func(w http.ResponseWriter, r *http.Request) {
+			if auto {
+				replyJSON(`{"enabled":true,"emailAddress":"me@backup.example","disposition":"archive"}`)(w, r)
+				return
+			}
+			replyJSON(`{}`)(w, r)
+		})
+
+		res, out, err := createListForwardingHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, ListForwardingInput{UserEmail: settingsUser})
+		if err != nil {
+			t.Fatalf("auto=%v: handler error = %v", auto, err)
+		}
+		if len(out.Addresses) != 1 || out.Addresses[0].Status != "accepted" || out.AutoForwarding != auto {
+			t.Errorf("auto=%v: output = %+v", auto, out)
+		}
+		text := resultText(res)
+		if !strings.Contains(text, "me@backup.example [accepted]") {
+			t.Errorf("auto=%v: text = %q", auto, text)
+		}
+		if got := strings.Contains(text, "Original message: archive"); got != auto || (out.Disposition == "archive") != auto {
+			t.Errorf("auto=%v: disposition shown = %v, output = %+v", auto, got, out)
+		}
+	}
+}
+
+func TestManageForwardingHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    ManageForwardingInput
+		wantCall string
+		wantBody string
+		wantText string
+		wantErr  string
+	}{
+		{name: "add", input: ManageForwardingInput{Action: "add", Email: "me@backup.example"}, wantCall: "POST /forwardingAddresses", wantBody: `"forwardingEmail":"me@backup.example"`, wantText: "verification email was sent"},
+		{name: "remove", input: ManageForwardingInput{Action: "remove", Email: "me@backup.example"}, wantCall: "DELETE /forwardingAddresses/me@backup.example", wantText: "Forwarding Address Removed"},
+		{name: "enable defaults to leave in inbox", input: ManageForwardingInput{Action: "enable", Email: "me@backup.example"}, wantCall: "PUT /autoForwarding", wantBody: `"disposition":"leaveInInbox"`, wantText: "Auto-Forwarding Enabled"},
+		{name: "disable", input: ManageForwardingInput{Action: "disable"}, wantCall: "PUT /autoForwarding", wantBody: `{"enabled":false}`, wantText: "Auto-Forwarding Disabled"},
+		{name: "missing email", input: ManageForwardingInput{Action: "add"}, wantErr: "email is required for add"},
+		{name: "unknown action", input: ManageForwardingInput{Action: "pause", Email: "me@backup.example"}, wantErr: `invalid action "pause"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var call, body string
+			mux := http.NewServeMux()
+			mux.HandleFunc(settingsPath+"/", func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				call, body = r.Method+" "+strings.TrimPrefix(r.URL.Path, settingsPath), string(data)
+				if r.Method == http.MethodPost {
+					replyJSON(`{"forwardingEmail":"me@backup.example","verificationStatus":"pending"}`)(w, r)
+					return
+				}
+				replyJSON(`{"enabled":true,"emailAddress":"me@backup.example","disposition":"leaveInInbox"}`)(w, r)
+			})
+
+			tt.input.UserEmail = settingsUser
+			res, _, err := createManageForwardingHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want %q", err, tt.wantErr)
+				}
+				if call != "" {
+					t.Errorf("invalid input reached the API: %s", call)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+			if call != tt.wantCall || !strings.Contains(body, tt.wantBody) {
+				t.Errorf("request = %s %s, want %s with %s", call, body, tt.wantCall, tt.wantBody)
+			}
+			if !strings.Contains(resultText(res), tt.wantText) {
+				t.Errorf("text = %q, want %q", resultText(res), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestGetMailAccessHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+settingsPath+"/imap", replyJSON(`{"enabled":true,"autoExpunge":true,"expungeBehavior":"archive"}`))
+	mux.HandleFunc("GET "+settingsPath+"/pop", replyJSON(`{"accessWindow":"disabled","disposition":"leaveInInbox"}`))
+
+	res, out, err := createGetMailAccessHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, GetMailAccessInput{UserEmail: settingsUser})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if !out.IMAPEnabled || out.IMAPExpungeBehavior != "archive" || out.POPAccessWindow != "disabled" {
+		t.Errorf("output = %+v", out)
+	}
+	text := resultText(res)
+	if !strings.Contains(text, "Max folder size: unlimited") || strings.Contains(text, "After download") {
+		t.Errorf("text = %q, want unlimited IMAP folders and no POP disposition while POP is off", text)
+	}
+}
+
+func TestSettingsHandlersAPIErrors(t *testing.T) {
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	handlers := map[string]func(*services.Factory) error{
+		"get_gmail_profile": func(f *services.Factory) error {
+			_, _, err := createGetProfileHandler(f)(ctx, req, GetProfileInput{UserEmail: settingsUser})
+			return err
+		},
+		"get_gmail_vacation": func(f *services.Factory) error {
+			_, _, err := createGetVacationHandler(f)(ctx, req, GetVacationInput{UserEmail: settingsUser})
+			return err
+		},
+		"set_gmail_vacation": func(f *services.Factory) error {
+			_, _, err := createSetVacationHandler(f)(ctx, req, SetVacationInput{UserEmail: settingsUser})
+			return err
+		},
+		"list_gmail_forwarding": func(f *services.Factory) error {
+			_, _, err := createListForwardingHandler(f)(ctx, req, ListForwardingInput{UserEmail: settingsUser})
+			return err
+		},
+		"manage_gmail_forwarding": func(f *services.Factory) error {
+			_, _, err := createManageForwardingHandler(f)(ctx, req, ManageForwardingInput{UserEmail: settingsUser, Action: "disable"})
+			return err
+		},
+		"get_gmail_imap_pop_settings": func(f *services.Factory) error {
+			_, _, err := createGetMailAccessHandler(f)(ctx, req, GetMailAccessInput{UserEmail: settingsUser})
+			return err
+		},
+	}
+	statuses := []struct {
+		code int
+		want string
+	}{
+		{http.StatusForbidden, "permission denied"},
+		{http.StatusTooManyRequests, "rate limit exceeded"},
+	}
+	for _, st := range statuses {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", replyError(st.code, "Request had insufficient authentication scopes."))
+		factory := fakeFactory(mux)
+		factory.SetRetryPolicies(services.RetryPolicy{}, nil)
+		for name, call := range handlers {
+			if err := call(factory); err == nil || !strings.Contains(err.Error(), st.want) {
+				t.Errorf("%s on %d: error = %v, want %q", name, st.code, err, st.want)
+			}
+		}
+	}
+}
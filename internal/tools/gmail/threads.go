@@ -0,0 +1,167 @@
+package gmail
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// ThreadLabelsOutput is the state of a thread after a thread-level change.
+type ThreadLabelsOutput struct {
+	ThreadID string   `json:"thread_id"`
+	Messages int      `json:"messages"`
+	LabelIDs []string `json:"label_ids,omitempty"`
+}
+
+// threadLabels returns the union of the labels on a thread's messages, in
+// first-seen order.
+func threadLabels(thread *gmail.Thread) []string {
+	var labels []string
+	for _, m := range thread.Messages {
+		for _, l := range m.LabelIds {
+			if !slices.Contains(labels, l) {
+				labels = append(labels, l)
+			}
+		}
+	}
+	return labels
+}
+
+func threadLabelsOutput(thread *gmail.Thread) ThreadLabelsOutput {
+	return ThreadLabelsOutput{ThreadID: thread.Id, Messages: len(thread.Messages), LabelIDs: threadLabels(thread)}
+}
+
+// writeThreadLabels writes the thread state shared by the thread tools.
+func writeThreadLabels(rb *response.Builder, out ThreadLabelsOutput) {
+	rb.KeyValue("Thread ID", out.ThreadID)
+	rb.KeyValue("Messages", out.Messages)
+	if len(out.LabelIDs) > 0 {
+		rb.KeyValue("Labels", out.LabelIDs)
+	}
+}
+
+// --- modify_gmail_thread_labels (extended) ---
+
+type ModifyThreadLabelsInput struct {
+	UserEmail    string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	ThreadID     string   `json:"thread_id" jsonschema:"required" jsonschema_description:"The Gmail thread ID"`
+	AddLabels    []string `json:"add_label_ids,omitempty" jsonschema_description:"Label IDs to add to every message in the thread"`
+	RemoveLabels []string `json:"remove_label_ids,omitempty" jsonschema_description:"Label IDs to remove from every message in the thread"`
+}
+
+func createModifyThreadLabelsHandler(factory *services.Factory) mcp.ToolHandlerFor[ModifyThreadLabelsInput, ThreadLabelsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ModifyThreadLabelsInput) (*mcp.CallToolResult, ThreadLabelsOutput, error) {
+		if len(input.AddLabels) == 0 && len(input.RemoveLabels) == 0 {
+			return nil, ThreadLabelsOutput{}, fmt.Errorf("specify at least one label to add or remove")
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ThreadLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		thread, err := srv.Users.Threads.Modify(input.UserEmail, input.ThreadID, &gmail.ModifyThreadRequest{
+			AddLabelIds:    input.AddLabels,
+			RemoveLabelIds: input.RemoveLabels,
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, ThreadLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := threadLabelsOutput(thread)
+		rb := response.New()
+		rb.Header("Thread Labels Modified")
+		if len(input.AddLabels) > 0 {
+			rb.KeyValue("Added", input.AddLabels)
+		}
+		if len(input.RemoveLabels) > 0 {
+			rb.KeyValue("Removed", input.RemoveLabels)
+		}
+		writeThreadLabels(rb, out)
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- archive_gmail_thread (extended) ---
+
+type ArchiveThreadInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	ThreadID  string `json:"thread_id" jsonschema:"required" jsonschema_description:"The Gmail thread ID"`
+	MarkRead  bool   `json:"mark_read,omitempty" jsonschema_description:"Also mark every message in the thread as read"`
+}
+
+func createArchiveThreadHandler(factory *services.Factory) mcp.ToolHandlerFor[ArchiveThreadInput, ThreadLabelsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ArchiveThreadInput) (*mcp.CallToolResult, ThreadLabelsOutput, error) {
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ThreadLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		remove := []string{"INBOX"}
+		if input.MarkRead {
+			remove = append(remove, "UNREAD")
+		}
+		thread, err := srv.Users.Threads.Modify(input.UserEmail, input.ThreadID, &gmail.ModifyThreadRequest{
+			RemoveLabelIds: remove,
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, ThreadLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := threadLabelsOutput(thread)
+		rb := response.New()
+		rb.Header("Thread Archived")
+		writeThreadLabels(rb, out)
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- trash_gmail_thread (extended) ---
+
+type TrashThreadInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	ThreadID  string `json:"thread_id" jsonschema:"required" jsonschema_description:"The Gmail thread ID"`
+	Untrash   bool   `json:"untrash,omitempty" jsonschema_description:"Restore the thread from Trash instead"`
+}
+
+func createTrashThreadHandler(factory *services.Factory) mcp.ToolHandlerFor[TrashThreadInput, ThreadLabelsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input TrashThreadInput) (*mcp.CallToolResult, ThreadLabelsOutput, error) {
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ThreadLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		var thread *gmail.Thread
+		if input.Untrash {
+			thread, err = srv.Users.Threads.Untrash(input.UserEmail, input.ThreadID).Context(ctx).Do()
+		} else {
+			thread, err = srv.Users.Threads.Trash(input.UserEmail, input.ThreadID).Context(ctx).Do()
+		}
+		if err != nil {
+			return nil, ThreadLabelsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := threadLabelsOutput(thread)
+		rb := response.New()
+		if input.Untrash {
+			rb.Header("Thread Restored from Trash")
+		} else {
+			rb.Header("Thread Moved to Trash")
+		}
+		writeThreadLabels(rb, out)
+		if !input.Untrash {
+			rb.Line("Gmail deletes it permanently after 30 days; set untrash=true to restore it.")
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
@@ -0,0 +1,29 @@
+package gmail
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/gmail/v1"
+)
+
+func TestThreadLabels(t *testing.T) {
+	thread := &gmail.Thread{Messages: []*gmail.Message{
+		{LabelIds: []string{"INBOX", "UNREAD"}},
+		{LabelIds: []string{"INBOX", "IMPORTANT"}},
+		{},
+	}}
+	want := []string{"INBOX", "UNREAD", "IMPORTANT"}
+	if got := threadLabels(thread); !slices.Equal(got, want) {
+		t.Errorf("threadLabels() = %v, want %v", got, want)
+	}
+}
+
+func TestModifyThreadLabelsRequiresLabels(t *testing.T) {
+	_, _, err := createModifyThreadLabelsHandler(nil)(context.Background(), &mcp.CallToolRequest{}, ModifyThreadLabelsInput{ThreadID: "t1"})
+	if err == nil {
+		t.Error("expected an error when no labels are given")
+	}
+}
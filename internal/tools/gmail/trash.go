@@ -0,0 +1,201 @@
+package gmail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/progress"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// maxBatchMessages is the Gmail batchDelete and batchModify limit on message
+// IDs per call.
+const maxBatchMessages = 1000
+
+// errPermanentDeleteDisabled explains why permanent deletion is unavailable.
+var errPermanentDeleteDisabled = errors.New("permanent deletion is disabled — it needs the full https://mail.google.com/ scope, which the server requests only with WORKSPACE_MCP_GMAIL_PERMANENT_DELETE=true; use trash_gmail_message instead (Gmail empties Trash after 30 days)")
+
+// --- trash_gmail_message (core) ---
+
+type TrashMessageInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	MessageID string `json:"message_id" jsonschema:"required" jsonschema_description:"The Gmail message ID"`
+}
+
+func createTrashMessageHandler(factory *services.Factory) mcp.ToolHandlerFor[TrashMessageInput, MessageSummary] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input TrashMessageInput) (*mcp.CallToolResult, MessageSummary, error) {
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, MessageSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		msg, err := srv.Users.Messages.Trash(input.UserEmail, input.MessageID).Context(ctx).Do()
+		if err != nil {
+			return nil, MessageSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Message Moved to Trash")
+		rb.KeyValue("Message ID", msg.Id)
+		rb.KeyValue("Thread ID", msg.ThreadId)
+		rb.Line("Gmail deletes it permanently after 30 days; untrash_gmail_message restores it.")
+
+		return rb.TextResult(), messageToSummary(msg), nil
+	}
+}
+
+// --- untrash_gmail_message (core) ---
+
+func createUntrashMessageHandler(factory *services.Factory) mcp.ToolHandlerFor[TrashMessageInput, MessageSummary] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input TrashMessageInput) (*mcp.CallToolResult, MessageSummary, error) {
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, MessageSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		msg, err := srv.Users.Messages.Untrash(input.UserEmail, input.MessageID).Context(ctx).Do()
+		if err != nil {
+			return nil, MessageSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Message Restored from Trash")
+		rb.KeyValue("Message ID", msg.Id)
+		rb.KeyValue("Thread ID", msg.ThreadId)
+		rb.KeyValue("Labels", msg.LabelIds)
+
+		return rb.TextResult(), messageToSummary(msg), nil
+	}
+}
+
+// --- delete_gmail_message_permanently (extended) ---
+
+func createDeleteMessageHandler(factory *services.Factory, enabled bool) mcp.ToolHandlerFor[TrashMessageInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input TrashMessageInput) (*mcp.CallToolResult, any, error) {
+		if !enabled {
+			return nil, nil, errPermanentDeleteDisabled
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		if err := srv.Users.Messages.Delete(input.UserEmail, input.MessageID).Context(ctx).Do(); err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Message Deleted Permanently")
+		rb.KeyValue("Message ID", input.MessageID)
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// --- batch_trash_gmail_messages (extended) ---
+
+type BatchTrashInput struct {
+	UserEmail  string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	MessageIDs []string `json:"message_ids" jsonschema:"required" jsonschema_description:"Message IDs to discard (max 1000)"`
+	Untrash    bool     `json:"untrash,omitempty" jsonschema_description:"Restore the messages from Trash instead"`
+	Permanent  bool     `json:"permanent,omitempty" jsonschema_description:"Delete permanently, skipping Trash (cannot be undone; requires WORKSPACE_MCP_GMAIL_PERMANENT_DELETE)"`
+}
+
+type BatchTrashOutput struct {
+	Succeeded []string          `json:"succeeded"`
+	Failed    map[string]string `json:"failed,omitempty"`
+	Partial   bool              `json:"partial,omitempty"`
+}
+
+func createBatchTrashHandler(factory *services.Factory, permanentEnabled bool) mcp.ToolHandlerFor[BatchTrashInput, BatchTrashOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input BatchTrashInput) (*mcp.CallToolResult, BatchTrashOutput, error) {
+		switch {
+		case len(input.MessageIDs) == 0:
+			return nil, BatchTrashOutput{}, fmt.Errorf("message_ids must not be empty")
+		case len(input.MessageIDs) > maxBatchMessages:
+			return nil, BatchTrashOutput{}, fmt.Errorf("maximum %d messages per call, got %d - split into multiple calls", maxBatchMessages, len(input.MessageIDs))
+		case input.Permanent && input.Untrash:
+			return nil, BatchTrashOutput{}, fmt.Errorf("set permanent or untrash, not both")
+		case input.Permanent && !permanentEnabled:
+			return nil, BatchTrashOutput{}, errPermanentDeleteDisabled
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, BatchTrashOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		if input.Permanent {
+			// batchDelete is all-or-nothing in one request.
+			err := srv.Users.Messages.BatchDelete(input.UserEmail, &gmail.BatchDeleteMessagesRequest{Ids: input.MessageIDs}).Context(ctx).Do()
+			if err != nil {
+				return nil, BatchTrashOutput{}, middleware.HandleGoogleAPIError(err)
+			}
+			rb.Header("Messages Deleted Permanently")
+			rb.KeyValue("Deleted", len(input.MessageIDs))
+			return rb.TextResult(), BatchTrashOutput{Succeeded: input.MessageIDs}, nil
+		}
+
+		verb := "Trashing"
+		apply := func(id string) error {
+			_, err := srv.Users.Messages.Trash(input.UserEmail, id).Context(ctx).Do()
+			return err
+		}
+		if input.Untrash {
+			verb = "Restoring"
+			apply = func(id string) error {
+				_, err := srv.Users.Messages.Untrash(input.UserEmail, id).Context(ctx).Do()
+				return err
+			}
+		}
+		out := BatchTrashOutput{Succeeded: make([]string, 0, len(input.MessageIDs))}
+		p := progress.New(ctx, req).Begin(verb+" message", len(input.MessageIDs))
+		for _, id := range input.MessageIDs {
+			if p.Next() != nil {
+				break
+			}
+			if err := apply(id); err != nil {
+				if p.Cancelled() {
+					break
+				}
+				if out.Failed == nil {
+					out.Failed = map[string]string{}
+				}
+				out.Failed[id] = middleware.HandleGoogleAPIError(err).Error()
+				continue
+			}
+			out.Succeeded = append(out.Succeeded, id)
+		}
+		p.Done()
+		out.Partial = p.Cancelled()
+
+		if input.Untrash {
+			rb.Header("Messages Restored from Trash")
+			rb.KeyValue("Restored", len(out.Succeeded))
+		} else {
+			rb.Header("Messages Moved to Trash")
+			rb.KeyValue("Trashed", len(out.Succeeded))
+		}
+		if len(out.Failed) > 0 {
+			rb.KeyValue("Failed", len(out.Failed))
+			for _, id := range input.MessageIDs {
+				if msg, ok := out.Failed[id]; ok {
+					rb.Item("%s: %s", id, msg)
+				}
+			}
+		}
+		if out.Partial {
+			rb.KeyValue("Partial", p.Partial())
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
@@ -0,0 +1,39 @@
+package gmail
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBatchTrashValidation(t *testing.T) {
+	tooMany := make([]string, maxBatchMessages+1)
+	tests := []struct {
+		name    string
+		input   BatchTrashInput
+		enabled bool
+		errMsg  string
+	}{
+		{"empty", BatchTrashInput{}, true, "must not be empty"},
+		{"too many", BatchTrashInput{MessageIDs: tooMany}, true, "maximum 1000"},
+		{"permanent and untrash", BatchTrashInput{MessageIDs: []string{"a"}, Permanent: true, Untrash: true}, true, "not both"},
+		{"permanent disabled", BatchTrashInput{MessageIDs: []string{"a"}, Permanent: true}, false, "WORKSPACE_MCP_GMAIL_PERMANENT_DELETE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := createBatchTrashHandler(nil, tt.enabled)
+			_, _, err := handler(context.Background(), nil, tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("error = %v, want containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestDeleteMessageDisabled(t *testing.T) {
+	handler := createDeleteMessageHandler(nil, false)
+	if _, _, err := handler(context.Background(), nil, TrashMessageInput{MessageID: "a"}); !errors.Is(err, errPermanentDeleteDisabled) {
+		t.Errorf("error = %v, want errPermanentDeleteDisabled", err)
+	}
+}
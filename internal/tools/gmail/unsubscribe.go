@@ -0,0 +1,243 @@
+package gmail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+)
+
+// unsubscribeTimeout bounds a single one-click unsubscribe request.
+const unsubscribeTimeout = 15 * time.Second
+
+// unsubscribeMethod is how a mailing list can be left.
+type unsubscribeMethod string
+
+const (
+	unsubscribeOneClick unsubscribeMethod = "one-click" // RFC 8058 HTTPS POST
+	unsubscribeMailto   unsubscribeMethod = "mailto"    // email to the list's unsubscribe address
+	unsubscribeManual   unsubscribeMethod = "manual"    // web page the user must visit
+	unsubscribeNone     unsubscribeMethod = "none"
+)
+
+// unsubscribeTarget is the parsed List-Unsubscribe information of one message.
+type unsubscribeTarget struct {
+	HTTPS    string // first https:// URL, if any
+	Mailto   string // first mailto: URI, if any
+	OneClick bool   // List-Unsubscribe-Post: List-Unsubscribe=One-Click
+}
+
+// parseListUnsubscribe parses List-Unsubscribe (RFC 2369) and
+// List-Unsubscribe-Post (RFC 8058) header values.
+func parseListUnsubscribe(header, post string) unsubscribeTarget {
+	var t unsubscribeTarget
+	for _, entry := range strings.Split(header, ",") {
+		entry = strings.TrimSpace(entry)
+		if !strings.HasPrefix(entry, "<") || !strings.HasSuffix(entry, ">") {
+			continue
+		}
+		uri := strings.TrimSpace(entry[1 : len(entry)-1])
+		lower := strings.ToLower(uri)
+		switch {
+		case strings.HasPrefix(lower, "https://") && t.HTTPS == "":
+			t.HTTPS = uri
+		case strings.HasPrefix(lower, "mailto:") && t.Mailto == "":
+			t.Mailto = uri
+		}
+	}
+	t.OneClick = t.HTTPS != "" && strings.EqualFold(strings.TrimSpace(post), "List-Unsubscribe=One-Click")
+	return t
+}
+
+// method returns the preferred way to unsubscribe: one-click needs no user
+// interaction, mailto is handled by sending an email, and a plain link has
+// to be opened by the user.
+func (t unsubscribeTarget) method() unsubscribeMethod {
+	switch {
+	case t.OneClick:
+		return unsubscribeOneClick
+	case t.Mailto != "":
+		return unsubscribeMailto
+	case t.HTTPS != "":
+		return unsubscribeManual
+	default:
+		return unsubscribeNone
+	}
+}
+
+// host returns the domain an unsubscribe via method would contact: the
+// one-click URL's host or the mailto recipient's domain.
+func (t unsubscribeTarget) host(method unsubscribeMethod) string {
+	switch method {
+	case unsubscribeOneClick, unsubscribeManual:
+		if u, err := url.Parse(t.HTTPS); err == nil {
+			return strings.ToLower(u.Hostname())
+		}
+	case unsubscribeMailto:
+		if m, err := parseMailto(t.Mailto); err == nil {
+			_, domain, _ := strings.Cut(m.To, "@")
+			return strings.ToLower(domain)
+		}
+	}
+	return ""
+}
+
+// domainAllowed reports whether host equals or is a subdomain of an entry in
+// allowed. An empty allow-list permits every host.
+func domainAllowed(host string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, d := range allowed {
+		d = strings.ToLower(strings.TrimPrefix(d, "."))
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// unsubscribeTargetFor reads the List-Unsubscribe headers of a message.
+func unsubscribeTargetFor(msg *gmail.Message) unsubscribeTarget {
+	return parseListUnsubscribe(extractHeader(msg, "List-Unsubscribe"), extractHeader(msg, "List-Unsubscribe-Post"))
+}
+
+// mailtoMessage is an unsubscribe email derived from a mailto: URI.
+type mailtoMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// parseMailto parses a mailto: URI (RFC 6068) into an email to send.
+func parseMailto(uri string) (mailtoMessage, error) {
+	u, err := url.Parse(uri)
+	if err != nil || !strings.EqualFold(u.Scheme, "mailto") {
+		return mailtoMessage{}, fmt.Errorf("invalid mailto URI %q", uri)
+	}
+	to, err := url.PathUnescape(u.Opaque)
+	if err != nil || !strings.Contains(to, "@") {
+		return mailtoMessage{}, fmt.Errorf("mailto URI %q has no recipient", uri)
+	}
+	q := u.Query()
+	m := mailtoMessage{To: to, Subject: q.Get("subject"), Body: q.Get("body")}
+	if m.Subject == "" {
+		m.Subject = "unsubscribe"
+	}
+	if m.Body == "" {
+		m.Body = "unsubscribe"
+	}
+	return m, nil
+}
+
+// oneClickClient sends RFC 8058 unsubscribe requests. It refuses to connect
+// to loopback, private, and link-local addresses so a crafted header cannot
+// make the server reach into its own network.
+var oneClickClient = &http.Client{
+	Timeout: unsubscribeTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{Timeout: 5 * time.Second, Control: publicAddressOnly}).DialContext,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if req.URL.Scheme != "https" {
+			return errors.New("refusing non-HTTPS redirect")
+		}
+		if len(via) >= 3 {
+			return errors.New("too many redirects")
+		}
+		return nil
+	},
+}
+
+// publicAddressOnly is a net.Dialer Control hook rejecting non-public addresses.
+func publicAddressOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("refusing to connect to non-public address %s", host)
+	}
+	return nil
+}
+
+// nonPublicNets are special-purpose ranges that pass net.IP's unicast checks
+// but are not globally reachable, or embed an IPv4 address a gateway
+// translates to (NAT64, 6to4, Teredo), which could be private.
+var nonPublicNets = func() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range []string{
+		"0.0.0.0/8",       // "this network"
+		"100.64.0.0/10",   // carrier-grade NAT
+		"192.0.0.0/24",    // IETF protocol assignments
+		"192.0.2.0/24",    // documentation
+		"198.18.0.0/15",   // benchmarking
+		"198.51.100.0/24", // documentation
+		"203.0.113.0/24",  // documentation
+		"240.0.0.0/4",     // reserved
+		"64:ff9b::/96",    // NAT64 well-known prefix
+		"64:ff9b:1::/48",  // NAT64 local-use prefix
+		"100::/64",        // discard-only
+		"2001::/32",       // Teredo
+		"2001:db8::/32",   // documentation
+		"2002::/16",       // 6to4
+	} {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}()
+
+// isPublicIP reports whether ip is a globally routable unicast address.
+// IPv4-mapped IPv6 addresses are judged by their IPv4 address.
+func isPublicIP(ip net.IP) bool {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+		return false
+	}
+	for _, n := range nonPublicNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// postOneClick performs an RFC 8058 one-click unsubscribe.
+func postOneClick(ctx context.Context, client *http.Client, target string) error {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid one-click unsubscribe URL %q", target)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader("List-Unsubscribe=One-Click"))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("one-click unsubscribe request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("one-click unsubscribe returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
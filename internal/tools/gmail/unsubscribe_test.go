@@ -0,0 +1,207 @@
+package gmail
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseListUnsubscribe(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		post       string
+		wantHTTPS  string
+		wantMailto string
+		wantMethod unsubscribeMethod
+	}{
+		{
+			name:       "one-click",
+			header:     "<mailto:leave@list.example.com?subject=unsubscribe>, <https://list.example.com/u/abc>",
+			post:       "List-Unsubscribe=One-Click",
+			wantHTTPS:  "https://list.example.com/u/abc",
+			wantMailto: "mailto:leave@list.example.com?subject=unsubscribe",
+			wantMethod: unsubscribeOneClick,
+		},
+		{
+			name:       "mailto preferred without one-click",
+			header:     "<https://list.example.com/u/abc>, <mailto:leave@list.example.com>",
+			wantHTTPS:  "https://list.example.com/u/abc",
+			wantMailto: "mailto:leave@list.example.com",
+			wantMethod: unsubscribeMailto,
+		},
+		{
+			name:       "web page only",
+			header:     "<https://list.example.com/u/abc>",
+			wantHTTPS:  "https://list.example.com/u/abc",
+			wantMethod: unsubscribeManual,
+		},
+		{
+			name:       "plain http ignored",
+			header:     "<http://list.example.com/u/abc>",
+			post:       "List-Unsubscribe=One-Click",
+			wantMethod: unsubscribeNone,
+		},
+		{name: "missing", wantMethod: unsubscribeNone},
+		{name: "malformed", header: "mailto:leave@list.example.com", wantMethod: unsubscribeNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseListUnsubscribe(tt.header, tt.post)
+			if got.HTTPS != tt.wantHTTPS || got.Mailto != tt.wantMailto {
+				t.Errorf("targets: got https=%q mailto=%q", got.HTTPS, got.Mailto)
+			}
+			if m := got.method(); m != tt.wantMethod {
+				t.Errorf("method: got %q, want %q", m, tt.wantMethod)
+			}
+		})
+	}
+}
+
+func TestParseMailto(t *testing.T) {
+	tests := []struct {
+		uri     string
+		want    mailtoMessage
+		wantErr bool
+	}{
+		{"mailto:leave@list.example.com?subject=remove%20me&body=bye", mailtoMessage{"leave@list.example.com", "remove me", "bye"}, false},
+		{"mailto:leave@list.example.com", mailtoMessage{"leave@list.example.com", "unsubscribe", "unsubscribe"}, false},
+		{"mailto:?subject=x", mailtoMessage{}, true},
+		{"https://list.example.com", mailtoMessage{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseMailto(tt.uri)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseMailto(%q) error = %v, wantErr %v", tt.uri, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseMailto(%q) = %+v, want %+v", tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":    true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"10.1.2.3":         false,
+		"192.168.0.10":     false,
+		"169.254.169.254":  false,
+		"::1":              false,
+		"fd00::1":          false,
+		"0.0.0.0":          false,
+		"0.1.2.3":          false,
+		"100.64.0.1":       false,
+		"100.127.255.254":  false,
+		"100.128.0.1":      true,
+		"198.18.0.1":       false,
+		"240.0.0.1":        false,
+		"::ffff:10.1.2.3":  false,
+		"::ffff:8.8.8.8":   true,
+		"64:ff9b::a01:203": false,
+		"64:ff9b:1::1":     false,
+		"2002:a01:203::1":  false,
+		"2001:0:4136::1":   false,
+		"2001:db8::1":      false,
+	}
+	for addr, want := range tests {
+		if got := isPublicIP(net.ParseIP(addr)); got != want {
+			t.Errorf("isPublicIP(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestPostOneClick(t *testing.T) {
+	var gotBody, gotType string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody, gotType = string(body), r.Header.Get("Content-Type")
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer srv.Close()
+
+	if err := postOneClick(context.Background(), srv.Client(), srv.URL+"/u/abc"); err != nil {
+		t.Fatalf("postOneClick: %v", err)
+	}
+	if gotBody != "List-Unsubscribe=One-Click" || gotType != "application/x-www-form-urlencoded" {
+		t.Errorf("request: body=%q content-type=%q", gotBody, gotType)
+	}
+
+	// The production client must refuse to reach a loopback server.
+	err := postOneClick(context.Background(), oneClickClient, srv.URL+"/u/abc")
+	if err == nil || !strings.Contains(err.Error(), "non-public address") {
+		t.Errorf("expected loopback to be refused, got %v", err)
+	}
+
+	if err := postOneClick(context.Background(), srv.Client(), "http://list.example.com/u"); err == nil {
+		t.Error("expected plain HTTP URL to be rejected")
+	}
+}
+
+func TestDomainAllowed(t *testing.T) {
+	allowed := []string{"example.com", ".lists.org"}
+	tests := []struct {
+		host    string
+		allowed []string
+		want    bool
+	}{
+		{"anything.net", nil, true},
+		{"example.com", allowed, true},
+		{"mail.example.com", allowed, true},
+		{"news.lists.org", allowed, true},
+		{"badexample.com", allowed, false},
+		{"example.com.evil.net", allowed, false},
+		{"", allowed, false},
+	}
+	for _, tt := range tests {
+		if got := domainAllowed(tt.host, tt.allowed); got != tt.want {
+			t.Errorf("domainAllowed(%q, %v) = %v, want %v", tt.host, tt.allowed, got, tt.want)
+		}
+	}
+}
+
+func TestChooseUnsubscribeMethod(t *testing.T) {
+	both := unsubscribeTarget{HTTPS: "https://example.com/u", Mailto: "mailto:u@example.com", OneClick: true}
+	tests := []struct {
+		name      string
+		target    unsubscribeTarget
+		requested unsubscribeMethod
+		want      unsubscribeMethod
+		wantErr   bool
+	}{
+		{"default one-click", both, "", unsubscribeOneClick, false},
+		{"forced mailto", both, unsubscribeMailto, unsubscribeMailto, false},
+		{"mailto only", unsubscribeTarget{Mailto: "mailto:u@example.com"}, "", unsubscribeMailto, false},
+		{"no one-click", unsubscribeTarget{Mailto: "mailto:u@example.com"}, unsubscribeOneClick, "", true},
+		{"manual link", unsubscribeTarget{HTTPS: "https://example.com/u"}, "", "", true},
+		{"no header", unsubscribeTarget{}, "", "", true},
+		{"invalid method", both, "manual", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := chooseUnsubscribeMethod(tt.target, tt.requested)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("got (%q, %v), want %q (err=%v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnsubscribeTargetHost(t *testing.T) {
+	target := unsubscribeTarget{HTTPS: "https://Links.Example.com/u?id=1", Mailto: "mailto:leave@Lists.Example.org?subject=x"}
+	if got := target.host(unsubscribeOneClick); got != "links.example.com" {
+		t.Errorf("one-click host = %q", got)
+	}
+	if got := target.host(unsubscribeMailto); got != "lists.example.org" {
+		t.Errorf("mailto host = %q", got)
+	}
+}
@@ -0,0 +1,582 @@
+package gmail
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// PushPath is the HTTP path a Pub/Sub push subscription delivers Gmail
+// mailbox notifications to.
+const PushPath = "/gmail/push"
+
+// watchRenewInterval is how often active watches are renewed. Gmail stops
+// a watch after seven days unless it is renewed.
+const watchRenewInterval = 24 * time.Hour
+
+// maxBufferedEvents bounds the new-mail events kept per user for
+// list_gmail_watch_events; older events are dropped first.
+const maxBufferedEvents = 200
+
+// maxMessagesPerPush caps the messages fetched for one notification, so a
+// burst of mail cannot turn one push into hundreds of API calls.
+const maxMessagesPerPush = 25
+
+// maxPushBody bounds the size of a Pub/Sub push request.
+const maxPushBody = 64 << 10
+
+// mailWatchLogger is the MCP logger name used for new-mail notifications.
+const mailWatchLogger = "gmail-watch"
+
+// errWatchDisabled explains why Gmail push notifications are unavailable.
+var errWatchDisabled = errors.New("Gmail push notifications are not configured — the server needs WORKSPACE_MCP_GMAIL_PUSH_TOPIC and WORKSPACE_MCP_GMAIL_PUSH_TOKEN on an HTTP transport, with a Pub/Sub push subscription delivering to " + PushPath)
+
+// MailEvent is a new message reported by a Gmail watch.
+type MailEvent struct {
+	Seq       int64    `json:"seq"`
+	UserEmail string   `json:"user_google_email"`
+	MessageID string   `json:"message_id"`
+	ThreadID  string   `json:"thread_id"`
+	From      string   `json:"from,omitempty"`
+	Subject   string   `json:"subject,omitempty"`
+	Snippet   string   `json:"snippet,omitempty"`
+	LabelIDs  []string `json:"label_ids,omitempty"`
+	Received  string   `json:"received"`
+}
+
+// mailboxWatch is one user's Gmail watch: its history cursor, the sessions
+// to notify, and the events buffered for list_gmail_watch_events.
+type mailboxWatch struct {
+	historyID uint64
+	labelIDs  []string
+	sessions  map[*mcp.ServerSession]struct{}
+	events    []MailEvent
+	seq       int64
+	stop      context.CancelFunc
+
+	// busy serializes processing of the user's notifications so the same
+	// history range is never read twice.
+	busy sync.Mutex
+}
+
+// MailboxWatcher registers Gmail watches that publish mailbox changes to a
+// Pub/Sub topic, and receives the topic's push deliveries over HTTP. Each
+// notification is resolved through the history API into new-message
+// events, which are buffered per user and sent as MCP log notifications
+// (level "notice", logger "gmail-watch") to the sessions that started the
+// watch. Watches are renewed daily and last until stop_gmail_watch or a
+// server restart.
+type MailboxWatcher struct {
+	factory *services.Factory
+	topic   string
+	token   string
+
+	mu      sync.Mutex
+	users   map[string]*mailboxWatch
+	closing map[*mcp.ServerSession]bool // sessions whose end is awaited
+}
+
+// NewMailboxWatcher creates a watcher publishing to topic
+// (projects/{project}/topics/{topic}). Push requests must carry token as
+// their token query parameter. An empty topic disables the watch tools.
+func NewMailboxWatcher(factory *services.Factory, topic, token string) *MailboxWatcher {
+	return &MailboxWatcher{
+		factory: factory,
+		topic:   topic,
+		token:   token,
+		users:   make(map[string]*mailboxWatch),
+		closing: make(map[*mcp.ServerSession]bool),
+	}
+}
+
+// Enabled reports whether push notifications are configured.
+func (w *MailboxWatcher) Enabled() bool {
+	return w != nil && w.topic != ""
+}
+
+// watch starts or renews the user's Gmail watch and registers session, if
+// any, for notifications.
+func (w *MailboxWatcher) watch(ctx context.Context, srv *gmail.Service, userEmail string, labelIDs []string, session *mcp.ServerSession) (*gmail.WatchResponse, error) {
+	resp, err := srv.Users.Watch(userEmail, watchRequest(w.topic, labelIDs)).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	mw, ok := w.users[userEmail]
+	if !ok {
+		renewCtx, stop := context.WithCancel(context.Background())
+		mw = &mailboxWatch{historyID: resp.HistoryId, sessions: make(map[*mcp.ServerSession]struct{}), stop: stop}
+		w.users[userEmail] = mw
+		go w.renew(renewCtx, userEmail)
+	}
+	// An existing watch keeps its older cursor so no mail is skipped.
+	mw.labelIDs = labelIDs
+	if session != nil {
+		mw.sessions[session] = struct{}{}
+		if !w.closing[session] {
+			w.closing[session] = true
+			go w.dropOnClose(session)
+		}
+	}
+	return resp, nil
+}
+
+// watchRequest builds the users.watch request for topic, limited to
+// labelIDs when any are given.
+func watchRequest(topic string, labelIDs []string) *gmail.WatchRequest {
+	req := &gmail.WatchRequest{TopicName: topic, LabelIds: labelIDs}
+	if len(labelIDs) > 0 {
+		req.LabelFilterBehavior = "include"
+	}
+	return req
+}
+
+// stopWatch stops the user's Gmail watch and forgets its buffered events.
+// It reports whether this server was watching the mailbox.
+func (w *MailboxWatcher) stopWatch(ctx context.Context, srv *gmail.Service, userEmail string) (bool, error) {
+	w.mu.Lock()
+	mw, ok := w.users[userEmail]
+	if ok {
+		mw.stop()
+		delete(w.users, userEmail)
+	}
+	w.mu.Unlock()
+
+	return ok, srv.Users.Stop(userEmail).Context(ctx).Do()
+}
+
+// events returns up to limit buffered events after seq afterSeq, the
+// sequence number of the newest event, and whether the user is watched.
+func (w *MailboxWatcher) events(userEmail string, afterSeq int64, limit int) ([]MailEvent, int64, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	mw, ok := w.users[userEmail]
+	if !ok {
+		return nil, 0, false
+	}
+	out := []MailEvent{}
+	for _, e := range mw.events {
+		if e.Seq > afterSeq && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, mw.seq, true
+}
+
+// renew re-registers the user's watch every watchRenewInterval until ctx is
+// cancelled.
+func (w *MailboxWatcher) renew(ctx context.Context, userEmail string) {
+	ticker := time.NewTicker(watchRenewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		w.mu.Lock()
+		mw, ok := w.users[userEmail]
+		var labels []string
+		if ok {
+			labels = mw.labelIDs
+		}
+		w.mu.Unlock()
+		if !ok {
+			return
+		}
+
+		srv, err := w.factory.Gmail(ctx, userEmail)
+		if err == nil {
+			if _, err = srv.Users.Watch(userEmail, watchRequest(w.topic, labels)).Context(ctx).Do(); err == nil {
+				continue
+			}
+		}
+		slog.Warn("gmail watch renewal failed", "email", userEmail, "error", err)
+	}
+}
+
+// dropOnClose waits for session to end and stops notifying it. The watches
+// themselves continue, buffering events for list_gmail_watch_events.
+func (w *MailboxWatcher) dropOnClose(session *mcp.ServerSession) {
+	session.Wait()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.closing, session)
+	for _, mw := range w.users {
+		delete(mw.sessions, session)
+	}
+}
+
+// pushEnvelope is the body of a Pub/Sub push request.
+type pushEnvelope struct {
+	Message struct {
+		Data      string `json:"data"`
+		MessageID string `json:"messageId"`
+	} `json:"message"`
+	Subscription string `json:"subscription"`
+}
+
+// pushNotification is the payload Gmail publishes for a mailbox change.
+type pushNotification struct {
+	EmailAddress string `json:"emailAddress"`
+	HistoryID    uint64 `json:"historyId"`
+}
+
+// decodePush parses a Pub/Sub push body into Gmail's notification.
+func decodePush(body []byte) (pushNotification, error) {
+	var env pushEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return pushNotification{}, fmt.Errorf("invalid push body: %w", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(env.Message.Data)
+	if err != nil {
+		return pushNotification{}, fmt.Errorf("invalid push message data: %w", err)
+	}
+	var n pushNotification
+	if err := json.Unmarshal(data, &n); err != nil {
+		return pushNotification{}, fmt.Errorf("invalid Gmail notification: %w", err)
+	}
+	if n.EmailAddress == "" || n.HistoryID == 0 {
+		return pushNotification{}, fmt.Errorf("Gmail notification lacks emailAddress or historyId")
+	}
+	return n, nil
+}
+
+// ServeHTTP receives Pub/Sub push deliveries. Requests must carry the
+// configured token. Notifications are acknowledged at once and resolved in
+// the background; those for mailboxes this server does not watch are
+// acknowledged and dropped.
+func (w *MailboxWatcher) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if w.token == "" || subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(w.token)) != 1 {
+		http.Error(rw, "forbidden", http.StatusForbidden)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPushBody))
+	if err != nil {
+		http.Error(rw, "reading body failed", http.StatusBadRequest)
+		return
+	}
+	n, err := decodePush(body)
+	if err != nil {
+		// A malformed message would be redelivered forever; acknowledge it.
+		slog.Warn("dropping malformed gmail push", "error", err)
+		rw.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+	w.mu.Lock()
+	mw, ok := w.users[n.EmailAddress]
+	w.mu.Unlock()
+	if ok {
+		go w.process(n.EmailAddress, mw, n.HistoryID)
+	}
+}
+
+// process turns the mailbox history since the watch's cursor into events
+// and delivers them.
+func (w *MailboxWatcher) process(userEmail string, mw *mailboxWatch, historyID uint64) {
+	mw.busy.Lock()
+	defer mw.busy.Unlock()
+
+	w.mu.Lock()
+	start, labels := mw.historyID, mw.labelIDs
+	w.mu.Unlock()
+	if historyID <= start {
+		return // already processed
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	srv, err := w.factory.Gmail(ctx, userEmail)
+	if err != nil {
+		slog.Warn("gmail push processing failed", "email", userEmail, "error", err)
+		return
+	}
+
+	ids, latest, err := addedMessageIDs(ctx, srv, userEmail, start, labels)
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+		// The cursor is older than Gmail's history; restart from here.
+		slog.Warn("gmail history cursor expired; new mail before it is not reported", "email", userEmail)
+		ids, latest, err = nil, historyID, nil
+	}
+	if err != nil {
+		slog.Warn("gmail push processing failed", "email", userEmail, "error", err)
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	var fresh []MailEvent
+	for _, id := range ids {
+		msg, err := srv.Users.Messages.Get(userEmail, id).
+			Format("metadata").
+			MetadataHeaders(summaryHeaders...).
+			Context(ctx).
+			Do()
+		if err != nil {
+			continue // deleted since it arrived
+		}
+		s := messageToSummary(msg)
+		fresh = append(fresh, MailEvent{
+			UserEmail: userEmail,
+			MessageID: s.ID,
+			ThreadID:  s.ThreadID,
+			From:      s.From,
+			Subject:   s.Subject,
+			Snippet:   s.Snippet,
+			LabelIDs:  s.LabelIDs,
+			Received:  now,
+		})
+	}
+
+	w.mu.Lock()
+	if w.users[userEmail] != mw {
+		w.mu.Unlock()
+		return // stopped meanwhile
+	}
+	mw.historyID = max(latest, historyID)
+	for i := range fresh {
+		mw.seq++
+		fresh[i].Seq = mw.seq
+	}
+	mw.events = append(mw.events, fresh...)
+	if over := len(mw.events) - maxBufferedEvents; over > 0 {
+		mw.events = slices.Delete(mw.events, 0, over)
+	}
+	sessions := make([]*mcp.ServerSession, 0, len(mw.sessions))
+	for s := range mw.sessions {
+		sessions = append(sessions, s)
+	}
+	w.mu.Unlock()
+
+	for _, session := range sessions {
+		for _, e := range fresh {
+			if !w.send(ctx, userEmail, session, e) {
+				break
+			}
+		}
+	}
+}
+
+// addedMessageIDs returns the messages added to the mailbox since
+// startHistoryID, limited to those carrying one of labelIDs when given and
+// capped at maxMessagesPerPush, plus the mailbox's current history ID.
+func addedMessageIDs(ctx context.Context, srv *gmail.Service, userEmail string, startHistoryID uint64, labelIDs []string) ([]string, uint64, error) {
+	var ids []string
+	var latest uint64
+	pageToken := ""
+	for {
+		page, err := srv.Users.History.List(userEmail).
+			StartHistoryId(startHistoryID).
+			HistoryTypes("messageAdded").
+			PageToken(pageToken).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, 0, err
+		}
+		latest = page.HistoryId
+		for _, h := range page.History {
+			for _, added := range h.MessagesAdded {
+				m := added.Message
+				if m == nil || slices.Contains(ids, m.Id) || !hasAnyLabel(m.LabelIds, labelIDs) {
+					continue
+				}
+				if len(ids) < maxMessagesPerPush {
+					ids = append(ids, m.Id)
+				}
+			}
+		}
+		if page.NextPageToken == "" {
+			return ids, latest, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// hasAnyLabel reports whether labels include one of want; an empty want
+// matches everything.
+func hasAnyLabel(labels, want []string) bool {
+	if len(want) == 0 {
+		return true
+	}
+	for _, l := range want {
+		if slices.Contains(labels, l) {
+			return true
+		}
+	}
+	return false
+}
+
+// send delivers one event notification and reports whether the session was
+// reached. Unreachable sessions stop receiving the user's notifications.
+func (w *MailboxWatcher) send(ctx context.Context, userEmail string, session *mcp.ServerSession, e MailEvent) bool {
+	err := session.Log(ctx, &mcp.LoggingMessageParams{
+		Level:  "notice",
+		Logger: mailWatchLogger,
+		Data:   e,
+	})
+	if err == nil {
+		return true
+	}
+
+	slog.Debug("dropping gmail watch notifications for unreachable session", "email", userEmail, "error", err)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if mw, ok := w.users[userEmail]; ok {
+		delete(mw.sessions, session)
+	}
+	return false
+}
+
+// --- watch_gmail_mailbox (extended) ---
+
+type WatchMailboxInput struct {
+	UserEmail string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	LabelIDs  []string `json:"label_ids,omitempty" jsonschema_description:"Only report new mail carrying one of these labels (default INBOX)"`
+}
+
+type WatchMailboxOutput struct {
+	HistoryID  uint64   `json:"history_id"`
+	Expiration string   `json:"expiration"`
+	LabelIDs   []string `json:"label_ids"`
+}
+
+func createWatchMailboxHandler(factory *services.Factory, watcher *MailboxWatcher) mcp.ToolHandlerFor[WatchMailboxInput, WatchMailboxOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input WatchMailboxInput) (*mcp.CallToolResult, WatchMailboxOutput, error) {
+		if !watcher.Enabled() {
+			return nil, WatchMailboxOutput{}, errWatchDisabled
+		}
+		if len(input.LabelIDs) == 0 {
+			input.LabelIDs = []string{"INBOX"}
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, WatchMailboxOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		var session *mcp.ServerSession
+		if req != nil {
+			session = req.Session
+		}
+		resp, err := watcher.watch(ctx, srv, input.UserEmail, input.LabelIDs, session)
+		if err != nil {
+			return nil, WatchMailboxOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		out := WatchMailboxOutput{
+			HistoryID:  resp.HistoryId,
+			Expiration: time.UnixMilli(resp.Expiration).UTC().Format(time.RFC3339),
+			LabelIDs:   input.LabelIDs,
+		}
+		rb := response.New()
+		rb.Header("Gmail Watch Active")
+		rb.KeyValue("Labels", out.LabelIDs)
+		rb.KeyValue("History ID", out.HistoryID)
+		rb.KeyValue("Renews before", out.Expiration)
+		rb.Blank()
+		rb.Line("New mail is sent to this session as notifications from logger %q and buffered for list_gmail_watch_events.", mailWatchLogger)
+
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- stop_gmail_watch (extended) ---
+
+type StopWatchInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+}
+
+func createStopWatchHandler(factory *services.Factory, watcher *MailboxWatcher) mcp.ToolHandlerFor[StopWatchInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input StopWatchInput) (*mcp.CallToolResult, any, error) {
+		if !watcher.Enabled() {
+			return nil, nil, errWatchDisabled
+		}
+
+		srv, err := factory.Gmail(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		watching, err := watcher.stopWatch(ctx, srv, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Gmail Watch Stopped")
+		rb.KeyValue("User", input.UserEmail)
+		if !watching {
+			rb.Line("This server had no watch for the mailbox; any other push notifications for it were stopped.")
+		}
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// --- list_gmail_watch_events (extended) ---
+
+type ListWatchEventsInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	AfterSeq   int64  `json:"after_seq,omitempty" jsonschema_description:"Only return events after this sequence number (pass last_seq from the previous call)"`
+	MaxResults int    `json:"max_results,omitempty" jsonschema_description:"Maximum events to return (default 50)"`
+}
+
+type ListWatchEventsOutput struct {
+	Events  []MailEvent `json:"events"`
+	LastSeq int64       `json:"last_seq"`
+}
+
+func createListWatchEventsHandler(watcher *MailboxWatcher) mcp.ToolHandlerFor[ListWatchEventsInput, ListWatchEventsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListWatchEventsInput) (*mcp.CallToolResult, ListWatchEventsOutput, error) {
+		if !watcher.Enabled() {
+			return nil, ListWatchEventsOutput{}, errWatchDisabled
+		}
+		if input.MaxResults == 0 {
+			input.MaxResults = 50
+		}
+
+		events, lastSeq, ok := watcher.events(input.UserEmail, input.AfterSeq, input.MaxResults)
+		if !ok {
+			return nil, ListWatchEventsOutput{}, fmt.Errorf("no Gmail watch is active for %s — call watch_gmail_mailbox first", input.UserEmail)
+		}
+
+		out := ListWatchEventsOutput{Events: events, LastSeq: lastSeq}
+		rb := response.New()
+		rb.Header("Gmail Watch Events")
+		rb.KeyValue("Events", len(events))
+		rb.KeyValue("Last seq", lastSeq)
+		rb.Blank()
+		for _, e := range events {
+			rb.Item("[%d] %s — %s", e.Seq, e.From, e.Subject)
+			rb.Line("    Message ID: %s (Thread: %s)", e.MessageID, e.ThreadID)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
@@ -0,0 +1,227 @@
+package gmail
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+func pushBody(payload string) string {
+	return `{"message":{"data":"` + base64.StdEncoding.EncodeToString([]byte(payload)) + `","messageId":"1"},"subscription":"projects/p/subscriptions/s"}`
+}
+
+func TestDecodePush(t *testing.T) {
+	n, err := decodePush([]byte(pushBody(`{"emailAddress":"user@example.com","historyId":9876}`)))
+	if err != nil {
+		t.Fatalf("decodePush() error = %v", err)
+	}
+	if n.EmailAddress != "user@example.com" || n.HistoryID != 9876 {
+		t.Errorf("decodePush() = %+v", n)
+	}
+
+	for _, body := range []string{`not json`, `{"message":{"data":"%%%"}}`, pushBody(`{"historyId":1}`)} {
+		if _, err := decodePush([]byte(body)); err == nil {
+			t.Errorf("decodePush(%q) succeeded, want error", body)
+		}
+	}
+}
+
+func TestPushEndpointToken(t *testing.T) {
+	w := NewMailboxWatcher(nil, "projects/p/topics/t", "secret")
+	tests := []struct {
+		name   string
+		method string
+		target string
+		want   int
+	}{
+		{"valid", http.MethodPost, PushPath + "?token=secret", http.StatusNoContent},
+		{"wrong token", http.MethodPost, PushPath + "?token=nope", http.StatusForbidden},
+		{"no token", http.MethodPost, PushPath, http.StatusForbidden},
+		{"get", http.MethodGet, PushPath + "?token=secret", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := pushBody(`{"emailAddress":"unwatched@example.com","historyId":5}`)
+			rec := httptest.NewRecorder()
+			w.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(body)))
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatcherEvents(t *testing.T) {
+	w := NewMailboxWatcher(nil, "projects/p/topics/t", "secret")
+	if _, _, ok := w.events("user@example.com", 0, 10); ok {
+		t.Fatal("events() ok for an unwatched user")
+	}
+	w.users["user@example.com"] = &mailboxWatch{
+		events: []MailEvent{{Seq: 1, MessageID: "a"}, {Seq: 2, MessageID: "b"}, {Seq: 3, MessageID: "c"}},
+		seq:    3,
+	}
+
+	events, last, ok := w.events("user@example.com", 1, 10)
+	if !ok || last != 3 || len(events) != 2 || events[0].MessageID != "b" {
+		t.Errorf("events(after 1) = %+v, last %d, ok %v", events, last, ok)
+	}
+	if events, _, _ := w.events("user@example.com", 0, 1); len(events) != 1 || events[0].MessageID != "a" {
+		t.Errorf("events(limit 1) = %+v, want the oldest event", events)
+	}
+}
+
+func TestHasAnyLabel(t *testing.T) {
+	if !hasAnyLabel([]string{"INBOX"}, nil) {
+		t.Error("empty filter should match")
+	}
+	if !hasAnyLabel([]string{"UNREAD", "INBOX"}, []string{"INBOX"}) {
+		t.Error("INBOX message should match INBOX filter")
+	}
+	if hasAnyLabel([]string{"SENT"}, []string{"INBOX"}) {
+		t.Error("SENT message should not match INBOX filter")
+	}
+}
+
+func TestWatchToolsDisabled(t *testing.T) {
+	_, _, err := createWatchMailboxHandler(nil, nil)(context.Background(), &mcp.CallToolRequest{}, WatchMailboxInput{UserEmail: "user@example.com"})
+	if err != errWatchDisabled {
+		t.Errorf("error = %v, want errWatchDisabled", err)
+	}
+	_, _, err = createListWatchEventsHandler(NewMailboxWatcher(nil, "", ""))(context.Background(), &mcp.CallToolRequest{}, ListWatchEventsInput{UserEmail: "user@example.com"})
+	if err != errWatchDisabled {
+		t.Errorf("error = %v, want errWatchDisabled", err)
+	}
+}
+
+const watchPath = "/gmail/v1/users/user@example.com"
+
+func TestWatchHandlers(t *testing.T) {
+	var watchBody string
+	stops := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+watchPath+"/watch", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		watchBody = string(data)
+		replyJSON(`{"historyId":"1234","expiration":"1784066400000"}`)(w, r)
+	})
+	mux.HandleFunc("POST "+watchPath+"/stop", func(w http.ResponseWriter, r *http.Request) {
+		stops++
+		w.WriteHeader(http.StatusNoContent)
+	})
+	factory := fakeFactory(mux)
+	watcher := NewMailboxWatcher(factory, "projects/p/topics/t", "secret")
+	ctx := context.Background()
+
+	res, out, err := createWatchMailboxHandler(factory, watcher)(ctx, &mcp.CallToolRequest{}, WatchMailboxInput{UserEmail: settingsUser})
+	if err != nil {
+		t.Fatalf("watch error = %v", err)
+	}
+	for _, want := range []string{`"topicName":"projects/p/topics/t"`, `"labelIds":["INBOX"]`, `"labelFilterBehavior":"include"`} {
+		if !strings.Contains(watchBody, want) {
+			t.Errorf("watch request %s, want %s", watchBody, want)
+		}
+	}
+	if out.HistoryID != 1234 || out.Expiration != "2026-07-14T22:00:00Z" || len(out.LabelIDs) != 1 || out.LabelIDs[0] != "INBOX" {
+		t.Errorf("watch output = %+v", out)
+	}
+	text := resultText(res)
+	for _, want := range []string{"Gmail Watch Active", "History ID: 1234", "Renews before: 2026-07-14T22:00:00Z", "list_gmail_watch_events"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("watch text missing %q:\n%s", want, text)
+		}
+	}
+
+	watcher.mu.Lock()
+	mw := watcher.users[settingsUser]
+	mw.events = []MailEvent{
+		{Seq: 1, MessageID: "m1", ThreadID: "t1", From: "a@example.com", Subject: "First"},
+		{Seq: 2, MessageID: "m2", ThreadID: "t2", From: "b@example.com", Subject: "Second"},
+	}
+	mw.seq = 2
+	watcher.mu.Unlock()
+
+	list := createListWatchEventsHandler(watcher)
+	if _, _, err := list(ctx, &mcp.CallToolRequest{}, ListWatchEventsInput{UserEmail: "other@example.com"}); err == nil || !strings.Contains(err.Error(), "watch_gmail_mailbox first") {
+		t.Errorf("list for an unwatched user error = %v", err)
+	}
+	res, events, err := list(ctx, &mcp.CallToolRequest{}, ListWatchEventsInput{UserEmail: settingsUser, AfterSeq: 1})
+	if err != nil {
+		t.Fatalf("list error = %v", err)
+	}
+	if events.LastSeq != 2 || len(events.Events) != 1 || events.Events[0].MessageID != "m2" {
+		t.Errorf("list output = %+v", events)
+	}
+	text = resultText(res)
+	for _, want := range []string{"Events: 1", "Last seq: 2", "[2] b@example.com — Second", "Message ID: m2 (Thread: t2)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("list text missing %q:\n%s", want, text)
+		}
+	}
+
+	stop := createStopWatchHandler(factory, watcher)
+	res, _, err = stop(ctx, &mcp.CallToolRequest{}, StopWatchInput{UserEmail: settingsUser})
+	if err != nil {
+		t.Fatalf("stop error = %v", err)
+	}
+	if stops != 1 || strings.Contains(resultText(res), "had no watch") {
+		t.Errorf("stop calls = %d, text %q", stops, resultText(res))
+	}
+	if _, _, ok := watcher.events(settingsUser, 0, 10); ok {
+		t.Error("events still buffered after stop")
+	}
+	res, _, err = stop(ctx, &mcp.CallToolRequest{}, StopWatchInput{UserEmail: settingsUser})
+	if err != nil || stops != 2 || !strings.Contains(resultText(res), "had no watch") {
+		t.Errorf("second stop = %v, calls %d, text %q", err, stops, resultText(res))
+	}
+}
+
+func TestWatchHandlersValidation(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected API call %s %s", r.Method, r.URL.Path)
+	})
+	factory := fakeFactory(mux)
+	watcher := NewMailboxWatcher(factory, "projects/p/topics/t", "secret")
+	ctx := context.Background()
+
+	if _, _, err := createWatchMailboxHandler(factory, watcher)(ctx, &mcp.CallToolRequest{}, WatchMailboxInput{UserEmail: "not-an-email"}); err == nil || !strings.Contains(err.Error(), "invalid user email") {
+		t.Errorf("watch error = %v, want invalid user email", err)
+	}
+	if _, _, err := createStopWatchHandler(factory, watcher)(ctx, &mcp.CallToolRequest{}, StopWatchInput{UserEmail: "not-an-email"}); err == nil || !strings.Contains(err.Error(), "invalid user email") {
+		t.Errorf("stop error = %v, want invalid user email", err)
+	}
+	if _, _, err := createStopWatchHandler(factory, NewMailboxWatcher(factory, "", ""))(ctx, &mcp.CallToolRequest{}, StopWatchInput{UserEmail: settingsUser}); err != errWatchDisabled {
+		t.Errorf("stop error = %v, want errWatchDisabled", err)
+	}
+}
+
+func TestWatchHandlersAPIErrors(t *testing.T) {
+	for _, code := range []int{http.StatusForbidden, http.StatusNotFound} {
+		mux := http.NewServeMux()
+		mux.Handle("/", replyError(code, "denied"))
+		factory := fakeFactory(mux)
+		factory.SetRetryPolicies(services.RetryPolicy{}, nil)
+		watcher := NewMailboxWatcher(factory, "projects/p/topics/t", "secret")
+		want := map[int]string{http.StatusForbidden: "permission denied", http.StatusNotFound: "resource not found"}[code]
+
+		_, _, err := createWatchMailboxHandler(factory, watcher)(context.Background(), &mcp.CallToolRequest{}, WatchMailboxInput{UserEmail: settingsUser})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("watch %d error = %v, want %q", code, err, want)
+		}
+		if _, _, ok := watcher.events(settingsUser, 0, 10); ok {
+			t.Errorf("watch %d registered the user despite the API error", code)
+		}
+		_, _, err = createStopWatchHandler(factory, watcher)(context.Background(), &mcp.CallToolRequest{}, StopWatchInput{UserEmail: settingsUser})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("stop %d error = %v, want %q", code, err, want)
+		}
+	}
+}
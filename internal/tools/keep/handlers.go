@@ -0,0 +1,328 @@
+package keep
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/keep/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/media"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- list_keep_notes (core) ---
+
+type ListNotesInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Filter    string `json:"filter,omitempty" jsonschema_description:"Filter, e.g. trashed=true or update_time > \"2025-06-01T00:00:00Z\""`
+	PageSize  int    `json:"page_size,omitempty" jsonschema_description:"Maximum notes to return (default 25)"`
+	PageToken string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type ListNotesOutput struct {
+	Notes         []NoteSummary `json:"notes"`
+	NextPageToken string        `json:"next_page_token,omitempty"`
+}
+
+func createListNotesHandler(factory *services.Factory) mcp.ToolHandlerFor[ListNotesInput, ListNotesOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListNotesInput) (*mcp.CallToolResult, ListNotesOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 25
+		}
+
+		srv, err := factory.Keep(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListNotesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.Notes.List().PageSize(int64(input.PageSize)).Context(ctx)
+		if input.Filter != "" {
+			call = call.Filter(input.Filter)
+		}
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListNotesOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		notes := make([]NoteSummary, 0, len(result.Notes))
+		rb := response.New()
+		rb.Header("Keep Notes")
+		rb.KeyValue("Count", len(result.Notes))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+
+		for _, n := range result.Notes {
+			ns := noteToSummary(n)
+			notes = append(notes, ns)
+			rb.Item("%s (%s)", ns.Title, ns.Name)
+			rb.Line("    Updated: %s", ns.UpdateTime)
+			if len(ns.Checklist) > 0 {
+				rb.Line("    Checklist: %d items", len(ns.Checklist))
+			}
+			if len(ns.Attachments) > 0 {
+				rb.Line("    Attachments: %d", len(ns.Attachments))
+			}
+		}
+
+		return rb.TextResult(), ListNotesOutput{Notes: notes, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// --- get_keep_note (core) ---
+
+type GetNoteInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	NoteID    string `json:"note_id" jsonschema:"required" jsonschema_description:"The note ID or resource name (notes/...)"`
+}
+
+type GetNoteOutput struct {
+	Note NoteSummary `json:"note"`
+}
+
+func createGetNoteHandler(factory *services.Factory) mcp.ToolHandlerFor[GetNoteInput, GetNoteOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetNoteInput) (*mcp.CallToolResult, GetNoteOutput, error) {
+		srv, err := factory.Keep(ctx, input.UserEmail)
+		if err != nil {
+			return nil, GetNoteOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		note, err := srv.Notes.Get(noteName(input.NoteID)).Context(ctx).Do()
+		if err != nil {
+			return nil, GetNoteOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		ns := noteToSummary(note)
+		rb := response.New()
+		rb.Header("Keep Note")
+		rb.KeyValue("Title", ns.Title)
+		rb.KeyValue("Name", ns.Name)
+		rb.KeyValue("Updated", ns.UpdateTime)
+		if ns.Trashed {
+			rb.KeyValue("Trashed", true)
+		}
+		rb.Blank()
+		if len(ns.Checklist) > 0 {
+			rb.Section("Checklist")
+			for _, ci := range ns.Checklist {
+				rb.Raw(formatChecklistItem(ci))
+			}
+		} else {
+			rb.Section("Text")
+			rb.Raw(ns.Text)
+		}
+		if len(ns.Attachments) > 0 {
+			rb.Section("Attachments")
+			for _, a := range ns.Attachments {
+				rb.Item("%s (%v)", a.Name, a.MimeTypes)
+			}
+		}
+
+		return rb.TextResult(), GetNoteOutput{Note: ns}, nil
+	}
+}
+
+// --- create_keep_note (core) ---
+
+type CreateNoteInput struct {
+	UserEmail string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Title     string   `json:"title" jsonschema:"required" jsonschema_description:"The note title"`
+	Text      string   `json:"text,omitempty" jsonschema_description:"The note text"`
+	ListItems []string `json:"list_items,omitempty" jsonschema_description:"Checklist items; creates a checklist note instead of a text note"`
+}
+
+type CreateNoteOutput struct {
+	Note NoteSummary `json:"note"`
+}
+
+func createCreateNoteHandler(factory *services.Factory) mcp.ToolHandlerFor[CreateNoteInput, CreateNoteOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateNoteInput) (*mcp.CallToolResult, CreateNoteOutput, error) {
+		body, err := buildNoteBody(input.Text, input.ListItems)
+		if err != nil {
+			return nil, CreateNoteOutput{}, err
+		}
+
+		srv, err := factory.Keep(ctx, input.UserEmail)
+		if err != nil {
+			return nil, CreateNoteOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		note, err := srv.Notes.Create(&keep.Note{Title: input.Title, Body: body}).Context(ctx).Do()
+		if err != nil {
+			return nil, CreateNoteOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		ns := noteToSummary(note)
+		rb := response.New()
+		rb.Header("Keep Note Created")
+		rb.KeyValue("Title", ns.Title)
+		rb.KeyValue("Name", ns.Name)
+		if len(ns.Checklist) > 0 {
+			rb.KeyValue("Checklist items", len(ns.Checklist))
+		}
+
+		return rb.TextResult(), CreateNoteOutput{Note: ns}, nil
+	}
+}
+
+// --- delete_keep_note (extended) ---
+
+type DeleteNoteInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	NoteID    string `json:"note_id" jsonschema:"required" jsonschema_description:"The note ID or resource name (notes/...)"`
+}
+
+func createDeleteNoteHandler(factory *services.Factory) mcp.ToolHandlerFor[DeleteNoteInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DeleteNoteInput) (*mcp.CallToolResult, any, error) {
+		srv, err := factory.Keep(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		name := noteName(input.NoteID)
+		if _, err := srv.Notes.Delete(name).Context(ctx).Do(); err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Keep Note Deleted")
+		rb.KeyValue("Name", name)
+
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// --- list_keep_attachments (extended) ---
+
+type ListAttachmentsInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	NoteID    string `json:"note_id" jsonschema:"required" jsonschema_description:"The note ID or resource name (notes/...)"`
+}
+
+type ListAttachmentsOutput struct {
+	Attachments []AttachmentSummary `json:"attachments"`
+}
+
+func createListAttachmentsHandler(factory *services.Factory) mcp.ToolHandlerFor[ListAttachmentsInput, ListAttachmentsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListAttachmentsInput) (*mcp.CallToolResult, ListAttachmentsOutput, error) {
+		srv, err := factory.Keep(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListAttachmentsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		note, err := srv.Notes.Get(noteName(input.NoteID)).Fields("name", "attachments").Context(ctx).Do()
+		if err != nil {
+			return nil, ListAttachmentsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		attachments := noteToSummary(note).Attachments
+		rb := response.New()
+		rb.Header("Keep Attachments")
+		rb.KeyValue("Note", note.Name)
+		rb.KeyValue("Count", len(attachments))
+		rb.Blank()
+		for _, a := range attachments {
+			rb.Item("%s (%v)", a.Name, a.MimeTypes)
+		}
+
+		return rb.TextResult(), ListAttachmentsOutput{Attachments: attachments}, nil
+	}
+}
+
+// --- download_keep_attachment (extended) ---
+
+type DownloadAttachmentInput struct {
+	UserEmail      string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	AttachmentName string `json:"attachment_name" jsonschema:"required" jsonschema_description:"The attachment resource name (notes/{note}/attachments/{attachment})"`
+	MimeType       string `json:"mime_type,omitempty" jsonschema_description:"MIME type to download (default: the attachment's first available type)"`
+}
+
+type DownloadAttachmentOutput struct {
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type"`
+	Size     int    `json:"size"`
+	Data     string `json:"data,omitempty"`
+}
+
+func createDownloadAttachmentHandler(factory *services.Factory) mcp.ToolHandlerFor[DownloadAttachmentInput, DownloadAttachmentOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DownloadAttachmentInput) (*mcp.CallToolResult, DownloadAttachmentOutput, error) {
+		srv, err := factory.Keep(ctx, input.UserEmail)
+		if err != nil {
+			return nil, DownloadAttachmentOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		mimeType := input.MimeType
+		if mimeType == "" {
+			if mimeType, err = attachmentMimeType(ctx, srv, input.AttachmentName); err != nil {
+				return nil, DownloadAttachmentOutput{}, err
+			}
+		}
+		data, err := downloadAttachment(ctx, srv, input.AttachmentName, mimeType)
+		if err != nil {
+			return nil, DownloadAttachmentOutput{}, err
+		}
+
+		out := DownloadAttachmentOutput{Name: input.AttachmentName, MimeType: mimeType, Size: len(data)}
+		rb := response.New()
+		rb.Header("Keep Attachment")
+		rb.KeyValue("Name", out.Name)
+		rb.KeyValue("MIME Type", mimeType)
+		rb.KeyValue("Size", fmt.Sprintf("%d bytes", len(data)))
+		if media.InlineImage(mimeType, len(data)) {
+			rb.Line("Image returned as inline image content.")
+			result := &mcp.CallToolResult{Content: []mcp.Content{
+				&mcp.TextContent{Text: rb.Build()},
+				&mcp.ImageContent{Data: data, MIMEType: mimeType},
+			}}
+			return result, out, nil
+		}
+		out.Data = base64.StdEncoding.EncodeToString(data)
+		rb.Line("Content available in structured output as base64-encoded data.")
+		return rb.TextResult(), out, nil
+	}
+}
+
+// attachmentMimeType returns the first MIME type an attachment is available in.
+func attachmentMimeType(ctx context.Context, srv *keep.Service, name string) (string, error) {
+	noteName, err := attachmentNote(name)
+	if err != nil {
+		return "", err
+	}
+	note, err := srv.Notes.Get(noteName).Fields("attachments").Context(ctx).Do()
+	if err != nil {
+		return "", middleware.HandleGoogleAPIError(err)
+	}
+	for _, a := range note.Attachments {
+		if a.Name == name && len(a.MimeType) > 0 {
+			return a.MimeType[0], nil
+		}
+	}
+	return "", fmt.Errorf("attachment %s not found on %s", name, noteName)
+}
+
+// downloadAttachment fetches attachment content, up to maxAttachmentSize.
+func downloadAttachment(ctx context.Context, srv *keep.Service, name, mimeType string) ([]byte, error) {
+	resp, err := srv.Media.Download(name).MimeType(mimeType).Context(ctx).Download()
+	if err != nil {
+		return nil, middleware.HandleGoogleAPIError(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAttachmentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading attachment: %w", err)
+	}
+	if len(data) > maxAttachmentSize {
+		return nil, fmt.Errorf("attachment exceeds the %d MB download limit", maxAttachmentSize>>20)
+	}
+	return data, nil
+}
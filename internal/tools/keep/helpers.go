@@ -0,0 +1,116 @@
+package keep
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/keep/v1"
+)
+
+// maxAttachmentSize caps attachment downloads (10 MB).
+const maxAttachmentSize = 10 << 20
+
+// NoteSummary is a compact representation of a Keep note.
+type NoteSummary struct {
+	Name        string              `json:"name"`
+	Title       string              `json:"title,omitempty"`
+	Text        string              `json:"text,omitempty"`
+	Checklist   []ChecklistItem     `json:"checklist,omitempty"`
+	Attachments []AttachmentSummary `json:"attachments,omitempty"`
+	Trashed     bool                `json:"trashed,omitempty"`
+	CreateTime  string              `json:"create_time,omitempty"`
+	UpdateTime  string              `json:"update_time,omitempty"`
+}
+
+// ChecklistItem is one item of a checklist note; nested items are indented
+// one level by Depth.
+type ChecklistItem struct {
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+	Depth   int    `json:"depth,omitempty"`
+}
+
+// AttachmentSummary describes a note attachment.
+type AttachmentSummary struct {
+	Name      string   `json:"name"`
+	MimeTypes []string `json:"mime_types"`
+}
+
+// noteName returns the resource name for a note ID or name.
+func noteName(id string) string {
+	if strings.HasPrefix(id, "notes/") {
+		return id
+	}
+	return "notes/" + id
+}
+
+// attachmentNote returns the note that owns an attachment resource name
+// (notes/{note}/attachments/{attachment}).
+func attachmentNote(name string) (string, error) {
+	note, _, ok := strings.Cut(name, "/attachments/")
+	if !ok || !strings.HasPrefix(note, "notes/") {
+		return "", fmt.Errorf("invalid attachment name %q — expected notes/{note}/attachments/{attachment}", name)
+	}
+	return note, nil
+}
+
+func noteToSummary(n *keep.Note) NoteSummary {
+	ns := NoteSummary{
+		Name:       n.Name,
+		Title:      n.Title,
+		Trashed:    n.Trashed,
+		CreateTime: n.CreateTime,
+		UpdateTime: n.UpdateTime,
+	}
+	if n.Body != nil {
+		if n.Body.Text != nil {
+			ns.Text = n.Body.Text.Text
+		}
+		if n.Body.List != nil {
+			ns.Checklist = flattenChecklist(n.Body.List.ListItems, 0)
+		}
+	}
+	for _, a := range n.Attachments {
+		ns.Attachments = append(ns.Attachments, AttachmentSummary{Name: a.Name, MimeTypes: a.MimeType})
+	}
+	return ns
+}
+
+// flattenChecklist returns items and their children in display order.
+func flattenChecklist(items []*keep.ListItem, depth int) []ChecklistItem {
+	var out []ChecklistItem
+	for _, item := range items {
+		ci := ChecklistItem{Checked: item.Checked, Depth: depth}
+		if item.Text != nil {
+			ci.Text = item.Text.Text
+		}
+		out = append(out, ci)
+		out = append(out, flattenChecklist(item.ChildListItems, depth+1)...)
+	}
+	return out
+}
+
+// formatChecklistItem renders an item as "[x] text", indented by depth.
+func formatChecklistItem(ci ChecklistItem) string {
+	box := "[ ]"
+	if ci.Checked {
+		box = "[x]"
+	}
+	return strings.Repeat("  ", ci.Depth) + box + " " + ci.Text
+}
+
+// buildNoteBody returns a checklist body when items are given, otherwise a
+// text body.
+func buildNoteBody(text string, items []string) (*keep.Section, error) {
+	if len(items) == 0 {
+		return &keep.Section{Text: &keep.TextContent{Text: text}}, nil
+	}
+	if text != "" {
+		return nil, fmt.Errorf("a note has either text or list_items, not both")
+	}
+	list := &keep.ListContent{}
+	for _, item := range items {
+		list.ListItems = append(list.ListItems, &keep.ListItem{Text: &keep.TextContent{Text: item}})
+	}
+	return &keep.Section{List: list}, nil
+}
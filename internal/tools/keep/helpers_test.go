@@ -0,0 +1,78 @@
+package keep
+
+import (
+	"testing"
+
+	"google.golang.org/api/keep/v1"
+)
+
+func TestNoteName(t *testing.T) {
+	tests := map[string]string{
+		"abc123":       "notes/abc123",
+		"notes/abc123": "notes/abc123",
+	}
+	for id, want := range tests {
+		if got := noteName(id); got != want {
+			t.Errorf("noteName(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestAttachmentNote(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"notes/abc/attachments/img1", "notes/abc", false},
+		{"abc/attachments/img1", "", true},
+		{"notes/abc", "", true},
+	}
+	for _, tt := range tests {
+		got, err := attachmentNote(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("attachmentNote(%q) = %q, %v; want %q, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestNoteToSummaryChecklist(t *testing.T) {
+	note := &keep.Note{
+		Name:  "notes/abc",
+		Title: "Groceries",
+		Body: &keep.Section{List: &keep.ListContent{ListItems: []*keep.ListItem{
+			{Text: &keep.TextContent{Text: "Fruit"}, ChildListItems: []*keep.ListItem{
+				{Text: &keep.TextContent{Text: "Apples"}, Checked: true},
+			}},
+			{Text: &keep.TextContent{Text: "Bread"}},
+		}}},
+		Attachments: []*keep.Attachment{{Name: "notes/abc/attachments/1", MimeType: []string{"image/png"}}},
+	}
+	ns := noteToSummary(note)
+	want := []string{"[ ] Fruit", "  [x] Apples", "[ ] Bread"}
+	if len(ns.Checklist) != len(want) {
+		t.Fatalf("checklist = %+v, want %d items", ns.Checklist, len(want))
+	}
+	for i, ci := range ns.Checklist {
+		if got := formatChecklistItem(ci); got != want[i] {
+			t.Errorf("item %d = %q, want %q", i, got, want[i])
+		}
+	}
+	if len(ns.Attachments) != 1 || ns.Attachments[0].MimeTypes[0] != "image/png" {
+		t.Errorf("attachments = %+v", ns.Attachments)
+	}
+}
+
+func TestBuildNoteBody(t *testing.T) {
+	body, err := buildNoteBody("hello", nil)
+	if err != nil || body.Text == nil || body.Text.Text != "hello" {
+		t.Errorf("text body = %+v, %v", body, err)
+	}
+	body, err = buildNoteBody("", []string{"a", "b"})
+	if err != nil || body.List == nil || len(body.List.ListItems) != 2 {
+		t.Errorf("checklist body = %+v, %v", body, err)
+	}
+	if _, err := buildNoteBody("hello", []string{"a"}); err == nil {
+		t.Error("text and list_items: want error")
+	}
+}
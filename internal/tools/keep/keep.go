@@ -0,0 +1,89 @@
+// Package keep implements Google Keep tools for notes and their attachments.
+// The Keep API is available to Google Workspace accounts only.
+package keep
+
+import (
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/ptr"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+var serviceIcons = []mcp.Icon{{
+	Source:   "https://www.gstatic.com/images/branding/product/1x/keep_48dp.png",
+	MIMEType: "image/png",
+	Sizes:    []string{"48x48"},
+}}
+
+// Register registers all Keep tools (core + extended) with the MCP server.
+func Register(server *mcp.Server, factory *services.Factory) {
+	// --- Core tools (3) ---
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_keep_notes",
+		Icons:       serviceIcons,
+		Description: "List Google Keep notes, newest first. Trashed notes are excluded unless filter is \"trashed=true\"; filter also accepts create_time and update_time comparisons.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Keep Notes",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListNotesHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_keep_note",
+		Icons:       serviceIcons,
+		Description: "Get a Google Keep note's title, text or checklist, and attachments.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Keep Note",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetNoteHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_keep_note",
+		Icons:       serviceIcons,
+		Description: "Create a Google Keep note with text, or a checklist when list_items is given.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Create Keep Note",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createCreateNoteHandler(factory))
+
+	// --- Extended tools (3) ---
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_keep_note",
+		Icons:       serviceIcons,
+		Description: "Permanently delete a Google Keep note. Only the note's owner can delete it.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Delete Keep Note",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createDeleteNoteHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_keep_attachments",
+		Icons:       serviceIcons,
+		Description: "List the attachments of a Google Keep note with their resource names and available MIME types.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Keep Attachments",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListAttachmentsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "download_keep_attachment",
+		Icons:       serviceIcons,
+		Description: "Download a Google Keep note attachment. Images are returned as inline image content; other content (e.g. audio) as base64 in structured output.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Download Keep Attachment",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createDownloadAttachmentHandler(factory))
+}
@@ -0,0 +1,312 @@
+package meet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	meet "google.golang.org/api/meet/v2"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- create_meet_space (core) ---
+
+type CreateSpaceInput struct {
+	UserEmail  string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	AccessType string `json:"access_type,omitempty" jsonschema_description:"Who can join without knocking: OPEN (anyone with the link) TRUSTED (organization members and invitees) or RESTRICTED (invitees only). Default: organization setting,enum=OPEN,enum=TRUSTED,enum=RESTRICTED"`
+}
+
+func createCreateSpaceHandler(factory *services.Factory) mcp.ToolHandlerFor[CreateSpaceInput, SpaceSummary] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateSpaceInput) (*mcp.CallToolResult, SpaceSummary, error) {
+		space := &meet.Space{}
+		switch access := strings.ToUpper(input.AccessType); access {
+		case "":
+		case "OPEN", "TRUSTED", "RESTRICTED":
+			space.Config = &meet.SpaceConfig{AccessType: access}
+		default:
+			return nil, SpaceSummary{}, fmt.Errorf("invalid access_type %q: use OPEN, TRUSTED, or RESTRICTED", input.AccessType)
+		}
+
+		srv, err := factory.Meet(ctx, input.UserEmail)
+		if err != nil {
+			return nil, SpaceSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		created, err := srv.Spaces.Create(space).Context(ctx).Do()
+		if err != nil {
+			return nil, SpaceSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		ss := spaceToSummary(created)
+		rb := response.New()
+		rb.Header("Meet Space Created")
+		writeSpace(rb, ss)
+		return rb.TextResult(), ss, nil
+	}
+}
+
+// --- get_meet_space (extended) ---
+
+type GetSpaceInput struct {
+	UserEmail string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Space     string `json:"space" jsonschema:"required" jsonschema_description:"Space resource name (spaces/...) or meeting code (e.g. abc-mnop-xyz)"`
+}
+
+func createGetSpaceHandler(factory *services.Factory) mcp.ToolHandlerFor[GetSpaceInput, SpaceSummary] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetSpaceInput) (*mcp.CallToolResult, SpaceSummary, error) {
+		srv, err := factory.Meet(ctx, input.UserEmail)
+		if err != nil {
+			return nil, SpaceSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		space, err := srv.Spaces.Get(spaceName(input.Space)).Context(ctx).Do()
+		if err != nil {
+			return nil, SpaceSummary{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		ss := spaceToSummary(space)
+		rb := response.New()
+		rb.Header("Meet Space")
+		writeSpace(rb, ss)
+		return rb.TextResult(), ss, nil
+	}
+}
+
+func writeSpace(rb *response.Builder, ss SpaceSummary) {
+	rb.KeyValue("Name", ss.Name)
+	rb.KeyValue("Meeting link", ss.MeetingURI)
+	rb.KeyValue("Meeting code", ss.MeetingCode)
+	if ss.AccessType != "" {
+		rb.KeyValue("Access type", ss.AccessType)
+	}
+	if ss.ActiveConference != "" {
+		rb.KeyValue("Active conference", ss.ActiveConference)
+	}
+}
+
+// --- list_conference_records (core) ---
+
+type ListConferenceRecordsInput struct {
+	UserEmail   string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	MeetingCode string `json:"meeting_code,omitempty" jsonschema_description:"Only conferences held in the space with this meeting code"`
+	StartAfter  string `json:"start_after,omitempty" jsonschema_description:"Only conferences that started at or after this time (RFC3339)"`
+	StartBefore string `json:"start_before,omitempty" jsonschema_description:"Only conferences that started at or before this time (RFC3339)"`
+	PageSize    int    `json:"page_size,omitempty" jsonschema_description:"Maximum records to return (default 10, max 100)"`
+	PageToken   string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type ListConferenceRecordsOutput struct {
+	Conferences   []ConferenceSummary `json:"conferences"`
+	NextPageToken string              `json:"next_page_token,omitempty"`
+}
+
+func createListConferenceRecordsHandler(factory *services.Factory) mcp.ToolHandlerFor[ListConferenceRecordsInput, ListConferenceRecordsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListConferenceRecordsInput) (*mcp.CallToolResult, ListConferenceRecordsOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 10
+		}
+		filter, err := conferenceFilter(input.MeetingCode, input.StartAfter, input.StartBefore)
+		if err != nil {
+			return nil, ListConferenceRecordsOutput{}, err
+		}
+
+		srv, err := factory.Meet(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListConferenceRecordsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		call := srv.ConferenceRecords.List().PageSize(int64(input.PageSize)).Context(ctx)
+		if filter != "" {
+			call = call.Filter(filter)
+		}
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+
+		result, err := call.Do()
+		if err != nil {
+			return nil, ListConferenceRecordsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		conferences := make([]ConferenceSummary, 0, len(result.ConferenceRecords))
+		rb := response.New()
+		rb.Header("Conference Records")
+		rb.KeyValue("Count", len(result.ConferenceRecords))
+		if result.NextPageToken != "" {
+			rb.KeyValue("Next page token", result.NextPageToken)
+		}
+		rb.Blank()
+		for _, r := range result.ConferenceRecords {
+			cs := ConferenceSummary{Name: r.Name, Space: r.Space, StartTime: r.StartTime, EndTime: r.EndTime}
+			conferences = append(conferences, cs)
+			rb.Item("%s", cs.Name)
+			rb.Line("    Space: %s", cs.Space)
+			if cs.EndTime != "" {
+				rb.Line("    %s → %s", cs.StartTime, cs.EndTime)
+			} else {
+				rb.Line("    Started: %s (in progress)", cs.StartTime)
+			}
+		}
+
+		return rb.TextResult(), ListConferenceRecordsOutput{Conferences: conferences, NextPageToken: result.NextPageToken}, nil
+	}
+}
+
+// --- list_meet_recordings (extended) ---
+
+type ListRecordingsInput struct {
+	UserEmail        string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	ConferenceRecord string `json:"conference_record" jsonschema:"required" jsonschema_description:"Conference record name (conferenceRecords/...) or ID from list_conference_records"`
+}
+
+type ListRecordingsOutput struct {
+	Recordings []RecordingSummary `json:"recordings"`
+}
+
+func createListRecordingsHandler(factory *services.Factory) mcp.ToolHandlerFor[ListRecordingsInput, ListRecordingsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListRecordingsInput) (*mcp.CallToolResult, ListRecordingsOutput, error) {
+		srv, err := factory.Meet(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ListRecordingsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		var recordings []RecordingSummary
+		err = srv.ConferenceRecords.Recordings.List(recordName(input.ConferenceRecord)).Context(ctx).
+			Pages(ctx, func(page *meet.ListRecordingsResponse) error {
+				for _, r := range page.Recordings {
+					recordings = append(recordings, recordingToSummary(r))
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, ListRecordingsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		rb := response.New()
+		rb.Header("Meet Recordings")
+		rb.KeyValue("Conference", recordName(input.ConferenceRecord))
+		rb.KeyValue("Count", len(recordings))
+		rb.Blank()
+		for _, r := range recordings {
+			rb.Item("%s [%s]", r.Name, r.State)
+			if r.StartTime != "" {
+				rb.Line("    %s → %s", r.StartTime, r.EndTime)
+			}
+			if r.PlayURI != "" {
+				rb.Line("    Play: %s", r.PlayURI)
+			}
+		}
+		if len(recordings) == 0 {
+			rb.Line("No recordings — the meeting was not recorded or the recording has expired.")
+		}
+
+		if recordings == nil {
+			recordings = []RecordingSummary{}
+		}
+		return rb.TextResult(), ListRecordingsOutput{Recordings: recordings}, nil
+	}
+}
+
+// --- get_meet_transcript (core) ---
+
+type GetTranscriptInput struct {
+	UserEmail        string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	ConferenceRecord string `json:"conference_record" jsonschema:"required" jsonschema_description:"Conference record name (conferenceRecords/...) or ID from list_conference_records"`
+	Transcript       string `json:"transcript,omitempty" jsonschema_description:"Transcript resource name when the conference has several (default: the first)"`
+	PageSize         int    `json:"page_size,omitempty" jsonschema_description:"Maximum entries to return (default 100, max 100)"`
+	PageToken        string `json:"page_token,omitempty" jsonschema_description:"Token for pagination"`
+}
+
+type GetTranscriptOutput struct {
+	Transcript    string           `json:"transcript"`
+	DocumentURI   string           `json:"document_uri,omitempty"`
+	Entries       []TranscriptLine `json:"entries"`
+	NextPageToken string           `json:"next_page_token,omitempty"`
+}
+
+func createGetTranscriptHandler(factory *services.Factory) mcp.ToolHandlerFor[GetTranscriptInput, GetTranscriptOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetTranscriptInput) (*mcp.CallToolResult, GetTranscriptOutput, error) {
+		if input.PageSize == 0 {
+			input.PageSize = 100
+		}
+		record := recordName(input.ConferenceRecord)
+
+		srv, err := factory.Meet(ctx, input.UserEmail)
+		if err != nil {
+			return nil, GetTranscriptOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		var transcript *meet.Transcript
+		if input.Transcript != "" {
+			transcript, err = srv.ConferenceRecords.Transcripts.Get(input.Transcript).Context(ctx).Do()
+			if err != nil {
+				return nil, GetTranscriptOutput{}, middleware.HandleGoogleAPIError(err)
+			}
+		} else {
+			list, err := srv.ConferenceRecords.Transcripts.List(record).Context(ctx).Do()
+			if err != nil {
+				return nil, GetTranscriptOutput{}, middleware.HandleGoogleAPIError(err)
+			}
+			if len(list.Transcripts) == 0 {
+				return nil, GetTranscriptOutput{}, fmt.Errorf("conference %s has no transcript — transcription was not turned on or the transcript has expired", record)
+			}
+			transcript = list.Transcripts[0]
+		}
+
+		call := srv.ConferenceRecords.Transcripts.Entries.List(transcript.Name).PageSize(int64(input.PageSize)).Context(ctx)
+		if input.PageToken != "" {
+			call = call.PageToken(input.PageToken)
+		}
+		entries, err := call.Do()
+		if err != nil {
+			return nil, GetTranscriptOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		speakers := make(map[string]string)
+		participants := srv.ConferenceRecords.Participants.List(record).PageSize(250).Context(ctx)
+		for page := 0; page < maxParticipantPages; page++ {
+			result, err := participants.Do()
+			if err != nil {
+				return nil, GetTranscriptOutput{}, middleware.HandleGoogleAPIError(err)
+			}
+			for _, p := range result.Participants {
+				speakers[p.Name] = participantName(p)
+			}
+			if result.NextPageToken == "" {
+				break
+			}
+			participants = participants.PageToken(result.NextPageToken)
+		}
+
+		out := GetTranscriptOutput{
+			Transcript:    transcript.Name,
+			Entries:       transcriptLines(entries.TranscriptEntries, speakers),
+			NextPageToken: entries.NextPageToken,
+		}
+		if transcript.DocsDestination != nil {
+			out.DocumentURI = transcript.DocsDestination.ExportUri
+		}
+
+		rb := response.New()
+		rb.Header("Meet Transcript")
+		rb.KeyValue("Transcript", out.Transcript)
+		rb.KeyValue("State", transcript.State)
+		if out.DocumentURI != "" {
+			rb.KeyValue("Document", out.DocumentURI)
+		}
+		rb.KeyValue("Entries", len(out.Entries))
+		if out.NextPageToken != "" {
+			rb.KeyValue("Next page token", out.NextPageToken)
+		}
+		rb.Blank()
+		for _, l := range out.Entries {
+			rb.Line("[%s] %s: %s", l.Time, l.Speaker, l.Text)
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
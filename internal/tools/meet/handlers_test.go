@@ -0,0 +1,305 @@
+package meet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/auth"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// fakeAPI answers Meet requests in-process from a ServeMux, so the handlers
+// run against canned API responses.
+type fakeAPI struct{ *http.ServeMux }
+
+func (f fakeAPI) RoundTrip(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	f.ServeHTTP(rec, r)
+	resp := rec.Result()
+	resp.Request = r
+	return resp, nil
+}
+
+func fakeFactory(mux *http.ServeMux) *services.Factory {
+	oauth := auth.NewOAuthManager("id", "secret", "http://localhost/callback", nil, auth.NewInMemoryTokenStore())
+	factory := services.NewFactory(oauth)
+	factory.SetSandbox(fakeAPI{mux})
+	return factory
+}
+
+func replyJSON(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	}
+}
+
+func replyError(code int, message string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		fmt.Fprintf(w, `{"error":{"code":%d,"message":%q}}`, code, message)
+	}
+}
+
+func resultText(res *mcp.CallToolResult) string {
+	var b strings.Builder
+	for _, c := range res.Content {
+		if tc, ok := c.(*mcp.TextContent); ok {
+			b.WriteString(tc.Text)
+		}
+	}
+	return b.String()
+}
+
+const user = "host@example.com"
+
+const spaceJSON = `{"name":"spaces/abc","meetingUri":"https://meet.google.com/abc-mnop-xyz","meetingCode":"abc-mnop-xyz",
+	"config":{"accessType":"TRUSTED"},"activeConference":{"conferenceRecord":"conferenceRecords/r1"}}`
+
+func TestCreateSpaceHandler(t *testing.T) {
+	tests := []struct {
+		access   string
+		wantBody string
+		wantErr  string
+	}{
+		{access: "", wantBody: `{}`},
+		{access: "trusted", wantBody: `{"config":{"accessType":"TRUSTED"}}`},
+		{access: "PUBLIC", wantErr: "invalid access_type"},
+	}
+	for _, tt := range tests {
+		var body string
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /v2/spaces", func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			body = strings.TrimSpace(string(data))
+			replyJSON(spaceJSON)(w, r)
+		})
+
+		res, out, err := createCreateSpaceHandler(fakeFactory(mux))(context.Background(), &mcp.CallToolRequest{}, CreateSpaceInput{UserEmail: user, AccessType: tt.access})
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("access %q: error = %v, want %q", tt.access, err, tt.wantErr)
+			}
+			if body != "" {
+				t.Errorf("access %q: invalid input reached the API", tt.access)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("access %q: handler error = %v", tt.access, err)
+		}
+		if body != tt.wantBody {
+			t.Errorf("access %q: request body = %s, want %s", tt.access, body, tt.wantBody)
+		}
+		if out.MeetingCode != "abc-mnop-xyz" || out.AccessType != "TRUSTED" || out.ActiveConference != "conferenceRecords/r1" {
+			t.Errorf("access %q: output = %+v", tt.access, out)
+		}
+		if text := resultText(res); !strings.Contains(text, "Meeting link: https://meet.google.com/abc-mnop-xyz") || !strings.Contains(text, "Active conference: conferenceRecords/r1") {
+			t.Errorf("access %q: text = %q", tt.access, text)
+		}
+	}
+}
+
+func TestGetSpaceHandler(t *testing.T) {
+	var got []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/spaces/{id}", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.PathValue("id"))
+		replyJSON(spaceJSON)(w, r)
+	})
+	factory := fakeFactory(mux)
+
+	for _, space := range []string{"abc-mnop-xyz", "spaces/abc"} {
+		res, out, err := createGetSpaceHandler(factory)(context.Background(), &mcp.CallToolRequest{}, GetSpaceInput{UserEmail: user, Space: space})
+		if err != nil {
+			t.Fatalf("space %q: handler error = %v", space, err)
+		}
+		if out.Name != "spaces/abc" || !strings.Contains(resultText(res), "Meet Space") {
+			t.Errorf("space %q: output = %+v, text = %q", space, out, resultText(res))
+		}
+	}
+	if strings.Join(got, ",") != "abc-mnop-xyz,abc" {
+		t.Errorf("requested spaces = %v, want the meeting code and the bare ID", got)
+	}
+}
+
+func TestListConferenceRecordsHandler(t *testing.T) {
+	var query string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/conferenceRecords", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("filter") + " pageSize=" + r.URL.Query().Get("pageSize")
+		replyJSON(`{"conferenceRecords":[
+			{"name":"conferenceRecords/r1","space":"spaces/abc","startTime":"2026-06-02T09:00:00Z","endTime":"2026-06-02T10:00:00Z"},
+			{"name":"conferenceRecords/r2","space":"spaces/abc","startTime":"2026-06-03T09:00:00Z"}
+		],"nextPageToken":"c2"}`)(w, r)
+	})
+	factory := fakeFactory(mux)
+
+	res, out, err := createListConferenceRecordsHandler(factory)(context.Background(), &mcp.CallToolRequest{}, ListConferenceRecordsInput{
+		UserEmail:   user,
+		MeetingCode: "abc-mnop-xyz",
+		StartAfter:  "2026-06-01T00:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if want := `space.meeting_code = "abc-mnop-xyz" AND start_time >= "2026-06-01T00:00:00Z" pageSize=10`; query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+	if len(out.Conferences) != 2 || out.Conferences[1].EndTime != "" || out.NextPageToken != "c2" {
+		t.Errorf("output = %+v", out)
+	}
+	text := resultText(res)
+	for _, want := range []string{"2026-06-02T09:00:00Z → 2026-06-02T10:00:00Z", "Started: 2026-06-03T09:00:00Z (in progress)", "Next page token: c2"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text missing %q:\n%s", want, text)
+		}
+	}
+
+	query = ""
+	_, _, err = createListConferenceRecordsHandler(factory)(context.Background(), &mcp.CallToolRequest{}, ListConferenceRecordsInput{UserEmail: user, StartBefore: "yesterday"})
+	if err == nil || !strings.Contains(err.Error(), "invalid start_before") {
+		t.Errorf("invalid start_before error = %v", err)
+	}
+	if query != "" {
+		t.Error("invalid start_before reached the API")
+	}
+}
+
+func TestListRecordingsHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/conferenceRecords/{id}/recordings", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "empty" {
+			replyJSON(`{}`)(w, r)
+			return
+		}
+		if r.URL.Query().Get("pageToken") == "" {
+			replyJSON(`{"recordings":[{"name":"conferenceRecords/r1/recordings/a","state":"FILE_GENERATED","startTime":"09:00","endTime":"10:00",
+				"driveDestination":{"file":"f1","exportUri":"https://drive.google.com/f1"}}],"nextPageToken":"p2"}`)(w, r)
+			return
+		}
+		replyJSON(`{"recordings":[{"name":"conferenceRecords/r1/recordings/b","state":"STARTED"}]}`)(w, r)
+	})
+	factory := fakeFactory(mux)
+
+	res, out, err := createListRecordingsHandler(factory)(context.Background(), &mcp.CallToolRequest{}, ListRecordingsInput{UserEmail: user, ConferenceRecord: "r1"})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if len(out.Recordings) != 2 || out.Recordings[0].FileID != "f1" || out.Recordings[1].State != "STARTED" {
+		t.Errorf("output = %+v, want both pages", out)
+	}
+	if text := resultText(res); !strings.Contains(text, "Play: https://drive.google.com/f1") || !strings.Contains(text, "Count: 2") {
+		t.Errorf("text = %q", text)
+	}
+
+	res, out, err = createListRecordingsHandler(factory)(context.Background(), &mcp.CallToolRequest{}, ListRecordingsInput{UserEmail: user, ConferenceRecord: "conferenceRecords/empty"})
+	if err != nil {
+		t.Fatalf("empty: handler error = %v", err)
+	}
+	if out.Recordings == nil || len(out.Recordings) != 0 || !strings.Contains(resultText(res), "No recordings") {
+		t.Errorf("empty: output = %#v, text = %q", out, resultText(res))
+	}
+}
+
+func TestGetTranscriptHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/conferenceRecords/{id}/transcripts", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "silent" {
+			replyJSON(`{}`)(w, r)
+			return
+		}
+		replyJSON(`{"transcripts":[{"name":"conferenceRecords/r1/transcripts/t1","state":"FILE_GENERATED","docsDestination":{"exportUri":"https://docs.google.com/t1"}}]}`)(w, r)
+	})
+	mux.HandleFunc("GET /v2/conferenceRecords/r1/transcripts/t1/entries", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pageSize") != "100" {
+			t.Errorf("entries pageSize = %q, want 100", r.URL.Query().Get("pageSize"))
+		}
+		replyJSON(`{"transcriptEntries":[
+			{"participant":"conferenceRecords/r1/participants/p1","text":"Hello","startTime":"09:00:01"},
+			{"participant":"conferenceRecords/r1/participants/p9","text":"Hi","startTime":"09:00:05"}
+		],"nextPageToken":"e2"}`)(w, r)
+	})
+	mux.HandleFunc("GET /v2/conferenceRecords/r1/participants", replyJSON(`{"participants":[{"name":"conferenceRecords/r1/participants/p1","signedinUser":{"displayName":"Ada"}}]}`))
+	factory := fakeFactory(mux)
+
+	res, out, err := createGetTranscriptHandler(factory)(context.Background(), &mcp.CallToolRequest{}, GetTranscriptInput{UserEmail: user, ConferenceRecord: "r1"})
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if out.Transcript != "conferenceRecords/r1/transcripts/t1" || out.DocumentURI != "https://docs.google.com/t1" || out.NextPageToken != "e2" {
+		t.Errorf("output = %+v", out)
+	}
+	if len(out.Entries) != 2 || out.Entries[0].Speaker != "Ada" || out.Entries[1].Speaker != "conferenceRecords/r1/participants/p9" {
+		t.Errorf("entries = %+v, want named and unknown speakers", out.Entries)
+	}
+	if text := resultText(res); !strings.Contains(text, "[09:00:01] Ada: Hello") || !strings.Contains(text, "Document: https://docs.google.com/t1") {
+		t.Errorf("text = %q", text)
+	}
+
+	_, _, err = createGetTranscriptHandler(factory)(context.Background(), &mcp.CallToolRequest{}, GetTranscriptInput{UserEmail: user, ConferenceRecord: "silent"})
+	if err == nil || !strings.Contains(err.Error(), "has no transcript") {
+		t.Errorf("no transcript error = %v", err)
+	}
+}
+
+func TestMeetHandlersAPIErrors(t *testing.T) {
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	handlers := map[string]func(*services.Factory) error{
+		"create_meet_space": func(f *services.Factory) error {
+			_, _, err := createCreateSpaceHandler(f)(ctx, req, CreateSpaceInput{UserEmail: user})
+			return err
+		},
+		"get_meet_space": func(f *services.Factory) error {
+			_, _, err := createGetSpaceHandler(f)(ctx, req, GetSpaceInput{UserEmail: user, Space: "abc"})
+			return err
+		},
+		"list_conference_records": func(f *services.Factory) error {
+			_, _, err := createListConferenceRecordsHandler(f)(ctx, req, ListConferenceRecordsInput{UserEmail: user})
+			return err
+		},
+		"list_meet_recordings": func(f *services.Factory) error {
+			_, _, err := createListRecordingsHandler(f)(ctx, req, ListRecordingsInput{UserEmail: user, ConferenceRecord: "r1"})
+			return err
+		},
+		"get_meet_transcript": func(f *services.Factory) error {
+			_, _, err := createGetTranscriptHandler(f)(ctx, req, GetTranscriptInput{UserEmail: user, ConferenceRecord: "r1"})
+			return err
+		},
+	}
+	statuses := []struct {
+		code int
+		want string
+	}{
+		{http.StatusForbidden, "permission denied"},
+		{http.StatusNotFound, "resource not found"},
+	}
+	for _, st := range statuses {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", replyError(st.code, "The caller does not have permission"))
+		factory := fakeFactory(mux)
+		for name, call := range handlers {
+			if err := call(factory); err == nil || !strings.Contains(err.Error(), st.want) {
+				t.Errorf("%s on %d: error = %v, want %q", name, st.code, err, st.want)
+			}
+		}
+	}
+
+	// A failure after the transcript lookup surfaces the same way.
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/conferenceRecords/r1/transcripts/t1", replyJSON(`{"name":"conferenceRecords/r1/transcripts/t1"}`))
+	mux.HandleFunc("/", replyError(http.StatusForbidden, "The caller does not have permission"))
+	_, _, err := createGetTranscriptHandler(fakeFactory(mux))(ctx, req, GetTranscriptInput{UserEmail: user, ConferenceRecord: "r1", Transcript: "conferenceRecords/r1/transcripts/t1"})
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("entries failure error = %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package meet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	meet "google.golang.org/api/meet/v2"
+)
+
+// maxParticipantPages bounds the participant pages read to name transcript
+// speakers (250 participants per page).
+const maxParticipantPages = 4
+
+// SpaceSummary is a compact representation of a meeting space.
+type SpaceSummary struct {
+	Name             string `json:"name"`
+	MeetingURI       string `json:"meeting_uri"`
+	MeetingCode      string `json:"meeting_code"`
+	AccessType       string `json:"access_type,omitempty"`
+	ActiveConference string `json:"active_conference,omitempty"`
+}
+
+// ConferenceSummary is a compact representation of a conference record.
+type ConferenceSummary struct {
+	Name      string `json:"name"`
+	Space     string `json:"space"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time,omitempty"`
+}
+
+// RecordingSummary is a compact representation of a recording.
+type RecordingSummary struct {
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+	FileID    string `json:"file_id,omitempty"`
+	PlayURI   string `json:"play_uri,omitempty"`
+}
+
+// TranscriptLine is one transcript entry with the speaker's name.
+type TranscriptLine struct {
+	Time    string `json:"time"`
+	Speaker string `json:"speaker"`
+	Text    string `json:"text"`
+}
+
+// spaceName returns the resource name for a space ID, meeting code, or
+// resource name; the API accepts meeting codes as space aliases.
+func spaceName(id string) string {
+	if strings.HasPrefix(id, "spaces/") {
+		return id
+	}
+	return "spaces/" + id
+}
+
+// recordName returns the resource name for a conference record ID or name.
+func recordName(id string) string {
+	if strings.HasPrefix(id, "conferenceRecords/") {
+		return id
+	}
+	return "conferenceRecords/" + id
+}
+
+// conferenceFilter builds a conference record filter for an optional
+// meeting code and start time range (RFC3339).
+func conferenceFilter(meetingCode, startAfter, startBefore string) (string, error) {
+	var terms []string
+	if meetingCode != "" {
+		terms = append(terms, fmt.Sprintf("space.meeting_code = %q", meetingCode))
+	}
+	for _, bound := range []struct{ name, value, op string }{
+		{"start_after", startAfter, ">="},
+		{"start_before", startBefore, "<="},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, bound.value); err != nil {
+			return "", fmt.Errorf("invalid %s %q — expected RFC3339 (e.g. 2025-06-02T00:00:00Z): %w", bound.name, bound.value, err)
+		}
+		terms = append(terms, fmt.Sprintf("start_time %s %q", bound.op, bound.value))
+	}
+	return strings.Join(terms, " AND "), nil
+}
+
+func spaceToSummary(s *meet.Space) SpaceSummary {
+	ss := SpaceSummary{Name: s.Name, MeetingURI: s.MeetingUri, MeetingCode: s.MeetingCode}
+	if s.Config != nil {
+		ss.AccessType = s.Config.AccessType
+	}
+	if s.ActiveConference != nil {
+		ss.ActiveConference = s.ActiveConference.ConferenceRecord
+	}
+	return ss
+}
+
+func recordingToSummary(r *meet.Recording) RecordingSummary {
+	rs := RecordingSummary{Name: r.Name, State: r.State, StartTime: r.StartTime, EndTime: r.EndTime}
+	if r.DriveDestination != nil {
+		rs.FileID = r.DriveDestination.File
+		rs.PlayURI = r.DriveDestination.ExportUri
+	}
+	return rs
+}
+
+// participantName returns a participant's display name.
+func participantName(p *meet.Participant) string {
+	switch {
+	case p.SignedinUser != nil:
+		return p.SignedinUser.DisplayName
+	case p.AnonymousUser != nil:
+		return p.AnonymousUser.DisplayName
+	case p.PhoneUser != nil:
+		return p.PhoneUser.DisplayName
+	}
+	return ""
+}
+
+// transcriptLines pairs entries with speaker names, falling back to the
+// participant resource name for unknown speakers.
+func transcriptLines(entries []*meet.TranscriptEntry, speakers map[string]string) []TranscriptLine {
+	lines := make([]TranscriptLine, 0, len(entries))
+	for _, e := range entries {
+		speaker := speakers[e.Participant]
+		if speaker == "" {
+			speaker = e.Participant
+		}
+		lines = append(lines, TranscriptLine{Time: e.StartTime, Speaker: speaker, Text: e.Text})
+	}
+	return lines
+}
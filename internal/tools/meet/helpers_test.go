@@ -0,0 +1,59 @@
+package meet
+
+import (
+	"testing"
+
+	meet "google.golang.org/api/meet/v2"
+)
+
+func TestConferenceFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		after   string
+		before  string
+		want    string
+		wantErr bool
+	}{
+		{"empty", "", "", "", "", false},
+		{"meeting code", "abc-mnop-xyz", "", "", `space.meeting_code = "abc-mnop-xyz"`, false},
+		{"range", "", "2026-03-01T00:00:00Z", "2026-03-08T00:00:00Z", `start_time >= "2026-03-01T00:00:00Z" AND start_time <= "2026-03-08T00:00:00Z"`, false},
+		{"code and after", "abc-mnop-xyz", "2026-03-01T00:00:00Z", "", `space.meeting_code = "abc-mnop-xyz" AND start_time >= "2026-03-01T00:00:00Z"`, false},
+		{"invalid time", "", "2026-03-01", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conferenceFilter(tt.code, tt.after, tt.before)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("conferenceFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("conferenceFilter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceNames(t *testing.T) {
+	if got := spaceName("abc-mnop-xyz"); got != "spaces/abc-mnop-xyz" {
+		t.Errorf("spaceName(code) = %q", got)
+	}
+	if got := spaceName("spaces/jQCFfuBOdN5z"); got != "spaces/jQCFfuBOdN5z" {
+		t.Errorf("spaceName(name) = %q", got)
+	}
+	if got := recordName("abc123"); got != "conferenceRecords/abc123" {
+		t.Errorf("recordName(id) = %q", got)
+	}
+}
+
+func TestTranscriptLines(t *testing.T) {
+	entries := []*meet.TranscriptEntry{
+		{Participant: "conferenceRecords/r/participants/1", StartTime: "t1", Text: "Hello"},
+		{Participant: "conferenceRecords/r/participants/2", StartTime: "t2", Text: "Hi"},
+	}
+	speakers := map[string]string{"conferenceRecords/r/participants/1": "Ada"}
+	lines := transcriptLines(entries, speakers)
+	if lines[0].Speaker != "Ada" || lines[1].Speaker != "conferenceRecords/r/participants/2" {
+		t.Errorf("transcriptLines() = %+v", lines)
+	}
+}
@@ -0,0 +1,77 @@
+// Package meet implements Google Meet tools for meeting spaces and the
+// records of past conferences: recordings and transcripts.
+package meet
+
+import (
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/ptr"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+var serviceIcons = []mcp.Icon{{
+	Source:   "https://www.gstatic.com/images/branding/product/1x/meet_48dp.png",
+	MIMEType: "image/png",
+	Sizes:    []string{"48x48"},
+}}
+
+// Register registers all Meet tools (core + extended) with the MCP server.
+func Register(server *mcp.Server, factory *services.Factory) {
+	// --- Core tools (3) ---
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_meet_space",
+		Icons:       serviceIcons,
+		Description: "Create a Google Meet meeting space and return its join link and meeting code. For a meeting tied to a calendar event, use create_event with add_google_meet instead.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Create Meet Space",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createCreateSpaceHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_conference_records",
+		Icons:       serviceIcons,
+		Description: "List past Google Meet conferences the user organized, newest first, optionally for one meeting code or time range. Records are kept for 30 days after a conference ends.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Conference Records",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListConferenceRecordsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_meet_transcript",
+		Icons:       serviceIcons,
+		Description: "Get the transcript of a past Google Meet conference as timestamped entries with speaker names. Requires that transcription was turned on during the meeting.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Meet Transcript",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetTranscriptHandler(factory))
+
+	// --- Extended tools (2) ---
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_meet_space",
+		Icons:       serviceIcons,
+		Description: "Get a Google Meet space by resource name or meeting code: join link, access type, and any active conference.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Get Meet Space",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createGetSpaceHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_meet_recordings",
+		Icons:       serviceIcons,
+		Description: "List the recordings of a past Google Meet conference with their state and Drive playback links.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "List Meet Recordings",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createListRecordingsHandler(factory))
+}
@@ -0,0 +1,45 @@
+// Package output implements the continue_output MCP tool, which reads the
+// rest of tool output cut off by the server's output budget. It is
+// registered only when MAX_OUTPUT_KB is set.
+package output
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/ptr"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+)
+
+// Register registers the continue_output tool with the MCP server.
+func Register(server *mcp.Server, overflow *response.Overflow) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        response.ContinueTool,
+		Description: "Read the next part of a tool result that was truncated to fit the output budget. Pass the token from the truncation notice; the returned part ends with a new token while more remains. Tokens are valid for 30 minutes in the session that received them.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Continue Truncated Output",
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(false),
+		},
+	}, createContinueHandler(overflow))
+}
+
+type ContinueInput struct {
+	Token string `json:"token" jsonschema:"required" jsonschema_description:"The continuation token from the truncation notice"`
+}
+
+func createContinueHandler(overflow *response.Overflow) mcp.ToolHandlerFor[ContinueInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ContinueInput) (*mcp.CallToolResult, any, error) {
+		var sessionID string
+		if req != nil && req.Session != nil {
+			sessionID = req.Session.ID()
+		}
+		text, err := overflow.Next(sessionID, input.Token)
+		if err != nil {
+			return nil, nil, err
+		}
+		return response.New().Raw(text).TextResult(), nil, nil
+	}
+}
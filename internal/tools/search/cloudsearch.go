@@ -0,0 +1,169 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/cloudsearch/v1"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// workspaceSources maps the source names accepted by search_workspace to
+// Cloud Search predefined sources.
+var workspaceSources = map[string]string{
+	"gmail":    "GOOGLE_GMAIL",
+	"drive":    "GOOGLE_DRIVE",
+	"sites":    "GOOGLE_SITES",
+	"calendar": "GOOGLE_CALENDAR",
+	"groups":   "GOOGLE_GROUPS",
+	"keep":     "GOOGLE_KEEP",
+}
+
+// sourceRestrictions converts source names to Cloud Search restrictions.
+// No names searches every source of the search application.
+func sourceRestrictions(sources []string) ([]*cloudsearch.DataSourceRestriction, error) {
+	var restrictions []*cloudsearch.DataSourceRestriction
+	for _, s := range sources {
+		predefined, ok := workspaceSources[strings.ToLower(strings.TrimSpace(s))]
+		if !ok {
+			return nil, fmt.Errorf("invalid source %q — use gmail, drive, sites, calendar, groups, or keep", s)
+		}
+		restrictions = append(restrictions, &cloudsearch.DataSourceRestriction{
+			Source: &cloudsearch.Source{PredefinedSource: predefined},
+		})
+	}
+	return restrictions, nil
+}
+
+// searchApplicationID normalizes a search application name or ID.
+func searchApplicationID(app string) string {
+	if strings.HasPrefix(app, "searchapplications/") {
+		return app
+	}
+	return "searchapplications/" + app
+}
+
+// WorkspaceResult is one Cloud Search result.
+type WorkspaceResult struct {
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Snippet    string `json:"snippet,omitempty"`
+	Source     string `json:"source,omitempty"`
+	MimeType   string `json:"mime_type,omitempty"`
+	Owner      string `json:"owner,omitempty"`
+	UpdateTime string `json:"update_time,omitempty"`
+}
+
+func workspaceResult(r *cloudsearch.SearchResult) WorkspaceResult {
+	wr := WorkspaceResult{Title: r.Title, URL: r.Url}
+	if r.Snippet != nil {
+		wr.Snippet = r.Snippet.Snippet
+	}
+	if m := r.Metadata; m != nil {
+		wr.MimeType, wr.UpdateTime = m.MimeType, m.UpdateTime
+		if m.Source != nil {
+			wr.Source = m.Source.PredefinedSource
+			if wr.Source == "" {
+				wr.Source = m.Source.Name
+			}
+			wr.Source = strings.ToLower(strings.TrimPrefix(wr.Source, "GOOGLE_"))
+		}
+		if m.Owner != nil && len(m.Owner.PersonNames) > 0 {
+			wr.Owner = m.Owner.PersonNames[0].DisplayName
+		}
+	}
+	return wr
+}
+
+// --- search_workspace (core) ---
+
+type SearchWorkspaceInput struct {
+	UserEmail string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	Query     string   `json:"query" jsonschema:"required" jsonschema_description:"The search query (supports Cloud Search operators)"`
+	Sources   []string `json:"sources,omitempty" jsonschema_description:"Only these sources: gmail drive sites calendar groups keep (default: all)"`
+	PageSize  int      `json:"page_size,omitempty" jsonschema_description:"Maximum results to return (default 10, max 100)"`
+	Start     int      `json:"start,omitempty" jsonschema_description:"Zero-based index of the first result, for pagination"`
+	TimeZone  string   `json:"time_zone,omitempty" jsonschema_description:"IANA time zone for date queries (e.g. Europe/Zurich)"`
+}
+
+type SearchWorkspaceOutput struct {
+	Results       []WorkspaceResult `json:"results"`
+	EstimateCount int64             `json:"estimated_count,omitempty"`
+	NextStart     int               `json:"next_start,omitempty"`
+}
+
+func createSearchWorkspaceHandler(factory *services.Factory, app string) mcp.ToolHandlerFor[SearchWorkspaceInput, SearchWorkspaceOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SearchWorkspaceInput) (*mcp.CallToolResult, SearchWorkspaceOutput, error) {
+		if app == "" {
+			return nil, SearchWorkspaceOutput{}, fmt.Errorf("GOOGLE_CLOUD_SEARCH_APP environment variable is not set - set it to a Cloud Search search application ID (or \"default\") to enable Workspace search")
+		}
+		if input.PageSize == 0 {
+			input.PageSize = 10
+		}
+		restrictions, err := sourceRestrictions(input.Sources)
+		if err != nil {
+			return nil, SearchWorkspaceOutput{}, err
+		}
+
+		srv, err := factory.CloudSearch(ctx, input.UserEmail)
+		if err != nil {
+			return nil, SearchWorkspaceOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+
+		result, err := srv.Query.Search(&cloudsearch.SearchRequest{
+			Query:                  input.Query,
+			PageSize:               int64(input.PageSize),
+			Start:                  int64(input.Start),
+			DataSourceRestrictions: restrictions,
+			RequestOptions: &cloudsearch.RequestOptions{
+				SearchApplicationId: searchApplicationID(app),
+				TimeZone:            input.TimeZone,
+			},
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, SearchWorkspaceOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		if result.ErrorInfo != nil && len(result.ErrorInfo.ErrorMessages) > 0 {
+			return nil, SearchWorkspaceOutput{}, fmt.Errorf("cloud search: %s", result.ErrorInfo.ErrorMessages[0].ErrorMessage)
+		}
+
+		out := SearchWorkspaceOutput{
+			Results:       make([]WorkspaceResult, 0, len(result.Results)),
+			EstimateCount: result.ResultCountEstimate,
+		}
+		if result.ResultCountExact > 0 {
+			out.EstimateCount = result.ResultCountExact
+		}
+		if result.HasMoreResults {
+			out.NextStart = input.Start + len(result.Results)
+		}
+
+		rb := response.New()
+		rb.Header("Workspace Search Results")
+		rb.KeyValue("Query", input.Query)
+		rb.KeyValue("Results", len(result.Results))
+		if out.EstimateCount > 0 {
+			rb.KeyValue("Estimated total", out.EstimateCount)
+		}
+		if out.NextStart > 0 {
+			rb.KeyValue("Next start", out.NextStart)
+		}
+		rb.Blank()
+		for _, r := range result.Results {
+			wr := workspaceResult(r)
+			out.Results = append(out.Results, wr)
+			rb.Item("[%s] %s", wr.Source, wr.Title)
+			rb.Line("    %s", wr.URL)
+			if wr.Snippet != "" {
+				rb.Line("    %s", wr.Snippet)
+			}
+		}
+
+		return rb.TextResult(), out, nil
+	}
+}
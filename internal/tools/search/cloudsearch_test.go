@@ -0,0 +1,47 @@
+package search
+
+import (
+	"testing"
+
+	"google.golang.org/api/cloudsearch/v1"
+)
+
+func TestSourceRestrictions(t *testing.T) {
+	got, err := sourceRestrictions([]string{"Gmail", " drive "})
+	if err != nil {
+		t.Fatalf("sourceRestrictions() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Source.PredefinedSource != "GOOGLE_GMAIL" || got[1].Source.PredefinedSource != "GOOGLE_DRIVE" {
+		t.Errorf("sourceRestrictions() = %+v", got)
+	}
+	if none, _ := sourceRestrictions(nil); none != nil {
+		t.Errorf("sourceRestrictions(nil) = %+v, want nil", none)
+	}
+	if _, err := sourceRestrictions([]string{"web"}); err == nil {
+		t.Error("sourceRestrictions(web) succeeded, want error")
+	}
+}
+
+func TestSearchApplicationID(t *testing.T) {
+	if got := searchApplicationID("default"); got != "searchapplications/default" {
+		t.Errorf("searchApplicationID(default) = %q", got)
+	}
+	if got := searchApplicationID("searchapplications/abc"); got != "searchapplications/abc" {
+		t.Errorf("searchApplicationID(name) = %q", got)
+	}
+}
+
+func TestWorkspaceResult(t *testing.T) {
+	r := workspaceResult(&cloudsearch.SearchResult{
+		Title:   "Q3 plan",
+		Url:     "https://docs.google.com/document/d/1",
+		Snippet: &cloudsearch.Snippet{Snippet: "the plan"},
+		Metadata: &cloudsearch.Metadata{
+			Source: &cloudsearch.Source{PredefinedSource: "GOOGLE_DRIVE"},
+			Owner:  &cloudsearch.Person{PersonNames: []*cloudsearch.Name{{DisplayName: "Ada"}}},
+		},
+	})
+	if r.Source != "drive" || r.Owner != "Ada" || r.Snippet != "the plan" {
+		t.Errorf("workspaceResult() = %+v", r)
+	}
+}
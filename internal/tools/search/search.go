@@ -13,9 +13,10 @@ var serviceIcons = []mcp.Icon{{
 	Sizes:    []string{"48x48"},
 }}
 
-// Register registers all Custom Search tools (core + extended + complete) with the MCP server.
-// The cseID parameter is the Google Custom Search Engine ID from the GOOGLE_CSE_ID env var.
-func Register(server *mcp.Server, factory *services.Factory, cseID string) {
+// Register registers all search tools (core + extended + complete) with the MCP server.
+// The cseID parameter is the Google Custom Search Engine ID from the GOOGLE_CSE_ID env var;
+// cloudSearchApp is the Cloud Search search application from GOOGLE_CLOUD_SEARCH_APP.
+func Register(server *mcp.Server, factory *services.Factory, cseID, cloudSearchApp string) {
 	// --- Core tools ---
 
 	mcp.AddTool(server, &mcp.Tool{
@@ -29,6 +30,17 @@ func Register(server *mcp.Server, factory *services.Factory, cseID string) {
 		},
 	}, createSearchCustomHandler(factory, cseID))
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "search_workspace",
+		Icons:       serviceIcons,
+		Description: "Search the organization's Google Workspace content (Gmail, Drive, Sites, Calendar, Groups, Keep) in one ranked query using Cloud Search. Requires a Cloud Search license and GOOGLE_CLOUD_SEARCH_APP to be configured.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Search Workspace",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createSearchWorkspaceHandler(factory, cloudSearchApp))
+
 	// --- Extended tools ---
 
 	mcp.AddTool(server, &mcp.Tool{
@@ -3,7 +3,6 @@ package sheets
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"google.golang.org/api/sheets/v4"
@@ -50,6 +49,7 @@ func createCreateSpreadsheetHandler(factory *services.Factory) mcp.ToolHandlerFo
 		if err != nil {
 			return nil, nil, middleware.HandleGoogleAPIError(err)
 		}
+		factory.StampDriveFile(ctx, input.UserEmail, created.SpreadsheetId, factory.Provenance(req))
 
 		rb := response.New()
 		rb.Header("Spreadsheet Created")
@@ -72,49 +72,84 @@ func createCreateSpreadsheetHandler(factory *services.Factory) mcp.ToolHandlerFo
 // --- read_sheet_values ---
 
 type ReadSheetValuesInput struct {
-	UserEmail     string `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
-	SpreadsheetID string `json:"spreadsheet_id" jsonschema:"required" jsonschema_description:"The ID of the spreadsheet"`
-	RangeName     string `json:"range_name,omitempty" jsonschema_description:"Range to read (e.g. Sheet1!A1:D10). Default: A1:Z1000"`
+	UserEmail         string   `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	SpreadsheetID     string   `json:"spreadsheet_id" jsonschema:"required" jsonschema_description:"The ID of the spreadsheet"`
+	RangeName         string   `json:"range_name,omitempty" jsonschema_description:"Range to read (e.g. Sheet1!A1:D10). Default: A1:Z1000"`
+	Ranges            []string `json:"ranges,omitempty" jsonschema_description:"Several ranges to read in one request, such as [\"Sheet1!A1:B5\", \"Totals!C1:C3\"]; read after range_name when both are set"`
+	ValueRenderOption string   `json:"value_render_option,omitempty" jsonschema_description:"How to render values: FORMATTED_VALUE (default, as displayed) UNFORMATTED_VALUE (numbers and booleans as typed values) FORMULA (formulas instead of results)"`
+	MajorDimension    string   `json:"major_dimension,omitempty" jsonschema_description:"ROWS (default) returns one array per row; COLUMNS returns one array per column"`
 }
 
 type ReadSheetValuesOutput struct {
-	Values [][]interface{} `json:"values"`
-	Range  string          `json:"range"`
+	Values [][]interface{}   `json:"values"`
+	Range  string            `json:"range"`
+	Ranges []SheetValueRange `json:"ranges,omitempty"`
 }
 
 func createReadSheetValuesHandler(factory *services.Factory) mcp.ToolHandlerFor[ReadSheetValuesInput, ReadSheetValuesOutput] {
 	return func(ctx context.Context, req *mcp.CallToolRequest, input ReadSheetValuesInput) (*mcp.CallToolResult, ReadSheetValuesOutput, error) {
-		srv, err := factory.Sheets(ctx, input.UserEmail)
+		render, dimension, err := parseReadOptions(input.ValueRenderOption, input.MajorDimension)
 		if err != nil {
-			return nil, ReadSheetValuesOutput{}, middleware.HandleGoogleAPIError(err)
+			return nil, ReadSheetValuesOutput{}, err
+		}
+		ranges := input.Ranges
+		if input.RangeName != "" || len(ranges) == 0 {
+			rangeName := input.RangeName
+			if rangeName == "" {
+				rangeName = "A1:Z1000"
+			}
+			ranges = append([]string{rangeName}, ranges...)
 		}
 
-		rangeName := input.RangeName
-		if rangeName == "" {
-			rangeName = "A1:Z1000"
+		srv, err := factory.Sheets(ctx, input.UserEmail)
+		if err != nil {
+			return nil, ReadSheetValuesOutput{}, middleware.HandleGoogleAPIError(err)
 		}
 
-		result, err := srv.Spreadsheets.Values.Get(input.SpreadsheetID, rangeName).Context(ctx).Do()
+		// Unformatted dates would otherwise come back as serial numbers.
+		result, err := srv.Spreadsheets.Values.BatchGet(input.SpreadsheetID).
+			Ranges(ranges...).
+			ValueRenderOption(render).
+			DateTimeRenderOption("FORMATTED_STRING").
+			MajorDimension(dimension).
+			Context(ctx).Do()
 		if err != nil {
 			return nil, ReadSheetValuesOutput{}, middleware.HandleGoogleAPIError(err)
 		}
 
+		var out ReadSheetValuesOutput
+		for _, vr := range result.ValueRanges {
+			out.Ranges = append(out.Ranges, SheetValueRange{Range: vr.Range, MajorDimension: dimension, Values: vr.Values})
+		}
+		if len(out.Ranges) > 0 {
+			out.Values, out.Range = out.Ranges[0].Values, out.Ranges[0].Range
+		}
+
 		rb := response.New()
 		rb.Header("Sheet Values")
 		rb.KeyValue("Spreadsheet", input.SpreadsheetID)
-		rb.KeyValue("Range", result.Range)
-		rb.KeyValue("Rows", len(result.Values))
-		rb.Blank()
-
-		for i, row := range result.Values {
-			cells := make([]string, 0, len(row))
-			for _, cell := range row {
-				cells = append(cells, fmt.Sprintf("%v", cell))
+		if render != "FORMATTED_VALUE" {
+			rb.KeyValue("Render", render)
+		}
+		for _, vr := range out.Ranges {
+			rb.Blank()
+			rb.Section("%s", vr.Range)
+			if dimension == "COLUMNS" {
+				rb.KeyValue("Columns", len(vr.Values))
+			} else {
+				rb.KeyValue("Rows", len(vr.Values))
+			}
+			if len(vr.Values) == 0 {
+				rb.Line("(empty)")
+				continue
+			}
+			rb.Blank()
+			for _, line := range valueTable(vr) {
+				rb.Line("%s", line)
 			}
-			rb.Line("Row %d: %s", i+1, strings.Join(cells, " | "))
 		}
 
-		return rb.TextResult(), ReadSheetValuesOutput{Values: result.Values, Range: result.Range}, nil
+		return rb.TextResult(), out, nil
 	}
 }
 
@@ -0,0 +1,297 @@
+package sheets
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
+	"github.com/evert/google-workspace-mcp-go/internal/services"
+)
+
+// --- query_rows (extended) ---
+
+type QueryRowsInput struct {
+	UserEmail     string         `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	SpreadsheetID string         `json:"spreadsheet_id" jsonschema:"required" jsonschema_description:"The spreadsheet ID"`
+	SheetName     string         `json:"sheet_name,omitempty" jsonschema_description:"Sheet tab holding the table, with column names in row 1 (default: first sheet)"`
+	Where         map[string]any `json:"where,omitempty" jsonschema_description:"Column name to value; only rows whose cells equal every value are returned (default: all rows)"`
+	Columns       []string       `json:"columns,omitempty" jsonschema_description:"Columns to return (default: all)"`
+	Limit         int            `json:"limit,omitempty" jsonschema_description:"Maximum rows to return (default 100)"`
+}
+
+type QueryRowsOutput struct {
+	Sheet   string     `json:"sheet"`
+	Columns []string   `json:"columns"`
+	Rows    []TableRow `json:"rows"`
+	Matched int        `json:"matched"`
+}
+
+func createQueryRowsHandler(factory *services.Factory) mcp.ToolHandlerFor[QueryRowsInput, QueryRowsOutput] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input QueryRowsInput) (*mcp.CallToolResult, QueryRowsOutput, error) {
+		if input.Limit <= 0 {
+			input.Limit = 100
+		}
+		srv, err := factory.Sheets(ctx, input.UserEmail)
+		if err != nil {
+			return nil, QueryRowsOutput{}, middleware.HandleGoogleAPIError(err)
+		}
+		t, err := loadTable(ctx, srv, input.SpreadsheetID, input.SheetName)
+		if err != nil {
+			return nil, QueryRowsOutput{}, err
+		}
+		matched, err := t.match(input.Where)
+		if err != nil {
+			return nil, QueryRowsOutput{}, err
+		}
+		columns := t.columnNames()
+		if len(input.Columns) > 0 {
+			if columns, err = t.selectColumns(input.Columns); err != nil {
+				return nil, QueryRowsOutput{}, err
+			}
+		}
+
+		out := QueryRowsOutput{Sheet: t.sheet.Title, Columns: columns, Rows: []TableRow{}, Matched: len(matched)}
+		for _, r := range matched[:min(len(matched), input.Limit)] {
+			out.Rows = append(out.Rows, t.project(r, columns))
+		}
+
+		rb := response.New()
+		rb.Header("Table Rows")
+		rb.KeyValue("Sheet", out.Sheet)
+		rb.KeyValue("Matched", out.Matched)
+		if len(out.Rows) < out.Matched {
+			rb.KeyValue("Returned", fmt.Sprintf("%d (raise limit for more)", len(out.Rows)))
+		}
+		rb.KeyValue("Columns", strings.Join(columns, " | "))
+		rb.Blank()
+		for _, row := range out.Rows {
+			cells := make([]string, 0, len(columns))
+			for _, c := range columns {
+				cells = append(cells, cellString(row.Values[c]))
+			}
+			rb.Line("Row %d: %s", row.RowNumber, strings.Join(cells, " | "))
+		}
+		return rb.TextResult(), out, nil
+	}
+}
+
+// --- insert_row (extended) ---
+
+type InsertRowInput struct {
+	UserEmail     string         `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	SpreadsheetID string         `json:"spreadsheet_id" jsonschema:"required" jsonschema_description:"The spreadsheet ID"`
+	SheetName     string         `json:"sheet_name,omitempty" jsonschema_description:"Sheet tab holding the table, with column names in row 1 (default: first sheet)"`
+	Values        map[string]any `json:"values" jsonschema:"required" jsonschema_description:"Column name to value for the new row; unnamed columns are left empty. Values are parsed as if typed into the sheet."`
+}
+
+func createInsertRowHandler(factory *services.Factory) mcp.ToolHandlerFor[InsertRowInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input InsertRowInput) (*mcp.CallToolResult, any, error) {
+		if len(input.Values) == 0 {
+			return nil, nil, fmt.Errorf("values is required — give at least one column name and value")
+		}
+		srv, err := factory.Sheets(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		t, err := loadTable(ctx, srv, input.SpreadsheetID, input.SheetName)
+		if err != nil {
+			return nil, nil, err
+		}
+		updated, err := appendRow(ctx, srv, input.SpreadsheetID, t, input.Values)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rb := response.New()
+		rb.Header("Row Inserted")
+		rb.KeyValue("Spreadsheet", input.SpreadsheetID)
+		rb.KeyValue("Sheet", t.sheet.Title)
+		rb.KeyValue("Range", updated)
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// appendRow adds a row after the last row of t and returns the range written.
+func appendRow(ctx context.Context, srv *sheets.Service, spreadsheetID string, t *table, values map[string]any) (string, error) {
+	row, err := t.newRow(values)
+	if err != nil {
+		return "", err
+	}
+	result, err := srv.Spreadsheets.Values.Append(spreadsheetID, quoteSheet(t.sheet.Title)+"!A1", &sheets.ValueRange{
+		Values: [][]any{row},
+	}).ValueInputOption("USER_ENTERED").InsertDataOption("INSERT_ROWS").Context(ctx).Do()
+	if err != nil {
+		return "", middleware.HandleGoogleAPIError(err)
+	}
+	if result.Updates == nil {
+		return "", nil
+	}
+	return result.Updates.UpdatedRange, nil
+}
+
+// --- update_row_by_key (extended) ---
+
+type UpdateRowByKeyInput struct {
+	UserEmail     string         `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	SpreadsheetID string         `json:"spreadsheet_id" jsonschema:"required" jsonschema_description:"The spreadsheet ID"`
+	SheetName     string         `json:"sheet_name,omitempty" jsonschema_description:"Sheet tab holding the table, with column names in row 1 (default: first sheet)"`
+	KeyColumn     string         `json:"key_column" jsonschema:"required" jsonschema_description:"Column that identifies the row, such as ID or Email"`
+	KeyValue      any            `json:"key_value" jsonschema:"required" jsonschema_description:"Value of key_column in the row to update; exactly one row must match"`
+	Values        map[string]any `json:"values" jsonschema:"required" jsonschema_description:"Column name to new value; other cells are left unchanged"`
+	Upsert        bool           `json:"upsert,omitempty" jsonschema_description:"Insert a new row when no row matches the key (default false)"`
+}
+
+func createUpdateRowByKeyHandler(factory *services.Factory) mcp.ToolHandlerFor[UpdateRowByKeyInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateRowByKeyInput) (*mcp.CallToolResult, any, error) {
+		if len(input.Values) == 0 {
+			return nil, nil, fmt.Errorf("values is required — give at least one column name and value")
+		}
+		srv, err := factory.Sheets(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		t, err := loadTable(ctx, srv, input.SpreadsheetID, input.SheetName)
+		if err != nil {
+			return nil, nil, err
+		}
+		matched, err := t.match(map[string]any{input.KeyColumn: input.KeyValue})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rb := response.New()
+		switch {
+		case len(matched) == 0 && input.Upsert:
+			inserted, err := upsertRow(ctx, srv, input, t)
+			if err != nil {
+				return nil, nil, err
+			}
+			rb.Header("Row Inserted")
+			rb.KeyValue("Range", inserted)
+		case len(matched) == 0:
+			return nil, nil, fmt.Errorf("no row where %s = %v — check the key, or pass upsert=true to insert it", input.KeyColumn, input.KeyValue)
+		case len(matched) > 1:
+			return nil, nil, fmt.Errorf("%d rows have %s = %v (rows %s) — the key must be unique; nothing was updated",
+				len(matched), input.KeyColumn, input.KeyValue, rowNumbers(matched))
+		default:
+			if err := updateCells(ctx, srv, input.SpreadsheetID, t, matched[0]+2, input.Values); err != nil {
+				return nil, nil, err
+			}
+			rb.Header("Row Updated")
+			rb.KeyValue("Row", matched[0]+2)
+			rb.KeyValue("Columns", len(input.Values))
+		}
+		rb.KeyValue("Spreadsheet", input.SpreadsheetID)
+		rb.KeyValue("Sheet", t.sheet.Title)
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// upsertRow appends the update's values plus its key as a new row.
+func upsertRow(ctx context.Context, srv *sheets.Service, input UpdateRowByKeyInput, t *table) (string, error) {
+	values := make(map[string]any, len(input.Values)+1)
+	for k, v := range input.Values {
+		values[k] = v
+	}
+	values[input.KeyColumn] = input.KeyValue
+	return appendRow(ctx, srv, input.SpreadsheetID, t, values)
+}
+
+// updateCells writes values to their columns in sheet row n.
+func updateCells(ctx context.Context, srv *sheets.Service, spreadsheetID string, t *table, n int, values map[string]any) error {
+	data, err := t.cellUpdates(n, values)
+	if err != nil {
+		return err
+	}
+	_, err = srv.Spreadsheets.Values.BatchUpdate(spreadsheetID, &sheets.BatchUpdateValuesRequest{
+		ValueInputOption: "USER_ENTERED",
+		Data:             data,
+	}).Context(ctx).Do()
+	if err != nil {
+		return middleware.HandleGoogleAPIError(err)
+	}
+	return nil
+}
+
+// --- delete_rows_where (extended) ---
+
+type DeleteRowsWhereInput struct {
+	UserEmail     string         `json:"user_google_email" jsonschema:"required" jsonschema_description:"The user's Google email address"`
+	SpreadsheetID string         `json:"spreadsheet_id" jsonschema:"required" jsonschema_description:"The spreadsheet ID"`
+	SheetName     string         `json:"sheet_name,omitempty" jsonschema_description:"Sheet tab holding the table, with column names in row 1 (default: first sheet)"`
+	Where         map[string]any `json:"where" jsonschema:"required" jsonschema_description:"Column name to value; rows whose cells equal every value are deleted"`
+}
+
+func createDeleteRowsWhereHandler(factory *services.Factory) mcp.ToolHandlerFor[DeleteRowsWhereInput, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DeleteRowsWhereInput) (*mcp.CallToolResult, any, error) {
+		if len(input.Where) == 0 {
+			return nil, nil, fmt.Errorf("where is required — give at least one column name and value so not every row is deleted")
+		}
+		srv, err := factory.Sheets(ctx, input.UserEmail)
+		if err != nil {
+			return nil, nil, middleware.HandleGoogleAPIError(err)
+		}
+		t, err := loadTable(ctx, srv, input.SpreadsheetID, input.SheetName)
+		if err != nil {
+			return nil, nil, err
+		}
+		matched, err := t.match(input.Where)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(matched) > 0 {
+			_, err = srv.Spreadsheets.BatchUpdate(input.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+				Requests: deleteRowRequests(t.sheet.SheetId, matched),
+			}).Context(ctx).Do()
+			if err != nil {
+				return nil, nil, middleware.HandleGoogleAPIError(err)
+			}
+		}
+
+		rb := response.New()
+		rb.Header("Rows Deleted")
+		rb.KeyValue("Spreadsheet", input.SpreadsheetID)
+		rb.KeyValue("Sheet", t.sheet.Title)
+		rb.KeyValue("Deleted", len(matched))
+		if len(matched) > 0 {
+			rb.KeyValue("Former rows", rowNumbers(matched))
+		}
+		return rb.TextResult(), nil, nil
+	}
+}
+
+// deleteRowRequests deletes the given data rows bottom-up, so earlier
+// deletions do not shift the rows still to be deleted.
+func deleteRowRequests(sheetID int64, rows []int) []*sheets.Request {
+	sorted := slices.Clone(rows)
+	slices.Sort(sorted)
+	slices.Reverse(sorted)
+	reqs := make([]*sheets.Request, 0, len(sorted))
+	for _, r := range sorted {
+		reqs = append(reqs, &sheets.Request{DeleteDimension: &sheets.DeleteDimensionRequest{
+			Range: &sheets.DimensionRange{
+				SheetId:    sheetID,
+				Dimension:  "ROWS",
+				StartIndex: int64(r + 1), // data row r is 0-based sheet row r+1
+				EndIndex:   int64(r + 2),
+			},
+		}})
+	}
+	return reqs
+}
+
+// rowNumbers formats data row indexes as sheet row numbers.
+func rowNumbers(rows []int) string {
+	nums := make([]string, 0, len(rows))
+	for _, r := range rows {
+		nums = append(nums, fmt.Sprintf("%d", r+2))
+	}
+	return strings.Join(nums, ", ")
+}
@@ -29,7 +29,7 @@ func Register(server *mcp.Server, factory *services.Factory) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "read_sheet_values",
 		Icons:       serviceIcons,
-		Description: "Read cell values from a specific range in a Google Sheet. Returns raw values in a 2D array.",
+		Description: "Read cell values from one or more A1 ranges of a Google Sheet in a single request, as displayed, unformatted, or as formulas, by rows or by columns. Returns a 2D array per range and a table labelled with column letters and row numbers.",
 		Annotations: &mcp.ToolAnnotations{
 			Title:         "Read Sheet Values",
 			ReadOnlyHint:  true,
@@ -114,6 +114,52 @@ func Register(server *mcp.Server, factory *services.Factory) {
 		},
 	}, createDeleteConditionalFormattingHandler(factory))
 
+	// --- Table tools: a sheet with a header row as a table ---
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "query_rows",
+		Icons:       serviceIcons,
+		Description: "Query a sheet as a table: row 1 holds column names. Returns matching rows keyed by column name with typed values (numbers, booleans, text) and their row numbers.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Query Table Rows",
+			ReadOnlyHint:  true,
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createQueryRowsHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "insert_row",
+		Icons:       serviceIcons,
+		Description: "Append a row to a sheet used as a table, given values by column name. Column positions are looked up from the header row.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:         "Insert Table Row",
+			OpenWorldHint: ptr.Bool(true),
+		},
+	}, createInsertRowHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_row_by_key",
+		Icons:       serviceIcons,
+		Description: "Update the cells of the one row whose key column equals a value, leaving other cells unchanged. Optionally inserts the row when the key is not found.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:          "Update Table Row by Key",
+			IdempotentHint: true,
+			OpenWorldHint:  ptr.Bool(true),
+		},
+	}, createUpdateRowByKeyHandler(factory))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_rows_where",
+		Icons:       serviceIcons,
+		Description: "Delete every row of a sheet used as a table whose cells equal the given column values. Remaining rows move up.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Delete Table Rows",
+			DestructiveHint: ptr.Bool(true),
+			IdempotentHint:  true,
+			OpenWorldHint:   ptr.Bool(true),
+		},
+	}, createDeleteRowsWhereHandler(factory))
+
 	// --- Complete tools ---
 
 	mcp.AddTool(server, &mcp.Tool{
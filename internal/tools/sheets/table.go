@@ -0,0 +1,222 @@
+package sheets
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+)
+
+// table is a sheet read as a table: row 1 holds column names and every
+// following row is a record. Cells keep their types (numbers, booleans,
+// strings) because values are read unformatted.
+type table struct {
+	sheet   *sheets.SheetProperties
+	headers []string
+	columns map[string]int // lower-cased column name to index
+	rows    [][]any        // data rows; rows[i] is sheet row i+2
+}
+
+// TableRow is one record of a table, keyed by column name.
+type TableRow struct {
+	RowNumber int            `json:"row_number"`
+	Values    map[string]any `json:"values"`
+}
+
+// loadTable reads the named sheet, or the first sheet when name is empty.
+func loadTable(ctx context.Context, srv *sheets.Service, spreadsheetID, name string) (*table, error) {
+	ss, err := srv.Spreadsheets.Get(spreadsheetID).Fields("sheets.properties").Context(ctx).Do()
+	if err != nil {
+		return nil, middleware.HandleGoogleAPIError(err)
+	}
+	props, err := findSheet(ss.Sheets, name)
+	if err != nil {
+		return nil, err
+	}
+	vr, err := srv.Spreadsheets.Values.Get(spreadsheetID, quoteSheet(props.Title)).
+		ValueRenderOption("UNFORMATTED_VALUE").
+		DateTimeRenderOption("FORMATTED_STRING").
+		Context(ctx).Do()
+	if err != nil {
+		return nil, middleware.HandleGoogleAPIError(err)
+	}
+	return newTable(props, vr.Values)
+}
+
+func findSheet(all []*sheets.Sheet, name string) (*sheets.SheetProperties, error) {
+	titles := make([]string, 0, len(all))
+	for _, s := range all {
+		if s.Properties == nil {
+			continue
+		}
+		if name == "" || s.Properties.Title == name {
+			return s.Properties, nil
+		}
+		titles = append(titles, s.Properties.Title)
+	}
+	return nil, fmt.Errorf("sheet %q not found — available sheets: %s", name, strings.Join(titles, ", "))
+}
+
+// newTable builds a table from the values of a sheet, header row first.
+func newTable(props *sheets.SheetProperties, values [][]any) (*table, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("sheet %q is empty — write the column names to row 1 first", props.Title)
+	}
+	t := &table{sheet: props, columns: make(map[string]int), rows: values[1:]}
+	for i, h := range values[0] {
+		name := strings.TrimSpace(cellString(h))
+		t.headers = append(t.headers, name)
+		if _, dup := t.columns[strings.ToLower(name)]; name != "" && !dup {
+			t.columns[strings.ToLower(name)] = i
+		}
+	}
+	if len(t.columns) == 0 {
+		return nil, fmt.Errorf("sheet %q has no column names in row 1", props.Title)
+	}
+	return t, nil
+}
+
+// column returns the index of the named column, ignoring case.
+func (t *table) column(name string) (int, error) {
+	if i, ok := t.columns[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return i, nil
+	}
+	return 0, fmt.Errorf("unknown column %q — the table's columns are: %s", name, strings.Join(t.columnNames(), ", "))
+}
+
+func (t *table) columnNames() []string {
+	names := make([]string, 0, len(t.columns))
+	for _, h := range t.headers {
+		if h != "" {
+			names = append(names, h)
+		}
+	}
+	return names
+}
+
+// selectColumns resolves requested column names to the table's spelling.
+func (t *table) selectColumns(names []string) ([]string, error) {
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		i, err := t.column(name)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t.headers[i])
+	}
+	return out, nil
+}
+
+// project returns data row i keyed by the given columns, with missing
+// cells as "".
+func (t *table) project(i int, columns []string) TableRow {
+	values := make(map[string]any, len(columns))
+	for _, name := range columns {
+		idx := t.columns[strings.ToLower(name)]
+		if idx < len(t.rows[i]) {
+			values[name] = t.rows[i][idx]
+		} else {
+			values[name] = ""
+		}
+	}
+	return TableRow{RowNumber: i + 2, Values: values}
+}
+
+// match returns the indexes of data rows whose cells equal every value in
+// where. An empty where matches every row.
+func (t *table) match(where map[string]any) ([]int, error) {
+	cols := make(map[int]string, len(where))
+	for name, want := range where {
+		i, err := t.column(name)
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = cellString(want)
+	}
+	var matched []int
+	for r, row := range t.rows {
+		ok := true
+		for i, want := range cols {
+			var got any = ""
+			if i < len(row) {
+				got = row[i]
+			}
+			if cellString(got) != want {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, r)
+		}
+	}
+	return matched, nil
+}
+
+// newRow orders values by column for appending, leaving other cells empty.
+func (t *table) newRow(values map[string]any) ([]any, error) {
+	row := make([]any, len(t.headers))
+	for i := range row {
+		row[i] = ""
+	}
+	for name, v := range values {
+		i, err := t.column(name)
+		if err != nil {
+			return nil, err
+		}
+		row[i] = v
+	}
+	return row, nil
+}
+
+// cellUpdates returns one value range per column in values for sheet row n.
+func (t *table) cellUpdates(n int, values map[string]any) ([]*sheets.ValueRange, error) {
+	names := slices.Sorted(maps.Keys(values))
+	data := make([]*sheets.ValueRange, 0, len(names))
+	for _, name := range names {
+		i, err := t.column(name)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, &sheets.ValueRange{
+			Range:  fmt.Sprintf("%s!%s%d", quoteSheet(t.sheet.Title), columnLetter(i), n),
+			Values: [][]any{{values[name]}},
+		})
+	}
+	return data, nil
+}
+
+// cellString formats a cell or filter value for comparison, so the number
+// 5 read from a sheet equals 5 or "5" in a filter.
+func cellString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case string:
+		return val
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// columnLetter converts a 0-based column index to A1 notation (0 → A, 26 → AA).
+func columnLetter(i int) string {
+	s := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		s = string(rune('A'+(i-1)%26)) + s
+	}
+	return s
+}
+
+// quoteSheet quotes a sheet title for use in an A1 range.
+func quoteSheet(title string) string {
+	return "'" + strings.ReplaceAll(title, "'", "''") + "'"
+}
@@ -0,0 +1,111 @@
+package sheets
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func testTable(t *testing.T) *table {
+	t.Helper()
+	tbl, err := newTable(&sheets.SheetProperties{Title: "Orders", SheetId: 7}, [][]any{
+		{"ID", "Customer", " Qty ", "Paid"},
+		{float64(101), "Acme", float64(5), true},
+		{float64(102), "Globex", float64(2)}, // trailing empty cell omitted by the API
+		{float64(103), "Acme", float64(2), false},
+	})
+	if err != nil {
+		t.Fatalf("newTable: %v", err)
+	}
+	return tbl
+}
+
+func TestTableMatch(t *testing.T) {
+	tbl := testTable(t)
+	tests := []struct {
+		name    string
+		where   map[string]any
+		want    []int
+		wantErr string
+	}{
+		{"all rows", nil, []int{0, 1, 2}, ""},
+		{"text", map[string]any{"Customer": "Acme"}, []int{0, 2}, ""},
+		{"number equals json number", map[string]any{"id": float64(102)}, []int{1}, ""},
+		{"number equals string", map[string]any{"qty": "2"}, []int{1, 2}, ""},
+		{"boolean", map[string]any{"Paid": false}, []int{2}, ""},
+		{"missing cell is empty", map[string]any{"Paid": ""}, []int{1}, ""},
+		{"all conditions", map[string]any{"Customer": "Acme", "Qty": float64(2)}, []int{2}, ""},
+		{"no match", map[string]any{"Customer": "Initech"}, nil, ""},
+		{"unknown column", map[string]any{"Status": "open"}, nil, `unknown column "Status" — the table's columns are: ID, Customer, Qty, Paid`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tbl.match(tt.where)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableWrites(t *testing.T) {
+	tbl := testTable(t)
+
+	row, err := tbl.newRow(map[string]any{"customer": "Initech", "ID": float64(104)})
+	if err != nil {
+		t.Fatalf("newRow: %v", err)
+	}
+	if want := []any{float64(104), "Initech", "", ""}; !slices.Equal(row, want) {
+		t.Errorf("newRow() = %v, want %v", row, want)
+	}
+
+	data, err := tbl.cellUpdates(3, map[string]any{"Qty": float64(9), "Paid": true})
+	if err != nil {
+		t.Fatalf("cellUpdates: %v", err)
+	}
+	var ranges []string
+	for _, vr := range data {
+		ranges = append(ranges, vr.Range)
+	}
+	if want := []string{"'Orders'!D3", "'Orders'!C3"}; !slices.Equal(ranges, want) {
+		t.Errorf("cellUpdates ranges = %v, want %v", ranges, want)
+	}
+
+	reqs := deleteRowRequests(tbl.sheet.SheetId, []int{0, 2})
+	if got := reqs[0].DeleteDimension.Range; got.StartIndex != 3 || got.EndIndex != 4 || got.SheetId != 7 {
+		t.Errorf("first delete = %+v, want the bottom row (index 3) first", got)
+	}
+	if got := reqs[1].DeleteDimension.Range; got.StartIndex != 1 || got.EndIndex != 2 {
+		t.Errorf("second delete = %+v, want index 1", got)
+	}
+}
+
+func TestNewTableErrors(t *testing.T) {
+	props := &sheets.SheetProperties{Title: "Empty"}
+	if _, err := newTable(props, nil); err == nil || !strings.Contains(err.Error(), "is empty") {
+		t.Errorf("empty sheet: error = %v", err)
+	}
+	if _, err := newTable(props, [][]any{{"", " "}}); err == nil || !strings.Contains(err.Error(), "no column names") {
+		t.Errorf("blank header: error = %v", err)
+	}
+}
+
+func TestColumnLetter(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"}
+	for i, want := range tests {
+		if got := columnLetter(i); got != want {
+			t.Errorf("columnLetter(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package sheets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxCellWidth bounds how many characters of a cell the text table shows;
+// the structured output always has the full value.
+const maxCellWidth = 40
+
+// valueRenderOptions are the ways the Sheets API can render read values.
+var valueRenderOptions = map[string]bool{
+	"FORMATTED_VALUE":   true,
+	"UNFORMATTED_VALUE": true,
+	"FORMULA":           true,
+}
+
+// SheetValueRange is the values of one range read from a sheet.
+type SheetValueRange struct {
+	Range          string  `json:"range"`
+	MajorDimension string  `json:"major_dimension"`
+	Values         [][]any `json:"values"`
+}
+
+// parseReadOptions validates the render option and major dimension of a
+// read, applying the API defaults when they are empty.
+func parseReadOptions(render, dimension string) (string, string, error) {
+	render = strings.ToUpper(render)
+	if render == "" {
+		render = "FORMATTED_VALUE"
+	}
+	if !valueRenderOptions[render] {
+		return "", "", fmt.Errorf("invalid value_render_option %q — use FORMATTED_VALUE, UNFORMATTED_VALUE, or FORMULA", render)
+	}
+	dimension = strings.ToUpper(dimension)
+	if dimension == "" {
+		dimension = "ROWS"
+	}
+	if dimension != "ROWS" && dimension != "COLUMNS" {
+		return "", "", fmt.Errorf("invalid major_dimension %q — use ROWS or COLUMNS", dimension)
+	}
+	return render, dimension, nil
+}
+
+// rangeOrigin returns the 0-based column and row of the top-left cell of an
+// A1 range such as 'Sheet 1'!B2:D10, defaulting to A1 for whole sheets and
+// whole columns.
+func rangeOrigin(a1 string) (col, row int) {
+	if i := strings.LastIndex(a1, "!"); i >= 0 {
+		a1 = a1[i+1:]
+	}
+	cell, _, _ := strings.Cut(a1, ":")
+	letters := strings.IndexFunc(cell, func(r rune) bool { return !unicode.IsLetter(r) })
+	if letters < 0 {
+		letters = len(cell)
+	}
+	if letters > 3 {
+		// Columns stop at XFD, so this is a sheet name without a range.
+		return 0, 0
+	}
+	for _, r := range strings.ToUpper(cell[:letters]) {
+		if r < 'A' || r > 'Z' {
+			return 0, 0
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	if col > 0 {
+		col--
+	}
+	if n, err := strconv.Atoi(cell[letters:]); err == nil && n > 0 {
+		row = n - 1
+	}
+	return col, row
+}
+
+// valueTable renders a value range as a pipe table labelled with the sheet's
+// column letters and row numbers. With COLUMNS as the major dimension each
+// line of the table is a sheet column.
+func valueTable(vr SheetValueRange) []string {
+	if len(vr.Values) == 0 {
+		return nil
+	}
+	col, row := rangeOrigin(vr.Range)
+	width := 0
+	for _, line := range vr.Values {
+		width = max(width, len(line))
+	}
+
+	// The sheet labels of line i, and of cell j within a line.
+	lineLabel := func(i int) string { return strconv.Itoa(row + i + 1) }
+	cellLabel := func(j int) string { return columnLetter(col + j) }
+	if vr.MajorDimension == "COLUMNS" {
+		lineLabel = func(i int) string { return columnLetter(col + i) }
+		cellLabel = func(j int) string { return strconv.Itoa(row + j + 1) }
+	}
+
+	header := []string{""}
+	divider := []string{"---"}
+	for j := range width {
+		header = append(header, cellLabel(j))
+		divider = append(divider, "---")
+	}
+	lines := []string{tableLine(header), tableLine(divider)}
+	for i, values := range vr.Values {
+		cells := []string{lineLabel(i)}
+		for j := range width {
+			var s string
+			if j < len(values) {
+				s = tableCell(cellString(values[j]))
+			}
+			cells = append(cells, s)
+		}
+		lines = append(lines, tableLine(cells))
+	}
+	return lines
+}
+
+func tableLine(cells []string) string {
+	return "| " + strings.Join(cells, " | ") + " |"
+}
+
+// tableCell fits a value on one table line.
+func tableCell(s string) string {
+	if r := []rune(s); len(r) > maxCellWidth {
+		s = string(r[:maxCellWidth-3]) + "..."
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\n", " "), "|", `\|`)
+}
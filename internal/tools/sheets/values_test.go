@@ -0,0 +1,76 @@
+package sheets
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRangeOrigin(t *testing.T) {
+	tests := []struct {
+		a1       string
+		col, row int
+	}{
+		{"Sheet1!A1:D10", 0, 0},
+		{"'Q1 Sales'!C5:F9", 2, 4},
+		{"AA12", 26, 11},
+		{"Sheet1!B:D", 1, 0},
+		{"Sheet1", 0, 0},
+		{"'It''s'!3:5", 0, 2},
+	}
+	for _, tt := range tests {
+		if col, row := rangeOrigin(tt.a1); col != tt.col || row != tt.row {
+			t.Errorf("rangeOrigin(%q) = %d, %d; want %d, %d", tt.a1, col, row, tt.col, tt.row)
+		}
+	}
+}
+
+func TestValueTable(t *testing.T) {
+	vr := SheetValueRange{
+		Range:          "Sheet1!B2:D4",
+		MajorDimension: "ROWS",
+		Values: [][]any{
+			{"Name", "Qty"},
+			{"Widget | large", float64(2), true},
+			{strings.Repeat("x", 50)},
+		},
+	}
+	want := []string{
+		"|  | B | C | D |",
+		"| --- | --- | --- | --- |",
+		"| 2 | Name | Qty |  |",
+		`| 3 | Widget \| large | 2 | true |`,
+		"| 4 | " + strings.Repeat("x", 37) + "... |  |  |",
+	}
+	if got := valueTable(vr); !slices.Equal(got, want) {
+		t.Errorf("valueTable(rows) =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	vr = SheetValueRange{Range: "Sheet1!B2:C3", MajorDimension: "COLUMNS", Values: [][]any{{"a", "b"}, {"c", "d"}}}
+	want = []string{
+		"|  | 2 | 3 |",
+		"| --- | --- | --- |",
+		"| B | a | b |",
+		"| C | c | d |",
+	}
+	if got := valueTable(vr); !slices.Equal(got, want) {
+		t.Errorf("valueTable(columns) =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestParseReadOptions(t *testing.T) {
+	render, dimension, err := parseReadOptions("", "")
+	if err != nil || render != "FORMATTED_VALUE" || dimension != "ROWS" {
+		t.Errorf("parseReadOptions defaults = %q, %q, %v", render, dimension, err)
+	}
+	render, dimension, err = parseReadOptions("formula", "columns")
+	if err != nil || render != "FORMULA" || dimension != "COLUMNS" {
+		t.Errorf("parseReadOptions(formula, columns) = %q, %q, %v", render, dimension, err)
+	}
+	if _, _, err := parseReadOptions("RAW", ""); err == nil {
+		t.Error("parseReadOptions(RAW) succeeded, want error")
+	}
+	if _, _, err := parseReadOptions("", "DIAGONAL"); err == nil {
+		t.Error("parseReadOptions(DIAGONAL) succeeded, want error")
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	slidespb "google.golang.org/api/slides/v1"
 
 	"github.com/evert/google-workspace-mcp-go/internal/middleware"
+	"github.com/evert/google-workspace-mcp-go/internal/pkg/media"
 	"github.com/evert/google-workspace-mcp-go/internal/pkg/response"
 	"github.com/evert/google-workspace-mcp-go/internal/services"
 )
@@ -35,6 +37,7 @@ func createCreatePresentationHandler(factory *services.Factory) mcp.ToolHandlerF
 		if err != nil {
 			return nil, nil, middleware.HandleGoogleAPIError(err)
 		}
+		factory.StampDriveFile(ctx, input.UserEmail, created.PresentationId, factory.Provenance(req))
 
 		rb := response.New()
 		rb.Header("Presentation Created")
@@ -268,8 +271,27 @@ func createGetPageThumbnailHandler(factory *services.Factory) mcp.ToolHandlerFor
 		rb.KeyValue("Size", fmt.Sprintf("%dx%d", thumbnail.Width, thumbnail.Height))
 		rb.KeyValue("URL", thumbnail.ContentUrl)
 
-		return rb.TextResult(), output, nil
+		image, err := thumbnailImage(ctx, factory, input.UserEmail, thumbnail.ContentUrl)
+		if err != nil {
+			slog.Debug("thumbnail not inlined", "page", input.PageObjectID, "error", err)
+			return rb.TextResult(), output, nil
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: rb.Build()}, image}}, output, nil
+	}
+}
+
+// thumbnailImage downloads a rendered thumbnail so multimodal clients see
+// the slide itself rather than a short-lived URL.
+func thumbnailImage(ctx context.Context, factory *services.Factory, userEmail, url string) (*mcp.ImageContent, error) {
+	client, err := factory.HTTPClient(ctx, userEmail, "slides")
+	if err != nil {
+		return nil, err
+	}
+	data, mimeType, err := media.FetchImage(ctx, client, url)
+	if err != nil {
+		return nil, err
 	}
+	return &mcp.ImageContent{Data: data, MIMEType: mimeType}, nil
 }
 
 // --- Helper functions ---
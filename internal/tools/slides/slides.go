@@ -65,7 +65,7 @@ func Register(server *mcp.Server, factory *services.Factory) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_page_thumbnail",
 		Icons:       serviceIcons,
-		Description: "Get a thumbnail image URL for a specific slide in a Google Slides presentation.",
+		Description: "Get a thumbnail of a specific slide in a Google Slides presentation. Returns the rendered slide as inline image content for vision-capable models, plus its URL and size.",
 		Annotations: &mcp.ToolAnnotations{
 			Title:         "Get Slide Thumbnail",
 			ReadOnlyHint:  true,
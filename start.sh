@@ -14,7 +14,8 @@
 #   --port PORT           HTTP port (default: 8000)
 #   --services SVCS       Comma-separated services to enable (default: all)
 #                         Options: gmail,drive,calendar,docs,sheets,chat,
-#                                  forms,slides,tasks,contacts,search,appscript
+#                                  forms,slides,tasks,keep,classroom,meet,
+#                                  contacts,search,appscript
 #   --persistent-auth     Persist OAuth tokens to disk (Docker volume)
 #   --email EMAIL         Default user email for authentication
 #   --cse-id ID           Google Custom Search Engine ID (for search tools)
@@ -196,7 +197,7 @@ echo -e "  Endpoint:    ${CYAN}http://localhost:${PORT}/mcp${NC}"
 if [[ -n "$SERVICES" ]]; then
 echo -e "  Services:    ${CYAN}${SERVICES}${NC}"
 else
-echo -e "  Services:    all (gmail,drive,calendar,docs,sheets,chat,forms,slides,tasks,contacts,search,appscript)"
+echo -e "  Services:    all (gmail,drive,calendar,docs,sheets,chat,forms,slides,tasks,keep,classroom,meet,contacts,search,appscript)"
 fi
 echo -e "  Log level:   ${LOG_LEVEL}"
 echo -e "  Container:   ${CONTAINER_NAME}"